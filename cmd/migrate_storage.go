@@ -23,6 +23,7 @@ import (
 	"code.gitea.io/gitea/services/versioned_migration"
 
 	"github.com/urfave/cli/v3"
+	"xorm.io/builder"
 )
 
 // CmdMigrateStorage represents the available migrate storage sub-command.
@@ -36,7 +37,7 @@ var CmdMigrateStorage = &cli.Command{
 			Name:    "type",
 			Aliases: []string{"t"},
 			Value:   "",
-			Usage:   "Type of stored files to copy.  Allowed types: 'attachments', 'lfs', 'avatars', 'repo-avatars', 'repo-archivers', 'packages', 'actions-log', 'actions-artifacts'",
+			Usage:   "Type of stored files to copy.  Allowed types: 'attachments', 'lfs', 'avatars', 'repo-avatars', 'repo-archivers', 'packages', 'actions-log', 'actions-artifacts', 'actions-caches'",
 		},
 		&cli.StringFlag{
 			Name:    "storage",
@@ -213,6 +214,21 @@ func migrateActionsArtifacts(ctx context.Context, dstStorage storage.ObjectStora
 	})
 }
 
+func migrateActionsCaches(ctx context.Context, dstStorage storage.ObjectStorage) error {
+	return db.Iterate(ctx, builder.Eq{"complete": true}, func(ctx context.Context, c *actions_model.ActionCache) error {
+		_, err := storage.Copy(dstStorage, c.StoragePath, storage.ActionsCaches, c.StoragePath)
+		if err != nil {
+			// ignore files that do not exist
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		return nil
+	})
+}
+
 func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 	if err := initDB(ctx); err != nil {
 		return err
@@ -294,6 +310,7 @@ func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 		"packages":          migratePackages,
 		"actions-log":       migrateActionsLog,
 		"actions-artifacts": migrateActionsArtifacts,
+		"actions-caches":    migrateActionsCaches,
 	}
 
 	tp := strings.ToLower(cmd.String("type"))
@@ -0,0 +1,71 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// ActionApprovalPolicy decides which runs of fork pull requests can start without the approval of a maintainer.
+// It belongs to an organization (RepoID is 0) or to a repository (OwnerID is 0),
+// the policy of a repository overrides the policy of its organization.
+type ActionApprovalPolicy struct {
+	ID      int64 `xorm:"pk autoincr"`
+	OwnerID int64 `xorm:"UNIQUE(owner_repo) NOT NULL DEFAULT 0"`
+	RepoID  int64 `xorm:"UNIQUE(owner_repo) NOT NULL DEFAULT 0"`
+
+	// TrustMergedPullRequests trusts the users who have had at least this number of pull requests merged into the repository, 0 disables it
+	TrustMergedPullRequests int64 `xorm:"NOT NULL DEFAULT 0"`
+	// TrustedTeamIDs trusts the members of the teams of the organization
+	TrustedTeamIDs []int64 `xorm:"JSON TEXT"`
+	// ApproveWorkflowChanges requires an approval of every run of a pull request which modifies the workflow files, even for trusted users
+	ApproveWorkflowChanges bool `xorm:"NOT NULL DEFAULT false"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionApprovalPolicy))
+}
+
+// GetApprovalPolicy returns the policy of an organization or of a repository,
+// an unsaved policy (ID is 0) is returned if it hasn't been set.
+func GetApprovalPolicy(ctx context.Context, ownerID, repoID int64) (*ActionApprovalPolicy, error) {
+	p := &ActionApprovalPolicy{OwnerID: ownerID, RepoID: repoID}
+	if _, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID, "repo_id": repoID}).Get(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetEffectiveApprovalPolicy returns the policy of the repository if it has been set, or the policy of its owner
+func GetEffectiveApprovalPolicy(ctx context.Context, repo *repo_model.Repository) (*ActionApprovalPolicy, error) {
+	p, err := GetApprovalPolicy(ctx, 0, repo.ID)
+	if err != nil || p.ID > 0 {
+		return p, err
+	}
+	return GetApprovalPolicy(ctx, repo.OwnerID, 0)
+}
+
+// SaveApprovalPolicy inserts or updates a policy
+func SaveApprovalPolicy(ctx context.Context, p *ActionApprovalPolicy) error {
+	if p.ID == 0 {
+		return db.Insert(ctx, p)
+	}
+	_, err := db.GetEngine(ctx).ID(p.ID).Cols("trust_merged_pull_requests", "trusted_team_i_ds", "approve_workflow_changes").Update(p)
+	return err
+}
+
+// DeleteApprovalPolicy deletes the policy of an organization or of a repository
+func DeleteApprovalPolicy(ctx context.Context, ownerID, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID, "repo_id": repoID}).Delete(new(ActionApprovalPolicy))
+	return err
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEffectiveApprovalPolicy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	p, err := GetEffectiveApprovalPolicy(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Zero(t, p.ID)
+
+	orgPolicy := &ActionApprovalPolicy{OwnerID: repo.OwnerID, TrustMergedPullRequests: 3, TrustedTeamIDs: []int64{2}}
+	require.NoError(t, SaveApprovalPolicy(t.Context(), orgPolicy))
+	p, err = GetEffectiveApprovalPolicy(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, orgPolicy.ID, p.ID)
+	assert.Equal(t, []int64{2}, p.TrustedTeamIDs)
+
+	// the policy of the repository overrides the policy of the organization
+	repoPolicy := &ActionApprovalPolicy{RepoID: repo.ID, ApproveWorkflowChanges: true}
+	require.NoError(t, SaveApprovalPolicy(t.Context(), repoPolicy))
+	p, err = GetEffectiveApprovalPolicy(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, repoPolicy.ID, p.ID)
+	assert.Zero(t, p.TrustMergedPullRequests)
+
+	repoPolicy.TrustMergedPullRequests = 1
+	require.NoError(t, SaveApprovalPolicy(t.Context(), repoPolicy))
+	p, err = GetApprovalPolicy(t.Context(), 0, repo.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, p.TrustMergedPullRequests)
+
+	require.NoError(t, DeleteApprovalPolicy(t.Context(), 0, repo.ID))
+	p, err = GetEffectiveApprovalPolicy(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, orgPolicy.ID, p.ID)
+}
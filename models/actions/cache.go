@@ -0,0 +1,209 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// CacheKeyMaxLength is the max length of the key of a cache in Github Actions
+const CacheKeyMaxLength = 512
+
+// ActionCache is an entry of the built-in cache server of actions/cache.
+// It's saved by a run of a git ref, and can be restored by the runs of the same ref or of the default branch.
+type ActionCache struct {
+	ID      int64                  `xorm:"pk autoincr"`
+	RepoID  int64                  `xorm:"INDEX NOT NULL"`
+	Repo    *repo_model.Repository `xorm:"-"`
+	Ref     string                 `xorm:"NOT NULL"`
+	Key     string                 `xorm:"'cache_key' VARCHAR(512) NOT NULL"`
+	Version string                 `xorm:"NOT NULL"`
+	RunID   int64                  // the run which saved the cache
+
+	// Size is the size of the archive in bytes, it's the size announced by the client until the upload is complete, 0 if it's unknown
+	Size        int64
+	StoragePath string
+	Complete    bool `xorm:"INDEX NOT NULL DEFAULT false"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	// UsedUnix is the last time the cache has been saved or restored, the least recently used caches are evicted first
+	UsedUnix timeutil.TimeStamp `xorm:"INDEX"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionCache))
+}
+
+func (c *ActionCache) LoadRepo(ctx context.Context) error {
+	if c.Repo != nil {
+		return nil
+	}
+	repo, err := repo_model.GetRepositoryByID(ctx, c.RepoID)
+	if err != nil {
+		return err
+	}
+	c.Repo = repo
+	return nil
+}
+
+type CacheList []*ActionCache
+
+// LoadRepos loads the repositories of the caches
+func (caches CacheList) LoadRepos(ctx context.Context) error {
+	repoIDs := make(container.Set[int64])
+	for _, c := range caches {
+		repoIDs.Add(c.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDs.Values())
+	if err != nil {
+		return err
+	}
+	for _, c := range caches {
+		c.Repo = repos[c.RepoID]
+	}
+	return nil
+}
+
+type FindCachesOptions struct {
+	db.ListOptions
+	RepoID     int64
+	Ref        string
+	Key        string // exact key
+	Version    string
+	Complete   optional.Option[bool]
+	UsedBefore timeutil.TimeStamp
+}
+
+func (opts FindCachesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.Ref != "" {
+		cond = cond.And(builder.Eq{"ref": opts.Ref})
+	}
+	if opts.Key != "" {
+		cond = cond.And(builder.Eq{"cache_key": opts.Key})
+	}
+	if opts.Version != "" {
+		cond = cond.And(builder.Eq{"version": opts.Version})
+	}
+	if opts.Complete.Has() {
+		cond = cond.And(builder.Eq{"complete": opts.Complete.Value()})
+	}
+	if opts.UsedBefore > 0 {
+		cond = cond.And(builder.Lt{"used_unix": opts.UsedBefore})
+	}
+	return cond
+}
+
+func (opts FindCachesOptions) ToOrders() string {
+	return "used_unix DESC, id DESC"
+}
+
+// GetCacheByID returns a cache of the repository, or of any repository if repoID is 0
+func GetCacheByID(ctx context.Context, repoID, id int64) (*ActionCache, error) {
+	cond := builder.Eq{"id": id}
+	if repoID > 0 {
+		cond["repo_id"] = repoID
+	}
+	c := new(ActionCache)
+	has, err := db.GetEngine(ctx).Where(cond).Get(c)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("cache %d does not exist", id)
+	}
+	return c, nil
+}
+
+// FindCache looks for a complete cache of the version like actions/cache:
+// the refs are searched in order, an exact match of a key is preferred to a prefix match,
+// and the keys are tried in order. The most recent cache wins if several match.
+func FindCache(ctx context.Context, repoID int64, refs, keys []string, version string) (*ActionCache, error) {
+	for _, ref := range refs {
+		for _, key := range keys {
+			for _, prefix := range []bool{false, true} {
+				cond := builder.Eq{"repo_id": repoID, "ref": ref, "version": version, "complete": true}.And(keyCond(key, prefix))
+				c := new(ActionCache)
+				has, err := db.GetEngine(ctx).Where(cond).OrderBy("created_unix DESC, id DESC").Get(c)
+				if err != nil {
+					return nil, err
+				} else if has {
+					return c, nil
+				}
+			}
+		}
+	}
+	return nil, util.NewNotExistErrorf("cache does not exist")
+}
+
+func keyCond(key string, prefix bool) builder.Cond {
+	if !prefix {
+		return builder.Eq{"cache_key": key}
+	}
+	escaped := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(key)
+	return builder.Expr("cache_key LIKE ? ESCAPE '!'", escaped+"%")
+}
+
+// ReserveCache creates an incomplete cache, the key and the version can only be reserved once in a ref
+func ReserveCache(ctx context.Context, c *ActionCache) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": c.RepoID, "ref": c.Ref, "cache_key": c.Key, "version": c.Version}).Exist(new(ActionCache))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("cache %q of version %q already exists", c.Key, c.Version)
+		}
+		c.UsedUnix = timeutil.TimeStampNow()
+		return db.Insert(ctx, c)
+	})
+}
+
+// UpdateCacheCols updates the columns of a cache
+func UpdateCacheCols(ctx context.Context, c *ActionCache, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(c.ID).Cols(cols...).Update(c)
+	return err
+}
+
+// GetCachesSize returns the total size of the complete caches of a repository, or of all repositories if repoID is 0
+func GetCachesSize(ctx context.Context, repoID int64) (int64, error) {
+	cond := builder.Eq{"complete": true}
+	if repoID > 0 {
+		cond["repo_id"] = repoID
+	}
+	return db.GetEngine(ctx).Where(cond).SumInt(new(ActionCache), "size")
+}
+
+// GetReservedCachesSize returns the total size announced for the caches of a repository which are being uploaded,
+// the cache excludeID isn't counted
+func GetReservedCachesSize(ctx context.Context, repoID, excludeID int64) (int64, error) {
+	cond := builder.Eq{"repo_id": repoID, "complete": false}.And(builder.Neq{"id": excludeID})
+	return db.GetEngine(ctx).Where(cond).SumInt(new(ActionCache), "size")
+}
+
+// GetReposExceedingCacheSize returns the ids of the repositories whose complete caches are larger than the size
+func GetReposExceedingCacheSize(ctx context.Context, size int64) ([]int64, error) {
+	repoIDs := make([]int64, 0, 10)
+	return repoIDs, db.GetEngine(ctx).Table("action_cache").Where(builder.Eq{"complete": true}).
+		GroupBy("repo_id").Having(fmt.Sprintf("SUM(size) > %d", size)).Cols("repo_id").Find(&repoIDs)
+}
+
+// DeleteCacheByID deletes the record of a cache, the caller has to delete its files
+func DeleteCacheByID(ctx context.Context, id int64) error {
+	_, err := db.DeleteByID[ActionCache](ctx, id)
+	return err
+}
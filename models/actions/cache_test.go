@@ -0,0 +1,77 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCache(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	insert := func(ref, key, version string, complete bool, size int64) *ActionCache {
+		c := &ActionCache{RepoID: 1, Ref: ref, Key: key, Version: version, Size: size}
+		require.NoError(t, ReserveCache(t.Context(), c))
+		c.Complete = complete
+		require.NoError(t, UpdateCacheCols(t.Context(), c, "complete"))
+		return c
+	}
+	mainGo := insert("refs/heads/master", "go-linux-abc", "v1", true, 10)
+	mainGoOld := insert("refs/heads/master", "go-linux-old", "v1", true, 20)
+	featureGo := insert("refs/heads/feature", "go-linux-def", "v1", true, 30)
+	insert("refs/heads/feature", "go-linux-abc", "v1", false, 40)
+	insert("refs/heads/master", "go_linux", "v2", true, 50)
+
+	err := ReserveCache(t.Context(), &ActionCache{RepoID: 1, Ref: "refs/heads/master", Key: "go-linux-abc", Version: "v1"})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+
+	refs := []string{"refs/heads/feature", "refs/heads/master"}
+	cases := []struct {
+		keys    []string
+		version string
+		id      int64
+	}{
+		// the incomplete cache of the feature branch is ignored
+		{keys: []string{"go-linux-abc"}, version: "v1", id: mainGo.ID},
+		// the ref of the run is preferred to the default branch
+		{keys: []string{"go-linux-abc", "go-linux-"}, version: "v1", id: featureGo.ID},
+		{keys: []string{"go-linux-old"}, version: "v1", id: mainGoOld.ID},
+		// the version must match
+		{keys: []string{"go-linux-abc"}, version: "v2", id: 0},
+		// "_" and "%" aren't wildcards in the prefixes
+		{keys: []string{"go%linux"}, version: "v2", id: 0},
+		{keys: []string{"go-linux"}, version: "v2", id: 0},
+	}
+	for _, c := range cases {
+		found, err := FindCache(t.Context(), 1, refs, c.keys, c.version)
+		if c.id == 0 {
+			assert.ErrorIs(t, err, util.ErrNotExist, "keys: %v", c.keys)
+			continue
+		}
+		require.NoError(t, err, "keys: %v", c.keys)
+		assert.Equal(t, c.id, found.ID, "keys: %v", c.keys)
+	}
+
+	size, err := GetCachesSize(t.Context(), 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 110, size)
+
+	repoIDs, err := GetReposExceedingCacheSize(t.Context(), 100)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, repoIDs)
+	repoIDs, err = GetReposExceedingCacheSize(t.Context(), 110)
+	require.NoError(t, err)
+	assert.Empty(t, repoIDs)
+
+	caches, err := db.Find[ActionCache](t.Context(), FindCachesOptions{RepoID: 1, Ref: "refs/heads/master"})
+	require.NoError(t, err)
+	assert.Len(t, caches, 3)
+}
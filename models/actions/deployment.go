@@ -0,0 +1,184 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// DeploymentState is the state of the protection rules of a deployment
+type DeploymentState string
+
+const (
+	DeploymentStatePending    DeploymentState = "pending"     // waiting for a review
+	DeploymentStateApproved   DeploymentState = "approved"    // approved by a reviewer, or the environment has no required reviewers
+	DeploymentStateRejected   DeploymentState = "rejected"    // rejected by a reviewer, the job has failed
+	DeploymentStateNotAllowed DeploymentState = "not_allowed" // the ref doesn't match the branch filters of the environment, the job has failed
+)
+
+// ActionDeployment is a job which targets an environment, it's created when the job is ready to start.
+// The job keeps StatusPendingDeployment until the deployment is approved and WaitUntil has passed,
+// a rerun of the job creates a new deployment.
+type ActionDeployment struct {
+	ID            int64              `xorm:"pk autoincr"`
+	RepoID        int64              `xorm:"INDEX NOT NULL"`
+	EnvironmentID int64              `xorm:"INDEX NOT NULL"`
+	Environment   *ActionEnvironment `xorm:"-"`
+	RunID         int64              `xorm:"INDEX NOT NULL"`
+	Run           *ActionRun         `xorm:"-"`
+	RunJobID      int64              `xorm:"INDEX NOT NULL"`
+	Job           *ActionRunJob      `xorm:"-"`
+	Ref           string
+	CommitSHA     string
+	TriggerUserID int64
+	TriggerUser   *user_model.User `xorm:"-"`
+
+	State         DeploymentState `xorm:"VARCHAR(20) INDEX NOT NULL"`
+	ReviewerID    int64
+	Reviewer      *user_model.User `xorm:"-"`
+	ReviewComment string           `xorm:"TEXT"`
+	ReviewedUnix  timeutil.TimeStamp
+	// WaitUntil is the end of the wait timer of the environment, which starts when the deployment is created
+	WaitUntil timeutil.TimeStamp
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionDeployment))
+}
+
+// IsWaitingForReview returns whether a required reviewer has to approve or reject the deployment
+func (d *ActionDeployment) IsWaitingForReview() bool {
+	return d.State == DeploymentStatePending
+}
+
+type FindDeploymentsOptions struct {
+	db.ListOptions
+	RepoID        int64
+	EnvironmentID int64
+	RunID         int64
+	RunJobID      int64
+	States        []DeploymentState
+}
+
+func (opts FindDeploymentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.EnvironmentID > 0 {
+		cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
+	}
+	if opts.RunID > 0 {
+		cond = cond.And(builder.Eq{"run_id": opts.RunID})
+	}
+	if opts.RunJobID > 0 {
+		cond = cond.And(builder.Eq{"run_job_id": opts.RunJobID})
+	}
+	if len(opts.States) > 0 {
+		cond = cond.And(builder.In("state", opts.States))
+	}
+	return cond
+}
+
+func (opts FindDeploymentsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// GetDeploymentByID returns a deployment of the repository
+func GetDeploymentByID(ctx context.Context, repoID, id int64) (*ActionDeployment, error) {
+	d := new(ActionDeployment)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "repo_id": repoID}).Get(d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("deployment %d does not exist", id)
+	}
+	return d, nil
+}
+
+// GetLatestDeploymentOfJob returns the deployment created by the latest attempt of the job
+func GetLatestDeploymentOfJob(ctx context.Context, jobID int64) (*ActionDeployment, error) {
+	d := new(ActionDeployment)
+	has, err := db.GetEngine(ctx).Where("run_job_id=?", jobID).OrderBy("id DESC").Get(d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("job %d has no deployment", jobID)
+	}
+	return d, nil
+}
+
+// UpdateDeployment updates the review of a deployment if it still has the expected state
+func UpdateDeployment(ctx context.Context, d *ActionDeployment, expectedState DeploymentState) (bool, error) {
+	n, err := db.GetEngine(ctx).ID(d.ID).Where(builder.Eq{"state": expectedState}).
+		Cols("state", "reviewer_id", "review_comment", "reviewed_unix", "wait_until").
+		Update(d)
+	return n > 0, err
+}
+
+type DeploymentList []*ActionDeployment
+
+// LoadAttributes loads the environments, the runs, the jobs, the trigger users and the reviewers of the deployments
+func (deployments DeploymentList) LoadAttributes(ctx context.Context) error {
+	envIDs := make(container.Set[int64])
+	runIDs := make(container.Set[int64])
+	jobIDs := make(container.Set[int64])
+	userIDs := make(container.Set[int64])
+	for _, d := range deployments {
+		envIDs.Add(d.EnvironmentID)
+		runIDs.Add(d.RunID)
+		jobIDs.Add(d.RunJobID)
+		userIDs.Add(d.TriggerUserID)
+		if d.ReviewerID > 0 {
+			userIDs.Add(d.ReviewerID)
+		}
+	}
+
+	envs := make(map[int64]*ActionEnvironment, len(envIDs))
+	if err := db.GetEngine(ctx).In("id", envIDs.Values()).Find(&envs); err != nil {
+		return err
+	}
+	runs := make(map[int64]*ActionRun, len(runIDs))
+	if err := db.GetEngine(ctx).In("id", runIDs.Values()).Find(&runs); err != nil {
+		return err
+	}
+	jobs := make(map[int64]*ActionRunJob, len(jobIDs))
+	if err := db.GetEngine(ctx).In("id", jobIDs.Values()).Find(&jobs); err != nil {
+		return err
+	}
+	users, err := user_model.GetUsersMapByIDs(ctx, userIDs.Values())
+	if err != nil {
+		return err
+	}
+
+	for _, d := range deployments {
+		d.Environment = envs[d.EnvironmentID]
+		d.Run = runs[d.RunID]
+		d.Job = jobs[d.RunJobID]
+		d.TriggerUser = users[d.TriggerUserID]
+		if d.TriggerUserID == user_model.ActionsUserID {
+			d.TriggerUser = user_model.NewActionsUser()
+		} else if d.TriggerUser == nil {
+			d.TriggerUser = user_model.NewGhostUser()
+		}
+		if d.ReviewerID > 0 {
+			d.Reviewer = users[d.ReviewerID]
+			if d.Reviewer == nil {
+				d.Reviewer = user_model.NewGhostUser()
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+const (
+	EnvironmentNameMaxLength = 255
+	// EnvironmentWaitTimerMax is the max wait timer of an environment in minutes (30 days)
+	EnvironmentWaitTimerMax = 43200
+)
+
+// ActionEnvironment is a deployment environment of a repository, a job targets it with `jobs.<job_id>.environment`.
+// The jobs targeting a protected environment are held in StatusPendingDeployment
+// until one of the required reviewers approves them and the wait timer has passed.
+// It can also have its own secrets and variables, which take precedence over the repo level ones.
+type ActionEnvironment struct {
+	ID        int64                  `xorm:"pk autoincr"`
+	RepoID    int64                  `xorm:"UNIQUE(repo_name) NOT NULL"`
+	Repo      *repo_model.Repository `xorm:"-"`
+	Name      string                 `xorm:"NOT NULL"`
+	LowerName string                 `xorm:"UNIQUE(repo_name) NOT NULL"`
+
+	// RequiredReviewerIDs are the users who can approve the deployments, one approval is enough
+	RequiredReviewerIDs []int64 `xorm:"JSON TEXT"`
+	// WaitTimer is the delay in minutes between the approval (or the trigger without reviewers) and the start of the job
+	WaitTimer int64 `xorm:"NOT NULL DEFAULT 0"`
+	// BranchFilters are glob patterns of the branches or tags which can deploy to the environment, all of them can if it's empty
+	BranchFilters []string `xorm:"JSON TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionEnvironment))
+}
+
+func (env *ActionEnvironment) LoadRepo(ctx context.Context) error {
+	if env.Repo != nil {
+		return nil
+	}
+	repo, err := repo_model.GetRepositoryByID(ctx, env.RepoID)
+	if err != nil {
+		return err
+	}
+	env.Repo = repo
+	return nil
+}
+
+// IsProtected returns whether the deployments to the environment have to wait for a review or a timer
+func (env *ActionEnvironment) IsProtected() bool {
+	return len(env.RequiredReviewerIDs) > 0 || env.WaitTimer > 0
+}
+
+// IsRequiredReviewer returns whether the user can review the deployments to the environment
+func (env *ActionEnvironment) IsRequiredReviewer(userID int64) bool {
+	return slices.Contains(env.RequiredReviewerIDs, userID)
+}
+
+// CanDeployRef returns whether a run of the git ref can deploy to the environment
+func (env *ActionEnvironment) CanDeployRef(ref string) bool {
+	if len(env.BranchFilters) == 0 {
+		return true
+	}
+	name := git.RefName(ref).ShortName()
+	for _, filter := range env.BranchFilters {
+		g, err := glob.Compile(filter, '/')
+		if err != nil {
+			log.Warn("Invalid branch filter %q of environment %d: %v", filter, env.ID, err)
+			continue
+		}
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (env *ActionEnvironment) normalize() error {
+	env.Name = strings.TrimSpace(env.Name)
+	if env.Name == "" || len(env.Name) > EnvironmentNameMaxLength {
+		return util.NewInvalidArgumentErrorf("invalid environment name %q", env.Name)
+	}
+	env.LowerName = strings.ToLower(env.Name)
+	if env.WaitTimer < 0 || env.WaitTimer > EnvironmentWaitTimerMax {
+		return util.NewInvalidArgumentErrorf("wait timer must be between 0 and %d minutes", EnvironmentWaitTimerMax)
+	}
+
+	slices.Sort(env.RequiredReviewerIDs)
+	env.RequiredReviewerIDs = slices.Compact(env.RequiredReviewerIDs)
+
+	filters := make([]string, 0, len(env.BranchFilters))
+	for _, filter := range env.BranchFilters {
+		if filter = strings.TrimSpace(filter); filter == "" || slices.Contains(filters, filter) {
+			continue
+		}
+		if _, err := glob.Compile(filter, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid branch filter %q: %v", filter, err)
+		}
+		filters = append(filters, filter)
+	}
+	env.BranchFilters = filters
+	return nil
+}
+
+type FindEnvironmentsOptions struct {
+	db.ListOptions
+	RepoID int64
+	IDs    []int64
+}
+
+func (opts FindEnvironmentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if len(opts.IDs) > 0 {
+		cond = cond.And(builder.In("id", opts.IDs))
+	}
+	return cond
+}
+
+func (opts FindEnvironmentsOptions) ToOrders() string {
+	return "lower_name"
+}
+
+// GetEnvironmentByID returns an environment of the repository
+func GetEnvironmentByID(ctx context.Context, repoID, id int64) (*ActionEnvironment, error) {
+	env := new(ActionEnvironment)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "repo_id": repoID}).Get(env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("environment %d does not exist", id)
+	}
+	return env, nil
+}
+
+// GetEnvironmentByName returns an environment of the repository, the name is case-insensitive
+func GetEnvironmentByName(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	env := new(ActionEnvironment)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID, "lower_name": strings.ToLower(strings.TrimSpace(name))}).Get(env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("environment %q does not exist", name)
+	}
+	return env, nil
+}
+
+// CreateEnvironment creates an environment in the repository
+func CreateEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	if err := env.normalize(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": env.RepoID, "lower_name": env.LowerName}).Exist(new(ActionEnvironment))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("environment %q already exists", env.Name)
+		}
+		return db.Insert(ctx, env)
+	})
+}
+
+// UpdateEnvironment updates the name and the protection rules of an environment
+func UpdateEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	if err := env.normalize(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": env.RepoID, "lower_name": env.LowerName}).And(builder.Neq{"id": env.ID}).Exist(new(ActionEnvironment))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("environment %q already exists", env.Name)
+		}
+		_, err = db.GetEngine(ctx).ID(env.ID).Cols("name", "lower_name", "required_reviewer_i_ds", "wait_timer", "branch_filters").Update(env)
+		return err
+	})
+}
+
+// GetOrCreateEnvironment returns the environment with the name, it's created without protection rules if it doesn't exist,
+// like the environments referenced by workflows for the first time
+func GetOrCreateEnvironment(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) (*ActionEnvironment, error) {
+		env, err := GetEnvironmentByName(ctx, repoID, name)
+		if err == nil || !errors.Is(err, util.ErrNotExist) {
+			return env, err
+		}
+		env = &ActionEnvironment{RepoID: repoID, Name: name}
+		return env, CreateEnvironment(ctx, env)
+	})
+}
+
+// DeleteEnvironment deletes an environment with its variables and its deployments history,
+// the caller has to delete its secrets
+func DeleteEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"environment_id": env.ID}).Delete(new(ActionVariable)); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"environment_id": env.ID}).Delete(new(ActionDeployment)); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[ActionEnvironment](ctx, env.ID)
+		return err
+	})
+}
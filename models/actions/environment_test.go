@@ -0,0 +1,78 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironmentCanDeployRef(t *testing.T) {
+	env := &ActionEnvironment{}
+	assert.True(t, env.CanDeployRef("refs/heads/feature"))
+
+	env.BranchFilters = []string{"main", "release/*", "v*"}
+	assert.True(t, env.CanDeployRef("refs/heads/main"))
+	assert.True(t, env.CanDeployRef("refs/heads/release/1.0"))
+	assert.True(t, env.CanDeployRef("refs/tags/v1.0.0"))
+	assert.False(t, env.CanDeployRef("refs/heads/release/1.0/fix"))
+	assert.False(t, env.CanDeployRef("refs/heads/feature"))
+}
+
+func TestCreateEnvironment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	env := &ActionEnvironment{
+		RepoID:              4,
+		Name:                " Production ",
+		RequiredReviewerIDs: []int64{2, 1, 2},
+		BranchFilters:       []string{"main", "", "main", " release/* "},
+	}
+	require.NoError(t, CreateEnvironment(t.Context(), env))
+	assert.Equal(t, "Production", env.Name)
+	assert.Equal(t, []int64{1, 2}, env.RequiredReviewerIDs)
+	assert.Equal(t, []string{"main", "release/*"}, env.BranchFilters)
+	assert.True(t, env.IsProtected())
+
+	err := CreateEnvironment(t.Context(), &ActionEnvironment{RepoID: 4, Name: "production"})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	err = CreateEnvironment(t.Context(), &ActionEnvironment{RepoID: 4, Name: "staging", WaitTimer: EnvironmentWaitTimerMax + 1})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	got, err := GetOrCreateEnvironment(t.Context(), 4, "PRODUCTION")
+	require.NoError(t, err)
+	assert.Equal(t, env.ID, got.ID)
+	got, err = GetOrCreateEnvironment(t.Context(), 4, "staging")
+	require.NoError(t, err)
+	assert.NotEqual(t, env.ID, got.ID)
+	assert.False(t, got.IsProtected())
+}
+
+func TestGetVariablesOfJobWithEnvironment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	env, err := GetOrCreateEnvironment(t.Context(), 4, "production")
+	require.NoError(t, err)
+	_, err = InsertVariable(t.Context(), 0, 4, "URL", "https://staging.example.com", "")
+	require.NoError(t, err)
+	_, err = InsertVariable(t.Context(), 0, 4, "NAME", "repo", "")
+	require.NoError(t, err)
+	_, err = InsertEnvironmentVariable(t.Context(), 4, env.ID, "URL", "https://example.com", "")
+	require.NoError(t, err)
+
+	job := &ActionRunJob{RepoID: 4, RunID: 793}
+	got, err := GetVariablesOfJob(t.Context(), job)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"NAME": "repo", "URL": "https://staging.example.com"}, got)
+
+	job.Environment = "Production"
+	got, err = GetVariablesOfJob(t.Context(), job)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"NAME": "repo", "URL": "https://example.com"}, got)
+}
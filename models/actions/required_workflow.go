@@ -0,0 +1,161 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionRequiredWorkflow is a workflow of a repository of an organization which runs on the pull requests of the other repositories
+// of the organization, like they have a copy of it, and whose jobs are required status checks of their protected branches.
+// It's read from the default branch of the repository, so only the organization owners and the writers of the repository could change it.
+type ActionRequiredWorkflow struct {
+	ID         int64                  `xorm:"pk autoincr"`
+	OwnerID    int64                  `xorm:"UNIQUE(owner_repo_workflow) NOT NULL"`
+	RepoID     int64                  `xorm:"UNIQUE(owner_repo_workflow) NOT NULL"`
+	Repo       *repo_model.Repository `xorm:"-"`
+	WorkflowID string                 `xorm:"UNIQUE(owner_repo_workflow) NOT NULL"` // the file name of the workflow, like "security-scan.yml"
+
+	// AllRepos indicates the workflow runs in all the repositories of the organization, or only in the ones of RepoIDs
+	AllRepos bool    `xorm:"NOT NULL DEFAULT false"`
+	RepoIDs  []int64 `xorm:"JSON TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionRequiredWorkflow))
+}
+
+func (w *ActionRequiredWorkflow) LoadRepo(ctx context.Context) error {
+	if w.Repo != nil {
+		return nil
+	}
+	repo, err := repo_model.GetRepositoryByID(ctx, w.RepoID)
+	if err != nil {
+		return err
+	}
+	w.Repo = repo
+	return nil
+}
+
+// AppliesToRepo returns whether the workflow is required in the repository,
+// it's never required in its own repository, which runs it as a normal workflow
+func (w *ActionRequiredWorkflow) AppliesToRepo(repo *repo_model.Repository) bool {
+	if repo.OwnerID != w.OwnerID || repo.ID == w.RepoID {
+		return false
+	}
+	return w.AllRepos || slices.Contains(w.RepoIDs, repo.ID)
+}
+
+func (w *ActionRequiredWorkflow) normalize(ctx context.Context) error {
+	w.WorkflowID = strings.TrimSpace(w.WorkflowID)
+	if w.WorkflowID == "" || strings.Contains(w.WorkflowID, "/") {
+		return util.NewInvalidArgumentErrorf("invalid workflow %q, it should be a file name like \"security-scan.yml\"", w.WorkflowID)
+	}
+	if w.AllRepos {
+		w.RepoIDs = nil
+	}
+	slices.Sort(w.RepoIDs)
+	w.RepoIDs = slices.Compact(w.RepoIDs)
+	for _, repoID := range append([]int64{w.RepoID}, w.RepoIDs...) {
+		repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return util.NewInvalidArgumentErrorf("repository %d does not exist", repoID)
+			}
+			return err
+		}
+		if repo.OwnerID != w.OwnerID {
+			return util.NewInvalidArgumentErrorf("repository %d does not belong to the organization", repoID)
+		}
+	}
+	return nil
+}
+
+type FindRequiredWorkflowsOptions struct {
+	db.ListOptions
+	OwnerID int64
+}
+
+func (opts FindRequiredWorkflowsOptions) ToConds() builder.Cond {
+	return builder.Eq{"owner_id": opts.OwnerID}
+}
+
+func (opts FindRequiredWorkflowsOptions) ToOrders() string {
+	return "id"
+}
+
+// GetRequiredWorkflowByID returns a required workflow of the organization
+func GetRequiredWorkflowByID(ctx context.Context, ownerID, id int64) (*ActionRequiredWorkflow, error) {
+	w := new(ActionRequiredWorkflow)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "owner_id": ownerID}).Get(w)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("required workflow %d does not exist", id)
+	}
+	return w, nil
+}
+
+// GetRequiredWorkflowsOfRepo returns the required workflows of the organization which apply to the repository
+func GetRequiredWorkflowsOfRepo(ctx context.Context, repo *repo_model.Repository) ([]*ActionRequiredWorkflow, error) {
+	workflows, err := db.Find[ActionRequiredWorkflow](ctx, FindRequiredWorkflowsOptions{OwnerID: repo.OwnerID})
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(workflows, func(w *ActionRequiredWorkflow) bool {
+		return !w.AppliesToRepo(repo)
+	}), nil
+}
+
+// GetRequiredWorkflowIDsOfCommit returns the ids of the required workflows which have runs for the commit of the repository
+func GetRequiredWorkflowIDsOfCommit(ctx context.Context, repoID int64, commitSHA string) ([]int64, error) {
+	ids := make([]int64, 0, 2)
+	return ids, db.GetEngine(ctx).Table("action_run").
+		Where(builder.Eq{"repo_id": repoID, "commit_sha": commitSHA}.And(builder.Gt{"required_workflow_id": 0})).
+		Distinct("required_workflow_id").Find(&ids)
+}
+
+// CreateRequiredWorkflow creates a required workflow of the organization
+func CreateRequiredWorkflow(ctx context.Context, w *ActionRequiredWorkflow) error {
+	if err := w.normalize(ctx); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": w.OwnerID, "repo_id": w.RepoID, "workflow_id": w.WorkflowID}).Exist(new(ActionRequiredWorkflow))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("required workflow %q already exists", w.WorkflowID)
+		}
+		return db.Insert(ctx, w)
+	})
+}
+
+// UpdateRequiredWorkflowRepos updates the repositories in which the workflow is required
+func UpdateRequiredWorkflowRepos(ctx context.Context, w *ActionRequiredWorkflow) error {
+	if err := w.normalize(ctx); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(w.ID).Cols("all_repos", "repo_i_ds").Update(w)
+	return err
+}
+
+// DeleteRequiredWorkflow deletes a required workflow, its runs are kept
+func DeleteRequiredWorkflow(ctx context.Context, w *ActionRequiredWorkflow) error {
+	_, err := db.DeleteByID[ActionRequiredWorkflow](ctx, w.ID)
+	return err
+}
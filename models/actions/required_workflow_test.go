@@ -0,0 +1,61 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionRequiredWorkflow_AppliesToRepo(t *testing.T) {
+	w := &ActionRequiredWorkflow{OwnerID: 3, RepoID: 3, RepoIDs: []int64{5}}
+
+	assert.False(t, w.AppliesToRepo(&repo_model.Repository{ID: 3, OwnerID: 3}))
+	assert.True(t, w.AppliesToRepo(&repo_model.Repository{ID: 5, OwnerID: 3}))
+	assert.False(t, w.AppliesToRepo(&repo_model.Repository{ID: 32, OwnerID: 3}))
+	assert.False(t, w.AppliesToRepo(&repo_model.Repository{ID: 1, OwnerID: 2}))
+
+	w.AllRepos = true
+	assert.False(t, w.AppliesToRepo(&repo_model.Repository{ID: 3, OwnerID: 3}))
+	assert.True(t, w.AppliesToRepo(&repo_model.Repository{ID: 32, OwnerID: 3}))
+	assert.False(t, w.AppliesToRepo(&repo_model.Repository{ID: 1, OwnerID: 2}))
+}
+
+func TestCreateRequiredWorkflow(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo 1 belongs to user 2, repos 3 and 5 belong to org 3
+	w := &ActionRequiredWorkflow{OwnerID: 3, RepoID: 3, WorkflowID: " scan.yml ", RepoIDs: []int64{5, 5}}
+	require.NoError(t, CreateRequiredWorkflow(t.Context(), w))
+	assert.Equal(t, "scan.yml", w.WorkflowID)
+	assert.Equal(t, []int64{5}, w.RepoIDs)
+
+	err := CreateRequiredWorkflow(t.Context(), &ActionRequiredWorkflow{OwnerID: 3, RepoID: 3, WorkflowID: "scan.yml"})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	err = CreateRequiredWorkflow(t.Context(), &ActionRequiredWorkflow{OwnerID: 3, RepoID: 3, WorkflowID: ".gitea/workflows/test.yml"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = CreateRequiredWorkflow(t.Context(), &ActionRequiredWorkflow{OwnerID: 3, RepoID: 1, WorkflowID: "test.yml"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = CreateRequiredWorkflow(t.Context(), &ActionRequiredWorkflow{OwnerID: 3, RepoID: 3, WorkflowID: "test.yml", RepoIDs: []int64{1}})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	repo5 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 5})
+	workflows, err := GetRequiredWorkflowsOfRepo(t.Context(), repo5)
+	require.NoError(t, err)
+	if assert.Len(t, workflows, 1) {
+		assert.Equal(t, w.ID, workflows[0].ID)
+	}
+
+	w.RepoIDs = nil
+	require.NoError(t, UpdateRequiredWorkflowRepos(t.Context(), w))
+	workflows, err = GetRequiredWorkflowsOfRepo(t.Context(), repo5)
+	require.NoError(t, err)
+	assert.Empty(t, workflows)
+}
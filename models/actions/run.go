@@ -59,6 +59,8 @@ type ActionRun struct {
 	PreviousDuration time.Duration
 	Created          timeutil.TimeStamp `xorm:"created"`
 	Updated          timeutil.TimeStamp `xorm:"updated"`
+	// RequiredWorkflowID is the id of the required workflow of the organization which the run is created from, 0 for the workflows of the repository
+	RequiredWorkflowID int64 `xorm:"NOT NULL DEFAULT 0"`
 }
 
 func init() {
@@ -229,7 +231,7 @@ func CancelPreviousJobs(ctx context.Context, repoID int64, ref, workflowID strin
 		Ref:          ref,
 		WorkflowID:   workflowID,
 		TriggerEvent: event,
-		Status:       []Status{StatusRunning, StatusWaiting, StatusBlocked},
+		Status:       []Status{StatusRunning, StatusWaiting, StatusBlocked, StatusPendingDeployment},
 	})
 	if err != nil {
 		return nil, err
@@ -431,7 +433,7 @@ func CancelPreviousJobsByRunConcurrency(ctx context.Context, actionRun *ActionRu
 
 	var jobsToCancel []*ActionRunJob
 
-	statusFindOption := []Status{StatusWaiting, StatusBlocked}
+	statusFindOption := []Status{StatusWaiting, StatusBlocked, StatusPendingDeployment}
 	if actionRun.ConcurrencyCancel {
 		statusFindOption = append(statusFindOption, StatusRunning)
 	}
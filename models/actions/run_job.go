@@ -41,6 +41,13 @@ type ActionRunJob struct {
 	TaskID int64    // the latest task of the job
 	Status Status   `xorm:"index"`
 
+	// ParentJobID is the id of the job which calls a reusable workflow, if this job is one of the called workflow's jobs.
+	// The `needs` of a job only refer to the jobs with the same ParentJobID.
+	ParentJobID int64 `xorm:"index NOT NULL DEFAULT 0"`
+	// CallOutputs are the evaluated `on.workflow_call.outputs` of the called workflow,
+	// it is only set for a job calling a reusable workflow, which doesn't have a task itself.
+	CallOutputs map[string]string `xorm:"JSON LONGTEXT"`
+
 	RawConcurrency string // raw concurrency from job YAML's "concurrency" section
 
 	// IsConcurrencyEvaluated is only valid/needed when this job's RawConcurrency is not empty.
@@ -51,6 +58,15 @@ type ActionRunJob struct {
 	ConcurrencyGroup  string `xorm:"index(repo_concurrency) NOT NULL DEFAULT ''"` // evaluated concurrency.group
 	ConcurrencyCancel bool   `xorm:"NOT NULL DEFAULT FALSE"`                      // evaluated concurrency.cancel-in-progress
 
+	RawEnvironment string `xorm:"TEXT"` // raw environment from job YAML's "environment" section, a name or a map with the name
+	// Environment is the evaluated name of the job's environment, the job is a deployment to this environment of the repository.
+	// It's evaluated by the job emitter and the job can't start before the protection rules of the environment are satisfied.
+	Environment string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+
+	// RawMatrix is the raw "strategy.matrix" of a job whose matrix has expressions, like `${{ fromJSON(needs.setup.outputs.matrix) }}`.
+	// Such a job is a placeholder: when its needs are done, the job emitter evaluates the matrix and replaces it with a job for each combination.
+	RawMatrix string `xorm:"TEXT"`
+
 	Started timeutil.TimeStamp
 	Stopped timeutil.TimeStamp
 	Created timeutil.TimeStamp `xorm:"created"`
@@ -202,7 +218,7 @@ func UpdateRunJob(ctx context.Context, job *ActionRunJob, cond builder.Cond, col
 func AggregateJobStatus(jobs []*ActionRunJob) Status {
 	allSuccessOrSkipped := len(jobs) != 0
 	allSkipped := len(jobs) != 0
-	var hasFailure, hasCancelled, hasWaiting, hasRunning, hasBlocked bool
+	var hasFailure, hasCancelled, hasWaiting, hasRunning, hasBlocked, hasPendingDeployment bool
 	for _, job := range jobs {
 		allSuccessOrSkipped = allSuccessOrSkipped && (job.Status == StatusSuccess || job.Status == StatusSkipped)
 		allSkipped = allSkipped && job.Status == StatusSkipped
@@ -211,6 +227,7 @@ func AggregateJobStatus(jobs []*ActionRunJob) Status {
 		hasWaiting = hasWaiting || job.Status == StatusWaiting
 		hasRunning = hasRunning || job.Status == StatusRunning
 		hasBlocked = hasBlocked || job.Status == StatusBlocked
+		hasPendingDeployment = hasPendingDeployment || job.Status == StatusPendingDeployment
 	}
 	switch {
 	case allSkipped:
@@ -223,6 +240,8 @@ func AggregateJobStatus(jobs []*ActionRunJob) Status {
 		return StatusRunning
 	case hasWaiting:
 		return StatusWaiting
+	case hasPendingDeployment:
+		return StatusPendingDeployment
 	case hasFailure:
 		return StatusFailure
 	case hasBlocked:
@@ -243,7 +262,7 @@ func CancelPreviousJobsByJobConcurrency(ctx context.Context, job *ActionRunJob)
 		return nil, nil
 	}
 
-	statusFindOption := []Status{StatusWaiting, StatusBlocked}
+	statusFindOption := []Status{StatusWaiting, StatusBlocked, StatusPendingDeployment}
 	if job.ConcurrencyCancel {
 		statusFindOption = append(statusFindOption, StatusRunning)
 	}
@@ -49,6 +49,8 @@ type ActionRunner struct {
 	Description string                 `xorm:"TEXT"`
 	Base        int                    // 0 native 1 docker 2 virtual machine
 	RepoRange   string                 // glob match which repositories could use this runner
+	GroupID     int64                  `xorm:"index NOT NULL DEFAULT 0"` // the runner group restricting the jobs it runs, 0 means no restriction
+	Group       *ActionRunnerGroup     `xorm:"-"`
 
 	Token     string `xorm:"-"`
 	TokenHash string `xorm:"UNIQUE"` // sha256 of token
@@ -169,6 +171,22 @@ func (r *ActionRunner) LoadAttributes(ctx context.Context) error {
 	return nil
 }
 
+// LoadGroup loads the runner group of the runner if it's in a group
+func (r *ActionRunner) LoadGroup(ctx context.Context) error {
+	if r.GroupID == 0 || r.Group != nil {
+		return nil
+	}
+	g := new(ActionRunnerGroup)
+	has, err := db.GetEngine(ctx).ID(r.GroupID).Get(g)
+	if err != nil {
+		return err
+	} else if !has {
+		return util.NewNotExistErrorf("runner group %d of runner %d does not exist", r.GroupID, r.ID)
+	}
+	r.Group = g
+	return nil
+}
+
 func (r *ActionRunner) GenerateToken() (err error) {
 	r.Token, r.TokenSalt, r.TokenHash, _, err = generateSaltedToken()
 	return err
@@ -196,6 +214,7 @@ type FindRunnerOptions struct {
 	IDs           []int64
 	RepoID        int64
 	OwnerID       int64 // it will be ignored if RepoID is set
+	GroupID       optional.Option[int64]
 	Sort          string
 	Filter        string
 	IsOnline      optional.Option[bool]
@@ -228,6 +247,10 @@ func (opts FindRunnerOptions) ToConds() builder.Cond {
 		cond = cond.And(c)
 	}
 
+	if opts.GroupID.Has() {
+		cond = cond.And(builder.Eq{"group_id": opts.GroupID.Value()})
+	}
+
 	if opts.Filter != "" {
 		cond = cond.And(builder.Like{"name", opts.Filter})
 	}
@@ -0,0 +1,220 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+const RunnerGroupNameMaxLength = 255
+
+// ActionRunnerGroup restricts the jobs which the runners in it could run.
+// An org-level group (OwnerID is the org ID) contains the runners of the org,
+// an instance-level group (OwnerID is 0) contains the global runners.
+// The runners which aren't in any group (GroupID is 0) are available to all the repositories in their scope.
+type ActionRunnerGroup struct {
+	ID        int64  `xorm:"pk autoincr"`
+	OwnerID   int64  `xorm:"UNIQUE(owner_name) NOT NULL DEFAULT 0"`
+	Name      string `xorm:"NOT NULL"`
+	LowerName string `xorm:"UNIQUE(owner_name) NOT NULL"`
+
+	// AllRepos indicates all the repositories in the scope could use the runners, or only the ones of RepoIDs could
+	AllRepos bool    `xorm:"NOT NULL DEFAULT false"`
+	RepoIDs  []int64 `xorm:"JSON TEXT"`
+	// Workflows are the file names of the workflows which could use the runners, like "deploy.yml", all of them could if it's empty
+	Workflows []string `xorm:"JSON TEXT"`
+	// BranchFilters are glob patterns of the branches or tags whose runs could use the runners, all of them could if it's empty
+	BranchFilters []string `xorm:"JSON TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionRunnerGroup))
+}
+
+// jobsCond returns the condition of the jobs of the repositories which could use the runners of the group
+func (g *ActionRunnerGroup) jobsCond() builder.Cond {
+	if g.AllRepos {
+		return builder.NewCond()
+	}
+	return builder.In("repo_id", g.RepoIDs)
+}
+
+// CanRunJob returns whether the runners of the group could run the job, the run of the job has to be loaded
+func (g *ActionRunnerGroup) CanRunJob(job *ActionRunJob) bool {
+	if !g.AllRepos && !slices.Contains(g.RepoIDs, job.RepoID) {
+		return false
+	}
+	if len(g.Workflows) > 0 && !slices.Contains(g.Workflows, job.Run.WorkflowID) {
+		return false
+	}
+	if len(g.BranchFilters) == 0 {
+		return true
+	}
+	name := git.RefName(job.Run.Ref).ShortName()
+	for _, filter := range g.BranchFilters {
+		gl, err := glob.Compile(filter, '/')
+		if err != nil {
+			log.Warn("Invalid branch filter %q of runner group %d: %v", filter, g.ID, err)
+			continue
+		}
+		if gl.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *ActionRunnerGroup) normalize(ctx context.Context) error {
+	g.Name = strings.TrimSpace(g.Name)
+	if g.Name == "" || len(g.Name) > RunnerGroupNameMaxLength {
+		return util.NewInvalidArgumentErrorf("invalid runner group name %q", g.Name)
+	}
+	g.LowerName = strings.ToLower(g.Name)
+
+	if g.AllRepos {
+		g.RepoIDs = nil
+	}
+	slices.Sort(g.RepoIDs)
+	g.RepoIDs = slices.Compact(g.RepoIDs)
+	for _, repoID := range g.RepoIDs {
+		repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return util.NewInvalidArgumentErrorf("repository %d does not exist", repoID)
+			}
+			return err
+		}
+		if g.OwnerID != 0 && repo.OwnerID != g.OwnerID {
+			return util.NewInvalidArgumentErrorf("repository %d does not belong to the owner of the runner group", repoID)
+		}
+	}
+
+	workflows := make([]string, 0, len(g.Workflows))
+	for _, workflow := range g.Workflows {
+		if workflow = strings.TrimSpace(workflow); workflow == "" || slices.Contains(workflows, workflow) {
+			continue
+		}
+		if strings.Contains(workflow, "/") {
+			return util.NewInvalidArgumentErrorf("invalid workflow %q, it should be a file name like \"deploy.yml\"", workflow)
+		}
+		workflows = append(workflows, workflow)
+	}
+	g.Workflows = workflows
+
+	filters := make([]string, 0, len(g.BranchFilters))
+	for _, filter := range g.BranchFilters {
+		if filter = strings.TrimSpace(filter); filter == "" || slices.Contains(filters, filter) {
+			continue
+		}
+		if _, err := glob.Compile(filter, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid branch filter %q: %v", filter, err)
+		}
+		filters = append(filters, filter)
+	}
+	g.BranchFilters = filters
+	return nil
+}
+
+type FindRunnerGroupsOptions struct {
+	db.ListOptions
+	OwnerID int64
+}
+
+func (opts FindRunnerGroupsOptions) ToConds() builder.Cond {
+	return builder.Eq{"owner_id": opts.OwnerID}
+}
+
+func (opts FindRunnerGroupsOptions) ToOrders() string {
+	return "lower_name"
+}
+
+// GetRunnerGroupByID returns a runner group of the owner, ownerID is 0 for the instance-level groups
+func GetRunnerGroupByID(ctx context.Context, ownerID, id int64) (*ActionRunnerGroup, error) {
+	g := new(ActionRunnerGroup)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "owner_id": ownerID}).Get(g)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("runner group %d does not exist", id)
+	}
+	return g, nil
+}
+
+// CreateRunnerGroup creates a runner group of the owner
+func CreateRunnerGroup(ctx context.Context, g *ActionRunnerGroup) error {
+	if err := g.normalize(ctx); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": g.OwnerID, "lower_name": g.LowerName}).Exist(new(ActionRunnerGroup))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("runner group %q already exists", g.Name)
+		}
+		return db.Insert(ctx, g)
+	})
+}
+
+// UpdateRunnerGroup updates the name and the access policies of a runner group
+func UpdateRunnerGroup(ctx context.Context, g *ActionRunnerGroup) error {
+	if err := g.normalize(ctx); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": g.OwnerID, "lower_name": g.LowerName}).And(builder.Neq{"id": g.ID}).Exist(new(ActionRunnerGroup))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("runner group %q already exists", g.Name)
+		}
+		_, err = db.GetEngine(ctx).ID(g.ID).Cols("name", "lower_name", "all_repos", "repo_i_ds", "workflows", "branch_filters").Update(g)
+		return err
+	})
+}
+
+// DeleteRunnerGroup deletes an empty runner group, the groups with runners can't be deleted,
+// moving the runners out of them would make them available to all the repositories in their scope
+func DeleteRunnerGroup(ctx context.Context, g *ActionRunnerGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"group_id": g.ID}).Exist(new(ActionRunner))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewInvalidArgumentErrorf("runner group %q still contains runners", g.Name)
+		}
+		_, err = db.DeleteByID[ActionRunnerGroup](ctx, g.ID)
+		return err
+	})
+}
+
+// SetRunnerGroup moves the runner into the group, or out of any group if the group is nil
+func SetRunnerGroup(ctx context.Context, runner *ActionRunner, g *ActionRunnerGroup) error {
+	runner.GroupID, runner.Group = 0, nil
+	if g != nil {
+		if runner.RepoID != 0 || runner.OwnerID != g.OwnerID {
+			return util.NewInvalidArgumentErrorf("runner %d can't be added to runner group %d", runner.ID, g.ID)
+		}
+		runner.GroupID, runner.Group = g.ID, g
+	}
+	return UpdateRunner(ctx, runner, "group_id")
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionRunnerGroup_CanRunJob(t *testing.T) {
+	job := &ActionRunJob{RepoID: 4, Run: &ActionRun{WorkflowID: "deploy.yml", Ref: "refs/heads/release/v1"}}
+
+	cases := []struct {
+		group    *ActionRunnerGroup
+		expected bool
+	}{
+		{&ActionRunnerGroup{AllRepos: true}, true},
+		{&ActionRunnerGroup{}, false},
+		{&ActionRunnerGroup{RepoIDs: []int64{1, 4}}, true},
+		{&ActionRunnerGroup{RepoIDs: []int64{1}}, false},
+		{&ActionRunnerGroup{AllRepos: true, Workflows: []string{"deploy.yml"}}, true},
+		{&ActionRunnerGroup{AllRepos: true, Workflows: []string{"test.yml"}}, false},
+		{&ActionRunnerGroup{AllRepos: true, BranchFilters: []string{"main", "release/*"}}, true},
+		{&ActionRunnerGroup{AllRepos: true, BranchFilters: []string{"main"}}, false},
+		{&ActionRunnerGroup{RepoIDs: []int64{4}, Workflows: []string{"deploy.yml"}, BranchFilters: []string{"main"}}, false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.group.CanRunJob(job), "group: %+v", c.group)
+	}
+}
+
+func TestCreateRunnerGroup(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo 1 belongs to user 2, repo 3 belongs to org 3
+	g := &ActionRunnerGroup{OwnerID: 3, Name: "deploy", RepoIDs: []int64{3, 3}, Workflows: []string{" deploy.yml ", ""}}
+	require.NoError(t, CreateRunnerGroup(t.Context(), g))
+	assert.Equal(t, []int64{3}, g.RepoIDs)
+	assert.Equal(t, []string{"deploy.yml"}, g.Workflows)
+
+	err := CreateRunnerGroup(t.Context(), &ActionRunnerGroup{OwnerID: 3, Name: "Deploy"})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	err = CreateRunnerGroup(t.Context(), &ActionRunnerGroup{OwnerID: 3, Name: "other", RepoIDs: []int64{1}})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = CreateRunnerGroup(t.Context(), &ActionRunnerGroup{OwnerID: 3, Name: "other", Workflows: []string{".gitea/workflows/deploy.yml"}})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = CreateRunnerGroup(t.Context(), &ActionRunnerGroup{OwnerID: 3, Name: "other", BranchFilters: []string{"[main"}})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	// the instance-level groups could contain any repository
+	require.NoError(t, CreateRunnerGroup(t.Context(), &ActionRunnerGroup{Name: "deploy", RepoIDs: []int64{1, 3}}))
+
+	// the runners of other owners can't be added to the group
+	runner := &ActionRunner{UUID: "group-runner", Name: "group-runner", OwnerID: 2, TokenHash: "group-runner"}
+	require.NoError(t, CreateRunner(t.Context(), runner))
+	assert.ErrorIs(t, SetRunnerGroup(t.Context(), runner, g), util.ErrInvalidArgument)
+
+	runner = &ActionRunner{UUID: "group-runner-3", Name: "group-runner", OwnerID: 3, TokenHash: "group-runner-3"}
+	require.NoError(t, CreateRunner(t.Context(), runner))
+	require.NoError(t, SetRunnerGroup(t.Context(), runner, g))
+	count, err := db.Count[ActionRunner](t.Context(), FindRunnerOptions{GroupID: optional.Some(g.ID)})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	// the group can't be deleted before its runners are moved out of it
+	assert.ErrorIs(t, DeleteRunnerGroup(t.Context(), g), util.ErrInvalidArgument)
+	require.NoError(t, SetRunnerGroup(t.Context(), runner, nil))
+	require.NoError(t, DeleteRunnerGroup(t.Context(), g))
+	unittest.AssertNotExistsBean(t, &ActionRunnerGroup{ID: g.ID})
+}
+
+func TestCreateTaskForRunnerInGroup(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	jobs := []*ActionRunJob{
+		{RunID: 793, RepoID: 4, OwnerID: 5, JobID: "a", Status: StatusWaiting, RunsOn: []string{"deploy"}},
+		{RunID: 802, RepoID: 5, OwnerID: 3, JobID: "b", Status: StatusWaiting, RunsOn: []string{"deploy"}},
+	}
+	require.NoError(t, db.Insert(t.Context(), jobs))
+
+	// run 793 of repo 4 is triggered on "master", run 802 of repo 5 is triggered on "test"
+	g := &ActionRunnerGroup{Name: "deploy", RepoIDs: []int64{4, 5}, BranchFilters: []string{"main"}}
+	require.NoError(t, CreateRunnerGroup(t.Context(), g))
+	runner := &ActionRunner{UUID: "group-runner", Name: "group-runner", AgentLabels: []string{"deploy"}, TokenHash: "group-runner"}
+	require.NoError(t, CreateRunner(t.Context(), runner))
+	require.NoError(t, SetRunnerGroup(t.Context(), runner, g))
+
+	_, ok, err := CreateTaskForRunner(t.Context(), runner)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	g.RepoIDs = []int64{1}
+	g.BranchFilters = nil
+	require.NoError(t, UpdateRunnerGroup(t.Context(), g))
+	_, ok, err = CreateTaskForRunner(t.Context(), runner)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// RunnerQueue represents the waiting jobs requiring the same runner labels
+type RunnerQueue struct {
+	Labels        []string // the sorted distinct labels of "runs-on", a runner has to have all of them to pick the jobs
+	Count         int64
+	OldestUpdated timeutil.TimeStamp // the jobs are picked in the order of their updated time
+}
+
+// GetRunnerQueues returns the waiting jobs which could be picked by the runners of the owner or the repo
+// grouped by their labels, ownerID and repoID are both 0 for global runners. The queues are sorted by their labels.
+// The restrictions of the runner groups aren't applied: the queues count the jobs which the runners out of any group could pick,
+// like the just-in-time runners, the runners in a group could only pick the jobs allowed by its policies, see CanRunJob.
+func GetRunnerQueues(ctx context.Context, ownerID, repoID int64) ([]*RunnerQueue, error) {
+	var jobs []*ActionRunJob
+	if err := db.GetEngine(ctx).Cols("runs_on", "updated").
+		Where("task_id=? AND status=?", 0, StatusWaiting).And(runnerJobsCond(ownerID, repoID)).
+		Find(&jobs); err != nil {
+		return nil, err
+	}
+
+	queues := make(map[string]*RunnerQueue)
+	for _, job := range jobs {
+		labels := slices.Compact(slices.Sorted(slices.Values(job.RunsOn)))
+		key := strings.Join(labels, "\n")
+		queue, ok := queues[key]
+		if !ok {
+			queue = &RunnerQueue{Labels: labels, OldestUpdated: job.Updated}
+			queues[key] = queue
+		}
+		queue.Count++
+		queue.OldestUpdated = min(queue.OldestUpdated, job.Updated)
+	}
+
+	ret := make([]*RunnerQueue, 0, len(queues))
+	for _, queue := range queues {
+		ret = append(ret, queue)
+	}
+	slices.SortFunc(ret, func(a, b *RunnerQueue) int {
+		return slices.Compare(a.Labels, b.Labels)
+	})
+	return ret, nil
+}
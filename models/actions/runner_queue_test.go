@@ -0,0 +1,55 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRunnerQueues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	jobs := []*ActionRunJob{
+		{RunID: 793, RepoID: 4, OwnerID: 5, JobID: "a", Status: StatusWaiting, RunsOn: []string{"ubuntu"}},
+		{RunID: 793, RepoID: 4, OwnerID: 5, JobID: "b", Status: StatusWaiting, RunsOn: []string{"ubuntu", "docker"}},
+		{RunID: 793, RepoID: 4, OwnerID: 5, JobID: "c", Status: StatusWaiting, RunsOn: []string{"docker", "ubuntu", "docker"}},
+		{RunID: 793, RepoID: 4, OwnerID: 5, JobID: "d", Status: StatusWaiting, RunsOn: []string{"windows"}, TaskID: 1000},
+		{RunID: 793, RepoID: 4, OwnerID: 5, JobID: "e", Status: StatusBlocked, RunsOn: []string{"windows"}},
+		{RunID: 802, RepoID: 5, OwnerID: 3, JobID: "f", Status: StatusWaiting, RunsOn: []string{"ubuntu"}},
+	}
+	require.NoError(t, db.Insert(t.Context(), jobs))
+
+	queueCounts := func(queues []*RunnerQueue) map[string]int64 {
+		counts := make(map[string]int64, len(queues))
+		for _, queue := range queues {
+			assert.NotZero(t, queue.OldestUpdated)
+			counts[queue.Labels[0]+"/"+queue.Labels[len(queue.Labels)-1]] = queue.Count
+		}
+		return counts
+	}
+
+	queues, err := GetRunnerQueues(t.Context(), 0, 0)
+	require.NoError(t, err)
+	require.Len(t, queues, 2)
+	assert.Equal(t, []string{"docker", "ubuntu"}, queues[0].Labels)
+	assert.Equal(t, map[string]int64{"docker/ubuntu": 2, "ubuntu/ubuntu": 2}, queueCounts(queues))
+
+	queues, err = GetRunnerQueues(t.Context(), 0, 4)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"docker/ubuntu": 2, "ubuntu/ubuntu": 1}, queueCounts(queues))
+
+	queues, err = GetRunnerQueues(t.Context(), 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"docker/ubuntu": 2, "ubuntu/ubuntu": 1}, queueCounts(queues))
+
+	queues, err = GetRunnerQueues(t.Context(), 0, 1)
+	require.NoError(t, err)
+	assert.Empty(t, queues)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"slices"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+)
+
+// ScopeVisibility decides which repositories of the owner can use an org or user level secret or variable
+type ScopeVisibility string
+
+const (
+	ScopeVisibilityAll      ScopeVisibility = "all"      // all repositories of the owner
+	ScopeVisibilityPrivate  ScopeVisibility = "private"  // only the private repositories of the owner
+	ScopeVisibilitySelected ScopeVisibility = "selected" // only the selected repositories of the owner
+)
+
+// IsValid checks if the visibility is known
+func (v ScopeVisibility) IsValid() bool {
+	return v == ScopeVisibilityAll || v == ScopeVisibilityPrivate || v == ScopeVisibilitySelected
+}
+
+// RepoScope restricts an org or user level secret or variable to some repositories of its owner
+// and to the jobs running in some deployment environments. It's ignored for repo level and global ones.
+type RepoScope struct {
+	Visibility      ScopeVisibility `xorm:"VARCHAR(20) NOT NULL DEFAULT 'all'"`
+	SelectedRepoIDs []int64         `xorm:"JSON TEXT"`
+	// SelectedEnvironments are the names of the environments whose jobs can use the secret or variable,
+	// all jobs can use it if it's empty
+	SelectedEnvironments []string `xorm:"JSON TEXT"`
+}
+
+// Normalize fills the default visibility and sorts the selected repositories and environments,
+// the repositories are only kept for the "selected" visibility
+func (s *RepoScope) Normalize() {
+	if s.Visibility == "" {
+		s.Visibility = ScopeVisibilityAll
+	}
+
+	envs := make([]string, 0, len(s.SelectedEnvironments))
+	for _, env := range s.SelectedEnvironments {
+		if env = strings.TrimSpace(env); env != "" {
+			envs = append(envs, env)
+		}
+	}
+	slices.Sort(envs)
+	s.SelectedEnvironments = slices.Compact(envs)
+	if len(s.SelectedEnvironments) == 0 {
+		s.SelectedEnvironments = nil
+	}
+
+	if s.Visibility != ScopeVisibilitySelected {
+		s.SelectedRepoIDs = nil
+		return
+	}
+	slices.Sort(s.SelectedRepoIDs)
+	s.SelectedRepoIDs = slices.Compact(s.SelectedRepoIDs)
+}
+
+// IsRestricted returns whether some repositories of the owner or some jobs can't use the secret or variable
+func (s *RepoScope) IsRestricted() bool {
+	return s.Visibility == ScopeVisibilityPrivate || s.Visibility == ScopeVisibilitySelected || len(s.SelectedEnvironments) > 0
+}
+
+// AllowsRepo returns whether the repository can use the secret or variable
+func (s *RepoScope) AllowsRepo(repo *repo_model.Repository) bool {
+	switch s.Visibility {
+	case ScopeVisibilityPrivate:
+		return repo.IsPrivate
+	case ScopeVisibilitySelected:
+		return slices.Contains(s.SelectedRepoIDs, repo.ID)
+	}
+	return true
+}
+
+// AllowsEnvironment returns whether a job running in the environment can use the secret or variable,
+// a job without environment can only use it if it's not restricted to some environments
+func (s *RepoScope) AllowsEnvironment(environment string) bool {
+	if len(s.SelectedEnvironments) == 0 {
+		return true
+	}
+	// the names of environments are case-insensitive
+	return slices.ContainsFunc(s.SelectedEnvironments, func(name string) bool {
+		return strings.EqualFold(name, environment)
+	})
+}
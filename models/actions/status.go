@@ -23,6 +23,10 @@ const (
 	StatusWaiting                 // 5, isn't a runnerv1.Result
 	StatusRunning                 // 6, isn't a runnerv1.Result
 	StatusBlocked                 // 7, isn't a runnerv1.Result
+
+	// StatusPendingDeployment is a job targeting a protected environment, waiting for a review or a wait timer.
+	// It isn't a runnerv1.Result, it's named "waiting" by the API like GitHub, but StatusWaiting is already used for the queued jobs.
+	StatusPendingDeployment // 8
 )
 
 var statusNames = map[Status]string{
@@ -34,6 +38,8 @@ var statusNames = map[Status]string{
 	StatusCancelled: "cancelled",
 	StatusSkipped:   "skipped",
 	StatusBlocked:   "blocked",
+
+	StatusPendingDeployment: "pending_deployment",
 }
 
 // String returns the string name of the Status
@@ -88,6 +94,10 @@ func (s Status) IsBlocked() bool {
 	return s == StatusBlocked
 }
 
+func (s Status) IsPendingDeployment() bool {
+	return s == StatusPendingDeployment
+}
+
 // In returns whether s is one of the given statuses
 func (s Status) In(statuses ...Status) bool {
 	return slices.Contains(statuses, s)
@@ -214,6 +214,23 @@ func GetRunningTaskByToken(ctx context.Context, token string) (*ActionTask, erro
 	return nil, errNotExist
 }
 
+// runnerJobsCond returns the condition of the jobs which could be picked by the runners of the owner or the repo,
+// ownerID and repoID are both 0 for global runners
+func runnerJobsCond(ownerID, repoID int64) builder.Cond {
+	jobCond := builder.NewCond()
+	if repoID != 0 {
+		jobCond = builder.Eq{"repo_id": repoID}
+	} else if ownerID != 0 {
+		jobCond = builder.In("repo_id", builder.Select("`repository`.id").From("repository").
+			Join("INNER", "repo_unit", "`repository`.id = `repo_unit`.repo_id").
+			Where(builder.Eq{"`repository`.owner_id": ownerID, "`repo_unit`.type": unit.TypeActions}))
+	}
+	if jobCond.IsValid() {
+		jobCond = builder.In("run_id", builder.Select("id").From("action_run").Where(jobCond))
+	}
+	return jobCond
+}
+
 func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask, bool, error) {
 	ctx, committer, err := db.TxContext(ctx)
 	if err != nil {
@@ -223,16 +240,12 @@ func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask
 
 	e := db.GetEngine(ctx)
 
-	jobCond := builder.NewCond()
-	if runner.RepoID != 0 {
-		jobCond = builder.Eq{"repo_id": runner.RepoID}
-	} else if runner.OwnerID != 0 {
-		jobCond = builder.In("repo_id", builder.Select("`repository`.id").From("repository").
-			Join("INNER", "repo_unit", "`repository`.id = `repo_unit`.repo_id").
-			Where(builder.Eq{"`repository`.owner_id": runner.OwnerID, "`repo_unit`.type": unit.TypeActions}))
+	if err := runner.LoadGroup(ctx); err != nil {
+		return nil, false, err
 	}
-	if jobCond.IsValid() {
-		jobCond = builder.In("run_id", builder.Select("id").From("action_run").Where(jobCond))
+	jobCond := runnerJobsCond(runner.OwnerID, runner.RepoID)
+	if runner.Group != nil {
+		jobCond = jobCond.And(runner.Group.jobsCond())
 	}
 
 	var jobs []*ActionRunJob
@@ -244,10 +257,19 @@ func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask
 	var job *ActionRunJob
 	log.Trace("runner labels: %v", runner.AgentLabels)
 	for _, v := range jobs {
-		if runner.CanMatchLabels(v.RunsOn) {
-			job = v
-			break
+		if !runner.CanMatchLabels(v.RunsOn) {
+			continue
+		}
+		if runner.Group != nil {
+			if err := v.LoadRun(ctx); err != nil {
+				return nil, false, err
+			}
+			if !runner.Group.CanRunJob(v) {
+				continue
+			}
 		}
+		job = v
+		break
 	}
 	if job == nil {
 		return nil, false, nil
@@ -0,0 +1,133 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// MaxAnnotationsPerTask is the maximum number of annotations of a task, the others are ignored
+const MaxAnnotationsPerTask = 50
+
+// AnnotationLevel represents the level of an annotation
+type AnnotationLevel int
+
+const (
+	AnnotationLevelNotice AnnotationLevel = iota + 1
+	AnnotationLevelWarning
+	AnnotationLevelError
+)
+
+var annotationLevelNames = map[AnnotationLevel]string{
+	AnnotationLevelNotice:  "notice",
+	AnnotationLevelWarning: "warning",
+	AnnotationLevelError:   "error",
+}
+
+// String returns the name of the level, it's also the name of the workflow command creating the annotation
+func (l AnnotationLevel) String() string {
+	return annotationLevelNames[l]
+}
+
+// ParseAnnotationLevel returns the level named by a workflow command, 0 if the name is unknown
+func ParseAnnotationLevel(name string) AnnotationLevel {
+	for level, n := range annotationLevelNames {
+		if n == name {
+			return level
+		}
+	}
+	return 0
+}
+
+// ActionTaskAnnotation is an annotation created by a step of a task with the "::error", "::warning" or "::notice" commands.
+// Like the outputs, the annotations are bound to a task, a rerun job doesn't keep the annotations of its previous attempts.
+type ActionTaskAnnotation struct {
+	ID          int64
+	TaskID      int64           `xorm:"INDEX NOT NULL"`
+	RepoID      int64           `xorm:"INDEX(repo_commit) NOT NULL"`
+	CommitSHA   string          `xorm:"VARCHAR(64) INDEX(repo_commit)"`
+	Level       AnnotationLevel `xorm:"NOT NULL"`
+	Title       string          `xorm:"VARCHAR(255)"`
+	Message     string          `xorm:"TEXT"`
+	Path        string          `xorm:"TEXT"`
+	StartLine   int
+	EndLine     int
+	StartColumn int
+	EndColumn   int
+	LogIndex    int64 // index of the log line of the command in the logs of the task
+
+	Created timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionTaskAnnotation))
+}
+
+// InsertTaskAnnotations inserts the annotations of a task, up to MaxAnnotationsPerTask annotations are kept
+func InsertTaskAnnotations(ctx context.Context, task *ActionTask, annotations []*ActionTaskAnnotation) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		count, err := db.GetEngine(ctx).Where("task_id=?", task.ID).Count(new(ActionTaskAnnotation))
+		if err != nil {
+			return err
+		}
+		remaining := MaxAnnotationsPerTask - int(count)
+		if remaining <= 0 {
+			return nil
+		}
+		annotations = annotations[:min(remaining, len(annotations))]
+		for _, a := range annotations {
+			a.TaskID = task.ID
+			a.RepoID = task.RepoID
+			a.CommitSHA = task.CommitSHA
+		}
+		return db.Insert(ctx, annotations)
+	})
+}
+
+// FindTaskAnnotations returns the annotations of a task in the order they have been created
+func FindTaskAnnotations(ctx context.Context, taskID int64) ([]*ActionTaskAnnotation, error) {
+	var annotations []*ActionTaskAnnotation
+	return annotations, db.GetEngine(ctx).Where("task_id=?", taskID).OrderBy("log_index, id").Find(&annotations)
+}
+
+// FindCommitAnnotations returns the annotations of the latest attempts of the jobs which have run on a commit
+func FindCommitAnnotations(ctx context.Context, repoID int64, commitSHA string) ([]*ActionTaskAnnotation, error) {
+	var annotations []*ActionTaskAnnotation
+	return annotations, db.GetEngine(ctx).
+		Join("INNER", "action_run_job", "action_run_job.task_id = action_task_annotation.task_id").
+		Where(builder.Eq{
+			"action_task_annotation.repo_id":    repoID,
+			"action_task_annotation.commit_sha": commitSHA,
+		}).
+		OrderBy("action_task_annotation.id").
+		Find(&annotations)
+}
+
+// CountTaskAnnotationsByLevel returns the number of annotations of a task for each level
+func CountTaskAnnotationsByLevel(ctx context.Context, taskID int64) (map[AnnotationLevel]int64, error) {
+	var results []struct {
+		Level AnnotationLevel
+		Count int64
+	}
+	if err := db.GetEngine(ctx).Table("action_task_annotation").
+		Select("level, COUNT(*) AS count").
+		Where("task_id=?", taskID).
+		GroupBy("level").
+		Find(&results); err != nil {
+		return nil, err
+	}
+	counts := make(map[AnnotationLevel]int64, len(results))
+	for _, r := range results {
+		counts[r.Level] = r.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskAnnotations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	const commitSHA = "c2d72f548424103f01ee1dc02889c1e2bff816b0"
+	oldTask := &ActionTask{ID: 1001, RepoID: 4, CommitSHA: commitSHA}
+	task := &ActionTask{ID: 1002, RepoID: 4, CommitSHA: commitSHA}
+	// the job has been rerun, its latest attempt is the second task
+	require.NoError(t, db.Insert(t.Context(), &ActionRunJob{RunID: 791, RepoID: 4, CommitSHA: commitSHA, TaskID: task.ID, Name: "job"}))
+
+	require.NoError(t, InsertTaskAnnotations(t.Context(), oldTask, []*ActionTaskAnnotation{
+		{Level: AnnotationLevelError, Message: "old error", Path: "main.go", StartLine: 1, EndLine: 1},
+	}))
+	require.NoError(t, InsertTaskAnnotations(t.Context(), task, []*ActionTaskAnnotation{
+		{Level: AnnotationLevelError, Message: "error", Path: "main.go", StartLine: 1, EndLine: 1, LogIndex: 5},
+		{Level: AnnotationLevelWarning, Message: "warning", LogIndex: 3},
+		{Level: AnnotationLevelWarning, Message: "another warning", LogIndex: 8},
+	}))
+
+	annotations, err := FindTaskAnnotations(t.Context(), task.ID)
+	require.NoError(t, err)
+	if assert.Len(t, annotations, 3) {
+		assert.Equal(t, "warning", annotations[0].Message)
+		assert.Equal(t, "error", annotations[1].Message)
+		assert.Equal(t, int64(4), annotations[1].RepoID)
+		assert.Equal(t, commitSHA, annotations[1].CommitSHA)
+	}
+
+	annotations, err = FindCommitAnnotations(t.Context(), 4, commitSHA)
+	require.NoError(t, err)
+	assert.Len(t, annotations, 3)
+	for _, a := range annotations {
+		assert.Equal(t, task.ID, a.TaskID)
+	}
+
+	counts, err := CountTaskAnnotationsByLevel(t.Context(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[AnnotationLevel]int64{AnnotationLevelError: 1, AnnotationLevelWarning: 2}, counts)
+
+	// the annotations exceeding the limit are ignored
+	many := make([]*ActionTaskAnnotation, MaxAnnotationsPerTask)
+	for i := range many {
+		many[i] = &ActionTaskAnnotation{Level: AnnotationLevelNotice}
+	}
+	require.NoError(t, InsertTaskAnnotations(t.Context(), task, many))
+	annotations, err = FindTaskAnnotations(t.Context(), task.ID)
+	require.NoError(t, err)
+	assert.Len(t, annotations, MaxAnnotationsPerTask)
+}
+
+func TestTaskSummaries(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	task := &ActionTask{ID: 1003, RepoID: 4}
+	require.NoError(t, SetTaskStepSummary(t.Context(), task, 2, "## Step 2"))
+	require.NoError(t, SetTaskStepSummary(t.Context(), task, 0, "## Step 0"))
+	require.NoError(t, SetTaskStepSummary(t.Context(), task, 2, "## Step 2 updated"))
+
+	summaries, err := FindTaskSummaries(t.Context(), task.ID)
+	require.NoError(t, err)
+	if assert.Len(t, summaries, 2) {
+		assert.Equal(t, "## Step 0", summaries[0].Content)
+		assert.Equal(t, "## Step 2 updated", summaries[1].Content)
+		assert.Equal(t, int64(4), summaries[1].RepoID)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// MaxStepSummarySize is the maximum size of the summary of a step, it's the same as GitHub
+const MaxStepSummarySize = 1024 * 1024
+
+// ActionTaskSummary is the markdown summary written by a step of a task to $GITHUB_STEP_SUMMARY.
+// The summaries of the steps are shown together as the summary of the job.
+type ActionTaskSummary struct {
+	ID        int64
+	TaskID    int64  `xorm:"UNIQUE(task_step) NOT NULL"`
+	StepIndex int64  `xorm:"UNIQUE(task_step) NOT NULL"`
+	RepoID    int64  `xorm:"INDEX NOT NULL"`
+	Content   string `xorm:"MEDIUMTEXT"`
+
+	Created timeutil.TimeStamp `xorm:"created"`
+	Updated timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionTaskSummary))
+}
+
+// SetTaskStepSummary sets the summary of a step, it replaces the summary which has been set before
+func SetTaskStepSummary(ctx context.Context, task *ActionTask, stepIndex int64, content string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		summary := &ActionTaskSummary{}
+		has, err := db.GetEngine(ctx).Where("task_id=? AND step_index=?", task.ID, stepIndex).Get(summary)
+		if err != nil {
+			return err
+		}
+		if has {
+			summary.Content = content
+			_, err = db.GetEngine(ctx).ID(summary.ID).Cols("content").Update(summary)
+			return err
+		}
+		return db.Insert(ctx, &ActionTaskSummary{
+			TaskID:    task.ID,
+			StepIndex: stepIndex,
+			RepoID:    task.RepoID,
+			Content:   content,
+		})
+	})
+}
+
+// FindTaskSummaries returns the summaries of the steps of a task in the order of the steps
+func FindTaskSummaries(ctx context.Context, taskID int64) ([]*ActionTaskSummary, error) {
+	var summaries []*ActionTaskSummary
+	return summaries, db.GetEngine(ctx).Where("task_id=?", taskID).OrderBy("step_index").Find(&summaries)
+}
@@ -5,6 +5,8 @@ package actions
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
@@ -22,6 +24,7 @@ import (
 //  1. global variable, OwnerID is 0 and RepoID is 0
 //  2. org/user level variable, OwnerID is org/user ID and RepoID is 0
 //  3. repo level variable, OwnerID is 0 and RepoID is repo ID
+//  4. environment level variable, OwnerID is 0, RepoID is repo ID and EnvironmentID is the ID of an environment of the repo
 //
 // Please note that it's not acceptable to have both OwnerID and RepoID to be non-zero,
 // or it will be complicated to find variables belonging to a specific owner.
@@ -37,6 +40,11 @@ type ActionVariable struct {
 	Description string             `xorm:"TEXT"`
 	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	// EnvironmentID is only set for environment level variables, which are not repo level variables
+	EnvironmentID int64 `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+
+	RepoScope `xorm:"extends"`
 }
 
 const (
@@ -67,6 +75,27 @@ func InsertVariable(ctx context.Context, ownerID, repoID int64, name, data, desc
 		Name:        strings.ToUpper(name),
 		Data:        data,
 		Description: description,
+		RepoScope:   RepoScope{Visibility: ScopeVisibilityAll},
+	}
+	return variable, db.Insert(ctx, variable)
+}
+
+// InsertEnvironmentVariable inserts a variable of an environment of the repository
+func InsertEnvironmentVariable(ctx context.Context, repoID, environmentID int64, name, data, description string) (*ActionVariable, error) {
+	if repoID == 0 || environmentID == 0 {
+		return nil, util.NewInvalidArgumentErrorf("an environment level variable must belong to a repository")
+	}
+	if utf8.RuneCountInString(data) > VariableDataMaxLength {
+		return nil, util.NewInvalidArgumentErrorf("data too long")
+	}
+
+	variable := &ActionVariable{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          strings.ToUpper(name),
+		Data:          data,
+		Description:   util.TruncateRunes(description, VariableDescriptionMaxLength),
+		RepoScope:     RepoScope{Visibility: ScopeVisibilityAll},
 	}
 	return variable, db.Insert(ctx, variable)
 }
@@ -76,7 +105,9 @@ type FindVariablesOpts struct {
 	IDs     []int64
 	RepoID  int64
 	OwnerID int64 // it will be ignored if RepoID is set
-	Name    string
+	// EnvironmentID finds the variables of an environment of the repository instead of the repo level ones
+	EnvironmentID int64
+	Name          string
 }
 
 func (opts FindVariablesOpts) ToConds() builder.Cond {
@@ -99,6 +130,7 @@ func (opts FindVariablesOpts) ToConds() builder.Cond {
 	} else {
 		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
 	}
+	cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
 
 	if opts.Name != "" {
 		cond = cond.And(builder.Eq{"name": strings.ToUpper(opts.Name)})
@@ -132,7 +164,13 @@ func DeleteVariable(ctx context.Context, id int64) error {
 	return nil
 }
 
+// GetVariablesOfRun returns the global, org/user and repo level variables which can be used by the run,
+// the org/user level variables restricted to some environments are not included
 func GetVariablesOfRun(ctx context.Context, run *ActionRun) (map[string]string, error) {
+	return getVariablesOfRun(ctx, run, "")
+}
+
+func getVariablesOfRun(ctx context.Context, run *ActionRun, environment string) (map[string]string, error) {
 	variables := map[string]string{}
 
 	if err := run.LoadRepo(ctx); err != nil {
@@ -153,6 +191,9 @@ func GetVariablesOfRun(ctx context.Context, run *ActionRun) (map[string]string,
 		log.Error("find variables of org: %d, error: %v", run.Repo.OwnerID, err)
 		return nil, err
 	}
+	ownerVariables = slices.DeleteFunc(ownerVariables, func(v *ActionVariable) bool {
+		return !v.AllowsRepo(run.Repo) || !v.AllowsEnvironment(environment)
+	})
 
 	// Repo level
 	repoVariables, err := db.Find[ActionVariable](ctx, FindVariablesOpts{RepoID: run.RepoID})
@@ -169,6 +210,34 @@ func GetVariablesOfRun(ctx context.Context, run *ActionRun) (map[string]string,
 	return variables, nil
 }
 
+// GetVariablesOfJob returns the variables of the run, overridden by the variables of the job's environment
+func GetVariablesOfJob(ctx context.Context, job *ActionRunJob) (map[string]string, error) {
+	if err := job.LoadRun(ctx); err != nil {
+		return nil, err
+	}
+	variables, err := getVariablesOfRun(ctx, job.Run, job.Environment)
+	if err != nil || job.Environment == "" {
+		return variables, err
+	}
+
+	env, err := GetEnvironmentByName(ctx, job.RepoID, job.Environment)
+	if errors.Is(err, util.ErrNotExist) {
+		return variables, nil
+	} else if err != nil {
+		return nil, err
+	}
+	// Level precedence: Environment > Repo > Org / User > Global
+	envVariables, err := db.Find[ActionVariable](ctx, FindVariablesOpts{RepoID: job.RepoID, EnvironmentID: env.ID})
+	if err != nil {
+		log.Error("find variables of environment: %d, error: %v", env.ID, err)
+		return nil, err
+	}
+	for _, v := range envVariables {
+		variables[v.Name] = v.Data
+	}
+	return variables, nil
+}
+
 func CountWrongRepoLevelVariables(ctx context.Context) (int64, error) {
 	var result int64
 	_, err := db.GetEngine(ctx).SQL("SELECT count(`id`) FROM `action_variable` WHERE `repo_id` > 0 AND `owner_id` > 0").Get(&result)
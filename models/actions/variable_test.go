@@ -0,0 +1,88 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVariablesOfRunWithRepoScope(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	insert := func(name string, scope RepoScope) {
+		v, err := InsertVariable(t.Context(), repo.OwnerID, 0, name, "value", "")
+		require.NoError(t, err)
+		v.RepoScope = scope
+		_, err = UpdateVariableCols(t.Context(), v, "visibility", "selected_repo_i_ds", "selected_environments")
+		require.NoError(t, err)
+	}
+	insert("ALL", RepoScope{Visibility: ScopeVisibilityAll})
+	insert("PRIVATE", RepoScope{Visibility: ScopeVisibilityPrivate})
+	insert("SELECTED", RepoScope{Visibility: ScopeVisibilitySelected, SelectedRepoIDs: []int64{repo.ID}})
+	insert("OTHER", RepoScope{Visibility: ScopeVisibilitySelected, SelectedRepoIDs: []int64{repo.ID + 1}})
+
+	variables, err := db.Find[ActionVariable](t.Context(), FindVariablesOpts{OwnerID: repo.OwnerID})
+	require.NoError(t, err)
+	assert.Len(t, variables, 4)
+
+	run := &ActionRun{RepoID: repo.ID}
+	got, err := GetVariablesOfRun(t.Context(), run)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ALL": "value", "SELECTED": "value"}, got)
+
+	run.Repo.IsPrivate = true
+	got, err = GetVariablesOfRun(t.Context(), run)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ALL": "value", "PRIVATE": "value", "SELECTED": "value"}, got)
+}
+
+func TestGetVariablesOfJobWithEnvironmentScope(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	for name, envs := range map[string][]string{"ANY": nil, "PROD": {"production"}, "STAGING": {"staging"}} {
+		v, err := InsertVariable(t.Context(), repo.OwnerID, 0, name, "value", "")
+		require.NoError(t, err)
+		v.RepoScope = RepoScope{Visibility: ScopeVisibilityAll, SelectedEnvironments: envs}
+		_, err = UpdateVariableCols(t.Context(), v, "visibility", "selected_repo_i_ds", "selected_environments")
+		require.NoError(t, err)
+	}
+
+	run := &ActionRun{RepoID: repo.ID}
+	got, err := GetVariablesOfRun(t.Context(), run)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ANY": "value"}, got)
+
+	job := &ActionRunJob{RepoID: repo.ID, Run: run, Environment: "Production"}
+	got, err = GetVariablesOfJob(t.Context(), job)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ANY": "value", "PROD": "value"}, got)
+}
+
+func TestRepoScopeNormalize(t *testing.T) {
+	scope := RepoScope{SelectedRepoIDs: []int64{1}}
+	scope.Normalize()
+	assert.Equal(t, RepoScope{Visibility: ScopeVisibilityAll}, scope)
+	assert.False(t, scope.IsRestricted())
+
+	scope = RepoScope{Visibility: ScopeVisibilitySelected, SelectedRepoIDs: []int64{3, 1, 3}}
+	scope.Normalize()
+	assert.Equal(t, []int64{1, 3}, scope.SelectedRepoIDs)
+	assert.True(t, scope.IsRestricted())
+
+	scope = RepoScope{SelectedEnvironments: []string{" staging", "", "production", "staging"}}
+	scope.Normalize()
+	assert.Equal(t, []string{"production", "staging"}, scope.SelectedEnvironments)
+	assert.True(t, scope.IsRestricted())
+	assert.True(t, scope.AllowsEnvironment("Staging"))
+	assert.False(t, scope.AllowsEnvironment(""))
+}
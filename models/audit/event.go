@@ -0,0 +1,404 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// Action is the kind of an audited operation, e.g. "repo.collaborator.add"
+type Action string
+
+const (
+	ActionUserAccessTokenCreate Action = "user.access_token.create"
+	ActionUserAccessTokenDelete Action = "user.access_token.delete"
+	ActionUserTwoFactorEnable   Action = "user.two_factor.enable"
+	ActionUserTwoFactorDisable  Action = "user.two_factor.disable"
+	ActionUserWebAuthnAdd       Action = "user.webauthn.add"
+	ActionUserWebAuthnRemove    Action = "user.webauthn.remove"
+
+	ActionOrgUpdate           Action = "org.update"
+	ActionOrgTeamCreate       Action = "org.team.create"
+	ActionOrgTeamUpdate       Action = "org.team.update"
+	ActionOrgTeamDelete       Action = "org.team.delete"
+	ActionOrgTeamMemberAdd    Action = "org.team.member.add"
+	ActionOrgTeamMemberRemove Action = "org.team.member.remove"
+
+	ActionRepoCollaboratorAdd        Action = "repo.collaborator.add"
+	ActionRepoCollaboratorUpdate     Action = "repo.collaborator.update"
+	ActionRepoCollaboratorRemove     Action = "repo.collaborator.remove"
+	ActionRepoBranchProtectionCreate Action = "repo.branch_protection.create"
+	ActionRepoBranchProtectionUpdate Action = "repo.branch_protection.update"
+	ActionRepoBranchProtectionDelete Action = "repo.branch_protection.delete"
+	ActionRepoDeployKeyAdd           Action = "repo.deploy_key.add"
+	ActionRepoDeployKeyRemove        Action = "repo.deploy_key.remove"
+
+	// the webhooks of repositories, users, organizations and the system
+	ActionWebhookCreate Action = "webhook.create"
+	ActionWebhookUpdate Action = "webhook.update"
+	ActionWebhookDelete Action = "webhook.delete"
+)
+
+// ScopeType is the type of the object an event belongs to, it decides where the event is shown
+type ScopeType string
+
+const (
+	ScopeSystem       ScopeType = "system"
+	ScopeUser         ScopeType = "user"
+	ScopeOrganization ScopeType = "organization"
+	ScopeRepository   ScopeType = "repository"
+)
+
+// Event represents an audited administrative or security relevant operation.
+// The events are chained by their hashes, so a modified or removed event can be detected.
+// The hashes are keyed by the SECRET_KEY, so they can't be recomputed by someone who can only access the database.
+type Event struct {
+	ID         int64     `xorm:"pk autoincr"`
+	Action     Action    `xorm:"VARCHAR(255) INDEX NOT NULL"`
+	ActorID    int64     `xorm:"INDEX"`
+	ActorName  string    `xorm:"VARCHAR(255)"`
+	ScopeType  ScopeType `xorm:"VARCHAR(20) INDEX(s) NOT NULL"`
+	ScopeID    int64     `xorm:"INDEX(s)"`
+	ScopeName  string    `xorm:"VARCHAR(255)"`
+	TargetType string    `xorm:"VARCHAR(255)"`
+	TargetID   int64
+	TargetName string `xorm:"VARCHAR(255)"`
+	// Before and After are the JSON encoded states of the target
+	Before      string             `xorm:"LONGTEXT"`
+	After       string             `xorm:"LONGTEXT"`
+	IPAddress   string             `xorm:"VARCHAR(64)"`
+	PrevHash    string             `xorm:"VARCHAR(64)"`
+	Hash        string             `xorm:"VARCHAR(64) NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(Event))
+}
+
+// TableName returns the table name of the event
+func (*Event) TableName() string {
+	return "audit_event"
+}
+
+// FieldChange represents a changed field of the target of an event
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+func decodeState(state string) map[string]any {
+	m := map[string]any{}
+	if state != "" {
+		if err := json.Unmarshal([]byte(state), &m); err != nil {
+			log.Error("Unable to decode audit event state %q: %v", state, err)
+		}
+	}
+	return m
+}
+
+func encodeValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	bs, _ := json.Marshal(v)
+	return string(bs)
+}
+
+// Changes returns the fields of the target which differ before and after the operation, sorted by their names
+func (e *Event) Changes() []*FieldChange {
+	before, after := decodeState(e.Before), decodeState(e.After)
+	fields := make(container.Set[string])
+	for k := range before {
+		fields.Add(k)
+	}
+	for k := range after {
+		fields.Add(k)
+	}
+
+	changes := make([]*FieldChange, 0, len(fields))
+	for _, field := range slices.Sorted(maps.Keys(fields)) {
+		change := &FieldChange{Field: field, Before: encodeValue(before[field]), After: encodeValue(after[field])}
+		if change.Before != change.After {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// newMAC returns the keyed hash used for the events and the chain state
+func newMAC() hash.Hash {
+	return hmac.New(sha256.New, []byte(setting.SecretKey))
+}
+
+func writeMACFields(h hash.Hash, fields ...string) string {
+	for _, s := range fields {
+		// the length prefix prevents different fields from producing the same input
+		_, _ = fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ComputeHash returns the hash of the event which covers all its fields and the hash of the previous event
+func (e *Event) ComputeHash() string {
+	return writeMACFields(newMAC(),
+		e.PrevHash,
+		string(e.Action),
+		strconv.FormatInt(e.ActorID, 10),
+		e.ActorName,
+		string(e.ScopeType),
+		strconv.FormatInt(e.ScopeID, 10),
+		e.ScopeName,
+		e.TargetType,
+		strconv.FormatInt(e.TargetID, 10),
+		e.TargetName,
+		e.Before,
+		e.After,
+		e.IPAddress,
+		strconv.FormatInt(int64(e.CreatedUnix), 10),
+	)
+}
+
+// ChainState keeps both ends of the hash chain of the events: the newest event, so removing the newest events
+// can be detected, and the newest event removed by the retention, which the oldest remaining event has to refer to.
+// There is only one chain state, it's signed with the same key as the events.
+type ChainState struct {
+	ID         int64  `xorm:"pk"`
+	HeadID     int64  `xorm:"NOT NULL DEFAULT 0"`
+	HeadHash   string `xorm:"VARCHAR(64)"`
+	PurgedID   int64  `xorm:"NOT NULL DEFAULT 0"`
+	PurgedHash string `xorm:"VARCHAR(64)"`
+	Signature  string `xorm:"VARCHAR(64) NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(ChainState))
+}
+
+// TableName returns the table name of the chain state
+func (*ChainState) TableName() string {
+	return "audit_chain_state"
+}
+
+const chainStateID = 1
+
+// ComputeSignature returns the signature of the chain state
+func (s *ChainState) ComputeSignature() string {
+	return writeMACFields(newMAC(),
+		strconv.FormatInt(s.HeadID, 10),
+		s.HeadHash,
+		strconv.FormatInt(s.PurgedID, 10),
+		s.PurgedHash,
+	)
+}
+
+// getChainState returns the chain state, it's nil if no event has been recorded yet
+func getChainState(ctx context.Context) (*ChainState, error) {
+	state := new(ChainState)
+	has, err := db.GetEngine(ctx).ID(chainStateID).Get(state)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return state, nil
+}
+
+func saveChainState(ctx context.Context, state *ChainState, isNew bool) error {
+	state.ID = chainStateID
+	state.Signature = state.ComputeSignature()
+	if isNew {
+		return db.Insert(ctx, state)
+	}
+	_, err := db.GetEngine(ctx).ID(chainStateID).AllCols().Update(state)
+	return err
+}
+
+// InsertEvent appends the event to the chain, the caller has to make sure no other event is inserted concurrently
+func InsertEvent(ctx context.Context, e *Event) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		state, err := getChainState(ctx)
+		if err != nil {
+			return err
+		}
+		isNew := state == nil
+		if isNew {
+			state = &ChainState{}
+		}
+
+		e.PrevHash = state.HeadHash
+		if e.CreatedUnix == 0 {
+			e.CreatedUnix = timeutil.TimeStampNow()
+		}
+		e.Hash = e.ComputeHash()
+		if err := db.Insert(ctx, e); err != nil {
+			return err
+		}
+
+		state.HeadID, state.HeadHash = e.ID, e.Hash
+		return saveChainState(ctx, state, isNew)
+	})
+}
+
+// FindEventsOptions represents the options to find audit events
+type FindEventsOptions struct {
+	db.ListOptions
+	ScopeType ScopeType
+	ScopeID   int64
+	ActorID   int64
+	// Action filters the events by the action or the prefix of the action, e.g. "repo.collaborator"
+	Action string
+	Since  timeutil.TimeStamp
+	Until  timeutil.TimeStamp
+}
+
+// ToConds implements db.FindOptions
+func (opts FindEventsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.ScopeType != "" {
+		cond = cond.And(builder.Eq{"scope_type": opts.ScopeType, "scope_id": opts.ScopeID})
+	}
+	if opts.ActorID != 0 {
+		cond = cond.And(builder.Eq{"actor_id": opts.ActorID})
+	}
+	if opts.Action != "" {
+		action := strings.TrimSuffix(opts.Action, ".")
+		cond = cond.And(builder.Or(builder.Eq{"action": action}, builder.Like{"action", action + ".%"}))
+	}
+	if opts.Since > 0 {
+		cond = cond.And(builder.Gte{"created_unix": opts.Since})
+	}
+	if opts.Until > 0 {
+		cond = cond.And(builder.Lte{"created_unix": opts.Until})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptions
+func (opts FindEventsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// IterateEvents calls f for all matching events from the oldest to the newest one
+func IterateEvents(ctx context.Context, opts FindEventsOptions, f func(e *Event) error) error {
+	var lastID int64
+	for {
+		events := make([]*Event, 0, 100)
+		if err := db.GetEngine(ctx).
+			Where(opts.ToConds().And(builder.Gt{"id": lastID})).
+			OrderBy("id ASC").
+			Limit(100).
+			Find(&events); err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := f(e); err != nil {
+				return err
+			}
+		}
+		if len(events) < 100 {
+			return nil
+		}
+		lastID = events[len(events)-1].ID
+	}
+}
+
+var errStopIteration = errors.New("stop iteration")
+
+// VerifyEvents checks the hash chain of all events.
+// It returns the ID of the first event which has been modified or whose previous event has been removed,
+// the ID of the newest recorded event if the newest events have been removed, or 0 if the chain is intact.
+func VerifyEvents(ctx context.Context) (int64, error) {
+	state, err := getChainState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	stateValid := state != nil && hmac.Equal([]byte(state.ComputeSignature()), []byte(state.Signature))
+	if !stateValid {
+		state = &ChainState{}
+	}
+
+	// every event has to refer to its predecessor, the oldest remaining one to the last event removed by the retention
+	var brokenID, firstID int64
+	lastID, lastHash := state.PurgedID, state.PurgedHash
+	err = IterateEvents(ctx, FindEventsOptions{}, func(e *Event) error {
+		if firstID == 0 {
+			firstID = e.ID
+		}
+		if e.PrevHash != lastHash || !hmac.Equal([]byte(e.ComputeHash()), []byte(e.Hash)) {
+			brokenID = e.ID
+			return errStopIteration
+		}
+		lastID, lastHash = e.ID, e.Hash
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopIteration) {
+		return 0, err
+	}
+
+	switch {
+	case !stateValid && firstID != 0:
+		// the chain state has been modified or removed, so none of the events can be trusted
+		return firstID, nil
+	case brokenID != 0:
+		return brokenID, nil
+	case lastID != state.HeadID || lastHash != state.HeadHash:
+		// the newest events have been removed
+		return max(state.HeadID, lastID), nil
+	}
+	return 0, nil
+}
+
+// DeleteOldEvents removes the events which are older than the given duration.
+// The chain state keeps the last removed event, the caller has to make sure no event is inserted concurrently.
+func DeleteOldEvents(ctx context.Context, olderThan time.Duration) error {
+	if olderThan <= 0 {
+		return nil
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		// the events are removed up to the newest old event, so the remaining ones still form a chain
+		last := new(Event)
+		has, err := db.GetEngine(ctx).Where("created_unix < ?", time.Now().Add(-olderThan).Unix()).Desc("id").Get(last)
+		if err != nil || !has {
+			return err
+		}
+		state, err := getChainState(ctx)
+		if err != nil {
+			return err
+		} else if state == nil {
+			return errors.New("the chain state of the audit events does not exist")
+		}
+
+		if _, err := db.GetEngine(ctx).Where("id <= ?", last.ID).Delete(new(Event)); err != nil {
+			return err
+		}
+		state.PurgedID, state.PurgedHash = last.ID, last.Hash
+		return saveChainState(ctx, state, false)
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func insertTestEvent(t *testing.T, action audit_model.Action, scopeID int64, created timeutil.TimeStamp) *audit_model.Event {
+	e := &audit_model.Event{
+		Action:      action,
+		ActorID:     1,
+		ActorName:   "user1",
+		ScopeType:   audit_model.ScopeRepository,
+		ScopeID:     scopeID,
+		TargetType:  "user",
+		TargetID:    2,
+		TargetName:  "user2",
+		After:       `{"access_mode":"write"}`,
+		CreatedUnix: created,
+	}
+	require.NoError(t, audit_model.InsertEvent(t.Context(), e))
+	return e
+}
+
+func TestInsertEvent(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	e1 := insertTestEvent(t, audit_model.ActionRepoCollaboratorAdd, 1, timeutil.TimeStampNow())
+	e2 := insertTestEvent(t, audit_model.ActionRepoCollaboratorRemove, 1, timeutil.TimeStampNow())
+
+	assert.Empty(t, e1.PrevHash)
+	assert.Equal(t, e1.Hash, e2.PrevHash)
+	assert.Equal(t, e2.ComputeHash(), e2.Hash)
+	assert.NotEqual(t, e1.Hash, e2.Hash)
+
+	brokenID, err := audit_model.VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.Zero(t, brokenID)
+}
+
+func TestVerifyEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	insertTestEvent(t, audit_model.ActionRepoCollaboratorAdd, 1, timeutil.TimeStampNow())
+	e2 := insertTestEvent(t, audit_model.ActionRepoCollaboratorUpdate, 1, timeutil.TimeStampNow())
+	e3 := insertTestEvent(t, audit_model.ActionRepoCollaboratorRemove, 1, timeutil.TimeStampNow())
+
+	// a modified event is detected
+	_, err := db.GetEngine(t.Context()).ID(e2.ID).Cols("actor_name").Update(&audit_model.Event{ActorName: "user5"})
+	require.NoError(t, err)
+	brokenID, err := audit_model.VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, e2.ID, brokenID)
+
+	// a removed event is detected by its successor
+	_, err = db.DeleteByID[audit_model.Event](t.Context(), e2.ID)
+	require.NoError(t, err)
+	brokenID, err = audit_model.VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, e3.ID, brokenID)
+}
+
+func TestVerifyEventsTruncated(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	e1 := insertTestEvent(t, audit_model.ActionRepoCollaboratorAdd, 1, timeutil.TimeStampNow())
+	e2 := insertTestEvent(t, audit_model.ActionRepoCollaboratorUpdate, 1, timeutil.TimeStampNow())
+	e3 := insertTestEvent(t, audit_model.ActionRepoCollaboratorRemove, 1, timeutil.TimeStampNow())
+
+	// removing the newest event is detected by the chain state
+	_, err := db.DeleteByID[audit_model.Event](t.Context(), e3.ID)
+	require.NoError(t, err)
+	brokenID, err := audit_model.VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, e3.ID, brokenID)
+
+	// removing the oldest event is detected because it hasn't been removed by the retention
+	require.NoError(t, db.Insert(t.Context(), e3))
+	_, err = db.DeleteByID[audit_model.Event](t.Context(), e1.ID)
+	require.NoError(t, err)
+	brokenID, err = audit_model.VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, e2.ID, brokenID)
+}
+
+func TestVerifyEventsRecomputed(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	e1 := insertTestEvent(t, audit_model.ActionRepoCollaboratorAdd, 1, timeutil.TimeStampNow())
+	insertTestEvent(t, audit_model.ActionRepoCollaboratorRemove, 1, timeutil.TimeStampNow())
+
+	// the hash of a modified event can't be recomputed without the key
+	e1.ActorName = "user5"
+	sum := sha256.New()
+	_, _ = sum.Write([]byte(e1.ActorName))
+	e1.Hash = hex.EncodeToString(sum.Sum(nil))
+	_, err := db.GetEngine(t.Context()).ID(e1.ID).Cols("actor_name", "hash").Update(e1)
+	require.NoError(t, err)
+	brokenID, err := audit_model.VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, e1.ID, brokenID)
+
+	// a modified chain state is detected
+	require.NoError(t, unittest.PrepareTestDatabase())
+	e1 = insertTestEvent(t, audit_model.ActionRepoCollaboratorAdd, 1, timeutil.TimeStampNow())
+	e2 := insertTestEvent(t, audit_model.ActionRepoCollaboratorRemove, 1, timeutil.TimeStampNow())
+	_, err = db.DeleteByID[audit_model.Event](t.Context(), e2.ID)
+	require.NoError(t, err)
+	_, err = db.GetEngine(t.Context()).Where("1=1").Cols("head_id", "head_hash").Update(&audit_model.ChainState{HeadID: e1.ID, HeadHash: e1.Hash})
+	require.NoError(t, err)
+	brokenID, err = audit_model.VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, e1.ID, brokenID)
+}
+
+func TestFindEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	insertTestEvent(t, audit_model.ActionRepoCollaboratorAdd, 1, 1000)
+	insertTestEvent(t, audit_model.ActionRepoDeployKeyAdd, 1, 2000)
+	insertTestEvent(t, audit_model.ActionRepoCollaboratorRemove, 2, 3000)
+
+	events, err := db.Find[audit_model.Event](t.Context(), audit_model.FindEventsOptions{ScopeType: audit_model.ScopeRepository, ScopeID: 1})
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	events, err = db.Find[audit_model.Event](t.Context(), audit_model.FindEventsOptions{Action: "repo.collaborator"})
+	require.NoError(t, err)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, audit_model.ActionRepoCollaboratorRemove, events[0].Action)
+		assert.Equal(t, audit_model.ActionRepoCollaboratorAdd, events[1].Action)
+	}
+
+	events, err = db.Find[audit_model.Event](t.Context(), audit_model.FindEventsOptions{Since: 1500, Until: 2500})
+	require.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, audit_model.ActionRepoDeployKeyAdd, events[0].Action)
+	}
+}
+
+func TestEventChanges(t *testing.T) {
+	e := &audit_model.Event{
+		Before: `{"access_mode":"read","name":"team1","units":{"repo.code":"read"}}`,
+		After:  `{"access_mode":"write","name":"team1","units":{"repo.code":"write"}}`,
+	}
+	assert.Equal(t, []*audit_model.FieldChange{
+		{Field: "access_mode", Before: "read", After: "write"},
+		{Field: "units", Before: `{"repo.code":"read"}`, After: `{"repo.code":"write"}`},
+	}, e.Changes())
+
+	e = &audit_model.Event{After: `{"name":"team1"}`}
+	assert.Equal(t, []*audit_model.FieldChange{{Field: "name", After: "team1"}}, e.Changes())
+}
+
+func TestDeleteOldEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	old := insertTestEvent(t, audit_model.ActionRepoCollaboratorAdd, 1, timeutil.TimeStamp(time.Now().Add(-48*time.Hour).Unix()))
+	recent := insertTestEvent(t, audit_model.ActionRepoCollaboratorRemove, 1, timeutil.TimeStampNow())
+
+	require.NoError(t, audit_model.DeleteOldEvents(t.Context(), 24*time.Hour))
+	unittest.AssertNotExistsBean(t, &audit_model.Event{ID: old.ID})
+	unittest.AssertExistsAndLoadBean(t, &audit_model.Event{ID: recent.ID})
+
+	// the remaining events are still valid because the chain state keeps the removed event
+	brokenID, err := audit_model.VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.Zero(t, brokenID)
+
+	// new events are appended to the remaining chain
+	newest := insertTestEvent(t, audit_model.ActionRepoCollaboratorAdd, 1, timeutil.TimeStampNow())
+	assert.Equal(t, recent.Hash, newest.PrevHash)
+	brokenID, err = audit_model.VerifyEvents(t.Context())
+	require.NoError(t, err)
+	assert.Zero(t, brokenID)
+}
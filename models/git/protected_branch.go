@@ -63,6 +63,8 @@ type ProtectedBranch struct {
 	ProtectedFilePatterns         string   `xorm:"TEXT"`
 	UnprotectedFilePatterns       string   `xorm:"TEXT"`
 	BlockAdminMergeOverride       bool     `xorm:"NOT NULL DEFAULT false"`
+	EnableMergeQueue              bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireCodeOwnerApprovals     bool     `xorm:"NOT NULL DEFAULT false"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
@@ -110,6 +112,12 @@ func (protectBranch *ProtectedBranch) Match(branchName string) bool {
 	return protectBranch.globRule.Match(branchName)
 }
 
+// IsMergeQueueEnabled returns whether the pull requests have to be merged through the merge queue,
+// it needs the status checks to test the merge group commits.
+func (protectBranch *ProtectedBranch) IsMergeQueueEnabled() bool {
+	return protectBranch.EnableMergeQueue && protectBranch.EnableStatusCheck
+}
+
 func (protectBranch *ProtectedBranch) LoadRepo(ctx context.Context) (err error) {
 	if protectBranch.Repo != nil {
 		return nil
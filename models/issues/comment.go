@@ -115,6 +115,9 @@ const (
 	CommentTypeUnpin // 37 unpin Issue/PullRequest
 
 	CommentTypeChangeTimeEstimate // 38 Change time estimate
+
+	CommentTypePRAddedToMergeQueue     // 39 pr was added to the merge queue of its base branch
+	CommentTypePRRemovedFromMergeQueue // 40 pr was removed from the merge queue of its base branch
 )
 
 var commentStrings = []string{
@@ -157,6 +160,8 @@ var commentStrings = []string{
 	"pin",
 	"unpin",
 	"change_time_estimate",
+	"pull_added_to_merge_queue",
+	"pull_removed_from_merge_queue",
 }
 
 func (t CommentType) String() string {
@@ -1168,6 +1173,27 @@ func CreateAutoMergeComment(ctx context.Context, typ CommentType, pr *PullReques
 	return comment, err
 }
 
+// CreateMergeQueueComment is a internal function, only use it for CommentTypePRAddedToMergeQueue and CommentTypePRRemovedFromMergeQueue CommentTypes
+func CreateMergeQueueComment(ctx context.Context, typ CommentType, pr *PullRequest, doer *user_model.User) (comment *Comment, err error) {
+	if typ != CommentTypePRAddedToMergeQueue && typ != CommentTypePRRemovedFromMergeQueue {
+		return nil, fmt.Errorf("comment type %d cannot be used to create a merge queue comment", typ)
+	}
+	if err = pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+
+	if err = pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	return CreateComment(ctx, &CreateCommentOptions{
+		Type:  typ,
+		Doer:  doer,
+		Repo:  pr.BaseRepo,
+		Issue: pr.Issue,
+	})
+}
+
 // RemapExternalUser ExternalUserRemappable interface
 func (c *Comment) RemapExternalUser(externalName string, externalID, userID int64) error {
 	c.OriginalAuthor = externalName
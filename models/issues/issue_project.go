@@ -125,6 +125,9 @@ func IssueAssignOrRemoveProject(ctx context.Context, issue *Issue, doer *user_mo
 		if _, err := db.GetEngine(ctx).Where("project_issue.issue_id=?", issue.ID).Delete(&project_model.ProjectIssue{}); err != nil {
 			return err
 		}
+		if err := project_model.DeleteFieldValuesOfIssue(ctx, issue.ID, newProjectID); err != nil {
+			return err
+		}
 
 		if oldProjectID > 0 || newProjectID > 0 {
 			if _, err := CreateComment(ctx, &CreateCommentOptions{
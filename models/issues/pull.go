@@ -9,7 +9,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 
 	"code.gitea.io/gitea/models/db"
@@ -635,6 +637,14 @@ func GetAllUnmergedAgitPullRequestByPoster(ctx context.Context, uid int64) ([]*P
 	return pulls, err
 }
 
+// CountMergedPullRequestsByPoster counts the pull requests of a poster which have been merged into a repository
+func CountMergedPullRequestsByPoster(ctx context.Context, repoID, posterID int64) (int64, error) {
+	return db.GetEngine(ctx).
+		Where("pull_request.base_repo_id=? AND pull_request.has_merged=? AND issue.poster_id=?", repoID, true, posterID).
+		Join("INNER", "issue", "issue.id=pull_request.issue_id").
+		Count(new(PullRequest))
+}
+
 // UpdateCols updates specific fields of pull request.
 func (pr *PullRequest) UpdateCols(ctx context.Context, cols ...string) error {
 	_, err := db.GetEngine(ctx).ID(pr.ID).Cols(cols...).Update(pr)
@@ -817,6 +827,112 @@ func MergeBlockedByOutdatedBranch(protectBranch *git_model.ProtectedBranch, pr *
 	return protectBranch.BlockOnOutdatedBranch && pr.CommitsBehind > 0
 }
 
+// CodeOwnerGroup is a set of code owners and the changed files they own,
+// the changes are approved if one of the code owners has approved them.
+type CodeOwnerGroup struct {
+	Users  []*user_model.User
+	Teams  []*org_model.Team
+	Owners []string // the names of the users and teams as they are written in CODEOWNERS
+	Files  []string
+}
+
+// GroupFilesByCodeOwners groups the files by the code owners of the rules which match them.
+// Files without any code owner are skipped.
+func GroupFilesByCodeOwners(ctx context.Context, rules []*CodeOwnerRule, files []string) ([]*CodeOwnerGroup, error) {
+	groups := make([]*CodeOwnerGroup, 0)
+	groupsByKey := make(map[string]*CodeOwnerGroup)
+	orgNames := make(map[int64]string)
+	for _, f := range files {
+		users := make(map[int64]*user_model.User)
+		teams := make(map[int64]*org_model.Team)
+		for _, rule := range rules {
+			if rule.Rule.MatchString(f) == rule.Negative {
+				continue
+			}
+			for _, u := range rule.Users {
+				users[u.ID] = u
+			}
+			for _, t := range rule.Teams {
+				teams[t.ID] = t
+			}
+		}
+		if len(users) == 0 && len(teams) == 0 {
+			continue
+		}
+
+		userIDs := slices.Sorted(maps.Keys(users))
+		teamIDs := slices.Sorted(maps.Keys(teams))
+		key := fmt.Sprintf("%v/%v", userIDs, teamIDs)
+		if group, ok := groupsByKey[key]; ok {
+			group.Files = append(group.Files, f)
+			continue
+		}
+
+		group := &CodeOwnerGroup{Files: []string{f}}
+		for _, id := range userIDs {
+			group.Users = append(group.Users, users[id])
+			group.Owners = append(group.Owners, "@"+users[id].Name)
+		}
+		for _, id := range teamIDs {
+			t := teams[id]
+			orgName, ok := orgNames[t.OrgID]
+			if !ok {
+				org, err := org_model.GetOrgByID(ctx, t.OrgID)
+				if err != nil {
+					return nil, err
+				}
+				orgName = org.Name
+				orgNames[t.OrgID] = orgName
+			}
+			group.Teams = append(group.Teams, t)
+			group.Owners = append(group.Owners, "@"+orgName+"/"+t.Name)
+		}
+		groupsByKey[key] = group
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// GetCodeOwnerGroupsWithoutApproval returns the groups in which no code owner has approved the pull request
+func GetCodeOwnerGroupsWithoutApproval(ctx context.Context, protectBranch *git_model.ProtectedBranch, pr *PullRequest, groups []*CodeOwnerGroup) ([]*CodeOwnerGroup, error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	sess := db.GetEngine(ctx).Table("review").Where("issue_id = ?", pr.IssueID).
+		And("type = ?", ReviewTypeApprove).
+		And("dismissed = ?", false)
+	if protectBranch.IgnoreStaleApprovals {
+		sess = sess.And("stale = ?", false)
+	}
+	approverIDs := make([]int64, 0)
+	if err := sess.Distinct("reviewer_id").Find(&approverIDs); err != nil {
+		return nil, err
+	}
+
+	missing := make([]*CodeOwnerGroup, 0)
+	for _, group := range groups {
+		approved := slices.ContainsFunc(group.Users, func(u *user_model.User) bool {
+			return slices.Contains(approverIDs, u.ID)
+		})
+		if !approved && len(group.Teams) > 0 && len(approverIDs) > 0 {
+			teamIDs := make([]int64, 0, len(group.Teams))
+			for _, t := range group.Teams {
+				teamIDs = append(teamIDs, t.ID)
+			}
+			count, err := org_model.UsersInTeamsCount(ctx, approverIDs, teamIDs)
+			if err != nil {
+				return nil, err
+			}
+			approved = count > 0
+		}
+		if !approved {
+			missing = append(missing, group)
+		}
+	}
+	return missing, nil
+}
+
 // GetCodeOwnersFromContent returns the code owners configuration
 // Return empty slice if files missing
 // Return warning messages on parsing errors
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
@@ -339,6 +340,41 @@ func TestParseCodeOwnersLine(t *testing.T) {
 	}
 }
 
+func TestCodeOwnerApprovals(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	rules, warnings := issues_model.GetCodeOwnersFromContent(t.Context(), `.*\\.go @user1
+docs/.* @user5 @org3/team1
+README.md @user5
+`)
+	require.Empty(t, warnings)
+	groups, err := issues_model.GroupFilesByCodeOwners(t.Context(), rules, []string{"a.go", "docs/index.md", "b.go", "README.md", "LICENSE"})
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+	assert.Equal(t, []string{"@user1"}, groups[0].Owners)
+	assert.Equal(t, []string{"a.go", "b.go"}, groups[0].Files)
+	assert.Equal(t, []string{"@user5", "@org3/team1"}, groups[1].Owners)
+	assert.Equal(t, []string{"docs/index.md"}, groups[1].Files)
+	assert.Equal(t, []string{"@user5"}, groups[2].Owners)
+	assert.Equal(t, []string{"README.md"}, groups[2].Files)
+
+	// user4 of org3/team1 has approved the pull request, but the approval is stale
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{IssueID: 3})
+	missing, err := issues_model.GetCodeOwnerGroupsWithoutApproval(t.Context(), &git_model.ProtectedBranch{}, pr, groups)
+	require.NoError(t, err)
+	assert.Equal(t, []*issues_model.CodeOwnerGroup{groups[0], groups[2]}, missing)
+
+	missing, err = issues_model.GetCodeOwnerGroupsWithoutApproval(t.Context(), &git_model.ProtectedBranch{IgnoreStaleApprovals: true}, pr, groups)
+	require.NoError(t, err)
+	assert.Equal(t, groups, missing)
+
+	// user1 has approved the pull request
+	pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{IssueID: 2})
+	missing, err = issues_model.GetCodeOwnerGroupsWithoutApproval(t.Context(), &git_model.ProtectedBranch{}, pr, groups)
+	require.NoError(t, err)
+	assert.Equal(t, []*issues_model.CodeOwnerGroup{groups[1], groups[2]}, missing)
+}
+
 func TestGetApprovers(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})
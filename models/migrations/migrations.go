@@ -399,6 +399,27 @@ func prepareMigrationTasks() []*migration {
 
 		newMigration(323, "Add support for actions concurrency", v1_26.AddActionsConcurrency),
 		newMigration(324, "Fix closed milestone completeness for milestones with no issues", v1_26.FixClosedMilestoneCompleteness),
+		newMigration(325, "Add merge queue", v1_26.AddMergeQueue),
+		newMigration(326, "Add reusable workflow columns to action_run_job", v1_26.AddReusableWorkflowColumnsToActionRunJob),
+		newMigration(327, "Add require code owner approvals to protected branch", v1_26.AddRequireCodeOwnerApprovalsToProtectedBranch),
+		newMigration(328, "Add audit event table", v1_26.AddAuditEventTable),
+		newMigration(329, "Add project column automation table", v1_26.AddProjectColumnAutomationTable),
+		newMigration(330, "Add project field tables", v1_26.AddProjectFieldTables),
+		newMigration(331, "Add project view table", v1_26.AddProjectViewTable),
+		newMigration(332, "Add repository scope to secrets and action variables", v1_26.AddRepoScopeToSecretAndActionVariable),
+		newMigration(333, "Add deployment environments for Actions", v1_26.AddActionsDeploymentEnvironments),
+		newMigration(334, "Add Actions cache", v1_26.AddActionsCache),
+		newMigration(335, "Add Actions approval policy", v1_26.AddActionsApprovalPolicy),
+		newMigration(336, "Add Actions task annotations and summaries", v1_26.AddActionsTaskAnnotationsAndSummaries),
+		newMigration(337, "Add raw matrix to action run job", v1_26.AddRawMatrixToActionRunJob),
+		newMigration(338, "Add runner groups for actions", v1_26.AddActionsRunnerGroup),
+		newMigration(339, "Add required workflows for actions", v1_26.AddActionsRequiredWorkflow),
+		newMigration(340, "Add package proxies", v1_26.AddPackageProxy),
+		newMigration(341, "Add Terraform states", v1_26.AddTerraformState),
+		newMigration(342, "Add package attestations", v1_26.AddPackageAttestation),
+		newMigration(343, "Add required workflow ID to action run", v1_26.AddRequiredWorkflowIDToActionRun),
+		newMigration(344, "Add selected environments to secrets and action variables", v1_26.AddSelectedEnvironmentsToSecretAndActionVariable),
+		newMigration(345, "Add audit chain state and key the audit event hashes", v1_26.AddAuditChainState),
 	}
 	return preparedMigrations
 }
@@ -0,0 +1,39 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddMergeQueue(x *xorm.Engine) error {
+	type ProtectedBranch struct {
+		EnableMergeQueue bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains: true,
+		IgnoreIndices:    true,
+	}, new(ProtectedBranch)); err != nil {
+		return err
+	}
+
+	type PullMergeQueue struct {
+		ID                     int64  `xorm:"pk autoincr"`
+		RepoID                 int64  `xorm:"INDEX(s) NOT NULL"`
+		BaseBranch             string `xorm:"INDEX(s) NOT NULL"`
+		PullID                 int64  `xorm:"UNIQUE NOT NULL"`
+		DoerID                 int64  `xorm:"INDEX NOT NULL"`
+		MergeStyle             string `xorm:"varchar(30)"`
+		Message                string `xorm:"LONGTEXT"`
+		DeleteBranchAfterMerge bool
+		ParentCommitID         string             `xorm:"VARCHAR(64)"`
+		MergeGroupCommitID     string             `xorm:"VARCHAR(64) INDEX"`
+		CreatedUnix            timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix            timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(PullMergeQueue))
+}
@@ -0,0 +1,19 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddReusableWorkflowColumnsToActionRunJob(x *xorm.Engine) error {
+	type ActionRunJob struct {
+		ParentJobID int64             `xorm:"index NOT NULL DEFAULT 0"`
+		CallOutputs map[string]string `xorm:"JSON LONGTEXT"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionRunJob))
+	return err
+}
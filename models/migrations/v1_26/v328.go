@@ -0,0 +1,33 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddAuditEventTable(x *xorm.Engine) error {
+	type AuditEvent struct {
+		ID          int64  `xorm:"pk autoincr"`
+		Action      string `xorm:"VARCHAR(255) INDEX NOT NULL"`
+		ActorID     int64  `xorm:"INDEX"`
+		ActorName   string `xorm:"VARCHAR(255)"`
+		ScopeType   string `xorm:"VARCHAR(20) INDEX(s) NOT NULL"`
+		ScopeID     int64  `xorm:"INDEX(s)"`
+		ScopeName   string `xorm:"VARCHAR(255)"`
+		TargetType  string `xorm:"VARCHAR(255)"`
+		TargetID    int64
+		TargetName  string             `xorm:"VARCHAR(255)"`
+		Before      string             `xorm:"LONGTEXT"`
+		After       string             `xorm:"LONGTEXT"`
+		IPAddress   string             `xorm:"VARCHAR(64)"`
+		PrevHash    string             `xorm:"VARCHAR(64)"`
+		Hash        string             `xorm:"VARCHAR(64) NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	}
+
+	return x.Sync(new(AuditEvent))
+}
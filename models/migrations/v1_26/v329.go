@@ -0,0 +1,23 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddProjectColumnAutomationTable(x *xorm.Engine) error {
+	type ProjectColumnAutomation struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ProjectID   int64              `xorm:"INDEX NOT NULL"`
+		ColumnID    int64              `xorm:"INDEX NOT NULL"`
+		Event       string             `xorm:"VARCHAR(50) NOT NULL"`
+		LabelID     int64              `xorm:"NOT NULL DEFAULT 0"`
+		AutoAdd     bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+	return x.Sync(new(ProjectColumnAutomation))
+}
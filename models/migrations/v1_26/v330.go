@@ -0,0 +1,37 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddProjectFieldTables(x *xorm.Engine) error {
+	type ProjectField struct {
+		ID         int64  `xorm:"pk autoincr"`
+		ProjectID  int64  `xorm:"INDEX NOT NULL"`
+		Name       string `xorm:"NOT NULL"`
+		Type       string `xorm:"VARCHAR(20) NOT NULL"`
+		Options    string `xorm:"TEXT"`
+		Iterations string `xorm:"TEXT"`
+		Sorting    int64  `xorm:"NOT NULL DEFAULT 0"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ProjectFieldValue struct {
+		ID        int64  `xorm:"pk autoincr"`
+		ProjectID int64  `xorm:"INDEX NOT NULL"`
+		FieldID   int64  `xorm:"UNIQUE(s) NOT NULL"`
+		IssueID   int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Value     string `xorm:"TEXT NOT NULL"`
+
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(ProjectField), new(ProjectFieldValue))
+}
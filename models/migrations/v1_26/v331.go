@@ -0,0 +1,26 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddProjectViewTable(x *xorm.Engine) error {
+	type ProjectView struct {
+		ID        int64  `xorm:"pk autoincr"`
+		ProjectID int64  `xorm:"INDEX NOT NULL"`
+		Name      string `xorm:"NOT NULL"`
+		Type      string `xorm:"VARCHAR(20) NOT NULL"`
+		Query     string `xorm:"TEXT"`
+		CreatorID int64  `xorm:"NOT NULL DEFAULT 0"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(ProjectView))
+}
@@ -0,0 +1,23 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddRepoScopeToSecretAndActionVariable(x *xorm.Engine) error {
+	type Secret struct {
+		Visibility      string  `xorm:"VARCHAR(20) NOT NULL DEFAULT 'all'"`
+		SelectedRepoIDs []int64 `xorm:"JSON TEXT"`
+	}
+	type ActionVariable struct {
+		Visibility      string  `xorm:"VARCHAR(20) NOT NULL DEFAULT 'all'"`
+		SelectedRepoIDs []int64 `xorm:"JSON TEXT"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(Secret), new(ActionVariable))
+	return err
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsDeploymentEnvironments(x *xorm.Engine) error {
+	type ActionEnvironment struct {
+		ID                  int64              `xorm:"pk autoincr"`
+		RepoID              int64              `xorm:"UNIQUE(repo_name) NOT NULL"`
+		Name                string             `xorm:"NOT NULL"`
+		LowerName           string             `xorm:"UNIQUE(repo_name) NOT NULL"`
+		RequiredReviewerIDs []int64            `xorm:"JSON TEXT"`
+		WaitTimer           int64              `xorm:"NOT NULL DEFAULT 0"`
+		BranchFilters       []string           `xorm:"JSON TEXT"`
+		CreatedUnix         timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix         timeutil.TimeStamp `xorm:"updated"`
+	}
+	type ActionDeployment struct {
+		ID            int64  `xorm:"pk autoincr"`
+		RepoID        int64  `xorm:"INDEX NOT NULL"`
+		EnvironmentID int64  `xorm:"INDEX NOT NULL"`
+		RunID         int64  `xorm:"INDEX NOT NULL"`
+		RunJobID      int64  `xorm:"INDEX NOT NULL"`
+		Ref           string `xorm:"VARCHAR(255)"`
+		CommitSHA     string `xorm:"VARCHAR(255)"`
+		TriggerUserID int64
+		State         string `xorm:"VARCHAR(20) INDEX NOT NULL"`
+		ReviewerID    int64
+		ReviewComment string `xorm:"TEXT"`
+		ReviewedUnix  timeutil.TimeStamp
+		WaitUntil     timeutil.TimeStamp
+		CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	}
+	type ActionRunJob struct {
+		RawEnvironment string `xorm:"TEXT"`
+		Environment    string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	}
+	// the environment is added to the unique index of the names
+	type Secret struct {
+		OwnerID       int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+		EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	}
+	type ActionVariable struct {
+		OwnerID       int64  `xorm:"UNIQUE(owner_repo_name)"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name)"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+		EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionEnvironment), new(ActionDeployment), new(ActionRunJob), new(Secret), new(ActionVariable))
+	return err
+}
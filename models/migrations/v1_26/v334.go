@@ -0,0 +1,27 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsCache(x *xorm.Engine) error {
+	type ActionCache struct {
+		ID          int64  `xorm:"pk autoincr"`
+		RepoID      int64  `xorm:"INDEX NOT NULL"`
+		Ref         string `xorm:"VARCHAR(255) NOT NULL"`
+		Key         string `xorm:"'cache_key' VARCHAR(512) NOT NULL"`
+		Version     string `xorm:"VARCHAR(255) NOT NULL"`
+		RunID       int64
+		Size        int64
+		StoragePath string             `xorm:"VARCHAR(255)"`
+		Complete    bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UsedUnix    timeutil.TimeStamp `xorm:"INDEX"`
+	}
+	return x.Sync(new(ActionCache))
+}
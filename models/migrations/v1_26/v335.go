@@ -0,0 +1,24 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsApprovalPolicy(x *xorm.Engine) error {
+	type ActionApprovalPolicy struct {
+		ID                      int64              `xorm:"pk autoincr"`
+		OwnerID                 int64              `xorm:"UNIQUE(owner_repo) NOT NULL DEFAULT 0"`
+		RepoID                  int64              `xorm:"UNIQUE(owner_repo) NOT NULL DEFAULT 0"`
+		TrustMergedPullRequests int64              `xorm:"NOT NULL DEFAULT 0"`
+		TrustedTeamIDs          []int64            `xorm:"JSON TEXT"`
+		ApproveWorkflowChanges  bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix             timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix             timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(ActionApprovalPolicy))
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsTaskAnnotationsAndSummaries(x *xorm.Engine) error {
+	type ActionTaskAnnotation struct {
+		ID          int64
+		TaskID      int64  `xorm:"INDEX NOT NULL"`
+		RepoID      int64  `xorm:"INDEX(repo_commit) NOT NULL"`
+		CommitSHA   string `xorm:"VARCHAR(64) INDEX(repo_commit)"`
+		Level       int    `xorm:"NOT NULL"`
+		Title       string `xorm:"VARCHAR(255)"`
+		Message     string `xorm:"TEXT"`
+		Path        string `xorm:"TEXT"`
+		StartLine   int
+		EndLine     int
+		StartColumn int
+		EndColumn   int
+		LogIndex    int64
+		Created     timeutil.TimeStamp `xorm:"created"`
+	}
+	type ActionTaskSummary struct {
+		ID        int64
+		TaskID    int64              `xorm:"UNIQUE(task_step) NOT NULL"`
+		StepIndex int64              `xorm:"UNIQUE(task_step) NOT NULL"`
+		RepoID    int64              `xorm:"INDEX NOT NULL"`
+		Content   string             `xorm:"MEDIUMTEXT"`
+		Created   timeutil.TimeStamp `xorm:"created"`
+		Updated   timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(ActionTaskAnnotation), new(ActionTaskSummary))
+}
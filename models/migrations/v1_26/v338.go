@@ -0,0 +1,35 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsRunnerGroup(x *xorm.Engine) error {
+	type ActionRunnerGroup struct {
+		ID            int64              `xorm:"pk autoincr"`
+		OwnerID       int64              `xorm:"UNIQUE(owner_name) NOT NULL DEFAULT 0"`
+		Name          string             `xorm:"NOT NULL"`
+		LowerName     string             `xorm:"UNIQUE(owner_name) NOT NULL"`
+		AllRepos      bool               `xorm:"NOT NULL DEFAULT false"`
+		RepoIDs       []int64            `xorm:"JSON TEXT"`
+		Workflows     []string           `xorm:"JSON TEXT"`
+		BranchFilters []string           `xorm:"JSON TEXT"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	}
+	type ActionRunner struct {
+		GroupID int64 `xorm:"index NOT NULL DEFAULT 0"`
+	}
+	if err := x.Sync(new(ActionRunnerGroup)); err != nil {
+		return err
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionRunner))
+	return err
+}
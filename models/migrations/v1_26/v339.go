@@ -0,0 +1,24 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsRequiredWorkflow(x *xorm.Engine) error {
+	type ActionRequiredWorkflow struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"UNIQUE(owner_repo_workflow) NOT NULL"`
+		RepoID      int64              `xorm:"UNIQUE(owner_repo_workflow) NOT NULL"`
+		WorkflowID  string             `xorm:"UNIQUE(owner_repo_workflow) NOT NULL"`
+		AllRepos    bool               `xorm:"NOT NULL DEFAULT false"`
+		RepoIDs     []int64            `xorm:"JSON TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(ActionRequiredWorkflow))
+}
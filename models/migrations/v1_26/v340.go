@@ -0,0 +1,27 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageProxy(x *xorm.Engine) error {
+	type PackageProxy struct {
+		ID                 int64              `xorm:"pk autoincr"`
+		Enabled            bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		OwnerID            int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		Type               string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		URL                string             `xorm:"TEXT NOT NULL"`
+		Username           string             `xorm:"NOT NULL DEFAULT ''"`
+		PasswordEncrypted  string             `xorm:"TEXT"`
+		MetadataTTLMinutes int                `xorm:"NOT NULL DEFAULT 0"`
+		CacheDays          int                `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix        timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix        timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(PackageProxy))
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type terraformState struct {
+	ID            int64              `xorm:"pk autoincr"`
+	RepoID        int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Name          string             `xorm:"UNIQUE(s) NOT NULL"`
+	LatestVersion int64              `xorm:"NOT NULL DEFAULT 0"`
+	LockID        string             `xorm:"NOT NULL DEFAULT ''"`
+	LockInfo      string             `xorm:"TEXT"`
+	LockerID      int64              `xorm:"NOT NULL DEFAULT 0"`
+	LockedUnix    timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated NOT NULL"`
+}
+
+func (terraformState) TableName() string {
+	return "terraform_state"
+}
+
+type terraformStateVersion struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"INDEX NOT NULL"`
+	StateID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Version     int64              `xorm:"UNIQUE(s) NOT NULL"`
+	Serial      int64              `xorm:"NOT NULL DEFAULT 0"`
+	Lineage     string             `xorm:"NOT NULL DEFAULT ''"`
+	Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+func (terraformStateVersion) TableName() string {
+	return "terraform_state_version"
+}
+
+func AddTerraformState(x *xorm.Engine) error {
+	return x.Sync(new(terraformState), new(terraformStateVersion))
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type packageAttestation struct {
+	ID            int64              `xorm:"pk autoincr"`
+	OwnerID       int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Format        string             `xorm:"NOT NULL"`
+	PredicateType string             `xorm:"NOT NULL DEFAULT ''"`
+	Content       string             `xorm:"LONGTEXT NOT NULL"`
+	ContentSHA256 string             `xorm:"content_sha256 UNIQUE(s) CHAR(64) NOT NULL"`
+	CreatorID     int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+func (packageAttestation) TableName() string {
+	return "package_attestation"
+}
+
+type packageAttestationSubject struct {
+	ID            int64  `xorm:"pk autoincr"`
+	AttestationID int64  `xorm:"INDEX NOT NULL"`
+	OwnerID       int64  `xorm:"INDEX NOT NULL"`
+	SHA256        string `xorm:"sha256 INDEX CHAR(64) NOT NULL"`
+}
+
+func (packageAttestationSubject) TableName() string {
+	return "package_attestation_subject"
+}
+
+func AddPackageAttestation(x *xorm.Engine) error {
+	return x.Sync(new(packageAttestation), new(packageAttestationSubject))
+}
@@ -0,0 +1,15 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddRequiredWorkflowIDToActionRun(x *xorm.Engine) error {
+	type ActionRun struct {
+		RequiredWorkflowID int64 `xorm:"NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(ActionRun))
+}
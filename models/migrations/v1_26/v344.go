@@ -0,0 +1,21 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddSelectedEnvironmentsToSecretAndActionVariable(x *xorm.Engine) error {
+	type Secret struct {
+		SelectedEnvironments []string `xorm:"JSON TEXT"`
+	}
+	type ActionVariable struct {
+		SelectedEnvironments []string `xorm:"JSON TEXT"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(Secret), new(ActionVariable))
+	return err
+}
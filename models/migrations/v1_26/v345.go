@@ -0,0 +1,100 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"code.gitea.io/gitea/modules/setting"
+
+	"xorm.io/xorm"
+)
+
+// AddAuditChainState keys the hashes of the audit events with the SECRET_KEY and adds the chain state,
+// which keeps the newest event and the last event removed by the retention
+func AddAuditChainState(x *xorm.Engine) error {
+	type AuditEvent struct {
+		ID          int64 `xorm:"pk autoincr"`
+		Action      string
+		ActorID     int64
+		ActorName   string
+		ScopeType   string
+		ScopeID     int64
+		ScopeName   string
+		TargetType  string
+		TargetID    int64
+		TargetName  string
+		Before      string
+		After       string
+		IPAddress   string
+		PrevHash    string
+		Hash        string
+		CreatedUnix int64
+	}
+	type AuditChainState struct {
+		ID         int64  `xorm:"pk"`
+		HeadID     int64  `xorm:"NOT NULL DEFAULT 0"`
+		HeadHash   string `xorm:"VARCHAR(64)"`
+		PurgedID   int64  `xorm:"NOT NULL DEFAULT 0"`
+		PurgedHash string `xorm:"VARCHAR(64)"`
+		Signature  string `xorm:"VARCHAR(64) NOT NULL"`
+	}
+	if err := x.Sync(new(AuditChainState)); err != nil {
+		return err
+	}
+
+	mac := func(fields ...string) string {
+		h := hmac.New(sha256.New, []byte(setting.SecretKey))
+		for _, s := range fields {
+			_, _ = fmt.Fprintf(h, "%d:%s", len(s), s)
+		}
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	// rehash the existing events with the keyed hash, the oldest remaining one is the start of the new chain
+	state := &AuditChainState{ID: 1}
+	for {
+		events := make([]*AuditEvent, 0, 100)
+		if err := x.Where("id > ?", state.HeadID).OrderBy("id ASC").Limit(100).Find(&events); err != nil {
+			return err
+		}
+		for _, e := range events {
+			e.PrevHash = state.HeadHash
+			e.Hash = mac(
+				e.PrevHash,
+				e.Action,
+				strconv.FormatInt(e.ActorID, 10),
+				e.ActorName,
+				e.ScopeType,
+				strconv.FormatInt(e.ScopeID, 10),
+				e.ScopeName,
+				e.TargetType,
+				strconv.FormatInt(e.TargetID, 10),
+				e.TargetName,
+				e.Before,
+				e.After,
+				e.IPAddress,
+				strconv.FormatInt(e.CreatedUnix, 10),
+			)
+			if _, err := x.ID(e.ID).Cols("prev_hash", "hash").Update(e); err != nil {
+				return err
+			}
+			state.HeadID, state.HeadHash = e.ID, e.Hash
+		}
+		if len(events) < 100 {
+			break
+		}
+	}
+
+	if state.HeadID == 0 {
+		return nil
+	}
+	state.Signature = mac(strconv.FormatInt(state.HeadID, 10), state.HeadHash, strconv.FormatInt(state.PurgedID, 10), state.PurgedHash)
+	_, err := x.Insert(state)
+	return err
+}
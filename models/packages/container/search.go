@@ -118,6 +118,68 @@ func GetManifestVersions(ctx context.Context, opts *BlobSearchOptions) ([]*packa
 		Find(&pvs)
 }
 
+// GetReferrers gets the manifests of an image which refer to the subject manifest
+func GetReferrers(ctx context.Context, ownerID int64, image, subject string) ([]*packages.PackageFileDescriptor, error) {
+	cond := (&BlobSearchOptions{
+		OwnerID:    ownerID,
+		Image:      image,
+		IsManifest: true,
+		OnlyLead:   true,
+	}).toConds().And(builder.Eq{"package_version.is_internal": false})
+
+	var propsCond builder.Cond = builder.Eq{
+		"package_property.ref_type": packages.PropertyTypeVersion,
+		"package_property.name":     container_module.PropertyManifestSubject,
+		"package_property.value":    subject,
+	}
+
+	cond = cond.And(builder.In("package_version.id", builder.Select("package_property.ref_id").Where(propsCond).From("package_property")))
+
+	pfs := make([]*packages.PackageFile, 0, 10)
+	if err := db.GetEngine(ctx).
+		Join("INNER", "package_version", "package_version.id = package_file.version_id").
+		Join("INNER", "package", "package.id = package_version.package_id").
+		Where(cond).
+		Asc("package_file.id").
+		Find(&pfs); err != nil {
+		return nil, err
+	}
+
+	return packages.GetPackageFileDescriptors(ctx, pfs)
+}
+
+// FindOrphanedReferrers gets all versions older than specified which refer to a manifest which does not exist anymore
+func FindOrphanedReferrers(ctx context.Context, olderThan time.Duration) ([]*packages.PackageVersion, error) {
+	var cond builder.Cond = builder.Eq{
+		"package.type":                packages.TypeContainer,
+		"package_version.is_internal": false,
+		"package_property.ref_type":   packages.PropertyTypeVersion,
+		"package_property.name":       container_module.PropertyManifestSubject,
+	}
+	cond = cond.And(builder.Lt{"package_version.created_unix": time.Now().Add(-olderThan).Unix()})
+
+	cond = cond.And(builder.NotExists(
+		builder.
+			Select("package_file.id").
+			From("package_file").
+			Join("INNER", "package_version subject_version", "subject_version.id = package_file.version_id").
+			Join("INNER", "package_property digest_property", "digest_property.ref_id = package_file.id").
+			Where(builder.Eq{
+				"package_file.lower_name":  container_module.ManifestFilename,
+				"digest_property.ref_type": packages.PropertyTypeFile,
+				"digest_property.name":     container_module.PropertyDigest,
+			}.And(builder.Expr("subject_version.package_id = package_version.package_id")).
+				And(builder.Expr("digest_property.value = package_property.value"))),
+	))
+
+	pvs := make([]*packages.PackageVersion, 0, 10)
+	return pvs, db.GetEngine(ctx).
+		Join("INNER", "package", "package.id = package_version.package_id").
+		Join("INNER", "package_property", "package_property.ref_id = package_version.id").
+		Where(cond).
+		Find(&pvs)
+}
+
 // GetImageTags gets a sorted list of the tags of an image
 // The result is suitable for the api call.
 func GetImageTags(ctx context.Context, ownerID int64, image string, n int, last string) ([]string, error) {
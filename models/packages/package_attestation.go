@@ -0,0 +1,152 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+var (
+	ErrPackageAttestationNotExist  = util.NewNotExistErrorf("package attestation does not exist")
+	ErrDuplicatePackageAttestation = util.NewAlreadyExistErrorf("package attestation already exists")
+)
+
+func init() {
+	db.RegisterModel(new(PackageAttestation))
+	db.RegisterModel(new(PackageAttestationSubject))
+}
+
+// PackageAttestation represents a signature or provenance attestation of package files of an owner
+type PackageAttestation struct {
+	ID            int64              `xorm:"pk autoincr"`
+	OwnerID       int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Format        string             `xorm:"NOT NULL"`
+	PredicateType string             `xorm:"NOT NULL DEFAULT ''"`
+	Content       string             `xorm:"LONGTEXT NOT NULL"`
+	ContentSHA256 string             `xorm:"content_sha256 UNIQUE(s) CHAR(64) NOT NULL"`
+	CreatorID     int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// PackageAttestationSubject links an attestation to the SHA256 digest of a file it covers
+type PackageAttestationSubject struct {
+	ID            int64  `xorm:"pk autoincr"`
+	AttestationID int64  `xorm:"INDEX NOT NULL"`
+	OwnerID       int64  `xorm:"INDEX NOT NULL"`
+	SHA256        string `xorm:"sha256 INDEX CHAR(64) NOT NULL"`
+}
+
+// TryInsertAttestation inserts the attestation and its subjects if the owner has no attestation with the same content
+func TryInsertAttestation(ctx context.Context, pa *PackageAttestation, subjects []string) (*PackageAttestation, error) {
+	existing := &PackageAttestation{}
+	has, err := db.GetEngine(ctx).Where(builder.Eq{
+		"owner_id":       pa.OwnerID,
+		"content_sha256": pa.ContentSHA256,
+	}).Get(existing)
+	if err != nil {
+		return nil, err
+	}
+	if has {
+		return existing, ErrDuplicatePackageAttestation
+	}
+
+	return pa, db.WithTx(ctx, func(ctx context.Context) error {
+		if err := db.Insert(ctx, pa); err != nil {
+			return err
+		}
+		for _, sha256 := range subjects {
+			if err := db.Insert(ctx, &PackageAttestationSubject{
+				AttestationID: pa.ID,
+				OwnerID:       pa.OwnerID,
+				SHA256:        sha256,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetAttestationByID gets an attestation of the owner
+func GetAttestationByID(ctx context.Context, ownerID, attestationID int64) (*PackageAttestation, error) {
+	pa := &PackageAttestation{}
+
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": attestationID, "owner_id": ownerID}).Get(pa)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageAttestationNotExist
+	}
+	return pa, nil
+}
+
+// GetAttestationSubjects returns the SHA256 digests covered by the attestation
+func GetAttestationSubjects(ctx context.Context, attestationID int64) ([]string, error) {
+	subjects := make([]string, 0, 1)
+	return subjects, db.GetEngine(ctx).
+		Table("package_attestation_subject").
+		Where("attestation_id = ?", attestationID).
+		OrderBy("id").
+		Cols("sha256").
+		Find(&subjects)
+}
+
+// GetAttestationsBySubjects returns the attestations of the owner which cover at least one of the digests
+func GetAttestationsBySubjects(ctx context.Context, ownerID int64, sha256s []string) ([]*PackageAttestation, error) {
+	pas := make([]*PackageAttestation, 0, len(sha256s))
+	if len(sha256s) == 0 {
+		return pas, nil
+	}
+	return pas, db.GetEngine(ctx).
+		Where(builder.Eq{"owner_id": ownerID}).
+		And(builder.In("id", builder.Select("attestation_id").From("package_attestation_subject").Where(
+			builder.Eq{"owner_id": ownerID}.And(builder.In("sha256", sha256s)),
+		))).
+		OrderBy("id").
+		Find(&pas)
+}
+
+// FindUnreferencedAttestations returns the attestations older than the duration whose subjects don't match any package file of the owner
+func FindUnreferencedAttestations(ctx context.Context, olderThan time.Duration) ([]*PackageAttestation, error) {
+	referenced := builder.Select("package_attestation_subject.attestation_id").
+		From("package_attestation_subject").
+		InnerJoin("package_blob", "package_blob.hash_sha256 = package_attestation_subject.sha256").
+		InnerJoin("package_file", "package_file.blob_id = package_blob.id").
+		InnerJoin("package_version", "package_version.id = package_file.version_id").
+		InnerJoin("package", "package.id = package_version.package_id AND package.owner_id = package_attestation_subject.owner_id")
+
+	pas := make([]*PackageAttestation, 0, 10)
+	return pas, db.GetEngine(ctx).
+		Where(builder.Lt{"created_unix": time.Now().Add(-olderThan).Unix()}).
+		And(builder.NotIn("id", referenced)).
+		Find(&pas)
+}
+
+// DeleteAttestationByID deletes an attestation and its subjects
+func DeleteAttestationByID(ctx context.Context, attestationID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("attestation_id = ?", attestationID).Delete(&PackageAttestationSubject{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(attestationID).Delete(&PackageAttestation{})
+		return err
+	})
+}
+
+// DeleteAttestationsByOwnerID deletes all attestations of the owner
+func DeleteAttestationsByOwnerID(ctx context.Context, ownerID int64) error {
+	if _, err := db.GetEngine(ctx).Where("owner_id = ?", ownerID).Delete(&PackageAttestationSubject{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("owner_id = ?", ownerID).Delete(&PackageAttestation{})
+	return err
+}
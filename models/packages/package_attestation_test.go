@@ -0,0 +1,106 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages_test
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAttestation(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	const (
+		referencedSHA256   = "1111111111111111111111111111111111111111111111111111111111111111"
+		unreferencedSHA256 = "2222222222222222222222222222222222222222222222222222222222222222"
+	)
+
+	p, err := packages_model.TryInsertPackage(t.Context(), &packages_model.Package{
+		OwnerID:   2,
+		Type:      packages_model.TypeGeneric,
+		Name:      "test",
+		LowerName: "test",
+	})
+	require.NoError(t, err)
+	pv, err := packages_model.GetOrInsertVersion(t.Context(), &packages_model.PackageVersion{
+		PackageID:    p.ID,
+		Version:      "1.0",
+		LowerVersion: "1.0",
+	})
+	require.NoError(t, err)
+	pb, _, err := packages_model.GetOrInsertBlob(t.Context(), &packages_model.PackageBlob{
+		HashMD5:    "1",
+		HashSHA1:   "1",
+		HashSHA256: referencedSHA256,
+		HashSHA512: "1",
+	})
+	require.NoError(t, err)
+	_, err = packages_model.TryInsertFile(t.Context(), &packages_model.PackageFile{
+		VersionID: pv.ID,
+		BlobID:    pb.ID,
+		Name:      "file.bin",
+		LowerName: "file.bin",
+	})
+	require.NoError(t, err)
+
+	pa1, err := packages_model.TryInsertAttestation(t.Context(), &packages_model.PackageAttestation{
+		OwnerID:       2,
+		Format:        "signature",
+		Content:       "a",
+		ContentSHA256: "a",
+	}, []string{referencedSHA256})
+	require.NoError(t, err)
+
+	_, err = packages_model.TryInsertAttestation(t.Context(), &packages_model.PackageAttestation{
+		OwnerID:       2,
+		Format:        "signature",
+		Content:       "a",
+		ContentSHA256: "a",
+	}, []string{referencedSHA256})
+	assert.ErrorIs(t, err, packages_model.ErrDuplicatePackageAttestation)
+
+	pa2, err := packages_model.TryInsertAttestation(t.Context(), &packages_model.PackageAttestation{
+		OwnerID:       2,
+		Format:        "signature",
+		Content:       "b",
+		ContentSHA256: "b",
+	}, []string{unreferencedSHA256})
+	require.NoError(t, err)
+
+	subjects, err := packages_model.GetAttestationSubjects(t.Context(), pa1.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{referencedSHA256}, subjects)
+
+	pas, err := packages_model.GetAttestationsBySubjects(t.Context(), 2, []string{referencedSHA256})
+	assert.NoError(t, err)
+	assert.Len(t, pas, 1)
+	assert.Equal(t, pa1.ID, pas[0].ID)
+
+	pas, err = packages_model.GetAttestationsBySubjects(t.Context(), 1, []string{referencedSHA256})
+	assert.NoError(t, err)
+	assert.Empty(t, pas)
+
+	_, err = db.GetEngine(t.Context()).Exec("UPDATE package_attestation SET created_unix = ?", 1)
+	require.NoError(t, err)
+
+	pas, err = packages_model.FindUnreferencedAttestations(t.Context(), time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, pas, 1)
+	assert.Equal(t, pa2.ID, pas[0].ID)
+
+	assert.NoError(t, packages_model.DeleteAttestationByID(t.Context(), pa2.ID))
+	_, err = packages_model.GetAttestationByID(t.Context(), 2, pa2.ID)
+	assert.ErrorIs(t, err, packages_model.ErrPackageAttestationNotExist)
+	unittest.AssertNotExistsBean(t, &packages_model.PackageAttestationSubject{AttestationID: pa2.ID})
+
+	assert.NoError(t, packages_model.DeleteAttestationsByOwnerID(t.Context(), 2))
+	unittest.AssertNotExistsBean(t, &packages_model.PackageAttestation{OwnerID: 2})
+}
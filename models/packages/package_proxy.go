@@ -0,0 +1,160 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/secret"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrPackageProxyNotExist = util.NewNotExistErrorf("package proxy does not exist")
+
+// PropertyProxyUpstream is the name of the version property which contains the upstream registry of a version fetched by a proxy
+const PropertyProxyUpstream = "packages.proxy.upstream"
+
+// ProxyTypeList contains the package types which could fetch the missing packages from an upstream registry
+var ProxyTypeList = []Type{
+	TypeContainer,
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+func init() {
+	db.RegisterModel(new(PackageProxy))
+}
+
+// PackageProxy represents the upstream registry of a package type of an owner.
+// The packages which don't exist are fetched from the upstream and stored as normal package versions.
+type PackageProxy struct {
+	ID                 int64              `xorm:"pk autoincr"`
+	Enabled            bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	OwnerID            int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type               Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	URL                string             `xorm:"TEXT NOT NULL"`
+	Username           string             `xorm:"NOT NULL DEFAULT ''"`
+	PasswordEncrypted  string             `xorm:"TEXT"`
+	MetadataTTLMinutes int                `xorm:"NOT NULL DEFAULT 0"` // how long the metadata fetched from the upstream is used before it's refreshed
+	CacheDays          int                `xorm:"NOT NULL DEFAULT 0"` // the fetched versions are removed after these days, 0 keeps them
+	CreatedUnix        timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix        timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// IsProxyType returns whether the packages of the type could be fetched from an upstream registry
+func IsProxyType(pt Type) bool {
+	return slices.Contains(ProxyTypeList, pt)
+}
+
+// Password returns the decrypted password of the upstream registry
+func (pp *PackageProxy) Password() (string, error) {
+	if pp.PasswordEncrypted == "" {
+		return "", nil
+	}
+	return secret.DecryptSecret(setting.SecretKey, pp.PasswordEncrypted)
+}
+
+// SetPassword encrypts and sets the password of the upstream registry
+func (pp *PackageProxy) SetPassword(password string) error {
+	if password == "" {
+		pp.PasswordEncrypted = ""
+		return nil
+	}
+	encrypted, err := secret.EncryptSecret(setting.SecretKey, password)
+	if err != nil {
+		return err
+	}
+	pp.PasswordEncrypted = encrypted
+	return nil
+}
+
+func InsertProxy(ctx context.Context, pp *PackageProxy) (*PackageProxy, error) {
+	return pp, db.Insert(ctx, pp)
+}
+
+func GetProxyByID(ctx context.Context, id int64) (*PackageProxy, error) {
+	pp := &PackageProxy{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(pp)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageProxyNotExist
+	}
+	return pp, nil
+}
+
+// GetEnabledProxyByOwnerAndType returns the enabled proxy of the package type of the owner
+func GetEnabledProxyByOwnerAndType(ctx context.Context, ownerID int64, packageType Type) (*PackageProxy, error) {
+	pp := &PackageProxy{}
+
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID, "type": packageType, "enabled": true}).Get(pp)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageProxyNotExist
+	}
+	return pp, nil
+}
+
+func UpdateProxy(ctx context.Context, pp *PackageProxy) error {
+	_, err := db.GetEngine(ctx).ID(pp.ID).AllCols().Update(pp)
+	return err
+}
+
+func GetProxiesByOwner(ctx context.Context, ownerID int64) ([]*PackageProxy, error) {
+	pps := make([]*PackageProxy, 0, len(ProxyTypeList))
+	return pps, db.GetEngine(ctx).Where("owner_id = ?", ownerID).Find(&pps)
+}
+
+func DeleteProxyByID(ctx context.Context, proxyID int64) error {
+	_, err := db.GetEngine(ctx).ID(proxyID).Delete(&PackageProxy{})
+	return err
+}
+
+func HasOwnerProxyForPackageType(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Exist(&PackageProxy{})
+}
+
+// IterateProxiesWithCacheExpiration iterates the proxies whose fetched versions are removed after some days
+func IterateProxiesWithCacheExpiration(ctx context.Context, callback func(context.Context, *PackageProxy) error) error {
+	return db.Iterate(
+		ctx,
+		builder.Gt{"cache_days": 0},
+		callback,
+	)
+}
+
+// FindExpiredProxiedVersions returns the versions of the package type of the owner which were fetched by a proxy before the time
+func FindExpiredProxiedVersions(ctx context.Context, ownerID int64, packageType Type, olderThan timeutil.TimeStamp) ([]*PackageVersion, error) {
+	cond := builder.Eq{
+		"package.owner_id":            ownerID,
+		"package.type":                packageType,
+		"package_version.is_internal": false,
+	}.
+		And(builder.Lt{"package_version.created_unix": olderThan}).
+		And(builder.In("package_version.id", builder.Select("ref_id").From("package_property").Where(builder.Eq{
+			"ref_type": PropertyTypeVersion,
+			"name":     PropertyProxyUpstream,
+		})))
+
+	pvs := make([]*PackageVersion, 0, 10)
+	return pvs, db.GetEngine(ctx).
+		Select("package_version.*").
+		Table("package_version").
+		Join("INNER", "package", "package.id = package_version.package_id").
+		Where(cond).
+		Find(&pvs)
+}
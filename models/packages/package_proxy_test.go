@@ -0,0 +1,55 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages_test
+
+import (
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageProxy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pp := &packages_model.PackageProxy{
+		OwnerID: 2,
+		Type:    packages_model.TypeNpm,
+		URL:     "https://registry.npmjs.org",
+	}
+	require.NoError(t, pp.SetPassword("secret"))
+	assert.NotEqual(t, "secret", pp.PasswordEncrypted)
+	_, err := packages_model.InsertProxy(t.Context(), pp)
+	require.NoError(t, err)
+
+	_, err = packages_model.GetEnabledProxyByOwnerAndType(t.Context(), 2, packages_model.TypeNpm)
+	assert.ErrorIs(t, err, packages_model.ErrPackageProxyNotExist)
+
+	pp.Enabled = true
+	require.NoError(t, packages_model.UpdateProxy(t.Context(), pp))
+
+	pp, err = packages_model.GetEnabledProxyByOwnerAndType(t.Context(), 2, packages_model.TypeNpm)
+	require.NoError(t, err)
+	password, err := pp.Password()
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", password)
+
+	has, err := packages_model.HasOwnerProxyForPackageType(t.Context(), 2, packages_model.TypeNpm)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	has, err = packages_model.HasOwnerProxyForPackageType(t.Context(), 2, packages_model.TypeMaven)
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	assert.True(t, packages_model.IsProxyType(packages_model.TypeContainer))
+	assert.False(t, packages_model.IsProxyType(packages_model.TypeGeneric))
+
+	require.NoError(t, packages_model.DeleteProxyByID(t.Context(), pp.ID))
+	pps, err := packages_model.GetProxiesByOwner(t.Context(), 2)
+	assert.NoError(t, err)
+	assert.Empty(t, pps)
+}
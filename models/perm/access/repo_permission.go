@@ -267,10 +267,15 @@ func GetActionsUserRepoPermission(ctx context.Context, repo *repo_model.Reposito
 	if err != nil {
 		return perm, err
 	}
+	return GetActionsRunRepoPermission(ctx, repo, task.RepoID, task.IsForkPullRequest)
+}
 
+// GetActionsRunRepoPermission returns the permission of the actions user to the repository
+// when it's used by a workflow run of the repository runRepoID
+func GetActionsRunRepoPermission(ctx context.Context, repo *repo_model.Repository, runRepoID int64, isForkPullRequest bool) (perm Permission, err error) {
 	var accessMode perm_model.AccessMode
-	if task.RepoID != repo.ID {
-		taskRepo, exist, err := db.GetByID[repo_model.Repository](ctx, task.RepoID)
+	if runRepoID != repo.ID {
+		taskRepo, exist, err := db.GetByID[repo_model.Repository](ctx, runRepoID)
 		if err != nil || !exist {
 			return perm, err
 		}
@@ -289,7 +294,7 @@ func GetActionsUserRepoPermission(ctx context.Context, repo *repo_model.Reposito
 			return perm, nil
 		}
 		accessMode = perm_model.AccessModeRead
-	} else if task.IsForkPullRequest {
+	} else if isForkPullRequest {
 		accessMode = perm_model.AccessModeRead
 	} else {
 		accessMode = perm_model.AccessModeWrite
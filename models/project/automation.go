@@ -0,0 +1,158 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// AutomationEvent is the event which triggers an automation rule of a column
+type AutomationEvent string
+
+const (
+	AutomationEventIssueOpened         AutomationEvent = "issue_opened"
+	AutomationEventIssueClosed         AutomationEvent = "issue_closed"
+	AutomationEventIssueReopened       AutomationEvent = "issue_reopened"
+	AutomationEventPullRequestOpened   AutomationEvent = "pull_request_opened"
+	AutomationEventPullRequestClosed   AutomationEvent = "pull_request_closed"
+	AutomationEventPullRequestReopened AutomationEvent = "pull_request_reopened"
+	AutomationEventPullRequestMerged   AutomationEvent = "pull_request_merged"
+	AutomationEventPullRequestApproved AutomationEvent = "pull_request_approved"
+	AutomationEventLabelAdded          AutomationEvent = "label_added"
+)
+
+// AutomationEvents are all events in the order they are shown on the UI
+var AutomationEvents = []AutomationEvent{
+	AutomationEventIssueOpened,
+	AutomationEventIssueClosed,
+	AutomationEventIssueReopened,
+	AutomationEventPullRequestOpened,
+	AutomationEventPullRequestClosed,
+	AutomationEventPullRequestReopened,
+	AutomationEventPullRequestMerged,
+	AutomationEventPullRequestApproved,
+	AutomationEventLabelAdded,
+}
+
+// IsValid checks if the event is known
+func (e AutomationEvent) IsValid() bool {
+	for _, event := range AutomationEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAutoAdd returns whether new issues or pull requests can be added to the project by this event
+func (e AutomationEvent) CanAutoAdd() bool {
+	return e == AutomationEventIssueOpened || e == AutomationEventPullRequestOpened
+}
+
+// TrKey returns the locale key of the event
+func (e AutomationEvent) TrKey() string {
+	return "repo.projects.column.automation.event." + string(e)
+}
+
+// ColumnAutomation is a rule which moves an issue or a pull request of the project to the column when the event happens.
+// If AutoAdd is set, new issues or pull requests which are not in a project yet are added to the column.
+// LabelID is the label which triggers the "label_added" event, for other events the issue must have the label if it is set.
+type ColumnAutomation struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ProjectID   int64              `xorm:"INDEX NOT NULL"`
+	ColumnID    int64              `xorm:"INDEX NOT NULL"`
+	Event       AutomationEvent    `xorm:"VARCHAR(50) NOT NULL"`
+	LabelID     int64              `xorm:"NOT NULL DEFAULT 0"`
+	AutoAdd     bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ColumnAutomation))
+}
+
+// TableName return the real table name
+func (ColumnAutomation) TableName() string {
+	return "project_column_automation"
+}
+
+// FindColumnAutomationsOptions represents the options to find the automation rules
+type FindColumnAutomationsOptions struct {
+	db.ListOptions
+	ProjectID int64
+	ColumnID  int64
+	Event     AutomationEvent
+	AutoAdd   bool
+}
+
+// ToConds implements db.FindOptions
+func (opts FindColumnAutomationsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.ProjectID > 0 {
+		cond = cond.And(builder.Eq{"project_id": opts.ProjectID})
+	}
+	if opts.ColumnID > 0 {
+		cond = cond.And(builder.Eq{"column_id": opts.ColumnID})
+	}
+	if opts.Event != "" {
+		cond = cond.And(builder.Eq{"event": opts.Event})
+	}
+	if opts.AutoAdd {
+		cond = cond.And(builder.Eq{"auto_add": true})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptions
+func (opts FindColumnAutomationsOptions) ToOrders() string {
+	return "id ASC"
+}
+
+// CreateColumnAutomation adds an automation rule to a column
+func CreateColumnAutomation(ctx context.Context, a *ColumnAutomation) error {
+	if !a.Event.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid automation event %q", a.Event)
+	}
+	if a.Event == AutomationEventLabelAdded && a.LabelID == 0 {
+		return util.NewInvalidArgumentErrorf("the automation event %q requires a label", a.Event)
+	}
+	if a.AutoAdd && !a.Event.CanAutoAdd() {
+		return util.NewInvalidArgumentErrorf("the automation event %q can't add new items", a.Event)
+	}
+
+	column, err := GetColumn(ctx, a.ColumnID)
+	if err != nil {
+		return err
+	}
+	if column.ProjectID != a.ProjectID {
+		return fmt.Errorf("column %d doesn't belong to project %d", a.ColumnID, a.ProjectID)
+	}
+	return db.Insert(ctx, a)
+}
+
+// DeleteColumnAutomation removes an automation rule of a column
+func DeleteColumnAutomation(ctx context.Context, columnID, id int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "column_id": columnID}).Delete(&ColumnAutomation{})
+	return err
+}
+
+// GetColumnAutomationsMap returns the automation rules of a project grouped by their columns
+func (p *Project) GetColumnAutomationsMap(ctx context.Context) (map[int64][]*ColumnAutomation, error) {
+	automations, err := db.Find[ColumnAutomation](ctx, FindColumnAutomationsOptions{ProjectID: p.ID})
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int64][]*ColumnAutomation)
+	for _, a := range automations {
+		m[a.ColumnID] = append(m[a.ColumnID], a)
+	}
+	return m, nil
+}
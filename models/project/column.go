@@ -189,6 +189,10 @@ func deleteColumnByID(ctx context.Context, columnID int64) error {
 		return err
 	}
 
+	if _, err := db.GetEngine(ctx).Where("column_id=?", column.ID).Delete(&ColumnAutomation{}); err != nil {
+		return err
+	}
+
 	if _, err := db.GetEngine(ctx).ID(column.ID).NoAutoCondition().Delete(column); err != nil {
 		return err
 	}
@@ -196,6 +200,9 @@ func deleteColumnByID(ctx context.Context, columnID int64) error {
 }
 
 func deleteColumnByProjectID(ctx context.Context, projectID int64) error {
+	if _, err := db.GetEngine(ctx).Where("project_id=?", projectID).Delete(&ColumnAutomation{}); err != nil {
+		return err
+	}
 	_, err := db.GetEngine(ctx).Where("project_id=?", projectID).Delete(&Column{})
 	return err
 }
@@ -0,0 +1,472 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// FieldType is the type of the value of a project custom field
+type FieldType string
+
+const (
+	FieldTypeText         FieldType = "text"
+	FieldTypeNumber       FieldType = "number"
+	FieldTypeDate         FieldType = "date"
+	FieldTypeSingleSelect FieldType = "single_select"
+	FieldTypeIteration    FieldType = "iteration"
+)
+
+// FieldTypes are all field types in the order they are shown on the UI
+var FieldTypes = []FieldType{
+	FieldTypeText,
+	FieldTypeNumber,
+	FieldTypeDate,
+	FieldTypeSingleSelect,
+	FieldTypeIteration,
+}
+
+// IsValid checks if the field type is known
+func (t FieldType) IsValid() bool {
+	for _, ft := range FieldTypes {
+		if t == ft {
+			return true
+		}
+	}
+	return false
+}
+
+// TrKey returns the locale key of the field type
+func (t FieldType) TrKey() string {
+	return "repo.projects.field.type." + string(t)
+}
+
+// FieldDateLayout is the layout of date values and iteration start dates
+const FieldDateLayout = time.DateOnly
+
+const (
+	maxProjectFields    = 50
+	maxFieldTextLength  = 1024
+	maxFieldOptionCount = 100
+)
+
+// FieldOption is an option of a single select field
+type FieldOption struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+// FieldIteration is an iteration of an iteration field, it starts at StartDate and lasts Duration days
+type FieldIteration struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	Duration  int    `json:"duration"`
+}
+
+// EndDate returns the last day of the iteration
+func (it *FieldIteration) EndDate() string {
+	start, err := time.Parse(FieldDateLayout, it.StartDate)
+	if err != nil {
+		return ""
+	}
+	return start.AddDate(0, 0, max(it.Duration, 1)-1).Format(FieldDateLayout)
+}
+
+// Field is a custom field of a project, every issue of the project can have a value for it
+type Field struct {
+	ID         int64             `xorm:"pk autoincr"`
+	ProjectID  int64             `xorm:"INDEX NOT NULL"`
+	Name       string            `xorm:"NOT NULL"`
+	Type       FieldType         `xorm:"VARCHAR(20) NOT NULL"`
+	Options    []*FieldOption    `xorm:"JSON TEXT"`
+	Iterations []*FieldIteration `xorm:"JSON TEXT"`
+	Sorting    int64             `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (Field) TableName() string {
+	return "project_field"
+}
+
+// FieldValue is the value of a custom field for an issue of the project
+type FieldValue struct {
+	ID        int64  `xorm:"pk autoincr"`
+	ProjectID int64  `xorm:"INDEX NOT NULL"`
+	FieldID   int64  `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID   int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Value     string `xorm:"TEXT NOT NULL"`
+
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (FieldValue) TableName() string {
+	return "project_field_value"
+}
+
+func init() {
+	db.RegisterModel(new(Field))
+	db.RegisterModel(new(FieldValue))
+}
+
+// GetOption returns the option of a single select field by its id
+func (f *Field) GetOption(id int64) *FieldOption {
+	for _, opt := range f.Options {
+		if opt.ID == id {
+			return opt
+		}
+	}
+	return nil
+}
+
+// GetIteration returns the iteration of an iteration field by its id
+func (f *Field) GetIteration(id int64) *FieldIteration {
+	for _, it := range f.Iterations {
+		if it.ID == id {
+			return it
+		}
+	}
+	return nil
+}
+
+// NormalizeValue validates a value of the field and returns it in the stored format.
+// Single select and iteration values are the ids of the option or the iteration.
+func (f *Field) NormalizeValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	switch f.Type {
+	case FieldTypeText:
+		if len(value) > maxFieldTextLength {
+			return "", util.NewInvalidArgumentErrorf("the value of field %q is too long", f.Name)
+		}
+		return value, nil
+	case FieldTypeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", util.NewInvalidArgumentErrorf("the value of field %q must be a number", f.Name)
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case FieldTypeDate:
+		t, err := time.Parse(FieldDateLayout, value)
+		if err != nil {
+			return "", util.NewInvalidArgumentErrorf("the value of field %q must be a date like 2006-01-02", f.Name)
+		}
+		return t.Format(FieldDateLayout), nil
+	case FieldTypeSingleSelect, FieldTypeIteration:
+		id, _ := strconv.ParseInt(value, 10, 64)
+		if (f.Type == FieldTypeSingleSelect && f.GetOption(id) == nil) || (f.Type == FieldTypeIteration && f.GetIteration(id) == nil) {
+			return "", util.NewInvalidArgumentErrorf("the value of field %q is not a valid option", f.Name)
+		}
+		return strconv.FormatInt(id, 10), nil
+	}
+	return "", util.NewInvalidArgumentErrorf("unknown field type %q", f.Type)
+}
+
+// DisplayValue returns the human readable form of a stored value
+func (f *Field) DisplayValue(value string) string {
+	switch f.Type {
+	case FieldTypeSingleSelect:
+		id, _ := strconv.ParseInt(value, 10, 64)
+		if opt := f.GetOption(id); opt != nil {
+			return opt.Name
+		}
+		return ""
+	case FieldTypeIteration:
+		id, _ := strconv.ParseInt(value, 10, 64)
+		if it := f.GetIteration(id); it != nil {
+			return it.Title
+		}
+		return ""
+	}
+	return value
+}
+
+// CompareValues compares two stored values of the field, empty values are sorted last
+func (f *Field) CompareValues(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	switch f.Type {
+	case FieldTypeNumber:
+		na, _ := strconv.ParseFloat(a, 64)
+		nb, _ := strconv.ParseFloat(b, 64)
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		}
+		return 0
+	case FieldTypeSingleSelect:
+		return f.optionIndex(a) - f.optionIndex(b)
+	case FieldTypeIteration:
+		return strings.Compare(f.iterationStartDate(a), f.iterationStartDate(b))
+	}
+	// dates are stored in a sortable layout
+	return strings.Compare(a, b)
+}
+
+func (f *Field) optionIndex(value string) int {
+	id, _ := strconv.ParseInt(value, 10, 64)
+	for i, opt := range f.Options {
+		if opt.ID == id {
+			return i
+		}
+	}
+	return len(f.Options)
+}
+
+func (f *Field) iterationStartDate(value string) string {
+	id, _ := strconv.ParseInt(value, 10, 64)
+	if it := f.GetIteration(id); it != nil {
+		return it.StartDate
+	}
+	return ""
+}
+
+// validate checks the field definition and assigns ids to new options and iterations
+func (f *Field) validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return util.NewInvalidArgumentErrorf("field name is empty")
+	}
+	if !f.Type.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid field type %q", f.Type)
+	}
+	if f.Type != FieldTypeSingleSelect {
+		f.Options = nil
+	}
+	if f.Type != FieldTypeIteration {
+		f.Iterations = nil
+	}
+	if len(f.Options) > maxFieldOptionCount || len(f.Iterations) > maxFieldOptionCount {
+		return util.NewInvalidArgumentErrorf("field %q has too many options", f.Name)
+	}
+
+	var nextID int64
+	for _, opt := range f.Options {
+		nextID = max(nextID, opt.ID)
+	}
+	for _, it := range f.Iterations {
+		nextID = max(nextID, it.ID)
+	}
+	names := make(map[string]bool, len(f.Options))
+	for _, opt := range f.Options {
+		opt.Name = strings.TrimSpace(opt.Name)
+		if opt.Name == "" || names[opt.Name] {
+			return util.NewInvalidArgumentErrorf("field %q has an empty or duplicate option", f.Name)
+		}
+		names[opt.Name] = true
+		if opt.ID == 0 {
+			nextID++
+			opt.ID = nextID
+		}
+	}
+	for _, it := range f.Iterations {
+		if _, err := time.Parse(FieldDateLayout, it.StartDate); err != nil || it.Duration <= 0 || strings.TrimSpace(it.Title) == "" {
+			return util.NewInvalidArgumentErrorf("field %q has an invalid iteration", f.Name)
+		}
+		if it.ID == 0 {
+			nextID++
+			it.ID = nextID
+		}
+	}
+	return nil
+}
+
+// GetFields returns the custom fields of the project
+func (p *Project) GetFields(ctx context.Context) ([]*Field, error) {
+	return GetFieldsByProjectID(ctx, p.ID)
+}
+
+// GetFieldsByProjectID returns the custom fields of a project
+func GetFieldsByProjectID(ctx context.Context, projectID int64) ([]*Field, error) {
+	fields := make([]*Field, 0, 5)
+	return fields, db.GetEngine(ctx).Where("project_id=?", projectID).OrderBy("sorting, id").Find(&fields)
+}
+
+// GetFieldByID returns a custom field of a project
+func GetFieldByID(ctx context.Context, projectID, id int64) (*Field, error) {
+	f := new(Field)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "project_id": projectID}).Get(f)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("project field %d does not exist", id)
+	}
+	return f, nil
+}
+
+// CreateField adds a custom field to a project
+func CreateField(ctx context.Context, f *Field) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		count, err := db.GetEngine(ctx).Where("project_id=?", f.ProjectID).Count(new(Field))
+		if err != nil {
+			return err
+		}
+		if count >= maxProjectFields {
+			return util.NewInvalidArgumentErrorf("a project can't have more than %d fields", maxProjectFields)
+		}
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"project_id": f.ProjectID, "name": f.Name}).Exist(new(Field))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("project field %q already exists", f.Name)
+		}
+		f.Sorting = count
+		return db.Insert(ctx, f)
+	})
+}
+
+// UpdateField updates the name, the options and the iterations of a custom field.
+// Values referring to removed options or iterations are deleted.
+func UpdateField(ctx context.Context, f *Field) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"project_id": f.ProjectID, "name": f.Name}).And(builder.Neq{"id": f.ID}).Exist(new(Field))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("project field %q already exists", f.Name)
+		}
+		if _, err := db.GetEngine(ctx).ID(f.ID).Cols("name", "options", "iterations").Update(f); err != nil {
+			return err
+		}
+
+		if f.Type != FieldTypeSingleSelect && f.Type != FieldTypeIteration {
+			return nil
+		}
+		validValues := make([]string, 0, len(f.Options)+len(f.Iterations))
+		for _, opt := range f.Options {
+			validValues = append(validValues, strconv.FormatInt(opt.ID, 10))
+		}
+		for _, it := range f.Iterations {
+			validValues = append(validValues, strconv.FormatInt(it.ID, 10))
+		}
+		_, err = db.GetEngine(ctx).Where("field_id=?", f.ID).NotIn("value", validValues).Delete(new(FieldValue))
+		return err
+	})
+}
+
+// DeleteFieldByID deletes a custom field and all its values
+func DeleteFieldByID(ctx context.Context, projectID, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		n, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "project_id": projectID}).Delete(new(Field))
+		if err != nil {
+			return err
+		} else if n == 0 {
+			return util.NewNotExistErrorf("project field %d does not exist", id)
+		}
+		_, err = db.GetEngine(ctx).Where("field_id=?", id).Delete(new(FieldValue))
+		return err
+	})
+}
+
+func deleteFieldsByProjectID(ctx context.Context, projectID int64) error {
+	if _, err := db.GetEngine(ctx).Where("project_id=?", projectID).Delete(new(FieldValue)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("project_id=?", projectID).Delete(new(Field))
+	return err
+}
+
+// SetFieldValue sets the value of a custom field for an issue of the project, an empty value removes it
+func SetFieldValue(ctx context.Context, f *Field, issueID int64, value string) error {
+	value, err := f.NormalizeValue(value)
+	if err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		inProject, err := db.GetEngine(ctx).Where(builder.Eq{"project_id": f.ProjectID, "issue_id": issueID}).Exist(new(ProjectIssue))
+		if err != nil {
+			return err
+		}
+		if !inProject {
+			return util.NewInvalidArgumentErrorf("issue %d is not in project %d", issueID, f.ProjectID)
+		}
+
+		if value == "" {
+			_, err = db.GetEngine(ctx).Where(builder.Eq{"field_id": f.ID, "issue_id": issueID}).Delete(new(FieldValue))
+			return err
+		}
+		fv := &FieldValue{FieldID: f.ID, IssueID: issueID}
+		has, err := db.GetEngine(ctx).Get(fv)
+		if err != nil {
+			return err
+		}
+		fv.Value = value
+		if has {
+			_, err = db.GetEngine(ctx).ID(fv.ID).Cols("value").Update(fv)
+			return err
+		}
+		fv.ProjectID = f.ProjectID
+		return db.Insert(ctx, fv)
+	})
+}
+
+// FieldValuesMap maps issue ids to the values of their fields by field id
+type FieldValuesMap map[int64]map[int64]string
+
+// Get returns the stored value of a field for an issue
+func (m FieldValuesMap) Get(issueID, fieldID int64) string {
+	return m[issueID][fieldID]
+}
+
+// GetFieldValuesMap returns the field values of the issues of a project, all issues are returned if issueIDs is empty
+func GetFieldValuesMap(ctx context.Context, projectID int64, issueIDs ...int64) (FieldValuesMap, error) {
+	values := make([]*FieldValue, 0, 10)
+	sess := db.GetEngine(ctx).Where("project_id=?", projectID)
+	if len(issueIDs) > 0 {
+		sess = sess.In("issue_id", issueIDs)
+	}
+	if err := sess.Find(&values); err != nil {
+		return nil, err
+	}
+	m := make(FieldValuesMap, len(values))
+	for _, v := range values {
+		if m[v.IssueID] == nil {
+			m[v.IssueID] = make(map[int64]string)
+		}
+		m[v.IssueID][v.FieldID] = v.Value
+	}
+	return m, nil
+}
+
+// DeleteFieldValuesOfIssue deletes the field values of an issue except the ones of keepProjectID
+func DeleteFieldValuesOfIssue(ctx context.Context, issueID, keepProjectID int64) error {
+	_, err := db.GetEngine(ctx).Where("issue_id=?", issueID).And(builder.Neq{"project_id": keepProjectID}).Delete(new(FieldValue))
+	if err != nil {
+		return fmt.Errorf("delete field values of issue %d: %w", issueID, err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldNormalizeValue(t *testing.T) {
+	selectField := &Field{Name: "Status", Type: FieldTypeSingleSelect, Options: []*FieldOption{{ID: 1, Name: "Todo"}, {ID: 2, Name: "Done"}}}
+	iterationField := &Field{Name: "Sprint", Type: FieldTypeIteration, Iterations: []*FieldIteration{{ID: 3, Title: "Sprint 1", StartDate: "2025-01-06", Duration: 14}}}
+
+	cases := []struct {
+		field    *Field
+		value    string
+		expected string
+		hasError bool
+	}{
+		{&Field{Type: FieldTypeText}, " some text ", "some text", false},
+		{&Field{Type: FieldTypeNumber}, "3.50", "3.5", false},
+		{&Field{Type: FieldTypeNumber}, "three", "", true},
+		{&Field{Type: FieldTypeDate}, "2025-02-01", "2025-02-01", false},
+		{&Field{Type: FieldTypeDate}, "01/02/2025", "", true},
+		{selectField, "2", "2", false},
+		{selectField, "5", "", true},
+		{iterationField, "3", "3", false},
+		{iterationField, "1", "", true},
+		{selectField, "", "", false},
+	}
+	for _, c := range cases {
+		v, err := c.field.NormalizeValue(c.value)
+		if c.hasError {
+			assert.ErrorIs(t, err, util.ErrInvalidArgument, "value %q", c.value)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, v)
+		}
+	}
+
+	assert.Equal(t, "Done", selectField.DisplayValue("2"))
+	assert.Equal(t, "Sprint 1", iterationField.DisplayValue("3"))
+	assert.Equal(t, "2025-01-19", iterationField.Iterations[0].EndDate())
+}
+
+func TestFieldCompareValues(t *testing.T) {
+	number := &Field{Type: FieldTypeNumber}
+	assert.Negative(t, number.CompareValues("2", "10"))
+	assert.Negative(t, number.CompareValues("10", ""))
+	assert.Positive(t, number.CompareValues("", "1"))
+
+	selectField := &Field{Type: FieldTypeSingleSelect, Options: []*FieldOption{{ID: 2, Name: "High"}, {ID: 1, Name: "Low"}}}
+	assert.Negative(t, selectField.CompareValues("2", "1"))
+
+	date := &Field{Type: FieldTypeDate}
+	assert.Positive(t, date.CompareValues("2025-02-01", "2025-01-31"))
+}
+
+func TestProjectFields(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	field := &Field{
+		ProjectID: 1,
+		Name:      "Priority",
+		Type:      FieldTypeSingleSelect,
+		Options:   []*FieldOption{{Name: "High"}, {Name: "Low"}},
+	}
+	require.NoError(t, CreateField(t.Context(), field))
+	assert.EqualValues(t, 1, field.Options[0].ID)
+	assert.EqualValues(t, 2, field.Options[1].ID)
+
+	// names are unique per project
+	err := CreateField(t.Context(), &Field{ProjectID: 1, Name: "Priority", Type: FieldTypeText})
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+
+	estimate := &Field{ProjectID: 1, Name: "Estimate", Type: FieldTypeNumber}
+	require.NoError(t, CreateField(t.Context(), estimate))
+	assert.EqualValues(t, 1, estimate.Sorting)
+
+	fields, err := GetFieldsByProjectID(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Len(t, fields, 2)
+	assert.Equal(t, "Low", fields[0].Options[1].Name)
+
+	// issue 1 is in project 1, issue 4 isn't
+	require.NoError(t, SetFieldValue(t.Context(), field, 1, "2"))
+	require.NoError(t, SetFieldValue(t.Context(), estimate, 1, "5"))
+	require.NoError(t, SetFieldValue(t.Context(), estimate, 1, "8"))
+	assert.ErrorIs(t, SetFieldValue(t.Context(), estimate, 4, "1"), util.ErrInvalidArgument)
+
+	values, err := GetFieldValuesMap(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "2", values.Get(1, field.ID))
+	assert.Equal(t, "8", values.Get(1, estimate.ID))
+
+	// removing an option removes the values referring to it
+	field.Options = field.Options[:1]
+	require.NoError(t, UpdateField(t.Context(), field))
+	unittest.AssertNotExistsBean(t, &FieldValue{FieldID: field.ID, IssueID: 1})
+
+	require.NoError(t, SetFieldValue(t.Context(), estimate, 1, ""))
+	unittest.AssertNotExistsBean(t, &FieldValue{FieldID: estimate.ID, IssueID: 1})
+
+	require.NoError(t, SetFieldValue(t.Context(), estimate, 1, "3"))
+	require.NoError(t, DeleteFieldByID(t.Context(), 1, estimate.ID))
+	unittest.AssertNotExistsBean(t, &Field{ID: estimate.ID})
+	unittest.AssertNotExistsBean(t, &FieldValue{FieldID: estimate.ID})
+
+	require.NoError(t, DeleteProjectByID(t.Context(), 1))
+	unittest.AssertNotExistsBean(t, &Field{ID: field.ID})
+	assert.Zero(t, unittest.GetCount(t, &FieldValue{}))
+}
@@ -414,6 +414,14 @@ func DeleteProjectByID(ctx context.Context, id int64) error {
 			return err
 		}
 
+		if err := deleteFieldsByProjectID(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := db.GetEngine(ctx).Where("project_id=?", id).Delete(new(View)); err != nil {
+			return err
+		}
+
 		if _, err = db.GetEngine(ctx).ID(p.ID).Delete(new(Project)); err != nil {
 			return err
 		}
@@ -423,6 +431,13 @@ func DeleteProjectByID(ctx context.Context, id int64) error {
 }
 
 func DeleteProjectByRepoID(ctx context.Context, repoID int64) error {
+	repoProjectIDs := builder.Select("id").From("project").Where(builder.Eq{"repo_id": repoID})
+	for _, bean := range []any{&ColumnAutomation{}, &FieldValue{}, &Field{}, &View{}} {
+		if _, err := db.GetEngine(ctx).Where(builder.In("project_id", repoProjectIDs)).Delete(bean); err != nil {
+			return err
+		}
+	}
+
 	switch {
 	case setting.Database.Type.IsSQLite3():
 		if _, err := db.GetEngine(ctx).Exec("DELETE FROM project_issue WHERE project_issue.id IN (SELECT project_issue.id FROM project_issue INNER JOIN project WHERE project.id = project_issue.project_id AND project.repo_id = ?)", repoID); err != nil {
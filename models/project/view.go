@@ -0,0 +1,147 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ViewType is the layout of a saved project view
+type ViewType string
+
+const (
+	ViewTypeTable   ViewType = "table"
+	ViewTypeRoadmap ViewType = "roadmap"
+)
+
+// IsValid checks if the view type is known
+func (t ViewType) IsValid() bool {
+	return t == ViewTypeTable || t == ViewTypeRoadmap
+}
+
+const maxProjectViews = 20
+
+// View is a saved table or roadmap view of a project.
+// Query holds the url query of the filters, the grouping and the sorting of the view, e.g. "group_by=assignee&sort=field_1".
+type View struct {
+	ID        int64    `xorm:"pk autoincr"`
+	ProjectID int64    `xorm:"INDEX NOT NULL"`
+	Name      string   `xorm:"NOT NULL"`
+	Type      ViewType `xorm:"VARCHAR(20) NOT NULL"`
+	Query     string   `xorm:"TEXT"`
+	CreatorID int64    `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (View) TableName() string {
+	return "project_view"
+}
+
+func init() {
+	db.RegisterModel(new(View))
+}
+
+// Link returns the link of the view relative to the link of its project
+func (v *View) Link(projectLink string) string {
+	q, _ := url.ParseQuery(v.Query)
+	q.Set("view", strconv.FormatInt(v.ID, 10))
+	return projectLink + "/" + string(v.Type) + "?" + q.Encode()
+}
+
+func (v *View) validate() error {
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Name == "" {
+		return util.NewInvalidArgumentErrorf("view name is required")
+	}
+	if !v.Type.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid view type %q", v.Type)
+	}
+	if _, err := url.ParseQuery(v.Query); err != nil {
+		return util.NewInvalidArgumentErrorf("invalid view query: %v", err)
+	}
+	return nil
+}
+
+// GetViews returns the saved views of the project
+func (p *Project) GetViews(ctx context.Context) ([]*View, error) {
+	views := make([]*View, 0, 5)
+	return views, db.GetEngine(ctx).Where("project_id=?", p.ID).OrderBy("id").Find(&views)
+}
+
+// GetViewByID returns a saved view of a project
+func GetViewByID(ctx context.Context, projectID, id int64) (*View, error) {
+	v := new(View)
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "project_id": projectID}).Get(v)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("project view %d does not exist", id)
+	}
+	return v, nil
+}
+
+// CreateView saves a view of a project
+func CreateView(ctx context.Context, v *View) error {
+	if err := v.validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		count, err := db.GetEngine(ctx).Where("project_id=?", v.ProjectID).Count(new(View))
+		if err != nil {
+			return err
+		}
+		if count >= maxProjectViews {
+			return util.NewInvalidArgumentErrorf("a project can't have more than %d views", maxProjectViews)
+		}
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"project_id": v.ProjectID, "name": v.Name}).Exist(new(View))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("project view %q already exists", v.Name)
+		}
+		return db.Insert(ctx, v)
+	})
+}
+
+// UpdateView updates the name and the query of a saved view
+func UpdateView(ctx context.Context, v *View) error {
+	if err := v.validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"project_id": v.ProjectID, "name": v.Name}).And(builder.Neq{"id": v.ID}).Exist(new(View))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("project view %q already exists", v.Name)
+		}
+		_, err = db.GetEngine(ctx).ID(v.ID).Cols("name", "query").Update(v)
+		return err
+	})
+}
+
+// DeleteViewByID deletes a saved view of a project
+func DeleteViewByID(ctx context.Context, projectID, id int64) error {
+	n, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "project_id": projectID}).Delete(new(View))
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return util.NewNotExistErrorf("project view %d does not exist", id)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"fmt"
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectViews(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	project := unittest.AssertExistsAndLoadBean(t, &Project{ID: 1})
+	view := &View{ProjectID: project.ID, Name: "By assignee", Type: ViewTypeTable, Query: "group_by=assignee&state=open"}
+	require.NoError(t, CreateView(t.Context(), view))
+	assert.Equal(t, fmt.Sprintf("/user2/repo1/projects/1/table?group_by=assignee&state=open&view=%d", view.ID), view.Link("/user2/repo1/projects/1"))
+
+	assert.ErrorIs(t, CreateView(t.Context(), &View{ProjectID: project.ID, Name: "By assignee", Type: ViewTypeRoadmap}), util.ErrAlreadyExist)
+	assert.ErrorIs(t, CreateView(t.Context(), &View{ProjectID: project.ID, Name: " ", Type: ViewTypeTable}), util.ErrInvalidArgument)
+	assert.ErrorIs(t, CreateView(t.Context(), &View{ProjectID: project.ID, Name: "Board", Type: "board"}), util.ErrInvalidArgument)
+
+	roadmap := &View{ProjectID: project.ID, Name: "Roadmap", Type: ViewTypeRoadmap}
+	require.NoError(t, CreateView(t.Context(), roadmap))
+	views, err := project.GetViews(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, views, 2)
+
+	roadmap.Name = "By assignee"
+	assert.ErrorIs(t, UpdateView(t.Context(), roadmap), util.ErrAlreadyExist)
+	roadmap.Name = "Timeline"
+	roadmap.Query = "group_by=milestone"
+	require.NoError(t, UpdateView(t.Context(), roadmap))
+	roadmap, err = GetViewByID(t.Context(), project.ID, roadmap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "group_by=milestone", roadmap.Query)
+	_, err = GetViewByID(t.Context(), 2, roadmap.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	require.NoError(t, DeleteViewByID(t.Context(), project.ID, roadmap.ID))
+	assert.ErrorIs(t, DeleteViewByID(t.Context(), project.ID, roadmap.ID), util.ErrNotExist)
+
+	require.NoError(t, DeleteProjectByID(t.Context(), project.ID))
+	unittest.AssertNotExistsBean(t, &View{ID: view.ID})
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its base branch.
+// Entries are processed in the order of their IDs.
+type MergeQueueEntry struct {
+	ID                     int64                 `xorm:"pk autoincr"`
+	RepoID                 int64                 `xorm:"INDEX(s) NOT NULL"`
+	BaseBranch             string                `xorm:"INDEX(s) NOT NULL"`
+	PullID                 int64                 `xorm:"UNIQUE NOT NULL"`
+	DoerID                 int64                 `xorm:"INDEX NOT NULL"`
+	Doer                   *user_model.User      `xorm:"-"`
+	MergeStyle             repo_model.MergeStyle `xorm:"varchar(30)"`
+	Message                string                `xorm:"LONGTEXT"`
+	DeleteBranchAfterMerge bool
+	// ParentCommitID is the commit the merge group commit has been created upon,
+	// it is either the head of the base branch or the merge group commit of the previous entry
+	ParentCommitID string `xorm:"VARCHAR(64)"`
+	// MergeGroupCommitID is the result of merging this and all preceding pull requests of the queue
+	MergeGroupCommitID string             `xorm:"VARCHAR(64) INDEX"`
+	CreatedUnix        timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix        timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return database table name for xorm
+func (MergeQueueEntry) TableName() string {
+	return "pull_merge_queue"
+}
+
+func init() {
+	db.RegisterModel(new(MergeQueueEntry))
+}
+
+// ErrAlreadyInMergeQueue represents a "AlreadyInMergeQueue"-error
+type ErrAlreadyInMergeQueue struct {
+	PullID int64
+}
+
+func (err ErrAlreadyInMergeQueue) Error() string {
+	return fmt.Sprintf("pull request is already in the merge queue [pull_id: %d]", err.PullID)
+}
+
+func (err ErrAlreadyInMergeQueue) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// IsErrAlreadyInMergeQueue checks if an error is a ErrAlreadyInMergeQueue.
+func IsErrAlreadyInMergeQueue(err error) bool {
+	_, ok := err.(ErrAlreadyInMergeQueue)
+	return ok
+}
+
+// AddToMergeQueue appends a pull request to the end of the merge queue of its base branch
+func AddToMergeQueue(ctx context.Context, entry *MergeQueueEntry) error {
+	if exists, _, err := GetMergeQueueEntryByPullID(ctx, entry.PullID); err != nil {
+		return err
+	} else if exists {
+		return ErrAlreadyInMergeQueue{PullID: entry.PullID}
+	}
+
+	return db.Insert(ctx, entry)
+}
+
+// GetMergeQueueEntryByPullID gets the merge queue entry of a pull request
+func GetMergeQueueEntryByPullID(ctx context.Context, pullID int64) (bool, *MergeQueueEntry, error) {
+	entry := &MergeQueueEntry{}
+	exists, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Get(entry)
+	if err != nil || !exists {
+		return false, nil, err
+	}
+
+	if err := entry.LoadDoer(ctx); err != nil {
+		return false, nil, err
+	}
+	return true, entry, nil
+}
+
+// LoadDoer loads the user who added the pull request to the queue
+func (entry *MergeQueueEntry) LoadDoer(ctx context.Context) (err error) {
+	if entry.Doer != nil {
+		return nil
+	}
+	entry.Doer, err = user_model.GetPossibleUserByID(ctx, entry.DoerID)
+	if errors.Is(err, util.ErrNotExist) {
+		entry.Doer, err = user_model.NewGhostUser(), nil
+	}
+	return err
+}
+
+// GetMergeQueueEntries returns the merge queue of a branch in processing order
+func GetMergeQueueEntries(ctx context.Context, repoID int64, baseBranch string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 10)
+	return entries, db.GetEngine(ctx).
+		Where("repo_id = ? AND base_branch = ?", repoID, baseBranch).
+		Asc("id").
+		Find(&entries)
+}
+
+// GetMergeQueueEntriesByMergeGroupCommitID returns the merge queue entries which are tested on the given commit
+func GetMergeQueueEntriesByMergeGroupCommitID(ctx context.Context, repoID int64, commitID string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 1)
+	return entries, db.GetEngine(ctx).
+		Where("repo_id = ? AND merge_group_commit_id = ?", repoID, commitID).
+		Find(&entries)
+}
+
+// GetMergeQueuePosition returns the 1-based position of the entry in the merge queue of its branch
+func GetMergeQueuePosition(ctx context.Context, entry *MergeQueueEntry) (int64, error) {
+	return db.GetEngine(ctx).
+		Where("repo_id = ? AND base_branch = ? AND id <= ?", entry.RepoID, entry.BaseBranch, entry.ID).
+		Count(new(MergeQueueEntry))
+}
+
+// UpdateMergeGroupCommit stores the merge group commit which has been created for the entry
+func UpdateMergeGroupCommit(ctx context.Context, entry *MergeQueueEntry) error {
+	_, err := db.GetEngine(ctx).ID(entry.ID).Cols("parent_commit_id", "merge_group_commit_id").Update(entry)
+	return err
+}
+
+// DeleteMergeQueueEntry removes a pull request from the merge queue
+func DeleteMergeQueueEntry(ctx context.Context, pullID int64) error {
+	exist, entry, err := GetMergeQueueEntryByPullID(ctx, pullID)
+	if err != nil {
+		return err
+	} else if !exist {
+		return db.ErrNotExist{Resource: "merge_queue", ID: pullID}
+	}
+
+	_, err = db.GetEngine(ctx).ID(entry.ID).Delete(&MergeQueueEntry{})
+	return err
+}
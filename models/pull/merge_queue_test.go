@@ -0,0 +1,64 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeQueue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	newEntry := func(pullID int64) *pull_model.MergeQueueEntry {
+		return &pull_model.MergeQueueEntry{
+			RepoID:     1,
+			BaseBranch: "master",
+			PullID:     pullID,
+			DoerID:     2,
+			MergeStyle: repo_model.MergeStyleMerge,
+		}
+	}
+
+	require.NoError(t, pull_model.AddToMergeQueue(t.Context(), newEntry(2)))
+	require.NoError(t, pull_model.AddToMergeQueue(t.Context(), newEntry(5)))
+
+	err := pull_model.AddToMergeQueue(t.Context(), newEntry(2))
+	assert.True(t, pull_model.IsErrAlreadyInMergeQueue(err))
+
+	entries, err := pull_model.GetMergeQueueEntries(t.Context(), 1, "master")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.EqualValues(t, 2, entries[0].PullID)
+	assert.EqualValues(t, 5, entries[1].PullID)
+
+	exist, entry, err := pull_model.GetMergeQueueEntryByPullID(t.Context(), 5)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.EqualValues(t, 2, entry.Doer.ID)
+	position, err := pull_model.GetMergeQueuePosition(t.Context(), entry)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, position)
+
+	entry.ParentCommitID = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	entry.MergeGroupCommitID = "1032bbf17fbc0d9c95bb5418dabe8f8c99278700"
+	require.NoError(t, pull_model.UpdateMergeGroupCommit(t.Context(), entry))
+	entries, err = pull_model.GetMergeQueueEntriesByMergeGroupCommitID(t.Context(), 1, entry.MergeGroupCommitID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.EqualValues(t, 5, entries[0].PullID)
+
+	require.NoError(t, pull_model.DeleteMergeQueueEntry(t.Context(), 2))
+	assert.True(t, db.IsErrNotExist(pull_model.DeleteMergeQueueEntry(t.Context(), 2)))
+	position, err = pull_model.GetMergeQueuePosition(t.Context(), entry)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, position)
+}
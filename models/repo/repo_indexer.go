@@ -20,6 +20,8 @@ const (
 	RepoIndexerTypeCode RepoIndexerType = iota // 0
 	// RepoIndexerTypeStats repository stats indexer
 	RepoIndexerTypeStats // 1
+	// RepoIndexerTypeWiki wiki indexer
+	RepoIndexerTypeWiki // 2
 )
 
 // RepoIndexerStatus status of a repo's entry in the repo indexer
@@ -73,6 +75,10 @@ func GetIndexerStatus(ctx context.Context, repo *Repository, indexerType RepoInd
 		if repo.StatsIndexerStatus != nil {
 			return repo.StatsIndexerStatus, nil
 		}
+	case RepoIndexerTypeWiki:
+		if repo.WikiIndexerStatus != nil {
+			return repo.WikiIndexerStatus, nil
+		}
 	}
 	status := &RepoIndexerStatus{RepoID: repo.ID}
 	if has, err := db.GetEngine(ctx).Where("`indexer_type` = ?", indexerType).Get(status); err != nil {
@@ -86,10 +92,18 @@ func GetIndexerStatus(ctx context.Context, repo *Repository, indexerType RepoInd
 		repo.CodeIndexerStatus = status
 	case RepoIndexerTypeStats:
 		repo.StatsIndexerStatus = status
+	case RepoIndexerTypeWiki:
+		repo.WikiIndexerStatus = status
 	}
 	return status, nil
 }
 
+// DeleteIndexerStatusByType deletes the indexer status of all repos for the given indexer type
+func DeleteIndexerStatusByType(ctx context.Context, indexerType RepoIndexerType) error {
+	_, err := db.GetEngine(ctx).Where("`indexer_type` = ?", indexerType).Delete(new(RepoIndexerStatus))
+	return err
+}
+
 // UpdateIndexerStatus updates indexer status
 func UpdateIndexerStatus(ctx context.Context, repo *Repository, indexerType RepoIndexerType, sha string) error {
 	status, err := GetIndexerStatus(ctx, repo, indexerType)
@@ -5,7 +5,9 @@ package secret
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	actions_model "code.gitea.io/gitea/models/actions"
@@ -25,6 +27,7 @@ import (
 // It can be:
 //  1. org/user level secret, OwnerID is org/user ID and RepoID is 0
 //  2. repo level secret, OwnerID is 0 and RepoID is repo ID
+//  3. environment level secret, OwnerID is 0, RepoID is repo ID and EnvironmentID is the ID of an environment of the repo
 //
 // Please note that it's not acceptable to have both OwnerID and RepoID to be non-zero,
 // or it will be complicated to find secrets belonging to a specific owner.
@@ -42,6 +45,11 @@ type Secret struct {
 	Data        string             `xorm:"LONGTEXT"` // encrypted data
 	Description string             `xorm:"TEXT"`
 	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+
+	// EnvironmentID is only set for environment level secrets, which are not repo level secrets
+	EnvironmentID int64 `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+
+	actions_model.RepoScope `xorm:"extends"`
 }
 
 const (
@@ -73,24 +81,31 @@ func InsertEncryptedSecret(ctx context.Context, ownerID, repoID int64, name, dat
 		return nil, fmt.Errorf("%w: ownerID and repoID cannot be both zero, global secrets are not supported", util.ErrInvalidArgument)
 	}
 
+	return insertEncryptedSecret(ctx, &Secret{OwnerID: ownerID, RepoID: repoID, Name: name, Description: description}, data)
+}
+
+// InsertEncryptedEnvironmentSecret creates and encrypts a secret of an environment of the repository
+func InsertEncryptedEnvironmentSecret(ctx context.Context, repoID, environmentID int64, name, data, description string) (*Secret, error) {
+	if repoID == 0 || environmentID == 0 {
+		return nil, fmt.Errorf("%w: an environment level secret must belong to a repository", util.ErrInvalidArgument)
+	}
+	return insertEncryptedSecret(ctx, &Secret{RepoID: repoID, EnvironmentID: environmentID, Name: name, Description: description}, data)
+}
+
+func insertEncryptedSecret(ctx context.Context, secret *Secret, data string) (*Secret, error) {
 	if len(data) > SecretDataMaxLength {
 		return nil, util.NewInvalidArgumentErrorf("data too long")
 	}
 
-	description = util.TruncateRunes(description, SecretDescriptionMaxLength)
-
 	encrypted, err := secret_module.EncryptSecret(setting.SecretKey, data)
 	if err != nil {
 		return nil, err
 	}
 
-	secret := &Secret{
-		OwnerID:     ownerID,
-		RepoID:      repoID,
-		Name:        strings.ToUpper(name),
-		Data:        encrypted,
-		Description: description,
-	}
+	secret.Name = strings.ToUpper(secret.Name)
+	secret.Data = encrypted
+	secret.Description = util.TruncateRunes(secret.Description, SecretDescriptionMaxLength)
+	secret.RepoScope = actions_model.RepoScope{Visibility: actions_model.ScopeVisibilityAll}
 	return secret, db.Insert(ctx, secret)
 }
 
@@ -100,10 +115,12 @@ func init() {
 
 type FindSecretsOptions struct {
 	db.ListOptions
-	RepoID   int64
-	OwnerID  int64 // it will be ignored if RepoID is set
-	SecretID int64
-	Name     string
+	RepoID  int64
+	OwnerID int64 // it will be ignored if RepoID is set
+	// EnvironmentID finds the secrets of an environment of the repository instead of the repo level ones
+	EnvironmentID int64
+	SecretID      int64
+	Name          string
 }
 
 func (opts FindSecretsOptions) ToConds() builder.Cond {
@@ -116,6 +133,7 @@ func (opts FindSecretsOptions) ToConds() builder.Cond {
 	} else {
 		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
 	}
+	cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
 
 	if opts.SecretID != 0 {
 		cond = cond.And(builder.Eq{"id": opts.SecretID})
@@ -151,6 +169,13 @@ func UpdateSecret(ctx context.Context, secretID int64, data, description string)
 	return err
 }
 
+// UpdateSecretScope changes the repositories and the environments which can use an org or user level secret
+func UpdateSecretScope(ctx context.Context, secretID int64, scope actions_model.RepoScope) error {
+	scope.Normalize()
+	_, err := db.GetEngine(ctx).ID(secretID).Cols("visibility", "selected_repo_i_ds", "selected_environments").Update(&Secret{RepoScope: scope})
+	return err
+}
+
 func GetSecretsOfTask(ctx context.Context, task *actions_model.ActionTask) (map[string]string, error) {
 	secrets := map[string]string{}
 
@@ -169,13 +194,32 @@ func GetSecretsOfTask(ctx context.Context, task *actions_model.ActionTask) (map[
 		log.Error("find secrets of owner %v: %v", task.Job.Run.Repo.OwnerID, err)
 		return nil, err
 	}
+	// the org or user level secrets could be restricted to some repositories of the owner and some environments
+	ownerSecrets = slices.DeleteFunc(ownerSecrets, func(s *Secret) bool {
+		return !s.AllowsRepo(task.Job.Run.Repo) || !s.AllowsEnvironment(task.Job.Environment)
+	})
 	repoSecrets, err := db.Find[Secret](ctx, FindSecretsOptions{RepoID: task.Job.Run.RepoID})
 	if err != nil {
 		log.Error("find secrets of repo %v: %v", task.Job.Run.RepoID, err)
 		return nil, err
 	}
 
-	for _, secret := range append(ownerSecrets, repoSecrets...) {
+	// the secrets of the job's environment take precedence over the repo level ones
+	var envSecrets []*Secret
+	if task.Job.Environment != "" {
+		env, err := actions_model.GetEnvironmentByName(ctx, task.Job.RepoID, task.Job.Environment)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return nil, err
+		}
+		if env != nil {
+			if envSecrets, err = db.Find[Secret](ctx, FindSecretsOptions{RepoID: task.Job.RepoID, EnvironmentID: env.ID}); err != nil {
+				log.Error("find secrets of environment %v: %v", env.ID, err)
+				return nil, err
+			}
+		}
+	}
+
+	for _, secret := range slices.Concat(ownerSecrets, repoSecrets, envSecrets) {
 		v, err := secret_module.DecryptSecret(setting.SecretKey, secret.Data)
 		if err != nil {
 			log.Error("Unable to decrypt Actions secret %v %q, maybe SECRET_KEY is wrong: %v", secret.ID, secret.Name, err)
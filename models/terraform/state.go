@@ -0,0 +1,340 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+var (
+	ErrStateNotExist        = util.NewNotExistErrorf("terraform state does not exist")
+	ErrStateVersionNotExist = util.NewNotExistErrorf("terraform state version does not exist")
+	ErrStateLocked          = util.NewAlreadyExistErrorf("terraform state is locked")
+	ErrStateNotLocked       = util.NewInvalidArgumentErrorf("terraform state is not locked")
+)
+
+// State represents a named Terraform state of a repository which is accessed by the HTTP state backend
+type State struct {
+	ID            int64  `xorm:"pk autoincr"`
+	RepoID        int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Name          string `xorm:"UNIQUE(s) NOT NULL"`
+	LatestVersion int64  `xorm:"NOT NULL DEFAULT 0"`
+	// LockID is the id of the lock generated by Terraform, the state is unlocked if it's empty
+	LockID      string             `xorm:"NOT NULL DEFAULT ''"`
+	LockInfo    string             `xorm:"TEXT"`
+	LockerID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	Locker      *user_model.User   `xorm:"-"`
+	LockedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL"`
+}
+
+// TableName sets the table name of the state
+func (*State) TableName() string {
+	return "terraform_state"
+}
+
+// StateVersion represents a stored version of a Terraform state
+type StateVersion struct {
+	ID      int64 `xorm:"pk autoincr"`
+	RepoID  int64 `xorm:"INDEX NOT NULL"`
+	StateID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Version int64 `xorm:"UNIQUE(s) NOT NULL"`
+	// Serial and Lineage are read from the state document
+	Serial      int64              `xorm:"NOT NULL DEFAULT 0"`
+	Lineage     string             `xorm:"NOT NULL DEFAULT ''"`
+	Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	Creator     *user_model.User   `xorm:"-"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+// TableName sets the table name of the state version
+func (*StateVersion) TableName() string {
+	return "terraform_state_version"
+}
+
+func init() {
+	db.RegisterModel(new(State))
+	db.RegisterModel(new(StateVersion))
+}
+
+// LockInfo is the lock information sent by Terraform when it locks a state
+type LockInfo struct {
+	ID        string `json:"ID"`
+	Operation string `json:"Operation"`
+	Info      string `json:"Info"`
+	Who       string `json:"Who"`
+	Version   string `json:"Version"`
+	Created   string `json:"Created"`
+	Path      string `json:"Path"`
+}
+
+// IsLocked returns true if the state is locked
+func (s *State) IsLocked() bool {
+	return s.LockID != ""
+}
+
+// ParsedLockInfo returns the lock information sent by Terraform, it's nil if the state is not locked
+func (s *State) ParsedLockInfo() *LockInfo {
+	if !s.IsLocked() {
+		return nil
+	}
+	info := &LockInfo{}
+	if err := json.Unmarshal([]byte(s.LockInfo), info); err != nil {
+		info = &LockInfo{}
+	}
+	info.ID = s.LockID
+	return info
+}
+
+// LoadLocker loads the user who locked the state
+func (s *State) LoadLocker(ctx context.Context) (err error) {
+	if s.Locker != nil || !s.IsLocked() {
+		return nil
+	}
+	s.Locker, err = user_model.GetPossibleUserByID(ctx, s.LockerID)
+	if user_model.IsErrUserNotExist(err) {
+		s.Locker = user_model.NewGhostUser()
+		return nil
+	}
+	return err
+}
+
+// RelativePath returns the path of the version in the storage
+func (sv *StateVersion) RelativePath() string {
+	return fmt.Sprintf("%d/%d/%d", sv.RepoID, sv.StateID, sv.Version)
+}
+
+// LoadCreator loads the user who uploaded the version
+func (sv *StateVersion) LoadCreator(ctx context.Context) (err error) {
+	if sv.Creator != nil {
+		return nil
+	}
+	sv.Creator, err = user_model.GetPossibleUserByID(ctx, sv.CreatorID)
+	if user_model.IsErrUserNotExist(err) {
+		sv.Creator = user_model.NewGhostUser()
+		return nil
+	}
+	return err
+}
+
+// GetStateByName gets the state of the repository with the given name
+func GetStateByName(ctx context.Context, repoID int64, name string) (*State, error) {
+	s := &State{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND name = ?", repoID, name).Get(s)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateNotExist
+	}
+	return s, nil
+}
+
+// GetOrCreateState gets the state of the repository with the given name or creates an empty one
+func GetOrCreateState(ctx context.Context, repoID int64, name string) (*State, error) {
+	s, err := GetStateByName(ctx, repoID, name)
+	if err == nil || !errors.Is(err, ErrStateNotExist) {
+		return s, err
+	}
+
+	s = &State{RepoID: repoID, Name: name}
+	if err := db.Insert(ctx, s); err != nil {
+		// the state may be created concurrently
+		if s, err2 := GetStateByName(ctx, repoID, name); err2 == nil {
+			return s, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetStatesByRepoID returns all states of the repository ordered by name
+func GetStatesByRepoID(ctx context.Context, repoID int64) ([]*State, error) {
+	states := make([]*State, 0, 10)
+	return states, db.GetEngine(ctx).Where("repo_id = ?", repoID).Asc("name").Find(&states)
+}
+
+// LockState locks the state if it's not locked yet, ErrStateLocked is returned otherwise
+func LockState(ctx context.Context, s *State, lockID, lockInfo string, lockerID int64) error {
+	now := timeutil.TimeStampNow()
+	n, err := db.GetEngine(ctx).
+		Where(builder.Eq{"id": s.ID, "lock_id": ""}).
+		Cols("lock_id", "lock_info", "locker_id", "locked_unix").
+		NoAutoTime().
+		Update(&State{LockID: lockID, LockInfo: lockInfo, LockerID: lockerID, LockedUnix: now})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrStateLocked
+	}
+	s.LockID, s.LockInfo, s.LockerID, s.LockedUnix, s.Locker = lockID, lockInfo, lockerID, now, nil
+	return nil
+}
+
+// UnlockState removes the lock of the state. If lockID is empty the lock is removed regardless of its id,
+// otherwise ErrStateLocked is returned if the state is locked with another id.
+func UnlockState(ctx context.Context, s *State, lockID string) error {
+	cond := builder.Eq{"id": s.ID}
+	if lockID != "" {
+		cond["lock_id"] = lockID
+	}
+	n, err := db.GetEngine(ctx).
+		Where(cond.And(builder.Neq{"lock_id": ""})).
+		Cols("lock_id", "lock_info", "locker_id", "locked_unix").
+		NoAutoTime().
+		Update(&State{})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		if lockID == "" {
+			return ErrStateNotLocked
+		}
+		// reload the state to distinguish between a foreign lock and no lock at all
+		current := &State{}
+		has, err := db.GetEngine(ctx).ID(s.ID).Get(current)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return ErrStateNotExist
+		}
+		*s = *current
+		if s.IsLocked() {
+			return ErrStateLocked
+		}
+		return ErrStateNotLocked
+	}
+	s.LockID, s.LockInfo, s.LockerID, s.LockedUnix, s.Locker = "", "", 0, 0, nil
+	return nil
+}
+
+// AddStateVersion adds the version to the state with the next version number. If the state is locked, lockID must
+// match the id of the lock, otherwise ErrStateLocked is returned. The lock is checked by the same conditional update
+// which reserves the version number, so the state can't be locked by somebody else in the meantime.
+func AddStateVersion(ctx context.Context, s *State, lockID string, sv *StateVersion) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		n, err := db.GetEngine(ctx).
+			Where(builder.Eq{"id": s.ID}.And(builder.Eq{"lock_id": ""}.Or(builder.Eq{"lock_id": lockID}))).
+			Incr("latest_version").
+			Update(&State{})
+		if err != nil {
+			return err
+		}
+
+		current := &State{}
+		has, err := db.GetEngine(ctx).ID(s.ID).Get(current)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return ErrStateNotExist
+		}
+		if n == 0 {
+			*s = *current
+			return ErrStateLocked
+		}
+		s.LatestVersion = current.LatestVersion
+
+		sv.RepoID = s.RepoID
+		sv.StateID = s.ID
+		sv.Version = s.LatestVersion
+		return db.Insert(ctx, sv)
+	})
+}
+
+// GetStateVersion gets the version of the state
+func GetStateVersion(ctx context.Context, stateID, version int64) (*StateVersion, error) {
+	sv := &StateVersion{}
+	has, err := db.GetEngine(ctx).Where("state_id = ? AND version = ?", stateID, version).Get(sv)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateVersionNotExist
+	}
+	return sv, nil
+}
+
+// GetLatestStateVersion gets the most recent version of the state
+func GetLatestStateVersion(ctx context.Context, stateID int64) (*StateVersion, error) {
+	sv := &StateVersion{}
+	has, err := db.GetEngine(ctx).Where("state_id = ?", stateID).Desc("version").Get(sv)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateVersionNotExist
+	}
+	return sv, nil
+}
+
+// FindStateVersions returns the versions of the state, the most recent version first
+func FindStateVersions(ctx context.Context, stateID int64, opts db.ListOptions) ([]*StateVersion, int64, error) {
+	sess := db.GetEngine(ctx).Where("state_id = ?", stateID).Desc("version")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	versions := make([]*StateVersion, 0, 10)
+	count, err := sess.FindAndCount(&versions)
+	return versions, count, err
+}
+
+// FindOutdatedStateVersions returns the versions of the state which are older than the keep most recent versions
+func FindOutdatedStateVersions(ctx context.Context, stateID int64, keep int) ([]*StateVersion, error) {
+	versions := make([]*StateVersion, 0, 10)
+	return versions, db.GetEngine(ctx).
+		Where("state_id = ?", stateID).
+		Desc("version").
+		Limit(1000, keep).
+		Find(&versions)
+}
+
+// DeleteStateVersionByID deletes the version
+func DeleteStateVersionByID(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(&StateVersion{})
+	return err
+}
+
+// DeleteState deletes the state and all of its versions, the deleted versions are returned
+func DeleteState(ctx context.Context, s *State) ([]*StateVersion, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) ([]*StateVersion, error) {
+		versions := make([]*StateVersion, 0, 10)
+		if err := db.GetEngine(ctx).Where("state_id = ?", s.ID).Find(&versions); err != nil {
+			return nil, err
+		}
+		if _, err := db.GetEngine(ctx).Where("state_id = ?", s.ID).Delete(&StateVersion{}); err != nil {
+			return nil, err
+		}
+		if _, err := db.GetEngine(ctx).ID(s.ID).Delete(&State{}); err != nil {
+			return nil, err
+		}
+		return versions, nil
+	})
+}
+
+// DeleteStatesByRepoID deletes all states of the repository, the deleted versions are returned
+func DeleteStatesByRepoID(ctx context.Context, repoID int64) ([]*StateVersion, error) {
+	versions := make([]*StateVersion, 0, 10)
+	if err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Find(&versions); err != nil {
+		return nil, err
+	}
+	if err := db.DeleteBeans(ctx, &StateVersion{RepoID: repoID}, &State{RepoID: repoID}); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
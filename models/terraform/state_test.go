@@ -0,0 +1,100 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateLock(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	s, err := terraform_model.GetOrCreateState(t.Context(), 1, "production")
+	require.NoError(t, err)
+	assert.False(t, s.IsLocked())
+	assert.Nil(t, s.ParsedLockInfo())
+
+	s2, err := terraform_model.GetOrCreateState(t.Context(), 1, "production")
+	require.NoError(t, err)
+	assert.Equal(t, s.ID, s2.ID)
+
+	require.NoError(t, terraform_model.LockState(t.Context(), s, "lock-1", `{"ID":"lock-1","Operation":"OperationTypeApply","Who":"user@host"}`, 2))
+	assert.True(t, s.IsLocked())
+	info := s.ParsedLockInfo()
+	assert.Equal(t, "lock-1", info.ID)
+	assert.Equal(t, "OperationTypeApply", info.Operation)
+	assert.Equal(t, "user@host", info.Who)
+
+	assert.ErrorIs(t, terraform_model.LockState(t.Context(), s2, "lock-2", `{"ID":"lock-2"}`, 2), terraform_model.ErrStateLocked)
+
+	assert.ErrorIs(t, terraform_model.UnlockState(t.Context(), s2, "lock-2"), terraform_model.ErrStateLocked)
+	assert.Equal(t, "lock-1", s2.LockID)
+
+	require.NoError(t, terraform_model.UnlockState(t.Context(), s, "lock-1"))
+	assert.False(t, s.IsLocked())
+	assert.ErrorIs(t, terraform_model.UnlockState(t.Context(), s, "lock-1"), terraform_model.ErrStateNotLocked)
+
+	// a lock can be removed without knowing its id
+	require.NoError(t, terraform_model.LockState(t.Context(), s, "lock-3", `{"ID":"lock-3"}`, 2))
+	require.NoError(t, terraform_model.UnlockState(t.Context(), s, ""))
+	assert.ErrorIs(t, terraform_model.UnlockState(t.Context(), s, ""), terraform_model.ErrStateNotLocked)
+}
+
+func TestStateVersions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	s, err := terraform_model.GetOrCreateState(t.Context(), 1, "staging")
+	require.NoError(t, err)
+
+	_, err = terraform_model.GetLatestStateVersion(t.Context(), s.ID)
+	assert.ErrorIs(t, err, terraform_model.ErrStateVersionNotExist)
+
+	for serial := int64(1); serial <= 3; serial++ {
+		sv := &terraform_model.StateVersion{Serial: serial, Lineage: "lineage", Size: 10, CreatorID: 2}
+		require.NoError(t, terraform_model.AddStateVersion(t.Context(), s, "", sv))
+		assert.Equal(t, serial, sv.Version)
+		assert.Equal(t, s.ID, sv.StateID)
+		assert.Equal(t, int64(1), sv.RepoID)
+	}
+	assert.EqualValues(t, 3, s.LatestVersion)
+
+	// a locked state only accepts versions with the id of its lock
+	require.NoError(t, terraform_model.LockState(t.Context(), s, "lock-1", `{"ID":"lock-1"}`, 2))
+	err = terraform_model.AddStateVersion(t.Context(), s, "lock-2", &terraform_model.StateVersion{Serial: 4, CreatorID: 2})
+	assert.ErrorIs(t, err, terraform_model.ErrStateLocked)
+	assert.EqualValues(t, 3, s.LatestVersion)
+	sv := &terraform_model.StateVersion{Serial: 4, Lineage: "lineage", Size: 10, CreatorID: 2}
+	require.NoError(t, terraform_model.AddStateVersion(t.Context(), s, "lock-1", sv))
+	assert.EqualValues(t, 4, sv.Version)
+	require.NoError(t, terraform_model.UnlockState(t.Context(), s, "lock-1"))
+
+	latest, err := terraform_model.GetLatestStateVersion(t.Context(), s.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, latest.Version)
+
+	versions, count, err := terraform_model.FindStateVersions(t.Context(), s.ID, db.ListOptions{Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, count)
+	assert.Len(t, versions, 2)
+	assert.EqualValues(t, 4, versions[0].Version)
+
+	outdated, err := terraform_model.FindOutdatedStateVersions(t.Context(), s.ID, 2)
+	require.NoError(t, err)
+	assert.Len(t, outdated, 2)
+	assert.EqualValues(t, 2, outdated[0].Version)
+
+	deleted, err := terraform_model.DeleteStatesByRepoID(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Len(t, deleted, 4)
+
+	_, err = terraform_model.GetStateByName(t.Context(), 1, "staging")
+	assert.ErrorIs(t, err, terraform_model.ErrStateNotExist)
+}
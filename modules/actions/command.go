@@ -0,0 +1,105 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The runners keep the workflow commands in the logs as they have been written by the steps,
+// the commands can be written in the GitHub format "::name key=value,key=value::data"
+// or in the Azure DevOps format "##[name key=value;key=value]data".
+var (
+	commandPatternGA  = regexp.MustCompile(`^::([^ ]+)( (.+))?::(.*)$`)
+	commandPatternADO = regexp.MustCompile(`^##\[([^ ]+)( (.+))?](.*)$`)
+)
+
+// WorkflowCommand is a workflow command written by a step to its output
+type WorkflowCommand struct {
+	Name       string
+	Properties map[string]string
+	Data       string
+}
+
+var (
+	commandDataUnescaper     = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%25", "%")
+	commandPropertyUnescaper = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%3A", ":", "%2C", ",", "%25", "%")
+)
+
+// ParseWorkflowCommand parses a line of log, it returns false if the line isn't a workflow command
+func ParseWorkflowCommand(line string) (*WorkflowCommand, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	separator := ","
+	m := commandPatternGA.FindStringSubmatch(line)
+	if m == nil {
+		separator = ";"
+		if m = commandPatternADO.FindStringSubmatch(line); m == nil {
+			return nil, false
+		}
+	}
+
+	cmd := &WorkflowCommand{
+		Name:       m[1],
+		Properties: make(map[string]string),
+		Data:       commandDataUnescaper.Replace(m[4]),
+	}
+	for pair := range strings.SplitSeq(m[3], separator) {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			continue
+		}
+		cmd.Properties[strings.TrimSpace(k)] = commandPropertyUnescaper.Replace(v)
+	}
+	return cmd, true
+}
+
+// AnnotationCommand is a workflow command creating an annotation, like "::error file=main.go,line=10::message"
+type AnnotationCommand struct {
+	Level       string // "notice", "warning" or "error"
+	Title       string
+	File        string
+	StartLine   int
+	EndLine     int
+	StartColumn int
+	EndColumn   int
+	Message     string
+}
+
+// ParseAnnotationCommand parses a line of log, it returns false if the line isn't an annotation command
+func ParseAnnotationCommand(line string) (*AnnotationCommand, bool) {
+	cmd, ok := ParseWorkflowCommand(line)
+	if !ok {
+		return nil, false
+	}
+	switch cmd.Name {
+	case "notice", "warning", "error":
+	default:
+		return nil, false
+	}
+
+	atoi := func(keys ...string) int {
+		for _, key := range keys {
+			if n, err := strconv.Atoi(cmd.Properties[key]); err == nil && n > 0 {
+				return n
+			}
+		}
+		return 0
+	}
+	a := &AnnotationCommand{
+		Level:       cmd.Name,
+		Title:       cmd.Properties["title"],
+		File:        strings.TrimPrefix(cmd.Properties["file"], "./"),
+		StartLine:   atoi("line"),
+		EndLine:     atoi("endLine", "line"),
+		StartColumn: atoi("col"),
+		EndColumn:   atoi("endColumn", "col"),
+		Message:     cmd.Data,
+	}
+	if a.EndLine < a.StartLine {
+		a.EndLine = a.StartLine
+	}
+	return a, true
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWorkflowCommand(t *testing.T) {
+	cmd, ok := ParseWorkflowCommand("::set-output name=foo::bar")
+	assert.True(t, ok)
+	assert.Equal(t, &WorkflowCommand{Name: "set-output", Properties: map[string]string{"name": "foo"}, Data: "bar"}, cmd)
+
+	cmd, ok = ParseWorkflowCommand("##[warning file=a.go;line=3]multi%0Aline 100%25")
+	assert.True(t, ok)
+	assert.Equal(t, &WorkflowCommand{Name: "warning", Properties: map[string]string{"file": "a.go", "line": "3"}, Data: "multi\nline 100%"}, cmd)
+
+	cmd, ok = ParseWorkflowCommand("::endgroup::")
+	assert.True(t, ok)
+	assert.Equal(t, &WorkflowCommand{Name: "endgroup", Properties: map[string]string{}}, cmd)
+
+	_, ok = ParseWorkflowCommand("echo ::error::message")
+	assert.False(t, ok)
+	_, ok = ParseWorkflowCommand(":: not a command")
+	assert.False(t, ok)
+}
+
+func TestParseAnnotationCommand(t *testing.T) {
+	cases := []struct {
+		line     string
+		expected *AnnotationCommand
+	}{
+		{
+			line:     "::error::Something went wrong",
+			expected: &AnnotationCommand{Level: "error", Message: "Something went wrong"},
+		},
+		{
+			line: "::warning file=./cmd/main.go,line=10,endLine=12,col=3,title=Deprecated%3A API::Use the new API",
+			expected: &AnnotationCommand{
+				Level:       "warning",
+				Title:       "Deprecated: API",
+				File:        "cmd/main.go",
+				StartLine:   10,
+				EndLine:     12,
+				StartColumn: 3,
+				EndColumn:   3,
+				Message:     "Use the new API",
+			},
+		},
+		{
+			line:     "::notice file=README.md,line=5,endLine=2::Typo",
+			expected: &AnnotationCommand{Level: "notice", File: "README.md", StartLine: 5, EndLine: 5, Message: "Typo"},
+		},
+		{
+			line:     "::notice file=README.md,line=abc::Invalid line",
+			expected: &AnnotationCommand{Level: "notice", File: "README.md", Message: "Invalid line"},
+		},
+		{
+			line:     "::debug::Not an annotation",
+			expected: nil,
+		},
+		{
+			line:     "plain output",
+			expected: nil,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.line, func(t *testing.T) {
+			a, ok := ParseAnnotationCommand(c.line)
+			assert.Equal(t, c.expected != nil, ok)
+			assert.Equal(t, c.expected, a)
+		})
+	}
+}
@@ -202,6 +202,37 @@ func OpenLogs(ctx context.Context, inStorage bool, filename string) (io.ReadSeek
 	return reader, nil
 }
 
+// LogMatch is a line of the logs which matches a search
+type LogMatch struct {
+	Index   int64 // the index of the line in the logs
+	Time    time.Time
+	Content string
+}
+
+// SearchLogs returns the lines of the logs which contain the keyword case-insensitively, up to limit lines are returned
+func SearchLogs(r io.Reader, keyword string, limit int) ([]*LogMatch, error) {
+	keyword = strings.ToLower(keyword)
+
+	scanner := bufio.NewScanner(r)
+	maxLineSize := len(timeFormat) + MaxLineSize + 1
+	scanner.Buffer(make([]byte, maxLineSize), maxLineSize)
+
+	var matches []*LogMatch
+	for index := int64(0); scanner.Scan() && len(matches) < limit; index++ {
+		t, c, err := ParseLog(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("parse log %q: %w", scanner.Text(), err)
+		}
+		if strings.Contains(strings.ToLower(c), keyword) {
+			matches = append(matches, &LogMatch{Index: index, Time: t, Content: c})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("SearchLogs scan: %w", err)
+	}
+	return matches, nil
+}
+
 func FormatLog(timestamp time.Time, content string) string {
 	// Content shouldn't contain new line, it will break log indexes, other control chars are safe.
 	content = strings.ReplaceAll(content, "\n", `\n`)
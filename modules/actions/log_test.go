@@ -0,0 +1,40 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchLogs(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	logs := strings.Join([]string{
+		FormatLog(now, "go test ./..."),
+		FormatLog(now, "--- FAIL: TestFoo"),
+		FormatLog(now, "ok  	pkg/bar"),
+		FormatLog(now, "FAIL	pkg/foo"),
+		FormatLog(now, "fail again"),
+	}, "\n") + "\n"
+
+	matches, err := SearchLogs(strings.NewReader(logs), "Fail", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*LogMatch{
+		{Index: 1, Time: now, Content: "--- FAIL: TestFoo"},
+		{Index: 3, Time: now, Content: "FAIL	pkg/foo"},
+		{Index: 4, Time: now, Content: "fail again"},
+	}, matches)
+
+	matches, err = SearchLogs(strings.NewReader(logs), "fail", 2)
+	require.NoError(t, err)
+	assert.Len(t, matches, 2)
+
+	matches, err = SearchLogs(strings.NewReader(logs), "not found", 10)
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
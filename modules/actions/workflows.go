@@ -25,6 +25,8 @@ type DetectedWorkflow struct {
 	EntryName    string
 	TriggerEvent *jobparser.Event
 	Content      []byte
+	// RequiredWorkflowID is the id of the required workflow of the organization, 0 for the workflows of the repository
+	RequiredWorkflowID int64
 }
 
 func init() {
@@ -44,6 +46,43 @@ func IsWorkflow(path string) bool {
 	return strings.HasPrefix(path, ".gitea/workflows") || strings.HasPrefix(path, ".github/workflows")
 }
 
+// ReusableWorkflowRef is the parsed `uses` of a job which calls a reusable workflow
+type ReusableWorkflowRef struct {
+	// IsLocal is true for `./.gitea/workflows/x.yml`, the workflow is read from the same commit as the caller
+	IsLocal bool
+	Owner   string
+	Repo    string
+	Path    string
+	Ref     string
+}
+
+// ParseReusableWorkflowRef parses the `uses` of a job,
+// it supports `./.gitea/workflows/x.yml` and `owner/repo/.gitea/workflows/x.yml@ref` (or `.github/workflows`).
+// The second return value is false if `uses` doesn't refer to a reusable workflow.
+func ParseReusableWorkflowRef(uses string) (*ReusableWorkflowRef, bool) {
+	if after, ok := strings.CutPrefix(uses, "./"); ok {
+		if !IsWorkflow(after) {
+			return nil, false
+		}
+		return &ReusableWorkflowRef{IsLocal: true, Path: after}, true
+	}
+
+	idx := strings.LastIndex(uses, "@")
+	if idx <= 0 || idx == len(uses)-1 {
+		return nil, false
+	}
+	fields := strings.SplitN(uses[:idx], "/", 3)
+	if len(fields) != 3 || fields[0] == "" || fields[1] == "" || !IsWorkflow(fields[2]) {
+		return nil, false
+	}
+	return &ReusableWorkflowRef{
+		Owner: fields[0],
+		Repo:  fields[1],
+		Path:  fields[2],
+		Ref:   uses[idx+1:],
+	}, true
+}
+
 func ListWorkflows(commit *git.Commit) (string, git.Entries, error) {
 	rpath := ".gitea/workflows"
 	tree, err := commit.SubTree(rpath)
@@ -149,6 +188,33 @@ func DetectWorkflows(
 	return workflows, schedules, nil
 }
 
+// DetectWorkflowFromContent returns the workflows of the content which are triggered by the event of the commit,
+// the content could come from another repository, like the required workflows of an organization
+func DetectWorkflowFromContent(
+	gitRepo *git.Repository,
+	commit *git.Commit,
+	entryName string,
+	content []byte,
+	triggedEvent webhook_module.HookEventType,
+	payload api.Payloader,
+) ([]*DetectedWorkflow, error) {
+	events, err := GetEventsFromContent(content)
+	if err != nil {
+		return nil, err
+	}
+	var workflows []*DetectedWorkflow
+	for _, evt := range events {
+		if !evt.IsSchedule() && detectMatched(gitRepo, commit, triggedEvent, payload, evt) {
+			workflows = append(workflows, &DetectedWorkflow{
+				EntryName:    entryName,
+				TriggerEvent: evt,
+				Content:      content,
+			})
+		}
+	}
+	return workflows, nil
+}
+
 func DetectScheduledWorkflows(gitRepo *git.Repository, commit *git.Commit) ([]*DetectedWorkflow, error) {
 	_, entries, err := ListWorkflows(commit)
 	if err != nil {
@@ -250,6 +316,10 @@ func detectMatched(gitRepo *git.Repository, commit *git.Commit, triggedEvent web
 		webhook_module.HookEventWorkflowRun:
 		return matchWorkflowRunEvent(payload.(*api.WorkflowRunPayload), evt)
 
+	case // merge_group
+		webhook_module.HookEventMergeGroup:
+		return matchMergeGroupEvent(payload.(*api.MergeGroupPayload), evt)
+
 	default:
 		log.Warn("unsupported event %q", triggedEvent)
 		return false
@@ -753,3 +823,48 @@ func matchWorkflowRunEvent(payload *api.WorkflowRunPayload, evt *jobparser.Event
 	}
 	return matchTimes == len(evt.Acts())
 }
+
+func matchMergeGroupEvent(payload *api.MergeGroupPayload, evt *jobparser.Event) bool {
+	// with no special filter parameters
+	if len(evt.Acts()) == 0 {
+		return true
+	}
+
+	matchTimes := 0
+	// all acts conditions should be satisfied
+	for cond, vals := range evt.Acts() {
+		switch cond {
+		case "types":
+			// See https://docs.github.com/en/actions/using-workflows/events-that-trigger-workflows#merge_group
+			// Activity types with the same name:
+			// checks_requested
+			for _, val := range vals {
+				if glob.MustCompile(val, '/').Match(payload.Action) {
+					matchTimes++
+					break
+				}
+			}
+		case "branches":
+			refName := git.RefName(payload.MergeGroup.BaseRef)
+			patterns, err := workflowpattern.CompilePatterns(vals...)
+			if err != nil {
+				break
+			}
+			if !workflowpattern.Skip(patterns, []string{refName.BranchName()}, &workflowpattern.EmptyTraceWriter{}) {
+				matchTimes++
+			}
+		case "branches-ignore":
+			refName := git.RefName(payload.MergeGroup.BaseRef)
+			patterns, err := workflowpattern.CompilePatterns(vals...)
+			if err != nil {
+				break
+			}
+			if !workflowpattern.Filter(patterns, []string{refName.BranchName()}, &workflowpattern.EmptyTraceWriter{}) {
+				matchTimes++
+			}
+		default:
+			log.Warn("merge group event unsupported condition %q", cond)
+		}
+	}
+	return matchTimes == len(evt.Acts())
+}
@@ -125,6 +125,26 @@ func TestDetectMatched(t *testing.T) {
 			yamlOn:       "on: schedule",
 			expected:     true,
 		},
+		{
+			desc:         "HookEventMergeGroup(merge_group) matches GithubEventMergeGroup(merge_group)",
+			triggedEvent: webhook_module.HookEventMergeGroup,
+			payload: &api.MergeGroupPayload{
+				Action:     "checks_requested",
+				MergeGroup: &api.MergeGroup{BaseRef: "refs/heads/main", HeadRef: "refs/heads/gitea-mq/main/1"},
+			},
+			yamlOn:   "on:\n  merge_group:\n    types: [checks_requested]\n    branches: [main]",
+			expected: true,
+		},
+		{
+			desc:         "HookEventMergeGroup(merge_group) doesn't match GithubEventMergeGroup(merge_group) with other base branch",
+			triggedEvent: webhook_module.HookEventMergeGroup,
+			payload: &api.MergeGroupPayload{
+				Action:     "checks_requested",
+				MergeGroup: &api.MergeGroup{BaseRef: "refs/heads/release", HeadRef: "refs/heads/gitea-mq/release/1"},
+			},
+			yamlOn:   "on:\n  merge_group:\n    branches: [main]",
+			expected: false,
+		},
 		{
 			desc:         "push to tag matches workflow with paths condition (should skip paths check)",
 			triggedEvent: webhook_module.HookEventPush,
@@ -335,3 +355,36 @@ func TestMatchIssuesEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReusableWorkflowRef(t *testing.T) {
+	testCases := []struct {
+		uses     string
+		expected *ReusableWorkflowRef
+	}{
+		{
+			uses:     "./.gitea/workflows/build.yml",
+			expected: &ReusableWorkflowRef{IsLocal: true, Path: ".gitea/workflows/build.yml"},
+		},
+		{
+			uses:     "org/shared/.github/workflows/build.yaml@v1",
+			expected: &ReusableWorkflowRef{Owner: "org", Repo: "shared", Path: ".github/workflows/build.yaml", Ref: "v1"},
+		},
+		{
+			uses:     "org/shared/.gitea/workflows/ci/build.yml@refs/heads/main",
+			expected: &ReusableWorkflowRef{Owner: "org", Repo: "shared", Path: ".gitea/workflows/ci/build.yml", Ref: "refs/heads/main"},
+		},
+		{uses: "actions/checkout@v4"},
+		{uses: "org/shared/.gitea/workflows/build.yml"},
+		{uses: "org/shared/.gitea/workflows/build.yml@"},
+		{uses: "org/shared/scripts/build.yml@v1"},
+		{uses: "./.gitea/actions/build"},
+		{uses: "docker://alpine:3"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.uses, func(t *testing.T) {
+			ref, ok := ParseReusableWorkflowRef(tc.uses)
+			assert.Equal(t, tc.expected != nil, ok)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
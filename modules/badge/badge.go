@@ -79,6 +79,8 @@ var GlobalVars = sync.OnceValue(func() (ret struct {
 		actions_model.StatusWaiting:   "#dfb317", // Yellow
 		actions_model.StatusRunning:   "#dfb317", // Yellow
 		actions_model.StatusBlocked:   "#dfb317", // Yellow
+
+		actions_model.StatusPendingDeployment: "#dfb317", // Yellow
 	}
 	ret.DejaVuGlyphWidthData = dejaVuGlyphWidthDataFunc()
 	ret.AllStyles = []string{StyleFlat, StyleFlatSquare}
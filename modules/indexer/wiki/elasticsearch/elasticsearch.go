@@ -0,0 +1,229 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package elasticsearch
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/modules/indexer"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_elasticsearch "code.gitea.io/gitea/modules/indexer/internal/elasticsearch"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/olivere/elastic/v7"
+)
+
+const (
+	esWikiIndexerLatestVersion = 1
+	// multi-match-types, currently only 2 types are used
+	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
+	esMultiMatchTypeBestFields   = "best_fields"
+	esMultiMatchTypePhrasePrefix = "phrase_prefix"
+
+	highlightPreTag  = "<em>"
+	highlightPostTag = "</em>"
+)
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_elasticsearch.Indexer
+	indexer_internal.Indexer // do not composite inner_elasticsearch.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWords()
+}
+
+// NewIndexer creates a new elasticsearch indexer
+func NewIndexer(url, indexerName string) *Indexer {
+	inner := inner_elasticsearch.NewIndexer(url, indexerName, esWikiIndexerLatestVersion, defaultMapping)
+	indexer := &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}
+	return indexer
+}
+
+const (
+	defaultMapping = `{
+		"settings": {
+			"analysis": {
+				"analyzer": {
+					"content_analyzer": {
+						"tokenizer": "content_tokenizer",
+						"filter" : ["lowercase"]
+					}
+				},
+				"tokenizer": {
+					"content_tokenizer": {
+						"type": "simple_pattern_split",
+						"pattern": "[^a-zA-Z0-9]"
+					}
+				}
+			}
+		},
+		"mappings": {
+			"properties": {
+				"repo_id": {
+					"type": "long",
+					"index": true
+				},
+				"filename": {
+					"type": "text",
+					"index": true,
+					"analyzer": "content_analyzer"
+				},
+				"content": {
+					"type": "text",
+					"term_vector": "with_positions_offsets",
+					"index": true,
+					"analyzer": "content_analyzer"
+				},
+				"commit_id": {
+					"type": "keyword",
+					"index": true
+				},
+				"updated_at": {
+					"type": "long",
+					"index": true
+				}
+			}
+		}
+	}`
+)
+
+// Index will save the index data
+func (b *Indexer) Index(ctx context.Context, repoID int64, sha string, changes *internal.WikiChanges) error {
+	reqs := make([]elastic.BulkableRequest, 0, len(changes.Updates)+len(changes.RemovedFilenames))
+	for _, update := range changes.Updates {
+		reqs = append(reqs, elastic.NewBulkIndexRequest().
+			Index(b.inner.VersionedIndexName()).
+			Id(internal.FilenameIndexerID(repoID, update.Filename)).
+			Doc(map[string]any{
+				"repo_id":    repoID,
+				"filename":   update.Filename,
+				"content":    update.Content,
+				"commit_id":  sha,
+				"updated_at": timeutil.TimeStampNow(),
+			}))
+	}
+	for _, filename := range changes.RemovedFilenames {
+		reqs = append(reqs, elastic.NewBulkDeleteRequest().
+			Index(b.inner.VersionedIndexName()).
+			Id(internal.FilenameIndexerID(repoID, filename)))
+	}
+
+	esBatchSize := 50
+	for i := 0; i < len(reqs); i += esBatchSize {
+		_, err := b.inner.Client.Bulk().
+			Index(b.inner.VersionedIndexName()).
+			Add(reqs[i:min(i+esBatchSize, len(reqs))]...).
+			Do(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete deletes all pages of a wiki
+func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
+	_, err := b.inner.Client.DeleteByQuery(b.inner.VersionedIndexName()).
+		Query(elastic.NewTermsQuery("repo_id", repoID)).
+		Refresh("true").
+		Do(ctx)
+	return err
+}
+
+// highlightMatchIndexPos returns the boundaries of the first highlighted keyword in the original content,
+// it returns -1, -1 if there is no highlighted keyword.
+func highlightMatchIndexPos(highlighted string) (int, int) {
+	startIdx := strings.Index(highlighted, highlightPreTag)
+	if startIdx < 0 {
+		return -1, -1
+	}
+	endIdx := strings.Index(highlighted[startIdx:], highlightPostTag)
+	if endIdx < 0 {
+		return -1, -1
+	}
+	return startIdx, startIdx + endIdx - len(highlightPreTag)
+}
+
+// Search searches for wiki pages by given conditions.
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, error) {
+	var contentQuery elastic.Query
+	searchMode := util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue)
+	if searchMode == indexer.SearchModeExact {
+		contentQuery = elastic.NewMatchPhraseQuery("content", opts.Keyword)
+	} else /* words */ {
+		contentQuery = elastic.NewMultiMatchQuery(opts.Keyword, "content").Type(esMultiMatchTypeBestFields).Operator("and")
+	}
+	query := elastic.NewBoolQuery().Must(elastic.NewBoolQuery().Should(
+		contentQuery,
+		elastic.NewMultiMatchQuery(opts.Keyword, "filename^10").Type(esMultiMatchTypePhrasePrefix),
+	))
+	if len(opts.RepoIDs) > 0 {
+		repoIDs := make([]any, 0, len(opts.RepoIDs))
+		for _, repoID := range opts.RepoIDs {
+			repoIDs = append(repoIDs, repoID)
+		}
+		query = query.Must(elastic.NewTermsQuery("repo_id", repoIDs...))
+	}
+
+	skip, limit := indexer_internal.ParsePaginator(opts.Paginator)
+	searchResult, err := b.inner.Client.Search().
+		Index(b.inner.VersionedIndexName()).
+		Query(query).
+		Highlight(
+			elastic.NewHighlight().
+				Field("content").
+				NumOfFragments(0). // return the whole content, so the positions of the keywords can be found
+				PreTags(highlightPreTag).
+				PostTags(highlightPostTag).
+				HighlighterType("fvh"),
+		).
+		Sort("_score", false).
+		Sort("updated_at", true).
+		From(skip).Size(limit).
+		Do(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	hits := make([]*internal.SearchResult, 0, len(searchResult.Hits.Hits))
+	for _, hit := range searchResult.Hits.Hits {
+		var res struct {
+			RepoID    int64  `json:"repo_id"`
+			Filename  string `json:"filename"`
+			Content   string `json:"content"`
+			CommitID  string `json:"commit_id"`
+			UpdatedAt int64  `json:"updated_at"`
+		}
+		if err := json.Unmarshal(hit.Source, &res); err != nil {
+			return 0, nil, err
+		}
+
+		startIndex, endIndex := -1, -1
+		if c, ok := hit.Highlight["content"]; ok && len(c) > 0 {
+			startIndex, endIndex = highlightMatchIndexPos(c[0])
+		}
+
+		hits = append(hits, &internal.SearchResult{
+			RepoID:      res.RepoID,
+			Filename:    res.Filename,
+			Content:     res.Content,
+			CommitID:    res.CommitID,
+			UpdatedUnix: timeutil.TimeStamp(res.UpdatedAt),
+			StartIndex:  startIndex,
+			EndIndex:    endIndex,
+		})
+	}
+	return searchResult.TotalHits(), hits, nil
+}
@@ -0,0 +1,16 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightMatchIndexPos(t *testing.T) {
+	startIdx, endIdx := highlightMatchIndexPos("test <em>index</em> start")
+	assert.Equal(t, 5, startIdx)
+	assert.Equal(t, 10, endIdx)
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// getWikiBranchSha returns the head commit of the default wiki branch, it's empty if the wiki doesn't exist
+func getWikiBranchSha(ctx context.Context, repo *repo_model.Repository) (string, error) {
+	if exist, err := gitrepo.IsRepositoryExist(ctx, repo.WikiStorageRepo()); err != nil {
+		return "", err
+	} else if !exist || !gitrepo.IsBranchExist(ctx, repo.WikiStorageRepo(), repo.DefaultWikiBranch) {
+		return "", nil
+	}
+	stdout, err := gitrepo.RunCmdString(ctx, repo.WikiStorageRepo(), gitcmd.NewCommand("show-ref", "-s").AddDynamicArguments(git.BranchPrefix+repo.DefaultWikiBranch))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// isWikiPage returns whether the file is a wiki page, the other files of a wiki (e.g. images) are not indexed
+func isWikiPage(filename string) bool {
+	return strings.HasSuffix(filename, ".md")
+}
+
+// getWikiChanges returns changes to the wiki since last indexer update
+func getWikiChanges(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, revision string) (*internal.WikiChanges, error) {
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeWiki)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(status.CommitSha) == 0 {
+		return genesisChanges(ctx, indexer, repo, revision)
+	}
+
+	diffCmd := gitcmd.NewCommand("diff", "--name-status", "--no-renames", "-z").AddDynamicArguments(status.CommitSha, revision)
+	stdout, runErr := gitrepo.RunCmdString(ctx, repo.WikiStorageRepo(), diffCmd)
+	if runErr != nil {
+		// previous commit sha may have been removed by a force push or by deleting the wiki,
+		// so try rebuilding from scratch
+		log.Warn("git diff: %v", runErr)
+		return genesisChanges(ctx, indexer, repo, revision)
+	}
+
+	var updatedFilenames, removedFilenames []string
+	fields := strings.Split(strings.TrimSuffix(stdout, "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		diffStatus, filename := fields[i], fields[i+1]
+		if !isWikiPage(filename) {
+			continue
+		}
+		if diffStatus == "D" {
+			removedFilenames = append(removedFilenames, filename)
+		} else {
+			updatedFilenames = append(updatedFilenames, filename)
+		}
+	}
+	changes, err := readWikiPages(ctx, repo, revision, updatedFilenames)
+	if err != nil {
+		return nil, err
+	}
+	changes.RemovedFilenames = append(changes.RemovedFilenames, removedFilenames...)
+	return changes, nil
+}
+
+// genesisChanges get changes to add the wiki to the indexer from scratch
+func genesisChanges(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, revision string) (*internal.WikiChanges, error) {
+	// the pages which have been indexed before may have been removed
+	if err := indexer.Delete(ctx, repo.ID); err != nil {
+		return nil, err
+	}
+
+	stdout, _, err := gitrepo.RunCmdBytes(ctx, repo.WikiStorageRepo(), gitcmd.NewCommand("ls-tree", "--full-tree", "-r").AddDynamicArguments(revision))
+	if err != nil {
+		return nil, err
+	}
+	entries, err := git.ParseTreeEntries(stdout)
+	if err != nil {
+		return nil, err
+	}
+	filenames := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsRegular() && isWikiPage(entry.Name()) {
+			filenames = append(filenames, entry.Name())
+		}
+	}
+	return readWikiPages(ctx, repo, revision, filenames)
+}
+
+// readWikiPages reads the contents of the given pages at the revision, the pages which are too large are skipped
+func readWikiPages(ctx context.Context, repo *repo_model.Repository, revision string, filenames []string) (*internal.WikiChanges, error) {
+	changes := &internal.WikiChanges{}
+	if len(filenames) == 0 {
+		return changes, nil
+	}
+
+	batch, err := gitrepo.NewBatch(ctx, repo.WikiStorageRepo())
+	if err != nil {
+		return nil, err
+	}
+	defer batch.Close()
+
+	for _, filename := range filenames {
+		info, batchReader, err := batch.QueryContent(revision + ":" + filename)
+		if err != nil {
+			return nil, err
+		}
+		if info.Size > setting.Indexer.MaxIndexerFileSize {
+			if _, err := batchReader.Discard(int(info.Size) + 1); err != nil {
+				return nil, err
+			}
+			changes.RemovedFilenames = append(changes.RemovedFilenames, filename)
+			continue
+		}
+		content, err := io.ReadAll(io.LimitReader(batchReader, info.Size))
+		if err != nil {
+			return nil, err
+		}
+		if _, err = batchReader.Discard(1); err != nil {
+			return nil, err
+		}
+		changes.Updates = append(changes.Updates, internal.PageUpdate{
+			Filename: filename,
+			Content:  string(charset.ToUTF8DropErrors(content)),
+		})
+	}
+	return changes, nil
+}
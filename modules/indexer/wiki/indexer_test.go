@@ -0,0 +1,147 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"html/template"
+	"os"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	indexer_module "code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/wiki/bleve"
+	"code.gitea.io/gitea/modules/indexer/wiki/elasticsearch"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+
+	_ "code.gitea.io/gitea/models"
+	_ "code.gitea.io/gitea/models/actions"
+	_ "code.gitea.io/gitea/models/activities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func testIndexer(name string, t *testing.T, indexer internal.Indexer) {
+	t.Run(name, func(t *testing.T) {
+		// the wiki of repo1 has 4 pages, the other files (e.g. images) are not indexed
+		require.NoError(t, index(t.Context(), indexer, 1))
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		status, err := repo_model.GetIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeWiki)
+		require.NoError(t, err)
+		assert.NotEmpty(t, status.CommitSha)
+
+		cases := []struct {
+			Keyword    string
+			SearchMode indexer_module.SearchModeType
+			Filenames  []string
+			Snippet    template.HTML
+		}{
+			{
+				Keyword:   "spaces",
+				Filenames: []string{"Page-With-Spaced-Name.md"},
+				// the snippet keeps one line around the match, which is the empty line after the heading
+				Snippet: `This is a page with a name with <span class="search-highlight">spaces</span> (which will be replaced with &#39;-&#39;)`,
+			},
+			{
+				// matches the filename only
+				Keyword:   "image",
+				Filenames: []string{"Page-With-Image.md"},
+			},
+			{
+				Keyword:    "home page",
+				SearchMode: indexer_module.SearchModeExact,
+				Filenames:  []string{"Home.md", "Unescaped File.md"},
+			},
+			{
+				Keyword: "jpeg",
+				// images are not indexed, but the content of Page-With-Image.md refers to jpeg.jpg
+				Filenames: []string{"Page-With-Image.md"},
+			},
+			{
+				Keyword: "nothing",
+			},
+		}
+		for _, c := range cases {
+			t.Run(c.Keyword, func(t *testing.T) {
+				total, results, err := PerformSearch(t.Context(), &SearchOptions{
+					RepoIDs:    []int64{1},
+					Keyword:    c.Keyword,
+					SearchMode: c.SearchMode,
+					Paginator:  &db.ListOptions{Page: 1, PageSize: 10},
+				})
+				require.NoError(t, err)
+				assert.EqualValues(t, len(c.Filenames), total)
+				filenames := make([]string, 0, len(results))
+				for _, result := range results {
+					filenames = append(filenames, result.Filename)
+					assert.NotEmpty(t, result.Snippet)
+				}
+				assert.ElementsMatch(t, c.Filenames, filenames)
+				if c.Snippet != "" {
+					assert.Equal(t, c.Snippet, results[0].FormattedSnippet)
+				}
+			})
+		}
+
+		require.NoError(t, indexer.Delete(t.Context(), 1))
+		total, _, err := PerformSearch(t.Context(), &SearchOptions{
+			RepoIDs:   []int64{1},
+			Keyword:   "page",
+			Paginator: &db.ListOptions{Page: 1, PageSize: 10},
+		})
+		require.NoError(t, err)
+		assert.Zero(t, total)
+	})
+}
+
+func TestBleveIndexAndSearch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	idx := bleve.NewIndexer(t.TempDir())
+	defer idx.Close()
+
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	var indexer internal.Indexer = idx
+	globalIndexer.Store(&indexer)
+	defer func() {
+		dummyIndexer := internal.NewDummyIndexer()
+		globalIndexer.Store(&dummyIndexer)
+	}()
+
+	testIndexer("bleve", t, idx)
+}
+
+func TestESIndexAndSearch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	u := os.Getenv("TEST_INDEXER_WIKI_ES_URL")
+	if u == "" {
+		t.SkipNow()
+		return
+	}
+
+	idx := elasticsearch.NewIndexer(u, "gitea_wikis")
+	if _, err := idx.Init(t.Context()); err != nil {
+		idx.Close()
+		require.NoError(t, err, "Unable to init ES indexer")
+	}
+	defer idx.Close()
+
+	var indexer internal.Indexer = idx
+	globalIndexer.Store(&indexer)
+	defer func() {
+		dummyIndexer := internal.NewDummyIndexer()
+		globalIndexer.Store(&dummyIndexer)
+	}()
+
+	testIndexer("elastic_search", t, idx)
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/internal"
+)
+
+// Indexer defines an interface to index and search wiki pages
+type Indexer interface {
+	internal.Indexer
+	Index(ctx context.Context, repoID int64, sha string, changes *WikiChanges) error
+	Delete(ctx context.Context, repoID int64) error
+	Search(ctx context.Context, opts *SearchOptions) (int64, []*SearchResult, error)
+	SupportedSearchModes() []indexer.SearchMode
+}
+
+// SearchOptions represents the options of a wiki search
+type SearchOptions struct {
+	RepoIDs []int64
+	Keyword string
+
+	SearchMode indexer.SearchModeType
+
+	Paginator *db.ListOptions
+}
+
+// NewDummyIndexer returns a dummy indexer
+func NewDummyIndexer() Indexer {
+	return &dummyIndexer{
+		Indexer: internal.NewDummyIndexer(),
+	}
+}
+
+type dummyIndexer struct {
+	internal.Indexer
+}
+
+func (d *dummyIndexer) SupportedSearchModes() []indexer.SearchMode {
+	return nil
+}
+
+func (d *dummyIndexer) Index(ctx context.Context, repoID int64, sha string, changes *WikiChanges) error {
+	return errors.New("indexer is not ready")
+}
+
+func (d *dummyIndexer) Delete(ctx context.Context, repoID int64) error {
+	return errors.New("indexer is not ready")
+}
+
+func (d *dummyIndexer) Search(ctx context.Context, opts *SearchOptions) (int64, []*SearchResult, error) {
+	return 0, nil, errors.New("indexer is not ready")
+}
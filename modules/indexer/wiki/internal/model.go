@@ -0,0 +1,35 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import "code.gitea.io/gitea/modules/timeutil"
+
+// PageUpdate is an added or modified page of a wiki
+type PageUpdate struct {
+	Filename string
+	Content  string
+}
+
+// WikiChanges changes (page additions/updates/removals) to a wiki
+type WikiChanges struct {
+	Updates          []PageUpdate
+	RemovedFilenames []string
+}
+
+// IndexerData represents data stored in the wiki indexer
+type IndexerData struct {
+	RepoID int64
+}
+
+// SearchResult result of performing a search in a wiki
+type SearchResult struct {
+	RepoID      int64
+	Filename    string
+	Content     string
+	CommitID    string
+	UpdatedUnix timeutil.TimeStamp
+	// StartIndex and EndIndex are the boundaries of the first match in Content, they are -1 if only the filename matches
+	StartIndex int
+	EndIndex   int
+}
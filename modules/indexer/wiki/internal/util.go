@@ -0,0 +1,26 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"strings"
+
+	"code.gitea.io/gitea/modules/indexer/internal"
+	"code.gitea.io/gitea/modules/log"
+)
+
+// FilenameIndexerID returns the id of a wiki page in the indexer
+func FilenameIndexerID(repoID int64, filename string) string {
+	return internal.Base36(repoID) + "_" + filename
+}
+
+// ParseIndexerID returns the repo id and the filename of a wiki page by its id in the indexer
+func ParseIndexerID(indexerID string) (int64, string) {
+	before, after, ok := strings.Cut(indexerID, "_")
+	if !ok {
+		log.Error("Unexpected ID in wiki indexer: %s", indexerID)
+	}
+	repoID, _ := internal.ParseBase36(before)
+	return repoID, after
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package meilisearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/indexer"
+	indexer_internal "code.gitea.io/gitea/modules/indexer/internal"
+	inner_meilisearch "code.gitea.io/gitea/modules/indexer/internal/meilisearch"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/meilisearch/meilisearch-go"
+)
+
+const (
+	wikiIndexerLatestVersion = 1
+
+	// TODO: make this configurable if necessary
+	maxTotalHits = 10000
+)
+
+// ErrMalformedResponse is never expected as we initialize the indexer ourself and so define the types.
+var ErrMalformedResponse = errors.New("meilisearch returned unexpected malformed content")
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer implements Indexer interface
+type Indexer struct {
+	inner                    *inner_meilisearch.Indexer
+	indexer_internal.Indexer // do not composite inner_meilisearch.Indexer directly to avoid exposing too much
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWords()
+}
+
+// NewIndexer creates a new meilisearch indexer
+func NewIndexer(url, apiKey, indexerName string) *Indexer {
+	settings := &meilisearch.Settings{
+		// the filename has the highest priority in the "attribute" ranking rule
+		SearchableAttributes: []string{
+			"filename",
+			"content",
+		},
+		DisplayedAttributes: []string{
+			"id",
+			"repo_id",
+			"filename",
+			"content",
+			"commit_id",
+			"updated_unix",
+		},
+		FilterableAttributes: []string{
+			"repo_id",
+		},
+		SortableAttributes: []string{
+			"updated_unix",
+		},
+		Pagination: &meilisearch.Pagination{
+			MaxTotalHits: maxTotalHits,
+		},
+	}
+
+	inner := inner_meilisearch.NewIndexer(url, apiKey, indexerName, wikiIndexerLatestVersion, settings)
+	indexer := &Indexer{
+		inner:   inner,
+		Indexer: inner,
+	}
+	return indexer
+}
+
+type wikiDocument struct {
+	ID          string             `json:"id"`
+	RepoID      int64              `json:"repo_id"`
+	Filename    string             `json:"filename"`
+	Content     string             `json:"content"`
+	CommitID    string             `json:"commit_id"`
+	UpdatedUnix timeutil.TimeStamp `json:"updated_unix"`
+}
+
+// documentID returns the primary key of a page, which may only contain alphanumeric characters, hyphens and underscores.
+// See https://www.meilisearch.com/docs/learn/getting_started/primary_key#formatting-the-document-id
+func documentID(repoID int64, filename string) string {
+	return fmt.Sprintf("%d_%s", repoID, base.EncodeSha256(filename))
+}
+
+// Index will save the index data
+func (b *Indexer) Index(_ context.Context, repoID int64, sha string, changes *internal.WikiChanges) error {
+	if len(changes.Updates) > 0 {
+		docs := make([]*wikiDocument, 0, len(changes.Updates))
+		for _, update := range changes.Updates {
+			docs = append(docs, &wikiDocument{
+				ID:          documentID(repoID, update.Filename),
+				RepoID:      repoID,
+				Filename:    update.Filename,
+				Content:     update.Content,
+				CommitID:    sha,
+				UpdatedUnix: timeutil.TimeStampNow(),
+			})
+		}
+		if _, err := b.inner.Client.Index(b.inner.VersionedIndexName()).AddDocuments(docs, nil); err != nil {
+			return err
+		}
+	}
+	if len(changes.RemovedFilenames) > 0 {
+		ids := make([]string, 0, len(changes.RemovedFilenames))
+		for _, filename := range changes.RemovedFilenames {
+			ids = append(ids, documentID(repoID, filename))
+		}
+		if _, err := b.inner.Client.Index(b.inner.VersionedIndexName()).DeleteDocuments(ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete deletes all pages of a wiki
+func (b *Indexer) Delete(_ context.Context, repoID int64) error {
+	_, err := b.inner.Client.Index(b.inner.VersionedIndexName()).DeleteDocumentsByFilter(inner_meilisearch.NewFilterEq("repo_id", repoID).Statement())
+	return err
+}
+
+// Search searches for wiki pages by given conditions.
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, error) {
+	query := inner_meilisearch.FilterAnd{}
+	if len(opts.RepoIDs) > 0 {
+		query.And(inner_meilisearch.NewFilterIn("repo_id", opts.RepoIDs...))
+	}
+
+	skip, limit := indexer_internal.ParsePaginator(opts.Paginator, maxTotalHits)
+
+	counting := limit == 0
+	if counting {
+		// If set limit to 0, it will be 20 by default, and -1 is not allowed.
+		// See https://www.meilisearch.com/docs/reference/api/search#limit
+		// So set limit to 1 to make the cost as low as possible, then clear the result before returning.
+		limit = 1
+	}
+
+	keyword := opts.Keyword // default to match "words"
+	if opts.SearchMode == indexer.SearchModeExact {
+		// https://www.meilisearch.com/docs/reference/api/search#phrase-search
+		keyword = doubleQuoteKeyword(keyword)
+	}
+
+	searchRes, err := b.inner.Client.Index(b.inner.VersionedIndexName()).SearchWithContext(ctx, keyword, &meilisearch.SearchRequest{
+		Filter:              query.Statement(),
+		Limit:               int64(limit),
+		Offset:              int64(skip),
+		MatchingStrategy:    "all",
+		ShowMatchesPosition: true,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if counting {
+		searchRes.Hits = nil
+	}
+
+	hits, err := convertHits(searchRes)
+	if err != nil {
+		return 0, nil, err
+	}
+	return searchRes.EstimatedTotalHits, hits, nil
+}
+
+func doubleQuoteKeyword(k string) string {
+	kp := strings.Split(k, " ")
+	parts := 0
+	for i := range kp {
+		part := strings.Trim(kp[i], "\"")
+		if part != "" {
+			kp[parts] = fmt.Sprintf(`"%s"`, part)
+			parts++
+		}
+	}
+	return strings.Join(kp[:parts], " ")
+}
+
+type matchPosition struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+func convertHits(searchRes *meilisearch.SearchResponse) ([]*internal.SearchResult, error) {
+	hits := make([]*internal.SearchResult, 0, len(searchRes.Hits))
+	for _, hit := range searchRes.Hits {
+		var doc wikiDocument
+		if err := hit.Decode(&doc); err != nil {
+			return nil, ErrMalformedResponse
+		}
+
+		startIndex, endIndex := -1, -1
+		if raw, ok := hit["_matchesPosition"]; ok {
+			var positions map[string][]matchPosition
+			if err := json.Unmarshal(raw, &positions); err != nil {
+				return nil, ErrMalformedResponse
+			}
+			if matches := positions["content"]; len(matches) > 0 {
+				startIndex, endIndex = matches[0].Start, matches[0].Start+matches[0].Length
+			}
+		}
+
+		hits = append(hits, &internal.SearchResult{
+			RepoID:      doc.RepoID,
+			Filename:    doc.Filename,
+			Content:     doc.Content,
+			CommitID:    doc.CommitID,
+			UpdatedUnix: doc.UpdatedUnix,
+			StartIndex:  startIndex,
+			EndIndex:    endIndex,
+		})
+	}
+	return hits, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"html"
+	"html/template"
+	"strings"
+
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// snippetNumberOfLines is the number of lines of a snippet if only the filename of a page matches
+const snippetNumberOfLines = 3
+
+// Result a search result to display
+type Result struct {
+	RepoID      int64
+	Filename    string
+	CommitID    string
+	UpdatedUnix timeutil.TimeStamp
+	// Snippet is the plain text around the first match of the page content
+	Snippet string
+	// FormattedSnippet is the escaped Snippet with the match wrapped in a highlighting element
+	FormattedSnippet template.HTML
+}
+
+type SearchOptions = internal.SearchOptions
+
+// snippetIndices returns the boundaries of the line before and the line after the match
+func snippetIndices(content string, selectionStartIndex, selectionEndIndex int) (int, int) {
+	startIndex := selectionStartIndex
+	numLinesBefore := 0
+	for ; startIndex > 0; startIndex-- {
+		if content[startIndex-1] == '\n' {
+			if numLinesBefore == 1 {
+				break
+			}
+			numLinesBefore++
+		}
+	}
+
+	endIndex := selectionEndIndex
+	numLinesAfter := 0
+	for ; endIndex < len(content); endIndex++ {
+		if content[endIndex] == '\n' {
+			if numLinesAfter == 1 {
+				break
+			}
+			numLinesAfter++
+		}
+	}
+
+	return startIndex, endIndex
+}
+
+// firstLinesEndIndex returns the end of the first lines of the content
+func firstLinesEndIndex(content string) int {
+	count := 1
+	for i, c := range content {
+		if c == '\n' {
+			if count == snippetNumberOfLines {
+				return i
+			}
+			count++
+		}
+	}
+	return len(content)
+}
+
+func searchResult(result *internal.SearchResult) *Result {
+	var snippet string
+	var formattedSnippet template.HTML
+	if result.StartIndex < 0 || result.EndIndex > len(result.Content) || result.StartIndex >= result.EndIndex {
+		// only the filename matches, or the indexer returns unexpected positions
+		snippet = result.Content[:firstLinesEndIndex(result.Content)]
+		formattedSnippet = template.HTML(html.EscapeString(snippet))
+	} else {
+		startIndex, endIndex := snippetIndices(result.Content, result.StartIndex, result.EndIndex)
+		snippet = result.Content[startIndex:endIndex]
+		formattedSnippet = template.HTML(html.EscapeString(result.Content[startIndex:result.StartIndex]) +
+			`<span class="search-highlight">` + html.EscapeString(result.Content[result.StartIndex:result.EndIndex]) + `</span>` +
+			html.EscapeString(result.Content[result.EndIndex:endIndex]))
+	}
+
+	return &Result{
+		RepoID:           result.RepoID,
+		Filename:         result.Filename,
+		CommitID:         result.CommitID,
+		UpdatedUnix:      result.UpdatedUnix,
+		Snippet:          strings.TrimSpace(snippet),
+		FormattedSnippet: template.HTML(strings.TrimSpace(string(formattedSnippet))),
+	}
+}
+
+// PerformSearch perform a search on wikis
+func PerformSearch(ctx context.Context, opts *SearchOptions) (int, []*Result, error) {
+	if opts == nil || len(opts.Keyword) == 0 {
+		return 0, nil, nil
+	}
+
+	total, results, err := (*globalIndexer.Load()).Search(ctx, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	displayResults := make([]*Result, len(results))
+	for i, result := range results {
+		displayResults[i] = searchResult(result)
+	}
+	return int(total), displayResults, nil
+}
@@ -0,0 +1,293 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"os"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/wiki/bleve"
+	"code.gitea.io/gitea/modules/indexer/wiki/elasticsearch"
+	"code.gitea.io/gitea/modules/indexer/wiki/internal"
+	"code.gitea.io/gitea/modules/indexer/wiki/meilisearch"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	indexerQueue *queue.WorkerPoolQueue[*internal.IndexerData]
+	// globalIndexer is the global indexer, it cannot be nil.
+	// When the real indexer is not ready, it will be a dummy indexer which will return error to explain it's not ready.
+	// So it's always safe use it as *globalIndexer.Load() and call its methods.
+	globalIndexer atomic.Pointer[internal.Indexer]
+)
+
+func init() {
+	dummyIndexer := internal.NewDummyIndexer()
+	globalIndexer.Store(&dummyIndexer)
+}
+
+func index(ctx context.Context, indexer internal.Indexer, repoID int64) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if repo_model.IsErrRepoNotExist(err) {
+		return indexer.Delete(ctx, repoID)
+	}
+	if err != nil {
+		return err
+	}
+
+	sha, err := getWikiBranchSha(ctx, repo)
+	if err != nil {
+		return err
+	} else if sha == "" {
+		// the wiki has been deleted or has no pages yet
+		return indexer.Delete(ctx, repoID)
+	}
+
+	changes, err := getWikiChanges(ctx, indexer, repo, sha)
+	if err != nil {
+		return err
+	}
+
+	if err := indexer.Index(ctx, repo.ID, sha, changes); err != nil {
+		return err
+	}
+
+	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeWiki, sha)
+}
+
+// Init initialize the wiki indexer
+func Init() {
+	if !setting.Indexer.WikiIndexerEnabled {
+		(*globalIndexer.Load()).Close()
+		return
+	}
+
+	ctx, cancel, finished := process.GetManager().AddTypedContext(context.Background(), "Service: WikiIndexer", process.SystemProcessType, false)
+
+	graceful.GetManager().RunAtTerminate(func() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		cancel()
+		log.Debug("Closing wiki indexer")
+		(*globalIndexer.Load()).Close()
+		log.Info("PID: %d Wiki Indexer closed", os.Getpid())
+		finished()
+	})
+
+	waitChannel := make(chan time.Duration, 1)
+
+	// Create the Queue
+	switch setting.Indexer.WikiType {
+	case "bleve", "elasticsearch", "meilisearch":
+		handler := func(items ...*internal.IndexerData) (unhandled []*internal.IndexerData) {
+			indexer := *globalIndexer.Load()
+			for _, indexerData := range items {
+				log.Trace("IndexerData Process Wiki: %d", indexerData.RepoID)
+				if err := index(ctx, indexer, indexerData.RepoID); err != nil {
+					if !setting.IsInTesting {
+						log.Error("Wiki indexer handler: index error for repo %v: %v", indexerData.RepoID, err)
+					}
+				}
+			}
+			return nil // do not re-queue the failed items, otherwise some broken wiki will block the queue
+		}
+
+		indexerQueue = queue.CreateUniqueQueue(ctx, "wiki_indexer", handler)
+		if indexerQueue == nil {
+			log.Fatal("Unable to create wiki indexer queue")
+		}
+	default:
+		log.Fatal("Unknown wiki indexer type; %s", setting.Indexer.WikiType)
+	}
+
+	go func() {
+		pprof.SetGoroutineLabels(ctx)
+		start := time.Now()
+		var (
+			wIndexer internal.Indexer
+			existed  bool
+			err      error
+		)
+		switch setting.Indexer.WikiType {
+		case "bleve":
+			log.Info("PID: %d Initializing Wiki Indexer at: %s", os.Getpid(), setting.Indexer.WikiPath)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing wiki indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" directory to make Gitea recreate the indexes", setting.Indexer.WikiPath)
+				}
+			}()
+
+			wIndexer = bleve.NewIndexer(setting.Indexer.WikiPath)
+			existed, err = wIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the bleve Wiki Indexer at path: %s Error: %v", os.Getpid(), setting.Indexer.WikiPath, err)
+			}
+		case "elasticsearch":
+			log.Info("PID: %d Initializing Wiki Indexer at: %s", os.Getpid(), util.SanitizeCredentialURLs(setting.Indexer.WikiConnStr))
+			wIndexer = elasticsearch.NewIndexer(setting.Indexer.WikiConnStr, setting.Indexer.WikiIndexerName)
+			existed, err = wIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the elasticsearch Wiki Indexer connstr: %s Error: %v", os.Getpid(), util.SanitizeCredentialURLs(setting.Indexer.WikiConnStr), err)
+			}
+		case "meilisearch":
+			log.Info("PID: %d Initializing Wiki Indexer at: %s", os.Getpid(), setting.Indexer.WikiConnStr)
+			wIndexer = meilisearch.NewIndexer(setting.Indexer.WikiConnStr, setting.Indexer.WikiConnAuth, setting.Indexer.WikiIndexerName)
+			existed, err = wIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the meilisearch Wiki Indexer connstr: %s Error: %v", os.Getpid(), setting.Indexer.WikiConnStr, err)
+			}
+		default:
+			log.Fatal("PID: %d Unknown Indexer type: %s", os.Getpid(), setting.Indexer.WikiType)
+		}
+
+		globalIndexer.Store(&wIndexer)
+
+		// Start processing the queue
+		go graceful.GetManager().RunWithCancel(indexerQueue)
+
+		if !existed { // populate the index because it's created for the first time
+			go graceful.GetManager().RunWithShutdownContext(populateWikiIndexer)
+		}
+		select {
+		case waitChannel <- time.Since(start):
+		case <-graceful.GetManager().IsShutdown():
+		}
+
+		close(waitChannel)
+	}()
+
+	if setting.Indexer.StartupTimeout > 0 {
+		go func() {
+			pprof.SetGoroutineLabels(ctx)
+			timeout := setting.Indexer.StartupTimeout
+			if graceful.GetManager().IsChild() && setting.GracefulHammerTime > 0 {
+				timeout += setting.GracefulHammerTime
+			}
+			select {
+			case <-graceful.GetManager().IsShutdown():
+				log.Warn("Shutdown before Wiki Indexer completed initialization")
+				cancel()
+				(*globalIndexer.Load()).Close()
+			case duration, ok := <-waitChannel:
+				if !ok {
+					log.Warn("Wiki Indexer Initialization failed")
+					cancel()
+					(*globalIndexer.Load()).Close()
+					return
+				}
+				log.Info("Wiki Indexer Initialization took %v", duration)
+			case <-time.After(timeout):
+				cancel()
+				(*globalIndexer.Load()).Close()
+				log.Fatal("Wiki Indexer Initialization Timed-Out after: %v", timeout)
+			}
+		}()
+	}
+}
+
+// UpdateWikiIndexer update a wiki's entries in the indexer, it does nothing if the wiki indexer is disabled
+func UpdateWikiIndexer(repo *repo_model.Repository) {
+	if !setting.Indexer.WikiIndexerEnabled {
+		return
+	}
+	indexData := &internal.IndexerData{RepoID: repo.ID}
+	if err := indexerQueue.Push(indexData); err != nil {
+		log.Error("Update wiki index data %v failed: %v", indexData, err)
+	}
+}
+
+// IsAvailable checks if wiki indexer is available
+func IsAvailable(ctx context.Context) bool {
+	return (*globalIndexer.Load()).Ping(ctx) == nil
+}
+
+// populateWikiIndexer populate the wiki indexer with pre-existing data. This
+// should only be run when the indexer is created for the first time.
+func populateWikiIndexer(ctx context.Context) {
+	log.Info("Populating the wiki indexer with existing wikis")
+
+	exist, err := db.IsTableNotEmpty("repository")
+	if err != nil {
+		log.Fatal("System error: %v", err)
+	} else if !exist {
+		return
+	}
+
+	// if there is any existing wiki indexer metadata in the DB, delete it
+	// since we are starting afresh.
+	if err := repo_model.DeleteIndexerStatusByType(ctx, repo_model.RepoIndexerTypeWiki); err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	var maxRepoID int64
+	if maxRepoID, err = db.GetMaxID("repository"); err != nil {
+		log.Fatal("System error: %v", err)
+	}
+
+	// start with the maximum existing repo ID and work backwards, so that we
+	// don't include repos that are created after gitea starts; such repos will
+	// already be added to the indexer, and we don't need to add them again.
+	for maxRepoID > 0 {
+		select {
+		case <-ctx.Done():
+			log.Info("Wiki Indexer population shutdown before completion")
+			return
+		default:
+		}
+		ids, err := repo_model.GetUnindexedRepos(ctx, repo_model.RepoIndexerTypeWiki, maxRepoID, 0, 50)
+		if err != nil {
+			log.Error("populateWikiIndexer: %v", err)
+			return
+		} else if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			select {
+			case <-ctx.Done():
+				log.Info("Wiki Indexer population shutdown before completion")
+				return
+			default:
+			}
+			if err := indexerQueue.Push(&internal.IndexerData{RepoID: id}); err != nil {
+				log.Error("indexerQueue.Push: %v", err)
+				return
+			}
+			maxRepoID = id - 1
+		}
+	}
+	log.Info("Done (re)populating the wiki indexer with existing wikis")
+}
+
+func SupportedSearchModes() []indexer.SearchMode {
+	gi := globalIndexer.Load()
+	if gi == nil {
+		return nil
+	}
+	return (*gi).SupportedSearchModes()
+}
@@ -0,0 +1,503 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	// FormatSigstoreBundle is a Sigstore bundle which contains a DSSE envelope or a message signature
+	FormatSigstoreBundle = "sigstore-bundle"
+	// FormatDSSE is a DSSE envelope, e.g. an in-toto attestation created by "cosign attest-blob"
+	FormatDSSE = "dsse"
+	// FormatSignature is a base64 encoded signature of a file, e.g. created by "cosign sign-blob"
+	FormatSignature = "signature"
+	// FormatCosign is a signature of a container image, e.g. created by "cosign sign"
+	FormatCosign = "cosign"
+
+	PayloadTypeInToto = "application/vnd.in-toto+json"
+
+	// MediaTypeCosignSimpleSigning is the media type of the container image layer which contains the signed payload
+	MediaTypeCosignSimpleSigning = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// AnnotationCosignSignature is the layer annotation which contains the base64 encoded signature of the payload
+	AnnotationCosignSignature = "dev.cosignproject.cosign/signature"
+	// AnnotationCosignCertificate is the layer annotation which contains the PEM encoded signing certificate
+	AnnotationCosignCertificate = "dev.sigstore.cosign/certificate"
+
+	// SettingKeyTrustedKeys is the owner setting which contains the PEM encoded public keys trusted to sign packages
+	SettingKeyTrustedKeys = "packages.signing.trusted_keys"
+	// SettingKeyRequired is the owner setting which rejects uploads of package files without a verified signature
+	SettingKeyRequired = "packages.signing.required"
+)
+
+// Status is the result of the verification of an attestation
+type Status string
+
+const (
+	// StatusVerified means the attestation is signed by a trusted key
+	StatusVerified Status = "verified"
+	// StatusUnverified means the signature can't be attributed to a trusted key
+	StatusUnverified Status = "unverified"
+	// StatusInvalid means the signature doesn't match the public key embedded in the attestation
+	StatusInvalid Status = "invalid"
+)
+
+var (
+	ErrInvalidAttestation = util.NewInvalidArgumentErrorf("attestation is invalid")
+	ErrMissingSubject     = util.NewInvalidArgumentErrorf("attestation has no sha256 subject")
+	ErrInvalidPublicKey   = util.NewInvalidArgumentErrorf("public key is invalid")
+)
+
+var sha256Pattern = regexp.MustCompile(`\A[0-9a-f]{64}\z`)
+
+var (
+	// OIDs of the Fulcio certificate extensions which contain the OIDC issuer of the signing identity
+	oidIssuer   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// Attestation is a parsed signature or attestation of package files
+type Attestation struct {
+	Format        string
+	PayloadType   string
+	PredicateType string
+	// Subjects are the hex encoded SHA256 digests of the signed files
+	Subjects    []string
+	Certificate *x509.Certificate
+
+	signatures [][]byte
+	// message is the signed content, the PAE of a DSSE envelope or the digest of the signed file
+	message         []byte
+	messageIsDigest bool
+}
+
+// Verification is the result of the verification of an attestation
+type Verification struct {
+	Status Status
+	// KeyFingerprint is the fingerprint of the trusted key which signed the attestation
+	KeyFingerprint string
+	// Identity and Issuer are read from the certificate embedded in the attestation
+	Identity string
+	Issuer   string
+}
+
+type envelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		KeyID string `json:"keyid"`
+		Sig   string `json:"sig"`
+	} `json:"signatures"`
+}
+
+type bundle struct {
+	MediaType            string `json:"mediaType"`
+	VerificationMaterial struct {
+		Certificate *struct {
+			RawBytes string `json:"rawBytes"`
+		} `json:"certificate"`
+		X509CertificateChain *struct {
+			Certificates []struct {
+				RawBytes string `json:"rawBytes"`
+			} `json:"certificates"`
+		} `json:"x509CertificateChain"`
+	} `json:"verificationMaterial"`
+	DSSEEnvelope     *envelope `json:"dsseEnvelope"`
+	MessageSignature *struct {
+		MessageDigest struct {
+			Algorithm string `json:"algorithm"`
+			Digest    string `json:"digest"`
+		} `json:"messageDigest"`
+		Signature string `json:"signature"`
+	} `json:"messageSignature"`
+}
+
+// cosignSignature is the stored form of a container image signature which is spread over a layer and its annotations
+type cosignSignature struct {
+	MediaType   string `json:"mediaType"`
+	Payload     string `json:"payload"`
+	Signature   string `json:"signature"`
+	Certificate string `json:"certificate,omitempty"`
+}
+
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+type statement struct {
+	Type    string `json:"_type"`
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	PredicateType string `json:"predicateType"`
+}
+
+// IsValidSHA256 checks if s is a hex encoded SHA256 digest
+func IsValidSHA256(s string) bool {
+	return sha256Pattern.MatchString(s)
+}
+
+// Parse parses a Sigstore bundle, a DSSE envelope or a base64 encoded signature.
+// The subject digest is only used for a base64 encoded signature, which signs the digest itself.
+func Parse(data []byte, subjectSHA256 string) (*Attestation, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, ErrInvalidAttestation
+	}
+
+	var a *Attestation
+	var err error
+	if data[0] == '{' {
+		var probe struct {
+			MediaType   string `json:"mediaType"`
+			PayloadType string `json:"payloadType"`
+		}
+		if err := json.Unmarshal(data, &probe); err != nil {
+			return nil, ErrInvalidAttestation
+		}
+		switch {
+		case strings.HasPrefix(probe.MediaType, "application/vnd.dev.sigstore.bundle"):
+			a, err = parseBundle(data)
+		case probe.MediaType == MediaTypeCosignSimpleSigning:
+			a, err = parseCosignSignature(data)
+		case probe.PayloadType != "":
+			env := &envelope{}
+			if err := json.Unmarshal(data, env); err != nil {
+				return nil, ErrInvalidAttestation
+			}
+			a, err = parseEnvelope(env)
+			if a != nil {
+				a.Format = FormatDSSE
+			}
+		default:
+			return nil, ErrInvalidAttestation
+		}
+	} else {
+		var sig []byte
+		sig, err = base64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return nil, ErrInvalidAttestation
+		}
+		a = &Attestation{
+			Format:     FormatSignature,
+			signatures: [][]byte{sig},
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(a.Subjects) == 0 {
+		// only a plain signature signs the digest itself, the signed content of the other formats has to name the subjects,
+		// otherwise the same signature could be attached to any file
+		if a.Format != FormatSignature {
+			return nil, ErrMissingSubject
+		}
+		subjectSHA256 = strings.ToLower(subjectSHA256)
+		if !IsValidSHA256(subjectSHA256) {
+			return nil, ErrMissingSubject
+		}
+		a.Subjects = []string{subjectSHA256}
+		a.message, _ = hex.DecodeString(subjectSHA256)
+		a.messageIsDigest = true
+	}
+
+	return a, nil
+}
+
+func parseBundle(data []byte) (*Attestation, error) {
+	b := &bundle{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, ErrInvalidAttestation
+	}
+
+	var a *Attestation
+	switch {
+	case b.DSSEEnvelope != nil:
+		var err error
+		if a, err = parseEnvelope(b.DSSEEnvelope); err != nil {
+			return nil, err
+		}
+	case b.MessageSignature != nil:
+		if b.MessageSignature.MessageDigest.Algorithm != "SHA2_256" {
+			return nil, ErrInvalidAttestation
+		}
+		digest, err := base64.StdEncoding.DecodeString(b.MessageSignature.MessageDigest.Digest)
+		if err != nil || len(digest) != sha256.Size {
+			return nil, ErrInvalidAttestation
+		}
+		sig, err := base64.StdEncoding.DecodeString(b.MessageSignature.Signature)
+		if err != nil {
+			return nil, ErrInvalidAttestation
+		}
+		a = &Attestation{
+			Subjects:        []string{hex.EncodeToString(digest)},
+			signatures:      [][]byte{sig},
+			message:         digest,
+			messageIsDigest: true,
+		}
+	default:
+		return nil, ErrInvalidAttestation
+	}
+	a.Format = FormatSigstoreBundle
+
+	var rawCert string
+	if b.VerificationMaterial.Certificate != nil {
+		rawCert = b.VerificationMaterial.Certificate.RawBytes
+	} else if b.VerificationMaterial.X509CertificateChain != nil && len(b.VerificationMaterial.X509CertificateChain.Certificates) > 0 {
+		rawCert = b.VerificationMaterial.X509CertificateChain.Certificates[0].RawBytes
+	}
+	if rawCert != "" {
+		der, err := base64.StdEncoding.DecodeString(rawCert)
+		if err != nil {
+			return nil, ErrInvalidAttestation
+		}
+		if a.Certificate, err = x509.ParseCertificate(der); err != nil {
+			return nil, ErrInvalidAttestation
+		}
+	}
+
+	return a, nil
+}
+
+// MarshalCosignSignature creates the content of a container image signature from its layer payload and annotations
+func MarshalCosignSignature(payload []byte, signature, certificate string) ([]byte, error) {
+	return json.Marshal(&cosignSignature{
+		MediaType:   MediaTypeCosignSimpleSigning,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signature:   signature,
+		Certificate: certificate,
+	})
+}
+
+func parseCosignSignature(data []byte) (*Attestation, error) {
+	cs := &cosignSignature{}
+	if err := json.Unmarshal(data, cs); err != nil {
+		return nil, ErrInvalidAttestation
+	}
+	payload, err := base64.StdEncoding.DecodeString(cs.Payload)
+	if err != nil {
+		return nil, ErrInvalidAttestation
+	}
+	sig, err := base64.StdEncoding.DecodeString(cs.Signature)
+	if err != nil {
+		return nil, ErrInvalidAttestation
+	}
+
+	ss := &simpleSigning{}
+	if err := json.Unmarshal(payload, ss); err != nil {
+		return nil, ErrInvalidAttestation
+	}
+	digest, ok := strings.CutPrefix(ss.Critical.Image.DockerManifestDigest, "sha256:")
+	if !ok || !IsValidSHA256(digest) {
+		return nil, ErrMissingSubject
+	}
+
+	a := &Attestation{
+		Format:     FormatCosign,
+		Subjects:   []string{digest},
+		signatures: [][]byte{sig},
+		message:    payload,
+	}
+
+	if cs.Certificate != "" {
+		block, _ := pem.Decode([]byte(cs.Certificate))
+		if block == nil {
+			return nil, ErrInvalidAttestation
+		}
+		if a.Certificate, err = x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, ErrInvalidAttestation
+		}
+	}
+
+	return a, nil
+}
+
+func parseEnvelope(env *envelope) (*Attestation, error) {
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil || env.PayloadType == "" || len(env.Signatures) == 0 {
+		return nil, ErrInvalidAttestation
+	}
+
+	a := &Attestation{
+		PayloadType: env.PayloadType,
+		message:     PAE(env.PayloadType, payload),
+	}
+	for _, s := range env.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			return nil, ErrInvalidAttestation
+		}
+		a.signatures = append(a.signatures, sig)
+	}
+
+	if env.PayloadType == PayloadTypeInToto {
+		st := &statement{}
+		if err := json.Unmarshal(payload, st); err != nil {
+			return nil, ErrInvalidAttestation
+		}
+		a.PredicateType = st.PredicateType
+		for _, s := range st.Subject {
+			if digest := strings.ToLower(s.Digest["sha256"]); IsValidSHA256(digest) {
+				a.Subjects = append(a.Subjects, digest)
+			}
+		}
+		if len(a.Subjects) == 0 {
+			return nil, ErrMissingSubject
+		}
+	}
+
+	return a, nil
+}
+
+// PAE returns the pre-authentication encoding of a DSSE payload which is the signed message
+func PAE(payloadType string, payload []byte) []byte {
+	return fmt.Appendf(nil, "DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload)
+}
+
+// Verify checks the signatures of the attestation against the trusted keys
+func (a *Attestation) Verify(trustedKeys []crypto.PublicKey) *Verification {
+	v := &Verification{Status: StatusUnverified}
+
+	if a.Certificate != nil {
+		if len(a.Certificate.EmailAddresses) > 0 {
+			v.Identity = a.Certificate.EmailAddresses[0]
+		} else if len(a.Certificate.URIs) > 0 {
+			v.Identity = a.Certificate.URIs[0].String()
+		}
+		for _, ext := range a.Certificate.Extensions {
+			if ext.Id.Equal(oidIssuerV2) {
+				var issuer string
+				if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+					v.Issuer = issuer
+				}
+			} else if ext.Id.Equal(oidIssuer) && v.Issuer == "" {
+				v.Issuer = string(ext.Value)
+			}
+		}
+	}
+
+	for _, key := range trustedKeys {
+		if a.verifyWithKey(key) {
+			v.Status = StatusVerified
+			v.KeyFingerprint = Fingerprint(key)
+			return v
+		}
+	}
+
+	// the certificate chain can't be validated without the Sigstore trust root,
+	// but a signature which doesn't match the embedded certificate is broken
+	if a.Certificate != nil && !a.verifyWithKey(a.Certificate.PublicKey) {
+		v.Status = StatusInvalid
+	}
+
+	return v
+}
+
+func (a *Attestation) verifyWithKey(key crypto.PublicKey) bool {
+	if len(a.message) == 0 {
+		return false
+	}
+	for _, sig := range a.signatures {
+		if verifySignature(key, a.message, a.messageIsDigest, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+func verifySignature(key crypto.PublicKey, message []byte, isDigest bool, sig []byte) bool {
+	digest := func(h hash.Hash) []byte {
+		if isDigest {
+			return message
+		}
+		h.Write(message)
+		return h.Sum(nil)
+	}
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		var h hash.Hash
+		switch k.Curve {
+		case elliptic.P384():
+			h = sha512.New384()
+		case elliptic.P521():
+			h = sha512.New()
+		default:
+			h = sha256.New()
+		}
+		return ecdsa.VerifyASN1(k, digest(h), sig)
+	case ed25519.PublicKey:
+		return !isDigest && ed25519.Verify(k, message, sig)
+	case *rsa.PublicKey:
+		d := digest(sha256.New())
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, d, sig) == nil || rsa.VerifyPSS(k, crypto.SHA256, d, sig, nil) == nil
+	}
+	return false
+}
+
+// ParsePublicKeys parses PEM encoded public keys
+func ParsePublicKeys(s string) ([]crypto.PublicKey, error) {
+	keys := make([]crypto.PublicKey, 0, 2)
+	rest := []byte(s)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			return nil, ErrInvalidPublicKey
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, ErrInvalidPublicKey
+		}
+		switch key.(type) {
+		case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		default:
+			return nil, ErrInvalidPublicKey
+		}
+		keys = append(keys, key)
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, ErrInvalidPublicKey
+	}
+	return keys, nil
+}
+
+// Fingerprint returns the hex encoded SHA256 digest of the DER encoded public key
+func Fingerprint(key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAndVerify(t *testing.T) {
+	content := []byte("package content")
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("Signature", func(t *testing.T) {
+		sig, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+		require.NoError(t, err)
+
+		_, err = Parse([]byte(base64.StdEncoding.EncodeToString(sig)), "")
+		assert.ErrorIs(t, err, ErrMissingSubject)
+
+		a, err := Parse([]byte(base64.StdEncoding.EncodeToString(sig)+"\n"), digest)
+		require.NoError(t, err)
+		assert.Equal(t, FormatSignature, a.Format)
+		assert.Equal(t, []string{digest}, a.Subjects)
+
+		v := a.Verify([]crypto.PublicKey{otherKey.Public(), key.Public()})
+		assert.Equal(t, StatusVerified, v.Status)
+		assert.Equal(t, Fingerprint(key.Public()), v.KeyFingerprint)
+
+		v = a.Verify([]crypto.PublicKey{otherKey.Public()})
+		assert.Equal(t, StatusUnverified, v.Status)
+	})
+
+	t.Run("DSSE", func(t *testing.T) {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		payload := fmt.Appendf(nil, `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"test.tgz","digest":{"sha256":%q}}],"predicateType":"https://slsa.dev/provenance/v1","predicate":{}}`, digest)
+		sig := ed25519.Sign(priv, PAE(PayloadTypeInToto, payload))
+		data := fmt.Appendf(nil, `{"payloadType":%q,"payload":%q,"signatures":[{"keyid":"","sig":%q}]}`, PayloadTypeInToto, base64.StdEncoding.EncodeToString(payload), base64.StdEncoding.EncodeToString(sig))
+
+		a, err := Parse(data, "")
+		require.NoError(t, err)
+		assert.Equal(t, FormatDSSE, a.Format)
+		assert.Equal(t, "https://slsa.dev/provenance/v1", a.PredicateType)
+		assert.Equal(t, []string{digest}, a.Subjects)
+
+		assert.Equal(t, StatusVerified, a.Verify([]crypto.PublicKey{priv.Public()}).Status)
+		assert.Equal(t, StatusUnverified, a.Verify(nil).Status)
+
+		// an envelope which doesn't name its subjects can't be attached to a file, the signature doesn't cover the digest
+		payload = []byte("signed by a trusted key")
+		sig = ed25519.Sign(priv, PAE("text/plain", payload))
+		data = fmt.Appendf(nil, `{"payloadType":"text/plain","payload":%q,"signatures":[{"keyid":"","sig":%q}]}`, base64.StdEncoding.EncodeToString(payload), base64.StdEncoding.EncodeToString(sig))
+		_, err = Parse(data, digest)
+		assert.ErrorIs(t, err, ErrMissingSubject)
+	})
+
+	t.Run("SigstoreBundle", func(t *testing.T) {
+		template := &x509.Certificate{
+			SerialNumber:   big.NewInt(1),
+			Subject:        pkix.Name{CommonName: "sigstore"},
+			NotBefore:      time.Now(),
+			NotAfter:       time.Now().Add(time.Hour),
+			EmailAddresses: []string{"user2@example.com"},
+			ExtraExtensions: []pkix.Extension{
+				{Id: oidIssuer, Value: []byte("https://accounts.example.com")},
+			},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+		require.NoError(t, err)
+
+		createBundle := func(signer *ecdsa.PrivateKey) []byte {
+			sig, err := ecdsa.SignASN1(rand.Reader, signer, sum[:])
+			require.NoError(t, err)
+			return fmt.Appendf(nil, `{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json","verificationMaterial":{"certificate":{"rawBytes":%q}},"messageSignature":{"messageDigest":{"algorithm":"SHA2_256","digest":%q},"signature":%q}}`,
+				base64.StdEncoding.EncodeToString(der),
+				base64.StdEncoding.EncodeToString(sum[:]),
+				base64.StdEncoding.EncodeToString(sig),
+			)
+		}
+
+		a, err := Parse(createBundle(key), "")
+		require.NoError(t, err)
+		assert.Equal(t, FormatSigstoreBundle, a.Format)
+		assert.Equal(t, []string{digest}, a.Subjects)
+		assert.NotNil(t, a.Certificate)
+
+		v := a.Verify(nil)
+		assert.Equal(t, StatusUnverified, v.Status)
+		assert.Equal(t, "user2@example.com", v.Identity)
+		assert.Equal(t, "https://accounts.example.com", v.Issuer)
+
+		a, err = Parse(createBundle(otherKey), "")
+		require.NoError(t, err)
+		assert.Equal(t, StatusInvalid, a.Verify(nil).Status)
+	})
+
+	t.Run("Cosign", func(t *testing.T) {
+		payload := fmt.Appendf(nil, `{"critical":{"identity":{"docker-reference":"localhost/user2/image"},"image":{"docker-manifest-digest":"sha256:%s"},"type":"cosign container image signature"},"optional":null}`, digest)
+		h := sha256.Sum256(payload)
+		sig, err := ecdsa.SignASN1(rand.Reader, key, h[:])
+		require.NoError(t, err)
+
+		data, err := MarshalCosignSignature(payload, base64.StdEncoding.EncodeToString(sig), "")
+		require.NoError(t, err)
+
+		a, err := Parse(data, "")
+		require.NoError(t, err)
+		assert.Equal(t, FormatCosign, a.Format)
+		assert.Equal(t, []string{digest}, a.Subjects)
+		assert.Equal(t, StatusVerified, a.Verify([]crypto.PublicKey{key.Public()}).Status)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, data := range []string{"", "{}", `{"mediaType":"application/vnd.dev.sigstore.bundle+json"}`, `{"payloadType":"text/plain","payload":"","signatures":[]}`, "not base64!"} {
+			_, err := Parse([]byte(data), digest)
+			assert.Error(t, err, data)
+		}
+	})
+}
+
+func TestParsePublicKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	block := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	keys, err := ParsePublicKeys("")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	keys, err = ParsePublicKeys(block + "\n" + block)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	_, err = ParsePublicKeys("no key")
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+
+	_, err = ParsePublicKeys(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+}
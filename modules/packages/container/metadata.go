@@ -21,6 +21,7 @@ const (
 	PropertyMediaType         = "container.mediatype"
 	PropertyManifestTagged    = "container.manifest.tagged"
 	PropertyManifestReference = "container.manifest.reference"
+	PropertyManifestSubject   = "container.manifest.subject"
 
 	DefaultPlatform = "linux/amd64"
 
@@ -63,6 +64,9 @@ type Metadata struct {
 	Labels           map[string]string `json:"labels,omitempty"`
 	ImageLayers      []string          `json:"layer_creation,omitempty"`
 	Manifests        []*Manifest       `json:"manifests,omitempty"`
+	ArtifactType     string            `json:"artifact_type,omitempty"`
+	Subject          string            `json:"subject,omitempty"`
+	Annotations      map[string]string `json:"annotations,omitempty"`
 }
 
 type Manifest struct {
@@ -83,12 +87,27 @@ func IsMediaTypeImageIndex(mt string) bool {
 	return strings.EqualFold(mt, oci.MediaTypeImageIndex) || strings.EqualFold(mt, "application/vnd.docker.distribution.manifest.list.v2+json")
 }
 
+// ManifestArtifactType gets the artifact type of an image manifest
+// If the manifest has no explicit artifact type, the media type of the config is used.
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func ManifestArtifactType(manifest *oci.Manifest) string {
+	if manifest.ArtifactType != "" {
+		return manifest.ArtifactType
+	}
+	return manifest.Config.MediaType
+}
+
 // ParseImageConfig parses the metadata of an image config
 func ParseImageConfig(mediaType string, r io.Reader) (*Metadata, error) {
 	if strings.EqualFold(mediaType, helm.ConfigMediaType) {
 		return parseHelmConfig(r)
 	}
 
+	// artifacts without a config use the empty descriptor, they are not runnable images
+	if strings.EqualFold(mediaType, oci.MediaTypeEmptyJSON) {
+		return &Metadata{Type: TypeOCI, Platform: "unknown/unknown"}, nil
+	}
+
 	// fallback to OCI Image Config
 	// FIXME: this fallback is not right, we should strictly check the media type in the future
 	metadata, err := parseOCIImageConfig(r)
@@ -65,4 +65,18 @@ func TestParseImageConfig(t *testing.T) {
 	metadata, err = ParseImageConfig("anything-unknown", strings.NewReader(""))
 	require.NoError(t, err)
 	assert.Equal(t, &Metadata{Platform: "unknown/unknown"}, metadata)
+
+	metadata, err = ParseImageConfig(oci.MediaTypeEmptyJSON, strings.NewReader("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{Type: TypeOCI, Platform: "unknown/unknown"}, metadata)
+}
+
+func TestManifestArtifactType(t *testing.T) {
+	manifest := &oci.Manifest{
+		Config: oci.Descriptor{MediaType: oci.MediaTypeImageConfig},
+	}
+	assert.Equal(t, oci.MediaTypeImageConfig, ManifestArtifactType(manifest))
+
+	manifest.ArtifactType = "application/spdx+json"
+	assert.Equal(t, "application/spdx+json", ManifestArtifactType(manifest))
 }
@@ -0,0 +1,187 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	KindModule   = "module"
+	KindProvider = "provider"
+
+	PropertyOS        = "terraform.os"
+	PropertyArch      = "terraform.arch"
+	PropertyProtocols = "terraform.protocols"
+
+	SettingKeyPrivate = "terraform.key.private"
+	SettingKeyPublic  = "terraform.key.public"
+
+	// DefaultProtocols are the plugin protocol versions of a provider if none are specified on upload
+	DefaultProtocols = "5.0"
+)
+
+var (
+	ErrInvalidName         = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion      = util.NewInvalidArgumentErrorf("package version is invalid")
+	ErrInvalidFilename     = util.NewInvalidArgumentErrorf("provider filename is invalid")
+	ErrInvalidProtocols    = util.NewInvalidArgumentErrorf("provider protocols are invalid")
+	ErrMissingModuleFiles  = util.NewInvalidArgumentErrorf("module archive contains no Terraform files")
+	ErrMissingProviderFile = util.NewInvalidArgumentErrorf("provider archive contains no provider executable")
+)
+
+var (
+	// https://developer.hashicorp.com/terraform/registry/modules/publish#requirements
+	moduleNamePattern   = regexp.MustCompile(`\A[0-9A-Za-z](?:[0-9A-Za-z_-]{0,62}[0-9A-Za-z])?\z`)
+	moduleSystemPattern = regexp.MustCompile(`\A[0-9a-z]{1,64}\z`)
+	// https://developer.hashicorp.com/terraform/language/providers/requirements#source-addresses
+	providerTypePattern     = regexp.MustCompile(`\A[0-9a-z](?:[0-9a-z-]{0,62}[0-9a-z])?\z`)
+	providerFilenamePattern = regexp.MustCompile(`\Aterraform-provider-([0-9a-z-]+)_([^_/]+)_([0-9a-z]+)_([0-9a-z]+)\.zip\z`)
+	protocolPattern         = regexp.MustCompile(`\A\d+\.\d+\z`)
+)
+
+// Metadata represents the metadata of a Terraform module or provider
+type Metadata struct {
+	Kind   string `json:"kind"`
+	Readme string `json:"readme,omitempty"`
+}
+
+// ProviderFile represents a platform specific archive of a Terraform provider
+type ProviderFile struct {
+	Type    string
+	Version string
+	OS      string
+	Arch    string
+}
+
+// ModulePackageName returns the package name of the module, the system is part of the name to separate it from providers
+func ModulePackageName(name, system string) (string, error) {
+	if !moduleNamePattern.MatchString(name) || !moduleSystemPattern.MatchString(system) {
+		return "", ErrInvalidName
+	}
+	return name + "/" + system, nil
+}
+
+// ProviderPackageName returns the package name of the provider
+func ProviderPackageName(providerType string) (string, error) {
+	if !providerTypePattern.MatchString(providerType) {
+		return "", ErrInvalidName
+	}
+	return providerType, nil
+}
+
+// IsValidVersion checks if the version is a semantic version as required by Terraform
+func IsValidVersion(v string) bool {
+	_, err := version.NewSemver(v)
+	return err == nil
+}
+
+// ParseProtocols parses a comma separated list of plugin protocol versions
+func ParseProtocols(s string) ([]string, error) {
+	if s == "" {
+		s = DefaultProtocols
+	}
+	protocols := strings.Split(s, ",")
+	for i, p := range protocols {
+		p = strings.TrimSpace(p)
+		if !protocolPattern.MatchString(p) {
+			return nil, ErrInvalidProtocols
+		}
+		protocols[i] = p
+	}
+	return protocols, nil
+}
+
+// ParseModuleArchive parses a gzipped tar archive of a module and extracts its readme
+func ParseModuleArchive(r io.Reader) (*Metadata, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gzr.Close()
+
+	m := &Metadata{
+		Kind: KindModule,
+	}
+
+	hasTerraformFiles := false
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(hd.Name, "./"))
+		if strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json") {
+			hasTerraformFiles = true
+		}
+		if strings.EqualFold(name, "README.md") {
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			m.Readme = string(data)
+		}
+	}
+
+	if !hasTerraformFiles {
+		return nil, ErrMissingModuleFiles
+	}
+
+	return m, nil
+}
+
+// ParseProviderFilename parses the filename of a provider archive which follows the naming convention of the Terraform releases
+func ParseProviderFilename(filename string) (*ProviderFile, error) {
+	parts := providerFilenamePattern.FindStringSubmatch(filename)
+	if parts == nil {
+		return nil, ErrInvalidFilename
+	}
+	if !providerTypePattern.MatchString(parts[1]) {
+		return nil, ErrInvalidName
+	}
+	if !IsValidVersion(parts[2]) {
+		return nil, ErrInvalidVersion
+	}
+	return &ProviderFile{
+		Type:    parts[1],
+		Version: parts[2],
+		OS:      parts[3],
+		Arch:    parts[4],
+	}, nil
+}
+
+// ValidateProviderArchive checks if the zip archive contains the executable of the provider
+func ValidateProviderArchive(r io.ReaderAt, size int64, providerType string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range zr.File {
+		if strings.HasPrefix(path.Base(f.Name), "terraform-provider-"+providerType) && !f.FileInfo().IsDir() {
+			return nil
+		}
+	}
+	return ErrMissingProviderFile
+}
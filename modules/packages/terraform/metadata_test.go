@@ -0,0 +1,125 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseModuleArchive(t *testing.T) {
+	createArchive := func(files map[string][]byte) io.Reader {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for filename, content := range files {
+			hdr := &tar.Header{
+				Name: filename,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			}
+			tw.WriteHeader(hdr)
+			tw.Write(content)
+		}
+		tw.Close()
+		zw.Close()
+		return &buf
+	}
+
+	t.Run("MissingTerraformFiles", func(t *testing.T) {
+		data := createArchive(map[string][]byte{"README.md": []byte("# Module")})
+
+		metadata, err := ParseModuleArchive(data)
+		assert.Nil(t, metadata)
+		assert.ErrorIs(t, err, ErrMissingModuleFiles)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		data := createArchive(map[string][]byte{
+			"./main.tf":          []byte(`resource "null_resource" "test" {}`),
+			"./README.md":        []byte("# Module"),
+			"examples/README.md": []byte("# Example"),
+		})
+
+		metadata, err := ParseModuleArchive(data)
+		assert.NoError(t, err)
+		assert.Equal(t, KindModule, metadata.Kind)
+		assert.Equal(t, "# Module", metadata.Readme)
+	})
+}
+
+func TestParseProviderFilename(t *testing.T) {
+	pf, err := ParseProviderFilename("terraform-provider-test-dummy_1.2.3-beta.1_linux_amd64.zip")
+	assert.NoError(t, err)
+	assert.Equal(t, &ProviderFile{Type: "test-dummy", Version: "1.2.3-beta.1", OS: "linux", Arch: "amd64"}, pf)
+
+	for _, filename := range []string{
+		"terraform-provider-test_1.2.3_linux.zip",
+		"terraform-provider-test_1.2.3_linux_amd64.tar.gz",
+		"provider-test_1.2.3_linux_amd64.zip",
+		"terraform-provider-Test_1.2.3_linux_amd64.zip",
+	} {
+		_, err := ParseProviderFilename(filename)
+		assert.Error(t, err, filename)
+	}
+
+	_, err = ParseProviderFilename("terraform-provider-test_1.x_linux_amd64.zip")
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+}
+
+func TestParseProtocols(t *testing.T) {
+	protocols, err := ParseProtocols("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"5.0"}, protocols)
+
+	protocols, err = ParseProtocols("5.0, 6.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"5.0", "6.0"}, protocols)
+
+	_, err = ParseProtocols("5")
+	assert.ErrorIs(t, err, ErrInvalidProtocols)
+}
+
+func TestPackageNames(t *testing.T) {
+	name, err := ModulePackageName("network", "aws")
+	assert.NoError(t, err)
+	assert.Equal(t, "network/aws", name)
+
+	_, err = ModulePackageName("network", "AWS")
+	assert.ErrorIs(t, err, ErrInvalidName)
+	_, err = ModulePackageName("-network", "aws")
+	assert.ErrorIs(t, err, ErrInvalidName)
+
+	name, err = ProviderPackageName("dummy")
+	assert.NoError(t, err)
+	assert.Equal(t, "dummy", name)
+
+	_, err = ProviderPackageName("dummy/aws")
+	assert.ErrorIs(t, err, ErrInvalidName)
+}
+
+func TestValidateProviderArchive(t *testing.T) {
+	createArchive := func(filename string) *bytes.Reader {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.Create(filename)
+		require.NoError(t, err)
+		w.Write([]byte("binary"))
+		zw.Close()
+		return bytes.NewReader(buf.Bytes())
+	}
+
+	r := createArchive("terraform-provider-dummy_v1.0.0")
+	assert.NoError(t, ValidateProviderArchive(r, r.Size(), "dummy"))
+
+	r = createArchive("README.md")
+	assert.ErrorIs(t, ValidateProviderArchive(r, r.Size(), "dummy"), ErrMissingProviderFile)
+}
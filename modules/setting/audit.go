@@ -0,0 +1,35 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/url"
+
+	"code.gitea.io/gitea/modules/log"
+)
+
+// Audit settings
+var Audit = struct {
+	Enabled bool
+	// WebhookURL receives every audit event as a JSON encoded POST request
+	WebhookURL    string `ini:"WEBHOOK_URL"`
+	WebhookSecret string `ini:"WEBHOOK_SECRET"`
+	// SyslogAddress receives every audit event as a syslog message, e.g. "udp://localhost:514"
+	SyslogAddress string `ini:"SYSLOG_ADDRESS"`
+	SyslogTag     string `ini:"SYSLOG_TAG"`
+}{
+	Enabled:   false,
+	SyslogTag: "gitea",
+}
+
+func loadAuditFrom(rootCfg ConfigProvider) {
+	mustMapSetting(rootCfg, "audit", &Audit)
+
+	if Audit.SyslogAddress != "" {
+		u, err := url.Parse(Audit.SyslogAddress)
+		if err != nil || (u.Scheme != "udp" && u.Scheme != "tcp") || u.Host == "" {
+			log.Fatal("Invalid [audit].SYSLOG_ADDRESS %q, it must be like udp://host:port or tcp://host:port", Audit.SyslogAddress)
+		}
+	}
+}
@@ -32,6 +32,13 @@ var Indexer = struct {
 	ExcludePatterns      []*GlobMatcher
 	ExcludeVendored      bool
 
+	WikiIndexerEnabled bool
+	WikiType           string
+	WikiPath           string
+	WikiConnStr        string
+	WikiConnAuth       string
+	WikiIndexerName    string
+
 	TypeBleveMaxFuzzniess int
 }{
 	IssueType:        "bleve",
@@ -48,6 +55,13 @@ var Indexer = struct {
 	RepoIndexerName:      "gitea_codes",
 	MaxIndexerFileSize:   1024 * 1024,
 	ExcludeVendored:      true,
+
+	WikiIndexerEnabled: false,
+	WikiType:           "bleve",
+	WikiPath:           "indexers/wikis.bleve",
+	WikiConnStr:        "",
+	WikiConnAuth:       "",
+	WikiIndexerName:    "gitea_wikis",
 }
 
 func loadIndexerFrom(rootCfg ConfigProvider) {
@@ -89,6 +103,31 @@ func loadIndexerFrom(rootCfg ConfigProvider) {
 	Indexer.ExcludePatterns = IndexerGlobFromString(sec.Key("REPO_INDEXER_EXCLUDE").MustString(""))
 	Indexer.ExcludeVendored = sec.Key("REPO_INDEXER_EXCLUDE_VENDORED").MustBool(true)
 	Indexer.MaxIndexerFileSize = sec.Key("MAX_FILE_SIZE").MustInt64(1024 * 1024)
+
+	Indexer.WikiIndexerEnabled = sec.Key("WIKI_INDEXER_ENABLED").MustBool(false)
+	Indexer.WikiType = sec.Key("WIKI_INDEXER_TYPE").MustString("bleve")
+	if Indexer.WikiType == "bleve" {
+		Indexer.WikiPath = filepath.ToSlash(sec.Key("WIKI_INDEXER_PATH").MustString(filepath.ToSlash(filepath.Join(AppDataPath, "indexers/wikis.bleve"))))
+		if !filepath.IsAbs(Indexer.WikiPath) {
+			Indexer.WikiPath = filepath.ToSlash(filepath.Join(AppWorkPath, Indexer.WikiPath))
+		}
+		if Indexer.WikiIndexerEnabled {
+			checkOverlappedPath("[indexer].WIKI_INDEXER_PATH", Indexer.WikiPath)
+		}
+	} else {
+		Indexer.WikiConnStr = sec.Key("WIKI_INDEXER_CONN_STR").MustString("")
+		if Indexer.WikiType == "meilisearch" {
+			u, err := url.Parse(Indexer.WikiConnStr)
+			if err != nil {
+				log.Warn("Failed to parse WIKI_INDEXER_CONN_STR: %v", err)
+				u = &url.URL{}
+			}
+			Indexer.WikiConnAuth, _ = u.User.Password()
+			u.User = nil
+			Indexer.WikiConnStr = u.String()
+		}
+	}
+	Indexer.WikiIndexerName = sec.Key("WIKI_INDEXER_NAME").MustString("gitea_wikis")
 	Indexer.StartupTimeout = sec.Key("STARTUP_TIMEOUT").MustDuration(30 * time.Second)
 	Indexer.TypeBleveMaxFuzzniess = sec.Key("TYPE_BLEVE_MAX_FUZZINESS").MustInt(0)
 }
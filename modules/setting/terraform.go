@@ -0,0 +1,48 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dustin/go-humanize"
+)
+
+// TerraformStateSettingType contains the settings of the Terraform HTTP state backend
+type TerraformStateSettingType struct {
+	Enabled bool
+	Storage *Storage
+	// MaxVersions is the number of versions kept for every state, 0 keeps all versions
+	MaxVersions int
+	// MaxStateSize is the maximum size in bytes of a state document
+	MaxStateSize int64
+}
+
+var TerraformState TerraformStateSettingType
+
+func loadTerraformStateFrom(rootCfg ConfigProvider) (err error) {
+	TerraformState = TerraformStateSettingType{
+		Enabled:      true,
+		MaxVersions:  100,
+		MaxStateSize: 100 * 1024 * 1024,
+	}
+	sec, _ := rootCfg.GetSection("terraform_state")
+	if sec == nil {
+		TerraformState.Storage, err = getStorage(rootCfg, "terraform_states", "", nil)
+		return err
+	}
+
+	TerraformState.Enabled = sec.Key("ENABLED").MustBool(TerraformState.Enabled)
+	TerraformState.MaxVersions = sec.Key("MAX_VERSIONS").MustInt(TerraformState.MaxVersions)
+	if value := sec.Key("MAX_STATE_SIZE").String(); value != "" {
+		size, err := humanize.ParseBytes(value)
+		if err != nil || size == 0 || size > math.MaxInt64 {
+			return fmt.Errorf("invalid MAX_STATE_SIZE %q in [terraform_state]", value)
+		}
+		TerraformState.MaxStateSize = int64(size)
+	}
+	TerraformState.Storage, err = getStorage(rootCfg, "terraform_states", "", sec)
+	return err
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// AuditEvent represents an audited administrative or security relevant operation
+type AuditEvent struct {
+	// The unique identifier of the event
+	ID int64 `json:"id"`
+	// The kind of the operation, e.g. repo.collaborator.add
+	Action string `json:"action"`
+	// The ID of the user who performed the operation, 0 if it was performed by the system
+	ActorID int64 `json:"actor_id"`
+	// The name of the user who performed the operation
+	ActorName string `json:"actor_name"`
+	// The IP address the operation was performed from
+	IPAddress string `json:"ip_address"`
+	// The type of the object the event belongs to
+	//
+	// enum: system,user,organization,repository
+	ScopeType string `json:"scope_type"`
+	// The ID of the object the event belongs to
+	ScopeID int64 `json:"scope_id"`
+	// The name of the object the event belongs to
+	ScopeName string `json:"scope_name"`
+	// The type of the changed object
+	TargetType string `json:"target_type"`
+	// The ID of the changed object
+	TargetID int64 `json:"target_id"`
+	// The name of the changed object
+	TargetName string `json:"target_name"`
+	// The state of the changed object before the operation
+	Before map[string]any `json:"before,omitempty"`
+	// The state of the changed object after the operation
+	After map[string]any `json:"after,omitempty"`
+	// The hash of the previous event
+	PrevHash string `json:"prev_hash"`
+	// The hash of the event, it covers all fields of the event and the hash of the previous event
+	Hash string `json:"hash"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+}
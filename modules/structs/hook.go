@@ -565,6 +565,39 @@ func (p *WorkflowDispatchPayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
 
+// MergeGroup represents the temporary branch a merge queue tests pull requests on
+type MergeGroup struct {
+	// The SHA of the merge group commit
+	HeadSHA string `json:"head_sha"`
+	// The full ref of the merge group branch
+	HeadRef string `json:"head_ref"`
+	// The SHA of the commit the merge group was created upon
+	BaseSHA string `json:"base_sha"`
+	// The full ref of the branch the merge group will be merged into
+	BaseRef string `json:"base_ref"`
+	// The last commit of the pull request at the tail of the merge group
+	HeadCommit *PayloadCommit `json:"head_commit"`
+}
+
+// MergeGroupPayload represents a payload information of merge group event.
+type MergeGroupPayload struct {
+	// The action performed on the merge group, currently only "checks_requested"
+	Action string `json:"action"`
+	// The merge group which checks are requested for
+	MergeGroup *MergeGroup `json:"merge_group"`
+	// The pull request at the tail of the merge group
+	PullRequest *PullRequest `json:"pull_request,omitempty"`
+	// The repository of the merge queue
+	Repository *Repository `json:"repository"`
+	// The user who added the pull request to the merge queue
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *MergeGroupPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
 // CommitStatusPayload represents a payload information of commit status event.
 type CommitStatusPayload struct {
 	// TODO: add Branches per https://docs.github.com/en/webhooks/webhook-events-and-payloads#status
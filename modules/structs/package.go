@@ -47,3 +47,28 @@ type PackageFile struct {
 	// The SHA512 hash of the package file
 	HashSHA512 string `json:"sha512"`
 }
+
+// PackageAttestation represents a signature or provenance attestation of package files
+type PackageAttestation struct {
+	// The unique identifier of the attestation
+	ID int64 `json:"id"`
+	// The format of the attestation (sigstore-bundle, dsse, signature or cosign)
+	Format string `json:"format"`
+	// The in-toto predicate type of a provenance attestation
+	PredicateType string `json:"predicate_type,omitempty"`
+	// The SHA256 hashes of the files covered by the attestation
+	Subjects []string `json:"subjects"`
+	// The verification status (verified, unverified or invalid)
+	Status string `json:"status"`
+	// The fingerprint of the trusted key which signed the attestation
+	KeyFingerprint string `json:"key_fingerprint,omitempty"`
+	// The signing identity of the embedded certificate
+	Identity string `json:"identity,omitempty"`
+	// The OIDC issuer of the signing identity
+	Issuer string `json:"issuer,omitempty"`
+	// The user who uploaded the attestation
+	Creator *User `json:"creator"`
+	// swagger:strfmt date-time
+	// The date and time when the attestation was uploaded
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// ProjectField represents a custom field of a project
+type ProjectField struct {
+	// ID is the unique identifier for the field
+	ID int64 `json:"id"`
+	// Name is the name of the field
+	Name string `json:"name"`
+	// Type is the type of the field values
+	// enum: text,number,date,single_select,iteration
+	Type string `json:"type"`
+	// Options are the options of a single select field
+	Options []*ProjectFieldOption `json:"options,omitempty"`
+	// Iterations are the iterations of an iteration field
+	Iterations []*ProjectFieldIteration `json:"iterations,omitempty"`
+}
+
+// ProjectFieldOption represents an option of a single select project field
+type ProjectFieldOption struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+// ProjectFieldIteration represents an iteration of an iteration project field
+type ProjectFieldIteration struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	// StartDate is the first day of the iteration, formatted as YYYY-MM-DD
+	StartDate string `json:"start_date"`
+	// EndDate is the last day of the iteration, formatted as YYYY-MM-DD
+	EndDate string `json:"end_date"`
+	// Duration is the length of the iteration in days
+	Duration int `json:"duration"`
+}
+
+// ProjectFieldValue represents the value of a custom field for an issue or pull request of a project
+type ProjectFieldValue struct {
+	FieldID   int64  `json:"field_id"`
+	FieldName string `json:"field_name"`
+	// Value is the text, the number, the date (YYYY-MM-DD) or the id of the selected option or iteration
+	Value string `json:"value"`
+	// Name is the name of the selected option or the title of the selected iteration
+	Name string `json:"name,omitempty"`
+}
+
+// ProjectItem represents an issue or a pull request of a project
+type ProjectItem struct {
+	IssueID    int64  `json:"issue_id"`
+	IssueIndex int64  `json:"issue_index"`
+	IsPull     bool   `json:"is_pull"`
+	Title      string `json:"title"`
+	// Repository is the full name of the repository of the issue
+	Repository string `json:"repository"`
+	ColumnID   int64  `json:"column_id"`
+	// ColumnTitle is the title of the project column the item is in
+	ColumnTitle string               `json:"column_title"`
+	FieldValues []*ProjectFieldValue `json:"field_values"`
+}
+
+// SetProjectFieldValueOption options for setting the value of a project field, an empty value clears it
+type SetProjectFieldValueOption struct {
+	// Value is the text, the number, the date (YYYY-MM-DD) or the id of the option or iteration
+	Value string `json:"value"`
+}
@@ -51,8 +51,22 @@ type CreateActionWorkflowDispatch struct {
 	// required: true
 	// example: refs/heads/main
 	Ref string `json:"ref" binding:"Required"`
+	// Inputs are the values of the inputs of the workflow, the value of a boolean or number input can be a boolean or a number
 	// required: false
-	Inputs map[string]string `json:"inputs,omitempty"`
+	Inputs map[string]any `json:"inputs,omitempty"`
+	// ReturnRunDetails indicates whether to respond with the details of the created run
+	// required: false
+	ReturnRunDetails bool `json:"return_run_details"`
+}
+
+// ActionWorkflowDispatchRun represents the run created by a workflow dispatch event
+type ActionWorkflowDispatchRun struct {
+	// WorkflowRunID is the ID of the created workflow run
+	WorkflowRunID int64 `json:"workflow_run_id"`
+	// RunURL is the API URL of the created workflow run
+	RunURL string `json:"run_url"`
+	// HTMLURL is the web URL of the created workflow run
+	HTMLURL string `json:"html_url"`
 }
 
 // ActionWorkflow represents a ActionWorkflow
@@ -183,6 +197,20 @@ type ActionWorkflowJob struct {
 	CompletedAt time.Time `json:"completed_at"`
 }
 
+// ActionRunLogMatch represents a line of the logs of a workflow run which matches a search
+type ActionRunLogMatch struct {
+	JobID   int64  `json:"job_id"`
+	JobName string `json:"job_name"`
+	// StepNumber is the index of the step in the steps of the job, including "Set up job" and "Complete job"
+	StepNumber int    `json:"step_number"`
+	StepName   string `json:"step_name"`
+	// Line is the line number in the logs of the step, starting from 1
+	Line    int64  `json:"line"`
+	Content string `json:"content"`
+	// HTMLURL is the web URL of the line in the logs of the job
+	HTMLURL string `json:"html_url"`
+}
+
 // ActionRunnerLabel represents a Runner Label
 type ActionRunnerLabel struct {
 	ID   int64  `json:"id"`
@@ -198,6 +226,8 @@ type ActionRunner struct {
 	Busy      bool                 `json:"busy"`
 	Ephemeral bool                 `json:"ephemeral"`
 	Labels    []*ActionRunnerLabel `json:"labels"`
+	// GroupID is the runner group restricting the jobs the runner runs, 0 means it isn't in any group
+	GroupID int64 `json:"group_id"`
 }
 
 // ActionRunnersResponse returns Runners
@@ -205,3 +235,80 @@ type ActionRunnersResponse struct {
 	Entries    []*ActionRunner `json:"runners"`
 	TotalCount int64           `json:"total_count"`
 }
+
+// GenerateActionRunnerJITConfigOption options to generate the just-in-time config of a runner
+type GenerateActionRunnerJITConfigOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// Labels are the labels of the runner, like "ubuntu-latest" or "ubuntu-latest:docker://node:20-bookworm"
+	// required: true
+	Labels []string `json:"labels" binding:"Required"`
+	// Ephemeral indicates whether the runner only runs one job, just-in-time runners must be ephemeral so only true is accepted
+	Ephemeral *bool `json:"ephemeral"`
+}
+
+// ActionRunnerJITConfig represents the just-in-time config of a runner
+type ActionRunnerJITConfig struct {
+	Runner *ActionRunner `json:"runner"`
+	// EncodedJITConfig is the base64 encoded state file of the runner, act_runner can be started with it as its ".runner" file
+	EncodedJITConfig string `json:"encoded_jit_config"`
+}
+
+// ActionRunnerQueue represents the jobs waiting for a runner with the same labels
+type ActionRunnerQueue struct {
+	// Labels are the labels a runner needs to run the jobs
+	Labels []string `json:"labels"`
+	// PendingJobs is the number of the jobs waiting for a runner
+	PendingJobs int64 `json:"pending_jobs"`
+	// swagger:strfmt date-time
+	OldestQueuedAt time.Time `json:"oldest_queued_at"`
+}
+
+// ActionRunnerQueuesResponse returns ActionRunnerQueues
+type ActionRunnerQueuesResponse struct {
+	Entries          []*ActionRunnerQueue `json:"queues"`
+	TotalPendingJobs int64                `json:"total_pending_jobs"`
+}
+
+// ActionRunnerGroup represents a group of runners which restricts the jobs they run
+type ActionRunnerGroup struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// AllRepositories indicates whether all the repositories in the scope could use the runners
+	AllRepositories bool `json:"all_repositories"`
+	// RepositoryIDs are the repositories which could use the runners if AllRepositories is false
+	RepositoryIDs []int64 `json:"repository_ids"`
+	// Workflows are the file names of the workflows which could use the runners, like "deploy.yml", all of them could if it's empty
+	Workflows []string `json:"workflows"`
+	// BranchFilters are glob patterns of the branches or tags whose runs could use the runners, all of them could if it's empty
+	BranchFilters []string `json:"branch_filters"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// ActionRunnerGroupsResponse returns ActionRunnerGroups
+type ActionRunnerGroupsResponse struct {
+	Entries    []*ActionRunnerGroup `json:"runner_groups"`
+	TotalCount int64                `json:"total_count"`
+}
+
+// CreateActionRunnerGroupOption options to create a runner group
+type CreateActionRunnerGroupOption struct {
+	// required: true
+	Name            string   `json:"name" binding:"Required;MaxSize(255)"`
+	AllRepositories bool     `json:"all_repositories"`
+	RepositoryIDs   []int64  `json:"repository_ids"`
+	Workflows       []string `json:"workflows"`
+	BranchFilters   []string `json:"branch_filters"`
+}
+
+// EditActionRunnerGroupOption options to edit a runner group, the omitted fields are unchanged
+type EditActionRunnerGroupOption struct {
+	Name            *string  `json:"name" binding:"OmitEmpty;MaxSize(255)"`
+	AllRepositories *bool    `json:"all_repositories"`
+	RepositoryIDs   []int64  `json:"repository_ids"`
+	Workflows       []string `json:"workflows"`
+	BranchFilters   []string `json:"branch_filters"`
+}
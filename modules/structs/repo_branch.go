@@ -65,6 +65,8 @@ type BranchProtection struct {
 	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
 	BlockAdminMergeOverride       bool     `json:"block_admin_merge_override"`
+	EnableMergeQueue              bool     `json:"enable_merge_queue"`
+	RequireCodeOwnerApprovals     bool     `json:"require_code_owner_approvals"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
@@ -105,6 +107,8 @@ type CreateBranchProtectionOption struct {
 	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
 	BlockAdminMergeOverride       bool     `json:"block_admin_merge_override"`
+	EnableMergeQueue              bool     `json:"enable_merge_queue"`
+	RequireCodeOwnerApprovals     bool     `json:"require_code_owner_approvals"`
 }
 
 // EditBranchProtectionOption options for editing a branch protection
@@ -138,6 +142,8 @@ type EditBranchProtectionOption struct {
 	ProtectedFilePatterns         *string  `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       *string  `json:"unprotected_file_patterns"`
 	BlockAdminMergeOverride       *bool    `json:"block_admin_merge_override"`
+	EnableMergeQueue              *bool    `json:"enable_merge_queue"`
+	RequireCodeOwnerApprovals     *bool    `json:"require_code_owner_approvals"`
 }
 
 // UpdateBranchProtectionPriories a list to update the branch protection rule priorities
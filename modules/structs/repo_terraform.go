@@ -0,0 +1,49 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// TerraformState represents a Terraform state stored in a repository
+type TerraformState struct {
+	// Name is the name of the state which is part of the backend address
+	Name string `json:"name"`
+	// LatestVersion is the number of the most recent version, 0 if no version has been stored yet
+	LatestVersion int64 `json:"latest_version"`
+	// Lock is set if the state is locked
+	Lock *TerraformStateLock `json:"lock,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// TerraformStateLock represents the lock of a Terraform state
+type TerraformStateLock struct {
+	// ID is the lock id generated by Terraform
+	ID string `json:"id"`
+	// Operation is the Terraform operation which holds the lock
+	Operation string `json:"operation"`
+	// Who is the user and host reported by Terraform
+	Who string `json:"who"`
+	// Locker is the user who acquired the lock
+	Locker *User `json:"locker"`
+	// swagger:strfmt date-time
+	Locked time.Time `json:"locked_at"`
+}
+
+// TerraformStateVersion represents a stored version of a Terraform state
+type TerraformStateVersion struct {
+	Version int64 `json:"version"`
+	// Serial is the serial of the state document
+	Serial int64 `json:"serial"`
+	// Lineage is the lineage of the state document
+	Lineage string `json:"lineage"`
+	Size    int64  `json:"size"`
+	Creator *User  `json:"creator"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
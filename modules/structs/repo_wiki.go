@@ -40,6 +40,18 @@ type WikiPageMetaData struct {
 	LastCommit *WikiCommit `json:"last_commit"`
 }
 
+// WikiSearchResult a wiki page matching a search
+type WikiSearchResult struct {
+	// The title of the wiki page
+	Title string `json:"title"`
+	// The HTML URL to view the wiki page
+	HTMLURL string `json:"html_url"`
+	// The sub URL path for the wiki page
+	SubURL string `json:"sub_url"`
+	// The lines of the page content around the first match, empty if only the title matches
+	Snippet string `json:"snippet"`
+}
+
 // CreateWikiPageOptions form for creating wiki
 type CreateWikiPageOptions struct {
 	// page title. leave empty to keep unchanged
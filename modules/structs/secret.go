@@ -14,6 +14,13 @@ type Secret struct {
 	Description string `json:"description"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
+	// the repositories of the organization which can use the secret, only set for organization secrets
+	// enum: all,private,selected
+	Visibility string `json:"visibility,omitempty"`
+	// the ids of the repositories which can use the secret if the visibility is "selected"
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids,omitempty"`
+	// the names of the environments whose jobs can use the secret, all jobs can use it if it's empty
+	SelectedEnvironments []string `json:"selected_environments,omitempty"`
 }
 
 // CreateOrUpdateSecretOption options when creating or updating secret
@@ -28,4 +35,17 @@ type CreateOrUpdateSecretOption struct {
 	//
 	// required: false
 	Description string `json:"description"`
+
+	// The repositories of the organization which can use the secret, only used by organization secrets.
+	// The visibility of an existing secret is kept if it's empty.
+	//
+	// enum: all,private,selected
+	Visibility string `json:"visibility"`
+
+	// The ids of the repositories which can use the secret if the visibility is "selected"
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids"`
+
+	// The names of the environments whose jobs can use the secret, all jobs can use it if it's empty.
+	// It's only used together with the visibility.
+	SelectedEnvironments []string `json:"selected_environments"`
 }
@@ -15,6 +15,18 @@ type CreateVariableOption struct {
 	//
 	// required: false
 	Description string `json:"description"`
+
+	// The repositories of the organization which can use the variable, only used by organization variables.
+	//
+	// enum: all,private,selected
+	Visibility string `json:"visibility"`
+
+	// The ids of the repositories which can use the variable if the visibility is "selected"
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids"`
+
+	// The names of the environments whose jobs can use the variable, all jobs can use it if it's empty.
+	// It's only used together with the visibility.
+	SelectedEnvironments []string `json:"selected_environments"`
 }
 
 // UpdateVariableOption the option when updating variable
@@ -31,6 +43,19 @@ type UpdateVariableOption struct {
 	//
 	// required: false
 	Description string `json:"description"`
+
+	// The repositories of the organization which can use the variable, only used by organization variables.
+	// The visibility of the variable is kept if it's empty.
+	//
+	// enum: all,private,selected
+	Visibility string `json:"visibility"`
+
+	// The ids of the repositories which can use the variable if the visibility is "selected"
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids"`
+
+	// The names of the environments whose jobs can use the variable, all jobs can use it if it's empty.
+	// It's only used together with the visibility.
+	SelectedEnvironments []string `json:"selected_environments"`
 }
 
 // ActionVariable return value of the query API
@@ -46,4 +71,11 @@ type ActionVariable struct {
 	Data string `json:"data"`
 	// the description of the variable
 	Description string `json:"description"`
+	// the repositories of the organization which can use the variable, only set for organization variables
+	// enum: all,private,selected
+	Visibility string `json:"visibility,omitempty"`
+	// the ids of the repositories which can use the variable if the visibility is "selected"
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids,omitempty"`
+	// the names of the environments whose jobs can use the variable, all jobs can use it if it's empty
+	SelectedEnvironments []string `json:"selected_environments,omitempty"`
 }
@@ -0,0 +1,303 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+// GitHub Actions Cache API (v1), used by actions/cache
+//
+// The runners use the built-in cache server when the external cache server of act_runner is set to "<ROOT_URL>/api/actions_cache/".
+// The caches are scoped by the repository and the ref of the run, a run can restore the caches of its ref or of the default branch.
+//
+// 1. Look for a cache
+// GET: /_apis/artifactcache/cache?keys=key1,key2&version=xxx
+// Response: 200 {"result":"hit","archiveLocation":"...","cacheKey":"..."} or 204 if no cache matches
+//
+// 2. Reserve a cache
+// POST: /_apis/artifactcache/caches {"key":"...","version":"...","cacheSize":1024}
+// Response: {"cacheId":1}, 409 if the key and the version have already been reserved in the ref
+//
+// 3. Upload a chunk of the archive
+// PATCH: /_apis/artifactcache/caches/{cache_id} with header Content-Range: bytes 0-1023/*
+//
+// 4. Commit the cache
+// POST: /_apis/artifactcache/caches/{cache_id} {"size":1024}
+//
+// 5. Download the archive, with the signed URL of the archiveLocation
+// GET: /_apis/artifactcache/artifacts/{cache_id}?sig=...&expires=...&taskID=...
+//
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+)
+
+const cacheRouteBase = "/_apis/artifactcache"
+
+type cacheRoutes struct {
+	prefix string
+}
+
+func CacheRoutes(prefix string) *web.Router {
+	m := web.NewRouter()
+
+	r := cacheRoutes{prefix: prefix}
+
+	m.Group(cacheRouteBase, func() {
+		m.Group("", func() {
+			m.Get("/cache", r.findCache)
+			m.Post("/caches", r.reserveCache)
+			m.Patch("/caches/{cache_id}", r.uploadCache)
+			m.Post("/caches/{cache_id}", r.commitCache)
+			m.Post("/clean", r.clean)
+		}, ArtifactContexter())
+		// the archive is downloaded without the authorization header, with a signed URL
+		m.Get("/artifacts/{cache_id}", ArtifactV4Contexter(), r.downloadCache)
+	})
+
+	return m
+}
+
+// loadRun loads the run of the task, the caches are scoped to its repository and ref
+func (r cacheRoutes) loadRun(ctx *ArtifactContext) (*actions.ActionRun, bool) {
+	if err := ctx.ActionTask.Job.LoadRun(ctx); err != nil {
+		log.Error("Error loading run of job %d: %v", ctx.ActionTask.JobID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error loading run")
+		return nil, false
+	}
+	return ctx.ActionTask.Job.Run, true
+}
+
+// getCache returns a cache of the repository of the run, which has been saved in the ref of the run
+func (r cacheRoutes) getCache(ctx *ArtifactContext, run *actions.ActionRun) (*actions.ActionCache, bool) {
+	c, err := actions.GetCacheByID(ctx, run.RepoID, ctx.PathParamInt64("cache_id"))
+	if err == nil && c.Ref != run.Ref {
+		err = util.NewNotExistErrorf("cache %d isn't in ref %s", c.ID, run.Ref)
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.HTTPError(http.StatusNotFound, "Cache not found")
+			return nil, false
+		}
+		log.Error("Error getting cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting cache")
+		return nil, false
+	}
+	return c, true
+}
+
+// buildSignature signs the download URL of a cache, the ids are written with a fixed width so that they can't be shifted into each other
+func (r cacheRoutes) buildSignature(expires string, repoID, taskID, cacheID int64) []byte {
+	mac := hmac.New(sha256.New, setting.GetGeneralTokenSigningSecret())
+	mac.Write([]byte("DownloadCache"))
+	mac.Write([]byte(expires))
+	_ = binary.Write(mac, binary.BigEndian, []int64{repoID, taskID, cacheID})
+	return mac.Sum(nil)
+}
+
+func (r cacheRoutes) buildDownloadURL(ctx *ArtifactContext, repoID, taskID, cacheID int64) string {
+	expires := time.Now().Add(60 * time.Minute).Format(time.RFC3339)
+	return strings.TrimSuffix(httplib.GuessCurrentAppURL(ctx), "/") + strings.TrimSuffix(r.prefix, "/") +
+		cacheRouteBase + "/artifacts/" + strconv.FormatInt(cacheID, 10) +
+		"?sig=" + base64.URLEncoding.EncodeToString(r.buildSignature(expires, repoID, taskID, cacheID)) +
+		"&expires=" + url.QueryEscape(expires) + "&taskID=" + strconv.FormatInt(taskID, 10)
+}
+
+type findCacheResponse struct {
+	Result          string `json:"result"`
+	ArchiveLocation string `json:"archiveLocation"`
+	CacheKey        string `json:"cacheKey"`
+}
+
+// findCache looks for a cache matching the keys, the first key is the primary key and the others are the restore keys
+func (r cacheRoutes) findCache(ctx *ArtifactContext) {
+	run, ok := r.loadRun(ctx)
+	if !ok {
+		return
+	}
+	keys := strings.Split(ctx.Req.URL.Query().Get("keys"), ",")
+	version := ctx.Req.URL.Query().Get("version")
+
+	c, err := actions_service.FindCacheForRun(ctx, run, keys, version)
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.Status(http.StatusNoContent)
+		return
+	} else if err != nil {
+		log.Error("Error finding cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error finding cache")
+		return
+	}
+	ctx.JSON(http.StatusOK, findCacheResponse{
+		Result:          "hit",
+		ArchiveLocation: r.buildDownloadURL(ctx, c.RepoID, ctx.ActionTask.ID, c.ID),
+		CacheKey:        c.Key,
+	})
+}
+
+type reserveCacheRequest struct {
+	Key       string `json:"key"`
+	Version   string `json:"version"`
+	CacheSize int64  `json:"cacheSize"`
+}
+
+type reserveCacheResponse struct {
+	CacheID int64 `json:"cacheId"`
+}
+
+func (r cacheRoutes) reserveCache(ctx *ArtifactContext) {
+	run, ok := r.loadRun(ctx)
+	if !ok {
+		return
+	}
+	var req reserveCacheRequest
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&req); err != nil {
+		log.Error("Error decode request body: %v", err)
+		ctx.HTTPError(http.StatusBadRequest, "Error decode request body")
+		return
+	}
+
+	c, err := actions_service.ReserveCacheForRun(ctx, run, req.Key, req.Version, req.CacheSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.HTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+		default:
+			log.Error("Error reserving cache: %v", err)
+			ctx.HTTPError(http.StatusInternalServerError, "Error reserving cache")
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, reserveCacheResponse{CacheID: c.ID})
+}
+
+func (r cacheRoutes) uploadCache(ctx *ArtifactContext) {
+	run, ok := r.loadRun(ctx)
+	if !ok {
+		return
+	}
+	c, ok := r.getCache(ctx, run)
+	if !ok {
+		return
+	}
+
+	// parse content-range header, format: bytes 0-1023/*
+	var start, end int64
+	if _, err := fmt.Sscanf(ctx.Req.Header.Get("Content-Range"), "bytes %d-%d/", &start, &end); err != nil {
+		ctx.HTTPError(http.StatusBadRequest, "Invalid Content-Range header")
+		return
+	}
+	if err := actions_service.UploadCacheChunk(c, ctx.Req.Body, start, end); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Error uploading chunk of cache %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error uploading chunk")
+		return
+	}
+	ctx.JSON(http.StatusOK, map[string]string{"message": "success"})
+}
+
+type commitCacheRequest struct {
+	Size int64 `json:"size"`
+}
+
+func (r cacheRoutes) commitCache(ctx *ArtifactContext) {
+	run, ok := r.loadRun(ctx)
+	if !ok {
+		return
+	}
+	c, ok := r.getCache(ctx, run)
+	if !ok {
+		return
+	}
+	var req commitCacheRequest
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&req); err != nil {
+		log.Error("Error decode request body: %v", err)
+		ctx.HTTPError(http.StatusBadRequest, "Error decode request body")
+		return
+	}
+
+	if err := actions_service.CommitCache(ctx, c, req.Size); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Error committing cache %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error committing cache")
+		return
+	}
+	ctx.JSON(http.StatusOK, map[string]string{"message": "success"})
+}
+
+// clean is called by the runners after a job, the caches are cleaned up by the cron task of the server
+func (r cacheRoutes) clean(ctx *ArtifactContext) {
+	ctx.Status(http.StatusOK)
+}
+
+func (r cacheRoutes) downloadCache(ctx *ArtifactContext) {
+	query := ctx.Req.URL.Query()
+	sig, _ := base64.URLEncoding.DecodeString(query.Get("sig"))
+	expires := query.Get("expires")
+	taskID, _ := strconv.ParseInt(query.Get("taskID"), 10, 64)
+	cacheID := ctx.PathParamInt64("cache_id")
+	if t, err := time.Parse(time.RFC3339, expires); err != nil || t.Before(time.Now()) {
+		ctx.HTTPError(http.StatusUnauthorized, "Error link expired")
+		return
+	}
+
+	task, err := actions.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.HTTPError(http.StatusUnauthorized, "Error unauthorized")
+			return
+		}
+		log.Error("Error runner api getting task by ID: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error runner api getting task by ID")
+		return
+	}
+	// the signature binds the URL to the repository of the task, the cache is looked up in it
+	if !hmac.Equal(sig, r.buildSignature(expires, task.RepoID, taskID, cacheID)) {
+		ctx.HTTPError(http.StatusUnauthorized, "Error unauthorized")
+		return
+	}
+	c, err := actions.GetCacheByID(ctx, task.RepoID, cacheID)
+	if err != nil || !c.Complete {
+		ctx.HTTPError(http.StatusNotFound, "Cache not found")
+		return
+	}
+
+	f, err := storage.ActionsCaches.Open(c.StoragePath)
+	if err != nil {
+		log.Error("Error opening cache %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error opening cache")
+		return
+	}
+	defer f.Close()
+	ctx.ServeContent(f, &context.ServeHeaderOptions{
+		Filename:     fmt.Sprintf("cache-%d.tzst", c.ID),
+		ContentType:  "application/octet-stream",
+		LastModified: c.CreatedUnix.AsLocalTime(),
+	})
+}
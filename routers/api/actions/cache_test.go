@@ -0,0 +1,25 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheRoutes_BuildSignature(t *testing.T) {
+	r := cacheRoutes{}
+	expires := "2025-01-01T00:00:00Z"
+
+	sig := r.buildSignature(expires, 1, 12, 3)
+	assert.Equal(t, sig, r.buildSignature(expires, 1, 12, 3))
+
+	// the digits of the ids can't be shifted from one id to another
+	assert.NotEqual(t, sig, r.buildSignature(expires, 1, 1, 23))
+	assert.NotEqual(t, sig, r.buildSignature(expires, 11, 2, 3))
+	// the signature is bound to the repository
+	assert.NotEqual(t, sig, r.buildSignature(expires, 2, 12, 3))
+	assert.NotEqual(t, sig, r.buildSignature("2025-01-01T00:00:01Z", 1, 12, 3))
+}
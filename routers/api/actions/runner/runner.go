@@ -272,6 +272,10 @@ func (s *Service) UpdateLog(
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "write logs: %v", err)
 	}
+	if err := actions_service.CreateTaskAnnotationsFromLogs(ctx, task, rows, ack); err != nil {
+		// It's ok not to return errors, the annotations are not critical.
+		log.Warn("Failed to create the annotations of task %d: %v", task.ID, err)
+	}
 	task.LogLength += int64(len(rows))
 	for _, n := range ns {
 		task.LogIndexes = append(task.LogIndexes, task.LogSize)
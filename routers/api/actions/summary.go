@@ -0,0 +1,69 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+// Job Summaries API, used by the runners to upload the content written by the steps to $GITHUB_STEP_SUMMARY
+//
+// The runners upload the summary of a step after the step has finished, with the ACTIONS_RUNTIME_TOKEN of the task.
+// The summaries of the steps are rendered as markdown on the page of the job.
+//
+// The summaries are not part of the logs or of the task state the runners report, so this endpoint needs a runner
+// which uploads them: act writes the summary of every step to a file and resets it before the next step, and the runner
+// has to send the content of that file here before the reset. act_runner doesn't do it yet, with older runners the jobs
+// just have no summary, the annotations are parsed from the logs and don't depend on it.
+//
+// PUT: /api/actions_summary/steps/{step_index}
+// Request: the markdown content, up to 1 MiB
+// Response: 204, 404 if the task doesn't have the step, 413 if the content is too large
+//
+
+import (
+	"io"
+	"net/http"
+
+	"code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/web"
+)
+
+func SummaryRoutes() *web.Router {
+	m := web.NewRouter()
+	m.Use(ArtifactContexter())
+	m.Put("/steps/{step_index}", uploadStepSummary)
+	return m
+}
+
+func uploadStepSummary(ctx *ArtifactContext) {
+	task := ctx.ActionTask
+	stepIndex := ctx.PathParamInt64("step_index")
+
+	steps, err := actions.GetTaskStepsByTaskID(ctx, task.ID)
+	if err != nil {
+		log.Error("Error getting steps of task %d: %v", task.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting steps")
+		return
+	}
+	if stepIndex < 0 || stepIndex >= int64(len(steps)) {
+		ctx.HTTPError(http.StatusNotFound, "Step not found")
+		return
+	}
+
+	content, err := io.ReadAll(io.LimitReader(ctx.Req.Body, actions.MaxStepSummarySize+1))
+	if err != nil {
+		log.Error("Error reading summary: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error reading summary")
+		return
+	}
+	if len(content) > actions.MaxStepSummarySize {
+		ctx.HTTPError(http.StatusRequestEntityTooLarge, "Summary is too large")
+		return
+	}
+
+	if err := actions.SetTaskStepSummary(ctx, task, stepIndex, string(content)); err != nil {
+		log.Error("Error setting summary of step %d of task %d: %v", stepIndex, task.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error setting summary")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
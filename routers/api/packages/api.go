@@ -32,6 +32,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/rpm"
 	"code.gitea.io/gitea/routers/api/packages/rubygems"
 	"code.gitea.io/gitea/routers/api/packages/swift"
+	"code.gitea.io/gitea/routers/api/packages/terraform"
 	"code.gitea.io/gitea/routers/api/packages/vagrant"
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/context"
@@ -121,6 +122,18 @@ func CommonRoutes() *web.Router {
 		&chef.Auth{},
 	})
 
+	// Terraform resolves the registry APIs of a host by service discovery, so the namespace (owner) can't be part of the base path
+	r.Group("/-/terraform", func() {
+		r.Group("/modules/{username}/{name}/{system}", func() {
+			r.Get("/versions", terraform.EnumerateModuleVersions)
+			r.Get("/{version}/download", terraform.DownloadModule)
+		})
+		r.Group("/providers/{username}/{type}", func() {
+			r.Get("/versions", terraform.EnumerateProviderVersions)
+			r.Get("/{version}/download/{os}/{arch}", terraform.DownloadProviderPlatform)
+		})
+	}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
+
 	r.Group("/{username}", func() {
 		r.Group("/alpine", func() {
 			r.Get("/key", alpine.GetRepositoryKey)
@@ -509,6 +522,23 @@ func CommonRoutes() *web.Router {
 				r.Get("/identifiers", swift.CheckAcceptMediaType(swift.AcceptJSON), swift.LookupPackageIdentifiers)
 			}, reqPackageAccess(perm.AccessModeRead))
 		})
+		r.Group("/terraform", func() {
+			r.Get("/key", terraform.GetSigningKey)
+			r.Group("/modules/{name}/{system}/{version}", func() {
+				r.Get("", terraform.DownloadModuleArchive)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), terraform.UploadModule)
+				r.Delete("", reqPackageAccess(perm.AccessModeWrite), terraform.DeleteModule)
+			})
+			r.Group("/providers/{type}/{version}", func() {
+				r.Delete("", reqPackageAccess(perm.AccessModeWrite), terraform.DeleteProvider)
+				r.Get("/SHA256SUMS", terraform.GetProviderSHA256Sums)
+				r.Get("/SHA256SUMS.sig", terraform.GetProviderSHA256SumsSignature)
+				r.Group("/{filename}", func() {
+					r.Get("", terraform.DownloadProviderFile)
+					r.Put("", reqPackageAccess(perm.AccessModeWrite), terraform.UploadProviderFile)
+				})
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/vagrant", func() {
 			r.Group("/authenticate", func() {
 				r.Get("", vagrant.CheckAuthenticate)
@@ -540,8 +570,6 @@ func ContainerRoutes() *web.Router {
 		&container.Auth{},
 	})
 
-	// TODO: Content Discovery / References (not implemented yet)
-
 	r.Get("", container.ReqContainerAccess, container.DetermineSupport)
 	r.Group("/token", func() {
 		r.Get("", container.Authenticate)
@@ -552,6 +580,7 @@ func ContainerRoutes() *web.Router {
 		r.PathGroup("/*", func(g *web.RouterPathGroup) {
 			g.MatchPath("POST", "/<image:*>/blobs/uploads", reqPackageAccess(perm.AccessModeWrite), container.VerifyImageName, container.PostBlobsUploads)
 			g.MatchPath("GET", "/<image:*>/tags/list", container.VerifyImageName, container.GetTagsList)
+			g.MatchPath("GET", `/<image:*>/referrers/<digest>`, container.VerifyImageName, container.GetReferrers)
 
 			patternBlobsUploadsUUID := g.PatternRegexp(`/<image:*>/blobs/uploads/<uuid:[-.=\w]+>`, reqPackageAccess(perm.AccessModeWrite), container.VerifyImageName)
 			g.MatchPattern("GET", patternBlobsUploadsUUID, container.GetBlobsUpload)
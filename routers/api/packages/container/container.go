@@ -34,6 +34,8 @@ import (
 	container_service "code.gitea.io/gitea/services/packages/container"
 
 	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // maximum size of a container manifest
@@ -57,6 +59,7 @@ type containerHeaders struct {
 	Location      string
 	ContentType   string
 	ContentLength optional.Option[int64]
+	Subject       string
 }
 
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#legacy-docker-support-http-headers
@@ -80,6 +83,9 @@ func setResponseHeaders(resp http.ResponseWriter, h *containerHeaders) {
 		resp.Header().Set("Docker-Content-Digest", h.ContentDigest)
 		resp.Header().Set("ETag", fmt.Sprintf(`"%s"`, h.ContentDigest))
 	}
+	if h.Subject != "" {
+		resp.Header().Set("OCI-Subject", h.Subject)
+	}
 	resp.Header().Set("Docker-Distribution-Api-Version", "registry/2.0")
 	resp.WriteHeader(h.Status)
 }
@@ -603,6 +609,7 @@ func PutManifest(ctx *context.Context) {
 	setResponseHeaders(ctx.Resp, &containerHeaders{
 		Location:      fmt.Sprintf("/v2/%s/%s/manifests/%s", ctx.Package.Owner.LowerName, mci.Image, reference),
 		ContentDigest: digest,
+		Subject:       mci.Subject,
 		Status:        http.StatusCreated,
 	})
 }
@@ -633,7 +640,30 @@ func getManifestFromContext(ctx *context.Context) (*packages_model.PackageFileDe
 		return nil, err
 	}
 
-	return workaroundGetContainerBlob(ctx, opts)
+	client, err := getProxyClient(ctx, ctx.Package.Owner)
+	if err != nil {
+		return nil, err
+	}
+	var upstreamErr error
+	if client != nil {
+		upstreamErr = syncManifestFromUpstream(ctx, client, ctx.PathParam("image"), ctx.PathParam("reference"))
+	}
+
+	pfd, err := workaroundGetContainerBlob(ctx, opts)
+	// the stored manifest is still served if the upstream registry is not available
+	if errors.Is(err, container_model.ErrContainerBlobNotExist) && upstreamErr != nil && !errors.Is(upstreamErr, util.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w", errUpstreamUnavailable, upstreamErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Tag != "" {
+		if err := container_service.CheckTagSignatureRequired(ctx, ctx.Package.Owner.ID, pfd); err != nil {
+			return nil, err
+		}
+	}
+	return pfd, nil
 }
 
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#checking-if-content-exists-in-the-registry
@@ -642,6 +672,10 @@ func HeadManifest(ctx *context.Context) {
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
 			apiErrorDefined(ctx, errManifestUnknown)
+		} else if errors.Is(err, packages_service.ErrMissingSignature) {
+			apiErrorDefined(ctx, errDenied.WithMessage("The manifest of the tag has no signature by a trusted key"))
+		} else if errors.Is(err, errUpstreamUnavailable) {
+			apiError(ctx, http.StatusBadGateway, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -662,6 +696,10 @@ func GetManifest(ctx *context.Context) {
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
 			apiErrorDefined(ctx, errManifestUnknown)
+		} else if errors.Is(err, packages_service.ErrMissingSignature) {
+			apiErrorDefined(ctx, errDenied.WithMessage("The manifest of the tag has no signature by a trusted key"))
+		} else if errors.Is(err, errUpstreamUnavailable) {
+			apiError(ctx, http.StatusBadGateway, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -799,3 +837,41 @@ func workaroundGetContainerBlob(ctx *context.Context, opts *container_model.Blob
 
 	return blob, nil
 }
+
+// GetReferrers lists the manifests which refer to the manifest with the specified digest
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func GetReferrers(ctx *context.Context) {
+	subject := ctx.PathParam("digest")
+	if digest.Digest(subject).Validate() != nil {
+		apiErrorDefined(ctx, errDigestInvalid)
+		return
+	}
+
+	artifactType := ctx.FormTrim("artifactType")
+
+	// an unknown subject results in an empty index, the registry must not respond with 404
+	referrers, err := container_service.GetReferrers(ctx, ctx.Package.Owner.ID, ctx.PathParam("image"), subject, artifactType)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	descriptors := make([]oci.Descriptor, 0, len(referrers))
+	for _, r := range referrers {
+		descriptors = append(descriptors, r.Descriptor)
+	}
+
+	if artifactType != "" {
+		ctx.Resp.Header().Set("OCI-Filters-Applied", "artifactType")
+	}
+
+	setResponseHeaders(ctx.Resp, &containerHeaders{
+		Status:      http.StatusOK,
+		ContentType: oci.MediaTypeImageIndex,
+	})
+	_ = json.NewEncoder(ctx.Resp).Encode(oci.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: oci.MediaTypeImageIndex,
+		Manifests: descriptors,
+	}) // ignore network errors
+}
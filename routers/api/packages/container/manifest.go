@@ -38,6 +38,8 @@ type manifestCreationInfo struct {
 	Reference  string
 	IsTagged   bool
 	Properties map[string]string
+	// Subject is the digest of the manifest this manifest refers to, set while processing the manifest
+	Subject string
 }
 
 func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (string, error) {
@@ -52,6 +54,14 @@ func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packag
 		return "", err
 	}
 
+	// image manifests and indexes share the same subject field
+	if index.Subject != nil {
+		if err := index.Subject.Digest.Validate(); err != nil {
+			return "", errManifestInvalid.WithMessage("Subject digest is invalid")
+		}
+		mci.Subject = index.Subject.Digest.String()
+	}
+
 	if !container_module.IsMediaTypeValid(mci.MediaType) {
 		mci.MediaType = index.MediaType
 		if !container_module.IsMediaTypeValid(mci.MediaType) {
@@ -157,7 +167,16 @@ func processOciImageManifest(ctx context.Context, mci *manifestCreationInfo, buf
 		return err
 	})
 
-	return handleCreateManifestResult(ctx, err, mci, contentStore, &txRet)
+	manifestDigest, err = handleCreateManifestResult(ctx, err, mci, contentStore, &txRet)
+	if err != nil {
+		return "", err
+	}
+
+	if err := container_service.StoreCosignSignatures(ctx, mci.Owner, mci.Creator, mci.Image, manifest); err != nil {
+		log.Error("Error storing cosign signatures: %v", err) // the manifest is stored, ignore this error
+	}
+
+	return manifestDigest, nil
 }
 
 func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (manifestDigest string, errRet error) {
@@ -173,8 +192,11 @@ func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *p
 	var txRet processManifestTxRet
 	err := db.WithTx(ctx, func(ctx context.Context) (err error) {
 		metadata := &container_module.Metadata{
-			Type:      container_module.TypeOCI,
-			Manifests: make([]*container_module.Manifest, 0, len(index.Manifests)),
+			Type:         container_module.TypeOCI,
+			Manifests:    make([]*container_module.Manifest, 0, len(index.Manifests)),
+			ArtifactType: index.ArtifactType,
+			Subject:      mci.Subject,
+			Annotations:  index.Annotations,
 		}
 
 		for _, manifest := range index.Manifests {
@@ -318,6 +340,16 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 		}
 	}
 
+	if metadata.Subject != "" {
+		if err = packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject, metadata.Subject); err != nil {
+			return nil, fmt.Errorf("InsertOrUpdateProperty(ManifestSubject): %w", err)
+		}
+	} else {
+		if err = packages_model.DeletePropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject); err != nil {
+			return nil, fmt.Errorf("DeletePropertiesByName(ManifestSubject): %w", err)
+		}
+	}
+
 	return pv, nil
 }
 
@@ -0,0 +1,246 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+	proxy_service "code.gitea.io/gitea/services/packages/proxy"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// errUpstreamUnavailable indicates that the upstream registry failed to provide a manifest which is not stored
+var errUpstreamUnavailable = errors.New("the upstream registry is not available")
+
+var manifestAcceptHeader = http.Header{
+	"Accept": []string{
+		oci.MediaTypeImageManifest,
+		oci.MediaTypeImageIndex,
+		"application/vnd.docker.distribution.manifest.v2+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+	},
+}
+
+// getProxyClient returns the client of the container proxy of the owner or nil if there is none
+func getProxyClient(ctx context.Context, owner *user_model.User) (*proxy_service.Client, error) {
+	client, err := proxy_service.GetClient(ctx, owner, packages_model.TypeContainer)
+	if errors.Is(err, packages_model.ErrPackageProxyNotExist) {
+		return nil, nil
+	}
+	return client, err
+}
+
+// upstreamImageName returns the name of the image in the upstream registry,
+// the official images of Docker Hub are in the "library" namespace
+func upstreamImageName(client *proxy_service.Client, image string) string {
+	if !strings.Contains(image, "/") && strings.HasSuffix(strings.ToLower(client.URL("")), "docker.io/") {
+		return "library/" + image
+	}
+	return image
+}
+
+// syncManifestFromUpstream fetches the manifest of the reference and its blobs from the upstream registry if they are not stored.
+// A tag which was fetched before is updated if it points to another manifest in the upstream registry after the metadata TTL.
+// The manifests and blobs uploaded to this registry are never changed.
+func syncManifestFromUpstream(ctx context.Context, client *proxy_service.Client, image, reference string) error {
+	pi := &packages_service.PackageInfo{
+		Owner:       client.Owner,
+		PackageType: packages_model.TypeContainer,
+		Name:        image,
+	}
+
+	isTagged := digest.Digest(reference).Validate() != nil
+	if isTagged && !globalVars().referencePattern.MatchString(reference) {
+		return util.NewNotExistErrorf("invalid tag")
+	}
+
+	release, err := client.Lock(ctx, image)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	local, err := getStoredManifest(ctx, pi, reference, isTagged)
+	if err != nil {
+		return err
+	}
+	if local != nil && !isTagged {
+		return nil
+	}
+	if local != nil {
+		pvps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, local.File.VersionID, packages_model.PropertyProxyUpstream)
+		if err != nil {
+			return err
+		}
+		if len(pvps) == 0 {
+			return nil
+		}
+	}
+
+	data, err := client.GetMetadata(ctx, fmt.Sprintf("v2/%s/manifests/%s", upstreamImageName(client, image), reference), manifestAcceptHeader)
+	if err != nil {
+		return err
+	}
+	if local != nil && local.Properties.GetByName(container_module.PropertyDigest) == digestFromContent(data) {
+		return nil
+	}
+
+	return storeUpstreamManifest(ctx, client, pi, reference, isTagged, data)
+}
+
+func getStoredManifest(ctx context.Context, pi *packages_service.PackageInfo, reference string, isTagged bool) (*packages_model.PackageFileDescriptor, error) {
+	opts := &container_model.BlobSearchOptions{
+		OwnerID:    pi.Owner.ID,
+		Image:      pi.Name,
+		IsManifest: true,
+	}
+	if isTagged {
+		opts.Tag = reference
+		opts.OnlyLead = true
+	} else {
+		opts.Digest = reference
+	}
+
+	pfd, err := container_model.GetContainerBlob(ctx, opts)
+	if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		return nil, nil
+	}
+	return pfd, err
+}
+
+func digestFromContent(data []byte) string {
+	hash := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
+
+// storeUpstreamManifest stores the manifest of the upstream registry after fetching the blobs or child manifests it references
+func storeUpstreamManifest(ctx context.Context, client *proxy_service.Client, pi *packages_service.PackageInfo, reference string, isTagged bool, data []byte) error {
+	if len(data) > maxManifestSize {
+		return errManifestInvalid.WithMessage("Manifest exceeds maximum size")
+	}
+	if !isTagged && reference != digestFromContent(data) {
+		return fmt.Errorf("digest mismatch of the manifest %s of the upstream registry", reference)
+	}
+
+	var index oci.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return err
+	}
+	mediaType := index.MediaType
+	if mediaType == "" {
+		mediaType = util.Iif(len(index.Manifests) != 0, oci.MediaTypeImageIndex, oci.MediaTypeImageManifest)
+	}
+
+	if container_module.IsMediaTypeImageIndex(mediaType) {
+		for _, m := range index.Manifests {
+			local, err := getStoredManifest(ctx, pi, string(m.Digest), false)
+			if err != nil {
+				return err
+			}
+			if local != nil {
+				continue
+			}
+			child, err := client.GetMetadata(ctx, fmt.Sprintf("v2/%s/manifests/%s", upstreamImageName(client, pi.Name), m.Digest), manifestAcceptHeader)
+			if err != nil {
+				return err
+			}
+			if err := storeUpstreamManifest(ctx, client, pi, string(m.Digest), false, child); err != nil {
+				return err
+			}
+		}
+	} else if container_module.IsMediaTypeImageManifest(mediaType) {
+		var manifest oci.Manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return err
+		}
+		for _, d := range append([]oci.Descriptor{manifest.Config}, manifest.Layers...) {
+			if err := fetchUpstreamBlob(ctx, client, pi, d.Digest); err != nil {
+				return err
+			}
+		}
+	} else {
+		return errManifestInvalid.WithMessage("MediaType not recognized")
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	if _, err := processManifest(ctx, &manifestCreationInfo{
+		MediaType: mediaType,
+		Owner:     pi.Owner,
+		Creator:   pi.Owner,
+		Image:     pi.Name,
+		Reference: reference,
+		IsTagged:  isTagged,
+	}, buf); err != nil {
+		log.Error("Package proxy [%d]: failed to store the manifest %s of %s: %v", client.Proxy.ID, reference, pi.Name, err)
+		return err
+	}
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, pi.Owner.ID, packages_model.TypeContainer, pi.Name, strings.ToLower(reference))
+	if err != nil {
+		return err
+	}
+	for name, value := range client.VersionProperties() {
+		if err := packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchUpstreamBlob fetches the blob from the upstream registry into the upload version of the image if it's not stored
+func fetchUpstreamBlob(ctx context.Context, client *proxy_service.Client, pi *packages_service.PackageInfo, d digest.Digest) error {
+	if d.Validate() != nil || d.Algorithm() != digest.SHA256 {
+		return fmt.Errorf("unsupported digest %s of the upstream registry", d)
+	}
+
+	_, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+		OwnerID: pi.Owner.ID,
+		Image:   pi.Name,
+		Digest:  string(d),
+	})
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		return err
+	}
+
+	buf, err := client.Download(ctx, fmt.Sprintf("v2/%s/blobs/%s", upstreamImageName(client, pi.Name), d), nil)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	if digestFromHashSummer(buf) != string(d) {
+		return fmt.Errorf("digest mismatch of the blob %s of the upstream registry", d)
+	}
+
+	_, err = saveAsPackageBlob(ctx, buf, &packages_service.PackageCreationInfo{
+		PackageInfo: *pi,
+		Creator:     pi.Owner,
+	})
+	return err
+}
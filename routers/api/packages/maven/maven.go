@@ -92,34 +92,59 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	}
 	pvs = append(pvsLegacy, pvs...)
 
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
-		return
+	var metadata *MetadataResponse
+	if len(pvs) != 0 {
+		pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		sort.Slice(pds, func(i, j int) bool {
+			// Maven and Gradle order packages by their creation timestamp and not by their version string
+			return pds[i].Version.CreatedUnix < pds[j].Version.CreatedUnix
+		})
+
+		metadata = createMetadataResponse(pds, params.GroupID, params.ArtifactID)
+
+		latest := pds[len(pds)-1]
+		// http.TimeFormat required a UTC time, refer to https://pkg.go.dev/net/http#TimeFormat
+		lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
+		ctx.Resp.Header().Set("Last-Modified", lastModified)
 	}
 
-	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	client, err := getProxyClient(ctx)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+	if client != nil {
+		upstream, err := fetchUpstreamMetadata(ctx, client, params)
+		if err == nil {
+			metadata = mergeUpstreamMetadata(metadata, upstream)
+		} else if metadata == nil {
+			// the stored versions are still served if the upstream registry is not available
+			if errors.Is(err, util.ErrNotExist) {
+				apiError(ctx, http.StatusNotFound, err)
+			} else {
+				apiError(ctx, http.StatusBadGateway, err)
+			}
+			return
+		}
+	}
 
-	sort.Slice(pds, func(i, j int) bool {
-		// Maven and Gradle order packages by their creation timestamp and not by their version string
-		return pds[i].Version.CreatedUnix < pds[j].Version.CreatedUnix
-	})
+	if metadata == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
 
-	xmlMetadata, err := xml.Marshal(createMetadataResponse(pds, params.GroupID, params.ArtifactID))
+	xmlMetadata, err := xml.Marshal(metadata)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	xmlMetadataWithHeader := append([]byte(xml.Header), xmlMetadata...)
 
-	latest := pds[len(pds)-1]
-	// http.TimeFormat required a UTC time, refer to https://pkg.go.dev/net/http#TimeFormat
-	lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
-	ctx.Resp.Header().Set("Last-Modified", lastModified)
-
 	ext := strings.ToLower(path.Ext(params.Filename))
 	if isChecksumExtension(ext) {
 		var hash []byte
@@ -148,19 +173,6 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 }
 
 func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
-	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
-	if errors.Is(err, util.ErrNotExist) {
-		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
-	}
-	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
-		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
-		}
-		return
-	}
-
 	filename := params.Filename
 
 	ext := strings.ToLower(path.Ext(filename))
@@ -168,9 +180,27 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 		filename = filename[:len(filename)-len(ext)]
 	}
 
-	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+	pf, err := getPackageFile(ctx, params, filename)
+	if errors.Is(err, util.ErrNotExist) {
+		client, proxyErr := getProxyClient(ctx)
+		if proxyErr != nil {
+			apiError(ctx, http.StatusInternalServerError, proxyErr)
+			return
+		}
+		if client != nil {
+			if proxyErr := fetchPackageFile(ctx, client, params, filename); proxyErr != nil {
+				if errors.Is(proxyErr, util.ErrNotExist) {
+					apiError(ctx, http.StatusNotFound, proxyErr)
+				} else {
+					apiError(ctx, http.StatusBadGateway, proxyErr)
+				}
+				return
+			}
+			pf, err = getPackageFile(ctx, params, filename)
+		}
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -228,6 +258,18 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 	helper.ServePackageFile(ctx, s, u, pf, opts)
 }
 
+func getPackageFile(ctx *context.Context, params parameters, filename string) (*packages_model.PackageFile, error) {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
+	if errors.Is(err, util.ErrNotExist) {
+		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+}
+
 func mavenPkgNameKey(packageName string) string {
 	return "pkg_maven_" + packageName
 }
@@ -393,7 +435,7 @@ func UploadPackageFile(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrMissingSignature:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
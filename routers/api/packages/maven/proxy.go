@@ -0,0 +1,170 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package maven
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	maven_module "code.gitea.io/gitea/modules/packages/maven"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	proxy_service "code.gitea.io/gitea/services/packages/proxy"
+)
+
+// getProxyClient returns the client of the Maven proxy of the owner or nil if there is none
+func getProxyClient(ctx *context.Context) (*proxy_service.Client, error) {
+	client, err := proxy_service.GetClient(ctx, ctx.Package.Owner, packages_model.TypeMaven)
+	if errors.Is(err, packages_model.ErrPackageProxyNotExist) {
+		return nil, nil
+	}
+	return client, err
+}
+
+func (p *parameters) toUpstreamPath(filename string) string {
+	elems := []string{strings.ReplaceAll(p.GroupID, ".", "/"), p.ArtifactID}
+	if p.Version != "" {
+		elems = append(elems, p.Version)
+	}
+	return strings.Join(append(elems, filename), "/")
+}
+
+// fetchUpstreamMetadata returns the maven-metadata.xml of the package in the upstream registry
+func fetchUpstreamMetadata(ctx *context.Context, client *proxy_service.Client, params parameters) (*MetadataResponse, error) {
+	data, err := client.GetMetadata(ctx, params.toUpstreamPath(mavenMetadataFile), nil)
+	if err != nil {
+		return nil, err
+	}
+	var metadata *MetadataResponse
+	if err := xml.Unmarshal(data, &metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// mergeUpstreamMetadata adds the versions of the upstream registry, they are fetched on the first download
+func mergeUpstreamMetadata(local, upstream *MetadataResponse) *MetadataResponse {
+	if local == nil {
+		return upstream
+	}
+
+	versions := slices.Clone(upstream.Version)
+	for _, v := range local.Version {
+		if !slices.Contains(versions, v) {
+			versions = append(versions, v)
+		}
+	}
+	return &MetadataResponse{
+		GroupID:    local.GroupID,
+		ArtifactID: local.ArtifactID,
+		Release:    util.IfZero(upstream.Release, local.Release),
+		Latest:     util.IfZero(upstream.Latest, local.Latest),
+		Version:    versions,
+	}
+}
+
+// fetchPackageFile fetches the file of the package version from the upstream registry and stores it.
+// The snapshot versions are not fetched because their files change.
+func fetchPackageFile(ctx *context.Context, client *proxy_service.Client, params parameters, filename string) error {
+	if params.IsMeta || strings.HasSuffix(params.Version, "-SNAPSHOT") {
+		return util.NewNotExistErrorf("snapshot versions are not fetched from the upstream registry")
+	}
+
+	packageName := params.toInternalPackageName()
+
+	// uploads and fetches of the same package are done one at a time
+	releaser, err := globallock.Lock(ctx, mavenPkgNameKey(packageName))
+	if err != nil {
+		return err
+	}
+	defer releaser()
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, packageName, params.Version)
+	if err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+		return err
+	}
+	if pv != nil {
+		// the file may be fetched by a concurrent request meanwhile
+		if _, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey); err == nil {
+			return nil
+		}
+	}
+
+	buf, err := client.Download(ctx, params.toUpstreamPath(filename), nil)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	// verify the file against its checksum if the upstream registry provides it
+	if checksum, err := client.GetMetadata(ctx, params.toUpstreamPath(filename+extensionSHA1), nil); err == nil {
+		_, hashSHA1, _, _ := buf.Sums()
+		expected, _, _ := strings.Cut(strings.TrimSpace(string(checksum)), " ")
+		if !strings.EqualFold(expected, hex.EncodeToString(hashSHA1)) {
+			return fmt.Errorf("hash mismatch of the file %s of the upstream registry", filename)
+		}
+	} else if !errors.Is(err, util.ErrNotExist) {
+		return err
+	}
+
+	pvci := &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeMaven,
+			Name:        packageName,
+			Version:     params.Version,
+		},
+		SemverCompatible:  false,
+		Creator:           ctx.Package.Owner,
+		VersionProperties: client.VersionProperties(),
+	}
+	pfci := &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Creator: ctx.Package.Owner,
+		Data:    buf,
+	}
+
+	if path.Ext(filename) == extensionPom {
+		pfci.IsLead = true
+
+		if metadata, err := maven_module.ParsePackageMetaData(buf); err != nil {
+			log.Warn("Package proxy [%d]: failed to parse %s: %v", client.Proxy.ID, filename, err)
+		} else if metadata != nil {
+			pvci.Metadata = metadata
+			if pv != nil {
+				raw, err := json.Marshal(metadata)
+				if err != nil {
+					return err
+				}
+				pv.MetadataJSON = string(raw)
+				if err := packages_model.UpdateVersion(ctx, pv); err != nil {
+					return err
+				}
+			}
+		}
+
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	if _, _, err := packages_service.CreatePackageOrAddFileToExisting(ctx, pvci, pfci); err != nil {
+		log.Error("Package proxy [%d]: failed to store %s: %v", client.Proxy.ID, filename, err)
+		return err
+	}
+	return nil
+}
@@ -74,11 +74,15 @@ func createPackageMetadataVersion(registryURL string, pd *packages_model.Package
 		Dist: npm_module.PackageDistribution{
 			Shasum:    pd.Files[0].Blob.HashSHA1,
 			Integrity: "sha512-" + base64.StdEncoding.EncodeToString(hashBytes),
-			Tarball:   fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(pd.Package.Name), url.PathEscape(pd.Version.Version), url.PathEscape(pd.Files[0].File.LowerName)),
+			Tarball:   createTarballURL(registryURL, pd.Package.Name, pd.Version.Version, pd.Files[0].File.LowerName),
 		},
 	}
 }
 
+func createTarballURL(registryURL, packageName, packageVersion, filename string) string {
+	return fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(packageName), url.PathEscape(packageVersion), url.PathEscape(filename))
+}
+
 func createPackageSearchResponse(pds []*packages_model.PackageDescriptor, total int64) *npm_module.PackageSearch {
 	objects := make([]*npm_module.PackageSearchObject, 0, len(pds))
 	for _, pd := range pds {
@@ -53,27 +53,49 @@ func packageNameFromParams(ctx *context.Context) string {
 // PackageMetadata returns the metadata for a single package
 func PackageMetadata(ctx *context.Context) {
 	packageName := packageNameFromParams(ctx)
+	registryURL := setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/npm"
 
 	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
-		return
+
+	var resp *npm_module.PackageMetadata
+	if len(pvs) != 0 {
+		pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		resp = createPackageMetadataResponse(registryURL, pds)
 	}
 
-	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	client, err := getProxyClient(ctx)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+	if client != nil {
+		upstream, err := fetchUpstreamPackageMetadata(ctx, client, packageName)
+		if err == nil {
+			resp = mergeUpstreamPackageMetadata(registryURL, resp, upstream)
+		} else if resp == nil {
+			// the stored versions are still served if the upstream registry is not available
+			if errors.Is(err, util.ErrNotExist) {
+				apiError(ctx, http.StatusNotFound, err)
+			} else {
+				apiError(ctx, http.StatusBadGateway, err)
+			}
+			return
+		}
+	}
 
-	resp := createPackageMetadataResponse(
-		setting.AppURL+"api/packages/"+ctx.Package.Owner.Name+"/npm",
-		pds,
-	)
+	if resp == nil {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
 
 	ctx.JSON(http.StatusOK, resp)
 }
@@ -84,19 +106,35 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypeNpm,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-		ctx.Req.Method,
-	)
+	pi := &packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypeNpm,
+		Name:        packageName,
+		Version:     packageVersion,
+	}
+	pfi := &packages_service.PackageFileInfo{
+		Filename: filename,
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pi, pfi, ctx.Req.Method)
+	if errors.Is(err, packages_model.ErrPackageNotExist) {
+		client, proxyErr := getProxyClient(ctx)
+		if proxyErr != nil {
+			apiError(ctx, http.StatusInternalServerError, proxyErr)
+			return
+		}
+		if client != nil {
+			if proxyErr := fetchPackageVersion(ctx, client, packageName, packageVersion); proxyErr != nil {
+				if errors.Is(proxyErr, util.ErrNotExist) {
+					apiError(ctx, http.StatusNotFound, proxyErr)
+				} else {
+					apiError(ctx, http.StatusBadGateway, proxyErr)
+				}
+				return
+			}
+			s, u, pf, err = packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pi, pfi, ctx.Req.Method)
+		}
+	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -217,7 +255,7 @@ func UploadPackage(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageVersion:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrMissingSignature:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -0,0 +1,159 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	proxy_service "code.gitea.io/gitea/services/packages/proxy"
+)
+
+// getProxyClient returns the client of the npm proxy of the owner or nil if there is none
+func getProxyClient(ctx *context.Context) (*proxy_service.Client, error) {
+	client, err := proxy_service.GetClient(ctx, ctx.Package.Owner, packages_model.TypeNpm)
+	if errors.Is(err, packages_model.ErrPackageProxyNotExist) {
+		return nil, nil
+	}
+	return client, err
+}
+
+// fetchUpstreamPackageMetadata returns the metadata of the package in the upstream registry
+func fetchUpstreamPackageMetadata(ctx *context.Context, client *proxy_service.Client, packageName string) (*npm_module.PackageMetadata, error) {
+	data, err := client.GetMetadata(ctx, strings.Replace(packageName, "/", "%2f", 1), http.Header{"Accept": []string{"application/json"}})
+	if err != nil {
+		return nil, err
+	}
+	var metadata *npm_module.PackageMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// mergeUpstreamPackageMetadata adds the versions of the upstream registry which are not stored yet.
+// Their tarballs point to this registry which fetches them on the first download.
+func mergeUpstreamPackageMetadata(registryURL string, local, upstream *npm_module.PackageMetadata) *npm_module.PackageMetadata {
+	if local == nil {
+		local = &npm_module.PackageMetadata{
+			ID:          upstream.Name,
+			Name:        upstream.Name,
+			Description: upstream.Description,
+			Readme:      upstream.Readme,
+			Homepage:    upstream.Homepage,
+			Author:      upstream.Author,
+			License:     upstream.License,
+			Repository:  upstream.Repository,
+			Versions:    make(map[string]*npm_module.PackageMetadataVersion),
+			DistTags:    make(map[string]string),
+		}
+	}
+
+	for v, pmv := range upstream.Versions {
+		if _, ok := local.Versions[v]; ok {
+			continue
+		}
+		p, err := npm_module.CreatePackageFromVersion(pmv)
+		if err != nil {
+			continue
+		}
+		pmv.Dist.Tarball = createTarballURL(registryURL, p.Name, p.Version, p.Filename)
+		local.Versions[v] = pmv
+	}
+	for tag, v := range upstream.DistTags {
+		if _, ok := local.DistTags[tag]; !ok {
+			local.DistTags[tag] = v
+		}
+	}
+	return local
+}
+
+// fetchPackageVersion fetches the version of the package from the upstream registry and stores it
+func fetchPackageVersion(ctx *context.Context, client *proxy_service.Client, packageName, packageVersion string) error {
+	release, err := client.Lock(ctx, packageName)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// the version may be fetched by a concurrent request meanwhile
+	if _, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName, packageVersion); err == nil {
+		return nil
+	}
+
+	upstream, err := fetchUpstreamPackageMetadata(ctx, client, packageName)
+	if err != nil {
+		return err
+	}
+	var meta *npm_module.PackageMetadataVersion
+	for _, pmv := range upstream.Versions {
+		if pmv.Version == packageVersion {
+			meta = pmv
+			break
+		}
+	}
+	if meta == nil || meta.Dist.Tarball == "" {
+		return util.NewNotExistErrorf("version %s of %s does not exist in the upstream registry", packageVersion, packageName)
+	}
+
+	npmPackage, err := npm_module.CreatePackageFromVersion(meta)
+	if err != nil {
+		return err
+	}
+	if npmPackage.Name != packageName {
+		return util.NewNotExistErrorf("the upstream registry returned the package %s instead of %s", npmPackage.Name, packageName)
+	}
+
+	buf, err := client.Download(ctx, meta.Dist.Tarball, nil)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	_, hashSHA1, _, hashSHA512 := buf.Sums()
+	if meta.Dist.Integrity != "" {
+		if err := npm_module.ValidateIntegrity(meta.Dist.Integrity, hashSHA1, hashSHA512); err != nil {
+			return err
+		}
+	} else if hex.EncodeToString(hashSHA1) != meta.Dist.Shasum {
+		return npm_module.ErrInvalidIntegrity
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeNpm,
+				Name:        npmPackage.Name,
+				Version:     npmPackage.Version,
+			},
+			SemverCompatible:  true,
+			Creator:           ctx.Package.Owner,
+			Metadata:          npmPackage.Metadata,
+			VersionProperties: client.VersionProperties(),
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: npmPackage.Filename,
+			},
+			Creator: ctx.Package.Owner,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		log.Error("Package proxy [%d]: failed to store %s@%s: %v", client.Proxy.ID, packageName, packageVersion, err)
+	}
+	return err
+}
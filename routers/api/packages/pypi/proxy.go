@@ -0,0 +1,199 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pypi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	proxy_service "code.gitea.io/gitea/services/packages/proxy"
+)
+
+// upstreamProject is the response of the JSON API of the upstream registry,
+// see https://docs.pypi.org/api/json/
+type upstreamProject struct {
+	Info struct {
+		Name           string `json:"name"`
+		Author         string `json:"author"`
+		Summary        string `json:"summary"`
+		Description    string `json:"description"`
+		HomePage       string `json:"home_page"`
+		License        string `json:"license"`
+		RequiresPython string `json:"requires_python"`
+	} `json:"info"`
+	Releases map[string][]*upstreamFile `json:"releases"`
+	URLs     []*upstreamFile            `json:"urls"`
+}
+
+type upstreamFile struct {
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+	Digests  struct {
+		SHA256 string `json:"sha256"`
+	} `json:"digests"`
+	RequiresPython string `json:"requires_python"`
+	Yanked         bool   `json:"yanked"`
+}
+
+// upstreamLink is a file of the upstream registry which is not stored yet
+type upstreamLink struct {
+	Version        string
+	Filename       string
+	SHA256         string
+	RequiresPython string
+}
+
+// getProxyClient returns the client of the PyPI proxy of the owner or nil if there is none
+func getProxyClient(ctx *context.Context) (*proxy_service.Client, error) {
+	client, err := proxy_service.GetClient(ctx, ctx.Package.Owner, packages_model.TypePyPI)
+	if errors.Is(err, packages_model.ErrPackageProxyNotExist) {
+		return nil, nil
+	}
+	return client, err
+}
+
+func fetchUpstreamProject(ctx *context.Context, client *proxy_service.Client, p string) (*upstreamProject, error) {
+	data, err := client.GetMetadata(ctx, p, http.Header{"Accept": []string{"application/json"}})
+	if err != nil {
+		return nil, err
+	}
+	var project *upstreamProject
+	if err := json.Unmarshal(data, &project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// fetchUpstreamLinks returns the files of the package in the upstream registry which are not stored yet
+func fetchUpstreamLinks(ctx *context.Context, client *proxy_service.Client, packageName string, pds []*packages_model.PackageDescriptor) (string, []*upstreamLink, error) {
+	project, err := fetchUpstreamProject(ctx, client, fmt.Sprintf("pypi/%s/json", url.PathEscape(packageName)))
+	if err != nil {
+		return "", nil, err
+	}
+
+	stored := make(map[string]bool)
+	for _, pd := range pds {
+		for _, pf := range pd.Files {
+			stored[pf.File.LowerName] = true
+		}
+	}
+
+	links := make([]*upstreamLink, 0, len(project.Releases))
+	for v, files := range project.Releases {
+		for _, f := range files {
+			if f.Yanked || stored[strings.ToLower(f.Filename)] {
+				continue
+			}
+			links = append(links, &upstreamLink{
+				Version:        v,
+				Filename:       f.Filename,
+				SHA256:         f.Digests.SHA256,
+				RequiresPython: f.RequiresPython,
+			})
+		}
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Filename < links[j].Filename
+	})
+	return project.Info.Name, links, nil
+}
+
+// fetchPackageFile fetches the file of the package version from the upstream registry and stores it
+func fetchPackageFile(ctx *context.Context, client *proxy_service.Client, packageName, packageVersion, filename string) error {
+	if !isValidNameAndVersion(packageName, packageVersion) {
+		return util.NewNotExistErrorf("invalid name or version")
+	}
+
+	release, err := client.Lock(ctx, packageName)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// the file may be fetched by a concurrent request meanwhile
+	if pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypePyPI, packageName, packageVersion); err == nil {
+		if _, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey); err == nil {
+			return nil
+		}
+	}
+
+	project, err := fetchUpstreamProject(ctx, client, fmt.Sprintf("pypi/%s/%s/json", url.PathEscape(packageName), url.PathEscape(packageVersion)))
+	if err != nil {
+		return err
+	}
+	var file *upstreamFile
+	for _, f := range project.URLs {
+		if strings.EqualFold(f.Filename, filename) {
+			file = f
+			break
+		}
+	}
+	if file == nil {
+		return util.NewNotExistErrorf("file %s does not exist in the upstream registry", filename)
+	}
+
+	buf, err := client.Download(ctx, file.URL, nil)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	_, _, hashSHA256, _ := buf.Sums()
+	if !strings.EqualFold(file.Digests.SHA256, hex.EncodeToString(hashSHA256)) {
+		return fmt.Errorf("hash mismatch of the file %s of the upstream registry", filename)
+	}
+
+	homepageURL := project.Info.HomePage
+	if !validation.IsValidURL(homepageURL) {
+		homepageURL = ""
+	}
+
+	_, _, err = packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypePyPI,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			SemverCompatible: false,
+			Creator:          ctx.Package.Owner,
+			Metadata: &pypi_module.Metadata{
+				Author:          project.Info.Author,
+				LongDescription: project.Info.Description,
+				Summary:         project.Info.Summary,
+				ProjectURL:      homepageURL,
+				License:         project.Info.License,
+				RequiresPython:  util.IfZero(file.RequiresPython, project.Info.RequiresPython),
+			},
+			VersionProperties: client.VersionProperties(),
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: file.Filename,
+			},
+			Creator: ctx.Package.Owner,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		log.Error("Package proxy [%d]: failed to store %s: %v", client.Proxy.ID, filename, err)
+	}
+	return err
+}
@@ -17,6 +17,7 @@ import (
 	packages_module "code.gitea.io/gitea/modules/packages"
 	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/validation"
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
@@ -53,10 +54,6 @@ func PackageMetadata(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
-		return
-	}
 
 	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
 	if err != nil {
@@ -69,8 +66,40 @@ func PackageMetadata(ctx *context.Context) {
 		return strings.Compare(pds[i].Version.Version, pds[j].Version.Version) < 0
 	})
 
+	var displayName string
+	if len(pds) != 0 {
+		displayName = pds[0].Package.Name
+	}
+
+	client, err := getProxyClient(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if client != nil {
+		upstreamName, links, err := fetchUpstreamLinks(ctx, client, packageName, pds)
+		if err == nil {
+			displayName = util.IfZero(displayName, upstreamName)
+			ctx.Data["UpstreamLinks"] = links
+		} else if len(pds) == 0 {
+			// the stored versions are still served if the upstream registry is not available
+			if errors.Is(err, util.ErrNotExist) {
+				apiError(ctx, http.StatusNotFound, err)
+			} else {
+				apiError(ctx, http.StatusBadGateway, err)
+			}
+			return
+		}
+	}
+
+	if displayName == "" {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
 	ctx.Data["RegistryURL"] = setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/pypi"
-	ctx.Data["PackageDescriptor"] = pds[0]
+	ctx.Data["PackageName"] = displayName
+	ctx.Data["PackageLowerName"] = strings.ToLower(packageName)
 	ctx.Data["PackageDescriptors"] = pds
 	ctx.HTML(http.StatusOK, "api/packages/pypi/simple")
 }
@@ -81,19 +110,35 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypePyPI,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-		ctx.Req.Method,
-	)
+	pi := &packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypePyPI,
+		Name:        packageName,
+		Version:     packageVersion,
+	}
+	pfi := &packages_service.PackageFileInfo{
+		Filename: filename,
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pi, pfi, ctx.Req.Method)
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		client, proxyErr := getProxyClient(ctx)
+		if proxyErr != nil {
+			apiError(ctx, http.StatusInternalServerError, proxyErr)
+			return
+		}
+		if client != nil {
+			if proxyErr := fetchPackageFile(ctx, client, packageName, packageVersion, filename); proxyErr != nil {
+				if errors.Is(proxyErr, util.ErrNotExist) {
+					apiError(ctx, http.StatusNotFound, proxyErr)
+				} else {
+					apiError(ctx, http.StatusBadGateway, proxyErr)
+				}
+				return
+			}
+			s, u, pf, err = packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pi, pfi, ctx.Req.Method)
+		}
+	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -201,7 +246,7 @@ func UploadPackageFile(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrMissingSignature:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
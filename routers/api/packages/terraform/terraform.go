@@ -0,0 +1,630 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	terraform_service "code.gitea.io/gitea/services/packages/terraform"
+)
+
+const sha256SumsFilename = "SHA256SUMS"
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.JSON(status, struct {
+		Errors []string `json:"errors"`
+	}{
+		Errors: []string{
+			message,
+		},
+	})
+}
+
+// ServiceDiscovery returns the locations of the module and provider registry APIs
+// https://developer.hashicorp.com/terraform/internals/remote-service-discovery
+func ServiceDiscovery(resp http.ResponseWriter, req *http.Request) {
+	resp.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(resp).Encode(map[string]string{
+		"modules.v1":   setting.AppSubURL + "/api/packages/-/terraform/modules/",
+		"providers.v1": setting.AppSubURL + "/api/packages/-/terraform/providers/",
+	})
+}
+
+// GetSigningKey returns the public key used to sign the checksums of the providers
+func GetSigningKey(ctx *context.Context) {
+	_, pub, err := terraform_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(strings.NewReader(pub), &context.ServeHeaderOptions{
+		ContentType: "application/pgp-keys",
+	})
+}
+
+func moduleBaseURL(ctx *context.Context, name, system string) string {
+	return fmt.Sprintf("%sapi/packages/%s/terraform/modules/%s/%s", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name), url.PathEscape(name), url.PathEscape(system))
+}
+
+func providerVersionURL(ctx *context.Context, providerType, version string) string {
+	return fmt.Sprintf("%sapi/packages/%s/terraform/providers/%s/%s", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name), url.PathEscape(providerType), url.PathEscape(version))
+}
+
+func moduleArchiveFilename(name, system, version string) string {
+	return fmt.Sprintf("%s-%s-%s.tar.gz", name, system, version)
+}
+
+// getPackageDescriptors returns the descriptors of all versions of the package sorted by version
+func getPackageDescriptors(ctx *context.Context, packageName string) ([]*packages_model.PackageDescriptor, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, packageName)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+
+	return pds, nil
+}
+
+func getPackageDescriptor(ctx *context.Context, packageName, packageVersion string) (*packages_model.PackageDescriptor, error) {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, packageName, packageVersion)
+	if err != nil {
+		return nil, err
+	}
+	return packages_model.GetPackageDescriptor(ctx, pv)
+}
+
+type moduleVersion struct {
+	Version string `json:"version"`
+}
+
+type moduleVersions struct {
+	Versions []*moduleVersion `json:"versions"`
+}
+
+// EnumerateModuleVersions lists the available versions of a module
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#list-available-versions-for-a-specific-module
+func EnumerateModuleVersions(ctx *context.Context) {
+	packageName, err := terraform_module.ModulePackageName(ctx.PathParam("name"), ctx.PathParam("system"))
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	pds, err := getPackageDescriptors(ctx, packageName)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versions := make([]*moduleVersion, 0, len(pds))
+	for _, pd := range pds {
+		versions = append(versions, &moduleVersion{Version: pd.Version.Version})
+	}
+
+	ctx.JSON(http.StatusOK, map[string]any{
+		"modules": []*moduleVersions{{Versions: versions}},
+	})
+}
+
+// DownloadModule returns the location of the module archive in the X-Terraform-Get header
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#download-source-code-for-a-specific-module-version
+func DownloadModule(ctx *context.Context) {
+	name, system := ctx.PathParam("name"), ctx.PathParam("system")
+	packageName, err := terraform_module.ModulePackageName(name, system)
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, packageName, ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// the archive parameter tells Terraform how to extract the file
+	ctx.Resp.Header().Set("X-Terraform-Get", moduleBaseURL(ctx, name, system)+"/"+url.PathEscape(pv.Version)+"?archive=tar.gz")
+	ctx.Status(http.StatusNoContent)
+}
+
+// DownloadModuleArchive serves the archive of the module version
+func DownloadModuleArchive(ctx *context.Context) {
+	name, system, version := ctx.PathParam("name"), ctx.PathParam("system"), ctx.PathParam("version")
+	packageName, err := terraform_module.ModulePackageName(name, system)
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        packageName,
+			Version:     version,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: moduleArchiveFilename(name, system, version),
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadModule creates a new module version from a gzipped tar archive
+func UploadModule(ctx *context.Context) {
+	name, system, version := ctx.PathParam("name"), ctx.PathParam("system"), ctx.PathParam("version")
+	packageName, err := terraform_module.ModulePackageName(name, system)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if !terraform_module.IsValidVersion(version) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidVersion)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata, err := terraform_module.ParseModuleArchive(buf)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        packageName,
+				Version:     version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: moduleArchiveFilename(name, system, version),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrMissingSignature:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DeleteModule deletes a module version
+func DeleteModule(ctx *context.Context) {
+	packageName, err := terraform_module.ModulePackageName(ctx.PathParam("name"), ctx.PathParam("system"))
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	deletePackageVersion(ctx, packageName, ctx.PathParam("version"))
+}
+
+func deletePackageVersion(ctx *context.Context, packageName, packageVersion string) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        packageName,
+			Version:     packageVersion,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+type providerPlatform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+type providerVersion struct {
+	Version   string              `json:"version"`
+	Protocols []string            `json:"protocols"`
+	Platforms []*providerPlatform `json:"platforms"`
+}
+
+// EnumerateProviderVersions lists the available versions and platforms of a provider
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#list-available-versions
+func EnumerateProviderVersions(ctx *context.Context) {
+	packageName, err := terraform_module.ProviderPackageName(ctx.PathParam("type"))
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	pds, err := getPackageDescriptors(ctx, packageName)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versions := make([]*providerVersion, 0, len(pds))
+	for _, pd := range pds {
+		if len(pd.Files) == 0 {
+			continue
+		}
+
+		platforms := make([]*providerPlatform, 0, len(pd.Files))
+		for _, pfd := range pd.Files {
+			platforms = append(platforms, &providerPlatform{
+				OS:   pfd.Properties.GetByName(terraform_module.PropertyOS),
+				Arch: pfd.Properties.GetByName(terraform_module.PropertyArch),
+			})
+		}
+
+		versions = append(versions, &providerVersion{
+			Version:   pd.Version.Version,
+			Protocols: strings.Split(pd.Files[0].Properties.GetByName(terraform_module.PropertyProtocols), ","),
+			Platforms: platforms,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, map[string]any{
+		"versions": versions,
+	})
+}
+
+type gpgPublicKey struct {
+	KeyID      string `json:"key_id"`
+	ASCIIArmor string `json:"ascii_armor"`
+}
+
+type signingKeys struct {
+	GPGPublicKeys []*gpgPublicKey `json:"gpg_public_keys"`
+}
+
+type providerPackage struct {
+	Protocols           []string     `json:"protocols"`
+	OS                  string       `json:"os"`
+	Arch                string       `json:"arch"`
+	Filename            string       `json:"filename"`
+	DownloadURL         string       `json:"download_url"`
+	SHASumsURL          string       `json:"shasums_url"`
+	SHASumsSignatureURL string       `json:"shasums_signature_url"`
+	SHASum              string       `json:"shasum"`
+	SigningKeys         *signingKeys `json:"signing_keys"`
+}
+
+// DownloadProviderPlatform returns the download information of the provider for a specific platform
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#find-a-provider-package
+func DownloadProviderPlatform(ctx *context.Context) {
+	providerType := ctx.PathParam("type")
+	packageName, err := terraform_module.ProviderPackageName(providerType)
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	pd, err := getPackageDescriptor(ctx, packageName, ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	var pfd *packages_model.PackageFileDescriptor
+	for _, f := range pd.Files {
+		if f.Properties.GetByName(terraform_module.PropertyOS) == ctx.PathParam("os") && f.Properties.GetByName(terraform_module.PropertyArch) == ctx.PathParam("arch") {
+			pfd = f
+			break
+		}
+	}
+	if pfd == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	pub, keyID, err := terraform_service.GetPublicKeyID(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versionURL := providerVersionURL(ctx, providerType, pd.Version.Version)
+
+	ctx.JSON(http.StatusOK, &providerPackage{
+		Protocols:           strings.Split(pfd.Properties.GetByName(terraform_module.PropertyProtocols), ","),
+		OS:                  pfd.Properties.GetByName(terraform_module.PropertyOS),
+		Arch:                pfd.Properties.GetByName(terraform_module.PropertyArch),
+		Filename:            pfd.File.Name,
+		DownloadURL:         versionURL + "/" + url.PathEscape(pfd.File.Name),
+		SHASumsURL:          versionURL + "/" + sha256SumsFilename,
+		SHASumsSignatureURL: versionURL + "/" + sha256SumsFilename + ".sig",
+		SHASum:              pfd.Blob.HashSHA256,
+		SigningKeys: &signingKeys{
+			GPGPublicKeys: []*gpgPublicKey{
+				{
+					KeyID:      keyID,
+					ASCIIArmor: pub,
+				},
+			},
+		},
+	})
+}
+
+func getProviderSHA256Sums(ctx *context.Context) ([]byte, bool) {
+	packageName, err := terraform_module.ProviderPackageName(ctx.PathParam("type"))
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return nil, false
+	}
+
+	pd, err := getPackageDescriptor(ctx, packageName, ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return nil, false
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	return terraform_service.BuildSHA256Sums(pd), true
+}
+
+// GetProviderSHA256Sums returns the checksums of all platform archives of the provider version
+func GetProviderSHA256Sums(ctx *context.Context) {
+	sums, ok := getProviderSHA256Sums(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.ServeContent(bytes.NewReader(sums), &context.ServeHeaderOptions{
+		Filename: sha256SumsFilename,
+	})
+}
+
+// GetProviderSHA256SumsSignature returns the detached signature of the checksums of the provider version
+func GetProviderSHA256SumsSignature(ctx *context.Context) {
+	sums, ok := getProviderSHA256Sums(ctx)
+	if !ok {
+		return
+	}
+
+	signature, err := terraform_service.SignData(ctx, ctx.Package.Owner.ID, bytes.NewReader(sums))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(bytes.NewReader(signature), &context.ServeHeaderOptions{
+		Filename: sha256SumsFilename + ".sig",
+	})
+}
+
+// DownloadProviderFile serves the platform archive of the provider version
+func DownloadProviderFile(ctx *context.Context) {
+	packageName, err := terraform_module.ProviderPackageName(ctx.PathParam("type"))
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        packageName,
+			Version:     ctx.PathParam("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: ctx.PathParam("filename"),
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadProviderFile adds the zip archive of a platform to the provider version
+func UploadProviderFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+	providerFile, err := terraform_module.ParseProviderFilename(filename)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if providerFile.Type != ctx.PathParam("type") || providerFile.Version != ctx.PathParam("version") {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidFilename)
+		return
+	}
+
+	protocols, err := terraform_module.ParseProtocols(ctx.FormTrim("protocols"))
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	if err := terraform_module.ValidateProviderArchive(buf, buf.Size(), providerFile.Type); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        providerFile.Type,
+				Version:     providerFile.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata: &terraform_module.Metadata{
+				Kind: terraform_module.KindProvider,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+			Properties: map[string]string{
+				terraform_module.PropertyOS:        providerFile.OS,
+				terraform_module.PropertyArch:      providerFile.Arch,
+				terraform_module.PropertyProtocols: strings.Join(protocols, ","),
+			},
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageFile:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrMissingSignature:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DeleteProvider deletes a provider version with the archives of all platforms
+func DeleteProvider(ctx *context.Context) {
+	packageName, err := terraform_module.ProviderPackageName(ctx.PathParam("type"))
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	deletePackageVersion(ctx, packageName, ctx.PathParam("version"))
+}
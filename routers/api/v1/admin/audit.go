@@ -0,0 +1,40 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ExportAuditEvents exports the audit events of all scopes
+func ExportAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /admin/audit/export admin adminExportAuditEvents
+	// ---
+	// summary: Export the audit events of all scopes as JSON lines
+	// produces:
+	// - application/x-ndjson
+	// parameters:
+	// - name: action
+	//   in: query
+	//   description: only export the events of this action or of the actions starting with this prefix, e.g. repo.collaborator
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: only export the events created at or after this time. Format should be RFC 3339
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only export the events created at or before this time. Format should be RFC 3339
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditEventList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	shared.ExportAuditEvents(ctx, "", 0)
+}
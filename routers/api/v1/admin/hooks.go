@@ -185,7 +185,7 @@ func DeleteHook(ctx *context.APIContext) {
 	//     "$ref": "#/responses/empty"
 
 	hookID := ctx.PathParamInt64("id")
-	if err := webhook.DeleteDefaultSystemWebhook(ctx, hookID); err != nil {
+	if err := webhook_service.DeleteDefaultSystemWebhook(ctx, ctx.Doer, hookID); err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			ctx.APIErrorNotFound()
 		} else {
@@ -0,0 +1,225 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListRunnerGroups lists the instance-level runner groups
+func ListRunnerGroups(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/runner-groups admin adminListRunnerGroups
+	// ---
+	// summary: List the instance-level runner groups
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroupList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.ListRunnerGroups(ctx, 0)
+}
+
+// CreateRunnerGroup creates an instance-level runner group
+func CreateRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation POST /admin/actions/runner-groups admin adminCreateRunnerGroup
+	// ---
+	// summary: Create an instance-level runner group
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateActionRunnerGroupOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.CreateRunnerGroup(ctx, 0)
+}
+
+// GetRunnerGroup gets an instance-level runner group
+func GetRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/runner-groups/{group_id} admin adminGetRunnerGroup
+	// ---
+	// summary: Get an instance-level runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.GetRunnerGroup(ctx, 0, ctx.PathParamInt64("group_id"))
+}
+
+// EditRunnerGroup edits an instance-level runner group
+func EditRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation PATCH /admin/actions/runner-groups/{group_id} admin adminEditRunnerGroup
+	// ---
+	// summary: Edit an instance-level runner group
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditActionRunnerGroupOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.EditRunnerGroup(ctx, 0, ctx.PathParamInt64("group_id"))
+}
+
+// DeleteRunnerGroup deletes an instance-level runner group, the groups which contain runners can't be deleted
+func DeleteRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/actions/runner-groups/{group_id} admin adminDeleteRunnerGroup
+	// ---
+	// summary: Delete an instance-level runner group, it must not contain any runner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.DeleteRunnerGroup(ctx, 0, ctx.PathParamInt64("group_id"))
+}
+
+// ListRunnerGroupRunners lists the runners in an instance-level runner group
+func ListRunnerGroupRunners(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/runner-groups/{group_id}/runners admin adminListRunnerGroupRunners
+	// ---
+	// summary: List the runners in an instance-level runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/ActionRunnersResponse"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.ListRunnerGroupRunners(ctx, 0, ctx.PathParamInt64("group_id"))
+}
+
+// AddRunnerToGroup moves a runner into an instance-level runner group
+func AddRunnerToGroup(ctx *context.APIContext) {
+	// swagger:operation PUT /admin/actions/runner-groups/{group_id}/runners/{runner_id} admin adminAddRunnerToGroup
+	// ---
+	// summary: Move a runner into an instance-level runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: runner_id
+	//   in: path
+	//   description: id of the runner
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.AddRunnerToGroup(ctx, 0, ctx.PathParamInt64("group_id"), ctx.PathParamInt64("runner_id"))
+}
+
+// RemoveRunnerFromGroup moves a runner out of an instance-level runner group
+func RemoveRunnerFromGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/actions/runner-groups/{group_id}/runners/{runner_id} admin adminRemoveRunnerFromGroup
+	// ---
+	// summary: Move a runner out of an instance-level runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: runner_id
+	//   in: path
+	//   description: id of the runner
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.RemoveRunnerFromGroup(ctx, 0, ctx.PathParamInt64("group_id"), ctx.PathParamInt64("runner_id"))
+}
@@ -40,6 +40,49 @@ func CreateRegistrationToken(ctx *context.APIContext) {
 	shared.GetRegistrationToken(ctx, 0, 0)
 }
 
+// GenerateRunnerJITConfig creates a global runner and returns its just-in-time config
+func GenerateRunnerJITConfig(ctx *context.APIContext) {
+	// swagger:operation POST /admin/actions/runners/generate-jitconfig admin adminGenerateRunnerJITConfig
+	// ---
+	// summary: Create a global runner and get its just-in-time config
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/GenerateActionRunnerJITConfigOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ActionRunnerJITConfig"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.GenerateRunnerJITConfig(ctx, 0, 0)
+}
+
+// ListRunnerQueues lists the jobs waiting for global runners grouped by their labels
+func ListRunnerQueues(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/runners/queue admin getAdminRunnerQueues
+	// ---
+	// summary: Get the jobs waiting for global runners grouped by their labels
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerQueues"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.ListRunnerQueues(ctx, 0, 0)
+}
+
 // ListRunners get all runners
 func ListRunners(ctx *context.APIContext) {
 	// swagger:operation GET /admin/actions/runners admin getAdminRunners
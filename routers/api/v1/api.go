@@ -315,7 +315,8 @@ func tokenRequiresScopes(requiredScopeCategories ...auth_model.AccessTokenScopeC
 
 		// use the http method to determine the access level
 		requiredScopeLevel := auth_model.Read
-		if ctx.Req.Method == http.MethodPost || ctx.Req.Method == http.MethodPut || ctx.Req.Method == http.MethodPatch || ctx.Req.Method == http.MethodDelete {
+		switch ctx.Req.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, repo.MethodLock, repo.MethodUnlock:
 			requiredScopeLevel = auth_model.Write
 		}
 
@@ -530,6 +531,19 @@ func reqTeamMembership() func(ctx *context.APIContext) {
 	}
 }
 
+// reqOrgUnitAccess user should have the access mode to the unit of the organization, like the projects of the organization, or be a site admin
+func reqOrgUnitAccess(unitType unit.Type, accessMode perm.AccessMode) func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if ctx.IsUserSiteAdmin() {
+			return
+		}
+		if ctx.Org.Organization.UnitPermission(ctx, ctx.Doer, unitType) < accessMode {
+			ctx.APIErrorNotFound()
+			return
+		}
+	}
+}
+
 // reqOrgMembership user should be an organization member, or a site admin
 func reqOrgMembership() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
@@ -581,6 +595,26 @@ func reqWebhooksEnabled() func(ctx *context.APIContext) {
 	}
 }
 
+// reqAuditEnabled requires the audit log to be enabled in the config.
+func reqAuditEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.Audit.Enabled {
+			ctx.APIError(http.StatusForbidden, "audit log disabled by administrator")
+			return
+		}
+	}
+}
+
+// reqTerraformStateEnabled requires the Terraform state backend to be enabled in the config.
+func reqTerraformStateEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.TerraformState.Enabled {
+			ctx.APIError(http.StatusForbidden, "terraform state backend disabled by administrator")
+			return
+		}
+	}
+}
+
 // reqStarsEnabled requires Starring to be enabled in the config.
 func reqStarsEnabled() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
@@ -924,6 +958,8 @@ func Routes() *web.Router {
 				m.Get("", reqToken(), reqChecker, act.ListRunners)
 				m.Get("/registration-token", reqToken(), reqChecker, act.GetRegistrationToken)
 				m.Post("/registration-token", reqToken(), reqChecker, act.CreateRegistrationToken)
+				m.Post("/generate-jitconfig", reqToken(), reqChecker, bind(api.GenerateActionRunnerJITConfigOption{}), act.GenerateRunnerJITConfig)
+				m.Get("/queue", reqToken(), reqChecker, act.ListRunnerQueues)
 				m.Get("/{runner_id}", reqToken(), reqChecker, act.GetRunner)
 				m.Delete("/{runner_id}", reqToken(), reqChecker, act.DeleteRunner)
 			})
@@ -1062,6 +1098,8 @@ func Routes() *web.Router {
 					m.Get("", reqToken(), user.ListRunners)
 					m.Get("/registration-token", reqToken(), user.GetRegistrationToken)
 					m.Post("/registration-token", reqToken(), user.CreateRegistrationToken)
+					m.Post("/generate-jitconfig", reqToken(), bind(api.GenerateActionRunnerJITConfigOption{}), user.GenerateRunnerJITConfig)
+					m.Get("/queue", reqToken(), user.ListRunnerQueues)
 					m.Get("/{runner_id}", reqToken(), user.GetRunner)
 					m.Delete("/{runner_id}", reqToken(), user.DeleteRunner)
 				})
@@ -1194,6 +1232,7 @@ func Routes() *web.Router {
 				m.Group("/actions/jobs", func() {
 					m.Get("/{job_id}", repo.GetWorkflowJob)
 					m.Get("/{job_id}/logs", repo.DownloadActionsRunJobLogs)
+					m.Post("/{job_id}/rerun", reqRepoWriter(unit.TypeActions), repo.RerunWorkflowJob)
 				}, reqToken(), reqRepoReader(unit.TypeActions))
 
 				m.Group("/hooks/git", func() {
@@ -1276,7 +1315,13 @@ func Routes() *web.Router {
 						m.Group("/{run}", func() {
 							m.Get("", repo.GetWorkflowRun)
 							m.Delete("", reqToken(), reqRepoWriter(unit.TypeActions), repo.DeleteActionRun)
+							m.Post("/approve", reqToken(), reqRepoWriter(unit.TypeActions), repo.ApproveWorkflowRun)
+							m.Post("/rerun", reqToken(), reqRepoWriter(unit.TypeActions), repo.RerunWorkflowRun)
+							m.Post("/rerun-failed-jobs", reqToken(), reqRepoWriter(unit.TypeActions), repo.RerunFailedWorkflowRunJobs)
+							m.Post("/cancel", reqToken(), reqRepoWriter(unit.TypeActions), repo.CancelWorkflowRun)
 							m.Get("/jobs", repo.ListWorkflowRunJobs)
+							m.Get("/logs", repo.DownloadActionsRunLogs)
+							m.Get("/logs/search", repo.SearchActionsRunLogs)
 							m.Get("/artifacts", repo.GetArtifactsOfRun)
 						})
 					})
@@ -1293,6 +1338,19 @@ func Routes() *web.Router {
 					m.Combo("/{id}").Get(repo.GetDeployKey).
 						Delete(repo.DeleteDeploykey)
 				}, reqToken(), reqAdmin())
+				m.Get("/audit/export", reqToken(), reqAdmin(), reqAuditEnabled(), repo.ExportAuditEvents)
+				m.Group("/terraform/state", func() {
+					m.Get("", repo.ListTerraformStates)
+					m.Group("/{name}", func() {
+						m.Get("", repo.GetTerraformState)
+						m.Post("", mustNotBeArchived, repo.UpdateTerraformState)
+						m.Delete("", reqAdmin(), repo.DeleteTerraformState)
+						m.Methods(repo.MethodLock, "", mustNotBeArchived, repo.LockTerraformState)
+						m.Methods(repo.MethodUnlock, "", repo.UnlockTerraformState)
+						m.Get("/versions", repo.ListTerraformStateVersions)
+						m.Get("/versions/{version}", repo.GetTerraformStateVersion)
+					})
+				}, reqToken(), reqRepoWriter(unit.TypeCode), reqTerraformStateEnabled())
 				m.Group("/times", func() {
 					m.Combo("").Get(repo.ListTrackedTimesByRepository)
 					m.Combo("/{timetrackingusername}").Get(repo.ListTrackedTimesByUser)
@@ -1305,6 +1363,7 @@ func Routes() *web.Router {
 					m.Get("/revisions/{pageName}", repo.ListPageRevisions)
 					m.Post("/new", reqToken(), mustNotBeArchived, reqRepoWriter(unit.TypeWiki), bind(api.CreateWikiPageOptions{}), repo.NewWikiPage)
 					m.Get("/pages", repo.ListWikiPages)
+					m.Get("/search", repo.SearchWikiPages)
 				}, mustEnableWiki)
 				m.Post("/markup", reqToken(), bind(api.MarkupOption{}), misc.Markup)
 				m.Post("/markdown", reqToken(), bind(api.MarkdownOption{}), misc.Markdown)
@@ -1586,11 +1645,25 @@ func Routes() *web.Router {
 						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditMilestoneOption{}), repo.EditMilestone).
 						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteMilestone)
 				})
+				m.Group("/projects/{id}", func() {
+					m.Get("/fields", repo.ListProjectFields)
+					m.Get("/items", repo.ListProjectItems)
+					m.Put("/items/{index}/fields/{field_id}", reqToken(), reqRepoWriter(unit.TypeProjects), bind(api.SetProjectFieldValueOption{}), repo.SetProjectItemFieldValue)
+				}, reqRepoReader(unit.TypeProjects))
 			}, repoAssignment(), checkTokenPublicOnly())
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue))
 
 		// NOTE: these are Gitea package management API - see packages.CommonRoutes and packages.DockerContainerRoutes for endpoints that implement package manager APIs
 		m.Group("/packages/{username}", func() {
+			m.Group("/-/attestations", func() {
+				m.Post("", reqPackageAccess(perm.AccessModeWrite), packages.UploadPackageAttestation)
+				m.Group("/{id}", func() {
+					m.Get("", packages.GetPackageAttestation)
+					m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackageAttestation)
+					m.Get("/content", packages.GetPackageAttestationContent)
+				})
+			})
+
 			m.Group("/{type}/{name}", func() {
 				m.Get("/", packages.ListPackageVersions)
 
@@ -1598,6 +1671,7 @@ func Routes() *web.Router {
 					m.Get("", packages.GetPackage)
 					m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
 					m.Get("/files", packages.ListPackageFiles)
+					m.Get("/attestations", packages.ListPackageAttestations)
 				})
 
 				m.Group("/-", func() {
@@ -1635,6 +1709,18 @@ func Routes() *web.Router {
 				reqOrgOwnership(),
 				org.NewAction(),
 			)
+			m.Group("/actions/runner-groups", func() {
+				m.Combo("").Get(org.ListRunnerGroups).
+					Post(bind(api.CreateActionRunnerGroupOption{}), org.CreateRunnerGroup)
+				m.Group("/{group_id}", func() {
+					m.Combo("").Get(org.GetRunnerGroup).
+						Patch(bind(api.EditActionRunnerGroupOption{}), org.EditRunnerGroup).
+						Delete(org.DeleteRunnerGroup)
+					m.Get("/runners", org.ListRunnerGroupRunners)
+					m.Combo("/runners/{runner_id}").Put(org.AddRunnerToGroup).
+						Delete(org.RemoveRunnerFromGroup)
+				})
+			}, reqToken(), reqOrgOwnership())
 			m.Group("/public_members", func() {
 				m.Get("", org.ListPublicMembers)
 				m.Combo("/{username}").Get(org.IsPublicMember).
@@ -1653,6 +1739,11 @@ func Routes() *web.Router {
 					Patch(reqToken(), reqOrgOwnership(), bind(api.EditLabelOption{}), org.EditLabel).
 					Delete(reqToken(), reqOrgOwnership(), org.DeleteLabel)
 			})
+			m.Group("/projects/{id}", func() {
+				m.Get("/fields", org.ListProjectFields)
+				m.Get("/items", org.ListProjectItems)
+				m.Put("/items/{issue_id}/fields/{field_id}", reqToken(), reqOrgUnitAccess(unit.TypeProjects, perm.AccessModeWrite), bind(api.SetProjectFieldValueOption{}), org.SetProjectItemFieldValue)
+			}, reqOrgUnitAccess(unit.TypeProjects, perm.AccessModeRead))
 			m.Group("/hooks", func() {
 				m.Combo("").Get(org.ListHooks).
 					Post(bind(api.CreateHookOption{}), org.CreateHook)
@@ -1674,6 +1765,7 @@ func Routes() *web.Router {
 					m.Delete("", org.UnblockUser)
 				})
 			}, reqToken(), reqOrgOwnership())
+			m.Get("/audit/export", reqToken(), reqOrgOwnership(), reqAuditEnabled(), org.ExportAuditEvents)
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryOrganization), orgAssignment(true), checkTokenPublicOnly())
 		m.Group("/teams/{teamid}", func() {
 			m.Combo("").Get(reqToken(), org.GetTeam).
@@ -1741,15 +1833,30 @@ func Routes() *web.Router {
 				m.Group("/runners", func() {
 					m.Get("", admin.ListRunners)
 					m.Post("/registration-token", admin.CreateRegistrationToken)
+					m.Post("/generate-jitconfig", bind(api.GenerateActionRunnerJITConfigOption{}), admin.GenerateRunnerJITConfig)
+					m.Get("/queue", admin.ListRunnerQueues)
 					m.Get("/{runner_id}", admin.GetRunner)
 					m.Delete("/{runner_id}", admin.DeleteRunner)
 				})
+				m.Group("/runner-groups", func() {
+					m.Combo("").Get(admin.ListRunnerGroups).
+						Post(bind(api.CreateActionRunnerGroupOption{}), admin.CreateRunnerGroup)
+					m.Group("/{group_id}", func() {
+						m.Combo("").Get(admin.GetRunnerGroup).
+							Patch(bind(api.EditActionRunnerGroupOption{}), admin.EditRunnerGroup).
+							Delete(admin.DeleteRunnerGroup)
+						m.Get("/runners", admin.ListRunnerGroupRunners)
+						m.Combo("/runners/{runner_id}").Put(admin.AddRunnerToGroup).
+							Delete(admin.RemoveRunnerFromGroup)
+					})
+				})
 				m.Get("/runs", admin.ListWorkflowRuns)
 				m.Get("/jobs", admin.ListWorkflowJobs)
 			})
 			m.Group("/runners", func() {
 				m.Get("/registration-token", admin.GetRegistrationToken)
 			})
+			m.Get("/audit/export", reqAuditEnabled(), admin.ExportAuditEvents)
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryAdmin), reqToken(), reqSiteAdmin())
 
 		m.Group("/topics", func() {
@@ -61,9 +61,12 @@ func (Action) ListActionsSecrets(ctx *context.APIContext) {
 	apiSecrets := make([]*api.Secret, len(secrets))
 	for k, v := range secrets {
 		apiSecrets[k] = &api.Secret{
-			Name:        v.Name,
-			Description: v.Description,
-			Created:     v.CreatedUnix.AsTime(),
+			Name:                  v.Name,
+			Description:           v.Description,
+			Created:               v.CreatedUnix.AsTime(),
+			Visibility:            string(v.Visibility),
+			SelectedRepositoryIDs: v.SelectedRepoIDs,
+			SelectedEnvironments:  v.SelectedEnvironments,
 		}
 	}
 
@@ -107,7 +110,7 @@ func (Action) CreateOrUpdateSecret(ctx *context.APIContext) {
 
 	opt := web.GetForm(ctx).(*api.CreateOrUpdateSecretOption)
 
-	_, created, err := secret_service.CreateOrUpdateSecret(ctx, ctx.Org.Organization.ID, 0, ctx.PathParam("secretname"), opt.Data, opt.Description)
+	_, created, err := secret_service.CreateOrUpdateSecret(ctx, ctx.Org.Organization.ID, 0, ctx.PathParam("secretname"), opt.Data, opt.Description, toRepoScope(opt.Visibility, opt.SelectedRepositoryIDs, opt.SelectedEnvironments))
 	if err != nil {
 		if errors.Is(err, util.ErrInvalidArgument) {
 			ctx.APIError(http.StatusBadRequest, err)
@@ -211,6 +214,60 @@ func (Action) CreateRegistrationToken(ctx *context.APIContext) {
 	shared.GetRegistrationToken(ctx, ctx.Org.Organization.ID, 0)
 }
 
+// GenerateRunnerJITConfig creates an org-level runner and returns its just-in-time config
+func (Action) GenerateRunnerJITConfig(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/actions/runners/generate-jitconfig organization orgGenerateRunnerJITConfig
+	// ---
+	// summary: Create an org-level runner and get its just-in-time config
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/GenerateActionRunnerJITConfigOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ActionRunnerJITConfig"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.GenerateRunnerJITConfig(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// ListRunnerQueues lists the jobs waiting for org-level runners grouped by their labels
+func (Action) ListRunnerQueues(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runners/queue organization getOrgRunnerQueues
+	// ---
+	// summary: Get the jobs waiting for org-level runners grouped by their labels
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerQueues"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.ListRunnerQueues(ctx, ctx.Org.Organization.ID, 0)
+}
+
 // ListVariables list org-level variables
 func (Action) ListVariables(ctx *context.APIContext) {
 	// swagger:operation GET /orgs/{org}/actions/variables organization getOrgVariablesList
@@ -251,13 +308,7 @@ func (Action) ListVariables(ctx *context.APIContext) {
 
 	variables := make([]*api.ActionVariable, len(vars))
 	for i, v := range vars {
-		variables[i] = &api.ActionVariable{
-			OwnerID:     v.OwnerID,
-			RepoID:      v.RepoID,
-			Name:        v.Name,
-			Data:        v.Data,
-			Description: v.Description,
-		}
+		variables[i] = toActionVariable(v)
 	}
 
 	ctx.SetTotalCountHeader(count)
@@ -303,15 +354,7 @@ func (Action) GetVariable(ctx *context.APIContext) {
 		return
 	}
 
-	variable := &api.ActionVariable{
-		OwnerID:     v.OwnerID,
-		RepoID:      v.RepoID,
-		Name:        v.Name,
-		Data:        v.Data,
-		Description: v.Description,
-	}
-
-	ctx.JSON(http.StatusOK, variable)
+	ctx.JSON(http.StatusOK, toActionVariable(v))
 }
 
 // DeleteVariable delete an org-level variable
@@ -410,7 +453,7 @@ func (Action) CreateVariable(ctx *context.APIContext) {
 		return
 	}
 
-	if _, err := actions_service.CreateVariable(ctx, ownerID, 0, variableName, opt.Value, opt.Description); err != nil {
+	if _, err := actions_service.CreateVariable(ctx, ownerID, 0, variableName, opt.Value, opt.Description, toRepoScope(opt.Visibility, opt.SelectedRepositoryIDs, opt.SelectedEnvironments)); err != nil {
 		if errors.Is(err, util.ErrInvalidArgument) {
 			ctx.APIError(http.StatusBadRequest, err)
 		} else {
@@ -479,7 +522,7 @@ func (Action) UpdateVariable(ctx *context.APIContext) {
 	v.Data = opt.Value
 	v.Description = opt.Description
 
-	if _, err := actions_service.UpdateVariableNameData(ctx, v); err != nil {
+	if _, err := actions_service.UpdateVariableNameData(ctx, v, toRepoScope(opt.Visibility, opt.SelectedRepositoryIDs, opt.SelectedEnvironments)); err != nil {
 		if errors.Is(err, util.ErrInvalidArgument) {
 			ctx.APIError(http.StatusBadRequest, err)
 		} else {
@@ -491,6 +534,27 @@ func (Action) UpdateVariable(ctx *context.APIContext) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// toRepoScope returns the repositories and environments which can use an org-level secret or variable, it's nil to keep the current ones
+func toRepoScope(visibility string, repoIDs []int64, environments []string) *actions_model.RepoScope {
+	if visibility == "" {
+		return nil
+	}
+	return &actions_model.RepoScope{Visibility: actions_model.ScopeVisibility(visibility), SelectedRepoIDs: repoIDs, SelectedEnvironments: environments}
+}
+
+func toActionVariable(v *actions_model.ActionVariable) *api.ActionVariable {
+	return &api.ActionVariable{
+		OwnerID:               v.OwnerID,
+		RepoID:                v.RepoID,
+		Name:                  v.Name,
+		Data:                  v.Data,
+		Description:           v.Description,
+		Visibility:            string(v.Visibility),
+		SelectedRepositoryIDs: v.SelectedRepoIDs,
+		SelectedEnvironments:  v.SelectedEnvironments,
+	}
+}
+
 // ListRunners get org-level runners
 func (Action) ListRunners(ctx *context.APIContext) {
 	// swagger:operation GET /orgs/{org}/actions/runners organization getOrgRunners
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	audit_model "code.gitea.io/gitea/models/audit"
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/organization"
 	"code.gitea.io/gitea/models/perm"
@@ -17,6 +18,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/user"
 	"code.gitea.io/gitea/routers/api/v1/utils"
+	audit_service "code.gitea.io/gitea/services/audit"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	feed_service "code.gitea.io/gitea/services/feed"
@@ -340,6 +342,7 @@ func Rename(ctx *context.APIContext) {
 
 	form := web.GetForm(ctx).(*api.RenameOrgOption)
 	orgUser := ctx.Org.Organization.AsUser()
+	before := audit_service.OrgState(ctx.Org.Organization)
 	if err := user_service.RenameUser(ctx, orgUser, form.NewName, ctx.Doer); err != nil {
 		if user_model.IsErrUserAlreadyExist(err) || db.IsErrNameReserved(err) || db.IsErrNamePatternNotAllowed(err) || db.IsErrNameCharsNotAllowed(err) {
 			ctx.APIError(http.StatusUnprocessableEntity, err)
@@ -348,6 +351,7 @@ func Rename(ctx *context.APIContext) {
 		}
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionOrgUpdate, audit_service.OwnerScope(ctx.Org.Organization.AsUser()), audit_service.OrgTarget(ctx.Org.Organization), before, audit_service.OrgState(ctx.Org.Organization))
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -378,6 +382,7 @@ func Edit(ctx *context.APIContext) {
 	//     "$ref": "#/responses/notFound"
 
 	form := web.GetForm(ctx).(*api.EditOrgOption)
+	before := audit_service.OrgState(ctx.Org.Organization)
 
 	if form.Email != "" {
 		if err := user_service.ReplacePrimaryEmailAddress(ctx, ctx.Org.Organization.AsUser(), form.Email); err != nil {
@@ -399,6 +404,8 @@ func Edit(ctx *context.APIContext) {
 		return
 	}
 
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionOrgUpdate, audit_service.OwnerScope(ctx.Org.Organization.AsUser()), audit_service.OrgTarget(ctx.Org.Organization), before, audit_service.OrgState(ctx.Org.Organization))
+
 	ctx.JSON(http.StatusOK, convert.ToOrganization(ctx, ctx.Org.Organization))
 }
 
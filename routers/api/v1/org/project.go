@@ -0,0 +1,195 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	project_model "code.gitea.io/gitea/models/project"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+func getOrgProject(ctx *context.APIContext) *project_model.Project {
+	project, err := project_model.GetProjectByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if project_model.IsErrProjectNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	if project.OwnerID != ctx.Org.Organization.ID || project.RepoID != 0 {
+		ctx.APIErrorNotFound()
+		return nil
+	}
+	return project
+}
+
+// ListProjectFields list the custom fields of an organization project
+func ListProjectFields(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects/{id}/fields organization orgListProjectFields
+	// ---
+	// summary: List the custom fields of an organization project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getOrgProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.ListProjectFields(ctx, project)
+}
+
+// ListProjectItems list the issues and pull requests of an organization project with their custom field values
+func ListProjectItems(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects/{id}/items organization orgListProjectItems
+	// ---
+	// summary: List the issues and pull requests of an organization project with their custom field values
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectItemList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getOrgProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	opts := &issues_model.IssuesOptions{
+		Owner: ctx.Org.Organization.AsUser(),
+		Doer:  ctx.Doer,
+	}
+	// the board may contain issues of private repositories, anonymous users and public-only tokens may only see public ones
+	if ctx.Doer == nil || ctx.PublicOnly {
+		opts.AllPublic = true
+	}
+	shared.ListProjectItems(ctx, project, opts)
+}
+
+// SetProjectItemFieldValue set the value of a custom field for an issue or pull request of an organization project
+func SetProjectItemFieldValue(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/projects/{id}/items/{issue_id}/fields/{field_id} organization orgSetProjectItemFieldValue
+	// ---
+	// summary: Set the value of a custom field for an issue or pull request of an organization project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: id of the issue or pull request, see the issue_id of the project items
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetProjectFieldValueOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldValue"
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.SetProjectFieldValueOption)
+	project := getOrgProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	issue, err := issues_model.GetIssueByID(ctx, ctx.PathParamInt64("issue_id"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	// the board may contain issues of private repositories, the doer has to be able to read the issue
+	if issue.Repo.OwnerID != ctx.Org.Organization.ID || (ctx.PublicOnly && issue.Repo.IsPrivate) {
+		ctx.APIErrorNotFound()
+		return
+	}
+	permission, err := access_model.GetUserRepoPermission(ctx, issue.Repo, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if !permission.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	shared.SetProjectItemFieldValue(ctx, project, issue, form)
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListRunnerGroups lists the org-level runner groups
+func ListRunnerGroups(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runner-groups organization orgListRunnerGroups
+	// ---
+	// summary: List the org-level runner groups
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroupList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.ListRunnerGroups(ctx, ctx.Org.Organization.ID)
+}
+
+// CreateRunnerGroup creates an org-level runner group
+func CreateRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/actions/runner-groups organization orgCreateRunnerGroup
+	// ---
+	// summary: Create an org-level runner group
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateActionRunnerGroupOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.CreateRunnerGroup(ctx, ctx.Org.Organization.ID)
+}
+
+// GetRunnerGroup gets an org-level runner group
+func GetRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runner-groups/{group_id} organization orgGetRunnerGroup
+	// ---
+	// summary: Get an org-level runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.GetRunnerGroup(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("group_id"))
+}
+
+// EditRunnerGroup edits an org-level runner group
+func EditRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/actions/runner-groups/{group_id} organization orgEditRunnerGroup
+	// ---
+	// summary: Edit an org-level runner group
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditActionRunnerGroupOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.EditRunnerGroup(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("group_id"))
+}
+
+// DeleteRunnerGroup deletes an org-level runner group, the groups which contain runners can't be deleted
+func DeleteRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/actions/runner-groups/{group_id} organization orgDeleteRunnerGroup
+	// ---
+	// summary: Delete an org-level runner group, it must not contain any runner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.DeleteRunnerGroup(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("group_id"))
+}
+
+// ListRunnerGroupRunners lists the runners in an org-level runner group
+func ListRunnerGroupRunners(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runner-groups/{group_id}/runners organization orgListRunnerGroupRunners
+	// ---
+	// summary: List the runners in an org-level runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/ActionRunnersResponse"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.ListRunnerGroupRunners(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("group_id"))
+}
+
+// AddRunnerToGroup moves a runner into an org-level runner group
+func AddRunnerToGroup(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/actions/runner-groups/{group_id}/runners/{runner_id} organization orgAddRunnerToGroup
+	// ---
+	// summary: Move a runner into an org-level runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: runner_id
+	//   in: path
+	//   description: id of the runner
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.AddRunnerToGroup(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("group_id"), ctx.PathParamInt64("runner_id"))
+}
+
+// RemoveRunnerFromGroup moves a runner out of an org-level runner group
+func RemoveRunnerFromGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/actions/runner-groups/{group_id}/runners/{runner_id} organization orgRemoveRunnerFromGroup
+	// ---
+	// summary: Move a runner out of an org-level runner group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: runner_id
+	//   in: path
+	//   description: id of the runner
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.RemoveRunnerFromGroup(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("group_id"), ctx.PathParamInt64("runner_id"))
+}
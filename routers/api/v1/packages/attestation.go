@@ -0,0 +1,273 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	attestation_service "code.gitea.io/gitea/services/packages/attestation"
+)
+
+// ListPackageAttestations gets the attestations of the files of a package
+func ListPackageAttestations(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/attestations package listPackageAttestations
+	// ---
+	// summary: Gets the signatures and attestations of the files of a package
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageAttestationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	vas, err := attestation_service.GetVersionAttestations(ctx, ctx.Package.Descriptor)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiAttestations := make([]*api.PackageAttestation, 0, len(vas))
+	for _, va := range vas {
+		apiAttestation, err := convert.ToPackageAttestation(ctx, va, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiAttestations = append(apiAttestations, apiAttestation)
+	}
+
+	ctx.JSON(http.StatusOK, apiAttestations)
+}
+
+// UploadPackageAttestation uploads a signature or attestation of package files
+func UploadPackageAttestation(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/-/attestations package uploadPackageAttestation
+	// ---
+	// summary: Uploads a signature or attestation of package files
+	// description: Accepts a Sigstore bundle, a DSSE envelope with an in-toto statement or a base64 encoded signature of a file.
+	//   The attestation applies to all packages of the owner which contain a file with a covered SHA256 hash.
+	// consumes:
+	// - application/json
+	// - text/plain
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: sha256
+	//   in: query
+	//   description: SHA256 hash of the signed file, required for plain signatures which sign the hash of the file
+	//   type: string
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     type: string
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageAttestation"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	data, err := io.ReadAll(io.LimitReader(ctx.Req.Body, attestation_service.MaxAttestationSize+1))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	pa, err := attestation_service.UploadAttestation(ctx, ctx.Package.Owner, ctx.Doer, data, ctx.FormTrim("sha256"))
+	if err != nil {
+		switch {
+		case errors.Is(err, packages_model.ErrDuplicatePackageAttestation):
+			ctx.APIError(http.StatusConflict, err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusBadRequest, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	writeAttestation(ctx, http.StatusCreated, pa)
+}
+
+// GetPackageAttestation gets an attestation
+func GetPackageAttestation(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/attestations/{id} package getPackageAttestation
+	// ---
+	// summary: Gets a signature or attestation of package files
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageAttestation"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pa := getAttestation(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	writeAttestation(ctx, http.StatusOK, pa)
+}
+
+// GetPackageAttestationContent downloads the content of an attestation
+func GetPackageAttestationContent(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/attestations/{id}/content package getPackageAttestationContent
+	// ---
+	// summary: Downloads the uploaded signature or attestation
+	// produces:
+	// - application/octet-stream
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the uploaded content
+	//     schema:
+	//       type: file
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pa := getAttestation(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.ServeContent(strings.NewReader(pa.Content), &context.ServeHeaderOptions{
+		ContentType:  util.Iif(strings.HasPrefix(pa.Content, "{"), "application/json", "text/plain"),
+		Filename:     fmt.Sprintf("attestation-%d", pa.ID),
+		LastModified: pa.CreatedUnix.AsLocalTime(),
+	})
+}
+
+// DeletePackageAttestation deletes an attestation
+func DeletePackageAttestation(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/-/attestations/{id} package deletePackageAttestation
+	// ---
+	// summary: Deletes a signature or attestation of package files
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pa := getAttestation(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := packages_model.DeleteAttestationByID(ctx, pa.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func getAttestation(ctx *context.APIContext) *packages_model.PackageAttestation {
+	pa, err := packages_model.GetAttestationByID(ctx, ctx.Package.Owner.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return pa
+}
+
+func writeAttestation(ctx *context.APIContext, status int, pa *packages_model.PackageAttestation) {
+	trustedKeys, err := attestation_service.GetTrustedKeys(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	va, err := attestation_service.VerifyAttestation(ctx, pa, trustedKeys)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiAttestation, err := convert.ToPackageAttestation(ctx, va, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(status, apiAttestation)
+}
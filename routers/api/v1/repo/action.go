@@ -137,7 +137,7 @@ func (Action) CreateOrUpdateSecret(ctx *context.APIContext) {
 
 	opt := web.GetForm(ctx).(*api.CreateOrUpdateSecretOption)
 
-	_, created, err := secret_service.CreateOrUpdateSecret(ctx, 0, repo.ID, ctx.PathParam("secretname"), opt.Data, opt.Description)
+	_, created, err := secret_service.CreateOrUpdateSecret(ctx, 0, repo.ID, ctx.PathParam("secretname"), opt.Data, opt.Description, nil)
 	if err != nil {
 		if errors.Is(err, util.ErrInvalidArgument) {
 			ctx.APIError(http.StatusBadRequest, err)
@@ -364,7 +364,7 @@ func (Action) CreateVariable(ctx *context.APIContext) {
 		return
 	}
 
-	if _, err := actions_service.CreateVariable(ctx, 0, repoID, variableName, opt.Value, opt.Description); err != nil {
+	if _, err := actions_service.CreateVariable(ctx, 0, repoID, variableName, opt.Value, opt.Description, nil); err != nil {
 		if errors.Is(err, util.ErrInvalidArgument) {
 			ctx.APIError(http.StatusBadRequest, err)
 		} else {
@@ -436,7 +436,7 @@ func (Action) UpdateVariable(ctx *context.APIContext) {
 	v.Data = opt.Value
 	v.Description = opt.Description
 
-	if _, err := actions_service.UpdateVariableNameData(ctx, v); err != nil {
+	if _, err := actions_service.UpdateVariableNameData(ctx, v, nil); err != nil {
 		if errors.Is(err, util.ErrInvalidArgument) {
 			ctx.APIError(http.StatusBadRequest, err)
 		} else {
@@ -556,6 +556,70 @@ func (Action) CreateRegistrationToken(ctx *context.APIContext) {
 	shared.GetRegistrationToken(ctx, 0, ctx.Repo.Repository.ID)
 }
 
+// GenerateRunnerJITConfig creates a repo-level runner and returns its just-in-time config
+func (Action) GenerateRunnerJITConfig(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runners/generate-jitconfig repository repoGenerateRunnerJITConfig
+	// ---
+	// summary: Create a repo-level runner and get its just-in-time config
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/GenerateActionRunnerJITConfigOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ActionRunnerJITConfig"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.GenerateRunnerJITConfig(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// ListRunnerQueues lists the jobs waiting for repo-level runners grouped by their labels
+func (Action) ListRunnerQueues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runners/queue repository getRepoRunnerQueues
+	// ---
+	// summary: Get the jobs waiting for repo-level runners grouped by their labels
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerQueues"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.ListRunnerQueues(ctx, 0, ctx.Repo.Repository.ID)
+}
+
 // ListRunners get repo-level runners
 func (Action) ListRunners(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/actions/runners repository getRepoRunners
@@ -998,6 +1062,8 @@ func ActionsDispatchWorkflow(ctx *context.APIContext) {
 	//   schema:
 	//     "$ref": "#/definitions/CreateActionWorkflowDispatch"
 	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionWorkflowDispatchRun"
 	//   "204":
 	//     description: No Content
 	//   "400":
@@ -1016,23 +1082,32 @@ func ActionsDispatchWorkflow(ctx *context.APIContext) {
 		return
 	}
 
-	err := actions_service.DispatchActionWorkflow(ctx, ctx.Doer, ctx.Repo.Repository, ctx.Repo.GitRepo, workflowID, opt.Ref, func(workflowDispatch *model.WorkflowDispatch, inputs map[string]any) error {
-		if strings.Contains(ctx.Req.Header.Get("Content-Type"), "form-urlencoded") {
-			// The chi framework's "Binding" doesn't support to bind the form map values into a map[string]string
-			// So we have to manually read the `inputs[key]` from the form
-			for name, config := range workflowDispatch.Inputs {
-				value := ctx.FormString("inputs["+name+"]", config.Default)
-				inputs[name] = value
+	run, err := actions_service.DispatchActionWorkflow(ctx, ctx.Doer, ctx.Repo.Repository, ctx.Repo.GitRepo, workflowID, opt.Ref, func(workflowDispatch *model.WorkflowDispatch, inputs map[string]any) error {
+		isForm := strings.Contains(ctx.Req.Header.Get("Content-Type"), "form-urlencoded")
+		for name, config := range workflowDispatch.Inputs {
+			var value any
+			var ok bool
+			if isForm {
+				// The chi framework's "Binding" doesn't support to bind the form map values into a map[string]any
+				// So we have to manually read the `inputs[key]` from the form
+				key := "inputs[" + name + "]"
+				value = ctx.FormString(key)
+				_, ok = ctx.Req.Form[key]
+			} else {
+				value, ok = opt.Inputs[name]
 			}
-		} else {
-			for name, config := range workflowDispatch.Inputs {
-				value, ok := opt.Inputs[name]
-				if ok {
-					inputs[name] = value
-				} else {
-					inputs[name] = config.Default
+			if !ok {
+				if config.Required && config.Default == "" {
+					return util.NewInvalidArgumentErrorf("input %q is required", name)
 				}
+				inputs[name] = config.Default
+				continue
 			}
+			input, err := actions_service.ConvertWorkflowDispatchInput(name, config, value)
+			if err != nil {
+				return err
+			}
+			inputs[name] = input
 		}
 		return nil
 	})
@@ -1041,13 +1116,23 @@ func ActionsDispatchWorkflow(ctx *context.APIContext) {
 			ctx.APIError(http.StatusNotFound, err)
 		} else if errors.Is(err, util.ErrPermissionDenied) {
 			ctx.APIError(http.StatusForbidden, err)
+		} else if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
 		} else {
 			ctx.APIErrorInternal(err)
 		}
 		return
 	}
 
-	ctx.Status(http.StatusNoContent)
+	if !opt.ReturnRunDetails {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.ActionWorkflowDispatchRun{
+		WorkflowRunID: run.ID,
+		RunURL:        fmt.Sprintf("%s/actions/runs/%d", ctx.Repo.Repository.APIURL(), run.ID),
+		HTMLURL:       run.HTMLURL(),
+	})
 }
 
 func ActionsEnableWorkflow(ctx *context.APIContext) {
@@ -1382,6 +1467,57 @@ func DeleteActionRun(ctx *context.APIContext) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// ApproveWorkflowRun approves a workflow run of a fork pull request waiting for an approval
+func ApproveWorkflowRun(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/approve repository approveWorkflowRun
+	// ---
+	// summary: Approve a workflow run of a fork pull request
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: runid of the workflow run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: "No Content"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run, err := actions_model.GetRunByRepoAndID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("run"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err)
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if !run.NeedApproval {
+		ctx.APIError(http.StatusBadRequest, "this workflow run doesn't need approval")
+		return
+	}
+
+	if err := actions_service.ApproveRuns(ctx, ctx.Repo.Repository, ctx.Doer, []int64{run.Index}); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
 // GetArtifacts Lists all artifacts for a repository.
 func GetArtifacts(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/actions/artifacts repository getArtifacts
@@ -5,13 +5,22 @@ package repo
 
 import (
 	"errors"
+	"net/http"
+	"slices"
+	"strings"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/common"
+	actions_service "code.gitea.io/gitea/services/actions"
 	"code.gitea.io/gitea/services/context"
 )
 
+// maxRunLogSearchResults is the max number of lines returned by a search of the logs of a run
+const maxRunLogSearchResults = 500
+
 func DownloadActionsRunJobLogs(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs repository downloadActionsRunJobLogs
 	// ---
@@ -62,3 +71,370 @@ func DownloadActionsRunJobLogs(ctx *context.APIContext) {
 		}
 	}
 }
+
+func getRunByPathParam(ctx *context.APIContext) *actions_model.ActionRun {
+	run, has, err := db.GetByID[actions_model.ActionRun](ctx, ctx.PathParamInt64("run"))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	if !has || run.RepoID != ctx.Repo.Repository.ID {
+		ctx.APIErrorNotFound(util.ErrNotExist)
+		return nil
+	}
+	run.Repo = ctx.Repo.Repository
+	return run
+}
+
+// DownloadActionsRunLogs downloads the logs of all jobs of a workflow run as a zip archive
+func DownloadActionsRunLogs(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runs/{run}/logs repository downloadActionsRunLogs
+	// ---
+	// summary: Downloads the logs of all jobs of a workflow run as a zip archive
+	// produces:
+	// - application/zip
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: zip archive of the logs
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getRunByPathParam(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := common.DownloadActionsRunLogs(ctx.Base, ctx.Repo.Repository, run); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+	}
+}
+
+// SearchActionsRunLogs searches the logs of all jobs of a workflow run
+func SearchActionsRunLogs(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runs/{run}/logs/search repository searchActionsRunLogs
+	// ---
+	// summary: Searches the logs of all jobs of a workflow run
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: keyword to search, it's matched case-insensitively
+	//   type: string
+	//   required: true
+	// - name: limit
+	//   in: query
+	//   description: max number of matched lines to return, the default and max value is 500
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunLogMatchList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getRunByPathParam(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	keyword := strings.TrimSpace(ctx.FormString("q"))
+	if keyword == "" {
+		ctx.APIError(http.StatusBadRequest, util.NewInvalidArgumentErrorf("q is required"))
+		return
+	}
+	limit := ctx.FormInt("limit")
+	if limit <= 0 || limit > maxRunLogSearchResults {
+		limit = maxRunLogSearchResults
+	}
+
+	jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	matches, err := actions_service.SearchRunLogs(ctx, jobs, keyword, limit)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.ActionRunLogMatch, 0, len(matches))
+	for _, m := range matches {
+		res = append(res, &api.ActionRunLogMatch{
+			JobID:      m.Job.ID,
+			JobName:    m.Job.Name,
+			StepNumber: m.StepIndex,
+			StepName:   m.StepName,
+			Line:       m.Line,
+			Content:    m.Content,
+			HTMLURL:    m.Link(run.HTMLURL()),
+		})
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func getRunAndJobsByPathParam(ctx *context.APIContext) (*actions_model.ActionRun, []*actions_model.ActionRunJob) {
+	run := getRunByPathParam(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+	jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, nil
+	}
+	for _, job := range jobs {
+		job.Run = run
+	}
+	return run, jobs
+}
+
+func handleRerunWorkflowRunError(ctx *context.APIContext, err error) {
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusBadRequest, err)
+	} else if errors.Is(err, util.ErrPermissionDenied) {
+		ctx.APIError(http.StatusForbidden, err)
+	} else {
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// RerunWorkflowRun reruns all jobs of a workflow run
+func RerunWorkflowRun(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/rerun repository rerunWorkflowRun
+	// ---
+	// summary: Rerun all jobs of a workflow run
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: runid of the workflow run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: "No Content"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run, jobs := getRunAndJobsByPathParam(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := actions_service.RerunWorkflowRun(ctx, ctx.Repo.Repository, run, jobs); err != nil {
+		handleRerunWorkflowRunError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RerunFailedWorkflowRunJobs reruns the failed jobs of a workflow run and the jobs depending on them
+func RerunFailedWorkflowRunJobs(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/rerun-failed-jobs repository rerunFailedWorkflowRunJobs
+	// ---
+	// summary: Rerun the failed or cancelled jobs of a workflow run and the jobs depending on them
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: runid of the workflow run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: "No Content"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run, jobs := getRunAndJobsByPathParam(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := actions_service.RerunFailedWorkflowRunJobs(ctx, ctx.Repo.Repository, run, jobs); err != nil {
+		handleRerunWorkflowRunError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RerunWorkflowJob reruns a job of a workflow run and the jobs depending on it
+func RerunWorkflowJob(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/jobs/{job_id}/rerun repository rerunWorkflowJob
+	// ---
+	// summary: Rerun a job of a workflow run and the jobs depending on it
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: job_id
+	//   in: path
+	//   description: id of the job
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: "No Content"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	job, has, err := db.GetByID[actions_model.ActionRunJob](ctx, ctx.PathParamInt64("job_id"))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if !has || job.RepoID != ctx.Repo.Repository.ID {
+		ctx.APIErrorNotFound(util.ErrNotExist)
+		return
+	}
+	run, err := actions_model.GetRunByRepoAndID(ctx, ctx.Repo.Repository.ID, job.RunID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	// the job to rerun has to be the one in the jobs of the run
+	idx := slices.IndexFunc(jobs, func(j *actions_model.ActionRunJob) bool { return j.ID == job.ID })
+	if idx < 0 {
+		ctx.APIErrorNotFound(util.ErrNotExist)
+		return
+	}
+
+	if err := actions_service.RerunWorkflowRunJobs(ctx, ctx.Repo.Repository, run, jobs, jobs[idx:idx+1]); err != nil {
+		handleRerunWorkflowRunError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// CancelWorkflowRun cancels the jobs of a workflow run which are not done
+func CancelWorkflowRun(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/cancel repository cancelWorkflowRun
+	// ---
+	// summary: Cancel a workflow run
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: runid of the workflow run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: "No Content"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+
+	run, jobs := getRunAndJobsByPathParam(ctx)
+	if ctx.Written() {
+		return
+	}
+	if run.Status.IsDone() {
+		ctx.APIError(http.StatusConflict, "this workflow run is done")
+		return
+	}
+
+	if err := actions_service.CancelRun(ctx, run, jobs); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ExportAuditEvents exports the audit events of a repository
+func ExportAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/audit/export repository repoExportAuditEvents
+	// ---
+	// summary: Export the audit events of a repository as JSON lines
+	// produces:
+	// - application/x-ndjson
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: action
+	//   in: query
+	//   description: only export the events of this action or of the actions starting with this prefix, e.g. repo.collaborator
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: only export the events created at or after this time. Format should be RFC 3339
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only export the events created at or before this time. Format should be RFC 3339
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditEventList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ExportAuditEvents(ctx, audit_model.ScopeRepository, ctx.Repo.Repository.ID)
+}
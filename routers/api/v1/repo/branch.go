@@ -670,6 +670,11 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		return
 	}
 
+	if form.EnableMergeQueue && !form.EnableStatusCheck {
+		ctx.APIError(http.StatusUnprocessableEntity, "the merge queue requires status checks")
+		return
+	}
+
 	var requiredApprovals int64
 	if form.RequiredApprovals > 0 {
 		requiredApprovals = form.RequiredApprovals
@@ -775,9 +780,11 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		UnprotectedFilePatterns:       form.UnprotectedFilePatterns,
 		BlockOnOutdatedBranch:         form.BlockOnOutdatedBranch,
 		BlockAdminMergeOverride:       form.BlockAdminMergeOverride,
+		EnableMergeQueue:              form.EnableMergeQueue,
+		RequireCodeOwnerApprovals:     form.RequireCodeOwnerApprovals,
 	}
 
-	if err := pull_service.CreateOrUpdateProtectedBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
+	if err := pull_service.CreateOrUpdateProtectedBranch(ctx, ctx.Doer, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
 		UserIDs:          whitelistUsers,
 		TeamIDs:          whitelistTeams,
 		ForcePushUserIDs: forcePushAllowlistUsers,
@@ -958,6 +965,19 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.BlockAdminMergeOverride = *form.BlockAdminMergeOverride
 	}
 
+	if form.EnableMergeQueue != nil {
+		protectBranch.EnableMergeQueue = *form.EnableMergeQueue
+	}
+
+	if form.RequireCodeOwnerApprovals != nil {
+		protectBranch.RequireCodeOwnerApprovals = *form.RequireCodeOwnerApprovals
+	}
+
+	if protectBranch.EnableMergeQueue && !protectBranch.EnableStatusCheck {
+		ctx.APIError(http.StatusUnprocessableEntity, "the merge queue requires status checks")
+		return
+	}
+
 	var whitelistUsers, forcePushAllowlistUsers, mergeWhitelistUsers, approvalsWhitelistUsers []int64
 	if form.PushWhitelistUsernames != nil {
 		whitelistUsers, err = user_model.GetUserIDsByNames(ctx, form.PushWhitelistUsernames, false)
@@ -1179,7 +1199,7 @@ func DeleteBranchProtection(ctx *context.APIContext) {
 		return
 	}
 
-	if err := git_model.DeleteProtectedBranch(ctx, ctx.Repo.Repository, bp.ID); err != nil {
+	if err := pull_service.DeleteProtectedBranch(ctx, ctx.Doer, ctx.Repo.Repository, bp); err != nil {
 		ctx.APIErrorInternal(err)
 		return
 	}
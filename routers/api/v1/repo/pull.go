@@ -41,6 +41,7 @@ import (
 	git_service "code.gitea.io/gitea/services/git"
 	"code.gitea.io/gitea/services/gitdiff"
 	issue_service "code.gitea.io/gitea/services/issue"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -898,6 +899,8 @@ func MergePullRequest(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/empty"
+	//   "202":
+	//     "$ref": "#/responses/empty"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 	//   "405":
@@ -1012,6 +1015,31 @@ func MergePullRequest(ctx *context.APIContext) {
 		return
 	}
 
+	if mergeQueueRequired, err := pull_service.IsMergeQueueRequired(ctx, pr); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if mergeQueueRequired {
+		if form.ForceMerge {
+			ctx.APIError(http.StatusMethodNotAllowed, "Force merge is not available for pull requests merged through the merge queue")
+			return
+		}
+		if err := mergequeue.AddToMergeQueue(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message, deleteBranchAfterMerge); err != nil {
+			if pull_model.IsErrAlreadyInMergeQueue(err) {
+				ctx.APIError(http.StatusConflict, err)
+				return
+			}
+			if pull_service.IsErrInvalidMergeStyle(err) {
+				ctx.APIError(http.StatusMethodNotAllowed, fmt.Errorf("%s is not allowed an allowed merge style for this repository", repo_model.MergeStyle(form.Do)))
+				return
+			}
+			ctx.APIErrorInternal(err)
+			return
+		}
+		// the pull request is merged by the merge queue once the checks of its merge group succeed
+		ctx.Status(http.StatusAccepted)
+		return
+	}
+
 	if form.MergeWhenChecksSucceed {
 		scheduled, err := automerge.ScheduleAutoMerge(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message, deleteBranchAfterMerge)
 		if err != nil {
@@ -6,12 +6,14 @@ package repo
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	commitstatus_service "code.gitea.io/gitea/services/repository/commitstatus"
@@ -58,6 +60,10 @@ func NewCommitStatus(ctx *context.APIContext) {
 		ctx.APIError(http.StatusBadRequest, nil)
 		return
 	}
+	if strings.HasPrefix(strings.TrimSpace(form.Context), actions_service.RequiredWorkflowStatusContextPrefix) {
+		ctx.APIError(http.StatusBadRequest, fmt.Sprintf("the context prefix %q is reserved for the required workflows", actions_service.RequiredWorkflowStatusContextPrefix))
+		return
+	}
 	status := &git_model.CommitStatus{
 		State:       form.State,
 		TargetURL:   form.TargetURL,
@@ -0,0 +1,507 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	terraform_service "code.gitea.io/gitea/services/terraform"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// MethodLock and MethodUnlock are the HTTP methods used by the http backend of Terraform to lock and unlock a state
+	MethodLock   = "LOCK"
+	MethodUnlock = "UNLOCK"
+)
+
+func init() {
+	chi.RegisterMethod(MethodLock)
+	chi.RegisterMethod(MethodUnlock)
+}
+
+// ListTerraformStates lists the Terraform states of a repository
+func ListTerraformStates(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/state repository repoListTerraformStates
+	// ---
+	// summary: List the Terraform states of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	states, err := terraform_model.GetStatesByRepoID(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiStates := make([]*api.TerraformState, 0, len(states))
+	for _, s := range states {
+		apiState, err := convert.ToTerraformState(ctx, s, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiStates = append(apiStates, apiState)
+	}
+
+	ctx.SetTotalCountHeader(int64(len(apiStates)))
+	ctx.JSON(http.StatusOK, apiStates)
+}
+
+func getTerraformState(ctx *context.APIContext) *terraform_model.State {
+	s, err := terraform_model.GetStateByName(ctx, ctx.Repo.Repository.ID, ctx.PathParam("name"))
+	if err != nil {
+		if errors.Is(err, terraform_model.ErrStateNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return s
+}
+
+func serveTerraformStateVersion(ctx *context.APIContext, sv *terraform_model.StateVersion) {
+	obj, err := terraform_service.OpenStateVersion(sv)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	defer obj.Close()
+
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Resp.Header().Set("Cache-Control", "no-store")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(ctx.Resp, obj)
+}
+
+// writeTerraformLockInfo writes the lock information which is shown by Terraform if it can't acquire the lock
+func writeTerraformLockInfo(ctx *context.APIContext, status int, s *terraform_model.State) {
+	ctx.JSON(status, s.ParsedLockInfo())
+}
+
+// GetTerraformState returns the latest version of a Terraform state
+func GetTerraformState(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/state/{name} repository repoGetTerraformState
+	// ---
+	// summary: Get the latest version of a Terraform state
+	// description: The state endpoint is used as address of the Terraform http backend,
+	//   it also supports the LOCK and UNLOCK methods to lock and unlock the state.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the state document
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	sv, err := terraform_model.GetLatestStateVersion(ctx, s.ID)
+	if err != nil {
+		if errors.Is(err, terraform_model.ErrStateVersionNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	serveTerraformStateVersion(ctx, sv)
+}
+
+// UpdateTerraformState stores a new version of a Terraform state
+func UpdateTerraformState(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/terraform/state/{name} repository repoUpdateTerraformState
+	// ---
+	// summary: Store a new version of a Terraform state
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: ID
+	//   in: query
+	//   description: id of the lock if the state is locked
+	//   type: string
+	// - name: body
+	//   in: body
+	//   description: the state document
+	//   schema:
+	//     type: object
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/TerraformStateVersion"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/error"
+	//   "423":
+	//     "$ref": "#/responses/error"
+
+	data, ok := readTerraformRequestBody(ctx)
+	if !ok {
+		return
+	}
+
+	if checksum := ctx.Req.Header.Get("Content-MD5"); checksum != "" {
+		expected, err := base64.StdEncoding.DecodeString(checksum)
+		actual := md5.Sum(data)
+		if err != nil || !bytes.Equal(expected, actual[:]) {
+			ctx.APIError(http.StatusBadRequest, "checksum of the state does not match Content-MD5")
+			return
+		}
+	}
+
+	sv, err := terraform_service.SaveState(ctx, ctx.Repo.Repository, ctx.Doer, ctx.PathParam("name"), ctx.FormString("ID"), data)
+	if err != nil {
+		switch {
+		case errors.Is(err, terraform_service.ErrInvalidStateName), errors.Is(err, terraform_service.ErrInvalidStateDocument):
+			ctx.APIError(http.StatusBadRequest, err)
+		case errors.Is(err, terraform_model.ErrStateLocked):
+			ctx.APIError(http.StatusLocked, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	apiVersion, err := convert.ToTerraformStateVersion(ctx, sv, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, apiVersion)
+}
+
+// DeleteTerraformState deletes a Terraform state and all of its versions
+func DeleteTerraformState(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/terraform/state/{name} repository repoDeleteTerraformState
+	// ---
+	// summary: Delete a Terraform state and all of its versions
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/error"
+
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if s.IsLocked() {
+		ctx.APIError(http.StatusLocked, terraform_model.ErrStateLocked)
+		return
+	}
+
+	if err := terraform_service.DeleteState(ctx, s); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// LockTerraformState locks a Terraform state, the state is created if it doesn't exist yet
+func LockTerraformState(ctx *context.APIContext) {
+	name := ctx.PathParam("name")
+	if !terraform_service.IsValidStateName(name) {
+		ctx.APIError(http.StatusBadRequest, terraform_service.ErrInvalidStateName)
+		return
+	}
+
+	data, ok := readTerraformRequestBody(ctx)
+	if !ok {
+		return
+	}
+
+	info := &terraform_model.LockInfo{}
+	if err := json.Unmarshal(data, info); err != nil || info.ID == "" {
+		ctx.APIError(http.StatusBadRequest, "invalid lock information")
+		return
+	}
+
+	s, err := terraform_model.GetOrCreateState(ctx, ctx.Repo.Repository.ID, name)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if err := terraform_model.LockState(ctx, s, info.ID, string(data), ctx.Doer.ID); err != nil {
+		if errors.Is(err, terraform_model.ErrStateLocked) {
+			if s, err = terraform_model.GetStateByName(ctx, s.RepoID, s.Name); err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			}
+			writeTerraformLockInfo(ctx, http.StatusLocked, s)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	writeTerraformLockInfo(ctx, http.StatusOK, s)
+}
+
+// UnlockTerraformState unlocks a Terraform state. A request without lock information is sent by
+// "terraform force-unlock" and removes the lock regardless of its id.
+func UnlockTerraformState(ctx *context.APIContext) {
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	data, ok := readTerraformRequestBody(ctx)
+	if !ok {
+		return
+	}
+
+	info := &terraform_model.LockInfo{}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, info); err != nil || info.ID == "" {
+			ctx.APIError(http.StatusBadRequest, "invalid lock information")
+			return
+		}
+	}
+
+	if err := terraform_model.UnlockState(ctx, s, info.ID); err != nil {
+		switch {
+		case errors.Is(err, terraform_model.ErrStateLocked):
+			writeTerraformLockInfo(ctx, http.StatusConflict, s)
+		case errors.Is(err, terraform_model.ErrStateNotLocked):
+			ctx.Status(http.StatusOK)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// ListTerraformStateVersions lists the stored versions of a Terraform state
+func ListTerraformStateVersions(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/state/{name}/versions repository repoListTerraformStateVersions
+	// ---
+	// summary: List the stored versions of a Terraform state, the most recent version first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateVersionList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	versions, count, err := terraform_model.FindStateVersions(ctx, s.ID, utils.GetListOptions(ctx))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiVersions := make([]*api.TerraformStateVersion, 0, len(versions))
+	for _, sv := range versions {
+		apiVersion, err := convert.ToTerraformStateVersion(ctx, sv, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiVersions = append(apiVersions, apiVersion)
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiVersions)
+}
+
+// GetTerraformStateVersion returns a stored version of a Terraform state
+func GetTerraformStateVersion(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/state/{name}/versions/{version} repository repoGetTerraformStateVersion
+	// ---
+	// summary: Get a stored version of a Terraform state
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: number of the version
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the state document
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	sv, err := terraform_model.GetStateVersion(ctx, s.ID, ctx.PathParamInt64("version"))
+	if err != nil {
+		if errors.Is(err, terraform_model.ErrStateVersionNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	serveTerraformStateVersion(ctx, sv)
+}
+
+// readTerraformRequestBody reads the body of the request, the request is rejected if it's larger than the maximum state size
+func readTerraformRequestBody(ctx *context.APIContext) ([]byte, bool) {
+	data, err := io.ReadAll(io.LimitReader(ctx.Req.Body, setting.TerraformState.MaxStateSize+1))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, false
+	}
+	if int64(len(data)) > setting.TerraformState.MaxStateSize {
+		ctx.APIError(http.StatusRequestEntityTooLarge, fmt.Sprintf("the request is larger than the maximum state size of %d bytes", setting.TerraformState.MaxStateSize))
+		return nil, false
+	}
+	return data, true
+}
@@ -16,9 +16,11 @@ import (
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	notify_service "code.gitea.io/gitea/services/notify"
+	repo_service "code.gitea.io/gitea/services/repository"
 	wiki_service "code.gitea.io/gitea/services/wiki"
 )
 
@@ -337,6 +339,75 @@ func ListWikiPages(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, pages)
 }
 
+// SearchWikiPages search the pages of a wiki
+func SearchWikiPages(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/wiki/search repository repoSearchWikiPages
+	// ---
+	// summary: Search the wiki pages, only the page titles are searched if the wiki indexer is disabled
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: keyword to search for
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/WikiSearchResultList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	keyword := ctx.FormTrim("q")
+	if keyword == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, "keyword is required")
+		return
+	}
+	if !repo_service.HasWiki(ctx, ctx.Repo.Repository) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	results, total, err := wiki_service.SearchWikiPages(ctx, ctx.Repo.Repository, keyword, "", utils.GetListOptions(ctx))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiResults := make([]*api.WikiSearchResult, 0, len(results))
+	for _, result := range results {
+		apiResults = append(apiResults, &api.WikiSearchResult{
+			Title:   result.Title,
+			HTMLURL: util.URLJoin(ctx.Repo.Repository.HTMLURL(), "wiki", result.SubURL),
+			SubURL:  result.SubURL,
+			Snippet: result.Snippet,
+		})
+	}
+
+	ctx.SetTotalCountHeader(int64(total))
+	ctx.JSON(http.StatusOK, apiResults)
+}
+
 // GetWikiPage get single wiki page
 func GetWikiPage(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/wiki/page/{pageName} repository repoGetWikiPage
@@ -85,7 +85,7 @@ func ListJobs(ctx *context.APIContext, ownerID, repoID, runID int64) {
 func convertToInternal(s string) ([]actions_model.Status, error) {
 	switch s {
 	case "pending", "waiting", "requested", "action_required":
-		return []actions_model.Status{actions_model.StatusBlocked}, nil
+		return []actions_model.Status{actions_model.StatusBlocked, actions_model.StatusPendingDeployment}, nil
 	case "queued":
 		return []actions_model.Status{actions_model.StatusWaiting}, nil
 	case "in_progress":
@@ -0,0 +1,44 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	audit_service "code.gitea.io/gitea/services/audit"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ExportAuditEvents writes the matching audit events of the scope as JSON lines from the oldest to the newest one,
+// the events of all scopes are exported if scopeType is empty
+func ExportAuditEvents(ctx *context.APIContext, scopeType audit_model.ScopeType, scopeID int64) {
+	before, since, err := context.GetQueryBeforeSince(ctx.Base)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+	opts := audit_model.FindEventsOptions{
+		ScopeType: scopeType,
+		ScopeID:   scopeID,
+		Action:    ctx.FormTrim("action"),
+		Since:     timeutil.TimeStamp(since),
+		Until:     timeutil.TimeStamp(before),
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/x-ndjson")
+	ctx.Resp.Header().Set("Content-Disposition", `attachment; filename="audit.jsonl"`)
+	ctx.Resp.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(ctx.Resp)
+	if err := audit_model.IterateEvents(ctx, opts, func(e *audit_model.Event) error {
+		return enc.Encode(audit_service.ToAPIEvent(e))
+	}); err != nil {
+		// the status has been sent already, so the export can only be aborted
+		log.Error("Unable to export audit events: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	project_service "code.gitea.io/gitea/services/projects"
+)
+
+// ListProjectFields writes the custom fields of a project
+func ListProjectFields(ctx *context.APIContext, project *project_model.Project) {
+	fields, err := project.GetFields(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiFields := make([]*api.ProjectField, len(fields))
+	for i, f := range fields {
+		apiFields[i] = convert.ToAPIProjectField(f)
+	}
+	ctx.JSON(http.StatusOK, apiFields)
+}
+
+// ListProjectItems writes a page of the issues and pull requests of a project with their columns and custom field values
+func ListProjectItems(ctx *context.APIContext, project *project_model.Project, issueOpts *issues_model.IssuesOptions) {
+	columns, err := project.GetColumns(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	fields, err := project.GetFields(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	rows, err := project_service.LoadProjectTableRows(ctx, project, columns, issueOpts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	pageRows := util.PaginateSlice(rows, listOptions.Page, listOptions.PageSize).([]*project_service.TableRow)
+
+	items := make([]*api.ProjectItem, len(pageRows))
+	for i, row := range pageRows {
+		items[i] = convert.ToAPIProjectItem(row, fields)
+	}
+	ctx.SetTotalCountHeader(int64(len(rows)))
+	ctx.JSON(http.StatusOK, items)
+}
+
+// SetProjectItemFieldValue sets the value of a custom field of the project for an issue or pull request,
+// it writes the new value, or no content if the value is cleared
+func SetProjectItemFieldValue(ctx *context.APIContext, project *project_model.Project, issue *issues_model.Issue, form *api.SetProjectFieldValueOption) {
+	field, err := project_model.GetFieldByID(ctx, project.ID, ctx.PathParamInt64("field_id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if err := project_model.SetFieldValue(ctx, field, issue.ID, form.Value); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	values, err := project_model.GetFieldValuesMap(ctx, project.ID, issue.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	value := values.Get(issue.ID, field.ID)
+	if value == "" {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIProjectFieldValue(field, value))
+}
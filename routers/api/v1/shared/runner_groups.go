@@ -0,0 +1,189 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/optional"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// The runner groups of an org have ownerID of the org, the instance-level runner groups have ownerID 0.
+// Access rights are checked at the API route level.
+
+func handleRunnerGroupError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		ctx.APIErrorNotFound(err)
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.APIError(http.StatusConflict, err)
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// ListRunnerGroups lists the runner groups of the owner
+func ListRunnerGroups(ctx *context.APIContext, ownerID int64) {
+	groups, total, err := db.FindAndCount[actions_model.ActionRunnerGroup](ctx, &actions_model.FindRunnerGroupsOptions{
+		OwnerID:     ownerID,
+		ListOptions: utils.GetListOptions(ctx),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := &api.ActionRunnerGroupsResponse{
+		Entries:    make([]*api.ActionRunnerGroup, len(groups)),
+		TotalCount: total,
+	}
+	for i, g := range groups {
+		res.Entries[i] = convert.ToActionRunnerGroup(g)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetRunnerGroup gets a runner group of the owner
+func GetRunnerGroup(ctx *context.APIContext, ownerID, groupID int64) {
+	g, err := actions_model.GetRunnerGroupByID(ctx, ownerID, groupID)
+	if err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionRunnerGroup(g))
+}
+
+// CreateRunnerGroup creates a runner group of the owner
+func CreateRunnerGroup(ctx *context.APIContext, ownerID int64) {
+	form := web.GetForm(ctx).(*api.CreateActionRunnerGroupOption)
+	g := &actions_model.ActionRunnerGroup{
+		OwnerID:       ownerID,
+		Name:          form.Name,
+		AllRepos:      form.AllRepositories,
+		RepoIDs:       form.RepositoryIDs,
+		Workflows:     form.Workflows,
+		BranchFilters: form.BranchFilters,
+	}
+	if err := actions_model.CreateRunnerGroup(ctx, g); err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToActionRunnerGroup(g))
+}
+
+// EditRunnerGroup edits a runner group of the owner
+func EditRunnerGroup(ctx *context.APIContext, ownerID, groupID int64) {
+	g, err := actions_model.GetRunnerGroupByID(ctx, ownerID, groupID)
+	if err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.EditActionRunnerGroupOption)
+	g.Name = optional.FromPtr(form.Name).ValueOrDefault(g.Name)
+	g.AllRepos = optional.FromPtr(form.AllRepositories).ValueOrDefault(g.AllRepos)
+	if form.RepositoryIDs != nil {
+		g.RepoIDs = form.RepositoryIDs
+	}
+	if form.Workflows != nil {
+		g.Workflows = form.Workflows
+	}
+	if form.BranchFilters != nil {
+		g.BranchFilters = form.BranchFilters
+	}
+	if err := actions_model.UpdateRunnerGroup(ctx, g); err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionRunnerGroup(g))
+}
+
+// DeleteRunnerGroup deletes an empty runner group of the owner
+func DeleteRunnerGroup(ctx *context.APIContext, ownerID, groupID int64) {
+	g, err := actions_model.GetRunnerGroupByID(ctx, ownerID, groupID)
+	if err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	if err := actions_model.DeleteRunnerGroup(ctx, g); err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListRunnerGroupRunners lists the runners in a runner group of the owner
+func ListRunnerGroupRunners(ctx *context.APIContext, ownerID, groupID int64) {
+	if _, err := actions_model.GetRunnerGroupByID(ctx, ownerID, groupID); err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	runners, total, err := db.FindAndCount[actions_model.ActionRunner](ctx, &actions_model.FindRunnerOptions{
+		GroupID:     optional.Some(groupID),
+		ListOptions: utils.GetListOptions(ctx),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := &api.ActionRunnersResponse{
+		Entries:    make([]*api.ActionRunner, len(runners)),
+		TotalCount: total,
+	}
+	for i, runner := range runners {
+		res.Entries[i] = convert.ToActionRunner(ctx, runner)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// AddRunnerToGroup moves a runner of the owner into a runner group of the owner
+func AddRunnerToGroup(ctx *context.APIContext, ownerID, groupID, runnerID int64) {
+	g, err := actions_model.GetRunnerGroupByID(ctx, ownerID, groupID)
+	if err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	runner, ok := getRunnerByID(ctx, ownerID, 0, runnerID)
+	if !ok {
+		return
+	}
+	if err := actions_model.SetRunnerGroup(ctx, runner, g); err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveRunnerFromGroup moves a runner out of a runner group of the owner
+func RemoveRunnerFromGroup(ctx *context.APIContext, ownerID, groupID, runnerID int64) {
+	if _, err := actions_model.GetRunnerGroupByID(ctx, ownerID, groupID); err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	runner, ok := getRunnerByID(ctx, ownerID, 0, runnerID)
+	if !ok {
+		return
+	}
+	if runner.GroupID != groupID {
+		ctx.APIErrorNotFound("The runner is not in the runner group")
+		return
+	}
+	if err := actions_model.SetRunnerGroup(ctx, runner, nil); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -9,10 +9,13 @@ import (
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 )
@@ -125,3 +128,65 @@ func DeleteRunner(ctx *context.APIContext, ownerID, repoID, runnerID int64) {
 	}
 	ctx.Status(http.StatusNoContent)
 }
+
+// GenerateRunnerJITConfig creates a runner for api route validated ownerID and repoID and returns its just-in-time config
+// ownerID == 0 and repoID == 0 means a global runner
+// ownerID == 0 and repoID != 0 means a runner for the given repo
+// ownerID != 0 and repoID == 0 means a runner for the given user/org
+// ownerID != 0 and repoID != 0 undefined behavior
+// Access rights are checked at the API route level
+func GenerateRunnerJITConfig(ctx *context.APIContext, ownerID, repoID int64) {
+	if ownerID != 0 && repoID != 0 {
+		setting.PanicInDevOrTesting("ownerID and repoID should not be both set")
+	}
+	form := web.GetForm(ctx).(*api.GenerateActionRunnerJITConfigOption)
+	if !optional.FromPtr(form.Ephemeral).ValueOrDefault(true) {
+		ctx.APIError(http.StatusBadRequest, "just-in-time runners must be ephemeral")
+		return
+	}
+	runner, config, err := actions_service.CreateJITRunner(ctx, ownerID, repoID, form.Name, form.Labels)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &api.ActionRunnerJITConfig{
+		Runner:           convert.ToActionRunner(ctx, runner),
+		EncodedJITConfig: config,
+	})
+}
+
+// ListRunnerQueues lists the jobs waiting for the runners for api route validated ownerID and repoID grouped by their labels
+// ownerID == 0 and repoID == 0 means the jobs which could be picked by global runners
+// ownerID == 0 and repoID != 0 means the jobs which could be picked by the runners of the given repo
+// ownerID != 0 and repoID == 0 means the jobs which could be picked by the runners of the given user/org
+// ownerID != 0 and repoID != 0 undefined behavior
+// The jobs are the ones which the runners out of any runner group could pick, like the just-in-time runners
+// Access rights are checked at the API route level
+func ListRunnerQueues(ctx *context.APIContext, ownerID, repoID int64) {
+	if ownerID != 0 && repoID != 0 {
+		setting.PanicInDevOrTesting("ownerID and repoID should not be both set")
+	}
+	queues, err := actions_model.GetRunnerQueues(ctx, ownerID, repoID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := &api.ActionRunnerQueuesResponse{
+		Entries: make([]*api.ActionRunnerQueue, len(queues)),
+	}
+	for i, queue := range queues {
+		res.Entries[i] = &api.ActionRunnerQueue{
+			Labels:         queue.Labels,
+			PendingJobs:    queue.Count,
+			OldestQueuedAt: queue.OldestUpdated.AsTime(),
+		}
+		res.TotalPendingJobs += queue.Count
+	}
+	ctx.JSON(http.StatusOK, res)
+}
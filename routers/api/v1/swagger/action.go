@@ -46,3 +46,45 @@ type swaggerResponseActionWorkflowList struct {
 	// in:body
 	Body api.ActionWorkflowResponse `json:"body"`
 }
+
+// ActionRunLogMatchList
+// swagger:response ActionRunLogMatchList
+type swaggerResponseActionRunLogMatchList struct {
+	// in:body
+	Body []api.ActionRunLogMatch `json:"body"`
+}
+
+// ActionWorkflowDispatchRun
+// swagger:response ActionWorkflowDispatchRun
+type swaggerResponseActionWorkflowDispatchRun struct {
+	// in:body
+	Body api.ActionWorkflowDispatchRun `json:"body"`
+}
+
+// ActionRunnerJITConfig
+// swagger:response ActionRunnerJITConfig
+type swaggerResponseActionRunnerJITConfig struct {
+	// in:body
+	Body api.ActionRunnerJITConfig `json:"body"`
+}
+
+// ActionRunnerQueues
+// swagger:response ActionRunnerQueues
+type swaggerResponseActionRunnerQueues struct {
+	// in:body
+	Body api.ActionRunnerQueuesResponse `json:"body"`
+}
+
+// ActionRunnerGroup
+// swagger:response ActionRunnerGroup
+type swaggerResponseActionRunnerGroup struct {
+	// in:body
+	Body api.ActionRunnerGroup `json:"body"`
+}
+
+// ActionRunnerGroupList
+// swagger:response ActionRunnerGroupList
+type swaggerResponseActionRunnerGroupList struct {
+	// in:body
+	Body api.ActionRunnerGroupsResponse `json:"body"`
+}
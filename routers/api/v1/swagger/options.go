@@ -225,6 +225,18 @@ type swaggerParameterBodies struct {
 	// in:body
 	UpdateVariableOption api.UpdateVariableOption
 
+	// in:body
+	GenerateActionRunnerJITConfigOption api.GenerateActionRunnerJITConfigOption
+
+	// in:body
+	CreateActionRunnerGroupOption api.CreateActionRunnerGroupOption
+
+	// in:body
+	EditActionRunnerGroupOption api.EditActionRunnerGroupOption
+
 	// in:body
 	LockIssueOption api.LockIssueOption
+
+	// in:body
+	SetProjectFieldValueOption api.SetProjectFieldValueOption
 }
@@ -27,3 +27,17 @@ type swaggerResponsePackageFileList struct {
 	// in:body
 	Body []api.PackageFile `json:"body"`
 }
+
+// PackageAttestation
+// swagger:response PackageAttestation
+type swaggerResponsePackageAttestation struct {
+	// in:body
+	Body api.PackageAttestation `json:"body"`
+}
+
+// PackageAttestationList
+// swagger:response PackageAttestationList
+type swaggerResponsePackageAttestationList struct {
+	// in:body
+	Body []api.PackageAttestation `json:"body"`
+}
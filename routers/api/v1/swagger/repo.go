@@ -386,6 +386,13 @@ type swaggerWikiPageList struct {
 	Body []api.WikiPageMetaData `json:"body"`
 }
 
+// WikiSearchResultList
+// swagger:response WikiSearchResultList
+type swaggerWikiSearchResultList struct {
+	// in:body
+	Body []api.WikiSearchResult `json:"body"`
+}
+
 // WikiPage
 // swagger:response WikiPage
 type swaggerWikiPage struct {
@@ -522,3 +529,45 @@ type swaggerMergeUpstreamResponse struct {
 	// in:body
 	Body api.MergeUpstreamResponse `json:"body"`
 }
+
+// ProjectFieldList
+// swagger:response ProjectFieldList
+type swaggerResponseProjectFieldList struct {
+	// in:body
+	Body []api.ProjectField `json:"body"`
+}
+
+// ProjectFieldValue
+// swagger:response ProjectFieldValue
+type swaggerResponseProjectFieldValue struct {
+	// in:body
+	Body api.ProjectFieldValue `json:"body"`
+}
+
+// ProjectItemList
+// swagger:response ProjectItemList
+type swaggerResponseProjectItemList struct {
+	// in:body
+	Body []api.ProjectItem `json:"body"`
+}
+
+// TerraformStateList
+// swagger:response TerraformStateList
+type swaggerResponseTerraformStateList struct {
+	// in:body
+	Body []api.TerraformState `json:"body"`
+}
+
+// TerraformStateVersion
+// swagger:response TerraformStateVersion
+type swaggerResponseTerraformStateVersion struct {
+	// in:body
+	Body api.TerraformStateVersion `json:"body"`
+}
+
+// TerraformStateVersionList
+// swagger:response TerraformStateVersionList
+type swaggerResponseTerraformStateVersionList struct {
+	// in:body
+	Body []api.TerraformStateVersion `json:"body"`
+}
@@ -18,6 +18,7 @@ import (
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
+	user_service "code.gitea.io/gitea/services/user"
 )
 
 // ListAccessTokens list all the access tokens
@@ -126,7 +127,7 @@ func CreateAccessToken(ctx *context.APIContext) {
 	}
 	t.Scope = scope
 
-	if err := auth_model.NewAccessToken(ctx, t); err != nil {
+	if err := user_service.CreateAccessToken(ctx, ctx.Doer, ctx.ContextUser, t); err != nil {
 		ctx.APIErrorInternal(err)
 		return
 	}
@@ -196,7 +197,7 @@ func DeleteAccessToken(ctx *context.APIContext) {
 		return
 	}
 
-	if err := auth_model.DeleteAccessTokenByID(ctx, tokenID, ctx.ContextUser.ID); err != nil {
+	if err := user_service.DeleteAccessToken(ctx, ctx.Doer, ctx.ContextUser, tokenID); err != nil {
 		if auth_model.IsErrAccessTokenNotExist(err) {
 			ctx.APIErrorNotFound()
 		} else {
@@ -40,6 +40,49 @@ func CreateRegistrationToken(ctx *context.APIContext) {
 	shared.GetRegistrationToken(ctx, ctx.Doer.ID, 0)
 }
 
+// GenerateRunnerJITConfig creates an user-level runner and returns its just-in-time config
+func GenerateRunnerJITConfig(ctx *context.APIContext) {
+	// swagger:operation POST /user/actions/runners/generate-jitconfig user userGenerateRunnerJITConfig
+	// ---
+	// summary: Create an user-level runner and get its just-in-time config
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/GenerateActionRunnerJITConfigOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ActionRunnerJITConfig"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.GenerateRunnerJITConfig(ctx, ctx.Doer.ID, 0)
+}
+
+// ListRunnerQueues lists the jobs waiting for user-level runners grouped by their labels
+func ListRunnerQueues(ctx *context.APIContext) {
+	// swagger:operation GET /user/actions/runners/queue user getUserRunnerQueues
+	// ---
+	// summary: Get the jobs waiting for user-level runners grouped by their labels
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerQueues"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.ListRunnerQueues(ctx, ctx.Doer.ID, 0)
+}
+
 // ListRunners get user-level runners
 func ListRunners(ctx *context.APIContext) {
 	// swagger:operation GET /user/actions/runners user getUserRunners
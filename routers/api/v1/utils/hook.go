@@ -261,7 +261,7 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 	if err := w.UpdateEvent(); err != nil {
 		ctx.APIErrorInternal(err)
 		return nil, false
-	} else if err := webhook.CreateWebhook(ctx, w); err != nil {
+	} else if err := webhook_service.CreateWebhook(ctx, ctx.Doer, w); err != nil {
 		ctx.APIErrorInternal(err)
 		return nil, false
 	}
@@ -391,7 +391,7 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 		w.IsActive = *form.Active
 	}
 
-	if err := webhook.UpdateWebhook(ctx, w); err != nil {
+	if err := webhook_service.UpdateWebhook(ctx, ctx.Doer, w); err != nil {
 		ctx.APIErrorInternal(err)
 		return false
 	}
@@ -400,7 +400,7 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 
 // DeleteOwnerHook deletes the hook owned by the owner.
 func DeleteOwnerHook(ctx *context.APIContext, owner *user_model.User, hookID int64) {
-	if err := webhook.DeleteWebhookByOwnerID(ctx, owner.ID, hookID); err != nil {
+	if err := webhook_service.DeleteWebhookByOwnerID(ctx, ctx.Doer, owner.ID, hookID); err != nil {
 		if webhook.IsErrWebhookNotExist(err) {
 			ctx.APIErrorNotFound()
 		} else {
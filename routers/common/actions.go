@@ -5,12 +5,15 @@ package common
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/util"
+	actions_service "code.gitea.io/gitea/services/actions"
 	"code.gitea.io/gitea/services/context"
 )
 
@@ -56,12 +59,8 @@ func DownloadActionsRunJobLogs(ctx *context.Base, ctxRepo *repo_model.Repository
 	}
 	defer reader.Close()
 
-	workflowName := curJob.Run.WorkflowID
-	if p := strings.Index(workflowName, "."); p > 0 {
-		workflowName = workflowName[0:p]
-	}
 	ctx.ServeContent(reader, &context.ServeHeaderOptions{
-		Filename:           fmt.Sprintf("%v-%v-%v.log", workflowName, curJob.Name, task.ID),
+		Filename:           fmt.Sprintf("%v-%v-%v.log", workflowNameOfRun(curJob.Run), curJob.Name, task.ID),
 		ContentLength:      &task.LogSize,
 		ContentType:        "text/plain",
 		ContentTypeCharset: "utf-8",
@@ -69,3 +68,36 @@ func DownloadActionsRunJobLogs(ctx *context.Base, ctxRepo *repo_model.Repository
 	})
 	return nil
 }
+
+// DownloadActionsRunLogs serves a zip archive of the logs of the latest attempts of all jobs of a run
+func DownloadActionsRunLogs(ctx *context.Base, ctxRepo *repo_model.Repository, run *actions_model.ActionRun) error {
+	if run.RepoID != ctxRepo.ID {
+		return util.NewNotExistErrorf("run not found")
+	}
+	jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
+	if err != nil {
+		return fmt.Errorf("GetRunJobsByRunID: %w", err)
+	}
+	if !slices.ContainsFunc(jobs, func(job *actions_model.ActionRunJob) bool { return job.TaskID > 0 }) {
+		return util.NewNotExistErrorf("no job has been started")
+	}
+
+	ctx.SetServeHeaders(&context.ServeHeaderOptions{
+		Filename:    fmt.Sprintf("%v-%v-logs.zip", workflowNameOfRun(run), run.Index),
+		ContentType: "application/zip",
+		Disposition: "attachment",
+	})
+	// the headers have been sent, an error can only be logged
+	if err := actions_service.WriteRunLogsArchive(ctx, ctx.Resp, jobs); err != nil {
+		log.Error("WriteRunLogsArchive of run %d: %v", run.ID, err)
+	}
+	return nil
+}
+
+func workflowNameOfRun(run *actions_model.ActionRun) string {
+	workflowName := run.WorkflowID
+	if p := strings.Index(workflowName, "."); p > 0 {
+		workflowName = workflowName[0:p]
+	}
+	return workflowName
+}
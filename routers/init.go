@@ -31,12 +31,14 @@ import (
 	"code.gitea.io/gitea/modules/web/routing"
 	actions_router "code.gitea.io/gitea/routers/api/actions"
 	packages_router "code.gitea.io/gitea/routers/api/packages"
+	terraform_router "code.gitea.io/gitea/routers/api/packages/terraform"
 	apiv1 "code.gitea.io/gitea/routers/api/v1"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/routers/private"
 	web_routers "code.gitea.io/gitea/routers/web"
 	actions_service "code.gitea.io/gitea/services/actions"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
+	audit_service "code.gitea.io/gitea/services/audit"
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/auth/source/oauth2"
 	"code.gitea.io/gitea/services/automerge"
@@ -46,6 +48,7 @@ import (
 	"code.gitea.io/gitea/services/mailer"
 	mailer_incoming "code.gitea.io/gitea/services/mailer/incoming"
 	markup_service "code.gitea.io/gitea/services/markup"
+	"code.gitea.io/gitea/services/mergequeue"
 	repo_migrations "code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	"code.gitea.io/gitea/services/oauth2_provider"
@@ -160,7 +163,9 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(webhook.Init)
 	mustInit(pull_service.Init)
 	mustInit(automerge.Init)
+	mustInit(mergequeue.Init)
 	mustInit(task.Init)
+	mustInit(audit_service.Init)
 	mustInit(repo_migrations.Init)
 	eventsource.GetManager().Init()
 	mustInitCtx(ctx, mailer_incoming.Init)
@@ -195,6 +200,8 @@ func NormalRoutes() *web.Router {
 	if setting.Packages.Enabled {
 		// This implements package support for most package managers
 		r.Mount("/api/packages", packages_router.CommonRoutes())
+		// Terraform looks up the locations of the registry APIs in the root of the host
+		r.Get("/.well-known/terraform.json", terraform_router.ServiceDiscovery)
 		// This implements the OCI API, this container registry "/v2" endpoint must be in the root of the site.
 		// If site admin deploys Gitea in a sub-path, they must configure their reverse proxy to map the "https://host/v2" endpoint to Gitea.
 		r.Mount("/v2", packages_router.ContainerRoutes())
@@ -211,6 +218,9 @@ func NormalRoutes() *web.Router {
 		r.Mount(prefix, actions_router.ArtifactsRoutes(prefix))
 		prefix = actions_router.ArtifactV4RouteBase
 		r.Mount(prefix, actions_router.ArtifactsV4Routes(prefix))
+		prefix = "/api/actions_cache"
+		r.Mount(prefix, actions_router.CacheRoutes(prefix))
+		r.Mount("/api/actions_summary", actions_router.SummaryRoutes())
 	}
 
 	r.NotFound(func(w http.ResponseWriter, req *http.Request) {
@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
@@ -17,6 +19,7 @@ import (
 	"code.gitea.io/gitea/modules/cachegroup"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/private"
 	repo_module "code.gitea.io/gitea/modules/repository"
@@ -42,6 +45,11 @@ func HookPostReceive(ctx *gitea_context.PrivateContext) {
 	ownerName := ctx.PathParam("owner")
 	repoName := ctx.PathParam("repo")
 
+	if opts.IsWiki {
+		hookPostReceiveWiki(ctx, ownerName, repoName, opts)
+		return
+	}
+
 	// defer getting the repository at this point - as we should only retrieve it if we're going to call update
 	var (
 		repo    *repo_model.Repository
@@ -320,6 +328,20 @@ func HookPostReceive(ctx *gitea_context.PrivateContext) {
 	})
 }
 
+// hookPostReceiveWiki updates the wiki indexer if the default branch of the wiki has been pushed
+func hookPostReceiveWiki(ctx *gitea_context.PrivateContext, ownerName, repoName string, opts *private.HookOptions) {
+	// the web editor pushes to "<repo>.wiki", the git clients to the repository name
+	repo := loadRepository(ctx, ownerName, strings.TrimSuffix(repoName, ".wiki"))
+	if ctx.Written() {
+		return
+	}
+
+	if slices.Contains(opts.RefFullNames, git.RefNameFromBranch(repo.DefaultWikiBranch)) {
+		wiki_indexer.UpdateWikiIndexer(repo)
+	}
+	ctx.JSON(http.StatusOK, private.HookPostReceiveResult{})
+}
+
 func loadContextCacheUser(ctx context.Context, id int64) (*user_model.User, error) {
 	return cache.GetWithContextCache(ctx, cachegroup.User, id, user_model.GetUserByID)
 }
@@ -0,0 +1,35 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/modules/templates"
+	shared_audit "code.gitea.io/gitea/routers/web/shared/audit"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplAudit templates.TplName = "admin/audit"
+
+// AuditLog shows the audit events of all scopes and whether the hash chain is intact
+func AuditLog(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("audit.title")
+	ctx.Data["PageIsAdminAudit"] = true
+	ctx.Data["ShowAuditScope"] = true
+
+	brokenID, err := audit_model.VerifyEvents(ctx)
+	if err != nil {
+		ctx.ServerError("VerifyEvents", err)
+		return
+	}
+	ctx.Data["AuditChainBrokenID"] = brokenID
+
+	shared_audit.SetAuditEventsContext(ctx, "", 0)
+	if ctx.Written() {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplAudit)
+}
@@ -458,7 +458,7 @@ func EditUserPost(ctx *context.Context) {
 			ctx.ServerError("auth.GetTwoFactorByUID", err)
 			return
 		} else if tf != nil {
-			if err := auth.DeleteTwoFactorByID(ctx, tf.ID, u.ID); err != nil {
+			if err := user_service.DisableTwoFactor(ctx, ctx.Doer, u, tf); err != nil {
 				ctx.ServerError("auth.DeleteTwoFactorByID", err)
 				return
 			}
@@ -470,7 +470,7 @@ func EditUserPost(ctx *context.Context) {
 			return
 		}
 		for _, cred := range wn {
-			if _, err := auth.DeleteCredential(ctx, cred.ID, u.ID); err != nil {
+			if _, err := user_service.RemoveWebAuthnCredential(ctx, ctx.Doer, u, cred.ID); err != nil {
 				ctx.ServerError("auth.DeleteCredential", err)
 				return
 			}
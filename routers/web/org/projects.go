@@ -21,6 +21,7 @@ import (
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/web/shared/issue"
+	shared_project "code.gitea.io/gitea/routers/web/shared/project"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
@@ -28,9 +29,12 @@ import (
 )
 
 const (
-	tplProjects     templates.TplName = "org/projects/list"
-	tplProjectsNew  templates.TplName = "org/projects/new"
-	tplProjectsView templates.TplName = "org/projects/view"
+	tplProjects       templates.TplName = "org/projects/list"
+	tplProjectsNew    templates.TplName = "org/projects/new"
+	tplProjectsView   templates.TplName = "org/projects/view"
+	tplProjectTable   templates.TplName = "org/projects/table"
+	tplProjectRoadmap templates.TplName = "org/projects/roadmap"
+	tplProjectFields  templates.TplName = "org/projects/fields"
 )
 
 // MustEnableProjects check if projects are enabled in settings
@@ -420,6 +424,19 @@ func ViewProject(ctx *context.Context) {
 	ctx.Data["Labels"] = labels
 	ctx.Data["NumLabels"] = len(labels)
 
+	columnAutomations, err := project.GetColumnAutomationsMap(ctx)
+	if err != nil {
+		ctx.ServerError("GetColumnAutomationsMap", err)
+		return
+	}
+	ctx.Data["ColumnAutomations"] = columnAutomations
+	ctx.Data["AutomationEvents"] = project_model.AutomationEvents
+
+	shared_project.PrepareFieldsData(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
 	// Get assignees.
 	assigneeUsers, err := org_model.GetOrgAssignees(ctx, project.OwnerID)
 	if err != nil {
@@ -446,6 +463,71 @@ func ViewProject(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplProjectsView)
 }
 
+// ViewProjectTable renders the issues of a project as a table
+func ViewProjectTable(ctx *context.Context) {
+	viewProjectIssues(ctx, shared_project.PrepareTableContext, tplProjectTable)
+}
+
+// ViewProjectRoadmap renders the issues of a project on a timeline
+func ViewProjectRoadmap(ctx *context.Context) {
+	viewProjectIssues(ctx, shared_project.PrepareRoadmapContext, tplProjectRoadmap)
+}
+
+func viewProjectIssues(ctx *context.Context, prepare func(*context.Context, *project_model.Project, *issues_model.IssuesOptions), tpl templates.TplName) {
+	project := shared_project.GetProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	if project.OwnerID != ctx.ContextUser.ID {
+		ctx.NotFound(nil)
+		return
+	}
+	if err := project.LoadOwner(ctx); err != nil {
+		ctx.ServerError("LoadOwner", err)
+		return
+	}
+
+	opts := &issues_model.IssuesOptions{
+		Owner: project.Owner,
+		Doer:  ctx.Doer,
+	}
+	// the project may contain issues of private repositories, anonymous users may only see public ones
+	if ctx.Doer == nil {
+		opts.AllPublic = true
+	}
+	prepare(ctx, project, opts)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["PageIsViewProjects"] = true
+	ctx.Data["CanWriteProjects"] = canWriteProjects(ctx)
+	ctx.Data["Title"] = fmt.Sprintf("%s - %s", project.Title, ctx.ContextUser.DisplayName())
+
+	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
+		ctx.ServerError("RenderUserOrgHeader", err)
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tpl)
+}
+
+// ProjectFields renders the custom fields of a project
+func ProjectFields(ctx *context.Context) {
+	shared_project.PrepareFieldsContext(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["PageIsViewProjects"] = true
+	ctx.Data["Title"] = fmt.Sprintf("%s - %s", ctx.Data["Title"], ctx.ContextUser.DisplayName())
+
+	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
+		ctx.ServerError("RenderUserOrgHeader", err)
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplProjectFields)
+}
+
 // DeleteProjectColumn allows for the deletion of a project column
 func DeleteProjectColumn(ctx *context.Context) {
 	if ctx.Doer == nil {
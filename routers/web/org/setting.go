@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 
+	audit_model "code.gitea.io/gitea/models/audit"
 	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -23,10 +24,12 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	user_setting "code.gitea.io/gitea/routers/web/user/setting"
+	audit_service "code.gitea.io/gitea/services/audit"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	org_service "code.gitea.io/gitea/services/org"
 	user_service "code.gitea.io/gitea/services/user"
+	webhook_service "code.gitea.io/gitea/services/webhook"
 )
 
 const (
@@ -69,6 +72,7 @@ func SettingsPost(ctx *context.Context) {
 	}
 
 	org := ctx.Org.Organization
+	before := audit_service.OrgState(org)
 
 	if form.Email != "" {
 		if err := user_service.ReplacePrimaryEmailAddress(ctx, org.AsUser(), form.Email); err != nil {
@@ -94,6 +98,7 @@ func SettingsPost(ctx *context.Context) {
 		return
 	}
 
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionOrgUpdate, audit_service.OwnerScope(org.AsUser()), audit_service.OrgTarget(org), before, audit_service.OrgState(org))
 	log.Trace("Organization setting updated: %s", org.Name)
 	ctx.Flash.Success(ctx.Tr("org.settings.update_setting_success"))
 	ctx.Redirect(ctx.Org.OrgLink + "/settings")
@@ -170,7 +175,7 @@ func Webhooks(ctx *context.Context) {
 
 // DeleteWebhook response for delete webhook
 func DeleteWebhook(ctx *context.Context) {
-	if err := webhook.DeleteWebhookByOwnerID(ctx, ctx.Org.Organization.ID, ctx.FormInt64("id")); err != nil {
+	if err := webhook_service.DeleteWebhookByOwnerID(ctx, ctx.Doer, ctx.Org.Organization.ID, ctx.FormInt64("id")); err != nil {
 		ctx.Flash.Error("DeleteWebhookByOwnerID: " + err.Error())
 	} else {
 		ctx.Flash.Success(ctx.Tr("repo.settings.webhook_deletion_success"))
@@ -203,6 +208,7 @@ func SettingsRenamePost(ctx *context.Context) {
 	}
 
 	oldOrgName, newOrgName := ctx.Org.Organization.Name, form.NewOrgName
+	before := audit_service.OrgState(ctx.Org.Organization)
 
 	if form.OrgName != oldOrgName {
 		ctx.JSONError(ctx.Tr("form.enterred_invalid_org_name"))
@@ -227,6 +233,8 @@ func SettingsRenamePost(ctx *context.Context) {
 		return
 	}
 
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionOrgUpdate, audit_service.OwnerScope(ctx.Org.Organization.AsUser()), audit_service.OrgTarget(ctx.Org.Organization), before, audit_service.OrgState(ctx.Org.Organization))
+
 	ctx.Flash.Success(ctx.Tr("org.settings.rename_success", oldOrgName, newOrgName))
 	ctx.JSONRedirect(setting.AppSubURL + "/org/" + url.PathEscape(newOrgName) + "/settings")
 }
@@ -246,12 +254,15 @@ func SettingsChangeVisibilityPost(ctx *context.Context) {
 		return
 	}
 
+	before := audit_service.OrgState(ctx.Org.Organization)
 	if err := org_service.ChangeOrganizationVisibility(ctx, ctx.Org.Organization, visibility); err != nil {
 		log.Error("ChangeOrganizationVisibility: %v", err)
 		ctx.JSONError(ctx.Tr("error.occurred"))
 		return
 	}
 
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionOrgUpdate, audit_service.OwnerScope(ctx.Org.Organization.AsUser()), audit_service.OrgTarget(ctx.Org.Organization), before, audit_service.OrgState(ctx.Org.Organization))
+
 	ctx.Flash.Success(ctx.Tr("org.settings.change_visibility_success", ctx.Org.Organization.Name))
 	ctx.JSONRedirect(setting.AppSubURL + "/org/" + url.PathEscape(ctx.Org.Organization.Name) + "/settings")
 }
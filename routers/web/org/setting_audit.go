@@ -0,0 +1,33 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"net/http"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/modules/templates"
+	shared_audit "code.gitea.io/gitea/routers/web/shared/audit"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplSettingsAudit templates.TplName = "org/settings/audit"
+
+// SettingsAudit shows the audit events of the organization
+func SettingsAudit(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("audit.title")
+	ctx.Data["PageIsSettingsAudit"] = true
+
+	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
+		ctx.ServerError("RenderUserOrgHeader", err)
+		return
+	}
+
+	shared_audit.SetAuditEventsContext(ctx, audit_model.ScopeOrganization, ctx.Org.Organization.ID)
+	if ctx.Written() {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSettingsAudit)
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const tplSettingsActions templates.TplName = "org/settings/actions"
+
+// SettingsRequiredWorkflows shows the workflows which run on the pull requests of the repositories of the organization
+func SettingsRequiredWorkflows(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("actions.required_workflows")
+	ctx.Data["PageType"] = "required_workflows"
+	ctx.Data["PageIsSharedSettingsRequiredWorkflows"] = true
+
+	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
+		ctx.ServerError("RenderUserOrgHeader", err)
+		return
+	}
+
+	workflows, err := db.Find[actions_model.ActionRequiredWorkflow](ctx, actions_model.FindRequiredWorkflowsOptions{OwnerID: ctx.Org.Organization.ID})
+	if err != nil {
+		ctx.ServerError("FindRequiredWorkflows", err)
+		return
+	}
+	// the workflows which don't exist in the default branch of their repositories are never required
+	missing := make(map[int64]bool)
+	targetRepos := make(map[int64]string)
+	for _, w := range workflows {
+		if _, err := actions_service.GetRequiredWorkflowContent(ctx, w); err != nil {
+			if !errors.Is(err, util.ErrNotExist) {
+				ctx.ServerError("GetRequiredWorkflowContent", err)
+				return
+			}
+			missing[w.ID] = true
+		}
+		targetRepos[w.ID] = strings.Join(base.Int64sToStrings(w.RepoIDs), ",")
+	}
+	repos, err := repo_model.GetOrgRepositories(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetOrgRepositories", err)
+		return
+	}
+
+	ctx.Data["RequiredWorkflows"] = workflows
+	ctx.Data["MissingWorkflows"] = missing
+	ctx.Data["TargetRepos"] = targetRepos
+	ctx.Data["Repos"] = repos
+	ctx.HTML(http.StatusOK, tplSettingsActions)
+}
+
+func parseRequiredWorkflowTargetRepos(form *forms.ActionsRequiredWorkflowForm) []int64 {
+	if form.AllRepos || strings.TrimSpace(form.TargetRepos) == "" {
+		return nil
+	}
+	repoIDs, _ := base.StringsToInt64s(strings.Split(form.TargetRepos, ","))
+	return repoIDs
+}
+
+// SettingsRequiredWorkflowsPost adds a required workflow
+func SettingsRequiredWorkflowsPost(ctx *context.Context) {
+	if ctx.HasError() { // form binding validation error
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+	form := web.GetForm(ctx).(*forms.ActionsRequiredWorkflowForm)
+
+	w := &actions_model.ActionRequiredWorkflow{
+		OwnerID:    ctx.Org.Organization.ID,
+		RepoID:     form.RepoID,
+		WorkflowID: form.WorkflowID,
+		AllRepos:   form.AllRepos,
+		RepoIDs:    parseRequiredWorkflowTargetRepos(form),
+	}
+	if err := actions_service.CreateRequiredWorkflow(ctx, w); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrAlreadyExist) {
+			ctx.JSONError(err.Error())
+			return
+		}
+		ctx.ServerError("CreateRequiredWorkflow", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.required_workflows.creation.success", w.WorkflowID))
+	ctx.JSONRedirect(ctx.Org.OrgLink + "/settings/actions/required_workflows")
+}
+
+// SettingsRequiredWorkflowEditPost changes the repositories in which a workflow is required
+func SettingsRequiredWorkflowEditPost(ctx *context.Context) {
+	if ctx.HasError() { // form binding validation error
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+	form := web.GetForm(ctx).(*forms.ActionsRequiredWorkflowForm)
+
+	w, err := actions_model.GetRequiredWorkflowByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(nil)
+		} else {
+			ctx.ServerError("GetRequiredWorkflowByID", err)
+		}
+		return
+	}
+	w.AllRepos = form.AllRepos
+	w.RepoIDs = parseRequiredWorkflowTargetRepos(form)
+	if err := actions_service.UpdateRequiredWorkflowRepos(ctx, w); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(err.Error())
+			return
+		}
+		ctx.ServerError("UpdateRequiredWorkflowRepos", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.required_workflows.update.success", w.WorkflowID))
+	ctx.JSONRedirect(ctx.Org.OrgLink + "/settings/actions/required_workflows")
+}
+
+// SettingsRequiredWorkflowDelete deletes a required workflow
+func SettingsRequiredWorkflowDelete(ctx *context.Context) {
+	w, err := actions_model.GetRequiredWorkflowByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(nil)
+		} else {
+			ctx.ServerError("GetRequiredWorkflowByID", err)
+		}
+		return
+	}
+	if err := actions_model.DeleteRequiredWorkflow(ctx, w); err != nil {
+		ctx.ServerError("DeleteRequiredWorkflow", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.required_workflows.deletion.success", w.WorkflowID))
+	ctx.JSONRedirect(ctx.Org.OrgLink + "/settings/actions/required_workflows")
+}
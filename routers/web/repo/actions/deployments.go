@@ -0,0 +1,134 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const tplDeployments templates.TplName = "repo/actions/deployments"
+
+// Deployments shows the deployments history of the repository, it can be filtered by environment
+func Deployments(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("actions.deployments")
+	ctx.Data["PageIsActions"] = true
+
+	envs, err := db.Find[actions_model.ActionEnvironment](ctx, actions_model.FindEnvironmentsOptions{RepoID: ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.ServerError("FindEnvironments", err)
+		return
+	}
+	ctx.Data["Environments"] = envs
+
+	page := max(ctx.FormInt("page"), 1)
+	opts := actions_model.FindDeploymentsOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.IssuePagingNum,
+		},
+		RepoID:        ctx.Repo.Repository.ID,
+		EnvironmentID: ctx.FormInt64("environment"),
+	}
+	ctx.Data["CurEnvironment"] = opts.EnvironmentID
+
+	deployments, total, err := db.FindAndCount[actions_model.ActionDeployment](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAndCount", err)
+		return
+	}
+	if err := actions_model.DeploymentList(deployments).LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	ctx.Data["Deployments"] = deployments
+
+	pager := context.NewPagination(int(total), opts.PageSize, opts.Page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplDeployments)
+}
+
+// setPendingDeploymentsData shows the deployments of the run which wait for a review or for the wait timer
+func setPendingDeploymentsData(ctx *context.Context, jobs []*actions_model.ActionRunJob) {
+	deployments := make(actions_model.DeploymentList, 0)
+	for _, job := range jobs {
+		if !job.Status.IsPendingDeployment() {
+			continue
+		}
+		deployment, err := actions_model.GetLatestDeploymentOfJob(ctx, job.ID)
+		if errors.Is(err, util.ErrNotExist) {
+			continue
+		} else if err != nil {
+			ctx.ServerError("GetLatestDeploymentOfJob", err)
+			return
+		}
+		deployments = append(deployments, deployment)
+	}
+	if err := deployments.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+
+	canReview := make(map[int64]bool, len(deployments))
+	for _, d := range deployments {
+		canReview[d.ID] = ctx.Doer != nil && d.Environment != nil && d.Environment.IsRequiredReviewer(ctx.Doer.ID)
+	}
+	ctx.Data["PendingDeployments"] = deployments
+	ctx.Data["CanReviewDeployment"] = canReview
+}
+
+// ReviewDeployment approves or rejects a deployment of a run waiting for a review
+func ReviewDeployment(ctx *context.Context) {
+	if ctx.HasError() {
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+	form := web.GetForm(ctx).(*forms.ReviewDeploymentForm)
+
+	run, err := actions_model.GetRunByIndex(ctx, ctx.Repo.Repository.ID, getRunIndex(ctx))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetRunByIndex", func(err error) bool {
+			return errors.Is(err, util.ErrNotExist)
+		}, err)
+		return
+	}
+	deployment, err := actions_model.GetDeploymentByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("deployment_id"))
+	if err == nil && deployment.RunID != run.ID {
+		err = util.NewNotExistErrorf("deployment %d doesn't belong to run %d", deployment.ID, run.ID)
+	}
+	if err != nil {
+		ctx.NotFoundOrServerError("GetDeploymentByID", func(err error) bool {
+			return errors.Is(err, util.ErrNotExist)
+		}, err)
+		return
+	}
+
+	if form.Action == "approve" {
+		err = actions_service.ApproveDeployment(ctx, deployment, ctx.Doer, form.Comment)
+	} else {
+		err = actions_service.RejectDeployment(ctx, deployment, ctx.Doer, form.Comment)
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrPermissionDenied) || errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(err.Error())
+			return
+		}
+		ctx.ServerError("ReviewDeployment", err)
+		return
+	}
+	ctx.JSONRedirect(fmt.Sprintf("%s/actions/runs/%d", ctx.Repo.RepoLink, run.Index))
+}
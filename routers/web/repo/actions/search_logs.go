@@ -0,0 +1,49 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/modules/templates"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+)
+
+const (
+	tplSearchLogs templates.TplName = "repo/actions/search_logs"
+
+	// searchLogsLimit is the max number of lines shown by a search of the logs of a run
+	searchLogsLimit = 500
+)
+
+// SearchLogs searches the logs of all jobs of a run, the matched lines link to the lines in the logs of the jobs
+func SearchLogs(ctx *context.Context) {
+	ctx.Data["PageIsActions"] = true
+	runIndex := getRunIndex(ctx)
+
+	current, jobs := getRunJobs(ctx, runIndex, -1)
+	if ctx.Written() {
+		return
+	}
+	run := current.Run
+	ctx.Data["Title"] = ctx.Locale.TrString("actions.runs.search_logs") + " - " + run.Title
+	ctx.Data["Run"] = run
+	ctx.Data["RunLink"] = run.Link()
+
+	keyword := strings.TrimSpace(ctx.FormString("q"))
+	ctx.Data["Keyword"] = keyword
+	if keyword != "" {
+		matches, err := actions_service.SearchRunLogs(ctx, jobs, keyword, searchLogsLimit)
+		if err != nil {
+			ctx.ServerError("SearchRunLogs", err)
+			return
+		}
+		ctx.Data["Matches"] = matches
+		ctx.Data["IsLimitReached"] = len(matches) >= searchLogsLimit
+	}
+
+	ctx.HTML(http.StatusOK, tplSearchLogs)
+}
@@ -14,17 +14,20 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/renderhelper"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/actions"
 	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup/markdown"
 	"code.gitea.io/gitea/modules/storage"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/util"
@@ -32,11 +35,8 @@ import (
 	"code.gitea.io/gitea/routers/common"
 	actions_service "code.gitea.io/gitea/services/actions"
 	context_module "code.gitea.io/gitea/services/context"
-	notify_service "code.gitea.io/gitea/services/notify"
 
 	"github.com/nektos/act/pkg/model"
-	"gopkg.in/yaml.v3"
-	"xorm.io/builder"
 )
 
 func getRunIndex(ctx *context_module.Context) int64 {
@@ -57,7 +57,11 @@ func View(ctx *context_module.Context) {
 	ctx.Data["JobIndex"] = jobIndex
 	ctx.Data["ActionsURL"] = ctx.Repo.RepoLink + "/actions"
 
-	if getRunJobs(ctx, runIndex, jobIndex); ctx.Written() {
+	_, jobs := getRunJobs(ctx, runIndex, jobIndex)
+	if ctx.Written() {
+		return
+	}
+	if setPendingDeploymentsData(ctx, jobs); ctx.Written() {
 		return
 	}
 
@@ -131,9 +135,11 @@ type ViewResponse struct {
 			Commit            ViewCommit    `json:"commit"`
 		} `json:"run"`
 		CurrentJob struct {
-			Title  string         `json:"title"`
-			Detail string         `json:"detail"`
-			Steps  []*ViewJobStep `json:"steps"`
+			Title       string            `json:"title"`
+			Detail      string            `json:"detail"`
+			Steps       []*ViewJobStep    `json:"steps"`
+			SummaryHTML template.HTML     `json:"summaryHTML"` // the summaries written by the steps to $GITHUB_STEP_SUMMARY
+			Annotations []*ViewAnnotation `json:"annotations"`
 		} `json:"currentJob"`
 	} `json:"state"`
 	Logs struct {
@@ -147,6 +153,7 @@ type ViewJob struct {
 	Status   string `json:"status"`
 	CanRerun bool   `json:"canRerun"`
 	Duration string `json:"duration"`
+	Matrix   string `json:"matrix"` // the combination of the matrix of the job, like "os: ubuntu, version: 1.25"
 }
 
 type ViewCommit struct {
@@ -173,6 +180,16 @@ type ViewJobStep struct {
 	Status   string `json:"status"`
 }
 
+type ViewAnnotation struct {
+	Level     string `json:"level"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	Path      string `json:"path"`
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
+	Link      string `json:"link"` // the anchor of the log line of the command
+}
+
 type ViewStepLog struct {
 	Step    int                `json:"step"`
 	Cursor  int64              `json:"cursor"`
@@ -251,6 +268,7 @@ func ViewPost(ctx *context_module.Context) {
 			Status:   v.Status.String(),
 			CanRerun: resp.State.Run.CanRerun,
 			Duration: v.Duration().String(),
+			Matrix:   actions_service.FormatJobMatrix(v),
 		})
 	}
 
@@ -299,8 +317,9 @@ func ViewPost(ctx *context_module.Context) {
 	if run.NeedApproval {
 		resp.State.CurrentJob.Detail = ctx.Locale.TrString("actions.need_approval_desc")
 	}
-	resp.State.CurrentJob.Steps = make([]*ViewJobStep, 0) // marshal to '[]' instead fo 'null' in json
-	resp.Logs.StepsLog = make([]*ViewStepLog, 0)          // marshal to '[]' instead fo 'null' in json
+	resp.State.CurrentJob.Steps = make([]*ViewJobStep, 0)          // marshal to '[]' instead fo 'null' in json
+	resp.State.CurrentJob.Annotations = make([]*ViewAnnotation, 0) // marshal to '[]' instead fo 'null' in json
+	resp.Logs.StepsLog = make([]*ViewStepLog, 0)                   // marshal to '[]' instead fo 'null' in json
 	if task != nil {
 		steps, logs, err := convertToViewModel(ctx, req.LogCursors, task)
 		if err != nil {
@@ -309,6 +328,19 @@ func ViewPost(ctx *context_module.Context) {
 		}
 		resp.State.CurrentJob.Steps = append(resp.State.CurrentJob.Steps, steps...)
 		resp.Logs.StepsLog = append(resp.Logs.StepsLog, logs...)
+
+		annotations, err := convertToViewAnnotations(ctx, task)
+		if err != nil {
+			ctx.ServerError("convertToViewAnnotations", err)
+			return
+		}
+		resp.State.CurrentJob.Annotations = append(resp.State.CurrentJob.Annotations, annotations...)
+
+		resp.State.CurrentJob.SummaryHTML, err = renderJobSummary(ctx, task)
+		if err != nil {
+			ctx.ServerError("renderJobSummary", err)
+			return
+		}
 	}
 
 	ctx.JSON(http.StatusOK, resp)
@@ -396,158 +428,75 @@ func convertToViewModel(ctx *context_module.Context, cursors []LogCursor, task *
 	return viewJobs, logs, nil
 }
 
-// Rerun will rerun jobs in the given run
-// If jobIndexStr is a blank string, it means rerun all jobs
-func Rerun(ctx *context_module.Context) {
-	runIndex := getRunIndex(ctx)
-	jobIndexStr := ctx.PathParam("job")
-	var jobIndex int64
-	if jobIndexStr != "" {
-		jobIndex, _ = strconv.ParseInt(jobIndexStr, 10, 64)
-	}
-
-	run, err := actions_model.GetRunByIndex(ctx, ctx.Repo.Repository.ID, runIndex)
+func convertToViewAnnotations(ctx *context_module.Context, task *actions_model.ActionTask) ([]*ViewAnnotation, error) {
+	annotations, err := actions_model.FindTaskAnnotations(ctx, task.ID)
 	if err != nil {
-		ctx.ServerError("GetRunByIndex", err)
-		return
-	}
-
-	// rerun is not allowed if the run is not done
-	if !run.Status.IsDone() {
-		ctx.JSONError(ctx.Locale.Tr("actions.runs.not_done"))
-		return
-	}
-
-	// can not rerun job when workflow is disabled
-	cfgUnit := ctx.Repo.Repository.MustGetUnit(ctx, unit.TypeActions)
-	cfg := cfgUnit.ActionsConfig()
-	if cfg.IsWorkflowDisabled(run.WorkflowID) {
-		ctx.JSONError(ctx.Locale.Tr("actions.workflow.disabled"))
-		return
-	}
-
-	// reset run's start and stop time
-	run.PreviousDuration = run.Duration()
-	run.Started = 0
-	run.Stopped = 0
-	run.Status = actions_model.StatusWaiting
-
-	vars, err := actions_model.GetVariablesOfRun(ctx, run)
-	if err != nil {
-		ctx.ServerError("GetVariablesOfRun", fmt.Errorf("get run %d variables: %w", run.ID, err))
-		return
+		return nil, err
 	}
-
-	if run.RawConcurrency != "" {
-		var rawConcurrency model.RawConcurrency
-		if err := yaml.Unmarshal([]byte(run.RawConcurrency), &rawConcurrency); err != nil {
-			ctx.ServerError("UnmarshalRawConcurrency", fmt.Errorf("unmarshal raw concurrency: %w", err))
-			return
+	steps := actions.FullSteps(task)
+	viewAnnotations := make([]*ViewAnnotation, 0, len(annotations))
+	for _, a := range annotations {
+		v := &ViewAnnotation{
+			Level:     a.Level.String(),
+			Title:     a.Title,
+			Message:   a.Message,
+			Path:      a.Path,
+			StartLine: a.StartLine,
+			EndLine:   a.EndLine,
 		}
-
-		err = actions_service.EvaluateRunConcurrencyFillModel(ctx, run, &rawConcurrency, vars)
-		if err != nil {
-			ctx.ServerError("EvaluateRunConcurrencyFillModel", err)
-			return
+		for i, step := range steps {
+			if a.LogIndex >= step.LogIndex && a.LogIndex < step.LogIndex+step.LogLength {
+				v.Link = fmt.Sprintf("#jobstep-%d-%d", i, a.LogIndex-step.LogIndex+1)
+				break
+			}
 		}
+		viewAnnotations = append(viewAnnotations, v)
+	}
+	return viewAnnotations, nil
+}
 
-		run.Status, err = actions_service.PrepareToStartRunWithConcurrency(ctx, run)
-		if err != nil {
-			ctx.ServerError("PrepareToStartRunWithConcurrency", err)
-			return
-		}
+func renderJobSummary(ctx *context_module.Context, task *actions_model.ActionTask) (template.HTML, error) {
+	summaries, err := actions_model.FindTaskSummaries(ctx, task.ID)
+	if err != nil || len(summaries) == 0 {
+		return "", err
 	}
-	if err := actions_model.UpdateRun(ctx, run, "started", "stopped", "previous_duration", "status", "concurrency_group", "concurrency_cancel"); err != nil {
-		ctx.ServerError("UpdateRun", err)
-		return
+	contents := make([]string, 0, len(summaries))
+	for _, s := range summaries {
+		contents = append(contents, s.Content)
 	}
+	return markdown.RenderString(renderhelper.NewRenderContextRepoComment(ctx, ctx.Repo.Repository), strings.Join(contents, "\n\n"))
+}
 
-	if err := run.LoadAttributes(ctx); err != nil {
-		ctx.ServerError("run.LoadAttributes", err)
-		return
+// Rerun will rerun jobs in the given run
+// If jobIndexStr is a blank string, it means rerun all jobs
+func Rerun(ctx *context_module.Context) {
+	runIndex := getRunIndex(ctx)
+	jobIndexStr := ctx.PathParam("job")
+	var jobIndex int64
+	if jobIndexStr != "" {
+		jobIndex, _ = strconv.ParseInt(jobIndexStr, 10, 64)
 	}
-	notify_service.WorkflowRunStatusUpdate(ctx, run.Repo, run.TriggerUser, run)
 
 	job, jobs := getRunJobs(ctx, runIndex, jobIndex)
 	if ctx.Written() {
 		return
 	}
 
-	isRunBlocked := run.Status == actions_model.StatusBlocked
+	var err error
 	if jobIndexStr == "" { // rerun all jobs
-		for _, j := range jobs {
-			// if the job has needs, it should be set to "blocked" status to wait for other jobs
-			shouldBlockJob := len(j.Needs) > 0 || isRunBlocked
-			if err := rerunJob(ctx, j, shouldBlockJob); err != nil {
-				ctx.ServerError("RerunJob", err)
-				return
-			}
-		}
-		ctx.JSONOK()
-		return
-	}
-
-	rerunJobs := actions_service.GetAllRerunJobs(job, jobs)
-
-	for _, j := range rerunJobs {
-		// jobs other than the specified one should be set to "blocked" status
-		shouldBlockJob := j.JobID != job.JobID || isRunBlocked
-		if err := rerunJob(ctx, j, shouldBlockJob); err != nil {
-			ctx.ServerError("RerunJob", err)
-			return
-		}
-	}
-
-	ctx.JSONOK()
-}
-
-func rerunJob(ctx *context_module.Context, job *actions_model.ActionRunJob, shouldBlock bool) error {
-	status := job.Status
-	if !status.IsDone() {
-		return nil
-	}
-
-	job.TaskID = 0
-	job.Status = util.Iif(shouldBlock, actions_model.StatusBlocked, actions_model.StatusWaiting)
-	job.Started = 0
-	job.Stopped = 0
-
-	job.ConcurrencyGroup = ""
-	job.ConcurrencyCancel = false
-	job.IsConcurrencyEvaluated = false
-	if err := job.LoadRun(ctx); err != nil {
-		return err
+		err = actions_service.RerunWorkflowRun(ctx, ctx.Repo.Repository, job.Run, jobs)
+	} else {
+		err = actions_service.RerunWorkflowRunJobs(ctx, ctx.Repo.Repository, job.Run, jobs, []*actions_model.ActionRunJob{job})
 	}
-
-	vars, err := actions_model.GetVariablesOfRun(ctx, job.Run)
 	if err != nil {
-		return fmt.Errorf("get run %d variables: %w", job.Run.ID, err)
-	}
-
-	if job.RawConcurrency != "" && !shouldBlock {
-		err = actions_service.EvaluateJobConcurrencyFillModel(ctx, job.Run, job, vars)
-		if err != nil {
-			return fmt.Errorf("evaluate job concurrency: %w", err)
-		}
-
-		job.Status, err = actions_service.PrepareToStartJobWithConcurrency(ctx, job)
-		if err != nil {
-			return err
+		if errTr := util.ErrorAsTranslatable(err); errTr != nil {
+			ctx.JSONError(errTr.Translate(ctx.Locale))
+		} else {
+			ctx.ServerError("RerunWorkflowRun", err)
 		}
+		return
 	}
-
-	if err := db.WithTx(ctx, func(ctx context.Context) error {
-		updateCols := []string{"task_id", "status", "started", "stopped", "concurrency_group", "concurrency_cancel", "is_concurrency_evaluated"}
-		_, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": status}, updateCols...)
-		return err
-	}); err != nil {
-		return err
-	}
-
-	actions_service.CreateCommitStatusForRunJobs(ctx, job.Run, job)
-	notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
-
-	return nil
+	ctx.JSONOK()
 }
 
 func Logs(ctx *context_module.Context) {
@@ -569,6 +518,23 @@ func Logs(ctx *context_module.Context) {
 	}
 }
 
+// RunLogs downloads the logs of all jobs of a run as a zip archive
+func RunLogs(ctx *context_module.Context) {
+	run, err := actions_model.GetRunByIndex(ctx, ctx.Repo.Repository.ID, getRunIndex(ctx))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetRunByIndex", func(err error) bool {
+			return errors.Is(err, util.ErrNotExist)
+		}, err)
+		return
+	}
+
+	if err = common.DownloadActionsRunLogs(ctx.Base, ctx.Repo.Repository, run); err != nil {
+		ctx.NotFoundOrServerError("DownloadActionsRunLogs", func(err error) bool {
+			return errors.Is(err, util.ErrNotExist)
+		}, err)
+	}
+}
+
 func Cancel(ctx *context_module.Context) {
 	runIndex := getRunIndex(ctx)
 
@@ -577,31 +543,10 @@ func Cancel(ctx *context_module.Context) {
 		return
 	}
 
-	var updatedJobs []*actions_model.ActionRunJob
-
-	if err := db.WithTx(ctx, func(ctx context.Context) error {
-		cancelledJobs, err := actions_model.CancelJobs(ctx, jobs)
-		if err != nil {
-			return fmt.Errorf("cancel jobs: %w", err)
-		}
-		updatedJobs = append(updatedJobs, cancelledJobs...)
-		return nil
-	}); err != nil {
-		ctx.ServerError("StopTask", err)
+	if err := actions_service.CancelRun(ctx, firstJob.Run, jobs); err != nil {
+		ctx.ServerError("CancelRun", err)
 		return
 	}
-
-	actions_service.CreateCommitStatusForRunJobs(ctx, firstJob.Run, jobs...)
-	actions_service.EmitJobsIfReadyByJobs(updatedJobs)
-
-	for _, job := range updatedJobs {
-		_ = job.LoadAttributes(ctx)
-		notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
-	}
-	if len(updatedJobs) > 0 {
-		job := updatedJobs[0]
-		actions_service.NotifyWorkflowRunStatusUpdateWithReload(ctx, job)
-	}
 	ctx.JSONOK()
 }
 
@@ -617,67 +562,10 @@ func Approve(ctx *context_module.Context) {
 }
 
 func approveRuns(ctx *context_module.Context, runIndexes []int64) {
-	doer := ctx.Doer
-	repo := ctx.Repo.Repository
-
-	updatedJobs := make([]*actions_model.ActionRunJob, 0)
-	runMap := make(map[int64]*actions_model.ActionRun, len(runIndexes))
-	runJobs := make(map[int64][]*actions_model.ActionRunJob, len(runIndexes))
-
-	err := db.WithTx(ctx, func(ctx context.Context) (err error) {
-		for _, runIndex := range runIndexes {
-			run, err := actions_model.GetRunByIndex(ctx, repo.ID, runIndex)
-			if err != nil {
-				return err
-			}
-			runMap[run.ID] = run
-			run.Repo = repo
-			run.NeedApproval = false
-			run.ApprovedBy = doer.ID
-			if err := actions_model.UpdateRun(ctx, run, "need_approval", "approved_by"); err != nil {
-				return err
-			}
-			jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
-			if err != nil {
-				return err
-			}
-			runJobs[run.ID] = jobs
-			for _, job := range jobs {
-				job.Status, err = actions_service.PrepareToStartJobWithConcurrency(ctx, job)
-				if err != nil {
-					return err
-				}
-				if job.Status == actions_model.StatusWaiting {
-					n, err := actions_model.UpdateRunJob(ctx, job, nil, "status")
-					if err != nil {
-						return err
-					}
-					if n > 0 {
-						updatedJobs = append(updatedJobs, job)
-					}
-				}
-			}
-		}
-		return nil
-	})
-	if err != nil {
-		ctx.ServerError("UpdateRunJob", err)
+	if err := actions_service.ApproveRuns(ctx, ctx.Repo.Repository, ctx.Doer, runIndexes); err != nil {
+		ctx.ServerError("ApproveRuns", err)
 		return
 	}
-
-	for runID, run := range runMap {
-		actions_service.CreateCommitStatusForRunJobs(ctx, run, runJobs[runID]...)
-	}
-
-	if len(updatedJobs) > 0 {
-		job := updatedJobs[0]
-		actions_service.NotifyWorkflowRunStatusUpdateWithReload(ctx, job)
-	}
-
-	for _, job := range updatedJobs {
-		_ = job.LoadAttributes(ctx)
-		notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
-	}
 }
 
 func Delete(ctx *context_module.Context) {
@@ -931,7 +819,7 @@ func Run(ctx *context_module.Context) {
 		ctx.ServerError("ref", nil)
 		return
 	}
-	err := actions_service.DispatchActionWorkflow(ctx, ctx.Doer, ctx.Repo.Repository, ctx.Repo.GitRepo, workflowID, ref, func(workflowDispatch *model.WorkflowDispatch, inputs map[string]any) error {
+	_, err := actions_service.DispatchActionWorkflow(ctx, ctx.Doer, ctx.Repo.Repository, ctx.Repo.GitRepo, workflowID, ref, func(workflowDispatch *model.WorkflowDispatch, inputs map[string]any) error {
 		for name, config := range workflowDispatch.Inputs {
 			value := ctx.Req.PostFormValue(name)
 			if config.Type == "boolean" {
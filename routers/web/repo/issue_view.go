@@ -389,6 +389,7 @@ func ViewIssue(ctx *context.Context) {
 		prepareIssueViewSidebarPin,
 		func(ctx *context.Context, issue *issues_model.Issue) { preparePullViewPullInfo(ctx, issue) },
 		preparePullViewReviewAndMerge,
+		preparePullViewStack,
 	}
 
 	for _, prepareFunc := range prepareFuncs {
@@ -511,6 +512,28 @@ func preparePullViewSigning(ctx *context.Context, issue *issues_model.Issue) {
 	}
 }
 
+// preparePullViewStack prepares the pull requests which are stacked with the pull request
+func preparePullViewStack(ctx *context.Context, issue *issues_model.Issue) {
+	if !issue.IsPull {
+		return
+	}
+	pull := issue.PullRequest
+	stack, err := pull_service.GetPullRequestStack(ctx, pull)
+	if err != nil {
+		ctx.ServerError("GetPullRequestStack", err)
+		return
+	}
+	if len(stack) == 0 {
+		return
+	}
+	for _, pr := range stack {
+		pr.Issue.Repo = ctx.Repo.Repository
+	}
+	ctx.Data["PullRequestStack"] = stack
+	// the stack can be merged up to this pull request if there is any pull request below it
+	ctx.Data["CanMergePullRequestStack"] = !pull.HasMerged && !issue.IsClosed && stack[0].ID != pull.ID
+}
+
 func prepareIssueViewSidebarWatch(ctx *context.Context, issue *issues_model.Issue) {
 	iw := new(issues_model.IssueWatch)
 	if ctx.Doer != nil {
@@ -953,8 +976,15 @@ func preparePullViewReviewAndMerge(ctx *context.Context, issue *issues_model.Iss
 		ctx.Data["IsBlockedByApprovals"] = !issues_model.HasEnoughApprovals(ctx, pb, pull)
 		ctx.Data["IsBlockedByRejection"] = issues_model.MergeBlockedByRejectedReview(ctx, pb, pull)
 		ctx.Data["IsBlockedByOfficialReviewRequests"] = issues_model.MergeBlockedByOfficialReviewRequests(ctx, pb, pull)
-		ctx.Data["IsBlockedByOutdatedBranch"] = issues_model.MergeBlockedByOutdatedBranch(pb, pull)
+		ctx.Data["IsBlockedByOutdatedBranch"] = !pb.IsMergeQueueEnabled() && issues_model.MergeBlockedByOutdatedBranch(pb, pull)
 		ctx.Data["GrantedApprovals"] = issues_model.GetGrantedApprovalsCount(ctx, pb, pull)
+		missingCodeOwnerApprovals, err := pull_service.GetMissingCodeOwnerApprovals(ctx, pb, pull)
+		if err != nil {
+			// don't break the pull request page, the merge is still blocked by the branch protection check
+			log.Error("GetMissingCodeOwnerApprovals: %v", err)
+		}
+		ctx.Data["MissingCodeOwnerApprovals"] = missingCodeOwnerApprovals
+		ctx.Data["IsBlockedByCodeOwners"] = len(missingCodeOwnerApprovals) != 0
 		ctx.Data["RequireSigned"] = pb.RequireSignedCommits
 		ctx.Data["ChangedProtectedFiles"] = pull.ChangedProtectedFiles
 		ctx.Data["IsBlockedByChangedProtectedFiles"] = len(pull.ChangedProtectedFiles) != 0
@@ -994,6 +1024,22 @@ func preparePullViewReviewAndMerge(ctx *context.Context, issue *issues_model.Iss
 		ctx.ServerError("GetScheduledMergeByPullID", err)
 		return
 	}
+
+	ctx.Data["IsMergeQueueRequired"] = pb != nil && pb.IsMergeQueueEnabled()
+	isInMergeQueue, mergeQueueEntry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pull.ID)
+	if err != nil {
+		ctx.ServerError("GetMergeQueueEntryByPullID", err)
+		return
+	}
+	ctx.Data["IsInMergeQueue"] = isInMergeQueue
+	if isInMergeQueue {
+		ctx.Data["MergeQueueEntry"] = mergeQueueEntry
+		ctx.Data["MergeQueuePosition"], err = pull_model.GetMergeQueuePosition(ctx, mergeQueueEntry)
+		if err != nil {
+			ctx.ServerError("GetMergeQueuePosition", err)
+			return
+		}
+	}
 }
 
 func prepareIssueViewContent(ctx *context.Context, issue *issues_model.Issue) {
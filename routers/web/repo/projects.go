@@ -24,6 +24,7 @@ import (
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/web/shared/issue"
+	shared_project "code.gitea.io/gitea/routers/web/shared/project"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
@@ -31,9 +32,12 @@ import (
 )
 
 const (
-	tplProjects     templates.TplName = "repo/projects/list"
-	tplProjectsNew  templates.TplName = "repo/projects/new"
-	tplProjectsView templates.TplName = "repo/projects/view"
+	tplProjects       templates.TplName = "repo/projects/list"
+	tplProjectsNew    templates.TplName = "repo/projects/new"
+	tplProjectsView   templates.TplName = "repo/projects/view"
+	tplProjectTable   templates.TplName = "repo/projects/table"
+	tplProjectRoadmap templates.TplName = "repo/projects/roadmap"
+	tplProjectFields  templates.TplName = "repo/projects/fields"
 )
 
 // MustEnableRepoProjects check if repo projects are enabled in settings
@@ -399,6 +403,19 @@ func ViewProject(ctx *context.Context) {
 	ctx.Data["Labels"] = labels
 	ctx.Data["NumLabels"] = len(labels)
 
+	columnAutomations, err := project.GetColumnAutomationsMap(ctx)
+	if err != nil {
+		ctx.ServerError("GetColumnAutomationsMap", err)
+		return
+	}
+	ctx.Data["ColumnAutomations"] = columnAutomations
+	ctx.Data["AutomationEvents"] = project_model.AutomationEvents
+
+	shared_project.PrepareFieldsData(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
 	// Get assignees.
 	assigneeUsers, err := repo_model.GetRepoAssignees(ctx, ctx.Repo.Repository)
 	if err != nil {
@@ -425,6 +442,47 @@ func ViewProject(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplProjectsView)
 }
 
+// ViewProjectTable renders the issues of a project as a table
+func ViewProjectTable(ctx *context.Context) {
+	viewProjectIssues(ctx, shared_project.PrepareTableContext, tplProjectTable)
+}
+
+// ViewProjectRoadmap renders the issues of a project on a timeline
+func ViewProjectRoadmap(ctx *context.Context) {
+	viewProjectIssues(ctx, shared_project.PrepareRoadmapContext, tplProjectRoadmap)
+}
+
+func viewProjectIssues(ctx *context.Context, prepare func(*context.Context, *project_model.Project, *issues_model.IssuesOptions), tpl templates.TplName) {
+	project := shared_project.GetProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	if project.RepoID != ctx.Repo.Repository.ID {
+		ctx.NotFound(nil)
+		return
+	}
+
+	prepare(ctx, project, &issues_model.IssuesOptions{
+		RepoIDs: []int64{ctx.Repo.Repository.ID},
+	})
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["CanWriteProjects"] = ctx.Repo.Permission.CanWrite(unit.TypeProjects)
+
+	ctx.HTML(http.StatusOK, tpl)
+}
+
+// ProjectFields renders the custom fields of a project
+func ProjectFields(ctx *context.Context) {
+	shared_project.PrepareFieldsContext(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplProjectFields)
+}
+
 // UpdateIssueProject change an issue's project
 func UpdateIssueProject(ctx *context.Context) {
 	issues := getActionIssues(ctx)
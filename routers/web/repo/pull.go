@@ -47,6 +47,7 @@ import (
 	"code.gitea.io/gitea/services/forms"
 	git_service "code.gitea.io/gitea/services/git"
 	"code.gitea.io/gitea/services/gitdiff"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -346,7 +347,6 @@ func prepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git_s
 		ctx.ServerError("LoadProtectedBranch", err)
 		return nil
 	}
-	ctx.Data["EnableStatusCheck"] = pb != nil && pb.EnableStatusCheck
 
 	var baseGitRepo *git.Repository
 	if pull.BaseRepoID == ctx.Repo.Repository.ID && ctx.Repo.GitRepo != nil {
@@ -467,6 +467,14 @@ func prepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git_s
 		return nil
 	}
 
+	enableStatusCheck, requiredContexts, err := pull_service.GetRequiredStatusChecks(ctx, repo, pb, sha)
+	if err != nil {
+		ctx.ServerError("GetRequiredStatusChecks", err)
+		return nil
+	}
+	// with a merge queue the required status checks are verified against the merge group commit instead of the head commit
+	ctx.Data["EnableStatusCheck"] = enableStatusCheck && (pb == nil || !pb.IsMergeQueueEnabled())
+
 	statusCheckData := &pullCommitStatusCheckData{
 		ApproveLink: fmt.Sprintf("%s/actions/approve-all-checks?commit_id=%s", repo.Link(), sha),
 	}
@@ -500,9 +508,9 @@ func prepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git_s
 		ctx.Data["LatestCommitStatus"] = git_model.CalcCommitStatus(commitStatuses)
 	}
 
-	if pb != nil && pb.EnableStatusCheck {
+	if enableStatusCheck {
 		var missingRequiredChecks []string
-		for _, requiredContext := range pb.StatusCheckContexts {
+		for _, requiredContext := range requiredContexts {
 			contextFound := false
 			matchesRequiredContext := createRequiredContextMatcher(requiredContext)
 			for _, presentStatus := range commitStatuses {
@@ -519,7 +527,7 @@ func prepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git_s
 		statusCheckData.MissingRequiredChecks = missingRequiredChecks
 
 		statusCheckData.IsContextRequired = func(context string) bool {
-			for _, c := range pb.StatusCheckContexts {
+			for _, c := range requiredContexts {
 				if c == context {
 					return true
 				}
@@ -534,7 +542,7 @@ func prepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git_s
 			}
 			return false
 		}
-		ctx.Data["RequiredStatusCheckState"] = pull_service.MergeRequiredContextsCommitStatus(commitStatuses, pb.StatusCheckContexts)
+		ctx.Data["RequiredStatusCheckState"] = pull_service.MergeRequiredContextsCommitStatus(commitStatuses, requiredContexts)
 	}
 
 	ctx.Data["HeadBranchMovedOn"] = headBranchSha != sha
@@ -814,6 +822,13 @@ func viewPullFiles(ctx *context.Context, beforeCommitID, afterCommitID string) {
 		return
 	}
 
+	if setting.Actions.Enabled && ctx.Repo.CanRead(unit.TypeActions) {
+		if err = diff.LoadActionsAnnotations(ctx, ctx.Repo.Repository.ID, afterCommitID); err != nil {
+			ctx.ServerError("LoadActionsAnnotations", err)
+			return
+		}
+	}
+
 	allComments := issues_model.CommentList{}
 	for _, file := range diff.Files {
 		for _, section := range file.Sections {
@@ -1141,6 +1156,34 @@ func MergePullRequest(ctx *context.Context) {
 	// just use the user's choice, don't use pull_service.ShouldDeleteBranchAfterMerge to decide
 	deleteBranchAfterMerge := optional.FromPtr(form.DeleteBranchAfterMerge).Value()
 
+	if mergeQueueRequired, err := pull_service.IsMergeQueueRequired(ctx, pr); err != nil {
+		ctx.ServerError("IsMergeQueueRequired", err)
+		return
+	} else if mergeQueueRequired {
+		// the base branch is only updated by the merge queue, so there is nothing a force merge could bypass
+		if form.ForceMerge {
+			ctx.JSONError(ctx.Tr("repo.pulls.merge_queue_no_force_merge"))
+			return
+		}
+		// the merge queue takes over the status checks, so a scheduled auto merge is not needed anymore
+		_ = pull_model.DeleteScheduledAutoMerge(ctx, pr.ID)
+		if err := mergequeue.AddToMergeQueue(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message, deleteBranchAfterMerge); err != nil {
+			if pull_model.IsErrAlreadyInMergeQueue(err) {
+				ctx.JSONError(ctx.Tr("repo.pulls.merge_queue_already_queued"))
+				return
+			}
+			if pull_service.IsErrInvalidMergeStyle(err) {
+				ctx.JSONError(ctx.Tr("repo.pulls.invalid_merge_option"))
+				return
+			}
+			ctx.ServerError("AddToMergeQueue", err)
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue_added"))
+		ctx.JSONRedirect(issue.Link())
+		return
+	}
+
 	if form.MergeWhenChecksSucceed {
 		// delete all scheduled auto merges
 		_ = pull_model.DeleteScheduledAutoMerge(ctx, pr.ID)
@@ -1278,6 +1321,56 @@ func CancelAutoMergePullRequest(ctx *context.Context) {
 	ctx.Redirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, issue.Index))
 }
 
+// RemoveFromMergeQueue removes a pr from the merge queue of its base branch
+func RemoveFromMergeQueue(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+
+	if err := mergequeue.RemoveFromMergeQueue(ctx, ctx.Doer, issue.PullRequest); err != nil {
+		if errors.Is(err, util.ErrPermissionDenied) {
+			ctx.HTTPError(http.StatusForbidden)
+			return
+		}
+		if db.IsErrNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("repo.pulls.merge_queue_not_queued"))
+			ctx.Redirect(issue.Link())
+			return
+		}
+		ctx.ServerError("RemoveFromMergeQueue", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue_removed"))
+	ctx.Redirect(issue.Link())
+}
+
+// MergePullRequestStack merges a pr and all prs below it in its stack
+func MergePullRequestStack(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+	pr := issue.PullRequest
+
+	mergeStyle := repo_model.MergeStyle(ctx.FormString("do"))
+	merged, err := pull_service.MergeStack(ctx, ctx.Doer, &ctx.Repo.Permission, pr, mergeStyle)
+	if err != nil {
+		var errNotMergeable pull_service.ErrStackedPullNotMergeable
+		if !errors.As(err, &errNotMergeable) {
+			ctx.ServerError("MergeStack", err)
+			return
+		}
+		log.Debug("MergeStack: %v", err)
+		ctx.Flash.Error(ctx.Tr("repo.pulls.merge_stack_failed", merged, errNotMergeable.Index))
+		ctx.Redirect(issue.Link())
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.pulls.merge_stack_success", merged))
+	ctx.Redirect(issue.Link())
+}
+
 func stopTimerIfAvailable(ctx *context.Context, user *user_model.User, issue *issues_model.Issue) error {
 	_, err := issues_model.FinishIssueStopwatch(ctx, user, issue)
 	return err
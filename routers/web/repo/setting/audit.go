@@ -0,0 +1,27 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/modules/templates"
+	shared_audit "code.gitea.io/gitea/routers/web/shared/audit"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplAudit templates.TplName = "repo/settings/audit"
+
+// Audit shows the audit events of the repository
+func Audit(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("audit.title")
+	ctx.Data["PageIsSettingsAudit"] = true
+
+	shared_audit.SetAuditEventsContext(ctx, audit_model.ScopeRepository, ctx.Repo.Repository.ID)
+	if ctx.Written() {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplAudit)
+}
@@ -0,0 +1,323 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	secret_model "code.gitea.io/gitea/models/secret"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	secret_service "code.gitea.io/gitea/services/secrets"
+)
+
+func environmentsLink(ctx *context.Context) string {
+	return ctx.Repo.RepoLink + "/settings/actions/environments"
+}
+
+func environmentLink(ctx *context.Context, env *actions_model.ActionEnvironment) string {
+	return fmt.Sprintf("%s/%d", environmentsLink(ctx), env.ID)
+}
+
+// Environments lists the deployment environments of the repository
+func Environments(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("actions.environments")
+	ctx.Data["PageType"] = "environments"
+	ctx.Data["PageIsActionsSettingsEnvironments"] = true
+
+	envs, err := db.Find[actions_model.ActionEnvironment](ctx, actions_model.FindEnvironmentsOptions{RepoID: ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.ServerError("FindEnvironments", err)
+		return
+	}
+	ctx.Data["Environments"] = envs
+	ctx.Data["EnvironmentNameMaxLength"] = actions_model.EnvironmentNameMaxLength
+	ctx.HTML(http.StatusOK, tplRepoSecrets)
+}
+
+// EnvironmentCreate creates an environment without protection rules and redirects to its settings
+func EnvironmentCreate(ctx *context.Context) {
+	if ctx.HasError() {
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+	form := web.GetForm(ctx).(*forms.ActionsEnvironmentForm)
+
+	env := &actions_model.ActionEnvironment{RepoID: ctx.Repo.Repository.ID, Name: form.Name}
+	if err := actions_model.CreateEnvironment(ctx, env); err != nil {
+		handleEnvironmentError(ctx, "CreateEnvironment", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.environments.creation.success", env.Name))
+	ctx.JSONRedirect(environmentLink(ctx, env))
+}
+
+func getEnvironment(ctx *context.Context) *actions_model.ActionEnvironment {
+	env, err := actions_model.GetEnvironmentByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("environment_id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(nil)
+		} else {
+			ctx.ServerError("GetEnvironmentByID", err)
+		}
+		return nil
+	}
+	return env
+}
+
+func handleEnvironmentError(ctx *context.Context, action string, err error) {
+	switch {
+	case errors.Is(err, util.ErrAlreadyExist), errors.Is(err, util.ErrInvalidArgument):
+		ctx.JSONError(err.Error())
+	default:
+		ctx.ServerError(action, err)
+	}
+}
+
+// EnvironmentEdit shows the protection rules, the secrets and the variables of an environment
+func EnvironmentEdit(ctx *context.Context) {
+	env := getEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = env.Name
+	ctx.Data["PageType"] = "environment"
+	ctx.Data["PageIsActionsSettingsEnvironments"] = true
+	ctx.Data["Environment"] = env
+	ctx.Data["EnvironmentLink"] = environmentLink(ctx, env)
+	ctx.Data["EnvironmentNameMaxLength"] = actions_model.EnvironmentNameMaxLength
+	ctx.Data["EnvironmentWaitTimerMax"] = actions_model.EnvironmentWaitTimerMax
+
+	reviewers, err := user_model.GetUsersByIDs(ctx, env.RequiredReviewerIDs)
+	if err != nil {
+		ctx.ServerError("GetUsersByIDs", err)
+		return
+	}
+	reviewerNames := make([]string, 0, len(reviewers))
+	for _, u := range reviewers {
+		reviewerNames = append(reviewerNames, u.Name)
+	}
+	ctx.Data["ReviewerNames"] = strings.Join(reviewerNames, ", ")
+
+	secrets, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{RepoID: env.RepoID, EnvironmentID: env.ID})
+	if err != nil {
+		ctx.ServerError("FindSecrets", err)
+		return
+	}
+	ctx.Data["Secrets"] = secrets
+	ctx.Data["SecretDataMaxLength"] = secret_model.SecretDataMaxLength
+	ctx.Data["SecretDescriptionMaxLength"] = secret_model.SecretDescriptionMaxLength
+
+	variables, err := db.Find[actions_model.ActionVariable](ctx, actions_model.FindVariablesOpts{RepoID: env.RepoID, EnvironmentID: env.ID})
+	if err != nil {
+		ctx.ServerError("FindVariables", err)
+		return
+	}
+	ctx.Data["Variables"] = variables
+	ctx.Data["VariableDataMaxLength"] = actions_model.VariableDataMaxLength
+	ctx.Data["VariableDescriptionMaxLength"] = actions_model.VariableDescriptionMaxLength
+
+	ctx.HTML(http.StatusOK, tplRepoSecrets)
+}
+
+// EnvironmentEditPost updates the name and the protection rules of an environment
+func EnvironmentEditPost(ctx *context.Context) {
+	env := getEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() {
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+	form := web.GetForm(ctx).(*forms.ActionsEnvironmentForm)
+
+	reviewerIDs := make([]int64, 0)
+	for name := range strings.SplitSeq(form.Reviewers, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.JSONError(ctx.Tr("actions.environments.reviewer_not_found", name))
+			} else {
+				ctx.ServerError("GetUserByName", err)
+			}
+			return
+		}
+		// reviewers approve the deployments of the runs, so they must be able to manage the runs
+		perm, err := access_model.GetUserRepoPermission(ctx, ctx.Repo.Repository, u)
+		if err != nil {
+			ctx.ServerError("GetUserRepoPermission", err)
+			return
+		}
+		if !perm.CanWrite(unit.TypeActions) {
+			ctx.JSONError(ctx.Tr("actions.environments.reviewer_no_permission", u.Name))
+			return
+		}
+		reviewerIDs = append(reviewerIDs, u.ID)
+	}
+
+	env.Name = form.Name
+	env.RequiredReviewerIDs = reviewerIDs
+	env.WaitTimer = form.WaitTimer
+	env.BranchFilters = strings.Split(form.BranchFilters, "\n")
+	if err := actions_model.UpdateEnvironment(ctx, env); err != nil {
+		handleEnvironmentError(ctx, "UpdateEnvironment", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.environments.update.success"))
+	ctx.JSONRedirect(environmentLink(ctx, env))
+}
+
+// EnvironmentDelete deletes an environment with its secrets, variables and deployments history
+func EnvironmentDelete(ctx *context.Context) {
+	env := getEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_service.DeleteEnvironment(ctx, env); err != nil {
+		log.Error("DeleteEnvironment %d: %v", env.ID, err)
+		ctx.JSONError(ctx.Tr("actions.environments.deletion.failed"))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.environments.deletion.success"))
+	ctx.JSONRedirect(environmentsLink(ctx))
+}
+
+// EnvironmentSecretsPost creates or updates a secret of an environment
+func EnvironmentSecretsPost(ctx *context.Context) {
+	env := getEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() {
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+	form := web.GetForm(ctx).(*forms.AddSecretForm)
+
+	s, _, err := secret_service.CreateOrUpdateEnvironmentSecret(ctx, env, form.Name, util.ReserveLineBreakForTextarea(form.Data), form.Description)
+	if err != nil {
+		log.Error("CreateOrUpdateEnvironmentSecret failed: %v", err)
+		ctx.JSONError(ctx.Tr("secrets.save_failed"))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("secrets.save_success", s.Name))
+	ctx.JSONRedirect(environmentLink(ctx, env))
+}
+
+// EnvironmentSecretsDelete deletes a secret of an environment
+func EnvironmentSecretsDelete(ctx *context.Context) {
+	env := getEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	id := ctx.FormInt64("id")
+	if err := secret_service.DeleteEnvironmentSecretByID(ctx, env, id); err != nil {
+		log.Error("DeleteEnvironmentSecretByID(%d) failed: %v", id, err)
+		ctx.JSONError(ctx.Tr("secrets.deletion.failed"))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("secrets.deletion.success"))
+	ctx.JSONRedirect(environmentLink(ctx, env))
+}
+
+// EnvironmentVariableCreate creates a variable of an environment
+func EnvironmentVariableCreate(ctx *context.Context) {
+	env := getEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() { // form binding validation error
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+	form := web.GetForm(ctx).(*forms.EditVariableForm)
+
+	v, err := actions_service.CreateEnvironmentVariable(ctx, env, form.Name, form.Data, form.Description)
+	if err != nil {
+		log.Error("CreateEnvironmentVariable: %v", err)
+		ctx.JSONError(ctx.Tr("actions.variables.creation.failed"))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.variables.creation.success", v.Name))
+	ctx.JSONRedirect(environmentLink(ctx, env))
+}
+
+func findEnvironmentVariable(ctx *context.Context, env *actions_model.ActionEnvironment) *actions_model.ActionVariable {
+	got, err := actions_model.FindVariables(ctx, actions_model.FindVariablesOpts{
+		IDs:           []int64{ctx.PathParamInt64("variable_id")},
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		ctx.ServerError("FindVariables", err)
+		return nil
+	} else if len(got) == 0 {
+		ctx.NotFound(nil)
+		return nil
+	}
+	return got[0]
+}
+
+// EnvironmentVariableUpdate updates a variable of an environment
+func EnvironmentVariableUpdate(ctx *context.Context) {
+	env := getEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() { // form binding validation error
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+	variable := findEnvironmentVariable(ctx, env)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.EditVariableForm)
+	variable.Name = form.Name
+	variable.Data = form.Data
+	variable.Description = form.Description
+	if ok, err := actions_service.UpdateVariableNameData(ctx, variable, nil); err != nil || !ok {
+		log.Error("UpdateVariable: %v", err)
+		ctx.JSONError(ctx.Tr("actions.variables.update.failed"))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.variables.update.success"))
+	ctx.JSONRedirect(environmentLink(ctx, env))
+}
+
+// EnvironmentVariableDelete deletes a variable of an environment
+func EnvironmentVariableDelete(ctx *context.Context) {
+	env := getEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	variable := findEnvironmentVariable(ctx, env)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_service.DeleteVariableByID(ctx, variable.ID); err != nil {
+		log.Error("Delete variable [%d] failed: %v", variable.ID, err)
+		ctx.JSONError(ctx.Tr("actions.variables.deletion.failed"))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.variables.deletion.success"))
+	ctx.JSONRedirect(environmentLink(ctx, env))
+}
@@ -85,6 +85,8 @@ func Secrets(ctx *context.Context) {
 	if sCtx.IsRepo {
 		ctx.Data["DisableSSH"] = setting.SSH.Disabled
 	}
+	// org secrets can be restricted to some repositories of the org
+	ctx.Data["CanScopeToRepos"] = sCtx.IsOrg
 
 	shared.SetSecretsContext(ctx, sCtx.OwnerID, sCtx.RepoID)
 	if ctx.Written() {
@@ -0,0 +1,170 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/services/context"
+	terraform_service "code.gitea.io/gitea/services/terraform"
+)
+
+const (
+	tplTerraformStates        templates.TplName = "repo/settings/terraform_states"
+	tplTerraformStateVersions templates.TplName = "repo/settings/terraform_state_versions"
+)
+
+func terraformStatesLink(ctx *context.Context) string {
+	return ctx.Repo.RepoLink + "/settings/terraform"
+}
+
+// TerraformStates shows the Terraform states of the repository and their locks
+func TerraformStates(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.terraform")
+	ctx.Data["PageIsSettingsTerraform"] = true
+	ctx.Data["TerraformStatesLink"] = terraformStatesLink(ctx)
+	ctx.Data["TerraformBackendAddress"] = fmt.Sprintf("%sapi/v1/repos/%s/terraform/state/", setting.AppURL, ctx.Repo.Repository.FullName())
+
+	states, err := terraform_model.GetStatesByRepoID(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetStatesByRepoID", err)
+		return
+	}
+	for _, s := range states {
+		if err := s.LoadLocker(ctx); err != nil {
+			ctx.ServerError("LoadLocker", err)
+			return
+		}
+	}
+	ctx.Data["TerraformStates"] = states
+
+	ctx.HTML(http.StatusOK, tplTerraformStates)
+}
+
+func getTerraformState(ctx *context.Context) *terraform_model.State {
+	s, err := terraform_model.GetStateByName(ctx, ctx.Repo.Repository.ID, ctx.PathParam("name"))
+	if err != nil {
+		if errors.Is(err, terraform_model.ErrStateNotExist) {
+			ctx.NotFound(nil)
+		} else {
+			ctx.ServerError("GetStateByName", err)
+		}
+		return nil
+	}
+	return s
+}
+
+// TerraformStateVersions shows the version history of a Terraform state
+func TerraformStateVersions(ctx *context.Context) {
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := s.LoadLocker(ctx); err != nil {
+		ctx.ServerError("LoadLocker", err)
+		return
+	}
+
+	ctx.Data["Title"] = ctx.Tr("repo.settings.terraform")
+	ctx.Data["PageIsSettingsTerraform"] = true
+	ctx.Data["TerraformStatesLink"] = terraformStatesLink(ctx)
+	ctx.Data["TerraformState"] = s
+
+	page := max(ctx.FormInt("page"), 1)
+	versions, total, err := terraform_model.FindStateVersions(ctx, s.ID, db.ListOptions{
+		Page:     page,
+		PageSize: setting.UI.ExplorePagingNum,
+	})
+	if err != nil {
+		ctx.ServerError("FindStateVersions", err)
+		return
+	}
+	for _, sv := range versions {
+		if err := sv.LoadCreator(ctx); err != nil {
+			ctx.ServerError("LoadCreator", err)
+			return
+		}
+	}
+	ctx.Data["TerraformStateVersions"] = versions
+	ctx.Data["Total"] = total
+
+	pager := context.NewPagination(int(total), setting.UI.ExplorePagingNum, page, 5)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplTerraformStateVersions)
+}
+
+// TerraformStateVersionDownload downloads a version of a Terraform state
+func TerraformStateVersionDownload(ctx *context.Context) {
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	sv, err := terraform_model.GetStateVersion(ctx, s.ID, ctx.PathParamInt64("version"))
+	if err != nil {
+		if errors.Is(err, terraform_model.ErrStateVersionNotExist) {
+			ctx.NotFound(nil)
+		} else {
+			ctx.ServerError("GetStateVersion", err)
+		}
+		return
+	}
+
+	obj, err := terraform_service.OpenStateVersion(sv)
+	if err != nil {
+		ctx.ServerError("OpenStateVersion", err)
+		return
+	}
+	defer obj.Close()
+
+	ctx.ServeContent(obj, &context.ServeHeaderOptions{
+		ContentType:  "application/json",
+		Filename:     fmt.Sprintf("%s.%d.tfstate", s.Name, sv.Version),
+		LastModified: sv.CreatedUnix.AsLocalTime(),
+	})
+}
+
+// TerraformStateUnlock removes the lock of a Terraform state regardless of its holder
+func TerraformStateUnlock(ctx *context.Context) {
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := terraform_model.UnlockState(ctx, s, ""); err != nil && !errors.Is(err, terraform_model.ErrStateNotLocked) {
+		log.Error("UnlockState %d: %v", s.ID, err)
+		ctx.JSONError(ctx.Tr("repo.settings.terraform.unlock_failed"))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.settings.terraform.unlock_success", s.Name))
+	ctx.JSONRedirect(terraformStatesLink(ctx))
+}
+
+// TerraformStateDelete deletes a Terraform state and all of its versions
+func TerraformStateDelete(ctx *context.Context) {
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if s.IsLocked() {
+		ctx.JSONError(ctx.Tr("repo.settings.terraform.delete_locked"))
+		return
+	}
+	if err := terraform_service.DeleteState(ctx, s); err != nil {
+		log.Error("DeleteState %d: %v", s.ID, err)
+		ctx.JSONError(ctx.Tr("repo.settings.terraform.delete_failed"))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.settings.terraform.delete_success", s.Name))
+	ctx.JSONRedirect(terraformStatesLink(ctx))
+}
@@ -252,7 +252,7 @@ func createWebhook(ctx *context.Context, params webhookParams) {
 	if err := w.UpdateEvent(); err != nil {
 		ctx.ServerError("UpdateEvent", err)
 		return
-	} else if err := webhook.CreateWebhook(ctx, w); err != nil {
+	} else if err := webhook_service.CreateWebhook(ctx, ctx.Doer, w); err != nil {
 		ctx.ServerError("CreateWebhook", err)
 		return
 	}
@@ -304,7 +304,7 @@ func editWebhook(ctx *context.Context, params webhookParams) {
 	if err := w.UpdateEvent(); err != nil {
 		ctx.ServerError("UpdateEvent", err)
 		return
-	} else if err := webhook.UpdateWebhook(ctx, w); err != nil {
+	} else if err := webhook_service.UpdateWebhook(ctx, ctx.Doer, w); err != nil {
 		ctx.ServerError("UpdateWebhook", err)
 		return
 	}
@@ -730,7 +730,7 @@ func ReplayWebhook(ctx *context.Context) {
 
 // DeleteWebhook delete a webhook
 func DeleteWebhook(ctx *context.Context) {
-	if err := webhook.DeleteWebhookByRepoID(ctx, ctx.Repo.Repository.ID, ctx.FormInt64("id")); err != nil {
+	if err := webhook_service.DeleteWebhookByRepoID(ctx, ctx.Doer, ctx.Repo.Repository.ID, ctx.FormInt64("id")); err != nil {
 		ctx.Flash.Error("DeleteWebhookByRepoID: " + err.Error())
 	} else {
 		ctx.Flash.Success(ctx.Tr("repo.settings.webhook_deletion_success"))
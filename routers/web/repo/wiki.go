@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/renderhelper"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
@@ -20,6 +21,8 @@ import (
 	"code.gitea.io/gitea/modules/charset"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/indexer"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/markup"
 	"code.gitea.io/gitea/modules/markup/markdown"
@@ -43,6 +46,7 @@ const (
 	tplWikiRevision templates.TplName = "repo/wiki/revision"
 	tplWikiNew      templates.TplName = "repo/wiki/new"
 	tplWikiPages    templates.TplName = "repo/wiki/pages"
+	tplWikiSearch   templates.TplName = "repo/wiki/search"
 )
 
 // MustEnableWiki check if wiki is enabled, if external then redirect
@@ -456,6 +460,9 @@ func Wiki(ctx *context.Context) {
 	case "_pages":
 		WikiPages(ctx)
 		return
+	case "_search":
+		WikiSearch(ctx)
+		return
 	case "_revision":
 		WikiRevision(ctx)
 		return
@@ -601,6 +608,49 @@ func WikiPages(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplWikiPages)
 }
 
+// WikiSearch renders the wiki pages matching the search keyword
+func WikiSearch(ctx *context.Context) {
+	if !repo_service.HasWiki(ctx, ctx.Repo.Repository) {
+		ctx.Redirect(ctx.Repo.RepoLink + "/wiki")
+		return
+	}
+
+	keyword := ctx.FormTrim("q")
+	searchMode := indexer.SearchModeType(ctx.FormTrim("search_mode"))
+	ctx.Data["Title"] = ctx.Tr("repo.wiki.search")
+	ctx.Data["CanWriteWiki"] = ctx.Repo.CanWrite(unit.TypeWiki) && !ctx.Repo.Repository.IsArchived
+	ctx.Data["Keyword"] = keyword
+	ctx.Data["SelectedSearchMode"] = string(searchMode)
+	ctx.Data["IsWikiIndexerEnabled"] = setting.Indexer.WikiIndexerEnabled
+	if setting.Indexer.WikiIndexerEnabled {
+		ctx.Data["SearchModes"] = wiki_indexer.SupportedSearchModes()
+	}
+	if keyword == "" {
+		ctx.HTML(http.StatusOK, tplWikiSearch)
+		return
+	}
+
+	page := max(ctx.FormInt("page"), 1)
+	results, total, err := wiki_service.SearchWikiPages(ctx, ctx.Repo.Repository, keyword, searchMode, db.ListOptions{
+		Page:     page,
+		PageSize: setting.UI.RepoSearchPagingNum,
+	})
+	if err != nil {
+		if !setting.Indexer.WikiIndexerEnabled || wiki_indexer.IsAvailable(ctx) {
+			ctx.ServerError("SearchWikiPages", err)
+			return
+		}
+		ctx.Data["WikiIndexerUnavailable"] = true
+	}
+	ctx.Data["SearchResults"] = results
+
+	pager := context.NewPagination(total, setting.UI.RepoSearchPagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplWikiSearch)
+}
+
 // WikiRaw outputs raw blob requested by user (image for example)
 func WikiRaw(ctx *context.Context) {
 	_, commit, err := findWikiRepoCommit(ctx)
@@ -0,0 +1,172 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/web"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const (
+	tplRepoApprovalPolicy templates.TplName = "repo/settings/actions"
+	tplOrgApprovalPolicy  templates.TplName = "org/settings/actions"
+)
+
+type approvalPolicyCtx struct {
+	OwnerID int64
+	RepoID  int64
+	IsRepo  bool
+	IsOrg   bool
+	// OrgID is the organization whose teams can be trusted, 0 if the owner is a user
+	OrgID                  int64
+	ApprovalPolicyTemplate templates.TplName
+	RedirectLink           string
+}
+
+func getApprovalPolicyCtx(ctx *context.Context) (*approvalPolicyCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		pCtx := &approvalPolicyCtx{
+			OwnerID:                0,
+			RepoID:                 ctx.Repo.Repository.ID,
+			IsRepo:                 true,
+			ApprovalPolicyTemplate: tplRepoApprovalPolicy,
+			RedirectLink:           ctx.Repo.RepoLink + "/settings/actions/approval_policy",
+		}
+		if ctx.Repo.Owner.IsOrganization() {
+			pCtx.OrgID = ctx.Repo.Owner.ID
+		}
+		return pCtx, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
+			ctx.ServerError("RenderUserOrgHeader", err)
+			return nil, nil
+		}
+		return &approvalPolicyCtx{
+			OwnerID:                ctx.ContextUser.ID,
+			RepoID:                 0,
+			IsOrg:                  true,
+			OrgID:                  ctx.ContextUser.ID,
+			ApprovalPolicyTemplate: tplOrgApprovalPolicy,
+			RedirectLink:           ctx.Org.OrgLink + "/settings/actions/approval_policy",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set ApprovalPolicy context")
+}
+
+// ApprovalPolicy shows the policy deciding which runs of fork pull requests need the approval of a maintainer
+func ApprovalPolicy(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("actions.approval_policy")
+	ctx.Data["PageType"] = "approval_policy"
+	ctx.Data["PageIsSharedSettingsApprovalPolicy"] = true
+
+	pCtx, err := getApprovalPolicyCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getApprovalPolicyCtx", err)
+		return
+	}
+	if ctx.Written() {
+		return
+	}
+
+	policy, err := actions_model.GetApprovalPolicy(ctx, pCtx.OwnerID, pCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetApprovalPolicy", err)
+		return
+	}
+	// the repositories of an organization inherit its policy unless they override it
+	canInherit := pCtx.IsRepo && pCtx.OrgID > 0
+	if canInherit && policy.ID == 0 {
+		if policy, err = actions_model.GetApprovalPolicy(ctx, pCtx.OrgID, 0); err != nil {
+			ctx.ServerError("GetApprovalPolicy", err)
+			return
+		}
+	}
+	ctx.Data["ApprovalPolicy"] = policy
+	ctx.Data["CanInheritApprovalPolicy"] = canInherit
+	ctx.Data["OverrideOwnerPolicy"] = canInherit && policy.RepoID > 0
+	ctx.Data["TrustedTeams"] = strings.Join(base.Int64sToStrings(policy.TrustedTeamIDs), ",")
+
+	if pCtx.OrgID > 0 {
+		teams, err := organization.FindOrgTeams(ctx, pCtx.OrgID)
+		if err != nil {
+			ctx.ServerError("FindOrgTeams", err)
+			return
+		}
+		ctx.Data["Teams"] = teams
+	}
+
+	ctx.HTML(http.StatusOK, pCtx.ApprovalPolicyTemplate)
+}
+
+// ApprovalPolicyPost saves the approval policy, or deletes the policy of a repository to inherit the policy of its organization
+func ApprovalPolicyPost(ctx *context.Context) {
+	pCtx, err := getApprovalPolicyCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getApprovalPolicyCtx", err)
+		return
+	}
+	if ctx.Written() {
+		return
+	}
+
+	if ctx.HasError() { // form binding validation error
+		ctx.JSONError(ctx.GetErrMsg())
+		return
+	}
+	form := web.GetForm(ctx).(*forms.ActionsApprovalPolicyForm)
+
+	if pCtx.IsRepo && pCtx.OrgID > 0 && !form.OverrideOwnerPolicy {
+		if err := actions_model.DeleteApprovalPolicy(ctx, 0, pCtx.RepoID); err != nil {
+			ctx.ServerError("DeleteApprovalPolicy", err)
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("actions.approval_policy.update.success"))
+		ctx.JSONRedirect(pCtx.RedirectLink)
+		return
+	}
+
+	var teamIDs []int64
+	if pCtx.OrgID > 0 && strings.TrimSpace(form.TrustedTeams) != "" {
+		teamIDs, _ = base.StringsToInt64s(strings.Split(form.TrustedTeams, ","))
+		teams, err := organization.GetTeamsByIDs(ctx, teamIDs)
+		if err != nil {
+			ctx.ServerError("GetTeamsByIDs", err)
+			return
+		}
+		for _, id := range teamIDs {
+			if team, ok := teams[id]; !ok || team.OrgID != pCtx.OrgID {
+				ctx.JSONError(ctx.Tr("actions.approval_policy.invalid_team"))
+				return
+			}
+		}
+	}
+
+	policy, err := actions_model.GetApprovalPolicy(ctx, pCtx.OwnerID, pCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetApprovalPolicy", err)
+		return
+	}
+	policy.TrustMergedPullRequests = form.TrustMergedPullRequests
+	policy.TrustedTeamIDs = teamIDs
+	policy.ApproveWorkflowChanges = form.ApproveWorkflowChanges
+	if err := actions_model.SaveApprovalPolicy(ctx, policy); err != nil {
+		ctx.ServerError("SaveApprovalPolicy", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.approval_policy.update.success"))
+	ctx.JSONRedirect(pCtx.RedirectLink)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+)
+
+const (
+	tplRepoCaches  templates.TplName = "repo/settings/actions"
+	tplAdminCaches templates.TplName = "admin/actions"
+)
+
+type cachesCtx struct {
+	RepoID         int64
+	IsRepo         bool
+	IsGlobal       bool
+	CachesTemplate templates.TplName
+	RedirectLink   string
+}
+
+func getCachesCtx(ctx *context.Context) (*cachesCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &cachesCtx{
+			RepoID:         ctx.Repo.Repository.ID,
+			IsRepo:         true,
+			CachesTemplate: tplRepoCaches,
+			RedirectLink:   ctx.Repo.RepoLink + "/settings/actions/caches",
+		}, nil
+	}
+
+	if ctx.Data["PageIsAdmin"] == true {
+		return &cachesCtx{
+			RepoID:         0,
+			IsGlobal:       true,
+			CachesTemplate: tplAdminCaches,
+			RedirectLink:   setting.AppSubURL + "/-/admin/actions/caches",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set Caches context")
+}
+
+// Caches lists the caches saved by actions/cache with the built-in cache server
+func Caches(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("actions.caches")
+	ctx.Data["PageType"] = "caches"
+	ctx.Data["PageIsSharedSettingsCaches"] = true
+
+	cCtx, err := getCachesCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getCachesCtx", err)
+		return
+	}
+
+	page := max(ctx.FormInt("page"), 1)
+	opts := actions_model.FindCachesOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.IssuePagingNum,
+		},
+		RepoID: cCtx.RepoID,
+	}
+	caches, total, err := db.FindAndCount[actions_model.ActionCache](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAndCount", err)
+		return
+	}
+	if cCtx.IsGlobal {
+		if err := actions_model.CacheList(caches).LoadRepos(ctx); err != nil {
+			ctx.ServerError("LoadRepos", err)
+			return
+		}
+	}
+	ctx.Data["Caches"] = caches
+	ctx.Data["ShowCacheRepo"] = cCtx.IsGlobal
+
+	size, err := actions_model.GetCachesSize(ctx, cCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetCachesSize", err)
+		return
+	}
+	ctx.Data["CachesSize"] = size
+	ctx.Data["CacheMaxSizePerRepo"] = setting.Actions.CacheMaxSizePerRepo
+	ctx.Data["CacheRetentionDays"] = setting.Actions.CacheRetentionDays
+
+	pager := context.NewPagination(int(total), opts.PageSize, opts.Page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, cCtx.CachesTemplate)
+}
+
+// CacheDelete deletes a cache, the runs won't be able to restore it anymore
+func CacheDelete(ctx *context.Context) {
+	cCtx, err := getCachesCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getCachesCtx", err)
+		return
+	}
+
+	c, err := actions_model.GetCacheByID(ctx, cCtx.RepoID, ctx.PathParamInt64("cache_id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetCacheByID", func(err error) bool {
+			return errors.Is(err, util.ErrNotExist)
+		}, err)
+		return
+	}
+	if err := actions_service.DeleteCache(ctx, c); err != nil {
+		log.Error("DeleteCache %d: %v", c.ID, err)
+		ctx.JSONError(ctx.Tr("actions.caches.deletion.failed"))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.caches.deletion.success"))
+	ctx.JSONRedirect(cCtx.RedirectLink)
+}
+
+// CachesPurge deletes all the caches of the repository, or of all repositories in the admin panel
+func CachesPurge(ctx *context.Context) {
+	cCtx, err := getCachesCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getCachesCtx", err)
+		return
+	}
+
+	if err := actions_service.PurgeCaches(ctx, cCtx.RepoID); err != nil {
+		log.Error("PurgeCaches: %v", err)
+		ctx.JSONError(ctx.Tr("actions.caches.purge.failed"))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("actions.caches.purge.success"))
+	ctx.JSONRedirect(cCtx.RedirectLink)
+}
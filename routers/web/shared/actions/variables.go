@@ -13,6 +13,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/web"
+	shared_secrets "code.gitea.io/gitea/routers/web/shared/secrets"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	actions_service "code.gitea.io/gitea/services/actions"
 	"code.gitea.io/gitea/services/context"
@@ -105,6 +106,14 @@ func Variables(ctx *context.Context) {
 		return
 	}
 	ctx.Data["Variables"] = variables
+	ctx.Data["CanScopeToRepos"] = vCtx.IsOrg
+	scopes := make([]*actions_model.RepoScope, 0, len(variables))
+	for _, v := range variables {
+		scopes = append(scopes, &v.RepoScope)
+	}
+	if !shared_secrets.SetScopeReposContext(ctx, scopes) {
+		return
+	}
 	ctx.Data["DataMaxLength"] = actions_model.VariableDataMaxLength
 	ctx.Data["DescriptionMaxLength"] = actions_model.VariableDescriptionMaxLength
 	ctx.HTML(http.StatusOK, vCtx.VariablesTemplate)
@@ -124,7 +133,12 @@ func VariableCreate(ctx *context.Context) {
 
 	form := web.GetForm(ctx).(*forms.EditVariableForm)
 
-	v, err := actions_service.CreateVariable(ctx, vCtx.OwnerID, vCtx.RepoID, form.Name, form.Data, form.Description)
+	scope, ok := shared_secrets.ParseRepoScope(ctx, vCtx.OwnerID, form.Visibility, form.SelectedRepos, form.SelectedEnvs)
+	if !ok {
+		return
+	}
+
+	v, err := actions_service.CreateVariable(ctx, vCtx.OwnerID, vCtx.RepoID, form.Name, form.Data, form.Description, scope)
 	if err != nil {
 		log.Error("CreateVariable: %v", err)
 		ctx.JSONError(ctx.Tr("actions.variables.creation.failed"))
@@ -159,7 +173,12 @@ func VariableUpdate(ctx *context.Context) {
 	variable.Data = form.Data
 	variable.Description = form.Description
 
-	if ok, err := actions_service.UpdateVariableNameData(ctx, variable); err != nil || !ok {
+	scope, ok := shared_secrets.ParseRepoScope(ctx, vCtx.OwnerID, form.Visibility, form.SelectedRepos, form.SelectedEnvs)
+	if !ok {
+		return
+	}
+
+	if ok, err := actions_service.UpdateVariableNameData(ctx, variable, scope); err != nil || !ok {
 		log.Error("UpdateVariable: %v", err)
 		ctx.JSONError(ctx.Tr("actions.variables.update.failed"))
 		return
@@ -0,0 +1,57 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"time"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ParseEventsFilter parses the action, since and until filters of the audit log from the request,
+// since and until are dates like "2006-01-02" and until includes the whole day
+func ParseEventsFilter(ctx *context.Base) (action string, since, until timeutil.TimeStamp) {
+	action = ctx.FormTrim("action")
+	if t, err := time.ParseInLocation(time.DateOnly, ctx.FormTrim("since"), setting.DefaultUILocation); err == nil {
+		since = timeutil.TimeStamp(t.Unix())
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, ctx.FormTrim("until"), setting.DefaultUILocation); err == nil {
+		until = timeutil.TimeStamp(t.AddDate(0, 0, 1).Unix() - 1)
+	}
+	return action, since, until
+}
+
+// SetAuditEventsContext loads a page of the audit events of the scope, the events of all scopes are loaded if scopeType is empty
+func SetAuditEventsContext(ctx *context.Context, scopeType audit_model.ScopeType, scopeID int64) {
+	page := max(ctx.FormInt("page"), 1)
+	opts := audit_model.FindEventsOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.Admin.NoticePagingNum,
+		},
+		ScopeType: scopeType,
+		ScopeID:   scopeID,
+	}
+	opts.Action, opts.Since, opts.Until = ParseEventsFilter(ctx.Base)
+
+	events, total, err := db.FindAndCount[audit_model.Event](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAuditEvents", err)
+		return
+	}
+
+	ctx.Data["AuditEvents"] = events
+	ctx.Data["AuditAction"] = opts.Action
+	ctx.Data["AuditSince"] = ctx.FormTrim("since")
+	ctx.Data["AuditUntil"] = ctx.FormTrim("until")
+	ctx.Data["Total"] = total
+
+	pager := context.NewPagination(int(total), opts.PageSize, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+}
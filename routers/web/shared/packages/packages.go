@@ -4,6 +4,7 @@
 package packages
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -13,9 +14,11 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	attestation_service "code.gitea.io/gitea/services/packages/attestation"
 	cargo_service "code.gitea.io/gitea/services/packages/cargo"
 	container_service "code.gitea.io/gitea/services/packages/container"
 )
@@ -28,6 +31,22 @@ func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
 	}
 
 	ctx.Data["CleanupRules"] = pcrs
+
+	pps, err := packages_model.GetProxiesByOwner(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetProxiesByOwner", err)
+		return
+	}
+
+	ctx.Data["Proxies"] = pps
+
+	ss, err := attestation_service.GetSigningSettings(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetSigningSettings", err)
+		return
+	}
+
+	ctx.Data["SigningSettings"] = ss
 }
 
 func SetRuleAddContext(ctx *context.Context) {
@@ -249,3 +268,22 @@ func RebuildCargoIndex(ctx *context.Context, owner *user_model.User) {
 		ctx.Flash.Success(ctx.Tr("packages.owner.settings.cargo.rebuild.success"))
 	}
 }
+
+func UpdateSigningSettings(ctx *context.Context, owner *user_model.User) {
+	form := web.GetForm(ctx).(*forms.PackageSigningForm)
+
+	err := attestation_service.SaveSigningSettings(ctx, owner.ID, &attestation_service.SigningSettings{
+		TrustedKeys: form.TrustedKeys,
+		Required:    form.Required,
+	})
+	switch {
+	case err == nil:
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.signing.success"))
+	case errors.Is(err, attestation_service.ErrNoTrustedKeys):
+		ctx.Flash.Error(ctx.Tr("packages.owner.settings.signing.error.no_keys"))
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.Flash.Error(ctx.Tr("packages.owner.settings.signing.error.invalid_keys"))
+	default:
+		ctx.ServerError("SaveSigningSettings", err)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"fmt"
+	"net/http"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+func SetProxyAddContext(ctx *context.Context) {
+	setProxyEditContext(ctx, nil)
+}
+
+func SetProxyEditContext(ctx *context.Context, owner *user_model.User) {
+	pp := getProxyByContext(ctx, owner)
+	if pp == nil {
+		return
+	}
+
+	setProxyEditContext(ctx, pp)
+}
+
+func setProxyEditContext(ctx *context.Context, pp *packages_model.PackageProxy) {
+	ctx.Data["IsEditProxy"] = pp != nil
+
+	if pp == nil {
+		pp = &packages_model.PackageProxy{}
+	}
+	ctx.Data["Proxy"] = pp
+	ctx.Data["AvailableProxyTypes"] = packages_model.ProxyTypeList
+}
+
+func PerformProxyAddPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	performProxyEditPost(ctx, owner, nil, redirectURL, template)
+}
+
+func PerformProxyEditPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	pp := getProxyByContext(ctx, owner)
+	if pp == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageProxyForm)
+
+	if form.Action == "remove" {
+		if err := packages_model.DeleteProxyByID(ctx, pp.ID); err != nil {
+			ctx.ServerError("DeleteProxyByID", err)
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.proxies.success.delete"))
+		ctx.Redirect(redirectURL)
+	} else {
+		performProxyEditPost(ctx, owner, pp, redirectURL, template)
+	}
+}
+
+func performProxyEditPost(ctx *context.Context, owner *user_model.User, pp *packages_model.PackageProxy, redirectURL string, template templates.TplName) {
+	isEditProxy := pp != nil
+
+	if pp == nil {
+		pp = &packages_model.PackageProxy{}
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageProxyForm)
+
+	pp.Enabled = form.Enabled
+	pp.OwnerID = owner.ID
+	pp.URL = form.URL
+	pp.Username = form.Username
+	pp.MetadataTTLMinutes = form.MetadataTTLMinutes
+	pp.CacheDays = form.CacheDays
+
+	ctx.Data["IsEditProxy"] = isEditProxy
+	ctx.Data["Proxy"] = pp
+	ctx.Data["AvailableProxyTypes"] = packages_model.ProxyTypeList
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, template)
+		return
+	}
+
+	// an empty password keeps the stored one as long as there is a username
+	if form.Password != "" || form.Username == "" {
+		if err := pp.SetPassword(form.Password); err != nil {
+			ctx.ServerError("SetPassword", err)
+			return
+		}
+	}
+
+	if isEditProxy {
+		if err := packages_model.UpdateProxy(ctx, pp); err != nil {
+			ctx.ServerError("UpdateProxy", err)
+			return
+		}
+	} else {
+		pp.Type = packages_model.Type(form.Type)
+
+		if has, err := packages_model.HasOwnerProxyForPackageType(ctx, owner.ID, pp.Type); err != nil {
+			ctx.ServerError("HasOwnerProxyForPackageType", err)
+			return
+		} else if has {
+			ctx.Data["Err_Type"] = true
+			ctx.HTML(http.StatusOK, template)
+			return
+		}
+
+		var err error
+		if pp, err = packages_model.InsertProxy(ctx, pp); err != nil {
+			ctx.ServerError("InsertProxy", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.proxies.success.update"))
+	ctx.Redirect(fmt.Sprintf("%s/proxies/%d", redirectURL, pp.ID))
+}
+
+func getProxyByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageProxy {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.PathParamInt64("id")
+	}
+
+	pp, err := packages_model.GetProxyByID(ctx, id)
+	if err != nil {
+		if err == packages_model.ErrPackageProxyNotExist {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetProxyByID", err)
+		}
+		return nil
+	}
+
+	if pp.OwnerID == owner.ID {
+		return pp
+	}
+
+	ctx.NotFound(fmt.Errorf("PackageProxy[%v] not associated to owner %v", id, owner))
+
+	return nil
+}
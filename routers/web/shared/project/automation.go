@@ -0,0 +1,88 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"errors"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+func getProjectColumn(ctx *context.Context) (*project_model.Project, *project_model.Column) {
+	project := GetProject(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+
+	column, err := project_model.GetColumn(ctx, ctx.PathParamInt64("columnID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetColumn", project_model.IsErrProjectColumnNotExist, err)
+		return nil, nil
+	}
+	if column.ProjectID != project.ID {
+		ctx.NotFound(nil)
+		return nil, nil
+	}
+	return project, column
+}
+
+// AddColumnAutomation adds an automation rule to a project column
+func AddColumnAutomation(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ProjectColumnAutomationForm)
+	project, column := getProjectColumn(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if form.LabelID > 0 {
+		label, err := issues_model.GetLabelByID(ctx, form.LabelID)
+		if err != nil {
+			ctx.NotFoundOrServerError("GetLabelByID", issues_model.IsErrLabelNotExist, err)
+			return
+		}
+		// the label must be usable by the issues of the project
+		if (project.RepoID > 0 && label.RepoID != project.RepoID && label.OrgID != ctx.ContextUser.ID) ||
+			(project.RepoID == 0 && label.OrgID != project.OwnerID) {
+			ctx.NotFound(nil)
+			return
+		}
+	}
+
+	if err := project_model.CreateColumnAutomation(ctx, &project_model.ColumnAutomation{
+		ProjectID: project.ID,
+		ColumnID:  column.ID,
+		Event:     project_model.AutomationEvent(form.Event),
+		LabelID:   form.LabelID,
+		AutoAdd:   form.AutoAdd,
+	}); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(err.Error())
+		} else {
+			ctx.ServerError("CreateColumnAutomation", err)
+		}
+		return
+	}
+
+	ctx.JSONOK()
+}
+
+// DeleteColumnAutomation removes an automation rule from a project column
+func DeleteColumnAutomation(ctx *context.Context) {
+	_, column := getProjectColumn(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := project_model.DeleteColumnAutomation(ctx, column.ID, ctx.PathParamInt64("ruleID")); err != nil {
+		ctx.ServerError("DeleteColumnAutomation", err)
+		return
+	}
+
+	ctx.JSONOK()
+}
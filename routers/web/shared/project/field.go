@@ -0,0 +1,234 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+// GetProject returns the project of the "id" path parameter if it can be accessed by the context user or repository
+func GetProject(ctx *context.Context) *project_model.Project {
+	project, err := project_model.GetProjectByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetProjectByID", project_model.IsErrProjectNotExist, err)
+		return nil
+	}
+	if !project.CanBeAccessedByOwnerRepo(ctx.ContextUser.ID, ctx.Repo.Repository) {
+		ctx.NotFound(nil)
+		return nil
+	}
+	return project
+}
+
+// PrepareFieldsContext prepares the data of the custom fields page of a project
+func PrepareFieldsContext(ctx *context.Context) {
+	project := GetProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	fields, err := project.GetFields(ctx)
+	if err != nil {
+		ctx.ServerError("GetFields", err)
+		return
+	}
+
+	ctx.Data["Title"] = project.Title
+	ctx.Data["IsProjectsPage"] = true
+	ctx.Data["Project"] = project
+	ctx.Data["ProjectLink"] = project.Link(ctx)
+	ctx.Data["ProjectFields"] = fields
+	ctx.Data["FieldTypes"] = project_model.FieldTypes
+	ctx.Data["ProjectView"] = "fields"
+	ctx.Data["CanWriteProjects"] = true
+}
+
+func parseFieldOptions(field *project_model.Field, form *forms.ProjectFieldForm, iterationTitle func(n int) string) error {
+	switch field.Type {
+	case project_model.FieldTypeSingleSelect:
+		options := make([]*project_model.FieldOption, 0, len(field.Options))
+		for name := range strings.SplitSeq(form.Options, "\n") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			// keep the ids of the existing options, so the values referring to them are kept
+			opt := &project_model.FieldOption{Name: name}
+			for _, old := range field.Options {
+				if old.Name == name {
+					opt.ID = old.ID
+				}
+			}
+			options = append(options, opt)
+		}
+		field.Options = options
+	case project_model.FieldTypeIteration:
+		if form.IterationCount <= 0 {
+			return nil
+		}
+		duration := max(form.IterationDuration, 1)
+		start, err := time.Parse(project_model.FieldDateLayout, form.IterationStart)
+		if err != nil {
+			if len(field.Iterations) == 0 {
+				return util.NewInvalidArgumentErrorf("the first iteration needs a start date")
+			}
+			// new iterations start after the last one
+			last := field.Iterations[len(field.Iterations)-1]
+			start, _ = time.Parse(project_model.FieldDateLayout, last.EndDate())
+			start = start.AddDate(0, 0, 1)
+		}
+		for i := range form.IterationCount {
+			field.Iterations = append(field.Iterations, &project_model.FieldIteration{
+				Title:     iterationTitle(len(field.Iterations) + 1),
+				StartDate: start.AddDate(0, 0, i*duration).Format(project_model.FieldDateLayout),
+				Duration:  duration,
+			})
+		}
+	}
+	return nil
+}
+
+func handleFieldError(ctx *context.Context, name string, err error) {
+	switch {
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.JSONError(ctx.Tr("repo.projects.field.name_exists", name))
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.JSONError(err.Error())
+	case errors.Is(err, util.ErrNotExist):
+		ctx.JSONErrorNotFound()
+	default:
+		ctx.ServerError("SaveProjectField", err)
+	}
+}
+
+func iterationTitleFunc(ctx *context.Context) func(n int) string {
+	return func(n int) string {
+		return ctx.Locale.TrString("repo.projects.field.iteration_title", n)
+	}
+}
+
+// NewFieldPost adds a custom field to a project
+func NewFieldPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ProjectFieldForm)
+	project := GetProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	field := &project_model.Field{
+		ProjectID: project.ID,
+		Name:      form.Name,
+		Type:      project_model.FieldType(form.Type),
+	}
+	if err := parseFieldOptions(field, form, iterationTitleFunc(ctx)); err != nil {
+		handleFieldError(ctx, form.Name, err)
+		return
+	}
+	if err := project_model.CreateField(ctx, field); err != nil {
+		handleFieldError(ctx, form.Name, err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.field.created", field.Name))
+	ctx.JSONRedirect(project.Link(ctx) + "/fields")
+}
+
+// EditFieldPost updates a custom field of a project
+func EditFieldPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ProjectFieldForm)
+	project := GetProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	field, err := project_model.GetFieldByID(ctx, project.ID, ctx.PathParamInt64("fieldID"))
+	if err != nil {
+		handleFieldError(ctx, form.Name, err)
+		return
+	}
+	field.Name = form.Name
+	if err := parseFieldOptions(field, form, iterationTitleFunc(ctx)); err != nil {
+		handleFieldError(ctx, form.Name, err)
+		return
+	}
+	if err := project_model.UpdateField(ctx, field); err != nil {
+		handleFieldError(ctx, form.Name, err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.field.updated", field.Name))
+	ctx.JSONRedirect(project.Link(ctx) + "/fields")
+}
+
+// DeleteField deletes a custom field of a project with all its values
+func DeleteField(ctx *context.Context) {
+	project := GetProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := project_model.DeleteFieldByID(ctx, project.ID, ctx.PathParamInt64("fieldID")); err != nil {
+		handleFieldError(ctx, "", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.field.deleted"))
+	ctx.JSONRedirect(project.Link(ctx) + "/fields")
+}
+
+// SetIssueFieldValues sets the custom field values of an issue of the project, the values are posted as "field_{id}"
+func SetIssueFieldValues(ctx *context.Context) {
+	project := GetProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	fields, err := project.GetFields(ctx)
+	if err != nil {
+		ctx.ServerError("GetFields", err)
+		return
+	}
+	if err := ctx.Req.ParseForm(); err != nil {
+		ctx.ServerError("ParseForm", err)
+		return
+	}
+
+	issueID := ctx.PathParamInt64("issueID")
+	for _, field := range fields {
+		key := fmt.Sprintf("field_%d", field.ID)
+		if !ctx.Req.Form.Has(key) {
+			continue
+		}
+		if err := project_model.SetFieldValue(ctx, field, issueID, ctx.Req.Form.Get(key)); err != nil {
+			handleFieldError(ctx, field.Name, err)
+			return
+		}
+	}
+
+	ctx.JSONOK()
+}
+
+// PrepareFieldsData loads the custom fields and their values of the project for the board view
+func PrepareFieldsData(ctx *context.Context, project *project_model.Project) {
+	fields, err := project.GetFields(ctx)
+	if err != nil {
+		ctx.ServerError("GetFields", err)
+		return
+	}
+	values, err := project_model.GetFieldValuesMap(ctx, project.ID)
+	if err != nil {
+		ctx.ServerError("GetFieldValuesMap", err)
+		return
+	}
+	ctx.Data["ProjectFields"] = fields
+	ctx.Data["FieldValues"] = values
+}
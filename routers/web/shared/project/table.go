@@ -0,0 +1,304 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	project_service "code.gitea.io/gitea/services/projects"
+)
+
+// tableQueryKeys are the query parameters of the table and roadmap views besides the "field_{id}" filters,
+// they are kept when a view is saved
+var tableQueryKeys = []string{"state", "assignee", "label", "milestone", "group_by", "sort", "direction", "start", "end"}
+
+// GroupByChoices are the built-in groupings of the table and roadmap views
+var GroupByChoices = []string{
+	project_service.GroupByColumn,
+	project_service.GroupByAssignee,
+	project_service.GroupByLabel,
+	project_service.GroupByMilestone,
+}
+
+type tableQuery struct {
+	opts    project_service.TableOptions
+	roadmap project_service.RoadmapOptions
+	state   string
+	groupBy string
+	values  url.Values
+}
+
+func findFieldByKey(fields []*project_model.Field, key string) *project_model.Field {
+	id, ok := strings.CutPrefix(key, "field_")
+	if !ok {
+		return nil
+	}
+	fieldID, _ := strconv.ParseInt(id, 10, 64)
+	for _, f := range fields {
+		if f.ID == fieldID {
+			return f
+		}
+	}
+	return nil
+}
+
+// parseTableQuery reads the filters, the grouping and the sorting from the query, the values of the result only keep the valid ones
+func parseTableQuery(ctx *context.Context, fields []*project_model.Field) *tableQuery {
+	q := &tableQuery{
+		opts:   project_service.TableOptions{FieldFilters: make(map[int64]string)},
+		values: url.Values{},
+	}
+
+	if state := ctx.FormString("state"); state == "open" || state == "closed" {
+		q.state = state
+		q.values.Set("state", state)
+	}
+	for key, id := range map[string]*int64{"assignee": &q.opts.AssigneeID, "label": &q.opts.LabelID, "milestone": &q.opts.MilestoneID} {
+		if *id = ctx.FormInt64(key); *id > 0 {
+			q.values.Set(key, strconv.FormatInt(*id, 10))
+		}
+	}
+
+	groupBy := ctx.FormString("group_by")
+	if slices.Contains(GroupByChoices, groupBy) || findFieldByKey(fields, groupBy) != nil {
+		q.groupBy = groupBy
+		q.values.Set("group_by", groupBy)
+	}
+	if f := findFieldByKey(fields, ctx.FormString("sort")); f != nil {
+		q.opts.SortFieldID = f.ID
+		q.values.Set("sort", ctx.FormString("sort"))
+		if ctx.FormString("direction") == "desc" {
+			q.opts.SortDesc = true
+			q.values.Set("direction", "desc")
+		}
+	}
+	for key, id := range map[string]*int64{"start": &q.roadmap.StartFieldID, "end": &q.roadmap.EndFieldID} {
+		if f := findFieldByKey(fields, ctx.FormString(key)); f != nil && (f.Type == project_model.FieldTypeDate || f.Type == project_model.FieldTypeIteration) {
+			*id = f.ID
+			q.values.Set(key, ctx.FormString(key))
+		}
+	}
+
+	for _, field := range fields {
+		key := fmt.Sprintf("field_%d", field.ID)
+		value, err := field.NormalizeValue(ctx.FormString(key))
+		if err != nil || value == "" {
+			continue
+		}
+		q.opts.FieldFilters[field.ID] = value
+		q.values.Set(key, value)
+	}
+	return q
+}
+
+// prepareTableData loads the rows of the table and roadmap views and the data shared by both
+func prepareTableData(ctx *context.Context, project *project_model.Project, issueOpts *issues_model.IssuesOptions, viewType project_model.ViewType) ([]*project_service.TableRow, []*project_model.Field, *tableQuery) {
+	columns, err := project.GetColumns(ctx)
+	if err != nil {
+		ctx.ServerError("GetProjectColumns", err)
+		return nil, nil, nil
+	}
+	fields, err := project.GetFields(ctx)
+	if err != nil {
+		ctx.ServerError("GetFields", err)
+		return nil, nil, nil
+	}
+	views, err := project.GetViews(ctx)
+	if err != nil {
+		ctx.ServerError("GetViews", err)
+		return nil, nil, nil
+	}
+
+	q := parseTableQuery(ctx, fields)
+	if q.state != "" {
+		issueOpts.IsClosed = optional.Some(q.state == "closed")
+	}
+	rows, err := project_service.LoadProjectTableRows(ctx, project, columns, issueOpts)
+	if err != nil {
+		ctx.ServerError("LoadProjectTableRows", err)
+		return nil, nil, nil
+	}
+	ctx.Data["TableChoices"] = project_service.CollectTableChoices(rows)
+	rows = project_service.FilterTableRows(rows, fields, q.opts)
+
+	ctx.Data["Title"] = project.Title
+	ctx.Data["IsProjectsPage"] = true
+	ctx.Data["Project"] = project
+	ctx.Data["ProjectLink"] = project.Link(ctx)
+	ctx.Data["ProjectFields"] = fields
+	ctx.Data["ProjectView"] = string(viewType)
+	ctx.Data["TableGroups"] = project_service.GroupTableRows(rows, fields, q.groupBy)
+	ctx.Data["GroupByChoices"] = GroupByChoices
+	ctx.Data["GroupBy"] = q.groupBy
+	ctx.Data["State"] = q.state
+	ctx.Data["AssigneeID"] = q.opts.AssigneeID
+	ctx.Data["LabelID"] = q.opts.LabelID
+	ctx.Data["MilestoneID"] = q.opts.MilestoneID
+	ctx.Data["FieldFilters"] = q.opts.FieldFilters
+	ctx.Data["SortFieldID"] = q.opts.SortFieldID
+	ctx.Data["SortDesc"] = q.opts.SortDesc
+	ctx.Data["TableQuery"] = q.values.Encode()
+
+	ctx.Data["ProjectViews"] = views
+	viewID := ctx.FormInt64("view")
+	for _, v := range views {
+		if v.ID == viewID && v.Type == viewType {
+			ctx.Data["CurrentView"] = v
+		}
+	}
+	return rows, fields, q
+}
+
+// PrepareTableContext prepares the data of the table view of a project.
+// The rows can be filtered by state, assignee, label, milestone and non-empty "field_{id}" query parameters,
+// grouped by "group_by" and sorted by "sort=field_{id}" and "direction=desc".
+func PrepareTableContext(ctx *context.Context, project *project_model.Project, issueOpts *issues_model.IssuesOptions) {
+	_, fields, q := prepareTableData(ctx, project, issueOpts, project_model.ViewTypeTable)
+	if ctx.Written() {
+		return
+	}
+
+	// clicking the header of the sorted field reverses the order
+	sortLinks := make(map[int64]string, len(fields))
+	for _, field := range fields {
+		values := maps.Clone(q.values)
+		values.Del("direction")
+		values.Set("sort", fmt.Sprintf("field_%d", field.ID))
+		if field.ID == q.opts.SortFieldID && !q.opts.SortDesc {
+			values.Set("direction", "desc")
+		}
+		if viewID := ctx.FormString("view"); viewID != "" {
+			values.Set("view", viewID)
+		}
+		sortLinks[field.ID] = "?" + values.Encode()
+	}
+	ctx.Data["FieldSortLinks"] = sortLinks
+}
+
+// PrepareRoadmapContext prepares the data of the roadmap view of a project, it accepts the query parameters of the table view.
+// The dates of the bars are read from the date or iteration fields of the "start" and "end" query parameters.
+func PrepareRoadmapContext(ctx *context.Context, project *project_model.Project, issueOpts *issues_model.IssuesOptions) {
+	rows, fields, q := prepareTableData(ctx, project, issueOpts, project_model.ViewTypeRoadmap)
+	if ctx.Written() {
+		return
+	}
+
+	dateFields := make([]*project_model.Field, 0, len(fields))
+	for _, f := range fields {
+		if f.Type == project_model.FieldTypeDate || f.Type == project_model.FieldTypeIteration {
+			dateFields = append(dateFields, f)
+		}
+	}
+	ctx.Data["DateFields"] = dateFields
+	ctx.Data["RoadmapStartFieldID"] = q.roadmap.StartFieldID
+	ctx.Data["RoadmapEndFieldID"] = q.roadmap.EndFieldID
+	ctx.Data["Roadmap"] = project_service.BuildRoadmap(rows, fields, q.roadmap, time.Now())
+}
+
+// sanitizeViewQuery only keeps the query parameters of the table and roadmap views
+func sanitizeViewQuery(query string) string {
+	values, _ := url.ParseQuery(query)
+	for key := range values {
+		if !slices.Contains(tableQueryKeys, key) && !strings.HasPrefix(key, "field_") {
+			values.Del(key)
+		}
+	}
+	return values.Encode()
+}
+
+func handleViewError(ctx *context.Context, name string, err error) {
+	switch {
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.JSONError(ctx.Tr("repo.projects.view.name_exists", name))
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.JSONError(err.Error())
+	case errors.Is(err, util.ErrNotExist):
+		ctx.JSONErrorNotFound()
+	default:
+		ctx.ServerError("SaveProjectView", err)
+	}
+}
+
+// NewViewPost saves the filters, the grouping and the sorting of the table or roadmap view of a project
+func NewViewPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ProjectViewForm)
+	project := GetProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	view := &project_model.View{
+		ProjectID: project.ID,
+		Name:      form.Name,
+		Type:      project_model.ViewType(form.Type),
+		Query:     sanitizeViewQuery(form.Query),
+		CreatorID: ctx.Doer.ID,
+	}
+	if err := project_model.CreateView(ctx, view); err != nil {
+		handleViewError(ctx, form.Name, err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.view.created", view.Name))
+	ctx.JSONRedirect(view.Link(project.Link(ctx)))
+}
+
+// EditViewPost updates the name and the query of a saved view of a project
+func EditViewPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ProjectViewForm)
+	project := GetProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	view, err := project_model.GetViewByID(ctx, project.ID, ctx.PathParamInt64("viewID"))
+	if err != nil {
+		handleViewError(ctx, form.Name, err)
+		return
+	}
+	view.Name = form.Name
+	view.Query = sanitizeViewQuery(form.Query)
+	if err := project_model.UpdateView(ctx, view); err != nil {
+		handleViewError(ctx, form.Name, err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.view.updated", view.Name))
+	ctx.JSONRedirect(view.Link(project.Link(ctx)))
+}
+
+// DeleteView deletes a saved view of a project
+func DeleteView(ctx *context.Context) {
+	project := GetProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	view, err := project_model.GetViewByID(ctx, project.ID, ctx.PathParamInt64("viewID"))
+	if err != nil {
+		handleViewError(ctx, "", err)
+		return
+	}
+	if err := project_model.DeleteViewByID(ctx, project.ID, view.ID); err != nil {
+		handleViewError(ctx, view.Name, err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.view.deleted", view.Name))
+	ctx.JSONRedirect(project.Link(ctx) + "/" + string(view.Type))
+}
@@ -4,7 +4,12 @@
 package secrets
 
 import (
+	"errors"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
 	secret_model "code.gitea.io/gitea/models/secret"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/util"
@@ -22,6 +27,13 @@ func SetSecretsContext(ctx *context.Context, ownerID, repoID int64) {
 	}
 
 	ctx.Data["Secrets"] = secrets
+	scopes := make([]*actions_model.RepoScope, 0, len(secrets))
+	for _, s := range secrets {
+		scopes = append(scopes, &s.RepoScope)
+	}
+	if !SetScopeReposContext(ctx, scopes) {
+		return
+	}
 	ctx.Data["DataMaxLength"] = secret_model.SecretDataMaxLength
 	ctx.Data["DescriptionMaxLength"] = secret_model.SecretDescriptionMaxLength
 }
@@ -29,7 +41,12 @@ func SetSecretsContext(ctx *context.Context, ownerID, repoID int64) {
 func PerformSecretsPost(ctx *context.Context, ownerID, repoID int64, redirectURL string) {
 	form := web.GetForm(ctx).(*forms.AddSecretForm)
 
-	s, _, err := secret_service.CreateOrUpdateSecret(ctx, ownerID, repoID, form.Name, util.ReserveLineBreakForTextarea(form.Data), form.Description)
+	scope, ok := ParseRepoScope(ctx, ownerID, form.Visibility, form.SelectedRepos, form.SelectedEnvs)
+	if !ok {
+		return
+	}
+
+	s, _, err := secret_service.CreateOrUpdateSecret(ctx, ownerID, repoID, form.Name, util.ReserveLineBreakForTextarea(form.Data), form.Description, scope)
 	if err != nil {
 		log.Error("CreateOrUpdateSecret failed: %v", err)
 		ctx.JSONError(ctx.Tr("secrets.save_failed"))
@@ -53,3 +70,54 @@ func PerformSecretsDelete(ctx *context.Context, ownerID, repoID int64, redirectU
 	ctx.Flash.Success(ctx.Tr("secrets.deletion.success"))
 	ctx.JSONRedirect(redirectURL)
 }
+
+// SetScopeReposContext loads the repositories selected by the org or user level secrets or variables
+func SetScopeReposContext(ctx *context.Context, scopes []*actions_model.RepoScope) bool {
+	var ids []int64
+	for _, scope := range scopes {
+		ids = append(ids, scope.SelectedRepoIDs...)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, ids)
+	if err != nil {
+		ctx.ServerError("GetRepositoriesMapByIDs", err)
+		return false
+	}
+	ctx.Data["ScopeRepos"] = repos
+	ctx.Data["ScopeVisibilities"] = []actions_model.ScopeVisibility{
+		actions_model.ScopeVisibilityAll,
+		actions_model.ScopeVisibilityPrivate,
+		actions_model.ScopeVisibilitySelected,
+	}
+	return true
+}
+
+// ParseRepoScope reads the visibility and the comma separated names of the selected repositories and environments
+// of a secret or variable form, the scope is nil if the form has no visibility
+func ParseRepoScope(ctx *context.Context, ownerID int64, visibility, repoNames, envNames string) (*actions_model.RepoScope, bool) {
+	if visibility == "" {
+		return nil, true
+	}
+	scope := &actions_model.RepoScope{
+		Visibility:           actions_model.ScopeVisibility(visibility),
+		SelectedEnvironments: strings.Split(envNames, ","),
+	}
+	if scope.Visibility != actions_model.ScopeVisibilitySelected {
+		return scope, true
+	}
+	for name := range strings.SplitSeq(repoNames, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		repo, err := repo_model.GetRepositoryByName(ctx, ownerID, name)
+		if err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				ctx.JSONError(ctx.Tr("secrets.scope.repo_not_found", name))
+			} else {
+				ctx.ServerError("GetRepositoryByName", err)
+			}
+			return nil, false
+		}
+		scope.SelectedRepoIDs = append(scope.SelectedRepoIDs, repo.ID)
+	}
+	return scope, true
+}
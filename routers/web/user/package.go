@@ -34,6 +34,7 @@ import (
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	packages_service "code.gitea.io/gitea/services/packages"
+	attestation_service "code.gitea.io/gitea/services/packages/attestation"
 	container_service "code.gitea.io/gitea/services/packages/container"
 )
 
@@ -293,6 +294,21 @@ func ViewPackageVersion(ctx *context.Context) {
 			}
 		}
 		ctx.Data["ContainerImageMetadata"] = imageMetadata
+
+		subject := versionSub
+		if subject == "" {
+			for _, pfd := range pd.Files {
+				if pfd.File.IsLead {
+					subject = pfd.Properties.GetByName(container_module.PropertyDigest)
+				}
+			}
+		}
+		referrers, err := container_service.GetReferrers(ctx, pd.Owner.ID, pd.Package.LowerName, subject, "")
+		if err != nil {
+			ctx.ServerError("GetReferrers", err)
+			return
+		}
+		ctx.Data["ContainerReferrers"] = referrers
 	}
 	var pvs []*packages_model.PackageVersion
 	var pvsTotal int64
@@ -316,6 +332,13 @@ func ViewPackageVersion(ctx *context.Context) {
 	ctx.Data["LatestVersions"] = pvs
 	ctx.Data["TotalVersionCount"] = pvsTotal
 
+	attestations, err := attestation_service.GetVersionAttestations(ctx, pd)
+	if err != nil {
+		ctx.ServerError("GetVersionAttestations", err)
+		return
+	}
+	ctx.Data["Attestations"] = attestations
+
 	ctx.Data["CanWritePackages"] = ctx.Package.AccessMode >= perm.AccessModeWrite || ctx.IsUserSiteAdmin()
 
 	hasRepositoryAccess := false
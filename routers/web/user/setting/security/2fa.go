@@ -20,6 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	user_service "code.gitea.io/gitea/services/user"
 
 	"github.com/pquerna/otp"
 	"github.com/pquerna/otp/totp"
@@ -82,7 +83,7 @@ func DisableTwoFactor(ctx *context.Context) {
 		return
 	}
 
-	if err = auth.DeleteTwoFactorByID(ctx, t.ID, ctx.Doer.ID); err != nil {
+	if err = user_service.DisableTwoFactor(ctx, ctx.Doer, ctx.Doer, t); err != nil {
 		if auth.IsErrTwoFactorNotEnrolled(err) {
 			// There is a potential DB race here - we must have been disabled by another request in the intervening period
 			ctx.Flash.Success(ctx.Tr("settings.twofa_disabled"))
@@ -249,7 +250,7 @@ func EnrollTwoFactorPost(ctx *context.Context) {
 		return
 	}
 
-	newTwoFactorErr := auth.NewTwoFactor(ctx, t)
+	newTwoFactorErr := user_service.EnableTwoFactor(ctx, ctx.Doer, ctx.Doer, t)
 	if newTwoFactorErr == nil {
 		_ = ctx.Session.Set(session.KeyUserHasTwoFactorAuth, true)
 	}
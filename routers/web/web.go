@@ -379,6 +379,20 @@ func registerWebRoutes(m *web.Router) {
 		}
 	}
 
+	auditEnabled := func(ctx *context.Context) {
+		if !setting.Audit.Enabled {
+			ctx.HTTPError(http.StatusForbidden)
+			return
+		}
+	}
+
+	terraformStateEnabled := func(ctx *context.Context) {
+		if !setting.TerraformState.Enabled {
+			ctx.HTTPError(http.StatusForbidden)
+			return
+		}
+	}
+
 	feedEnabled := func(ctx *context.Context) {
 		if !setting.Other.EnableFeed {
 			ctx.HTTPError(http.StatusNotFound)
@@ -454,6 +468,19 @@ func registerWebRoutes(m *web.Router) {
 		})
 	}
 
+	addSettingsApprovalPolicyRoutes := func() {
+		m.Combo("/approval_policy").Get(shared_actions.ApprovalPolicy).
+			Post(web.Bind(forms.ActionsApprovalPolicyForm{}), shared_actions.ApprovalPolicyPost)
+	}
+
+	addSettingsCachesRoutes := func() {
+		m.Group("/caches", func() {
+			m.Get("", shared_actions.Caches)
+			m.Post("/{cache_id}/delete", shared_actions.CacheDelete)
+			m.Post("/purge", shared_actions.CachesPurge)
+		})
+	}
+
 	addSettingsRunnersRoutes := func() {
 		m.Group("/runners", func() {
 			m.Get("", shared_actions.Runners)
@@ -650,6 +677,17 @@ func registerWebRoutes(m *web.Router) {
 					m.Get("/preview", user_setting.PackagesRulePreview)
 				})
 			})
+			m.Group("/proxies", func() {
+				m.Group("/add", func() {
+					m.Get("", user_setting.PackagesProxyAdd)
+					m.Post("", web.Bind(forms.PackageProxyForm{}), user_setting.PackagesProxyAddPost)
+				})
+				m.Group("/{id}", func() {
+					m.Get("", user_setting.PackagesProxyEdit)
+					m.Post("", web.Bind(forms.PackageProxyForm{}), user_setting.PackagesProxyEditPost)
+				})
+			})
+			m.Post("/signing", web.Bind(forms.PackageSigningForm{}), user_setting.PackagesSigningPost)
 			m.Group("/cargo", func() {
 				m.Post("/initialize", user_setting.InitializeCargoIndex)
 				m.Post("/rebuild", user_setting.RebuildCargoIndex)
@@ -800,6 +838,8 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/empty", admin.EmptyNotices)
 		})
 
+		m.Get("/audit", auditEnabled, admin.AuditLog)
+
 		m.Group("/applications", func() {
 			m.Get("", admin.Applications)
 			m.Post("/oauth2", web.Bind(forms.EditOAuth2ApplicationForm{}), admin.ApplicationsPost)
@@ -814,8 +854,9 @@ func registerWebRoutes(m *web.Router) {
 			m.Get("", admin.RedirectToDefaultSetting)
 			addSettingsRunnersRoutes()
 			addSettingsVariablesRoutes()
+			addSettingsCachesRoutes()
 		})
-	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled))
+	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "EnableAudit", setting.Audit.Enabled))
 	// ***** END: Admin *****
 
 	m.Group("", func() {
@@ -963,6 +1004,13 @@ func registerWebRoutes(m *web.Router) {
 					addSettingsRunnersRoutes()
 					addSettingsSecretsRoutes()
 					addSettingsVariablesRoutes()
+					addSettingsApprovalPolicyRoutes()
+					m.Group("/required_workflows", func() {
+						m.Get("", org.SettingsRequiredWorkflows)
+						m.Post("/new", web.Bind(forms.ActionsRequiredWorkflowForm{}), org.SettingsRequiredWorkflowsPost)
+						m.Post("/{id}/edit", web.Bind(forms.ActionsRequiredWorkflowForm{}), org.SettingsRequiredWorkflowEditPost)
+						m.Post("/{id}/delete", org.SettingsRequiredWorkflowDelete)
+					})
 				}, actions.MustEnableActions)
 
 				m.Post("/rename", web.Bind(forms.RenameOrgForm{}), org.SettingsRenamePost)
@@ -982,6 +1030,17 @@ func registerWebRoutes(m *web.Router) {
 							m.Get("/preview", org.PackagesRulePreview)
 						})
 					})
+					m.Group("/proxies", func() {
+						m.Group("/add", func() {
+							m.Get("", org.PackagesProxyAdd)
+							m.Post("", web.Bind(forms.PackageProxyForm{}), org.PackagesProxyAddPost)
+						})
+						m.Group("/{id}", func() {
+							m.Get("", org.PackagesProxyEdit)
+							m.Post("", web.Bind(forms.PackageProxyForm{}), org.PackagesProxyEditPost)
+						})
+					})
+					m.Post("/signing", web.Bind(forms.PackageSigningForm{}), org.PackagesSigningPost)
 					m.Group("/cargo", func() {
 						m.Post("/initialize", org.InitializeCargoIndex)
 						m.Post("/rebuild", org.RebuildCargoIndex)
@@ -992,7 +1051,9 @@ func registerWebRoutes(m *web.Router) {
 					m.Get("", org.BlockedUsers)
 					m.Post("", web.Bind(forms.BlockUserForm{}), org.BlockedUsersPost)
 				})
-			}, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "PageIsOrgSettings", true))
+
+				m.Get("/audit", auditEnabled, org.SettingsAudit)
+			}, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "EnableAudit", setting.Audit.Enabled, "PageIsOrgSettings", true))
 		}, context.OrgAssignment(context.OrgAssignmentOptions{RequireOwner: true}))
 	}, reqSignIn)
 	// end "/org": most org routes
@@ -1032,6 +1093,8 @@ func registerWebRoutes(m *web.Router) {
 			m.Group("", func() {
 				m.Get("", org.Projects)
 				m.Get("/{id}", org.ViewProject)
+				m.Get("/{id}/table", org.ViewProjectTable)
+				m.Get("/{id}/roadmap", org.ViewProjectRoadmap)
 			}, reqUnitAccess(unit.TypeProjects, perm.AccessModeRead, true))
 			m.Group("", func() { //nolint:dupl // duplicates lines 1421-1441
 				m.Get("/new", org.RenderNewProject)
@@ -1046,11 +1109,21 @@ func registerWebRoutes(m *web.Router) {
 					// TODO: improper name. Others are "delete project", "edit project", but this one is "move columns"
 					m.Post("/move", project.MoveColumns)
 					m.Post("/columns/new", web.Bind(forms.EditProjectColumnForm{}), org.AddColumnToProjectPost)
+					m.Get("/fields", org.ProjectFields)
+					m.Post("/fields/new", web.Bind(forms.ProjectFieldForm{}), project.NewFieldPost)
+					m.Post("/fields/{fieldID}/edit", web.Bind(forms.ProjectFieldForm{}), project.EditFieldPost)
+					m.Post("/fields/{fieldID}/delete", project.DeleteField)
+					m.Post("/issues/{issueID}/fields", project.SetIssueFieldValues)
+					m.Post("/views/new", web.Bind(forms.ProjectViewForm{}), project.NewViewPost)
+					m.Post("/views/{viewID}/edit", web.Bind(forms.ProjectViewForm{}), project.EditViewPost)
+					m.Post("/views/{viewID}/delete", project.DeleteView)
 					m.Group("/{columnID}", func() {
 						m.Put("", web.Bind(forms.EditProjectColumnForm{}), org.EditProjectColumn)
 						m.Delete("", org.DeleteProjectColumn)
 						m.Post("/default", org.SetDefaultProjectColumn)
 						m.Post("/move", org.MoveIssues)
+						m.Post("/automation", web.Bind(forms.ProjectColumnAutomationForm{}), project.AddColumnAutomation)
+						m.Post("/automation/{ruleID}/delete", project.DeleteColumnAutomation)
 					})
 				})
 			}, reqSignIn, reqUnitAccess(unit.TypeProjects, perm.AccessModeWrite, true), func(ctx *context.Context) {
@@ -1160,6 +1233,22 @@ func registerWebRoutes(m *web.Router) {
 			addSettingsRunnersRoutes()
 			addSettingsSecretsRoutes()
 			addSettingsVariablesRoutes()
+			addSettingsApprovalPolicyRoutes()
+			addSettingsCachesRoutes()
+			m.Group("/environments", func() {
+				m.Get("", repo_setting.Environments)
+				m.Post("/new", web.Bind(forms.ActionsEnvironmentForm{}), repo_setting.EnvironmentCreate)
+				m.Group("/{environment_id}", func() {
+					m.Combo("").Get(repo_setting.EnvironmentEdit).
+						Post(web.Bind(forms.ActionsEnvironmentForm{}), repo_setting.EnvironmentEditPost)
+					m.Post("/delete", repo_setting.EnvironmentDelete)
+					m.Post("/secrets", web.Bind(forms.AddSecretForm{}), repo_setting.EnvironmentSecretsPost)
+					m.Post("/secrets/delete", repo_setting.EnvironmentSecretsDelete)
+					m.Post("/variables/new", web.Bind(forms.EditVariableForm{}), repo_setting.EnvironmentVariableCreate)
+					m.Post("/variables/{variable_id}/edit", web.Bind(forms.EditVariableForm{}), repo_setting.EnvironmentVariableUpdate)
+					m.Post("/variables/{variable_id}/delete", repo_setting.EnvironmentVariableDelete)
+				})
+			})
 			m.Group("/general", func() {
 				m.Group("/collaborative_owner", func() {
 					m.Post("/add", repo_setting.AddCollaborativeOwner)
@@ -1167,6 +1256,16 @@ func registerWebRoutes(m *web.Router) {
 				})
 			})
 		}, actions.MustEnableActions)
+		m.Get("/audit", auditEnabled, repo_setting.Audit)
+		m.Group("/terraform", func() {
+			m.Get("", repo_setting.TerraformStates)
+			m.Group("/{name}", func() {
+				m.Get("", repo_setting.TerraformStateVersions)
+				m.Get("/versions/{version}", repo_setting.TerraformStateVersionDownload)
+				m.Post("/unlock", repo_setting.TerraformStateUnlock)
+				m.Post("/delete", repo_setting.TerraformStateDelete)
+			})
+		}, terraformStateEnabled, reqUnitCodeReader)
 		// the follow handler must be under "settings", otherwise this incomplete repo can't be accessed
 		m.Group("/migrate", func() {
 			m.Post("/retry", repo.MigrateRetryPost)
@@ -1174,7 +1273,7 @@ func registerWebRoutes(m *web.Router) {
 		})
 	},
 		reqSignIn, context.RepoAssignment, reqRepoAdmin,
-		ctxDataSet("PageIsRepoSettings", true, "LFSStartServer", setting.LFS.StartServer),
+		ctxDataSet("PageIsRepoSettings", true, "LFSStartServer", setting.LFS.StartServer, "EnableAudit", setting.Audit.Enabled, "EnableTerraformState", setting.TerraformState.Enabled),
 	)
 	// end "/{username}/{reponame}/settings"
 
@@ -1431,6 +1530,8 @@ func registerWebRoutes(m *web.Router) {
 	m.Group("/{username}/{reponame}/projects", func() {
 		m.Get("", repo.Projects)
 		m.Get("/{id}", repo.ViewProject)
+		m.Get("/{id}/table", repo.ViewProjectTable)
+		m.Get("/{id}/roadmap", repo.ViewProjectRoadmap)
 		m.Group("", func() { //nolint:dupl // duplicates lines 1034-1054
 			m.Get("/new", repo.RenderNewProject)
 			m.Post("/new", web.Bind(forms.CreateProjectForm{}), repo.NewProjectPost)
@@ -1444,11 +1545,21 @@ func registerWebRoutes(m *web.Router) {
 				// TODO: improper name. Others are "delete project", "edit project", but this one is "move columns"
 				m.Post("/move", project.MoveColumns)
 				m.Post("/columns/new", web.Bind(forms.EditProjectColumnForm{}), repo.AddColumnToProjectPost)
+				m.Get("/fields", repo.ProjectFields)
+				m.Post("/fields/new", web.Bind(forms.ProjectFieldForm{}), project.NewFieldPost)
+				m.Post("/fields/{fieldID}/edit", web.Bind(forms.ProjectFieldForm{}), project.EditFieldPost)
+				m.Post("/fields/{fieldID}/delete", project.DeleteField)
+				m.Post("/issues/{issueID}/fields", project.SetIssueFieldValues)
+				m.Post("/views/new", web.Bind(forms.ProjectViewForm{}), project.NewViewPost)
+				m.Post("/views/{viewID}/edit", web.Bind(forms.ProjectViewForm{}), project.EditViewPost)
+				m.Post("/views/{viewID}/delete", project.DeleteView)
 				m.Group("/{columnID}", func() {
 					m.Put("", web.Bind(forms.EditProjectColumnForm{}), repo.EditProjectColumn)
 					m.Delete("", repo.DeleteProjectColumn)
 					m.Post("/default", repo.SetDefaultProjectColumn)
 					m.Post("/move", repo.MoveIssues)
+					m.Post("/automation", web.Bind(forms.ProjectColumnAutomationForm{}), project.AddColumnAutomation)
+					m.Post("/automation/{ruleID}/delete", project.DeleteColumnAutomation)
 				})
 			})
 		}, reqRepoProjectsWriter, context.RepoMustNotBeArchived())
@@ -1462,6 +1573,7 @@ func registerWebRoutes(m *web.Router) {
 		m.Post("/run", reqRepoActionsWriter, actions.Run)
 		m.Get("/workflow-dispatch-inputs", reqRepoActionsWriter, actions.WorkflowDispatchInputs)
 		m.Post("/approve-all-checks", reqRepoActionsWriter, actions.ApproveAllChecks)
+		m.Get("/deployments", actions.Deployments)
 
 		m.Group("/runs/{run}", func() {
 			m.Combo("").
@@ -1475,8 +1587,11 @@ func registerWebRoutes(m *web.Router) {
 				m.Get("/logs", actions.Logs)
 			})
 			m.Get("/workflow", actions.ViewWorkflowFile)
+			m.Get("/logs", actions.RunLogs)
+			m.Get("/logs/search", actions.SearchLogs)
 			m.Post("/cancel", reqRepoActionsWriter, actions.Cancel)
 			m.Post("/approve", reqRepoActionsWriter, actions.Approve)
+			m.Post("/deployments/{deployment_id}/review", reqSignIn, web.Bind(forms.ReviewDeploymentForm{}), actions.ReviewDeployment)
 			m.Post("/delete", reqRepoActionsWriter, actions.Delete)
 			m.Get("/artifacts/{artifact_name}", actions.ArtifactsDownloadView)
 			m.Delete("/artifacts/{artifact_name}", reqRepoActionsWriter, actions.ArtifactsDeleteView)
@@ -1544,6 +1659,8 @@ func registerWebRoutes(m *web.Router) {
 			})
 			m.Post("/merge", context.RepoMustNotBeArchived(), web.Bind(forms.MergePullRequestForm{}), repo.MergePullRequest)
 			m.Post("/cancel_auto_merge", context.RepoMustNotBeArchived(), repo.CancelAutoMergePullRequest)
+			m.Post("/remove_from_merge_queue", reqSignIn, context.RepoMustNotBeArchived(), repo.RemoveFromMergeQueue)
+			m.Post("/merge_stack", context.RepoMustNotBeArchived(), repo.MergePullRequestStack)
 			m.Post("/update", repo.UpdatePullRequest)
 			m.Post("/set_allow_maintainer_edit", web.Bind(forms.UpdateAllowEditsForm{}), repo.SetAllowEdits)
 			m.Post("/cleanup", context.RepoMustNotBeArchived(), repo.CleanUpPullRequest)
@@ -0,0 +1,55 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/util"
+
+	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+)
+
+// CreateTaskAnnotationsFromLogs creates the annotations of the "::error", "::warning" and "::notice" commands found in the logs of a task,
+// startIndex is the index of the first row in the logs of the task.
+func CreateTaskAnnotationsFromLogs(ctx context.Context, task *actions_model.ActionTask, rows []*runnerv1.LogRow, startIndex int64) error {
+	var annotations []*actions_model.ActionTaskAnnotation
+	for i, row := range rows {
+		cmd, ok := actions_module.ParseAnnotationCommand(row.Content)
+		if !ok {
+			continue
+		}
+		annotations = append(annotations, &actions_model.ActionTaskAnnotation{
+			Level:       actions_model.ParseAnnotationLevel(cmd.Level),
+			Title:       util.TruncateRunes(cmd.Title, 255),
+			Message:     cmd.Message,
+			Path:        cmd.File,
+			StartLine:   cmd.StartLine,
+			EndLine:     cmd.EndLine,
+			StartColumn: cmd.StartColumn,
+			EndColumn:   cmd.EndColumn,
+			LogIndex:    startIndex + int64(i),
+		})
+	}
+	return actions_model.InsertTaskAnnotations(ctx, task, annotations)
+}
+
+// formatAnnotationCounts formats the numbers of annotations of a task for the description of its commit status, like "2 errors, 1 warning"
+func formatAnnotationCounts(counts map[actions_model.AnnotationLevel]int64) string {
+	var parts []string
+	for _, level := range []actions_model.AnnotationLevel{actions_model.AnnotationLevelError, actions_model.AnnotationLevelWarning, actions_model.AnnotationLevelNotice} {
+		switch n := counts[level]; n {
+		case 0:
+		case 1:
+			parts = append(parts, "1 "+level.String())
+		default:
+			parts = append(parts, fmt.Sprintf("%d %ss", n, level.String()))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
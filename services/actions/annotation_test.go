@@ -0,0 +1,58 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/unittest"
+
+	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTaskAnnotationsFromLogs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+	rows := []*runnerv1.LogRow{
+		{Content: "go vet ./..."},
+		{Content: "::error file=main.go,line=12,col=5,title=vet::unreachable code"},
+		{Content: "::debug::not an annotation"},
+		{Content: "::notice::done"},
+	}
+	require.NoError(t, CreateTaskAnnotationsFromLogs(t.Context(), task, rows, 10))
+
+	annotations, err := actions_model.FindTaskAnnotations(t.Context(), task.ID)
+	require.NoError(t, err)
+	if assert.Len(t, annotations, 2) {
+		a := annotations[0]
+		assert.Equal(t, actions_model.AnnotationLevelError, a.Level)
+		assert.Equal(t, "vet", a.Title)
+		assert.Equal(t, "unreachable code", a.Message)
+		assert.Equal(t, "main.go", a.Path)
+		assert.Equal(t, 12, a.StartLine)
+		assert.Equal(t, 12, a.EndLine)
+		assert.Equal(t, 5, a.StartColumn)
+		assert.Equal(t, int64(11), a.LogIndex)
+		assert.Equal(t, task.CommitSHA, a.CommitSHA)
+
+		assert.Equal(t, actions_model.AnnotationLevelNotice, annotations[1].Level)
+		assert.Equal(t, int64(13), annotations[1].LogIndex)
+	}
+}
+
+func TestFormatAnnotationCounts(t *testing.T) {
+	assert.Empty(t, formatAnnotationCounts(nil))
+	assert.Equal(t, "1 error", formatAnnotationCounts(map[actions_model.AnnotationLevel]int64{
+		actions_model.AnnotationLevelError: 1,
+	}))
+	assert.Equal(t, "2 errors, 1 warning, 3 notices", formatAnnotationCounts(map[actions_model.AnnotationLevel]int64{
+		actions_model.AnnotationLevelNotice:  3,
+		actions_model.AnnotationLevelWarning: 1,
+		actions_model.AnnotationLevelError:   2,
+	}))
+}
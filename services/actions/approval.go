@@ -0,0 +1,126 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"slices"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// isTrustedByApprovalPolicy returns whether the runs of the user can start without an approval according to the policy
+func isTrustedByApprovalPolicy(ctx context.Context, policy *actions_model.ActionApprovalPolicy, repo *repo_model.Repository, user *user_model.User) (bool, error) {
+	if policy.TrustMergedPullRequests > 0 {
+		merged, err := issues_model.CountMergedPullRequestsByPoster(ctx, repo.ID, user.ID)
+		if err != nil {
+			return false, err
+		}
+		if merged >= policy.TrustMergedPullRequests {
+			return true, nil
+		}
+	}
+	if len(policy.TrustedTeamIDs) > 0 {
+		return organization.IsUserInTeams(ctx, user.ID, policy.TrustedTeamIDs)
+	}
+	return false, nil
+}
+
+// isWorkflowChangedByPullRequest returns whether the commits of the pull request up to headCommitID modify the workflow files
+func isWorkflowChangedByPullRequest(gitRepo *git.Repository, pr *issues_model.PullRequest, headCommitID string) (bool, error) {
+	if pr == nil {
+		return false, nil
+	}
+	mergeBase := pr.MergeBase
+	if mergeBase == "" {
+		var err error
+		mergeBase, _, err = gitRepo.GetMergeBase("", git.BranchPrefix+pr.BaseBranch, headCommitID)
+		if err != nil {
+			return false, err
+		}
+	}
+	files, err := gitRepo.GetFilesChangedBetween(mergeBase, headCommitID)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(files, actions_module.IsWorkflow), nil
+}
+
+// ApproveRuns approves the runs of a repository waiting for an approval, their jobs which are ready start
+func ApproveRuns(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, runIndexes []int64) error {
+	updatedJobs := make([]*actions_model.ActionRunJob, 0)
+	runMap := make(map[int64]*actions_model.ActionRun, len(runIndexes))
+	runJobs := make(map[int64][]*actions_model.ActionRunJob, len(runIndexes))
+
+	err := db.WithTx(ctx, func(ctx context.Context) (err error) {
+		for _, runIndex := range runIndexes {
+			run, err := actions_model.GetRunByIndex(ctx, repo.ID, runIndex)
+			if err != nil {
+				return err
+			}
+			runMap[run.ID] = run
+			run.Repo = repo
+			run.NeedApproval = false
+			run.ApprovedBy = doer.ID
+			if err := actions_model.UpdateRun(ctx, run, "need_approval", "approved_by"); err != nil {
+				return err
+			}
+			jobs, err := actions_model.GetRunJobsByRunID(ctx, run.ID)
+			if err != nil {
+				return err
+			}
+			runJobs[run.ID] = jobs
+			for _, job := range jobs {
+				if IsReusableWorkflowCall(job) || job.RawEnvironment != "" {
+					// it will be checked by the job emitter after the approval
+					continue
+				}
+				job.Status, err = PrepareToStartJobWithConcurrency(ctx, job)
+				if err != nil {
+					return err
+				}
+				if job.Status == actions_model.StatusWaiting {
+					n, err := actions_model.UpdateRunJob(ctx, job, nil, "status")
+					if err != nil {
+						return err
+					}
+					if n > 0 {
+						updatedJobs = append(updatedJobs, job)
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for runID, run := range runMap {
+		CreateCommitStatusForRunJobs(ctx, run, runJobs[runID]...)
+		if err := EmitJobsIfReadyByRun(runID); err != nil {
+			log.Error("EmitJobsIfReadyByRun: %v", err)
+		}
+	}
+
+	if len(updatedJobs) > 0 {
+		job := updatedJobs[0]
+		NotifyWorkflowRunStatusUpdateWithReload(ctx, job)
+	}
+
+	for _, job := range updatedJobs {
+		_ = job.LoadAttributes(ctx)
+		notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTrustedByApprovalPolicy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	user1 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	cases := []struct {
+		name    string
+		policy  *actions_model.ActionApprovalPolicy
+		repo    *repo_model.Repository
+		user    *user_model.User
+		trusted bool
+	}{
+		{name: "EmptyPolicy", policy: &actions_model.ActionApprovalPolicy{}, repo: repo1, user: user1, trusted: false},
+		// user1 has one pull request merged into repo1
+		{name: "EnoughMergedPullRequests", policy: &actions_model.ActionApprovalPolicy{TrustMergedPullRequests: 1}, repo: repo1, user: user1, trusted: true},
+		{name: "NotEnoughMergedPullRequests", policy: &actions_model.ActionApprovalPolicy{TrustMergedPullRequests: 2}, repo: repo1, user: user1, trusted: false},
+		{name: "MergedPullRequestsOfOtherRepo", policy: &actions_model.ActionApprovalPolicy{TrustMergedPullRequests: 1}, repo: repo3, user: user1, trusted: false},
+		// user4 is a member of team 2 of org3, user5 isn't
+		{name: "TeamMember", policy: &actions_model.ActionApprovalPolicy{TrustedTeamIDs: []int64{2}}, repo: repo3, user: user4, trusted: true},
+		{name: "NotTeamMember", policy: &actions_model.ActionApprovalPolicy{TrustedTeamIDs: []int64{2}}, repo: repo3, user: user5, trusted: false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			trusted, err := isTrustedByApprovalPolicy(t.Context(), c.policy, c.repo, c.user)
+			require.NoError(t, err)
+			assert.Equal(t, c.trusted, trusted)
+		})
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// cacheRefsOfRun returns the refs whose caches can be restored by a run, in the order of preference:
+// the ref of the run, the base branch of a pull request and the default branch of the repository.
+// See https://docs.github.com/en/actions/reference/workflows-and-actions/dependency-caching#restrictions-for-accessing-a-cache
+func cacheRefsOfRun(ctx context.Context, run *actions_model.ActionRun) ([]string, error) {
+	if err := run.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	refs := []string{run.Ref}
+	if payload, err := run.GetPullRequestEventPayload(); err == nil && payload != nil && payload.PullRequest != nil && payload.PullRequest.Base != nil {
+		refs = append(refs, git.BranchPrefix+payload.PullRequest.Base.Ref)
+	}
+	refs = append(refs, git.BranchPrefix+run.Repo.DefaultBranch)
+	return slices.Compact(refs), nil
+}
+
+// FindCacheForRun returns the cache restored by a run for the keys, see actions_model.FindCache for the lookup order
+func FindCacheForRun(ctx context.Context, run *actions_model.ActionRun, keys []string, version string) (*actions_model.ActionCache, error) {
+	refs, err := cacheRefsOfRun(ctx, run)
+	if err != nil {
+		return nil, err
+	}
+	c, err := actions_model.FindCache(ctx, run.RepoID, refs, keys, version)
+	if err != nil {
+		return nil, err
+	}
+	c.UsedUnix = timeutil.TimeStampNow()
+	if err := actions_model.UpdateCacheCols(ctx, c, "used_unix"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ReserveCacheForRun reserves a cache in the ref of a run, the archive has to be uploaded and committed to be restored
+func ReserveCacheForRun(ctx context.Context, run *actions_model.ActionRun, key, version string, size int64) (*actions_model.ActionCache, error) {
+	if key == "" || len(key) > actions_model.CacheKeyMaxLength {
+		return nil, util.NewInvalidArgumentErrorf("the length of the key must be between 1 and %d", actions_model.CacheKeyMaxLength)
+	}
+	if version == "" {
+		return nil, util.NewInvalidArgumentErrorf("the version is required")
+	}
+	size = max(size, 0)
+
+	releaser, err := globallock.Lock(ctx, getCacheSpaceLockKey(run.RepoID))
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	// an existing cache must not be evicted to make room for itself
+	exist, err := db.Exist[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		RepoID:  run.RepoID,
+		Ref:     run.Ref,
+		Key:     key,
+		Version: version,
+	}.ToConds())
+	if err != nil {
+		return nil, err
+	} else if exist {
+		return nil, util.NewAlreadyExistErrorf("cache %q of version %q already exists", key, version)
+	}
+	if err := makeRoomForCache(ctx, run.RepoID, 0, size); err != nil {
+		return nil, err
+	}
+	c := &actions_model.ActionCache{
+		RepoID:  run.RepoID,
+		Ref:     run.Ref,
+		Key:     key,
+		Version: version,
+		RunID:   run.ID,
+		Size:    size,
+	}
+	if err := actions_model.ReserveCache(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func getCacheSpaceLockKey(repoID int64) string {
+	return fmt.Sprintf("actions_cache_space_%d", repoID)
+}
+
+// makeRoomForCache evicts the least recently used caches of a repository until a cache of the size fits CACHE_MAX_SIZE_PER_REPO
+// together with the caches being uploaded, it fails if the caches being uploaded leave no room for it.
+// The cache cacheID is the one which needs the room if it has been reserved already.
+func makeRoomForCache(ctx context.Context, repoID, cacheID, size int64) error {
+	limit := setting.Actions.CacheMaxSizePerRepo
+	if limit <= 0 {
+		return nil
+	}
+	if size > limit {
+		return util.NewInvalidArgumentErrorf("the cache size %d exceeds the limit %d", size, limit)
+	}
+	reserved, err := actions_model.GetReservedCachesSize(ctx, repoID, cacheID)
+	if err != nil {
+		return err
+	}
+	if reserved+size > limit {
+		return util.NewInvalidArgumentErrorf("the cache size %d exceeds the space %d left by the caches being uploaded", size, max(limit-reserved, 0))
+	}
+	return evictCaches(ctx, repoID, limit-reserved-size)
+}
+
+func cacheChunksDir(c *actions_model.ActionCache) string {
+	return fmt.Sprintf("tmp/%d", c.ID)
+}
+
+// UploadCacheChunk saves the bytes from start to end (inclusive) of the archive of a reserved cache
+func UploadCacheChunk(c *actions_model.ActionCache, r io.Reader, start, end int64) error {
+	if c.Complete {
+		return util.NewInvalidArgumentErrorf("cache %d has been committed", c.ID)
+	}
+	if start < 0 || end < start {
+		return util.NewInvalidArgumentErrorf("invalid range %d-%d", start, end)
+	}
+	if setting.Actions.CacheMaxSizePerRepo > 0 && end >= setting.Actions.CacheMaxSizePerRepo {
+		return util.NewInvalidArgumentErrorf("the cache size exceeds the limit %d", setting.Actions.CacheMaxSizePerRepo)
+	}
+	if c.Size > 0 && end >= c.Size {
+		return util.NewInvalidArgumentErrorf("the cache size exceeds the reserved size %d", c.Size)
+	}
+
+	chunkPath := fmt.Sprintf("%s/%d-%d.chunk", cacheChunksDir(c), start, end)
+	size := end - start + 1
+	written, err := storage.ActionsCaches.Save(chunkPath, io.LimitReader(r, size), size)
+	if err == nil && written != size {
+		err = util.NewInvalidArgumentErrorf("the chunk has %d bytes instead of %d", written, size)
+	}
+	if err != nil {
+		if err := storage.ActionsCaches.Delete(chunkPath); err != nil {
+			log.Warn("Unable to delete chunk %s: %v", chunkPath, err)
+		}
+		return err
+	}
+	return nil
+}
+
+type cacheChunk struct {
+	Path       string
+	Start, End int64
+}
+
+func listCacheChunks(c *actions_model.ActionCache) ([]*cacheChunk, error) {
+	dir := cacheChunksDir(c)
+	var chunks []*cacheChunk
+	if err := storage.ActionsCaches.IterateObjects(dir, func(fpath string, _ storage.Object) error {
+		// the path only contains the dir and the base name, whatever the base path of the storage is
+		chunk := &cacheChunk{Path: dir + "/" + path.Base(fpath)}
+		if _, err := fmt.Sscanf(path.Base(fpath), "%d-%d.chunk", &chunk.Start, &chunk.End); err != nil {
+			return fmt.Errorf("parse chunk name %q: %w", fpath, err)
+		}
+		chunks = append(chunks, chunk)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		return chunks[i].Start < chunks[j].Start
+	})
+	return chunks, nil
+}
+
+// CommitCache merges the uploaded chunks of a reserved cache, the cache can be restored afterwards
+func CommitCache(ctx context.Context, c *actions_model.ActionCache, size int64) error {
+	if c.Complete {
+		return util.NewInvalidArgumentErrorf("cache %d has been committed", c.ID)
+	}
+	if c.Size > 0 && size > c.Size {
+		return util.NewInvalidArgumentErrorf("the cache size %d exceeds the reserved size %d", size, c.Size)
+	}
+	chunks, err := listCacheChunks(c)
+	if err != nil {
+		return err
+	}
+
+	// the chunks may overlap if the client has retried an upload, only keep the contiguous ones
+	readers := make([]io.Reader, 0, len(chunks))
+	closeReaders := func() {
+		for _, r := range readers {
+			_ = r.(io.Closer).Close()
+		}
+		readers = nil
+	}
+	defer closeReaders()
+	next := int64(0)
+	for _, chunk := range chunks {
+		if chunk.Start != next {
+			continue
+		}
+		f, err := storage.ActionsCaches.Open(chunk.Path)
+		if err != nil {
+			return fmt.Errorf("open chunk %s: %w", chunk.Path, err)
+		}
+		readers = append(readers, f)
+		next = chunk.End + 1
+	}
+	if next != size {
+		return util.NewInvalidArgumentErrorf("%d bytes of %d have been uploaded", next, size)
+	}
+
+	// the size may be unknown when the cache is reserved, the real size is reserved before the archive is saved
+	if err := reserveCommittedCacheSize(ctx, c, size); err != nil {
+		return err
+	}
+
+	storagePath := fmt.Sprintf("%d/%d.cache", c.RepoID, c.ID)
+	written, err := storage.ActionsCaches.Save(storagePath, io.MultiReader(readers...), size)
+	if err != nil {
+		return fmt.Errorf("save cache %d: %w", c.ID, err)
+	} else if written != size {
+		return fmt.Errorf("cache %d has %d bytes instead of %d", c.ID, written, size)
+	}
+	closeReaders()
+	deleteCacheChunks(c, chunks)
+
+	c.StoragePath = storagePath
+	c.Size = size
+	c.Complete = true
+	c.UsedUnix = timeutil.TimeStampNow()
+	return actions_model.UpdateCacheCols(ctx, c, "storage_path", "size", "complete", "used_unix")
+}
+
+func reserveCommittedCacheSize(ctx context.Context, c *actions_model.ActionCache, size int64) error {
+	releaser, err := globallock.Lock(ctx, getCacheSpaceLockKey(c.RepoID))
+	if err != nil {
+		return err
+	}
+	defer releaser()
+
+	if err := makeRoomForCache(ctx, c.RepoID, c.ID, size); err != nil {
+		return err
+	}
+	c.Size = size
+	return actions_model.UpdateCacheCols(ctx, c, "size")
+}
+
+func deleteCacheChunks(c *actions_model.ActionCache, chunks []*cacheChunk) {
+	for _, chunk := range chunks {
+		if err := storage.ActionsCaches.Delete(chunk.Path); err != nil {
+			log.Warn("Unable to delete chunk %s of cache %d: %v", chunk.Path, c.ID, err)
+		}
+	}
+}
+
+// DeleteCache deletes a cache with its archive or its uploaded chunks
+func DeleteCache(ctx context.Context, c *actions_model.ActionCache) error {
+	if err := actions_model.DeleteCacheByID(ctx, c.ID); err != nil {
+		return err
+	}
+	RemoveCacheFiles(c)
+	return nil
+}
+
+// RemoveCacheFiles removes the archive or the uploaded chunks of a cache from the storage, the errors are only logged
+func RemoveCacheFiles(c *actions_model.ActionCache) {
+	if c.StoragePath != "" {
+		if err := storage.ActionsCaches.Delete(c.StoragePath); err != nil {
+			log.Warn("Unable to delete cache %d: %v", c.ID, err)
+		}
+	}
+	if !c.Complete {
+		chunks, err := listCacheChunks(c)
+		if err != nil {
+			log.Warn("Unable to list chunks of cache %d: %v", c.ID, err)
+		}
+		deleteCacheChunks(c, chunks)
+	}
+}
+
+// deleteCacheBatchSize is the batch size of deleting caches
+const deleteCacheBatchSize = 100
+
+// deleteCaches deletes all the caches found with the options
+func deleteCaches(ctx context.Context, opts actions_model.FindCachesOptions) (int, error) {
+	opts.ListOptions = db.ListOptions{Page: 1, PageSize: deleteCacheBatchSize}
+	deleted := 0
+	for {
+		caches, err := db.Find[actions_model.ActionCache](ctx, opts)
+		if err != nil {
+			return deleted, err
+		}
+		for _, c := range caches {
+			if err := DeleteCache(ctx, c); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+		if len(caches) < deleteCacheBatchSize {
+			return deleted, nil
+		}
+	}
+}
+
+// PurgeCaches deletes all the caches of a repository, or of all repositories if repoID is 0
+func PurgeCaches(ctx context.Context, repoID int64) error {
+	_, err := deleteCaches(ctx, actions_model.FindCachesOptions{RepoID: repoID})
+	return err
+}
+
+// CleanupCaches deletes the caches which haven't been used for CACHE_RETENTION_DAYS and the abandoned uploads,
+// then evicts the least recently used caches of the repositories exceeding CACHE_MAX_SIZE_PER_REPO.
+func CleanupCaches(ctx context.Context) error {
+	now := time.Now()
+	deleted, err := deleteCaches(ctx, actions_model.FindCachesOptions{
+		Complete:   optional.Some(false),
+		UsedBefore: timeutil.TimeStamp(now.Add(-24 * time.Hour).Unix()),
+	})
+	if err != nil {
+		return fmt.Errorf("delete incomplete caches: %w", err)
+	}
+	log.Info("Deleted %d incomplete caches", deleted)
+
+	if setting.Actions.CacheRetentionDays > 0 {
+		deleted, err = deleteCaches(ctx, actions_model.FindCachesOptions{
+			UsedBefore: timeutil.TimeStamp(now.AddDate(0, 0, -int(setting.Actions.CacheRetentionDays)).Unix()),
+		})
+		if err != nil {
+			return fmt.Errorf("delete unused caches: %w", err)
+		}
+		log.Info("Deleted %d unused caches", deleted)
+	}
+
+	if setting.Actions.CacheMaxSizePerRepo <= 0 {
+		return nil
+	}
+	repoIDs, err := actions_model.GetReposExceedingCacheSize(ctx, setting.Actions.CacheMaxSizePerRepo)
+	if err != nil {
+		return fmt.Errorf("GetReposExceedingCacheSize: %w", err)
+	}
+	for _, repoID := range repoIDs {
+		if err := evictCaches(ctx, repoID, setting.Actions.CacheMaxSizePerRepo); err != nil {
+			return fmt.Errorf("evict caches of repository %d: %w", repoID, err)
+		}
+	}
+	return nil
+}
+
+// evictCaches deletes the least recently used caches of a repository until their total size fits the limit
+func evictCaches(ctx context.Context, repoID, limit int64) error {
+	size, err := actions_model.GetCachesSize(ctx, repoID)
+	if err != nil || size <= limit {
+		return err
+	}
+	caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		RepoID:   repoID,
+		Complete: optional.Some(true),
+	})
+	if err != nil {
+		return err
+	}
+	// the caches are sorted from the most recently used
+	for i := len(caches) - 1; i >= 0 && size > limit; i-- {
+		if err := DeleteCache(ctx, caches[i]); err != nil {
+			return err
+		}
+		size -= caches[i].Size
+		log.Info("Cache %d of repository %d is evicted", caches[i].ID, repoID)
+	}
+	return nil
+}
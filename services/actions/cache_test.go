@@ -0,0 +1,91 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheLifecycle(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Actions.CacheMaxSizePerRepo, 10)()
+
+	run := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRun{ID: 791})
+	_, err := ReserveCacheForRun(t.Context(), run, "key", "v1", 11)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = ReserveCacheForRun(t.Context(), run, strings.Repeat("k", actions_model.CacheKeyMaxLength+1), "v1", 1)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	c, err := ReserveCacheForRun(t.Context(), run, "key", "v1", 6)
+	require.NoError(t, err)
+
+	// the chunks can be uploaded in any order and retried
+	require.NoError(t, UploadCacheChunk(c, strings.NewReader("def"), 3, 5))
+	require.NoError(t, UploadCacheChunk(c, strings.NewReader("abc"), 0, 2))
+	require.NoError(t, UploadCacheChunk(c, strings.NewReader("abc"), 0, 2))
+	assert.ErrorIs(t, UploadCacheChunk(c, strings.NewReader("toolarge"), 6, 13), util.ErrInvalidArgument)
+	assert.ErrorIs(t, UploadCacheChunk(c, strings.NewReader("a"), 6, 7), util.ErrInvalidArgument)
+	assert.ErrorIs(t, CommitCache(t.Context(), c, 7), util.ErrInvalidArgument)
+	require.NoError(t, CommitCache(t.Context(), c, 6))
+
+	found, err := FindCacheForRun(t.Context(), run, []string{"other", "ke"}, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, c.ID, found.ID)
+	f, err := storage.ActionsCaches.Open(found.StoragePath)
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	f.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", string(content))
+	chunks, err := listCacheChunks(c)
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+
+	_, err = ReserveCacheForRun(t.Context(), run, "key", "v1", 6)
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+
+	// the least recently used caches are evicted to make room for a new cache
+	c2, err := ReserveCacheForRun(t.Context(), run, "key2", "v1", 6)
+	require.NoError(t, err)
+	unittest.AssertNotExistsBean(t, &actions_model.ActionCache{ID: c.ID})
+	_, err = storage.ActionsCaches.Stat(c.StoragePath)
+	assert.Error(t, err)
+
+	// the caches being uploaded aren't evicted, a cache which doesn't fit beside them is rejected
+	_, err = ReserveCacheForRun(t.Context(), run, "key3", "v1", 5)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	// the size of a cache which is unknown when it's reserved is checked when it's committed
+	c3, err := ReserveCacheForRun(t.Context(), run, "key3", "v1", 0)
+	require.NoError(t, err)
+	require.NoError(t, UploadCacheChunk(c3, strings.NewReader("mnopq"), 0, 4))
+	assert.ErrorIs(t, CommitCache(t.Context(), c3, 5), util.ErrInvalidArgument)
+
+	require.NoError(t, UploadCacheChunk(c2, strings.NewReader("ghijkl"), 0, 5))
+	require.NoError(t, CommitCache(t.Context(), c2, 6))
+	require.NoError(t, CommitCache(t.Context(), c3, 5))
+	unittest.AssertNotExistsBean(t, &actions_model.ActionCache{ID: c2.ID})
+	unittest.AssertExistsAndLoadBean(t, &actions_model.ActionCache{ID: c3.ID, Complete: true, Size: 5})
+
+	require.NoError(t, CleanupCaches(t.Context()))
+	unittest.AssertExistsAndLoadBean(t, &actions_model.ActionCache{ID: c3.ID})
+
+	require.NoError(t, PurgeCaches(t.Context(), run.RepoID))
+	caches, err := db.Find[actions_model.ActionCache](t.Context(), actions_model.FindCachesOptions{RepoID: run.RepoID})
+	require.NoError(t, err)
+	assert.Empty(t, caches)
+}
@@ -223,6 +223,14 @@ func DeleteRun(ctx context.Context, run *actions_model.ActionRun) error {
 		recordsToDelete = append(recordsToDelete, &actions_model.ActionTaskOutput{
 			TaskID: tas.ID,
 		})
+		recordsToDelete = append(recordsToDelete, &actions_model.ActionTaskAnnotation{
+			RepoID: repoID,
+			TaskID: tas.ID,
+		})
+		recordsToDelete = append(recordsToDelete, &actions_model.ActionTaskSummary{
+			RepoID: repoID,
+			TaskID: tas.ID,
+		})
 	}
 	recordsToDelete = append(recordsToDelete, &actions_model.ActionArtifact{
 		RepoID: repoID,
@@ -115,7 +115,8 @@ func getCommitStatusEventNameAndCommitID(run *actions_model.ActionRun) (event, c
 			return "", "", errors.New("head of pull request is missing in event payload")
 		}
 		commitID = payload.PullRequest.Head.Sha
-	case webhook_module.HookEventRelease:
+	case webhook_module.HookEventRelease,
+		webhook_module.HookEventMergeGroup:
 		event = string(run.Event)
 		commitID = run.CommitSHA
 	default: // do nothing, return empty
@@ -131,6 +132,14 @@ func createCommitStatus(ctx context.Context, repo *repo_model.Repository, event,
 	}
 	ctxName := fmt.Sprintf("%s / %s (%s)", runName, job.Name, event)
 	ctxName = strings.TrimSpace(ctxName) // git_model.NewCommitStatus also trims spaces
+	if run.RequiredWorkflowID > 0 {
+		ctxName = RequiredWorkflowStatusContextPrefix + ctxName
+	} else {
+		// the prefix is reserved for the runs of the required workflows
+		for strings.HasPrefix(ctxName, RequiredWorkflowStatusContextPrefix) {
+			ctxName = strings.TrimSpace(strings.TrimPrefix(ctxName, RequiredWorkflowStatusContextPrefix))
+		}
+	}
 	state := toCommitStatus(job.Status)
 	if statuses, err := git_model.GetLatestCommitStatus(ctx, repo.ID, commitID, db.ListOptionsAll); err == nil {
 		for _, v := range statuses {
@@ -163,9 +172,20 @@ func createCommitStatus(ctx context.Context, repo *repo_model.Repository, event,
 		description = "Waiting to run"
 	case actions_model.StatusBlocked:
 		description = "Blocked by required conditions"
+	case actions_model.StatusPendingDeployment:
+		description = "Waiting for deployment protection rules"
 	default:
 		description = "Unknown status: " + strconv.Itoa(int(job.Status))
 	}
+	if job.TaskID > 0 && job.Status.IsDone() {
+		counts, err := actions_model.CountTaskAnnotationsByLevel(ctx, job.TaskID)
+		if err != nil {
+			return fmt.Errorf("CountTaskAnnotationsByLevel: %w", err)
+		}
+		if s := formatAnnotationCounts(counts); s != "" {
+			description += " (" + s + ")"
+		}
+	}
 
 	index, err := getIndexOfJob(ctx, job)
 	if err != nil {
@@ -191,7 +211,7 @@ func toCommitStatus(status actions_model.Status) commitstatus.CommitStatusState
 		return commitstatus.CommitStatusSuccess
 	case actions_model.StatusFailure, actions_model.StatusCancelled:
 		return commitstatus.CommitStatusFailure
-	case actions_model.StatusWaiting, actions_model.StatusBlocked, actions_model.StatusRunning:
+	case actions_model.StatusWaiting, actions_model.StatusBlocked, actions_model.StatusPendingDeployment, actions_model.StatusRunning:
 		return commitstatus.CommitStatusPending
 	case actions_model.StatusSkipped:
 		return commitstatus.CommitStatusSkipped
@@ -85,7 +85,7 @@ func EvaluateJobConcurrencyFillModel(ctx context.Context, run *actions_model.Act
 		return fmt.Errorf("find job needs and fill job results: %w", err)
 	}
 
-	inputs, err := getInputsFromRun(run)
+	inputs, err := getInputsOfJob(run, actionRunJob)
 	if err != nil {
 		return fmt.Errorf("get inputs: %w", err)
 	}
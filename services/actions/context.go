@@ -114,8 +114,12 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 	}
 
 	jobIDJobs := make(map[string][]*actions_model.ActionRunJob)
-	for _, job := range jobs {
-		jobIDJobs[job.JobID] = append(jobIDJobs[job.JobID], job)
+	for _, v := range jobs {
+		// the needs of a job only refer to the jobs of the same workflow
+		if v.ParentJobID != job.ParentJobID {
+			continue
+		}
+		jobIDJobs[v.JobID] = append(jobIDJobs[v.JobID], v)
 	}
 
 	ret := make(map[string]*TaskNeed, len(needs))
@@ -125,17 +129,13 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 		}
 		var jobOutputs map[string]string
 		for _, job := range jobsWithSameID {
-			if job.TaskID == 0 || !job.Status.IsDone() {
+			if !job.Status.IsDone() {
 				// it shouldn't happen, or the job has been rerun
 				continue
 			}
-			got, err := actions_model.FindTaskOutputByTaskID(ctx, job.TaskID)
+			outputs, err := getJobOutputs(ctx, job)
 			if err != nil {
-				return nil, fmt.Errorf("FindTaskOutputByTaskID: %w", err)
-			}
-			outputs := make(map[string]string, len(got))
-			for _, v := range got {
-				outputs[v.OutputKey] = v.OutputValue
+				return nil, err
 			}
 			if len(jobOutputs) == 0 {
 				jobOutputs = outputs
@@ -0,0 +1,310 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	secret_model "code.gitea.io/gitea/models/secret"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/jobparser"
+	act_model "github.com/nektos/act/pkg/model"
+	"gopkg.in/yaml.v3"
+	"xorm.io/builder"
+)
+
+// readJobsRawEnvironment reads the raw "environment" of the jobs of a workflow by job id,
+// it isn't kept in the payloads of the jobs by the jobparser.
+func readJobsRawEnvironment(content []byte) (map[string]string, error) {
+	var workflow struct {
+		Jobs map[string]struct {
+			Environment yaml.Node `yaml:"environment"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(content, &workflow); err != nil {
+		return nil, err
+	}
+	envs := make(map[string]string)
+	for id, job := range workflow.Jobs {
+		if job.Environment.IsZero() {
+			continue
+		}
+		raw, err := yaml.Marshal(&job.Environment)
+		if err != nil {
+			return nil, fmt.Errorf("marshal environment of job %q: %w", id, err)
+		}
+		envs[id] = string(raw)
+	}
+	return envs, nil
+}
+
+// EvaluateJobEnvironmentFillModel evaluates the expressions in the job's environment and fills the job's model field with its name.
+// The environment is a name or a map with the name, which can refer to the outputs of the needed jobs, so it's evaluated by the job emitter.
+// See https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-syntax#jobsjob_idenvironment
+func EvaluateJobEnvironmentFillModel(ctx context.Context, run *actions_model.ActionRun, actionRunJob *actions_model.ActionRunJob, vars map[string]string) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(actionRunJob.RawEnvironment), &node); err != nil {
+		return fmt.Errorf("unmarshal raw environment: %w", err)
+	}
+	if node.Kind != yaml.DocumentNode || len(node.Content) == 0 {
+		return fmt.Errorf("invalid raw environment %q", actionRunJob.RawEnvironment)
+	}
+
+	workflowJob, err := actionRunJob.ParseJob()
+	if err != nil {
+		return fmt.Errorf("load job %d: %w", actionRunJob.ID, err)
+	}
+	jobResults, err := findJobNeedsAndFillJobResults(ctx, actionRunJob)
+	if err != nil {
+		return fmt.Errorf("find job needs and fill job results: %w", err)
+	}
+	inputs, err := getInputsOfJob(run, actionRunJob)
+	if err != nil {
+		return fmt.Errorf("get inputs: %w", err)
+	}
+
+	actJob := &act_model.Job{Strategy: &act_model.Strategy{RawMatrix: workflowJob.Strategy.RawMatrix}}
+	matrix := map[string]any{}
+	matrixes, err := actJob.GetMatrixes()
+	if err != nil {
+		return fmt.Errorf("get matrix: %w", err)
+	}
+	if len(matrixes) > 0 {
+		matrix = matrixes[0]
+	}
+
+	giteaCtx := GenerateGiteaContext(run, actionRunJob)
+	evaluator := jobparser.NewExpressionEvaluator(jobparser.NewInterpeter(actionRunJob.JobID, actJob, matrix, giteaCtx.ToGitHubContext(), jobResults, vars, inputs))
+	// the evaluator doesn't walk into the document node
+	if err := evaluator.EvaluateYamlNode(node.Content[0]); err != nil {
+		return fmt.Errorf("evaluate environment: %w", err)
+	}
+
+	var evaluated any
+	if err := node.Decode(&evaluated); err != nil {
+		return fmt.Errorf("decode evaluated environment: %w", err)
+	}
+	var name string
+	switch v := evaluated.(type) {
+	case string:
+		name = v
+	case map[string]any:
+		name, _ = v["name"].(string)
+	}
+	actionRunJob.Environment = util.EllipsisDisplayString(strings.TrimSpace(name), actions_model.EnvironmentNameMaxLength)
+	return nil
+}
+
+// prepareToStartJobWithEnvironment creates the deployment of a job which is ready to start and targets an environment.
+// It returns StatusWaiting if the job can start now, StatusPendingDeployment if the deployment has to be reviewed or wait for the timer,
+// or StatusFailure if the ref of the run can't deploy to the environment.
+func prepareToStartJobWithEnvironment(ctx context.Context, job *actions_model.ActionRunJob, vars map[string]string) (actions_model.Status, error) {
+	if err := EvaluateJobEnvironmentFillModel(ctx, job.Run, job, vars); err != nil {
+		return actions_model.StatusBlocked, err
+	}
+	if _, err := actions_model.UpdateRunJob(ctx, job, nil, "environment"); err != nil {
+		return actions_model.StatusBlocked, err
+	}
+	if job.Environment == "" {
+		// e.g. the expression has been evaluated to an empty string
+		return actions_model.StatusWaiting, nil
+	}
+
+	env, err := actions_model.GetOrCreateEnvironment(ctx, job.RepoID, job.Environment)
+	if err != nil {
+		return actions_model.StatusBlocked, fmt.Errorf("GetOrCreateEnvironment: %w", err)
+	}
+
+	deployment := &actions_model.ActionDeployment{
+		RepoID:        job.RepoID,
+		EnvironmentID: env.ID,
+		RunID:         job.RunID,
+		RunJobID:      job.ID,
+		Ref:           job.Run.Ref,
+		CommitSHA:     job.CommitSHA,
+		TriggerUserID: job.Run.TriggerUserID,
+		WaitUntil:     timeutil.TimeStampNow().Add(env.WaitTimer * 60),
+	}
+	status := actions_model.StatusWaiting
+	switch {
+	case !env.CanDeployRef(job.Run.Ref):
+		deployment.State = actions_model.DeploymentStateNotAllowed
+		status = actions_model.StatusFailure
+	case len(env.RequiredReviewerIDs) > 0:
+		deployment.State = actions_model.DeploymentStatePending
+		status = actions_model.StatusPendingDeployment
+	default:
+		deployment.State = actions_model.DeploymentStateApproved
+		if env.WaitTimer > 0 {
+			status = actions_model.StatusPendingDeployment
+		}
+	}
+	if err := db.Insert(ctx, deployment); err != nil {
+		return actions_model.StatusBlocked, err
+	}
+	return status, nil
+}
+
+// getPendingDeployment returns the deployment of a job waiting for a review, and the job
+func getPendingDeployment(ctx context.Context, deployment *actions_model.ActionDeployment) (*actions_model.ActionRunJob, error) {
+	if !deployment.IsWaitingForReview() {
+		return nil, util.NewInvalidArgumentErrorf("deployment %d has been reviewed", deployment.ID)
+	}
+	job, err := actions_model.GetRunJobByID(ctx, deployment.RunJobID)
+	if err != nil {
+		return nil, err
+	}
+	if !job.Status.IsPendingDeployment() {
+		return nil, util.NewInvalidArgumentErrorf("job %d isn't waiting for the deployment", job.ID)
+	}
+	return job, nil
+}
+
+// ApproveDeployment approves a deployment waiting for a review, the job starts when the wait timer of the environment has passed.
+// The doer must be one of the required reviewers of the environment.
+func ApproveDeployment(ctx context.Context, deployment *actions_model.ActionDeployment, doer *user_model.User, comment string) error {
+	var updatedJobs []*actions_model.ActionRunJob
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		job, err := reviewDeployment(ctx, deployment, doer, comment, actions_model.DeploymentStateApproved)
+		if err != nil {
+			return err
+		}
+		if deployment.WaitUntil > timeutil.TimeStampNow() {
+			return nil
+		}
+		job.Status = actions_model.StatusWaiting
+		if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusPendingDeployment}, "status"); err != nil {
+			return err
+		} else if n > 0 {
+			updatedJobs = append(updatedJobs, job)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	notifyWorkflowJobStatusUpdate(ctx, updatedJobs)
+	return nil
+}
+
+// RejectDeployment rejects a deployment waiting for a review, the job fails and the jobs which need it will be skipped.
+// The doer must be one of the required reviewers of the environment.
+func RejectDeployment(ctx context.Context, deployment *actions_model.ActionDeployment, doer *user_model.User, comment string) error {
+	var updatedJobs []*actions_model.ActionRunJob
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		job, err := reviewDeployment(ctx, deployment, doer, comment, actions_model.DeploymentStateRejected)
+		if err != nil {
+			return err
+		}
+		job.Status = actions_model.StatusFailure
+		job.Stopped = timeutil.TimeStampNow()
+		if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusPendingDeployment}, "status", "stopped"); err != nil {
+			return err
+		} else if n > 0 {
+			updatedJobs = append(updatedJobs, job)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	notifyWorkflowJobStatusUpdate(ctx, updatedJobs)
+	EmitJobsIfReadyByJobs(updatedJobs)
+	return nil
+}
+
+func reviewDeployment(ctx context.Context, deployment *actions_model.ActionDeployment, doer *user_model.User, comment string, state actions_model.DeploymentState) (*actions_model.ActionRunJob, error) {
+	job, err := getPendingDeployment(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+	env, err := actions_model.GetEnvironmentByID(ctx, deployment.RepoID, deployment.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	if !env.IsRequiredReviewer(doer.ID) {
+		return nil, util.NewPermissionDeniedErrorf("user %d can't review the deployments to environment %q", doer.ID, env.Name)
+	}
+
+	deployment.State = state
+	deployment.ReviewerID = doer.ID
+	deployment.ReviewComment = comment
+	deployment.ReviewedUnix = timeutil.TimeStampNow()
+	if updated, err := actions_model.UpdateDeployment(ctx, deployment, actions_model.DeploymentStatePending); err != nil {
+		return nil, err
+	} else if !updated {
+		return nil, util.NewInvalidArgumentErrorf("deployment %d has been reviewed", deployment.ID)
+	}
+	return job, nil
+}
+
+// StartApprovedDeployments starts the jobs of the approved deployments whose wait timer has passed
+func StartApprovedDeployments(ctx context.Context) error {
+	jobs, err := db.Find[actions_model.ActionRunJob](ctx, actions_model.FindRunJobOptions{
+		Statuses: []actions_model.Status{actions_model.StatusPendingDeployment},
+	})
+	if err != nil {
+		return err
+	}
+
+	now := timeutil.TimeStampNow()
+	var updatedJobs []*actions_model.ActionRunJob
+	for _, job := range jobs {
+		deployment, err := actions_model.GetLatestDeploymentOfJob(ctx, job.ID)
+		if err != nil {
+			log.Error("GetLatestDeploymentOfJob %d: %v", job.ID, err)
+			continue
+		}
+		if deployment.State != actions_model.DeploymentStateApproved || deployment.WaitUntil > now {
+			continue
+		}
+		job.Status = actions_model.StatusWaiting
+		if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusPendingDeployment}, "status"); err != nil {
+			log.Error("Start job %d of deployment %d: %v", job.ID, deployment.ID, err)
+		} else if n > 0 {
+			updatedJobs = append(updatedJobs, job)
+		}
+	}
+	notifyWorkflowJobStatusUpdate(ctx, updatedJobs)
+	return nil
+}
+
+// DeleteEnvironment deletes an environment of a repository with its secrets, variables and deployments history.
+// The jobs waiting for its deployments are cancelled, they could never be approved anymore.
+func DeleteEnvironment(ctx context.Context, env *actions_model.ActionEnvironment) error {
+	var cancelledJobs []*actions_model.ActionRunJob
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		var jobs []*actions_model.ActionRunJob
+		if err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": env.RepoID, "status": actions_model.StatusPendingDeployment}).
+			And(builder.In("id", builder.Select("run_job_id").From("action_deployment").Where(builder.Eq{"environment_id": env.ID}))).
+			Find(&jobs); err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			job.Status = actions_model.StatusCancelled
+			job.Stopped = timeutil.TimeStampNow()
+			if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusPendingDeployment}, "status", "stopped"); err != nil {
+				return err
+			} else if n > 0 {
+				cancelledJobs = append(cancelledJobs, job)
+			}
+		}
+
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": env.RepoID, "environment_id": env.ID}).Delete(new(secret_model.Secret)); err != nil {
+			return err
+		}
+		return actions_model.DeleteEnvironment(ctx, env)
+	}); err != nil {
+		return err
+	}
+	notifyWorkflowJobStatusUpdate(ctx, cancelledJobs)
+	EmitJobsIfReadyByJobs(cancelledJobs)
+	return nil
+}
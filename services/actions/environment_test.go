@@ -0,0 +1,155 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJobsRawEnvironment(t *testing.T) {
+	envs, err := readJobsRawEnvironment([]byte(`
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  deploy:
+    environment: production
+  release:
+    environment:
+      name: ${{ needs.build.outputs.env }}
+      url: https://example.com
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"deploy":  "production\n",
+		"release": "name: ${{ needs.build.outputs.env }}\nurl: https://example.com\n",
+	}, envs)
+}
+
+func TestDeploymentProtectionRules(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	run := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRun{ID: 793})
+	require.NoError(t, run.LoadAttributes(t.Context()))
+	reviewer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	other := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	require.NoError(t, actions_model.CreateEnvironment(t.Context(), &actions_model.ActionEnvironment{
+		RepoID:              run.RepoID,
+		Name:                "production",
+		RequiredReviewerIDs: []int64{reviewer.ID},
+	}))
+	require.NoError(t, actions_model.CreateEnvironment(t.Context(), &actions_model.ActionEnvironment{
+		RepoID:        run.RepoID,
+		Name:          "release",
+		BranchFilters: []string{"release/*"},
+	}))
+
+	insertJob := func(t *testing.T, jobID, rawEnvironment string) *actions_model.ActionRunJob {
+		job := &actions_model.ActionRunJob{
+			RunID:           run.ID,
+			Run:             run,
+			RepoID:          run.RepoID,
+			OwnerID:         run.OwnerID,
+			JobID:           jobID,
+			Name:            jobID,
+			Status:          actions_model.StatusBlocked,
+			RawEnvironment:  rawEnvironment,
+			WorkflowPayload: []byte("name: test\non: push\njobs:\n  " + jobID + ":\n    runs-on: ubuntu-latest\n"),
+		}
+		require.NoError(t, db.Insert(t.Context(), job))
+		return job
+	}
+
+	t.Run("NewEnvironment", func(t *testing.T) {
+		job := insertJob(t, "staging", "name: ${{ vars.ENV_NAME }}\n")
+		status, err := prepareToStartJobWithEnvironment(t.Context(), job, map[string]string{"ENV_NAME": "Staging"})
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusWaiting, status)
+		assert.Equal(t, "Staging", job.Environment)
+
+		env, err := actions_model.GetEnvironmentByName(t.Context(), run.RepoID, "staging")
+		require.NoError(t, err)
+		deployment, err := actions_model.GetLatestDeploymentOfJob(t.Context(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, env.ID, deployment.EnvironmentID)
+		assert.Equal(t, actions_model.DeploymentStateApproved, deployment.State)
+	})
+
+	t.Run("BranchFilters", func(t *testing.T) {
+		job := insertJob(t, "release", "release\n")
+		status, err := prepareToStartJobWithEnvironment(t.Context(), job, nil)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusFailure, status)
+		deployment, err := actions_model.GetLatestDeploymentOfJob(t.Context(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.DeploymentStateNotAllowed, deployment.State)
+	})
+
+	t.Run("RequiredReviewers", func(t *testing.T) {
+		job := insertJob(t, "production", "production\n")
+		status, err := prepareToStartJobWithEnvironment(t.Context(), job, nil)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusPendingDeployment, status)
+		job.Status = status
+		_, err = actions_model.UpdateRunJob(t.Context(), job, nil, "status")
+		require.NoError(t, err)
+
+		deployment, err := actions_model.GetLatestDeploymentOfJob(t.Context(), job.ID)
+		require.NoError(t, err)
+		assert.True(t, deployment.IsWaitingForReview())
+
+		err = RejectDeployment(t.Context(), deployment, other, "")
+		assert.ErrorIs(t, err, util.ErrPermissionDenied)
+
+		require.NoError(t, ApproveDeployment(t.Context(), deployment, reviewer, "ship it"))
+		job = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID})
+		assert.Equal(t, actions_model.StatusWaiting, job.Status)
+		deployment = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionDeployment{ID: deployment.ID})
+		assert.Equal(t, actions_model.DeploymentStateApproved, deployment.State)
+		assert.Equal(t, reviewer.ID, deployment.ReviewerID)
+		assert.Equal(t, "ship it", deployment.ReviewComment)
+
+		err = ApproveDeployment(t.Context(), deployment, reviewer, "")
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("DeleteEnvironment", func(t *testing.T) {
+		jobEmitterQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "actions_ready_job", func(items ...*jobUpdate) []*jobUpdate { return nil })
+		go jobEmitterQueue.Run()
+		defer func() {
+			jobEmitterQueue.ShutdownWait(time.Second)
+			jobEmitterQueue = nil
+		}()
+
+		job := insertJob(t, "production-2", "production\n")
+		status, err := prepareToStartJobWithEnvironment(t.Context(), job, nil)
+		require.NoError(t, err)
+		require.Equal(t, actions_model.StatusPendingDeployment, status)
+		job.Status = status
+		_, err = actions_model.UpdateRunJob(t.Context(), job, nil, "status")
+		require.NoError(t, err)
+
+		// the job waiting for the deleted environment could never be approved
+		env, err := actions_model.GetEnvironmentByName(t.Context(), run.RepoID, "production")
+		require.NoError(t, err)
+		require.NoError(t, DeleteEnvironment(t.Context(), env))
+		unittest.AssertNotExistsBean(t, &actions_model.ActionEnvironment{ID: env.ID})
+		job = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID})
+		assert.Equal(t, actions_model.StatusCancelled, job.Status)
+		assert.NotZero(t, job.Stopped)
+	})
+}
@@ -27,6 +27,10 @@ type API interface {
 	GetRegistrationToken(*context.APIContext)
 	// CreateRegistrationToken get registration token
 	CreateRegistrationToken(*context.APIContext)
+	// GenerateRunnerJITConfig create a runner and get its just-in-time config
+	GenerateRunnerJITConfig(*context.APIContext)
+	// ListRunnerQueues list the waiting jobs grouped by labels
+	ListRunnerQueues(*context.APIContext)
 	// ListRunners list runners
 	ListRunners(*context.APIContext)
 	// GetRunner get a runner
@@ -10,6 +10,7 @@ import (
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
+	actions_module "code.gitea.io/gitea/modules/actions"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/graceful"
 	"code.gitea.io/gitea/modules/log"
@@ -18,6 +19,7 @@ import (
 	"code.gitea.io/gitea/modules/util"
 	notify_service "code.gitea.io/gitea/services/notify"
 
+	"github.com/nektos/act/pkg/jobparser"
 	"xorm.io/builder"
 )
 
@@ -212,7 +214,10 @@ func checkJobsOfRun(ctx context.Context, run *actions_model.ActionRun) (jobs, up
 			job.Run = run
 		}
 
-		updates := newJobStatusResolver(jobs, vars).Resolve(ctx)
+		resolver := newJobStatusResolver(jobs, vars)
+		updates := resolver.Resolve(ctx)
+		// the jobs of the called reusable workflows may have been inserted
+		jobs = resolver.jobs
 		for _, job := range jobs {
 			if status, ok := updates[job.ID]; ok {
 				job.Status = status
@@ -244,41 +249,66 @@ func NotifyWorkflowRunStatusUpdateWithReload(ctx context.Context, job *actions_m
 type jobStatusResolver struct {
 	statuses map[int64]actions_model.Status
 	needs    map[int64][]int64
-	jobMap   map[int64]*actions_model.ActionRunJob
-	vars     map[string]string
+	// waits are the needs of the callers of a job in a called reusable workflow,
+	// the job can't start before they are done, but it doesn't care about their results
+	waits  map[int64][]int64
+	jobs   actions_model.ActionJobList
+	jobMap map[int64]*actions_model.ActionRunJob
+	vars   map[string]string
+	// jobsAdded is set when the jobs of a called reusable workflow have been inserted
+	jobsAdded bool
 }
 
 func newJobStatusResolver(jobs actions_model.ActionJobList, vars map[string]string) *jobStatusResolver {
-	idToJobs := make(map[string][]*actions_model.ActionRunJob, len(jobs))
-	jobMap := make(map[int64]*actions_model.ActionRunJob)
-	for _, job := range jobs {
-		idToJobs[job.JobID] = append(idToJobs[job.JobID], job)
-		jobMap[job.ID] = job
+	r := &jobStatusResolver{
+		statuses: make(map[int64]actions_model.Status, len(jobs)),
+		jobMap:   make(map[int64]*actions_model.ActionRunJob, len(jobs)),
+		vars:     vars,
 	}
+	r.addJobs(jobs...)
+	return r
+}
 
-	statuses := make(map[int64]actions_model.Status, len(jobs))
-	needs := make(map[int64][]int64, len(jobs))
+func (r *jobStatusResolver) addJobs(jobs ...*actions_model.ActionRunJob) {
 	for _, job := range jobs {
-		statuses[job.ID] = job.Status
+		r.jobs = append(r.jobs, job)
+		r.jobMap[job.ID] = job
+		r.statuses[job.ID] = job.Status
+	}
+
+	// the needs of a job only refer to the jobs of the same workflow
+	type scopedJobID struct {
+		parentJobID int64
+		jobID       string
+	}
+	idToJobs := make(map[scopedJobID][]*actions_model.ActionRunJob, len(r.jobs))
+	for _, job := range r.jobs {
+		key := scopedJobID{job.ParentJobID, job.JobID}
+		idToJobs[key] = append(idToJobs[key], job)
+	}
+
+	r.needs = make(map[int64][]int64, len(r.jobs))
+	for _, job := range r.jobs {
 		for _, need := range job.Needs {
-			for _, v := range idToJobs[need] {
-				needs[job.ID] = append(needs[job.ID], v.ID)
+			for _, v := range idToJobs[scopedJobID{job.ParentJobID, need}] {
+				r.needs[job.ID] = append(r.needs[job.ID], v.ID)
 			}
 		}
 	}
-	return &jobStatusResolver{
-		statuses: statuses,
-		needs:    needs,
-		jobMap:   jobMap,
-		vars:     vars,
+	r.waits = make(map[int64][]int64, len(r.jobs))
+	for _, job := range r.jobs {
+		for caller := r.jobMap[job.ParentJobID]; caller != nil; caller = r.jobMap[caller.ParentJobID] {
+			r.waits[job.ID] = append(r.waits[job.ID], r.needs[caller.ID]...)
+		}
 	}
 }
 
 func (r *jobStatusResolver) Resolve(ctx context.Context) map[int64]actions_model.Status {
 	ret := map[int64]actions_model.Status{}
 	for i := 0; i < len(r.statuses); i++ {
+		r.jobsAdded = false
 		updated := r.resolve(ctx)
-		if len(updated) == 0 {
+		if len(updated) == 0 && !r.jobsAdded {
 			return ret
 		}
 		for k, v := range updated {
@@ -300,6 +330,11 @@ func (r *jobStatusResolver) resolveCheckNeeds(id int64) (allDone, allSucceed boo
 			allSucceed = false
 		}
 	}
+	for _, wait := range r.waits[id] {
+		if !r.statuses[wait].IsDone() {
+			allDone = false
+		}
+	}
 	return allDone, allSucceed
 }
 
@@ -323,6 +358,20 @@ func (r *jobStatusResolver) resolve(ctx context.Context) map[int64]actions_model
 			continue
 		}
 
+		if actionRunJob.RawMatrix != "" {
+			if newStatus := r.resolveDynamicMatrix(ctx, actionRunJob, allSucceed); newStatus != actions_model.StatusBlocked {
+				ret[id] = newStatus
+			}
+			continue
+		}
+
+		if callerJob, ref, ok := parseReusableWorkflowCall(actionRunJob); ok {
+			if newStatus := r.resolveReusableWorkflowCall(ctx, actionRunJob, callerJob, ref); newStatus != actions_model.StatusBlocked {
+				ret[id] = newStatus
+			}
+			continue
+		}
+
 		// update concurrency and check whether the job can run now
 		err := updateConcurrencyEvaluationForJobWithNeeds(ctx, actionRunJob, r.vars)
 		if err != nil {
@@ -349,6 +398,17 @@ func (r *jobStatusResolver) resolve(ctx context.Context) map[int64]actions_model
 				log.Error("ShouldBlockJobByConcurrency failed, this job will stay blocked: job: %d, err: %v", id, err)
 			}
 		}
+		// a job targeting an environment can only start when the protection rules of the environment are satisfied
+		if newStatus == actions_model.StatusWaiting && actionRunJob.RawEnvironment != "" {
+			if actionRunJob.Run == nil || actionRunJob.Run.NeedApproval {
+				continue
+			}
+			newStatus, err = prepareToStartJobWithEnvironment(ctx, actionRunJob, r.vars)
+			if err != nil {
+				// TODO: like the concurrency, an invalid environment expression should be shown to the users
+				log.Error("prepareToStartJobWithEnvironment failed, this job will stay blocked: job: %d, err: %v", id, err)
+			}
+		}
 
 		if newStatus != actions_model.StatusBlocked {
 			ret[id] = newStatus
@@ -357,6 +417,89 @@ func (r *jobStatusResolver) resolve(ctx context.Context) map[int64]actions_model
 	return ret
 }
 
+// resolveReusableWorkflowCall calls the reusable workflow when the needs of the caller job are done,
+// and returns the aggregated status of the called jobs when all of them are done.
+func (r *jobStatusResolver) resolveReusableWorkflowCall(ctx context.Context, caller *actions_model.ActionRunJob, callerJob *jobparser.Job, ref *actions_module.ReusableWorkflowRef) actions_model.Status {
+	if caller.Run == nil || caller.Run.NeedApproval {
+		return actions_model.StatusBlocked
+	}
+
+	var calledJobs []*actions_model.ActionRunJob
+	for _, job := range r.jobs {
+		if job.ParentJobID == caller.ID {
+			calledJobs = append(calledJobs, job)
+		}
+	}
+
+	if len(calledJobs) > 0 {
+		for _, job := range calledJobs {
+			if !r.statuses[job.ID].IsDone() {
+				return actions_model.StatusBlocked
+			}
+			job.Status = r.statuses[job.ID]
+		}
+		if err := finishReusableWorkflowCall(ctx, caller, callerJob, calledJobs, r.vars); err != nil {
+			log.Error("finishReusableWorkflowCall failed, this job will stay blocked: job: %d, err: %v", caller.ID, err)
+			return actions_model.StatusBlocked
+		}
+		return actions_model.AggregateJobStatus(calledJobs)
+	}
+
+	depth := 1
+	for v := r.jobMap[caller.ParentJobID]; v != nil; v = r.jobMap[v.ParentJobID] {
+		depth++
+	}
+	if depth > maxReusableWorkflowDepth {
+		log.Warn("Job %d can't call %q: reusable workflows are nested too deeply", caller.ID, callerJob.Uses)
+		return actions_model.StatusFailure
+	}
+
+	interpreter, err := newJobInterpreter(ctx, caller, callerJob, r.vars, nil, nil)
+	if err != nil {
+		log.Error("newJobInterpreter failed, this job will stay blocked: job: %d, err: %v", caller.ID, err)
+		return actions_model.StatusBlocked
+	}
+	shouldCall, err := evaluateReusableWorkflowCallIf(interpreter, callerJob)
+	if err != nil {
+		log.Warn("Job %d has an invalid if condition: %v", caller.ID, err)
+		return actions_model.StatusFailure
+	}
+	if !shouldCall {
+		return actions_model.StatusSkipped
+	}
+
+	jobs, err := expandReusableWorkflowCall(ctx, caller, callerJob, ref, r.vars)
+	if err != nil {
+		// TODO: the error should be shown to the users
+		log.Warn("Job %d failed to call %q: %v", caller.ID, callerJob.Uses, err)
+		return actions_model.StatusFailure
+	}
+	r.addJobs(jobs...)
+	r.jobsAdded = true
+	return actions_model.StatusBlocked
+}
+
+// resolveDynamicMatrix expands the matrix of a job when its needs are done,
+// the jobs of the matrix stay blocked and are resolved like other jobs in the next round.
+func (r *jobStatusResolver) resolveDynamicMatrix(ctx context.Context, job *actions_model.ActionRunJob, allSucceed bool) actions_model.Status {
+	if job.Run == nil || job.Run.NeedApproval {
+		return actions_model.StatusBlocked
+	}
+	if !allSucceed && !r.resolveJobHasIfCondition(job) {
+		return actions_model.StatusSkipped
+	}
+
+	jobs, err := expandDynamicMatrix(ctx, job, r.vars)
+	if err != nil {
+		// TODO: the error should be shown to the users
+		log.Warn("Job %d failed to expand its matrix: %v", job.ID, err)
+		return actions_model.StatusFailure
+	}
+	r.addJobs(jobs...)
+	r.jobsAdded = true
+	return actions_model.StatusBlocked
+}
+
 func updateConcurrencyEvaluationForJobWithNeeds(ctx context.Context, actionRunJob *actions_model.ActionRunJob, vars map[string]string) error {
 	if setting.IsInTesting && actionRunJob.RepoID == 0 {
 		return nil // for testing purpose only, no repo, no evaluation
@@ -126,6 +126,42 @@ jobs:
 			},
 			want: map[int64]actions_model.Status{2: actions_model.StatusSkipped},
 		},
+		{
+			name: "jobs of a called workflow wait for the needs of the caller",
+			jobs: actions_model.ActionJobList{
+				{ID: 1, JobID: "job1", Status: actions_model.StatusRunning, Needs: []string{}},
+				{ID: 2, JobID: "call", Status: actions_model.StatusBlocked, Needs: []string{"job1"}, WorkflowPayload: []byte(
+					`
+name: test
+on: push
+jobs:
+  call:
+    needs: job1
+    uses: ./.gitea/workflows/called.yml
+`)},
+				{ID: 3, JobID: "build", Status: actions_model.StatusBlocked, Needs: []string{}, ParentJobID: 2},
+			},
+			want: map[int64]actions_model.Status{},
+		},
+		{
+			name: "needs of a called workflow only refer to its own jobs",
+			jobs: actions_model.ActionJobList{
+				{ID: 1, JobID: "build", Status: actions_model.StatusSuccess, Needs: []string{}},
+				{ID: 2, JobID: "call", Status: actions_model.StatusBlocked, Needs: []string{"build"}, WorkflowPayload: []byte(
+					`
+name: test
+on: push
+jobs:
+  call:
+    needs: build
+    uses: ./.gitea/workflows/called.yml
+`)},
+				{ID: 3, JobID: "build", Status: actions_model.StatusRunning, Needs: []string{}, ParentJobID: 2},
+				{ID: 4, JobID: "test", Status: actions_model.StatusBlocked, Needs: []string{"build"}, ParentJobID: 2},
+				{ID: 5, JobID: "lint", Status: actions_model.StatusBlocked, Needs: []string{}, ParentJobID: 2},
+			},
+			want: map[int64]actions_model.Status{5: actions_model.StatusWaiting},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
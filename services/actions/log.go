@@ -0,0 +1,137 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	actions_module "code.gitea.io/gitea/modules/actions"
+)
+
+// RunLogMatch is a line of the logs of a job of a run which matches a search
+type RunLogMatch struct {
+	JobIndex  int // the index of the job in the jobs of the run, it's used in the links of the jobs
+	Job       *actions_model.ActionRunJob
+	StepIndex int // the index of the step in the full steps of the task, including "Set up job" and "Complete job"
+	StepName  string
+	Line      int64 // the line number in the logs of the step, starting from 1
+	Content   string
+}
+
+// Link returns the link to the line in the logs of the job, like "/owner/repo/actions/runs/1/jobs/0#jobstep-1-10"
+func (m *RunLogMatch) Link(runLink string) string {
+	return fmt.Sprintf("%s/jobs/%d#jobstep-%d-%d", runLink, m.JobIndex, m.StepIndex, m.Line)
+}
+
+// getJobLogTask returns the latest task of a job if its logs are available
+func getJobLogTask(ctx context.Context, job *actions_model.ActionRunJob) (*actions_model.ActionTask, error) {
+	if job.TaskID == 0 {
+		return nil, nil
+	}
+	task, err := actions_model.GetTaskByID(ctx, job.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("GetTaskByID: %w", err)
+	}
+	if task.LogExpired {
+		return nil, nil
+	}
+	return task, nil
+}
+
+// SearchRunLogs searches the logs of the latest attempts of the jobs of a run, the jobs must be all jobs of the run in their order.
+// The keyword is matched case-insensitively, up to limit lines are returned.
+func SearchRunLogs(ctx context.Context, jobs actions_model.ActionJobList, keyword string, limit int) ([]*RunLogMatch, error) {
+	var matches []*RunLogMatch
+	for i, job := range jobs {
+		if len(matches) >= limit {
+			break
+		}
+		task, err := getJobLogTask(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+		if task == nil {
+			continue
+		}
+		if task.Steps, err = actions_model.GetTaskStepsByTaskID(ctx, task.ID); err != nil {
+			return nil, fmt.Errorf("GetTaskStepsByTaskID: %w", err)
+		}
+		steps := actions_module.FullSteps(task)
+
+		lines, err := searchTaskLogs(ctx, task, keyword, limit-len(matches))
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range lines {
+			for stepIndex, step := range steps {
+				if line.Index >= step.LogIndex && line.Index < step.LogIndex+step.LogLength {
+					matches = append(matches, &RunLogMatch{
+						JobIndex:  i,
+						Job:       job,
+						StepIndex: stepIndex,
+						StepName:  step.Name,
+						Line:      line.Index - step.LogIndex + 1,
+						Content:   line.Content,
+					})
+					break
+				}
+			}
+		}
+	}
+	return matches, nil
+}
+
+func searchTaskLogs(ctx context.Context, task *actions_model.ActionTask, keyword string, limit int) ([]*actions_module.LogMatch, error) {
+	reader, err := actions_module.OpenLogs(ctx, task.LogInStorage, task.LogFilename)
+	if err != nil {
+		return nil, fmt.Errorf("OpenLogs: %w", err)
+	}
+	defer reader.Close()
+	return actions_module.SearchLogs(reader, keyword, limit)
+}
+
+var logArchiveNameReplacer = strings.NewReplacer("/", "_", "\\", "_", ":", "_", "*", "_", "?", "_", "\"", "_", "<", "_", ">", "_", "|", "_")
+
+// WriteRunLogsArchive writes the logs of the latest attempts of the jobs of a run to a zip archive,
+// the jobs must be all jobs of the run in their order. The jobs without logs are skipped.
+func WriteRunLogsArchive(ctx context.Context, w io.Writer, jobs actions_model.ActionJobList) error {
+	writer := zip.NewWriter(w)
+	for i, job := range jobs {
+		task, err := getJobLogTask(ctx, job)
+		if err != nil {
+			return err
+		}
+		if task == nil {
+			continue
+		}
+		if err := writeTaskLogsToArchive(ctx, writer, fmt.Sprintf("%d_%s.log", i+1, logArchiveNameReplacer.Replace(job.Name)), task); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func writeTaskLogsToArchive(ctx context.Context, writer *zip.Writer, name string, task *actions_model.ActionTask) error {
+	reader, err := actions_module.OpenLogs(ctx, task.LogInStorage, task.LogFilename)
+	if err != nil {
+		return fmt.Errorf("OpenLogs: %w", err)
+	}
+	defer reader.Close()
+
+	file, err := writer.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: task.Updated.AsTime(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, reader)
+	return err
+}
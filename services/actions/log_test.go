@@ -0,0 +1,90 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	actions_module "code.gitea.io/gitea/modules/actions"
+
+	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRunLogs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	rows := []*runnerv1.LogRow{
+		{Time: now, Content: "set up"},
+		{Time: now, Content: "go build"},
+		{Time: now, Content: "go test"},
+		{Time: now, Content: "--- FAIL: TestFoo"},
+		{Time: now, Content: "FAIL"},
+	}
+	_, err := actions_module.WriteLogs(t.Context(), "test/run-logs.log", 0, rows)
+	require.NoError(t, err)
+
+	task := &actions_model.ActionTask{
+		RepoID:      4,
+		Status:      actions_model.StatusFailure,
+		TokenHash:   "run-logs-test",
+		LogFilename: "test/run-logs.log",
+		LogLength:   int64(len(rows)),
+	}
+	require.NoError(t, db.Insert(t.Context(), task))
+	require.NoError(t, db.Insert(t.Context(), []*actions_model.ActionTaskStep{
+		{TaskID: task.ID, RepoID: 4, Index: 0, Name: "Build", Status: actions_model.StatusSuccess, LogIndex: 1, LogLength: 1},
+		{TaskID: task.ID, RepoID: 4, Index: 1, Name: "Test", Status: actions_model.StatusFailure, LogIndex: 2, LogLength: 2},
+	}))
+
+	jobs := actions_model.ActionJobList{
+		{ID: 1001, Name: "not started"},
+		{ID: 1002, Name: "build/test", TaskID: task.ID},
+	}
+
+	t.Run("Search", func(t *testing.T) {
+		matches, err := SearchRunLogs(t.Context(), jobs, "fail", 10)
+		require.NoError(t, err)
+		require.Len(t, matches, 2)
+		assert.Equal(t, 1, matches[0].JobIndex)
+		assert.Equal(t, 2, matches[0].StepIndex)
+		assert.Equal(t, "Test", matches[0].StepName)
+		assert.EqualValues(t, 2, matches[0].Line)
+		assert.Equal(t, "--- FAIL: TestFoo", matches[0].Content)
+		assert.Equal(t, "/user5/repo4/actions/runs/1/jobs/1#jobstep-2-2", matches[0].Link("/user5/repo4/actions/runs/1"))
+		assert.Equal(t, 3, matches[1].StepIndex)
+		assert.Equal(t, "Complete job", matches[1].StepName)
+		assert.EqualValues(t, 1, matches[1].Line)
+
+		matches, err = SearchRunLogs(t.Context(), jobs, "fail", 1)
+		require.NoError(t, err)
+		assert.Len(t, matches, 1)
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteRunLogsArchive(t.Context(), &buf, jobs))
+
+		reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		require.Len(t, reader.File, 1)
+		assert.Equal(t, "2_build_test.log", reader.File[0].Name)
+		f, err := reader.File[0].Open()
+		require.NoError(t, err)
+		defer f.Close()
+		content, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "--- FAIL: TestFoo\n")
+	})
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/jobparser"
+	act_model "github.com/nektos/act/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// maxMatrixJobs is the max number of jobs generated by a matrix, it's the same as GitHub
+const maxMatrixJobs = 256
+
+// dynamicMatrix is the raw matrix of a job which has expressions, like `${{ fromJSON(needs.setup.outputs.matrix) }}`.
+// The jobparser can't expand such a matrix, it's expanded by the job emitter when the needs of the job are done.
+type dynamicMatrix struct {
+	RawMatrix string
+	// RawRunsOn is the raw "runs-on" of the job, it can refer to the matrix
+	// but the jobparser has interpolated it with an empty matrix.
+	RawRunsOn yaml.Node
+}
+
+// readJobsDynamicMatrix reads the matrixes which have expressions of the jobs of a workflow by job id
+func readJobsDynamicMatrix(content []byte) (map[string]*dynamicMatrix, error) {
+	var workflow struct {
+		Jobs map[string]struct {
+			RunsOn   yaml.Node `yaml:"runs-on"`
+			Strategy struct {
+				Matrix yaml.Node `yaml:"matrix"`
+			} `yaml:"strategy"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(content, &workflow); err != nil {
+		return nil, err
+	}
+	matrixes := make(map[string]*dynamicMatrix)
+	for id, job := range workflow.Jobs {
+		if job.Strategy.Matrix.IsZero() {
+			continue
+		}
+		raw, err := yaml.Marshal(&job.Strategy.Matrix)
+		if err != nil {
+			return nil, fmt.Errorf("marshal matrix of job %q: %w", id, err)
+		}
+		if !strings.Contains(string(raw), "${{") {
+			continue
+		}
+		matrixes[id] = &dynamicMatrix{RawMatrix: string(raw), RawRunsOn: job.RunsOn}
+	}
+	return matrixes, nil
+}
+
+// evaluateDynamicMatrix evaluates the raw matrix of a job and returns its combinations in the order of their names
+func evaluateDynamicMatrix(ctx context.Context, job *actions_model.ActionRunJob, workflowJob *jobparser.Job, vars map[string]string) ([]map[string]any, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(job.RawMatrix), &node); err != nil {
+		return nil, fmt.Errorf("unmarshal raw matrix: %w", err)
+	}
+	if node.Kind != yaml.DocumentNode || len(node.Content) == 0 {
+		return nil, fmt.Errorf("invalid raw matrix %q", job.RawMatrix)
+	}
+	interpreter, err := newJobInterpreter(ctx, job, workflowJob, vars, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	matrixNode := node.Content[0]
+	if err := jobparser.NewExpressionEvaluator(interpreter).EvaluateYamlNode(matrixNode); err != nil {
+		return nil, fmt.Errorf("evaluate matrix: %w", err)
+	}
+	if matrixNode.Kind != yaml.MappingNode {
+		return nil, errors.New("matrix isn't a map")
+	}
+	var matrix map[string][]any
+	if err := matrixNode.Decode(&matrix); err != nil {
+		return nil, fmt.Errorf("matrix isn't a map of lists: %w", err)
+	}
+
+	matrixes, err := (&act_model.Job{Strategy: &act_model.Strategy{RawMatrix: *matrixNode}}).GetMatrixes()
+	if err != nil {
+		return nil, err
+	}
+	if len(matrixes) == 0 || (len(matrixes) == 1 && len(matrixes[0]) == 0) {
+		return nil, errors.New("matrix is empty")
+	}
+	if len(matrixes) > maxMatrixJobs {
+		return nil, fmt.Errorf("matrix has %d combinations, the limit is %d", len(matrixes), maxMatrixJobs)
+	}
+	sort.Slice(matrixes, func(i, j int) bool {
+		return matrixName(matrixes[i]) < matrixName(matrixes[j])
+	})
+	return matrixes, nil
+}
+
+// expandDynamicMatrix evaluates the matrix of a job when its needs are done, the job becomes the job of the first combination
+// and the jobs of the other combinations are inserted, like the jobs generated by the jobparser for a static matrix.
+func expandDynamicMatrix(ctx context.Context, job *actions_model.ActionRunJob, vars map[string]string) ([]*actions_model.ActionRunJob, error) {
+	var workflow jobparser.SingleWorkflow
+	if err := yaml.Unmarshal(job.WorkflowPayload, &workflow); err != nil {
+		return nil, fmt.Errorf("unmarshal workflow payload: %w", err)
+	}
+	id, workflowJob := workflow.Job()
+	if workflowJob == nil {
+		return nil, fmt.Errorf("no job in the workflow payload of job %d", job.ID)
+	}
+
+	matrixes, err := evaluateDynamicMatrix(ctx, job, workflowJob, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	namePrefix := ""
+	if job.ParentJobID > 0 {
+		caller, err := actions_model.GetRunJobByID(ctx, job.ParentJobID)
+		if err != nil {
+			return nil, err
+		}
+		namePrefix = caller.Name + " / "
+	}
+
+	jobs := make([]*actions_model.ActionRunJob, 0, len(matrixes)-1)
+	for i, matrix := range matrixes {
+		matrixJob := workflowJob.Clone()
+		matrixJob.Strategy.RawMatrix = encodeMatrix(matrix)
+		interpreter, err := newJobInterpreter(ctx, job, matrixJob, vars, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		evaluator := jobparser.NewExpressionEvaluator(interpreter)
+		if matrixJob.Name == "" {
+			matrixJob.Name = id
+		}
+		if strings.Contains(matrixJob.Name, "${{") {
+			matrixJob.Name = evaluator.Interpolate(matrixJob.Name)
+		} else {
+			matrixJob.Name += " " + matrixName(matrix)
+		}
+		runsOn := matrixJob.RunsOn()
+		for i, v := range runsOn {
+			runsOn[i] = evaluator.Interpolate(v)
+		}
+		if err := matrixJob.RawRunsOn.Encode(util.Iif[any](len(runsOn) == 1, runsOn[0], runsOn)); err != nil {
+			return nil, fmt.Errorf("encode runs-on: %w", err)
+		}
+
+		if err := workflow.SetJob(id, matrixJob); err != nil {
+			return nil, err
+		}
+		payload, err := workflow.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			job.Name = util.EllipsisDisplayString(namePrefix+matrixJob.Name, 255)
+			job.WorkflowPayload = payload
+			job.RunsOn = runsOn
+			job.RawMatrix = ""
+			if _, err := actions_model.UpdateRunJob(ctx, job, nil, "name", "workflow_payload", "runs_on", "raw_matrix"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		runJob := &actions_model.ActionRunJob{
+			RunID:             job.RunID,
+			Run:               job.Run,
+			RepoID:            job.RepoID,
+			OwnerID:           job.OwnerID,
+			CommitSHA:         job.CommitSHA,
+			IsForkPullRequest: job.IsForkPullRequest,
+			Name:              util.EllipsisDisplayString(namePrefix+matrixJob.Name, 255),
+			Attempt:           job.Attempt,
+			WorkflowPayload:   payload,
+			JobID:             job.JobID,
+			Needs:             job.Needs,
+			RunsOn:            runsOn,
+			Status:            actions_model.StatusBlocked,
+			ParentJobID:       job.ParentJobID,
+			RawConcurrency:    job.RawConcurrency,
+			RawEnvironment:    job.RawEnvironment,
+		}
+		if err := db.Insert(ctx, runJob); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, runJob)
+	}
+	return jobs, nil
+}
+
+// encodeMatrix encodes a combination of a matrix like the jobparser, each key has a list with a single value
+func encodeMatrix(matrix map[string]any) yaml.Node {
+	value := make(map[string][]any, len(matrix))
+	for k, v := range matrix {
+		value[k] = []any{v}
+	}
+	node := yaml.Node{}
+	_ = node.Encode(value)
+	return node
+}
+
+// matrixName returns the values of a combination of a matrix in the order of the keys, like "(ubuntu, 1.25)",
+// it's the same as the jobparser, which appends it to the names of the jobs
+func matrixName(matrix map[string]any) string {
+	keys := make([]string, 0, len(matrix))
+	for k := range matrix {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, fmt.Sprint(matrix[k]))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(values, ", "))
+}
+
+// FormatJobMatrix returns the combination of the matrix of a job like "os: ubuntu, version: 1.25",
+// it returns an empty string if the job has no matrix or its matrix hasn't been expanded.
+func FormatJobMatrix(job *actions_model.ActionRunJob) string {
+	if job.RawMatrix != "" {
+		return ""
+	}
+	workflowJob, err := job.ParseJob()
+	if err != nil || workflowJob.Strategy.RawMatrix.Kind != yaml.MappingNode {
+		return ""
+	}
+	var matrix map[string][]any
+	if err := workflowJob.Strategy.RawMatrix.Decode(&matrix); err != nil {
+		return ""
+	}
+	keys := make([]string, 0, len(matrix))
+	for k := range matrix {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if len(matrix[k]) > 0 {
+			values = append(values, fmt.Sprintf("%s: %v", k, matrix[k][0]))
+		}
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJobsDynamicMatrix(t *testing.T) {
+	matrixes, err := readJobsDynamicMatrix([]byte(`
+on: push
+jobs:
+  static:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+        os: [ubuntu, windows]
+  dynamic:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix: ${{ fromJSON(needs.setup.outputs.matrix) }}
+  versions:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix:
+        version: ${{ fromJSON(needs.setup.outputs.versions) }}
+`))
+	require.NoError(t, err)
+	require.Len(t, matrixes, 2)
+	assert.Equal(t, "${{ fromJSON(needs.setup.outputs.matrix) }}\n", matrixes["dynamic"].RawMatrix)
+	assert.Equal(t, "${{ matrix.os }}", matrixes["dynamic"].RawRunsOn.Value)
+	assert.Equal(t, "version: ${{ fromJSON(needs.setup.outputs.versions) }}\n", matrixes["versions"].RawMatrix)
+}
+
+func TestExpandDynamicMatrix(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	run := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRun{ID: 793})
+	require.NoError(t, run.LoadAttributes(t.Context()))
+
+	insertJob := func(t *testing.T, job *actions_model.ActionRunJob) *actions_model.ActionRunJob {
+		job.RunID = run.ID
+		job.Run = run
+		job.RepoID = run.RepoID
+		job.OwnerID = run.OwnerID
+		require.NoError(t, db.Insert(t.Context(), job))
+		return job
+	}
+	insertSetupJob := func(t *testing.T, jobID string, taskID int64, status actions_model.Status, matrix string) *actions_model.ActionRunJob {
+		require.NoError(t, db.Insert(t.Context(), &actions_model.ActionTaskOutput{TaskID: taskID, OutputKey: "matrix", OutputValue: matrix}))
+		return insertJob(t, &actions_model.ActionRunJob{
+			JobID:  jobID,
+			Name:   jobID,
+			TaskID: taskID,
+			Status: status,
+		})
+	}
+	insertMatrixJob := func(t *testing.T, jobID, need string) *actions_model.ActionRunJob {
+		return insertJob(t, &actions_model.ActionRunJob{
+			JobID:     jobID,
+			Name:      jobID,
+			Needs:     []string{need},
+			Status:    actions_model.StatusBlocked,
+			RawMatrix: "${{ fromJSON(needs." + need + ".outputs.matrix) }}\n",
+			WorkflowPayload: []byte(`
+name: test
+on: push
+jobs:
+  ` + jobID + `:
+    runs-on: ${{ matrix.os }}
+    steps:
+      - run: echo ${{ matrix.version }}
+`),
+		})
+	}
+
+	t.Run("Expand", func(t *testing.T) {
+		setup := insertSetupJob(t, "setup", 1000, actions_model.StatusSuccess, `{"os":["windows","ubuntu"],"version":["1.25"],"include":[{"os":"macos","version":"1.24"}]}`)
+		build := insertMatrixJob(t, "build", "setup")
+
+		resolver := newJobStatusResolver(actions_model.ActionJobList{setup, build}, nil)
+		updates := resolver.Resolve(t.Context())
+
+		jobs, err := db.Find[actions_model.ActionRunJob](t.Context(), actions_model.FindRunJobOptions{RunID: run.ID})
+		require.NoError(t, err)
+		var names []string
+		var runsOn [][]string
+		for _, job := range jobs {
+			if job.JobID != "build" {
+				continue
+			}
+			assert.Empty(t, job.RawMatrix)
+			assert.Equal(t, []string{"setup"}, job.Needs)
+			assert.Equal(t, actions_model.StatusWaiting, updates[job.ID])
+			names = append(names, job.Name)
+			runsOn = append(runsOn, job.RunsOn)
+		}
+		assert.Equal(t, []string{"build (macos, 1.24)", "build (ubuntu, 1.25)", "build (windows, 1.25)"}, names)
+		assert.Equal(t, [][]string{{"macos"}, {"ubuntu"}, {"windows"}}, runsOn)
+
+		// the placeholder becomes the job of the first combination
+		build = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: build.ID})
+		assert.Equal(t, "build (macos, 1.24)", build.Name)
+		assert.Equal(t, "os: macos, version: 1.24", FormatJobMatrix(build))
+	})
+
+	t.Run("InvalidMatrix", func(t *testing.T) {
+		setup := insertSetupJob(t, "setup-invalid", 1001, actions_model.StatusSuccess, `["ubuntu"]`)
+		build := insertMatrixJob(t, "build-invalid", "setup-invalid")
+
+		updates := newJobStatusResolver(actions_model.ActionJobList{setup, build}, nil).Resolve(t.Context())
+		assert.Equal(t, map[int64]actions_model.Status{build.ID: actions_model.StatusFailure}, updates)
+	})
+
+	t.Run("NeedsFailed", func(t *testing.T) {
+		setup := insertSetupJob(t, "setup-failed", 1002, actions_model.StatusFailure, "")
+		build := insertMatrixJob(t, "build-skipped", "setup-failed")
+
+		updates := newJobStatusResolver(actions_model.ActionJobList{setup, build}, nil).Resolve(t.Context())
+		assert.Equal(t, map[int64]actions_model.Status{build.ID: actions_model.StatusSkipped}, updates)
+		assert.Equal(t, build.RawMatrix, unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: build.ID}).RawMatrix)
+	})
+}
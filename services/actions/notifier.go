@@ -685,6 +685,37 @@ func (n *actionsNotifier) AutoMergePullRequest(ctx context.Context, doer *user_m
 	n.MergePullRequest(ctx, doer, pr)
 }
 
+func (n *actionsNotifier) MergeGroupChecksRequested(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, mergeGroupBranch, baseCommitID, headCommitID string) {
+	ctx = withMethod(ctx, "MergeGroupChecksRequested")
+
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+
+	if err := pr.Issue.LoadRepo(ctx); err != nil {
+		log.Error("pr.Issue.LoadRepo: %v", err)
+		return
+	}
+
+	headRef := git.RefNameFromBranch(mergeGroupBranch)
+	newNotifyInput(pr.Issue.Repo, doer, webhook_module.HookEventMergeGroup).
+		WithRef(headRef.String()).
+		WithPayload(&api.MergeGroupPayload{
+			Action: "checks_requested",
+			MergeGroup: &api.MergeGroup{
+				HeadSHA: headCommitID,
+				HeadRef: headRef.String(),
+				BaseSHA: baseCommitID,
+				BaseRef: git.RefNameFromBranch(pr.BaseBranch).String(),
+			},
+			PullRequest: convert.ToAPIPullRequest(ctx, pr, nil),
+			Repository:  convert.ToRepo(ctx, pr.Issue.Repo, access_model.Permission{AccessMode: perm_model.AccessModeNone}),
+			Sender:      convert.ToUser(ctx, doer, nil),
+		}).
+		Notify(ctx)
+}
+
 func (n *actionsNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
 	ctx = withMethod(ctx, "PullRequestSynchronized")
 
@@ -233,13 +233,20 @@ func notify(ctx context.Context, input *notifyInput) error {
 		}
 	}
 
+	// the required workflows of the organization can't be disabled in the repository
+	requiredWorkflows, err := detectRequiredWorkflows(ctx, gitRepo, commit, input)
+	if err != nil {
+		return err
+	}
+	detectedWorkflows = append(detectedWorkflows, requiredWorkflows...)
+
 	if shouldDetectSchedules {
 		if err := handleSchedules(ctx, schedules, commit, input, ref); err != nil {
 			return err
 		}
 	}
 
-	return handleWorkflows(ctx, detectedWorkflows, commit, input, ref)
+	return handleWorkflows(ctx, gitRepo, detectedWorkflows, commit, input, ref)
 }
 
 func skipWorkflows(ctx context.Context, input *notifyInput, commit *git.Commit) bool {
@@ -288,6 +295,7 @@ func skipWorkflows(ctx context.Context, input *notifyInput, commit *git.Commit)
 
 func handleWorkflows(
 	ctx context.Context,
+	gitRepo *git.Repository,
 	detectedWorkflows []*actions_module.DetectedWorkflow,
 	commit *git.Commit,
 	input *notifyInput,
@@ -320,23 +328,24 @@ func handleWorkflows(
 
 	for _, dwf := range detectedWorkflows {
 		run := &actions_model.ActionRun{
-			Title:             strings.SplitN(commit.CommitMessage, "\n", 2)[0],
-			RepoID:            input.Repo.ID,
-			Repo:              input.Repo,
-			OwnerID:           input.Repo.OwnerID,
-			WorkflowID:        dwf.EntryName,
-			TriggerUserID:     input.Doer.ID,
-			TriggerUser:       input.Doer,
-			Ref:               ref.String(),
-			CommitSHA:         commit.ID.String(),
-			IsForkPullRequest: isForkPullRequest,
-			Event:             input.Event,
-			EventPayload:      string(p),
-			TriggerEvent:      dwf.TriggerEvent.Name,
-			Status:            actions_model.StatusWaiting,
+			Title:              strings.SplitN(commit.CommitMessage, "\n", 2)[0],
+			RepoID:             input.Repo.ID,
+			Repo:               input.Repo,
+			OwnerID:            input.Repo.OwnerID,
+			WorkflowID:         dwf.EntryName,
+			TriggerUserID:      input.Doer.ID,
+			TriggerUser:        input.Doer,
+			Ref:                ref.String(),
+			CommitSHA:          commit.ID.String(),
+			IsForkPullRequest:  isForkPullRequest,
+			Event:              input.Event,
+			EventPayload:       string(p),
+			TriggerEvent:       dwf.TriggerEvent.Name,
+			RequiredWorkflowID: dwf.RequiredWorkflowID,
+			Status:             actions_model.StatusWaiting,
 		}
 
-		need, err := ifNeedApproval(ctx, run, input.Repo, input.Doer)
+		need, err := ifNeedApproval(ctx, run, input, gitRepo)
 		if err != nil {
 			log.Error("check if need approval for repo %d with user %d: %v", input.Repo.ID, input.Doer.ID, err)
 			continue
@@ -398,7 +407,9 @@ func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_mo
 		Notify(ctx)
 }
 
-func ifNeedApproval(ctx context.Context, run *actions_model.ActionRun, repo *repo_model.Repository, user *user_model.User) (bool, error) {
+func ifNeedApproval(ctx context.Context, run *actions_model.ActionRun, input *notifyInput, gitRepo *git.Repository) (bool, error) {
+	repo, user := input.Repo, input.Doer
+
 	// 1. don't need approval if it's not a fork PR
 	// 2. don't need approval if the event is `pull_request_target` since the workflow will run in the context of base branch
 	// 		see https://docs.github.com/en/actions/managing-workflow-runs/approving-workflow-runs-from-public-forks#about-workflow-runs-from-public-forks
@@ -420,6 +431,21 @@ func ifNeedApproval(ctx context.Context, run *actions_model.ActionRun, repo *rep
 		return false, nil
 	}
 
+	policy, err := actions_model.GetEffectiveApprovalPolicy(ctx, repo)
+	if err != nil {
+		return false, fmt.Errorf("GetEffectiveApprovalPolicy: %w", err)
+	}
+
+	// always need approval if the pull request modifies the workflows and the policy requires it
+	if policy.ApproveWorkflowChanges {
+		if changed, err := isWorkflowChangedByPullRequest(gitRepo, input.PullRequest, run.CommitSHA); err != nil {
+			return false, fmt.Errorf("isWorkflowChangedByPullRequest: %w", err)
+		} else if changed {
+			log.Trace("need approval because the pull request of user %d modifies the workflows", user.ID)
+			return true, nil
+		}
+	}
+
 	// don't need approval if the user has been approved before
 	if count, err := db.Count[actions_model.ActionRun](ctx, actions_model.FindRunOptions{
 		RepoID:        repo.ID,
@@ -432,6 +458,14 @@ func ifNeedApproval(ctx context.Context, run *actions_model.ActionRun, repo *rep
 		return false, nil
 	}
 
+	// don't need approval if the user is trusted by the policy
+	if trusted, err := isTrustedByApprovalPolicy(ctx, policy, repo, user); err != nil {
+		return false, fmt.Errorf("isTrustedByApprovalPolicy: %w", err)
+	} else if trusted {
+		log.Trace("do not need approval because user %d is trusted by the approval policy", user.ID)
+		return false, nil
+	}
+
 	// otherwise, need approval
 	log.Trace("need approval because it's the first time user %d triggered actions", user.ID)
 	return true, nil
@@ -0,0 +1,189 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	unit_model "code.gitea.io/gitea/models/unit"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/jobparser"
+	act_model "github.com/nektos/act/pkg/model"
+)
+
+// GetRequiredWorkflowContent reads the content of the required workflow from the default branch of its repository
+func GetRequiredWorkflowContent(ctx context.Context, w *actions_model.ActionRequiredWorkflow) ([]byte, error) {
+	if err := w.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, w.Repo)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	commit, err := gitRepo.GetBranchCommit(w.Repo.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+	_, entries, err := actions_module.ListWorkflows(commit)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Name() == w.WorkflowID {
+			return actions_module.GetContentFromEntry(entry)
+		}
+	}
+	return nil, util.NewNotExistErrorf("workflow %q does not exist in repository %s", w.WorkflowID, w.Repo.FullName())
+}
+
+// CreateRequiredWorkflow creates a required workflow of the organization, it must be able to run on the pull requests of the target repositories
+func CreateRequiredWorkflow(ctx context.Context, w *actions_model.ActionRequiredWorkflow) error {
+	if err := checkRequiredWorkflow(ctx, w); err != nil {
+		return err
+	}
+	return actions_model.CreateRequiredWorkflow(ctx, w)
+}
+
+// UpdateRequiredWorkflowRepos updates the repositories in which the workflow is required, they must be able to run it on their pull requests
+func UpdateRequiredWorkflowRepos(ctx context.Context, w *actions_model.ActionRequiredWorkflow) error {
+	if err := checkRequiredWorkflow(ctx, w); err != nil {
+		return err
+	}
+	return actions_model.UpdateRequiredWorkflowRepos(ctx, w)
+}
+
+// checkRequiredWorkflow refuses the workflows which are never triggered by a pull request,
+// and the target repositories in which the pull requests can't trigger them
+func checkRequiredWorkflow(ctx context.Context, w *actions_model.ActionRequiredWorkflow) error {
+	if err := w.LoadRepo(ctx); err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return util.NewInvalidArgumentErrorf("repository %d does not exist", w.RepoID)
+		}
+		return err
+	}
+	content, err := GetRequiredWorkflowContent(ctx, w)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return util.NewInvalidArgumentErrorf("workflow %q does not exist in the default branch of repository %s", w.WorkflowID, w.Repo.FullName())
+		}
+		return err
+	}
+	events, err := actions_module.GetEventsFromContent(content)
+	if err != nil {
+		return util.NewInvalidArgumentErrorf("invalid workflow %q: %v", w.WorkflowID, err)
+	}
+	if !slices.ContainsFunc(events, func(evt *jobparser.Event) bool {
+		return evt.Name == actions_module.GithubEventPullRequest || evt.Name == actions_module.GithubEventPullRequestTarget
+	}) {
+		return util.NewInvalidArgumentErrorf("workflow %q isn't triggered by %s or %s", w.WorkflowID, actions_module.GithubEventPullRequest, actions_module.GithubEventPullRequestTarget)
+	}
+
+	if w.AllRepos {
+		// the repositories without pull requests or Actions are skipped
+		return nil
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, w.RepoIDs)
+	if err != nil {
+		return err
+	}
+	for _, repo := range repos {
+		if !repo.UnitEnabled(ctx, unit_model.TypePullRequests) || !repo.UnitEnabled(ctx, unit_model.TypeActions) {
+			return util.NewInvalidArgumentErrorf("repository %s has no pull requests or no Actions", repo.FullName())
+		}
+	}
+	return nil
+}
+
+// RequiredWorkflowStatusContextPrefix is the prefix of the commit status contexts of the runs created from required workflows,
+// the contexts of the other workflows and the commit statuses created by the API can't use it,
+// so a pull request can't satisfy a required workflow with a workflow of the same name.
+const RequiredWorkflowStatusContextPrefix = "required: "
+
+// detectRequiredWorkflows returns the required workflows of the organization which are triggered by the pull request event of the repository
+func detectRequiredWorkflows(ctx context.Context, gitRepo *git.Repository, commit *git.Commit, input *notifyInput) ([]*actions_module.DetectedWorkflow, error) {
+	if input.PullRequest == nil {
+		return nil, nil
+	}
+	workflows, err := actions_model.GetRequiredWorkflowsOfRepo(ctx, input.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("GetRequiredWorkflowsOfRepo: %w", err)
+	}
+
+	var detected []*actions_module.DetectedWorkflow
+	for _, w := range workflows {
+		content, err := GetRequiredWorkflowContent(ctx, w)
+		if err != nil {
+			log.Warn("ignore required workflow %d of repo %s: %v", w.ID, input.Repo.FullName(), err)
+			continue
+		}
+		dwfs, err := actions_module.DetectWorkflowFromContent(gitRepo, commit, w.WorkflowID, content, input.Event, input.Payload)
+		if err != nil {
+			log.Warn("ignore invalid required workflow %d: %v", w.ID, err)
+			continue
+		}
+		for _, dwf := range dwfs {
+			dwf.RequiredWorkflowID = w.ID
+		}
+		detected = append(detected, dwfs...)
+	}
+	return detected, nil
+}
+
+// GetRequiredWorkflowStatusCheckContexts returns the patterns of the commit status contexts of the jobs of the required workflows
+// which run on the head commit of a pull request in the repository, see createCommitStatus for the format of the contexts.
+// A required workflow isn't required if it hasn't been triggered for the commit, e.g. its events don't match the pull request
+// or Actions is disabled, otherwise the pull requests could never be merged.
+func GetRequiredWorkflowStatusCheckContexts(ctx context.Context, repo *repo_model.Repository, headCommitID string) ([]string, error) {
+	if !setting.Actions.Enabled || headCommitID == "" {
+		return nil, nil
+	}
+	if !repo.UnitEnabled(ctx, unit_model.TypeActions) {
+		return nil, nil
+	}
+	workflows, err := actions_model.GetRequiredWorkflowsOfRepo(ctx, repo)
+	if err != nil || len(workflows) == 0 {
+		return nil, err
+	}
+	runWorkflowIDs, err := actions_model.GetRequiredWorkflowIDsOfCommit(ctx, repo.ID, headCommitID)
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := make([]string, 0, len(workflows))
+	for _, w := range workflows {
+		if !slices.Contains(runWorkflowIDs, w.ID) {
+			continue
+		}
+		content, err := GetRequiredWorkflowContent(ctx, w)
+		if err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				// it can't run, requiring it would block all the pull requests
+				log.Warn("ignore required workflow %d of repo %s: %v", w.ID, repo.FullName(), err)
+				continue
+			}
+			return nil, err
+		}
+		name := w.WorkflowID
+		if wf, err := act_model.ReadWorkflow(bytes.NewReader(content)); err == nil && wf.Name != "" {
+			name = wf.Name
+		}
+		contexts = append(contexts, glob.QuoteMeta(RequiredWorkflowStatusContextPrefix+name)+" / *")
+	}
+	return contexts, nil
+}
@@ -4,30 +4,65 @@
 package actions
 
 import (
+	"context"
+	"fmt"
+	"slices"
+
 	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+
+	"github.com/nektos/act/pkg/model"
+	"gopkg.in/yaml.v3"
+	"xorm.io/builder"
 )
 
 // GetAllRerunJobs get all jobs that need to be rerun when job should be rerun
+// If a job calling a reusable workflow is rerun, all jobs of the called workflow are rerun too.
+// If a job of a called workflow is rerun, its callers have to wait for it again.
 func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.ActionRunJob) []*actions_model.ActionRunJob {
+	// the needs of a job only refer to the jobs of the same workflow
+	type scopedJobID struct {
+		parentJobID int64
+		jobID       string
+	}
+
 	rerunJobs := []*actions_model.ActionRunJob{job}
-	rerunJobsIDSet := make(container.Set[string])
-	rerunJobsIDSet.Add(job.JobID)
+	rerunJobsIDSet := make(container.Set[scopedJobID])
+	rerunJobsIDSet.Add(scopedJobID{job.ParentJobID, job.JobID})
+	// the callers whose called jobs are all rerun
+	rerunCallerIDs := make(container.Set[int64])
+	rerunCallerIDs.Add(job.ID)
+	// the callers which have to wait for the rerun jobs
+	waitingCallerIDs := make(container.Set[int64])
+	waitingCallerIDs.Add(job.ParentJobID)
 
 	for {
 		found := false
 		for _, j := range allJobs {
-			if rerunJobsIDSet.Contains(j.JobID) {
+			if rerunJobsIDSet.Contains(scopedJobID{j.ParentJobID, j.JobID}) {
 				continue
 			}
-			for _, need := range j.Needs {
-				if rerunJobsIDSet.Contains(need) {
-					found = true
-					rerunJobs = append(rerunJobs, j)
-					rerunJobsIDSet.Add(j.JobID)
-					break
-				}
+			isCalled := j.ParentJobID != 0 && rerunCallerIDs.Contains(j.ParentJobID)
+			isWaiting := j.ID != 0 && waitingCallerIDs.Contains(j.ID)
+			isNeeding := slices.ContainsFunc(j.Needs, func(need string) bool {
+				return rerunJobsIDSet.Contains(scopedJobID{j.ParentJobID, need})
+			})
+			if !isCalled && !isWaiting && !isNeeding {
+				continue
 			}
+			found = true
+			rerunJobs = append(rerunJobs, j)
+			rerunJobsIDSet.Add(scopedJobID{j.ParentJobID, j.JobID})
+			if isCalled || isNeeding {
+				rerunCallerIDs.Add(j.ID)
+			}
+			waitingCallerIDs.Add(j.ParentJobID)
 		}
 		if !found {
 			break
@@ -36,3 +71,196 @@ func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.A
 
 	return rerunJobs
 }
+
+// RerunWorkflowRun reruns all jobs of a done run, the jobs must be all jobs of the run.
+func RerunWorkflowRun(ctx context.Context, repo *repo_model.Repository, run *actions_model.ActionRun, jobs []*actions_model.ActionRunJob) error {
+	if err := prepareRunForRerun(ctx, repo, run); err != nil {
+		return err
+	}
+
+	isRunBlocked := run.Status == actions_model.StatusBlocked
+	for _, j := range jobs {
+		j.Run = run
+		// if the job has needs, it should be set to "blocked" status to wait for other jobs
+		shouldBlockJob := len(j.Needs) > 0 || isRunBlocked || IsEmittedJob(j)
+		if err := rerunJob(ctx, j, shouldBlockJob); err != nil {
+			return fmt.Errorf("rerun job %d: %w", j.ID, err)
+		}
+	}
+	emitRerunJobs(run)
+	return nil
+}
+
+// RerunWorkflowRunJobs reruns the given jobs of a done run and all jobs depending on them,
+// the jobs must be all jobs of the run.
+func RerunWorkflowRunJobs(ctx context.Context, repo *repo_model.Repository, run *actions_model.ActionRun, jobs, jobsToRerun []*actions_model.ActionRunJob) error {
+	if len(jobsToRerun) == 0 {
+		return util.NewInvalidArgumentErrorf("no jobs to rerun")
+	}
+	if err := prepareRunForRerun(ctx, repo, run); err != nil {
+		return err
+	}
+
+	// the jobs depending on the given jobs have to wait for them, even if they are given too
+	dependentJobIDs := make(container.Set[int64])
+	rerunJobs := make([]*actions_model.ActionRunJob, 0, len(jobs))
+	rerunJobIDs := make(container.Set[int64])
+	for _, job := range jobsToRerun {
+		for i, j := range GetAllRerunJobs(job, jobs) {
+			if i > 0 {
+				dependentJobIDs.Add(j.ID)
+			}
+			if rerunJobIDs.Add(j.ID) {
+				rerunJobs = append(rerunJobs, j)
+			}
+		}
+	}
+
+	isRunBlocked := run.Status == actions_model.StatusBlocked
+	for _, j := range rerunJobs {
+		j.Run = run
+		shouldBlockJob := dependentJobIDs.Contains(j.ID) || isRunBlocked || IsEmittedJob(j)
+		if err := rerunJob(ctx, j, shouldBlockJob); err != nil {
+			return fmt.Errorf("rerun job %d: %w", j.ID, err)
+		}
+	}
+	emitRerunJobs(run)
+	return nil
+}
+
+// RerunFailedWorkflowRunJobs reruns the failed or cancelled jobs of a done run and all jobs depending on them,
+// the jobs must be all jobs of the run.
+func RerunFailedWorkflowRunJobs(ctx context.Context, repo *repo_model.Repository, run *actions_model.ActionRun, jobs []*actions_model.ActionRunJob) error {
+	var failedJobs []*actions_model.ActionRunJob
+	for _, job := range jobs {
+		if job.Status == actions_model.StatusFailure || job.Status == actions_model.StatusCancelled {
+			failedJobs = append(failedJobs, job)
+		}
+	}
+	if len(failedJobs) == 0 {
+		return util.NewInvalidArgumentErrorf("run %d has no failed jobs", run.Index)
+	}
+	return RerunWorkflowRunJobs(ctx, repo, run, jobs, failedJobs)
+}
+
+// IsEmittedJob returns whether the job calls a reusable workflow, belongs to a called workflow, targets an environment
+// or has a matrix which hasn't been expanded, such jobs are started by the job emitter.
+// The called workflow isn't read again and an expanded matrix isn't evaluated again, their jobs are rerun like other jobs.
+func IsEmittedJob(job *actions_model.ActionRunJob) bool {
+	return job.ParentJobID > 0 || job.RawEnvironment != "" || job.RawMatrix != "" || IsReusableWorkflowCall(job)
+}
+
+// prepareRunForRerun resets the status and the times of a done run and evaluates its concurrency again
+func prepareRunForRerun(ctx context.Context, repo *repo_model.Repository, run *actions_model.ActionRun) error {
+	// rerun is not allowed if the run is not done
+	if !run.Status.IsDone() {
+		return util.ErrorWrapTranslatable(
+			util.NewInvalidArgumentErrorf("run %d is not done", run.Index),
+			"actions.runs.not_done",
+		)
+	}
+
+	// can not rerun job when workflow is disabled
+	cfgUnit := repo.MustGetUnit(ctx, unit.TypeActions)
+	cfg := cfgUnit.ActionsConfig()
+	if cfg.IsWorkflowDisabled(run.WorkflowID) {
+		return util.ErrorWrapTranslatable(
+			util.NewPermissionDeniedErrorf("workflow is disabled"),
+			"actions.workflow.disabled",
+		)
+	}
+
+	// reset run's start and stop time
+	run.PreviousDuration = run.Duration()
+	run.Started = 0
+	run.Stopped = 0
+	run.Status = actions_model.StatusWaiting
+
+	vars, err := actions_model.GetVariablesOfRun(ctx, run)
+	if err != nil {
+		return fmt.Errorf("get run %d variables: %w", run.ID, err)
+	}
+
+	if run.RawConcurrency != "" {
+		var rawConcurrency model.RawConcurrency
+		if err := yaml.Unmarshal([]byte(run.RawConcurrency), &rawConcurrency); err != nil {
+			return fmt.Errorf("unmarshal raw concurrency: %w", err)
+		}
+
+		if err := EvaluateRunConcurrencyFillModel(ctx, run, &rawConcurrency, vars); err != nil {
+			return fmt.Errorf("EvaluateRunConcurrencyFillModel: %w", err)
+		}
+
+		run.Status, err = PrepareToStartRunWithConcurrency(ctx, run)
+		if err != nil {
+			return fmt.Errorf("PrepareToStartRunWithConcurrency: %w", err)
+		}
+	}
+	if err := actions_model.UpdateRun(ctx, run, "started", "stopped", "previous_duration", "status", "concurrency_group", "concurrency_cancel"); err != nil {
+		return fmt.Errorf("UpdateRun: %w", err)
+	}
+
+	if err := run.LoadAttributes(ctx); err != nil {
+		return fmt.Errorf("LoadAttributes: %w", err)
+	}
+	notify_service.WorkflowRunStatusUpdate(ctx, run.Repo, run.TriggerUser, run)
+	return nil
+}
+
+func emitRerunJobs(run *actions_model.ActionRun) {
+	if run.Status == actions_model.StatusBlocked {
+		return
+	}
+	if err := EmitJobsIfReadyByRun(run.ID); err != nil {
+		log.Error("EmitJobsIfReadyByRun: %v", err)
+	}
+}
+
+func rerunJob(ctx context.Context, job *actions_model.ActionRunJob, shouldBlock bool) error {
+	status := job.Status
+	if !status.IsDone() {
+		return nil
+	}
+
+	job.TaskID = 0
+	job.Status = util.Iif(shouldBlock, actions_model.StatusBlocked, actions_model.StatusWaiting)
+	job.Started = 0
+	job.Stopped = 0
+
+	job.ConcurrencyGroup = ""
+	job.ConcurrencyCancel = false
+	job.IsConcurrencyEvaluated = false
+	if err := job.LoadRun(ctx); err != nil {
+		return err
+	}
+
+	vars, err := actions_model.GetVariablesOfRun(ctx, job.Run)
+	if err != nil {
+		return fmt.Errorf("get run %d variables: %w", job.Run.ID, err)
+	}
+
+	if job.RawConcurrency != "" && !shouldBlock {
+		err = EvaluateJobConcurrencyFillModel(ctx, job.Run, job, vars)
+		if err != nil {
+			return fmt.Errorf("evaluate job concurrency: %w", err)
+		}
+
+		job.Status, err = PrepareToStartJobWithConcurrency(ctx, job)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		updateCols := []string{"task_id", "status", "started", "stopped", "concurrency_group", "concurrency_cancel", "is_concurrency_evaluated"}
+		_, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": status}, updateCols...)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	CreateCommitStatusForRunJobs(ctx, job.Run, job)
+	notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
+
+	return nil
+}
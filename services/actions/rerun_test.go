@@ -4,11 +4,20 @@
 package actions
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/util"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetAllRerunJobs(t *testing.T) {
@@ -46,3 +55,111 @@ func TestGetAllRerunJobs(t *testing.T) {
 		assert.ElementsMatch(t, tc.rerunJobs, rerunJobs)
 	}
 }
+
+func TestGetAllRerunJobsOfReusableWorkflow(t *testing.T) {
+	job1 := &actions_model.ActionRunJob{ID: 1, JobID: "job1"}
+	caller := &actions_model.ActionRunJob{ID: 2, JobID: "call", Needs: []string{"job1"}}
+	called1 := &actions_model.ActionRunJob{ID: 3, JobID: "job1", ParentJobID: 2}
+	called2 := &actions_model.ActionRunJob{ID: 4, JobID: "job2", Needs: []string{"job1"}, ParentJobID: 2}
+	called3 := &actions_model.ActionRunJob{ID: 5, JobID: "job3", ParentJobID: 2}
+	job3 := &actions_model.ActionRunJob{ID: 6, JobID: "job3", Needs: []string{"call"}}
+
+	jobs := []*actions_model.ActionRunJob{job1, caller, called1, called2, called3, job3}
+
+	testCases := []struct {
+		job       *actions_model.ActionRunJob
+		rerunJobs []*actions_model.ActionRunJob
+	}{
+		{
+			job1,
+			[]*actions_model.ActionRunJob{job1, caller, called1, called2, called3, job3},
+		},
+		{
+			caller,
+			[]*actions_model.ActionRunJob{caller, called1, called2, called3, job3},
+		},
+		{
+			called1,
+			[]*actions_model.ActionRunJob{called1, called2, caller, job3},
+		},
+		{
+			called3,
+			[]*actions_model.ActionRunJob{called3, caller, job3},
+		},
+		{
+			job3,
+			[]*actions_model.ActionRunJob{job3},
+		},
+	}
+
+	for _, tc := range testCases {
+		rerunJobs := GetAllRerunJobs(tc.job, jobs)
+		assert.ElementsMatch(t, tc.rerunJobs, rerunJobs)
+	}
+}
+
+func TestRerunFailedWorkflowRunJobs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	jobEmitterQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "actions_ready_job", func(items ...*jobUpdate) []*jobUpdate { return nil })
+	go jobEmitterQueue.Run()
+	defer func() {
+		jobEmitterQueue.ShutdownWait(time.Second)
+		jobEmitterQueue = nil
+	}()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	runIndex := int64(1000)
+	insertRun := func(t *testing.T, statuses ...actions_model.Status) (*actions_model.ActionRun, []*actions_model.ActionRunJob) {
+		runIndex++
+		run := &actions_model.ActionRun{
+			Index:         runIndex,
+			RepoID:        repo.ID,
+			OwnerID:       repo.OwnerID,
+			WorkflowID:    "test.yaml",
+			TriggerUserID: 1,
+			Event:         "workflow_dispatch",
+			TriggerEvent:  "workflow_dispatch",
+		}
+		require.NoError(t, db.Insert(t.Context(), run))
+		jobs := make([]*actions_model.ActionRunJob, 0, len(statuses))
+		for i, status := range statuses {
+			job := &actions_model.ActionRunJob{
+				RunID:   run.ID,
+				RepoID:  repo.ID,
+				OwnerID: repo.OwnerID,
+				JobID:   fmt.Sprintf("job%d", i),
+				Name:    fmt.Sprintf("job%d", i),
+				Status:  status,
+			}
+			if i > 0 {
+				job.Needs = []string{fmt.Sprintf("job%d", i-1)}
+			}
+			require.NoError(t, db.Insert(t.Context(), job))
+			jobs = append(jobs, job)
+		}
+		run.Status = actions_model.AggregateJobStatus(jobs)
+		require.NoError(t, actions_model.UpdateRun(t.Context(), run, "status"))
+		return run, jobs
+	}
+
+	t.Run("Failed", func(t *testing.T) {
+		run, jobs := insertRun(t, actions_model.StatusSuccess, actions_model.StatusFailure, actions_model.StatusSkipped)
+		require.NoError(t, RerunFailedWorkflowRunJobs(t.Context(), repo, run, jobs))
+
+		assert.Equal(t, actions_model.StatusSuccess, unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: jobs[0].ID}).Status)
+		assert.Equal(t, actions_model.StatusWaiting, unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: jobs[1].ID}).Status)
+		assert.Equal(t, actions_model.StatusBlocked, unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: jobs[2].ID}).Status)
+	})
+
+	t.Run("NoFailedJobs", func(t *testing.T) {
+		run, jobs := insertRun(t, actions_model.StatusSuccess, actions_model.StatusSuccess)
+		assert.ErrorIs(t, RerunFailedWorkflowRunJobs(t.Context(), repo, run, jobs), util.ErrInvalidArgument)
+	})
+
+	t.Run("NotDone", func(t *testing.T) {
+		run, jobs := insertRun(t, actions_model.StatusFailure, actions_model.StatusRunning)
+		assert.ErrorIs(t, RerunFailedWorkflowRunJobs(t.Context(), repo, run, jobs), util.ErrInvalidArgument)
+		assert.Equal(t, actions_model.StatusFailure, unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: jobs[0].ID}).Status)
+	})
+}
@@ -0,0 +1,466 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/exprparser"
+	"github.com/nektos/act/pkg/jobparser"
+	act_model "github.com/nektos/act/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// maxReusableWorkflowDepth is the max nesting level of reusable workflows, it also stops a workflow from calling itself endlessly
+const maxReusableWorkflowDepth = 10
+
+// parseReusableWorkflowCall returns the parsed job and the called workflow if the job calls a reusable workflow
+func parseReusableWorkflowCall(job *actions_model.ActionRunJob) (*jobparser.Job, *actions_module.ReusableWorkflowRef, bool) {
+	workflowJob, err := job.ParseJob()
+	if err != nil {
+		return nil, nil, false
+	}
+	ref, ok := actions_module.ParseReusableWorkflowRef(workflowJob.Uses)
+	return workflowJob, ref, ok
+}
+
+// IsReusableWorkflowCall returns whether the job calls a reusable workflow.
+// Such a job is never run by a runner, it stays blocked until all jobs of the called workflow are done.
+func IsReusableWorkflowCall(job *actions_model.ActionRunJob) bool {
+	_, _, ok := parseReusableWorkflowCall(job)
+	return ok
+}
+
+// getReusableWorkflowContent reads the called workflow, a workflow of another repository can only be called
+// if the workflow run is allowed to read the code of that repository.
+func getReusableWorkflowContent(ctx context.Context, run *actions_model.ActionRun, ref *actions_module.ReusableWorkflowRef) ([]byte, error) {
+	repo := run.Repo
+	commitID := run.CommitSHA
+	if !ref.IsLocal {
+		var err error
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, ref.Owner, ref.Repo)
+		if err != nil {
+			return nil, fmt.Errorf("GetRepositoryByOwnerAndName: %w", err)
+		}
+		perm, err := access_model.GetActionsRunRepoPermission(ctx, repo, run.RepoID, run.IsForkPullRequest)
+		if err != nil {
+			return nil, fmt.Errorf("GetActionsRunRepoPermission: %w", err)
+		}
+		if !perm.CanRead(unit.TypeCode) {
+			return nil, util.NewPermissionDeniedErrorf("no permission to read workflows of %s/%s", ref.Owner, ref.Repo)
+		}
+		commitID = ref.Ref
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetCommit(commitID)
+	if err != nil {
+		return nil, fmt.Errorf("GetCommit %q: %w", commitID, err)
+	}
+	entry, err := commit.GetTreeEntryByPath(ref.Path)
+	if err != nil {
+		return nil, fmt.Errorf("GetTreeEntryByPath %q: %w", ref.Path, err)
+	}
+	return actions_module.GetContentFromEntry(entry)
+}
+
+// newJobInterpreter returns an interpreter for the expressions of a job which are evaluated by Gitea,
+// like `if`, `with` and `secrets` of a job calling a reusable workflow, or a dynamic matrix. secrets and jobs are optional.
+func newJobInterpreter(ctx context.Context, caller *actions_model.ActionRunJob, callerJob *jobparser.Job, vars, secrets map[string]string, jobs *map[string]*act_model.WorkflowCallResult) (exprparser.Interpreter, error) {
+	results, err := findJobNeedsAndFillJobResults(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := getInputsOfJob(caller.Run, caller)
+	if err != nil {
+		return nil, fmt.Errorf("get inputs: %w", err)
+	}
+
+	matrix := map[string]any{}
+	matrixes, err := (&act_model.Job{Strategy: &act_model.Strategy{RawMatrix: callerJob.Strategy.RawMatrix}}).GetMatrixes()
+	if err != nil {
+		return nil, fmt.Errorf("get matrix: %w", err)
+	}
+	if len(matrixes) > 0 {
+		matrix = matrixes[0]
+	}
+
+	// see jobparser.NewInterpeter, the status functions need the results of the needed jobs
+	run := &act_model.Run{
+		Workflow: &act_model.Workflow{Jobs: map[string]*act_model.Job{}},
+		JobID:    caller.JobID,
+	}
+	needs := make(map[string]exprparser.Needs, len(caller.Needs))
+	for id, result := range results {
+		var rawNeeds yaml.Node
+		_ = rawNeeds.Encode(result.Needs)
+		run.Workflow.Jobs[id] = &act_model.Job{
+			RawNeeds: rawNeeds,
+			Result:   result.Result,
+			Outputs:  result.Outputs,
+		}
+		if slices.Contains(caller.Needs, id) {
+			needs[id] = exprparser.Needs{Outputs: result.Outputs, Result: result.Result}
+		}
+	}
+
+	giteaCtx := GenerateGiteaContext(caller.Run, caller)
+	env := &exprparser.EvaluationEnvironment{
+		Github:  giteaCtx.ToGitHubContext(),
+		Job:     &act_model.JobContext{Status: actions_model.StatusSuccess.String()},
+		Jobs:    jobs,
+		Secrets: secrets,
+		Vars:    vars,
+		Matrix:  matrix,
+		Needs:   needs,
+		Inputs:  inputs,
+	}
+	return exprparser.NewInterpeter(env, exprparser.Config{Run: run, Context: "job"}), nil
+}
+
+// evaluateReusableWorkflowCallIf evaluates the `if` of a job which calls a reusable workflow,
+// unlike other jobs, it can't be evaluated by a runner.
+func evaluateReusableWorkflowCallIf(interpreter exprparser.Interpreter, callerJob *jobparser.Job) (bool, error) {
+	expr := strings.TrimSpace(callerJob.If.Value)
+	if strings.HasPrefix(expr, "${{") && strings.HasSuffix(expr, "}}") {
+		expr = strings.TrimSpace(expr[3 : len(expr)-2])
+	}
+	result, err := interpreter.Evaluate(expr, exprparser.DefaultStatusCheckSuccess)
+	if err != nil {
+		return false, err
+	}
+	return exprparser.IsTruthy(result), nil
+}
+
+// evaluateReusableWorkflowInputs evaluates the `with` of the caller and fills the defaults of the called workflow's inputs
+func evaluateReusableWorkflowInputs(interpreter exprparser.Interpreter, callerJob *jobparser.Job, config *act_model.WorkflowCall) (map[string]any, error) {
+	var node yaml.Node
+	if err := node.Encode(callerJob.With); err != nil {
+		return nil, fmt.Errorf("encode with: %w", err)
+	}
+	if err := jobparser.NewExpressionEvaluator(interpreter).EvaluateYamlNode(&node); err != nil {
+		return nil, fmt.Errorf("evaluate with: %w", err)
+	}
+	with := map[string]any{}
+	if err := node.Decode(&with); err != nil {
+		return nil, fmt.Errorf("decode with: %w", err)
+	}
+
+	inputs := make(map[string]any, len(config.Inputs))
+	for name, input := range config.Inputs {
+		value, ok := with[name]
+		if !ok {
+			if input.Required {
+				return nil, fmt.Errorf("input %q is required", name)
+			}
+			value = input.Default
+		}
+		value, err := convertWorkflowCallInput(name, input, value)
+		if err != nil {
+			return nil, err
+		}
+		inputs[name] = value
+	}
+	for name := range with {
+		if _, ok := config.Inputs[name]; !ok {
+			return nil, fmt.Errorf("input %q is not defined by the called workflow", name)
+		}
+	}
+	return inputs, nil
+}
+
+// convertWorkflowCallInput converts the value of an input to the type declared by the called workflow
+func convertWorkflowCallInput(name string, input act_model.WorkflowCallInput, value any) (any, error) {
+	str := fmt.Sprint(value)
+	switch input.Type {
+	case "boolean":
+		if str != "true" && str != "false" {
+			return nil, fmt.Errorf("input %q must be a boolean", name)
+		}
+		return str == "true", nil
+	case "number":
+		number, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("input %q must be a number", name)
+		}
+		return number, nil
+	default:
+		return str, nil
+	}
+}
+
+// setCalledWorkflowInputs stores the inputs passed by the caller in the payload of a job of the called workflow,
+// they replace the defaults of the inputs of its workflow_call trigger.
+func setCalledWorkflowInputs(workflow *jobparser.SingleWorkflow, config *act_model.WorkflowCall, inputs map[string]any) error {
+	callInputs := make(map[string]act_model.WorkflowCallInput, len(inputs))
+	for name, value := range inputs {
+		callInputs[name] = act_model.WorkflowCallInput{
+			Type:    config.Inputs[name].Type,
+			Default: fmt.Sprint(value),
+		}
+	}
+	return workflow.RawOn.Encode(map[string]act_model.WorkflowCall{
+		actions_module.GithubEventWorkflowCall: {Inputs: callInputs},
+	})
+}
+
+// getInputsOfJob returns the `inputs` context of a job, the inputs of the jobs of a called workflow are passed by the caller
+func getInputsOfJob(run *actions_model.ActionRun, job *actions_model.ActionRunJob) (map[string]any, error) {
+	if job == nil || job.ParentJobID == 0 {
+		return getInputsFromRun(run)
+	}
+	var workflow jobparser.SingleWorkflow
+	if err := yaml.Unmarshal(job.WorkflowPayload, &workflow); err != nil {
+		return nil, err
+	}
+	config := (&act_model.Workflow{RawOn: workflow.RawOn}).WorkflowCallConfig()
+	inputs := make(map[string]any, len(config.Inputs))
+	for name, input := range config.Inputs {
+		value, err := convertWorkflowCallInput(name, input, input.Default)
+		if err != nil {
+			return nil, err
+		}
+		inputs[name] = value
+	}
+	return inputs, nil
+}
+
+// expandReusableWorkflowCall inserts the jobs of the workflow which is called by the caller job.
+// The jobs don't need the caller's needs because they are only inserted when the caller's needs are done.
+func expandReusableWorkflowCall(ctx context.Context, caller *actions_model.ActionRunJob, callerJob *jobparser.Job, ref *actions_module.ReusableWorkflowRef, vars map[string]string) ([]*actions_model.ActionRunJob, error) {
+	run := caller.Run
+	if err := run.LoadAttributes(ctx); err != nil {
+		return nil, fmt.Errorf("run LoadAttributes: %w", err)
+	}
+
+	content, err := getReusableWorkflowContent(ctx, run, ref)
+	if err != nil {
+		return nil, err
+	}
+	workflow, err := act_model.ReadWorkflow(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("read workflow: %w", err)
+	}
+	events, err := jobparser.ParseRawOn(&workflow.RawOn)
+	if err != nil {
+		return nil, fmt.Errorf("parse workflow events: %w", err)
+	}
+	if !slices.ContainsFunc(events, func(evt *jobparser.Event) bool { return evt.Name == actions_module.GithubEventWorkflowCall }) {
+		return nil, fmt.Errorf("workflow %q isn't triggered by %s", callerJob.Uses, actions_module.GithubEventWorkflowCall)
+	}
+	callConfig := workflow.WorkflowCallConfig()
+
+	interpreter, err := newJobInterpreter(ctx, caller, callerJob, vars, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := evaluateReusableWorkflowInputs(interpreter, callerJob, callConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	giteaCtx := GenerateGiteaContext(run, nil)
+	workflows, err := jobparser.Parse(content, jobparser.WithVars(vars), jobparser.WithGitContext(giteaCtx.ToGitHubContext()), jobparser.WithInputs(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("parse workflow: %w", err)
+	}
+	if len(workflows) == 0 {
+		return nil, errors.New("called workflow has no jobs")
+	}
+
+	rawEnvironments, err := readJobsRawEnvironment(content)
+	if err != nil {
+		return nil, fmt.Errorf("read environments: %w", err)
+	}
+	dynamicMatrixes, err := readJobsDynamicMatrix(content)
+	if err != nil {
+		return nil, fmt.Errorf("read matrixes: %w", err)
+	}
+
+	jobs := make([]*actions_model.ActionRunJob, 0, len(workflows))
+	for _, v := range workflows {
+		id, job := v.Job()
+		needs := job.Needs()
+		matrix := dynamicMatrixes[id]
+		if matrix != nil {
+			job.RawRunsOn = matrix.RawRunsOn
+		}
+		if err := v.SetJob(id, job.EraseNeeds()); err != nil {
+			return nil, err
+		}
+		if err := setCalledWorkflowInputs(v, callConfig, inputs); err != nil {
+			return nil, err
+		}
+		payload, _ := v.Marshal()
+
+		runJob := &actions_model.ActionRunJob{
+			RunID:             caller.RunID,
+			Run:               run,
+			RepoID:            caller.RepoID,
+			OwnerID:           caller.OwnerID,
+			CommitSHA:         caller.CommitSHA,
+			IsForkPullRequest: caller.IsForkPullRequest,
+			Name:              util.EllipsisDisplayString(caller.Name+" / "+job.Name, 255),
+			WorkflowPayload:   payload,
+			JobID:             id,
+			Needs:             needs,
+			RunsOn:            job.RunsOn(),
+			Status:            actions_model.StatusBlocked,
+			ParentJobID:       caller.ID,
+			RawEnvironment:    rawEnvironments[id],
+		}
+		if matrix != nil {
+			runJob.RawMatrix = matrix.RawMatrix
+		}
+		if job.RawConcurrency != nil {
+			// it will be evaluated by the job emitter like the concurrency of a job with needs
+			rawConcurrency, err := yaml.Marshal(job.RawConcurrency)
+			if err != nil {
+				return nil, fmt.Errorf("marshal raw concurrency: %w", err)
+			}
+			runJob.RawConcurrency = string(rawConcurrency)
+		}
+		if err := db.Insert(ctx, runJob); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, runJob)
+	}
+
+	// A job calling a reusable workflow can't have outputs itself,
+	// so the caller's payload keeps the outputs of the called workflow until they are evaluated.
+	callerJob.Outputs = make(map[string]string, len(callConfig.Outputs))
+	for name, output := range callConfig.Outputs {
+		callerJob.Outputs[name] = output.Value
+	}
+	var callerWorkflow jobparser.SingleWorkflow
+	if err := yaml.Unmarshal(caller.WorkflowPayload, &callerWorkflow); err != nil {
+		return nil, err
+	}
+	if err := callerWorkflow.SetJob(caller.JobID, callerJob); err != nil {
+		return nil, err
+	}
+	if caller.WorkflowPayload, err = callerWorkflow.Marshal(); err != nil {
+		return nil, err
+	}
+	caller.Started = timeutil.TimeStampNow()
+	if _, err := actions_model.UpdateRunJob(ctx, caller, nil, "workflow_payload", "started"); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// getJobOutputs returns the outputs of a done job, the outputs of a job calling a reusable workflow are evaluated by Gitea
+func getJobOutputs(ctx context.Context, job *actions_model.ActionRunJob) (map[string]string, error) {
+	if job.TaskID == 0 {
+		return job.CallOutputs, nil
+	}
+	got, err := actions_model.FindTaskOutputByTaskID(ctx, job.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("FindTaskOutputByTaskID: %w", err)
+	}
+	outputs := make(map[string]string, len(got))
+	for _, v := range got {
+		outputs[v.OutputKey] = v.OutputValue
+	}
+	return outputs, nil
+}
+
+// finishReusableWorkflowCall evaluates the outputs of the called workflow when all of its jobs are done
+func finishReusableWorkflowCall(ctx context.Context, caller *actions_model.ActionRunJob, callerJob *jobparser.Job, calledJobs []*actions_model.ActionRunJob, vars map[string]string) error {
+	results := make(map[string]*act_model.WorkflowCallResult, len(calledJobs))
+	for _, job := range calledJobs {
+		if !job.Status.IsDone() {
+			continue
+		}
+		outputs, err := getJobOutputs(ctx, job)
+		if err != nil {
+			return err
+		}
+		if result, ok := results[job.JobID]; ok {
+			result.Outputs = mergeTwoOutputs(outputs, result.Outputs)
+		} else {
+			results[job.JobID] = &act_model.WorkflowCallResult{Outputs: outputs}
+		}
+	}
+
+	interpreter, err := newJobInterpreter(ctx, caller, callerJob, vars, nil, &results)
+	if err != nil {
+		return err
+	}
+	evaluator := jobparser.NewExpressionEvaluator(interpreter)
+	caller.CallOutputs = make(map[string]string, len(callerJob.Outputs))
+	for name, value := range callerJob.Outputs {
+		caller.CallOutputs[name] = evaluator.Interpolate(value)
+	}
+	caller.Stopped = timeutil.TimeStampNow()
+	_, err = actions_model.UpdateRunJob(ctx, caller, nil, "call_outputs", "stopped")
+	return err
+}
+
+// getSecretsOfCalledWorkflowJob returns the secrets which are passed by the callers to a job of a called workflow,
+// they are either inherited or explicitly mapped by `jobs.<job_id>.secrets`.
+func getSecretsOfCalledWorkflowJob(ctx context.Context, job *actions_model.ActionRunJob, secrets, vars map[string]string) (map[string]string, error) {
+	if job.ParentJobID == 0 {
+		return secrets, nil
+	}
+	caller, err := actions_model.GetRunJobByID(ctx, job.ParentJobID)
+	if err != nil {
+		return nil, err
+	}
+	caller.Run = job.Run
+	secrets, err = getSecretsOfCalledWorkflowJob(ctx, caller, secrets, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	callerJob, err := caller.ParseJob()
+	if err != nil {
+		return nil, err
+	}
+	actJob := &act_model.Job{RawSecrets: callerJob.RawSecrets}
+	if actJob.InheritSecrets() {
+		return secrets, nil
+	}
+
+	// the automatically generated tokens are always available
+	ret := map[string]string{
+		"GITHUB_TOKEN": secrets["GITHUB_TOKEN"],
+		"GITEA_TOKEN":  secrets["GITEA_TOKEN"],
+	}
+	mapping := actJob.Secrets()
+	if len(mapping) == 0 {
+		return ret, nil
+	}
+	interpreter, err := newJobInterpreter(ctx, caller, callerJob, vars, secrets, nil)
+	if err != nil {
+		return nil, err
+	}
+	evaluator := jobparser.NewExpressionEvaluator(interpreter)
+	for name, value := range mapping {
+		ret[name] = evaluator.Interpolate(value)
+	}
+	return ret, nil
+}
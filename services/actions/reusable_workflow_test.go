@@ -0,0 +1,182 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/nektos/act/pkg/jobparser"
+	act_model "github.com/nektos/act/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestReusableWorkflowCall(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	run := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRun{ID: 793})
+	require.NoError(t, run.LoadAttributes(t.Context()))
+
+	insertJob := func(t *testing.T, job *actions_model.ActionRunJob) *actions_model.ActionRunJob {
+		job.RunID = run.ID
+		job.Run = run
+		job.RepoID = run.RepoID
+		job.OwnerID = run.OwnerID
+		require.NoError(t, db.Insert(t.Context(), job))
+		return job
+	}
+
+	caller := insertJob(t, &actions_model.ActionRunJob{
+		JobID:  "call",
+		Name:   "call",
+		Needs:  []string{"job1"},
+		Status: actions_model.StatusBlocked,
+		WorkflowPayload: []byte(`
+name: test
+on: push
+jobs:
+  call:
+    if: ${{ needs.job1.outputs.output_a == 'abc' }}
+    uses: ./.gitea/workflows/called.yml
+    with:
+      name: ${{ needs.job1.outputs.output_b }}
+    secrets:
+      token: ${{ secrets.MY_TOKEN }}
+`),
+	})
+	callerJob, ref, ok := parseReusableWorkflowCall(caller)
+	require.True(t, ok)
+	assert.True(t, ref.IsLocal)
+	assert.True(t, IsReusableWorkflowCall(caller))
+
+	interpreter, err := newJobInterpreter(t.Context(), caller, callerJob, nil, nil, nil)
+	require.NoError(t, err)
+
+	t.Run("If", func(t *testing.T) {
+		shouldCall, err := evaluateReusableWorkflowCallIf(interpreter, callerJob)
+		require.NoError(t, err)
+		assert.True(t, shouldCall)
+	})
+
+	t.Run("Inputs", func(t *testing.T) {
+		inputs, err := evaluateReusableWorkflowInputs(interpreter, callerJob, &act_model.WorkflowCall{
+			Inputs: map[string]act_model.WorkflowCallInput{
+				"name":    {Required: true},
+				"version": {Default: "1.0"},
+				"debug":   {Type: "boolean", Default: "true"},
+				"retries": {Type: "number", Default: "3"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "bbb", "version": "1.0", "debug": true, "retries": float64(3)}, inputs)
+
+		_, err = evaluateReusableWorkflowInputs(interpreter, callerJob, &act_model.WorkflowCall{
+			Inputs: map[string]act_model.WorkflowCallInput{"name": {Type: "number"}},
+		})
+		assert.Error(t, err)
+
+		_, err = evaluateReusableWorkflowInputs(interpreter, callerJob, &act_model.WorkflowCall{
+			Inputs: map[string]act_model.WorkflowCallInput{"version": {Required: true}},
+		})
+		assert.Error(t, err)
+	})
+
+	called := insertJob(t, &actions_model.ActionRunJob{
+		JobID:       "build",
+		Name:        "call / build",
+		Status:      actions_model.StatusWaiting,
+		ParentJobID: caller.ID,
+		WorkflowPayload: []byte(`
+name: called
+on:
+  workflow_call:
+    inputs:
+      name:
+        type: string
+        default: bbb
+      debug:
+        type: boolean
+        default: "true"
+      retries:
+        type: number
+        default: "3"
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ inputs.name }}
+`),
+	})
+
+	t.Run("InputsOfCalledJob", func(t *testing.T) {
+		inputs, err := getInputsOfJob(run, called)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "bbb", "debug": true, "retries": float64(3)}, inputs)
+	})
+
+	t.Run("SetInputsOfCalledJob", func(t *testing.T) {
+		var workflow jobparser.SingleWorkflow
+		require.NoError(t, yaml.Unmarshal(called.WorkflowPayload, &workflow))
+		config := &act_model.WorkflowCall{Inputs: map[string]act_model.WorkflowCallInput{
+			"name":  {Type: "string"},
+			"debug": {Type: "boolean"},
+		}}
+		require.NoError(t, setCalledWorkflowInputs(&workflow, config, map[string]any{"name": "ccc", "debug": false}))
+		payload, err := workflow.Marshal()
+		require.NoError(t, err)
+
+		inputs, err := getInputsOfJob(run, &actions_model.ActionRunJob{ParentJobID: caller.ID, WorkflowPayload: payload})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "ccc", "debug": false}, inputs)
+	})
+
+	t.Run("Secrets", func(t *testing.T) {
+		secrets := map[string]string{
+			"GITHUB_TOKEN": "token",
+			"GITEA_TOKEN":  "token",
+			"MY_TOKEN":     "my-token",
+			"OTHER":        "other",
+		}
+		got, err := getSecretsOfCalledWorkflowJob(t.Context(), called, secrets, nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"GITHUB_TOKEN": "token",
+			"GITEA_TOKEN":  "token",
+			"token":        "my-token",
+		}, got)
+
+		got, err = getSecretsOfCalledWorkflowJob(t.Context(), caller, secrets, nil)
+		require.NoError(t, err)
+		assert.Equal(t, secrets, got)
+	})
+
+	t.Run("Outputs", func(t *testing.T) {
+		callerJob.Outputs = map[string]string{"name": "${{ jobs.build.outputs.name }}-done"}
+		called.Status = actions_model.StatusSuccess
+		called.CallOutputs = map[string]string{"name": "bbb"} // the outputs of a done job without a task
+		require.NoError(t, finishReusableWorkflowCall(t.Context(), caller, callerJob, []*actions_model.ActionRunJob{called}, nil))
+		assert.Equal(t, map[string]string{"name": "bbb-done"}, caller.CallOutputs)
+
+		caller.Status = actions_model.StatusSuccess
+		_, err := actions_model.UpdateRunJob(t.Context(), caller, nil, "status")
+		require.NoError(t, err)
+
+		next := insertJob(t, &actions_model.ActionRunJob{
+			JobID:  "next",
+			Needs:  []string{"call", "build"},
+			Status: actions_model.StatusBlocked,
+		})
+		needs, err := FindTaskNeeds(t.Context(), next)
+		require.NoError(t, err)
+		// "build" of the called workflow can't be needed by the jobs of the caller's workflow
+		assert.Len(t, needs, 1)
+		assert.Equal(t, actions_model.StatusSuccess, needs["call"].Result)
+		assert.Equal(t, map[string]string{"name": "bbb-done"}, needs["call"].Outputs)
+	})
+}
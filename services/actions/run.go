@@ -9,6 +9,7 @@ import (
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
+	actions_module "code.gitea.io/gitea/modules/actions"
 	"code.gitea.io/gitea/modules/util"
 	notify_service "code.gitea.io/gitea/services/notify"
 
@@ -52,7 +53,17 @@ func PrepareRunAndInsert(ctx context.Context, content []byte, run *actions_model
 		run.Title = jobs[0].RunName
 	}
 
-	if err = InsertRun(ctx, run, jobs, vars); err != nil {
+	rawEnvironments, err := readJobsRawEnvironment(content)
+	if err != nil {
+		return fmt.Errorf("readJobsRawEnvironment: %w", err)
+	}
+
+	dynamicMatrixes, err := readJobsDynamicMatrix(content)
+	if err != nil {
+		return fmt.Errorf("readJobsDynamicMatrix: %w", err)
+	}
+
+	if err = InsertRun(ctx, run, jobs, vars, rawEnvironments, dynamicMatrixes); err != nil {
 		return fmt.Errorf("InsertRun: %w", err)
 	}
 
@@ -72,10 +83,13 @@ func PrepareRunAndInsert(ctx context.Context, content []byte, run *actions_model
 	return nil
 }
 
-// InsertRun inserts a run
+// InsertRun inserts a run, rawEnvironments are the raw environments of the jobs by job id,
+// and dynamicMatrixes are the matrixes of the jobs which can only be expanded by the job emitter.
 // The title will be cut off at 255 characters if it's longer than 255 characters.
-func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobparser.SingleWorkflow, vars map[string]string) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobparser.SingleWorkflow, vars, rawEnvironments map[string]string, dynamicMatrixes map[string]*dynamicMatrix) error {
+	// the jobs calling reusable workflows, targeting environments or having dynamic matrixes are only checked by the job emitter
+	var shouldEmitJobs bool
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		index, err := db.GetNextResourceIndex(ctx, "action_run_index", run.RepoID)
 		if err != nil {
 			return err
@@ -106,12 +120,21 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 		for _, v := range jobs {
 			id, job := v.Job()
 			needs := job.Needs()
+			matrix := dynamicMatrixes[id]
+			if matrix != nil {
+				// the job is a placeholder of the jobs of the matrix, they interpolate the raw runs-on with their combinations
+				job.RawRunsOn = matrix.RawRunsOn
+			}
 			if err := v.SetJob(id, job.EraseNeeds()); err != nil {
 				return err
 			}
 			payload, _ := v.Marshal()
 
-			shouldBlockJob := len(needs) > 0 || run.NeedApproval || run.Status == actions_model.StatusBlocked
+			_, isReusableWorkflowCall := actions_module.ParseReusableWorkflowRef(job.Uses)
+			rawEnvironment := rawEnvironments[id]
+			isEmittedJob := isReusableWorkflowCall || rawEnvironment != "" || matrix != nil
+			shouldBlockJob := len(needs) > 0 || isEmittedJob || run.NeedApproval || run.Status == actions_model.StatusBlocked
+			shouldEmitJobs = shouldEmitJobs || (isEmittedJob && !run.NeedApproval && run.Status != actions_model.StatusBlocked)
 
 			job.Name = util.EllipsisDisplayString(job.Name, 255)
 			runJob := &actions_model.ActionRunJob{
@@ -126,6 +149,10 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 				Needs:             needs,
 				RunsOn:            job.RunsOn(),
 				Status:            util.Iif(shouldBlockJob, actions_model.StatusBlocked, actions_model.StatusWaiting),
+				RawEnvironment:    rawEnvironment,
+			}
+			if matrix != nil {
+				runJob.RawMatrix = matrix.RawMatrix
 			}
 			// check job concurrency
 			if job.RawConcurrency != nil {
@@ -174,5 +201,12 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 		}
 
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+
+	if shouldEmitJobs {
+		return EmitJobsIfReadyByRun(run.ID)
+	}
+	return nil
 }
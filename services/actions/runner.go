@@ -0,0 +1,87 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	gouuid "github.com/google/uuid"
+)
+
+// jitRunnerConfig is the state file (the ".runner" file) of act_runner, a runner started with it doesn't register again
+type jitRunnerConfig struct {
+	Warning   string   `json:"WARNING"`
+	ID        int64    `json:"id"`
+	UUID      string   `json:"uuid"`
+	Name      string   `json:"name"`
+	Token     string   `json:"token"`
+	Address   string   `json:"address"`
+	Labels    []string `json:"labels"`
+	Ephemeral bool     `json:"ephemeral"`
+}
+
+const jitRunnerConfigWarning = "This file is automatically generated by Gitea for a just-in-time runner. Do not share it, it contains the credentials of the runner."
+
+// CreateJITRunner creates a runner of the owner or the repo before it connects, and returns the base64 encoded state file of act_runner,
+// so the runner could be started without a registration token. ownerID and repoID are both 0 for a global runner.
+// The labels could be the labels of act_runner like "ubuntu-latest:docker://node:20-bookworm", the runner runs the jobs requiring their names.
+// The runner is always ephemeral, so the credentials in the config can't be used any more once it has run a job.
+func CreateJITRunner(ctx context.Context, ownerID, repoID int64, name string, labels []string) (*actions_model.ActionRunner, string, error) {
+	if ownerID != 0 && repoID != 0 {
+		// It's trying to create a runner that belongs to a repository, but OwnerID has been set accidentally.
+		ownerID = 0
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, "", util.NewInvalidArgumentErrorf("runner name is empty")
+	}
+	if len(labels) == 0 {
+		return nil, "", util.NewInvalidArgumentErrorf("runner labels are empty")
+	}
+	labelNames := make([]string, 0, len(labels))
+	for _, label := range labels {
+		labelName, _, _ := strings.Cut(label, ":")
+		if strings.TrimSpace(labelName) == "" {
+			return nil, "", util.NewInvalidArgumentErrorf("invalid runner label %q", label)
+		}
+		labelNames = append(labelNames, labelName)
+	}
+
+	runner := &actions_model.ActionRunner{
+		UUID:        gouuid.New().String(),
+		Name:        util.EllipsisDisplayString(name, 255),
+		OwnerID:     ownerID,
+		RepoID:      repoID,
+		AgentLabels: labelNames,
+		Ephemeral:   true,
+	}
+	if err := runner.GenerateToken(); err != nil {
+		return nil, "", fmt.Errorf("GenerateToken: %w", err)
+	}
+	if err := actions_model.CreateRunner(ctx, runner); err != nil {
+		return nil, "", fmt.Errorf("CreateRunner: %w", err)
+	}
+
+	config, err := json.Marshal(&jitRunnerConfig{
+		Warning:   jitRunnerConfigWarning,
+		ID:        runner.ID,
+		UUID:      runner.UUID,
+		Name:      runner.Name,
+		Token:     runner.Token,
+		Address:   setting.AppURL,
+		Labels:    labels,
+		Ephemeral: runner.Ephemeral,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return runner, base64.StdEncoding.EncodeToString(config), nil
+}
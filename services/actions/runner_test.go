@@ -0,0 +1,59 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"encoding/base64"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateJITRunner(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		_, _, err := CreateJITRunner(t.Context(), 0, 1, " ", []string{"ubuntu-latest"})
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		_, _, err = CreateJITRunner(t.Context(), 0, 1, "runner", nil)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		_, _, err = CreateJITRunner(t.Context(), 0, 1, "runner", []string{":docker://node:20-bookworm"})
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("RepoRunner", func(t *testing.T) {
+		labels := []string{"ubuntu-latest:docker://node:20-bookworm", "self-hosted"}
+		runner, encoded, err := CreateJITRunner(t.Context(), 2, 1, "jit-runner", labels)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, runner.OwnerID)
+		assert.EqualValues(t, 1, runner.RepoID)
+
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		require.NoError(t, err)
+		var config jitRunnerConfig
+		require.NoError(t, json.Unmarshal(data, &config))
+		assert.Equal(t, runner.ID, config.ID)
+		assert.Equal(t, runner.UUID, config.UUID)
+		assert.Equal(t, "jit-runner", config.Name)
+		assert.Equal(t, setting.AppURL, config.Address)
+		assert.Equal(t, labels, config.Labels)
+		assert.True(t, config.Ephemeral)
+
+		// the runner could be authenticated with the token of the config
+		dbRunner, err := actions_model.GetRunnerByUUID(t.Context(), config.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, runner.TokenHash, dbRunner.TokenHash)
+		assert.Equal(t, dbRunner.TokenHash, auth_model.HashToken(config.Token, dbRunner.TokenSalt))
+		assert.Equal(t, []string{"ubuntu-latest", "self-hosted"}, dbRunner.AgentLabels)
+		assert.True(t, dbRunner.Ephemeral)
+	})
+}
@@ -7,10 +7,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	secret_model "code.gitea.io/gitea/models/secret"
+	actions_module "code.gitea.io/gitea/modules/actions"
 	notify_service "code.gitea.io/gitea/services/notify"
 
 	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
@@ -44,6 +46,11 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 		}
 	}
 
+	// the runner group restricts the repositories, workflows and branches of the jobs the runner could pick
+	if err := runner.LoadGroup(ctx); err != nil {
+		return nil, false, fmt.Errorf("runner LoadGroup: %w", err)
+	}
+
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		t, ok, err := actions_model.CreateTaskForRunner(ctx, runner)
 		if err != nil {
@@ -59,14 +66,18 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 		job = t.Job
 		actionTask = t
 
+		vars, err := actions_model.GetVariablesOfJob(ctx, t.Job)
+		if err != nil {
+			return fmt.Errorf("GetVariablesOfJob: %w", err)
+		}
+
 		secrets, err := secret_model.GetSecretsOfTask(ctx, t)
 		if err != nil {
 			return fmt.Errorf("GetSecretsOfTask: %w", err)
 		}
-
-		vars, err := actions_model.GetVariablesOfRun(ctx, t.Job.Run)
+		secrets, err = getSecretsOfCalledWorkflowJob(ctx, job, secrets, vars)
 		if err != nil {
-			return fmt.Errorf("GetVariablesOfRun: %w", err)
+			return fmt.Errorf("getSecretsOfCalledWorkflowJob: %w", err)
 		}
 
 		needs, err := findTaskNeeds(ctx, job)
@@ -113,6 +124,27 @@ func generateTaskContext(t *actions_model.ActionTask) (*structpb.Struct, error)
 	gitCtx["token"] = t.Token
 	gitCtx["gitea_runtime_token"] = giteaRuntimeToken
 
+	if t.Job.ParentJobID > 0 {
+		// The runner reads the `inputs` context of a workflow_call event from the inputs of the event payload,
+		// it converts them to the types declared by the workflow_call trigger of the job's payload.
+		// Like the inputs of a workflow_dispatch event, it expects the booleans as strings.
+		inputs, err := getInputsOfJob(t.Job.Run, t.Job)
+		if err != nil {
+			return nil, err
+		}
+		eventInputs := make(map[string]any, len(inputs))
+		for name, value := range inputs {
+			if b, ok := value.(bool); ok {
+				value = strconv.FormatBool(b)
+			}
+			eventInputs[name] = value
+		}
+		if event, ok := gitCtx["event"].(map[string]any); ok {
+			event["inputs"] = eventInputs
+		}
+		gitCtx["event_name"] = actions_module.GithubEventWorkflowCall
+	}
+
 	return structpb.NewStruct(gitCtx)
 }
 
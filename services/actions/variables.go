@@ -7,31 +7,65 @@ import (
 	"context"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/modules/util"
 	secret_service "code.gitea.io/gitea/services/secrets"
 )
 
-func CreateVariable(ctx context.Context, ownerID, repoID int64, name, data, description string) (*actions_model.ActionVariable, error) {
+// CreateVariable creates a variable, an org or user level variable is restricted to some repositories of its owner
+// and some environments if scope is not nil, otherwise it's visible to all of them
+func CreateVariable(ctx context.Context, ownerID, repoID int64, name, data, description string, scope *actions_model.RepoScope) (*actions_model.ActionVariable, error) {
 	if err := secret_service.ValidateName(name); err != nil {
 		return nil, err
 	}
+	if scope != nil {
+		if err := secret_service.ValidateRepoScope(ctx, ownerID, repoID, scope); err != nil {
+			return nil, err
+		}
+	}
 
-	v, err := actions_model.InsertVariable(ctx, ownerID, repoID, name, util.ReserveLineBreakForTextarea(data), description)
-	if err != nil {
+	return db.WithTx2(ctx, func(ctx context.Context) (*actions_model.ActionVariable, error) {
+		v, err := actions_model.InsertVariable(ctx, ownerID, repoID, name, util.ReserveLineBreakForTextarea(data), description)
+		if err != nil {
+			return nil, err
+		}
+		if scope == nil {
+			return v, nil
+		}
+		v.RepoScope = *scope
+		if _, err := actions_model.UpdateVariableCols(ctx, v, "visibility", "selected_repo_i_ds", "selected_environments"); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+// CreateEnvironmentVariable creates a variable of an environment of a repository
+func CreateEnvironmentVariable(ctx context.Context, env *actions_model.ActionEnvironment, name, data, description string) (*actions_model.ActionVariable, error) {
+	if err := secret_service.ValidateName(name); err != nil {
 		return nil, err
 	}
-
-	return v, nil
+	return actions_model.InsertEnvironmentVariable(ctx, env.RepoID, env.ID, name, util.ReserveLineBreakForTextarea(data), description)
 }
 
-func UpdateVariableNameData(ctx context.Context, variable *actions_model.ActionVariable) (bool, error) {
+// UpdateVariableNameData updates the name, the data and the description of a variable,
+// and the repositories and environments which can use it if scope is not nil
+func UpdateVariableNameData(ctx context.Context, variable *actions_model.ActionVariable, scope *actions_model.RepoScope) (bool, error) {
 	if err := secret_service.ValidateName(variable.Name); err != nil {
 		return false, err
 	}
 
 	variable.Data = util.ReserveLineBreakForTextarea(variable.Data)
 
-	return actions_model.UpdateVariableCols(ctx, variable, "name", "data", "description")
+	cols := []string{"name", "data", "description"}
+	if scope != nil {
+		if err := secret_service.ValidateRepoScope(ctx, variable.OwnerID, variable.RepoID, scope); err != nil {
+			return false, err
+		}
+		variable.RepoScope = *scope
+		cols = append(cols, "visibility", "selected_repo_i_ds", "selected_environments")
+	}
+	return actions_model.UpdateVariableCols(ctx, variable, cols...)
 }
 
 func DeleteVariableByID(ctx context.Context, variableID int64) error {
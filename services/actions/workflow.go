@@ -5,6 +5,8 @@ package actions
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	actions_model "code.gitea.io/gitea/models/actions"
@@ -44,16 +46,17 @@ func EnableOrDisableWorkflow(ctx *context.APIContext, workflowID string, isEnabl
 	return repo_model.UpdateRepoUnit(ctx, cfgUnit)
 }
 
-func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, workflowID, ref string, processInputs func(model *model.WorkflowDispatch, inputs map[string]any) error) error {
+// DispatchActionWorkflow triggers a workflow_dispatch event of the workflow on the ref and returns the created run
+func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, workflowID, ref string, processInputs func(model *model.WorkflowDispatch, inputs map[string]any) error) (*actions_model.ActionRun, error) {
 	if workflowID == "" {
-		return util.ErrorWrapTranslatable(
+		return nil, util.ErrorWrapTranslatable(
 			util.NewNotExistErrorf("workflowID is empty"),
 			"actions.workflow.not_found", workflowID,
 		)
 	}
 
 	if ref == "" {
-		return util.ErrorWrapTranslatable(
+		return nil, util.ErrorWrapTranslatable(
 			util.NewNotExistErrorf("ref is empty"),
 			"form.target_ref_not_exist", ref,
 		)
@@ -63,7 +66,7 @@ func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, re
 	cfgUnit := repo.MustGetUnit(ctx, unit.TypeActions)
 	cfg := cfgUnit.ActionsConfig()
 	if cfg.IsWorkflowDisabled(workflowID) {
-		return util.ErrorWrapTranslatable(
+		return nil, util.ErrorWrapTranslatable(
 			util.NewPermissionDeniedErrorf("workflow is disabled"),
 			"actions.workflow.disabled",
 		)
@@ -82,7 +85,7 @@ func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, re
 		runTargetCommit, err = gitRepo.GetBranchCommit(ref)
 	}
 	if err != nil {
-		return util.ErrorWrapTranslatable(
+		return nil, util.ErrorWrapTranslatable(
 			util.NewNotExistErrorf("ref %q doesn't exist", ref),
 			"form.target_ref_not_exist", ref,
 		)
@@ -91,7 +94,7 @@ func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, re
 	// get workflow entry from runTargetCommit
 	_, entries, err := actions.ListWorkflows(runTargetCommit)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// find workflow from commit
@@ -122,7 +125,7 @@ func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, re
 	}
 
 	if entry == nil {
-		return util.ErrorWrapTranslatable(
+		return nil, util.ErrorWrapTranslatable(
 			util.NewNotExistErrorf("workflow %q doesn't exist", workflowID),
 			"actions.workflow.not_found", workflowID,
 		)
@@ -130,12 +133,12 @@ func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, re
 
 	content, err := actions.GetContentFromEntry(entry)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	singleWorkflow := &jobparser.SingleWorkflow{}
 	if err := yaml.Unmarshal(content, singleWorkflow); err != nil {
-		return fmt.Errorf("failed to unmarshal workflow content: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal workflow content: %w", err)
 	}
 	// get inputs from post
 	workflow := &model.Workflow{
@@ -144,7 +147,7 @@ func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, re
 	inputsWithDefaults := make(map[string]any)
 	if workflowDispatch := workflow.WorkflowDispatchConfig(); workflowDispatch != nil {
 		if err = processInputs(workflowDispatch, inputsWithDefaults); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -161,13 +164,55 @@ func DispatchActionWorkflow(ctx reqctx.RequestContext, doer *user_model.User, re
 
 	var eventPayload []byte
 	if eventPayload, err = workflowDispatchPayload.JSONPayload(); err != nil {
-		return fmt.Errorf("JSONPayload: %w", err)
+		return nil, fmt.Errorf("JSONPayload: %w", err)
 	}
 	run.EventPayload = string(eventPayload)
 
 	// Insert the action run and its associated jobs into the database
 	if err := PrepareRunAndInsert(ctx, content, run, inputsWithDefaults); err != nil {
-		return fmt.Errorf("PrepareRun: %w", err)
+		return nil, fmt.Errorf("PrepareRun: %w", err)
+	}
+	return run, nil
+}
+
+// ConvertWorkflowDispatchInput converts the value of an input of workflow_dispatch to its string form used in the event payload,
+// the value has to match the type of the input.
+func ConvertWorkflowDispatchInput(name string, input model.WorkflowDispatchInput, value any) (string, error) {
+	switch input.Type {
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return strconv.FormatBool(v), nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return strconv.FormatBool(b), nil
+			}
+		}
+		return "", util.NewInvalidArgumentErrorf("input %q must be a boolean", name)
+	case "number":
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case string:
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				return v, nil
+			}
+		}
+		return "", util.NewInvalidArgumentErrorf("input %q must be a number", name)
+	case "choice":
+		if v, ok := value.(string); ok && slices.Contains(input.Options, v) {
+			return v, nil
+		}
+		return "", util.NewInvalidArgumentErrorf("input %q must be one of %s", name, strings.Join(input.Options, ", "))
+	default: // string and environment
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+		return "", util.NewInvalidArgumentErrorf("input %q must be a string", name)
 	}
-	return nil
 }
@@ -0,0 +1,52 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertWorkflowDispatchInput(t *testing.T) {
+	cases := []struct {
+		input    model.WorkflowDispatchInput
+		value    any
+		expected string
+	}{
+		{model.WorkflowDispatchInput{Type: "boolean"}, true, "true"},
+		{model.WorkflowDispatchInput{Type: "boolean"}, "false", "false"},
+		{model.WorkflowDispatchInput{Type: "number"}, float64(42), "42"},
+		{model.WorkflowDispatchInput{Type: "number"}, 1.5, "1.5"},
+		{model.WorkflowDispatchInput{Type: "number"}, "3", "3"},
+		{model.WorkflowDispatchInput{Type: "choice", Options: []string{"a", "b"}}, "b", "b"},
+		{model.WorkflowDispatchInput{Type: "string"}, "text", "text"},
+		{model.WorkflowDispatchInput{}, float64(1), "1"},
+		{model.WorkflowDispatchInput{Type: "environment"}, "production", "production"},
+	}
+	for _, c := range cases {
+		value, err := ConvertWorkflowDispatchInput("input", c.input, c.value)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, value)
+	}
+
+	invalidCases := []struct {
+		input model.WorkflowDispatchInput
+		value any
+	}{
+		{model.WorkflowDispatchInput{Type: "boolean"}, "yes"},
+		{model.WorkflowDispatchInput{Type: "boolean"}, float64(1)},
+		{model.WorkflowDispatchInput{Type: "number"}, "many"},
+		{model.WorkflowDispatchInput{Type: "number"}, true},
+		{model.WorkflowDispatchInput{Type: "choice", Options: []string{"a", "b"}}, "c"},
+		{model.WorkflowDispatchInput{Type: "string"}, []any{"a"}},
+	}
+	for _, c := range invalidCases {
+		_, err := ConvertWorkflowDispatchInput("input", c.input, c.value)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"fmt"
 
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	audit_model "code.gitea.io/gitea/models/audit"
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/services/audit"
 )
 
 // DeleteRepoDeployKeys deletes all deploy keys of a repository. permissions check should be done outside
@@ -48,9 +51,10 @@ func deleteDeployKeyFromDB(ctx context.Context, key *asymkey_model.DeployKey) er
 
 // DeleteDeployKey deletes deploy key from its repository authorized_keys file if needed.
 // Permissions check should be done outside.
-func DeleteDeployKey(ctx context.Context, repo *repo_model.Repository, id int64) error {
-	if err := db.WithTx(ctx, func(ctx context.Context) error {
-		key, err := asymkey_model.GetDeployKeyByID(ctx, id)
+func DeleteDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, id int64) error {
+	var key *asymkey_model.DeployKey
+	if err := db.WithTx(ctx, func(ctx context.Context) (err error) {
+		key, err = asymkey_model.GetDeployKeyByID(ctx, id)
 		if err != nil {
 			if asymkey_model.IsErrDeployKeyNotExist(err) {
 				return nil
@@ -66,6 +70,21 @@ func DeleteDeployKey(ctx context.Context, repo *repo_model.Repository, id int64)
 	}); err != nil {
 		return err
 	}
+	if key == nil {
+		return nil
+	}
 
+	audit.Record(ctx, doer, audit_model.ActionRepoDeployKeyRemove, audit.RepoScope(repo), audit.DeployKeyTarget(key), audit.DeployKeyState(key), nil)
 	return RewriteAllPublicKeys(ctx)
 }
+
+// AddDeployKey adds a deploy key to the repository. Permissions check should be done outside.
+func AddDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, name, content string, readOnly bool) (*asymkey_model.DeployKey, error) {
+	key, err := asymkey_model.AddDeployKey(ctx, repo.ID, name, content, readOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	audit.Record(ctx, doer, audit_model.ActionRepoDeployKeyAdd, audit.RepoScope(repo), audit.DeployKeyTarget(key), nil, audit.DeployKeyState(key))
+	return key, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// Scope is the object an event belongs to, the event is shown in the audit log of this object
+type Scope struct {
+	Type audit_model.ScopeType
+	ID   int64
+	Name string
+}
+
+// SystemScope is the scope of the events which only the site administrators can see
+func SystemScope() Scope {
+	return Scope{Type: audit_model.ScopeSystem}
+}
+
+// OwnerScope returns the scope of a user or an organization
+func OwnerScope(owner *user_model.User) Scope {
+	if owner.IsOrganization() {
+		return Scope{Type: audit_model.ScopeOrganization, ID: owner.ID, Name: owner.Name}
+	}
+	return Scope{Type: audit_model.ScopeUser, ID: owner.ID, Name: owner.Name}
+}
+
+// RepoScope returns the scope of a repository
+func RepoScope(repo *repo_model.Repository) Scope {
+	return Scope{Type: audit_model.ScopeRepository, ID: repo.ID, Name: repo.FullName()}
+}
+
+// Target is the object which has been changed by the operation
+type Target struct {
+	Type string
+	ID   int64
+	Name string
+}
+
+// UserTarget returns the target of a changed user, e.g. a collaborator or a team member
+func UserTarget(u *user_model.User) Target {
+	return Target{Type: "user", ID: u.ID, Name: u.Name}
+}
+
+// Record appends an event to the audit log and streams it to the configured sinks.
+// The before and after states are encoded as JSON objects, nil means the target didn't exist.
+// Failures are only logged because they must not break the audited operation.
+func Record(ctx context.Context, doer *user_model.User, action audit_model.Action, scope Scope, target Target, before, after any) {
+	if !setting.Audit.Enabled {
+		return
+	}
+
+	e := &audit_model.Event{
+		Action:     action,
+		ScopeType:  scope.Type,
+		ScopeID:    scope.ID,
+		ScopeName:  scope.Name,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		TargetName: target.Name,
+		Before:     encodeState(before),
+		After:      encodeState(after),
+		IPAddress:  remoteIP(ctx),
+	}
+	if doer != nil {
+		e.ActorID = doer.ID
+		e.ActorName = doer.Name
+	}
+
+	if err := insertEvent(ctx, e); err != nil {
+		log.Error("Unable to record audit event %s: %v", action, err)
+		return
+	}
+	pushToSinks(e)
+}
+
+func insertEvent(ctx context.Context, e *audit_model.Event) error {
+	// the events are chained, so they have to be inserted one after another
+	releaser, err := globallock.Lock(ctx, "audit_event")
+	if err != nil {
+		return err
+	}
+	defer releaser()
+
+	return audit_model.InsertEvent(ctx, e)
+}
+
+// DeleteOldEvents removes the events which are older than the given duration
+func DeleteOldEvents(ctx context.Context, olderThan time.Duration) error {
+	// the retention updates the chain state, so no event must be inserted meanwhile
+	releaser, err := globallock.Lock(ctx, "audit_event")
+	if err != nil {
+		return err
+	}
+	defer releaser()
+
+	return audit_model.DeleteOldEvents(ctx, olderThan)
+}
+
+func encodeState(state any) string {
+	if state == nil {
+		return ""
+	}
+	bs, err := json.Marshal(state)
+	if err != nil {
+		log.Error("Unable to encode audit event state: %v", err)
+		return ""
+	}
+	return string(bs)
+}
+
+// remoteIP returns the IP address of the request which performed the operation, it's empty for background tasks
+func remoteIP(ctx context.Context) string {
+	req, ok := ctx.Value(httplib.RequestContextKey).(*http.Request)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// ToAPIEvent converts an audit event to its API format
+func ToAPIEvent(e *audit_model.Event) *api.AuditEvent {
+	return &api.AuditEvent{
+		ID:         e.ID,
+		Action:     string(e.Action),
+		ActorID:    e.ActorID,
+		ActorName:  e.ActorName,
+		IPAddress:  e.IPAddress,
+		ScopeType:  string(e.ScopeType),
+		ScopeID:    e.ScopeID,
+		ScopeName:  e.ScopeName,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		TargetName: e.TargetName,
+		Before:     decodeState(e.Before),
+		After:      decodeState(e.After),
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+		Created:    e.CreatedUnix.AsTime(),
+	}
+}
+
+func decodeState(state string) map[string]any {
+	if state == "" {
+		return nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal([]byte(state), &m); err != nil {
+		log.Error("Unable to decode audit event state: %v", err)
+	}
+	return m
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/models/perm"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	collaborator := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	// nothing is recorded if the audit log is disabled
+	Record(t.Context(), doer, audit_model.ActionRepoCollaboratorAdd, RepoScope(repo), UserTarget(collaborator), nil, AccessModeState(perm.AccessModeWrite))
+	unittest.AssertCount(t, &audit_model.Event{}, 0)
+
+	defer test.MockVariableValue(&setting.Audit.Enabled, true)()
+	Record(t.Context(), doer, audit_model.ActionRepoCollaboratorUpdate, RepoScope(repo), UserTarget(collaborator), AccessModeState(perm.AccessModeWrite), AccessModeState(perm.AccessModeAdmin))
+
+	e := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionRepoCollaboratorUpdate})
+	assert.Equal(t, doer.ID, e.ActorID)
+	assert.Equal(t, doer.Name, e.ActorName)
+	assert.Equal(t, audit_model.ScopeRepository, e.ScopeType)
+	assert.Equal(t, repo.ID, e.ScopeID)
+	assert.Equal(t, repo.FullName(), e.ScopeName)
+	assert.Equal(t, "user", e.TargetType)
+	assert.Equal(t, collaborator.ID, e.TargetID)
+	assert.Equal(t, []*audit_model.FieldChange{{Field: "access_mode", Before: "write", After: "admin"}}, e.Changes())
+	assert.Equal(t, e.ComputeHash(), e.Hash)
+}
+
+func TestSendToWebhook(t *testing.T) {
+	e := &audit_model.Event{
+		ID:         1,
+		Action:     audit_model.ActionRepoDeployKeyAdd,
+		ActorID:    2,
+		ActorName:  "user2",
+		ScopeType:  audit_model.ScopeRepository,
+		ScopeID:    1,
+		ScopeName:  "user2/repo1",
+		TargetType: "deploy_key",
+		TargetID:   3,
+		TargetName: "deploy",
+		After:      `{"access_mode":"read"}`,
+		Hash:       "hash",
+	}
+
+	var received *api.AuditEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "repo.deploy_key.add", r.Header.Get("X-Gitea-Audit-Event"))
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write(body)
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-Gitea-Signature"))
+
+		received = &api.AuditEvent{}
+		assert.NoError(t, json.Unmarshal(body, received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	defer test.MockVariableValue(&setting.Audit.WebhookURL, server.URL)()
+	defer test.MockVariableValue(&setting.Audit.WebhookSecret, "secret")()
+	require.NoError(t, sendToWebhook(t.Context(), e))
+	require.NotNil(t, received)
+	assert.Equal(t, "deploy", received.TargetName)
+	assert.Equal(t, map[string]any{"access_mode": "read"}, received.After)
+	assert.Nil(t, received.Before)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	defer test.MockVariableValue(&setting.Audit.WebhookURL, failing.URL)()
+	assert.Error(t, sendToWebhook(t.Context(), e))
+}
+
+func TestFormatSyslogMessage(t *testing.T) {
+	defer test.MockVariableValue(&setting.Audit.SyslogTag, "gitea")()
+
+	e := &audit_model.Event{
+		ID:          1,
+		Action:      audit_model.ActionOrgTeamMemberAdd,
+		ScopeType:   audit_model.ScopeOrganization,
+		CreatedUnix: 1700000000,
+	}
+	msg, err := formatSyslogMessage(e, "host")
+	require.NoError(t, err)
+
+	prefix := fmt.Sprintf("<37>1 2023-11-14T22:13:20Z host gitea %d org.team.member.add - ", os.Getpid())
+	require.True(t, strings.HasPrefix(string(msg), prefix), string(msg))
+
+	var event api.AuditEvent
+	require.NoError(t, json.Unmarshal(msg[len(prefix):], &event))
+	assert.Equal(t, "org.team.member.add", event.Action)
+	assert.Equal(t, "organization", event.ScopeType)
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// sinkQueue streams the recorded events to the configured webhook and syslog sinks,
+// it's nil if no sink is configured
+var sinkQueue *queue.WorkerPoolQueue[*audit_model.Event]
+
+// Init starts the queue which streams the events to the sinks
+func Init() error {
+	if !setting.Audit.Enabled || (setting.Audit.WebhookURL == "" && setting.Audit.SyslogAddress == "") {
+		return nil
+	}
+
+	sinkQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "audit_sink", sinkHandler)
+	if sinkQueue == nil {
+		return errors.New("unable to create audit_sink queue")
+	}
+	go graceful.GetManager().RunWithCancel(sinkQueue)
+	return nil
+}
+
+func pushToSinks(e *audit_model.Event) {
+	if sinkQueue == nil {
+		return
+	}
+	if err := sinkQueue.Push(e); err != nil {
+		log.Error("Unable to push audit event %d to the sinks: %v", e.ID, err)
+	}
+}
+
+func sinkHandler(items ...*audit_model.Event) []*audit_model.Event {
+	ctx := graceful.GetManager().ShutdownContext()
+	var unhandled []*audit_model.Event
+	for _, e := range items {
+		if setting.Audit.WebhookURL != "" {
+			if err := sendToWebhook(ctx, e); err != nil {
+				log.Error("Unable to send audit event %d to the webhook: %v", e.ID, err)
+				unhandled = append(unhandled, e)
+				continue
+			}
+		}
+		if setting.Audit.SyslogAddress != "" {
+			if err := sendToSyslog(e); err != nil {
+				// the webhook has already received the event, so it isn't retried to avoid duplicates there
+				log.Error("Unable to send audit event %d to syslog: %v", e.ID, err)
+			}
+		}
+	}
+	return unhandled
+}
+
+var webhookClient = &http.Client{
+	Timeout: 30 * time.Second,
+	Transport: &http.Transport{
+		Proxy: proxy.Proxy(),
+	},
+}
+
+// sendToWebhook posts the event to the webhook sink, the body is signed with the secret like the payloads of webhooks
+func sendToWebhook(ctx context.Context, e *audit_model.Event) error {
+	body, err := json.Marshal(ToAPIEvent(e))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, setting.Audit.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitea-Audit-Event", string(e.Action))
+	if setting.Audit.WebhookSecret != "" {
+		mac := hmac.New(sha256.New, []byte(setting.Audit.WebhookSecret))
+		_, _ = mac.Write(body)
+		req.Header.Set("X-Gitea-Signature", hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// formatSyslogMessage formats the event as a RFC 5424 message with the facility "security/authorization" and the severity "notice"
+func formatSyslogMessage(e *audit_model.Event, hostname string) ([]byte, error) {
+	msg, err := json.Marshal(ToAPIEvent(e))
+	if err != nil {
+		return nil, err
+	}
+	const priority = 4*8 + 5
+	return fmt.Appendf(nil, "<%d>1 %s %s %s %d %s - %s",
+		priority,
+		e.CreatedUnix.AsTime().UTC().Format(time.RFC3339),
+		hostname,
+		setting.Audit.SyslogTag,
+		os.Getpid(),
+		e.Action,
+		msg,
+	), nil
+}
+
+// sendToSyslog sends the event to the syslog sink, TCP messages are framed by their lengths as described in RFC 6587
+func sendToSyslog(e *audit_model.Event) error {
+	u, err := url.Parse(setting.Audit.SyslogAddress)
+	if err != nil {
+		return err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	msg, err := formatSyslogMessage(e, hostname)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout(u.Scheme, u.Host, 10*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if u.Scheme == "tcp" {
+		msg = fmt.Appendf(nil, "%d %s", len(msg), msg)
+	}
+	_, err = conn.Write(msg)
+	return err
+}
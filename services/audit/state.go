@@ -0,0 +1,158 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	auth_model "code.gitea.io/gitea/models/auth"
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// The states only contain the fields which are relevant for auditing, secrets are never recorded.
+
+// AccessModeState returns the state of a permission, e.g. of a collaborator
+func AccessModeState(mode perm.AccessMode) map[string]any {
+	return map[string]any{"access_mode": mode.ToString()}
+}
+
+// TeamTarget returns the target of a changed team
+func TeamTarget(t *organization.Team) Target {
+	return Target{Type: "team", ID: t.ID, Name: t.Name}
+}
+
+// TeamState returns the state of a team
+func TeamState(t *organization.Team) map[string]any {
+	units := make(map[string]any, len(t.Units))
+	for _, u := range t.Units {
+		units[u.Unit().NameKey] = u.AccessMode.ToString()
+	}
+	return map[string]any{
+		"name":                      t.Name,
+		"description":               t.Description,
+		"access_mode":               t.AccessMode.ToString(),
+		"includes_all_repositories": t.IncludesAllRepositories,
+		"can_create_org_repo":       t.CanCreateOrgRepo,
+		"units":                     units,
+	}
+}
+
+// OrgState returns the state of the settings of an organization
+func OrgState(org *organization.Organization) map[string]any {
+	return map[string]any{
+		"name":                          org.Name,
+		"full_name":                     org.FullName,
+		"email":                         org.Email,
+		"description":                   org.Description,
+		"website":                       org.Website,
+		"location":                      org.Location,
+		"visibility":                    org.Visibility.String(),
+		"repo_admin_change_team_access": org.RepoAdminChangeTeamAccess,
+		"max_repo_creation":             org.MaxRepoCreation,
+	}
+}
+
+// ProtectedBranchTarget returns the target of a changed branch protection rule
+func ProtectedBranchTarget(pb *git_model.ProtectedBranch) Target {
+	return Target{Type: "branch_protection", ID: pb.ID, Name: pb.RuleName}
+}
+
+// ProtectedBranchState returns the state of a branch protection rule
+func ProtectedBranchState(pb *git_model.ProtectedBranch) map[string]any {
+	return map[string]any{
+		"rule_name":                         pb.RuleName,
+		"priority":                          pb.Priority,
+		"can_push":                          pb.CanPush,
+		"enable_whitelist":                  pb.EnableWhitelist,
+		"whitelist_user_ids":                pb.WhitelistUserIDs,
+		"whitelist_team_ids":                pb.WhitelistTeamIDs,
+		"whitelist_deploy_keys":             pb.WhitelistDeployKeys,
+		"can_force_push":                    pb.CanForcePush,
+		"enable_force_push_allowlist":       pb.EnableForcePushAllowlist,
+		"force_push_allowlist_user_ids":     pb.ForcePushAllowlistUserIDs,
+		"force_push_allowlist_team_ids":     pb.ForcePushAllowlistTeamIDs,
+		"force_push_allowlist_deploy_keys":  pb.ForcePushAllowlistDeployKeys,
+		"enable_merge_whitelist":            pb.EnableMergeWhitelist,
+		"merge_whitelist_user_ids":          pb.MergeWhitelistUserIDs,
+		"merge_whitelist_team_ids":          pb.MergeWhitelistTeamIDs,
+		"enable_status_check":               pb.EnableStatusCheck,
+		"status_check_contexts":             pb.StatusCheckContexts,
+		"enable_approvals_whitelist":        pb.EnableApprovalsWhitelist,
+		"approvals_whitelist_user_ids":      pb.ApprovalsWhitelistUserIDs,
+		"approvals_whitelist_team_ids":      pb.ApprovalsWhitelistTeamIDs,
+		"required_approvals":                pb.RequiredApprovals,
+		"block_on_rejected_reviews":         pb.BlockOnRejectedReviews,
+		"block_on_official_review_requests": pb.BlockOnOfficialReviewRequests,
+		"block_on_outdated_branch":          pb.BlockOnOutdatedBranch,
+		"dismiss_stale_approvals":           pb.DismissStaleApprovals,
+		"ignore_stale_approvals":            pb.IgnoreStaleApprovals,
+		"require_signed_commits":            pb.RequireSignedCommits,
+		"require_code_owner_approvals":      pb.RequireCodeOwnerApprovals,
+		"protected_file_patterns":           pb.ProtectedFilePatterns,
+		"unprotected_file_patterns":         pb.UnprotectedFilePatterns,
+		"block_admin_merge_override":        pb.BlockAdminMergeOverride,
+		"enable_merge_queue":                pb.EnableMergeQueue,
+	}
+}
+
+// DeployKeyTarget returns the target of a changed deploy key
+func DeployKeyTarget(key *asymkey_model.DeployKey) Target {
+	return Target{Type: "deploy_key", ID: key.ID, Name: key.Name}
+}
+
+// DeployKeyState returns the state of a deploy key
+func DeployKeyState(key *asymkey_model.DeployKey) map[string]any {
+	return map[string]any{
+		"name":        key.Name,
+		"fingerprint": key.Fingerprint,
+		"access_mode": key.Mode.ToString(),
+	}
+}
+
+// WebhookTarget returns the target of a changed webhook
+func WebhookTarget(w *webhook_model.Webhook) Target {
+	return Target{Type: "webhook", ID: w.ID, Name: util.SanitizeCredentialURLs(w.URL)}
+}
+
+// WebhookState returns the state of a webhook, its secret and authorization header are omitted
+func WebhookState(w *webhook_model.Webhook) map[string]any {
+	state := map[string]any{
+		"type":         string(w.Type),
+		"url":          util.SanitizeCredentialURLs(w.URL),
+		"http_method":  w.HTTPMethod,
+		"content_type": w.ContentType.Name(),
+		"is_active":    w.IsActive,
+		"events":       w.Events,
+	}
+	if w.HookEvent != nil {
+		state["branch_filter"] = w.HookEvent.BranchFilter
+	}
+	return state
+}
+
+// AccessTokenTarget returns the target of a changed access token
+func AccessTokenTarget(t *auth_model.AccessToken) Target {
+	return Target{Type: "access_token", ID: t.ID, Name: t.Name}
+}
+
+// AccessTokenState returns the state of an access token, the token itself is never recorded
+func AccessTokenState(t *auth_model.AccessToken) map[string]any {
+	return map[string]any{
+		"name":  t.Name,
+		"scope": string(t.Scope),
+	}
+}
+
+// WebAuthnCredentialTarget returns the target of a changed security key
+func WebAuthnCredentialTarget(cred *auth_model.WebAuthnCredential) Target {
+	return Target{Type: "webauthn_credential", ID: cred.ID, Name: cred.Name}
+}
+
+// OrgTarget returns the target of changed organization settings
+func OrgTarget(org *organization.Organization) Target {
+	return Target{Type: "organization", ID: org.ID, Name: org.Name}
+}
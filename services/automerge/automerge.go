@@ -24,6 +24,7 @@ import (
 	"code.gitea.io/gitea/modules/process"
 	"code.gitea.io/gitea/modules/queue"
 	"code.gitea.io/gitea/services/automergequeue"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -261,6 +262,17 @@ func handlePullRequestAutoMerge(pullID int64, sha string) {
 	}
 
 	if err := pull_service.Merge(ctx, pr, doer, scheduledPRM.MergeStyle, "", scheduledPRM.Message, true); err != nil {
+		if errors.Is(err, pull_service.ErrMergeQueueRequired) {
+			// the merge queue has been enabled after the auto merge was scheduled, so hand the pull request over to it
+			if err := pull_model.DeleteScheduledAutoMerge(ctx, pr.ID); err != nil {
+				log.Error("DeleteScheduledAutoMerge: %v", err)
+				return
+			}
+			if err := mergequeue.AddToMergeQueue(ctx, doer, pr, scheduledPRM.MergeStyle, scheduledPRM.Message, scheduledPRM.DeleteBranchAfterMerge); err != nil {
+				log.Error("AddToMergeQueue: %v", err)
+			}
+			return
+		}
 		log.Error("pull_service.Merge: %v", err)
 		// FIXME: if merge failed, we should display some error message to the pull request page.
 		// The resolution is add a new column on automerge table named `error_message` to store the error message and displayed
@@ -196,6 +196,8 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       bp.UnprotectedFilePatterns,
 		BlockAdminMergeOverride:       bp.BlockAdminMergeOverride,
+		EnableMergeQueue:              bp.EnableMergeQueue,
+		RequireCodeOwnerApprovals:     bp.RequireCodeOwnerApprovals,
 		Created:                       bp.CreatedUnix.AsTime(),
 		Updated:                       bp.UpdatedUnix.AsTime(),
 	}
@@ -277,7 +279,7 @@ func ToActionWorkflowRun(ctx context.Context, repo *repo_model.Repository, run *
 func ToWorkflowRunAction(status actions_model.Status) string {
 	var action string
 	switch status {
-	case actions_model.StatusWaiting, actions_model.StatusBlocked:
+	case actions_model.StatusWaiting, actions_model.StatusBlocked, actions_model.StatusPendingDeployment:
 		action = "requested"
 	case actions_model.StatusRunning:
 		action = "in_progress"
@@ -295,7 +297,7 @@ func ToActionsStatus(status actions_model.Status) (string, string) {
 	// This is a naming conflict of the webhook between Gitea and GitHub Actions
 	case actions_model.StatusWaiting:
 		action = "queued"
-	case actions_model.StatusBlocked:
+	case actions_model.StatusBlocked, actions_model.StatusPendingDeployment:
 		action = "waiting"
 	case actions_model.StatusRunning:
 		action = "in_progress"
@@ -526,6 +528,21 @@ func ToActionRunner(ctx context.Context, runner *actions_model.ActionRunner) *ap
 		Busy:      status == runnerv1.RunnerStatus_RUNNER_STATUS_ACTIVE,
 		Ephemeral: runner.Ephemeral,
 		Labels:    labels,
+		GroupID:   runner.GroupID,
+	}
+}
+
+// ToActionRunnerGroup convert actions_model.ActionRunnerGroup to api.ActionRunnerGroup
+func ToActionRunnerGroup(g *actions_model.ActionRunnerGroup) *api.ActionRunnerGroup {
+	return &api.ActionRunnerGroup{
+		ID:              g.ID,
+		Name:            g.Name,
+		AllRepositories: g.AllRepos,
+		RepositoryIDs:   util.SliceNilAsEmpty(g.RepoIDs),
+		Workflows:       util.SliceNilAsEmpty(g.Workflows),
+		BranchFilters:   util.SliceNilAsEmpty(g.BranchFilters),
+		Created:         g.CreatedUnix.AsTime(),
+		Updated:         g.UpdatedUnix.AsTime(),
 	}
 }
 
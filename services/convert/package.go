@@ -10,6 +10,7 @@ import (
 	access_model "code.gitea.io/gitea/models/perm/access"
 	user_model "code.gitea.io/gitea/models/user"
 	api "code.gitea.io/gitea/modules/structs"
+	attestation_service "code.gitea.io/gitea/services/packages/attestation"
 )
 
 // ToPackage convert a packages.PackageDescriptor to api.Package
@@ -51,3 +52,27 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA512: pfd.Blob.HashSHA512,
 	}
 }
+
+// ToPackageAttestation converts a verified attestation to api.PackageAttestation
+func ToPackageAttestation(ctx context.Context, va *attestation_service.VerifiedAttestation, doer *user_model.User) (*api.PackageAttestation, error) {
+	creator, err := user_model.GetPossibleUserByID(ctx, va.CreatorID)
+	if err != nil {
+		if !user_model.IsErrUserNotExist(err) {
+			return nil, err
+		}
+		creator = user_model.NewGhostUser()
+	}
+
+	return &api.PackageAttestation{
+		ID:             va.ID,
+		Format:         va.Format,
+		PredicateType:  va.PredicateType,
+		Subjects:       va.Subjects,
+		Status:         string(va.Status),
+		KeyFingerprint: va.KeyFingerprint,
+		Identity:       va.Identity,
+		Issuer:         va.Issuer,
+		Creator:        ToUser(ctx, creator, doer),
+		CreatedAt:      va.CreatedUnix.AsTime(),
+	}, nil
+}
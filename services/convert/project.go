@@ -0,0 +1,67 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	project_model "code.gitea.io/gitea/models/project"
+	api "code.gitea.io/gitea/modules/structs"
+	project_service "code.gitea.io/gitea/services/projects"
+)
+
+// ToAPIProjectField converts a project custom field to API format
+func ToAPIProjectField(f *project_model.Field) *api.ProjectField {
+	field := &api.ProjectField{
+		ID:   f.ID,
+		Name: f.Name,
+		Type: string(f.Type),
+	}
+	for _, opt := range f.Options {
+		field.Options = append(field.Options, &api.ProjectFieldOption{ID: opt.ID, Name: opt.Name, Color: opt.Color})
+	}
+	for _, it := range f.Iterations {
+		field.Iterations = append(field.Iterations, &api.ProjectFieldIteration{
+			ID:        it.ID,
+			Title:     it.Title,
+			StartDate: it.StartDate,
+			EndDate:   it.EndDate(),
+			Duration:  it.Duration,
+		})
+	}
+	return field
+}
+
+// ToAPIProjectFieldValue converts a stored value of a project custom field to API format
+func ToAPIProjectFieldValue(f *project_model.Field, value string) *api.ProjectFieldValue {
+	v := &api.ProjectFieldValue{
+		FieldID:   f.ID,
+		FieldName: f.Name,
+		Value:     value,
+	}
+	if f.Type == project_model.FieldTypeSingleSelect || f.Type == project_model.FieldTypeIteration {
+		v.Name = f.DisplayValue(value)
+	}
+	return v
+}
+
+// ToAPIProjectItem converts a table row of a project to API format
+func ToAPIProjectItem(row *project_service.TableRow, fields []*project_model.Field) *api.ProjectItem {
+	item := &api.ProjectItem{
+		IssueID:     row.Issue.ID,
+		IssueIndex:  row.Issue.Index,
+		IsPull:      row.Issue.IsPull,
+		Title:       row.Issue.Title,
+		ColumnID:    row.Column.ID,
+		ColumnTitle: row.Column.Title,
+		FieldValues: make([]*api.ProjectFieldValue, 0, len(row.FieldValues)),
+	}
+	if row.Issue.Repo != nil {
+		item.Repository = row.Issue.Repo.FullName()
+	}
+	for _, f := range fields {
+		if value, ok := row.FieldValues[f.ID]; ok {
+			item.FieldValues = append(item.FieldValues, ToAPIProjectFieldValue(f, value))
+		}
+	}
+	return item
+}
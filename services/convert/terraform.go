@@ -0,0 +1,52 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToTerraformState converts a Terraform state to API format
+func ToTerraformState(ctx context.Context, s *terraform_model.State, doer *user_model.User) (*api.TerraformState, error) {
+	result := &api.TerraformState{
+		Name:          s.Name,
+		LatestVersion: s.LatestVersion,
+		Created:       s.CreatedUnix.AsTime(),
+		Updated:       s.UpdatedUnix.AsTime(),
+	}
+	if info := s.ParsedLockInfo(); info != nil {
+		if err := s.LoadLocker(ctx); err != nil {
+			return nil, err
+		}
+		result.Lock = &api.TerraformStateLock{
+			ID:        info.ID,
+			Operation: info.Operation,
+			Who:       info.Who,
+			Locked:    s.LockedUnix.AsTime(),
+		}
+		if s.Locker != nil {
+			result.Lock.Locker = ToUser(ctx, s.Locker, doer)
+		}
+	}
+	return result, nil
+}
+
+// ToTerraformStateVersion converts a version of a Terraform state to API format
+func ToTerraformStateVersion(ctx context.Context, sv *terraform_model.StateVersion, doer *user_model.User) (*api.TerraformStateVersion, error) {
+	if err := sv.LoadCreator(ctx); err != nil {
+		return nil, err
+	}
+	return &api.TerraformStateVersion{
+		Version: sv.Version,
+		Serial:  sv.Serial,
+		Lineage: sv.Lineage,
+		Size:    sv.Size,
+		Creator: ToUser(ctx, sv.Creator, doer),
+		Created: sv.CreatedUnix.AsTime(),
+	}, nil
+}
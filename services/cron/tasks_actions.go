@@ -20,6 +20,8 @@ func initActionsTasks() {
 	registerCancelAbandonedJobs()
 	registerScheduleTasks()
 	registerActionsCleanup()
+	registerStartApprovedDeployments()
+	registerActionsCacheCleanup()
 }
 
 func registerStopZombieTasks() {
@@ -74,3 +76,23 @@ func registerActionsCleanup() {
 		return actions_service.Cleanup(ctx)
 	})
 }
+
+func registerStartApprovedDeployments() {
+	RegisterTaskFatal("start_approved_deployments", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.StartApprovedDeployments(ctx)
+	})
+}
+
+func registerActionsCacheCleanup() {
+	RegisterTaskFatal("cleanup_actions_caches", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.CleanupCaches(ctx)
+	})
+}
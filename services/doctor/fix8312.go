@@ -29,7 +29,7 @@ func fixOwnerTeamCreateOrgRepo(ctx context.Context, logger log.Logger, autofix b
 				return nil
 			}
 
-			return org_service.UpdateTeam(ctx, team, false, false)
+			return org_service.UpdateTeam(ctx, nil, team, false, false)
 		},
 	)
 	if err != nil {
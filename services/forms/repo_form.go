@@ -191,6 +191,8 @@ type ProtectBranchForm struct {
 	ProtectedFilePatterns         string
 	UnprotectedFilePatterns       string
 	BlockAdminMergeOverride       bool
+	EnableMergeQueue              bool
+	RequireCodeOwnerApprovals     bool
 }
 
 // Validate validates the fields
@@ -475,6 +477,30 @@ type EditProjectColumnForm struct {
 	Color   string `binding:"MaxSize(7)"`
 }
 
+// ProjectColumnAutomationForm is a form for adding an automation rule to a project column
+type ProjectColumnAutomationForm struct {
+	Event   string `binding:"Required"`
+	LabelID int64
+	AutoAdd bool
+}
+
+// ProjectFieldForm is a form for creating or editing a project custom field
+type ProjectFieldForm struct {
+	Name              string `binding:"Required;MaxSize(100)"`
+	Type              string
+	Options           string
+	IterationStart    string
+	IterationDuration int
+	IterationCount    int `binding:"Range(0,20)"`
+}
+
+// ProjectViewForm is a form for saving a table or roadmap view of a project
+type ProjectViewForm struct {
+	Name  string `binding:"Required;MaxSize(100)"`
+	Type  string
+	Query string
+}
+
 // CreateMilestoneForm form for creating milestone
 type CreateMilestoneForm struct {
 	Title    string `binding:"Required;MaxSize(50)"`
@@ -714,3 +740,57 @@ func (f *DeadlineForm) Validate(req *http.Request, errs binding.Errors) binding.
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// ActionsEnvironmentForm form for creating or editing a deployment environment of Actions
+type ActionsEnvironmentForm struct {
+	Name          string `binding:"Required;MaxSize(255)"`
+	Reviewers     string // comma separated user names
+	WaitTimer     int64  `binding:"Range(0,43200)"`
+	BranchFilters string // one glob pattern per line
+}
+
+// Validate validates the fields
+func (f *ActionsEnvironmentForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// ActionsApprovalPolicyForm form for the approval policy of the runs of fork pull requests
+type ActionsApprovalPolicyForm struct {
+	OverrideOwnerPolicy     bool
+	TrustMergedPullRequests int64  `binding:"Range(0,1000)"`
+	TrustedTeams            string // comma separated team ids
+	ApproveWorkflowChanges  bool
+}
+
+// Validate validates the fields
+func (f *ActionsApprovalPolicyForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// ActionsRequiredWorkflowForm form for the workflows required in the repositories of an organization
+type ActionsRequiredWorkflowForm struct {
+	RepoID      int64  `binding:"Required"`
+	WorkflowID  string `binding:"Required;MaxSize(255)"`
+	AllRepos    bool
+	TargetRepos string // comma separated repository ids
+}
+
+// Validate validates the fields
+func (f *ActionsRequiredWorkflowForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// ReviewDeploymentForm form for approving or rejecting a deployment of Actions
+type ReviewDeploymentForm struct {
+	Action  string `binding:"Required;In(approve,reject)"`
+	Comment string `binding:"MaxSize(65535)"`
+}
+
+// Validate validates the fields
+func (f *ReviewDeploymentForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
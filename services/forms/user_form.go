@@ -323,9 +323,12 @@ func (f *AddKeyForm) Validate(req *http.Request, errs binding.Errors) binding.Er
 
 // AddSecretForm for adding secrets
 type AddSecretForm struct {
-	Name        string `binding:"Required;MaxSize(255)"`
-	Data        string `binding:"Required;MaxSize(65535)"`
-	Description string `binding:"MaxSize(65535)"`
+	Name          string `binding:"Required;MaxSize(255)"`
+	Data          string `binding:"Required;MaxSize(65535)"`
+	Description   string `binding:"MaxSize(65535)"`
+	Visibility    string `binding:"OmitEmpty;In(all,private,selected)"`
+	SelectedRepos string // comma separated repository names
+	SelectedEnvs  string // comma separated environment names
 }
 
 // Validate validates the fields
@@ -335,9 +338,12 @@ func (f *AddSecretForm) Validate(req *http.Request, errs binding.Errors) binding
 }
 
 type EditVariableForm struct {
-	Name        string `binding:"Required;MaxSize(255)"`
-	Data        string `binding:"Required;MaxSize(65535)"`
-	Description string `binding:"MaxSize(65535)"`
+	Name          string `binding:"Required;MaxSize(255)"`
+	Data          string `binding:"Required;MaxSize(65535)"`
+	Description   string `binding:"MaxSize(65535)"`
+	Visibility    string `binding:"OmitEmpty;In(all,private,selected)"`
+	SelectedRepos string // comma separated repository names
+	SelectedEnvs  string // comma separated environment names
 }
 
 func (f *EditVariableForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
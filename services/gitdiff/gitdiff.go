@@ -18,6 +18,7 @@ import (
 	"strings"
 	"time"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
@@ -76,7 +77,8 @@ type DiffLine struct {
 	Match       int // the diff matched index. -1: no match. 0: plain and no need to match. >0: for add/del, "Lines" slice index of the other side
 	Type        DiffLineType
 	Content     string
-	Comments    issues_model.CommentList // related PR code comments
+	Comments    issues_model.CommentList              // related PR code comments
+	Annotations []*actions_model.ActionTaskAnnotation // annotations created by the Actions jobs, only on the lines of the new file
 	SectionInfo *DiffLineSectionInfo
 }
 
@@ -578,6 +580,39 @@ func (diff *Diff) LoadComments(ctx context.Context, issue *issues_model.Issue, c
 	return nil
 }
 
+// LoadActionsAnnotations loads the annotations created by the Actions jobs which have run on the commit into the lines of the new files,
+// an annotation is shown under the last line it covers.
+func (diff *Diff) LoadActionsAnnotations(ctx context.Context, repoID int64, commitID string) error {
+	annotations, err := actions_model.FindCommitAnnotations(ctx, repoID, commitID)
+	if err != nil {
+		return err
+	}
+	fileAnnotations := make(map[string]map[int][]*actions_model.ActionTaskAnnotation)
+	for _, a := range annotations {
+		if a.Path == "" || a.EndLine == 0 {
+			continue
+		}
+		if fileAnnotations[a.Path] == nil {
+			fileAnnotations[a.Path] = make(map[int][]*actions_model.ActionTaskAnnotation)
+		}
+		fileAnnotations[a.Path][a.EndLine] = append(fileAnnotations[a.Path][a.EndLine], a)
+	}
+	for _, file := range diff.Files {
+		lineAnnotations, ok := fileAnnotations[file.Name]
+		if !ok {
+			continue
+		}
+		for _, section := range file.Sections {
+			for _, line := range section.Lines {
+				if line.Type != DiffLineSection && line.RightIdx > 0 {
+					line.Annotations = lineAnnotations[line.RightIdx]
+				}
+			}
+		}
+	}
+	return nil
+}
+
 const cmdDiffHead = "diff --git "
 
 // ParsePatch builds a Diff object from a io.Reader and some parameters.
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
@@ -586,6 +587,24 @@ func TestDiff_LoadCommentsWithOutdated(t *testing.T) {
 	assert.Len(t, diff.Files[0].Sections[0].Lines[0].Comments, 3)
 }
 
+func TestDiff_LoadActionsAnnotations(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+	require.NoError(t, actions_model.InsertTaskAnnotations(t.Context(), task, []*actions_model.ActionTaskAnnotation{
+		{Level: actions_model.AnnotationLevelError, Message: "on the line", Path: "README.md", StartLine: 2, EndLine: 4},
+		{Level: actions_model.AnnotationLevelWarning, Message: "not in the diff", Path: "README.md", StartLine: 5, EndLine: 5},
+		{Level: actions_model.AnnotationLevelNotice, Message: "without location"},
+	}))
+
+	diff := setupDefaultDiff()
+	assert.NoError(t, diff.LoadActionsAnnotations(t.Context(), task.RepoID, task.CommitSHA))
+	annotations := diff.Files[0].Sections[0].Lines[0].Annotations
+	if assert.Len(t, annotations, 1) {
+		assert.Equal(t, "on the line", annotations[0].Message)
+	}
+}
+
 func TestDiffLine_CanComment(t *testing.T) {
 	assert.False(t, (&DiffLine{Type: DiffLineSection}).CanComment())
 	assert.False(t, (&DiffLine{Type: DiffLineAdd, Comments: []*issues_model.Comment{{Content: "bla"}}}).CanComment())
@@ -7,6 +7,7 @@ import (
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	notify_service "code.gitea.io/gitea/services/notify"
 )
 
@@ -16,5 +17,6 @@ func Init() error {
 
 	issue_indexer.InitIssueIndexer(false)
 	code_indexer.Init()
+	wiki_indexer.Init()
 	return stats_indexer.Init()
 }
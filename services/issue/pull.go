@@ -49,6 +49,21 @@ func IsCodeOwnerFile(f string) bool {
 	return slices.Contains(codeOwnerFiles, f)
 }
 
+// GetCodeOwnersFromCommit returns the rules of the first CODEOWNERS file found in the commit
+func GetCodeOwnersFromCommit(ctx context.Context, commit *git.Commit) []*issues_model.CodeOwnerRule {
+	var data string
+	for _, file := range codeOwnerFiles {
+		if blob, err := commit.GetBlobByPath(file); err == nil {
+			data, err = blob.GetBlobContent(setting.UI.MaxDisplayFileSize)
+			if err == nil {
+				break
+			}
+		}
+	}
+	rules, _ := issues_model.GetCodeOwnersFromContent(ctx, data)
+	return rules
+}
+
 func PullRequestCodeOwnersReview(ctx context.Context, pr *issues_model.PullRequest) ([]*ReviewRequestNotifier, error) {
 	if err := pr.LoadIssue(ctx); err != nil {
 		return nil, err
@@ -80,20 +95,7 @@ func PullRequestCodeOwnersReview(ctx context.Context, pr *issues_model.PullReque
 		return nil, err
 	}
 
-	var data string
-	for _, file := range codeOwnerFiles {
-		if blob, err := commit.GetBlobByPath(file); err == nil {
-			data, err = blob.GetBlobContent(setting.UI.MaxDisplayFileSize)
-			if err == nil {
-				break
-			}
-		}
-	}
-	if data == "" {
-		return nil, nil
-	}
-
-	rules, _ := issues_model.GetCodeOwnersFromContent(ctx, data)
+	rules := GetCodeOwnersFromCommit(ctx, commit)
 	if len(rules) == 0 {
 		return nil, nil
 	}
@@ -0,0 +1,354 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/commitstatus"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+	pull_service "code.gitea.io/gitea/services/pull"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// mergeQueue holds the base branches whose merge queue needs to be processed, items are formatted as "<repo_id>:<branch>"
+var mergeQueue *queue.WorkerPoolQueue[string]
+
+// Init runs the task queue that handles merge queues
+func Init() error {
+	notify_service.RegisterNotifier(NewNotifier())
+
+	mergeQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "pr_merge_queue", handler)
+	if mergeQueue == nil {
+		return errors.New("unable to create pr_merge_queue queue")
+	}
+	go graceful.GetManager().RunWithCancel(mergeQueue)
+	return nil
+}
+
+func handler(items ...string) []string {
+	for _, s := range items {
+		repoIDStr, branch, ok := strings.Cut(s, ":")
+		repoID, err := strconv.ParseInt(repoIDStr, 10, 64)
+		if !ok || err != nil {
+			log.Error("could not parse data from pr_merge_queue queue (%v)", s)
+			continue
+		}
+		handleMergeQueue(repoID, branch)
+	}
+	return nil
+}
+
+// StartProcessing schedules the processing of the merge queue of a branch
+func StartProcessing(repoID int64, branch string) {
+	if mergeQueue == nil {
+		return
+	}
+	log.Trace("Adding merge queue of branch %s in repo %d to the processing queue", branch, repoID)
+	if err := mergeQueue.Push(fmt.Sprintf("%d:%s", repoID, branch)); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		log.Error("Error adding merge queue of branch %s in repo %d to the processing queue: %v", branch, repoID, err)
+	}
+}
+
+// AddToMergeQueue appends a pull request to the merge queue of its base branch.
+// The caller should check that the pull request is otherwise ready to be merged.
+func AddToMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, style repo_model.MergeStyle, message string, deleteBranchAfterMerge bool) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+	prUnit, err := pr.BaseRepo.GetUnit(ctx, unit.TypePullRequests)
+	if err != nil {
+		return err
+	}
+	// the merge group commits are created with this style, so it has to be checked before the pull request is queued
+	if style == repo_model.MergeStyleManuallyMerged || !prUnit.PullRequestsConfig().IsMergeStyleAllowed(style) {
+		return pull_service.ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: style}
+	}
+
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		if err := pull_model.AddToMergeQueue(ctx, &pull_model.MergeQueueEntry{
+			RepoID:                 pr.BaseRepoID,
+			BaseBranch:             pr.BaseBranch,
+			PullID:                 pr.ID,
+			DoerID:                 doer.ID,
+			MergeStyle:             style,
+			Message:                message,
+			DeleteBranchAfterMerge: deleteBranchAfterMerge,
+		}); err != nil {
+			return err
+		}
+		_, err := issues_model.CreateMergeQueueComment(ctx, issues_model.CommentTypePRAddedToMergeQueue, pr, doer)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Trace("Pull request [%d] added to the merge queue of branch %s with style [%s]", pr.ID, pr.BaseBranch, style)
+	StartProcessing(pr.BaseRepoID, pr.BaseBranch)
+	return nil
+}
+
+// CanRemoveFromMergeQueue checks if the user may remove the pull request from the merge queue.
+// This is the poster of the pull request and every user who is allowed to merge it.
+func CanRemoveFromMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) (bool, error) {
+	if doer == nil {
+		return false, nil
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		return false, err
+	}
+	if pr.Issue.IsPoster(doer.ID) {
+		return true, nil
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return false, err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, pr.BaseRepo, doer)
+	if err != nil {
+		return false, err
+	}
+	return pull_service.IsUserAllowedToMerge(ctx, pr, perm, doer)
+}
+
+// RemoveFromMergeQueue removes a pull request from the merge queue of its base branch on behalf of the user
+func RemoveFromMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
+	allowed, err := CanRemoveFromMergeQueue(ctx, doer, pr)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return util.NewPermissionDeniedErrorf("user is not allowed to remove the pull request from the merge queue")
+	}
+	return removeFromMergeQueue(ctx, doer, pr)
+}
+
+// removeFromMergeQueue removes a pull request from the merge queue of its base branch without checking the permissions of the doer
+func removeFromMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := pull_model.DeleteMergeQueueEntry(ctx, pr.ID); err != nil {
+			return err
+		}
+		_, err := issues_model.CreateMergeQueueComment(ctx, issues_model.CommentTypePRRemovedFromMergeQueue, pr, doer)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	pull_service.RemoveMergeGroupBranch(ctx, pr)
+	// the merge groups of the following pull requests have to be rebuilt without this pull request
+	StartProcessing(pr.BaseRepoID, pr.BaseBranch)
+	return nil
+}
+
+// queuedPull is a pull request with its merge queue entry
+type queuedPull struct {
+	entry *pull_model.MergeQueueEntry
+	pr    *issues_model.PullRequest
+}
+
+func handleMergeQueue(repoID int64, branch string) {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().HammerContext(),
+		fmt.Sprintf("Handle merge queue of branch %s in repo %d", branch, repoID))
+	defer finished()
+
+	releaser, err := globallock.Lock(ctx, fmt.Sprintf("merge_queue_%d_%s", repoID, branch))
+	if err != nil {
+		log.Error("lock.Lock(): %v", err)
+		return
+	}
+	defer releaser()
+
+	entries, err := pull_model.GetMergeQueueEntries(ctx, repoID, branch)
+	if err != nil {
+		log.Error("GetMergeQueueEntries[repo_id: %d, branch: %s]: %v", repoID, branch, err)
+		return
+	} else if len(entries) == 0 {
+		return
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		log.Error("GetRepositoryByID[%d]: %v", repoID, err)
+		return
+	}
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repoID, branch)
+	if err != nil {
+		log.Error("GetFirstMatchProtectedBranchRule[repo_id: %d, branch: %s]: %v", repoID, branch, err)
+		return
+	}
+	if pb == nil || !pb.IsMergeQueueEnabled() {
+		// the merge queue has been disabled, or the status checks which test the merge groups have been disabled,
+		// so all pull requests have to be merged in the usual way again
+		for _, entry := range entries {
+			removeEntry(ctx, entry, nil)
+		}
+		return
+	}
+
+	baseCommitID, err := gitrepo.GetBranchCommitID(ctx, repo, branch)
+	if err != nil {
+		log.Error("GetBranchCommitID[repo: %-v, branch: %s]: %v", repo, branch, err)
+		return
+	}
+
+	queued := buildMergeGroups(ctx, entries, branch, baseCommitID)
+
+	// Find the last merge group which passed its checks. As every merge group contains all preceding pull requests,
+	// they can all be merged together. A failed merge group stops the search as its pull request has to be dequeued.
+	lastSuccess := -1
+	var failed *queuedPull
+	for i, q := range queued {
+		state, err := getMergeGroupCommitStatusState(ctx, pb, q.entry)
+		if err != nil {
+			log.Error("getMergeGroupCommitStatusState[%-v]: %v", q.pr, err)
+			break
+		}
+		if state.IsSuccess() {
+			lastSuccess = i
+		} else if state.IsFailure() || state.IsError() {
+			failed = q
+			break
+		}
+	}
+
+	for _, q := range queued[:lastSuccess+1] {
+		if err := mergeQueuedPull(ctx, q); err != nil {
+			log.Error("mergeQueuedPull[%-v]: %v", q.pr, err)
+			if !git.IsErrPushOutOfDate(err) {
+				removeEntry(ctx, q.entry, q.pr)
+			}
+			// the merge groups of the remaining pull requests have to be rebuilt
+			StartProcessing(repoID, branch)
+			return
+		}
+	}
+
+	if failed != nil {
+		log.Info("Merge group of %-v has failed status checks, removing it from the merge queue", failed.pr)
+		removeEntry(ctx, failed.entry, failed.pr)
+		StartProcessing(repoID, branch)
+	}
+}
+
+// buildMergeGroups (re)creates the merge group commits of all entries which are not based on the current state of the queue anymore
+func buildMergeGroups(ctx context.Context, entries []*pull_model.MergeQueueEntry, branch, baseCommitID string) []*queuedPull {
+	queued := make([]*queuedPull, 0, len(entries))
+	parentBranch, parentCommitID := branch, baseCommitID
+	for _, entry := range entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil {
+			log.Error("GetPullRequestByID[%d]: %v", entry.PullID, err)
+			removeEntry(ctx, entry, nil)
+			continue
+		}
+		if err := pr.LoadIssue(ctx); err != nil {
+			log.Error("LoadIssue %-v: %v", pr, err)
+			return queued
+		}
+		if pr.HasMerged || pr.Issue.IsClosed {
+			removeEntry(ctx, entry, nil)
+			continue
+		}
+		if err := entry.LoadDoer(ctx); err != nil {
+			log.Error("LoadDoer[%d]: %v", entry.DoerID, err)
+			return queued
+		}
+
+		if entry.ParentCommitID != parentCommitID || entry.MergeGroupCommitID == "" {
+			entry.ParentCommitID, entry.MergeGroupCommitID, err = pull_service.CreateMergeGroupCommit(ctx, pr, entry.Doer, entry.MergeStyle, entry.Message, parentBranch)
+			if err != nil {
+				log.Info("Unable to create merge group commit for %-v, removing it from the merge queue: %v", pr, err)
+				removeEntry(ctx, entry, pr)
+				continue
+			}
+			if err := pull_model.UpdateMergeGroupCommit(ctx, entry); err != nil {
+				log.Error("UpdateMergeGroupCommit[%-v]: %v", pr, err)
+				return queued
+			}
+			notify_service.MergeGroupChecksRequested(ctx, entry.Doer, pr,
+				pull_service.GetMergeGroupBranchName(pr.BaseBranch, pr.Index), entry.ParentCommitID, entry.MergeGroupCommitID)
+		}
+
+		queued = append(queued, &queuedPull{entry: entry, pr: pr})
+		parentBranch = pull_service.GetMergeGroupBranchName(pr.BaseBranch, pr.Index)
+		parentCommitID = entry.MergeGroupCommitID
+	}
+	return queued
+}
+
+// getMergeGroupCommitStatusState returns the combined state of the required status checks of a merge group commit
+func getMergeGroupCommitStatusState(ctx context.Context, pb *git_model.ProtectedBranch, entry *pull_model.MergeQueueEntry) (commitstatus.CommitStatusState, error) {
+	statuses, err := git_model.GetLatestCommitStatus(ctx, entry.RepoID, entry.MergeGroupCommitID, db.ListOptionsAll)
+	if err != nil {
+		return "", err
+	}
+	return pull_service.MergeRequiredContextsCommitStatus(statuses, pb.StatusCheckContexts), nil
+}
+
+func mergeQueuedPull(ctx context.Context, q *queuedPull) error {
+	if err := pull_service.MergeQueuedPullRequest(ctx, q.pr, q.entry.Doer, q.entry.MergeGroupCommitID); err != nil {
+		return err
+	}
+	pull_service.RemoveMergeGroupBranch(ctx, q.pr)
+
+	deleteBranchAfterMerge, err := pull_service.ShouldDeleteBranchAfterMerge(ctx, &q.entry.DeleteBranchAfterMerge, q.pr.BaseRepo, q.pr)
+	if err != nil {
+		log.Error("ShouldDeleteBranchAfterMerge: %v", err)
+	} else if deleteBranchAfterMerge {
+		if err = repo_service.DeleteBranchAfterMerge(ctx, q.entry.Doer, q.pr.ID, nil); err != nil {
+			log.Error("DeleteBranchAfterMerge: %v", err)
+		}
+	}
+	return nil
+}
+
+// removeEntry drops an entry from the merge queue, if the pull request is given a comment is created for it
+func removeEntry(ctx context.Context, entry *pull_model.MergeQueueEntry, pr *issues_model.PullRequest) {
+	if pr == nil {
+		if err := pull_model.DeleteMergeQueueEntry(ctx, entry.PullID); err != nil && !errors.Is(err, util.ErrNotExist) {
+			log.Error("DeleteMergeQueueEntry[%d]: %v", entry.PullID, err)
+		}
+		if pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID); err == nil {
+			pull_service.RemoveMergeGroupBranch(ctx, pr)
+		}
+		return
+	}
+
+	if err := entry.LoadDoer(ctx); err != nil {
+		log.Error("LoadDoer[%d]: %v", entry.DoerID, err)
+		return
+	}
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := pull_model.DeleteMergeQueueEntry(ctx, entry.PullID); err != nil {
+			return err
+		}
+		_, err := issues_model.CreateMergeQueueComment(ctx, issues_model.CommentTypePRRemovedFromMergeQueue, pr, entry.Doer)
+		return err
+	}); err != nil && !errors.Is(err, util.ErrNotExist) {
+		log.Error("RemoveFromMergeQueue[%-v]: %v", pr, err)
+	}
+	pull_service.RemoveMergeGroupBranch(ctx, pr)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+	pull_service "code.gitea.io/gitea/services/pull"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanRemoveFromMergeQueue(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 3})
+
+	cases := []struct {
+		doerID  int64
+		allowed bool
+	}{
+		{doerID: 11, allowed: true}, // poster
+		{doerID: 12, allowed: true}, // repo owner
+		{doerID: 4, allowed: false}, // reader
+	}
+	for _, c := range cases {
+		doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: c.doerID})
+		allowed, err := CanRemoveFromMergeQueue(t.Context(), doer, pr)
+		assert.NoError(t, err)
+		assert.Equal(t, c.allowed, allowed, "user %d", c.doerID)
+	}
+
+	allowed, err := CanRemoveFromMergeQueue(t.Context(), nil, pr)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	assert.ErrorIs(t, RemoveFromMergeQueue(t.Context(), doer, pr), util.ErrPermissionDenied)
+}
+
+func TestAddToMergeQueueMergeStyle(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 3})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 12})
+
+	// only allow squash merges
+	prUnit := unittest.AssertExistsAndLoadBean(t, &repo_model.RepoUnit{RepoID: pr.BaseRepoID, Type: unit.TypePullRequests})
+	prUnit.Config = &repo_model.PullRequestsConfig{AllowSquash: true}
+	assert.NoError(t, repo_model.UpdateRepoUnit(t.Context(), prUnit))
+
+	for _, style := range []repo_model.MergeStyle{repo_model.MergeStyleMerge, repo_model.MergeStyleRebase, repo_model.MergeStyleManuallyMerged} {
+		err := AddToMergeQueue(t.Context(), doer, pr, style, "", false)
+		assert.True(t, pull_service.IsErrInvalidMergeStyle(err), "style %s", style)
+	}
+	unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: pr.ID})
+
+	assert.NoError(t, AddToMergeQueue(t.Context(), doer, pr, repo_model.MergeStyleSquash, "", false))
+	unittest.AssertExistsAndLoadBean(t, &pull_model.MergeQueueEntry{PullID: pr.ID, MergeStyle: repo_model.MergeStyleSquash})
+}
+
+func TestHandleMergeQueueWithoutStatusChecks(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 3})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 12})
+
+	// a rule saved before the merge queue required status checks, the merge group commits can't be tested
+	pb := &git_model.ProtectedBranch{RepoID: pr.BaseRepoID, RuleName: pr.BaseBranch, EnableMergeQueue: true}
+	assert.NoError(t, git_model.UpdateProtectBranch(t.Context(), unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: pr.BaseRepoID}), pb, git_model.WhitelistOptions{}))
+	assert.False(t, pb.IsMergeQueueEnabled())
+
+	assert.NoError(t, db.Insert(t.Context(), &pull_model.MergeQueueEntry{
+		RepoID:     pr.BaseRepoID,
+		BaseBranch: pr.BaseBranch,
+		PullID:     pr.ID,
+		DoerID:     doer.ID,
+		MergeStyle: repo_model.MergeStyleSquash,
+	}))
+
+	// the pull request is dequeued instead of being merged untested
+	handleMergeQueue(pr.BaseRepoID, pr.BaseBranch)
+	unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: pr.ID})
+	pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+	assert.False(t, pr.HasMerged)
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/repository"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+type mergeQueueNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &mergeQueueNotifier{}
+
+// NewNotifier create a new mergeQueueNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &mergeQueueNotifier{}
+}
+
+func (n *mergeQueueNotifier) CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, commit *repository.PushCommit, sender *user_model.User, status *git_model.CommitStatus) {
+	if status.State.IsPending() {
+		return
+	}
+	entries, err := pull_model.GetMergeQueueEntriesByMergeGroupCommitID(ctx, repo.ID, commit.Sha1)
+	if err != nil {
+		log.Error("GetMergeQueueEntriesByMergeGroupCommitID[repo_id: %d, sha: %s]: %v", repo.ID, commit.Sha1, err)
+		return
+	}
+	for _, entry := range entries {
+		StartProcessing(entry.RepoID, entry.BaseBranch)
+	}
+}
+
+func (n *mergeQueueNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if !opts.RefFullName.IsBranch() {
+		return
+	}
+	// the merge group commits have to be rebuilt on top of the new head of the base branch
+	entries, err := pull_model.GetMergeQueueEntries(ctx, repo.ID, opts.RefFullName.BranchName())
+	if err != nil {
+		log.Error("GetMergeQueueEntries[repo_id: %d, branch: %s]: %v", repo.ID, opts.RefFullName.BranchName(), err)
+		return
+	}
+	if len(entries) > 0 {
+		StartProcessing(repo.ID, opts.RefFullName.BranchName())
+	}
+}
+
+func (n *mergeQueueNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	// new commits have not been reviewed and tested, so the pull request must be queued again
+	removeFromMergeQueueIfQueued(ctx, doer, pr)
+}
+
+func (n *mergeQueueNotifier) PullRequestChangeTargetBranch(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, oldBranch string) {
+	// the pull request is still queued for the old base branch
+	oldPR := *pr
+	oldPR.BaseBranch = oldBranch
+	removeFromMergeQueueIfQueued(ctx, doer, &oldPR)
+}
+
+func (n *mergeQueueNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, closeOrReopen bool) {
+	if !issue.IsPull || !closeOrReopen {
+		return
+	}
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		log.Error("LoadPullRequest: %v", err)
+		return
+	}
+	removeFromMergeQueueIfQueued(ctx, doer, issue.PullRequest)
+}
+
+func removeFromMergeQueueIfQueued(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	exist, _, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		log.Error("GetMergeQueueEntryByPullID[%d]: %v", pr.ID, err)
+		return
+	} else if !exist {
+		return
+	}
+	// the pull request was closed or its base branch changed, so it has to leave the queue regardless of the doer's permissions
+	if err := removeFromMergeQueue(ctx, doer, pr); err != nil {
+		log.Error("RemoveFromMergeQueue[%-v]: %v", pr, err)
+	}
+}
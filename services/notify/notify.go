@@ -105,6 +105,13 @@ func AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues
 	}
 }
 
+// MergeGroupChecksRequested notifies that status checks should run against a merge group commit
+func MergeGroupChecksRequested(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, mergeGroupBranch, baseCommitID, headCommitID string) {
+	for _, notifier := range notifiers {
+		notifier.MergeGroupChecksRequested(ctx, doer, pr, mergeGroupBranch, baseCommitID, headCommitID)
+	}
+}
+
 // NewPullRequest notifies new pull request to notifiers
 func NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
 	if err := pr.LoadIssue(ctx); err != nil {
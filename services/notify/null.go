@@ -62,6 +62,10 @@ func (*NullNotifier) MergePullRequest(ctx context.Context, doer *user_model.User
 func (*NullNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
 }
 
+// MergeGroupChecksRequested places a place holder function
+func (*NullNotifier) MergeGroupChecksRequested(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, mergeGroupBranch, baseCommitID, headCommitID string) {
+}
+
 // PullRequestSynchronized places a place holder function
 func (*NullNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
 }
@@ -38,7 +38,11 @@ func deleteOrganization(ctx context.Context, org *org_model.Organization) error
 		&secret_model.Secret{OwnerID: org.ID},
 		&user_model.Blocking{BlockerID: org.ID},
 		&actions_model.ActionRunner{OwnerID: org.ID},
+		&actions_model.ActionApprovalPolicy{OwnerID: org.ID},
+		&actions_model.ActionRunnerGroup{OwnerID: org.ID},
+		&actions_model.ActionRequiredWorkflow{OwnerID: org.ID},
 		&actions_model.ActionRunnerToken{OwnerID: org.ID},
+		&packages_model.PackageProxy{OwnerID: org.ID},
 	); err != nil {
 		return fmt.Errorf("DeleteBeans: %w", err)
 	}
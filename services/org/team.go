@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	audit_model "code.gitea.io/gitea/models/audit"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
@@ -19,6 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/audit"
 	repo_service "code.gitea.io/gitea/services/repository"
 
 	"xorm.io/builder"
@@ -26,7 +28,7 @@ import (
 
 // NewTeam creates a record of new team.
 // It's caller's responsibility to assign organization ID.
-func NewTeam(ctx context.Context, t *organization.Team) (err error) {
+func NewTeam(ctx context.Context, doer *user_model.User, t *organization.Team) (err error) {
 	if len(t.Name) == 0 {
 		return util.NewInvalidArgumentErrorf("empty team name")
 	}
@@ -55,7 +57,7 @@ func NewTeam(ctx context.Context, t *organization.Team) (err error) {
 		return organization.ErrTeamAlreadyExist{OrgID: t.OrgID, Name: t.LowerName}
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	err = db.WithTx(ctx, func(ctx context.Context) error {
 		if err = db.Insert(ctx, t); err != nil {
 			return err
 		}
@@ -82,10 +84,16 @@ func NewTeam(ctx context.Context, t *organization.Team) (err error) {
 		_, err = db.Exec(ctx, "UPDATE `user` SET num_teams=num_teams+1 WHERE id = ?", t.OrgID)
 		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	recordTeamEvent(ctx, doer, audit_model.ActionOrgTeamCreate, t, audit.TeamTarget(t), nil, audit.TeamState(t))
+	return nil
 }
 
 // UpdateTeam updates information of team.
-func UpdateTeam(ctx context.Context, t *organization.Team, authChanged, includeAllChanged bool) (err error) {
+func UpdateTeam(ctx context.Context, doer *user_model.User, t *organization.Team, authChanged, includeAllChanged bool) (err error) {
 	if len(t.Name) == 0 {
 		return util.NewInvalidArgumentErrorf("empty team name")
 	}
@@ -94,7 +102,15 @@ func UpdateTeam(ctx context.Context, t *organization.Team, authChanged, includeA
 		t.Description = t.Description[:255]
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	oldTeam, err := organization.GetTeamByID(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	if err := oldTeam.LoadUnits(ctx); err != nil {
+		return err
+	}
+
+	err = db.WithTx(ctx, func(ctx context.Context) error {
 		t.LowerName = strings.ToLower(t.Name)
 		has, err := db.Exist[organization.Team](ctx, builder.Eq{
 			"org_id":     t.OrgID,
@@ -155,12 +171,27 @@ func UpdateTeam(ctx context.Context, t *organization.Team, authChanged, includeA
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	newState := audit.TeamState(t)
+	if len(t.Units) == 0 {
+		// the units haven't been changed
+		newState["units"] = audit.TeamState(oldTeam)["units"]
+	}
+	recordTeamEvent(ctx, doer, audit_model.ActionOrgTeamUpdate, t, audit.TeamTarget(t), audit.TeamState(oldTeam), newState)
+	return nil
 }
 
 // DeleteTeam deletes given team.
 // It's caller's responsibility to assign organization ID.
-func DeleteTeam(ctx context.Context, t *organization.Team) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+func DeleteTeam(ctx context.Context, doer *user_model.User, t *organization.Team) error {
+	if err := t.LoadUnits(ctx); err != nil {
+		return err
+	}
+
+	err := db.WithTx(ctx, func(ctx context.Context) error {
 		if err := t.LoadMembers(ctx); err != nil {
 			return err
 		}
@@ -205,11 +236,17 @@ func DeleteTeam(ctx context.Context, t *organization.Team) error {
 		_, err := db.Exec(ctx, "UPDATE `user` SET num_teams=num_teams-1 WHERE id=?", t.OrgID)
 		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	recordTeamEvent(ctx, doer, audit_model.ActionOrgTeamDelete, t, audit.TeamTarget(t), audit.TeamState(t), nil)
+	return nil
 }
 
 // AddTeamMember adds new membership of given team to given organization,
 // the user will have membership to given organization automatically when needed.
-func AddTeamMember(ctx context.Context, team *organization.Team, user *user_model.User) error {
+func AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, user *user_model.User) error {
 	if user_model.IsUserBlockedBy(ctx, user, team.OrgID) {
 		return user_model.ErrBlockedUser
 	}
@@ -249,6 +286,8 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 		return err
 	}
 
+	recordTeamEvent(ctx, doer, audit_model.ActionOrgTeamMemberAdd, team, audit.UserTarget(user), nil, map[string]any{"team": team.Name})
+
 	// this behaviour may spend much time so run it in a goroutine
 	// FIXME: Update watch repos batchly
 	if setting.Service.AutoWatchNewRepos {
@@ -345,8 +384,28 @@ func removeInvalidOrgUser(ctx context.Context, orgID int64, user *user_model.Use
 }
 
 // RemoveTeamMember removes member from given team of given organization.
-func RemoveTeamMember(ctx context.Context, team *organization.Team, user *user_model.User) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+func RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, user *user_model.User) error {
+	isMember, err := organization.IsTeamMember(ctx, team.OrgID, team.ID, user.ID)
+	if err != nil || !isMember {
+		return err
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		return removeTeamMember(ctx, team, user)
-	})
+	}); err != nil {
+		return err
+	}
+
+	recordTeamEvent(ctx, doer, audit_model.ActionOrgTeamMemberRemove, team, audit.UserTarget(user), map[string]any{"team": team.Name}, nil)
+	return nil
+}
+
+// recordTeamEvent records an audit event of a team in the audit log of its organization
+func recordTeamEvent(ctx context.Context, doer *user_model.User, action audit_model.Action, team *organization.Team, target audit.Target, before, after any) {
+	org, err := organization.GetOrgByID(ctx, team.OrgID)
+	if err != nil {
+		log.Error("GetOrgByID: %v", err)
+		return
+	}
+	audit.Record(ctx, doer, action, audit.OwnerScope(org.AsUser()), target, before, after)
 }
@@ -0,0 +1,200 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package attestation
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	attestation_module "code.gitea.io/gitea/modules/packages/attestation"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// MaxAttestationSize is the maximum size of an uploaded signature or attestation
+const MaxAttestationSize = 4 * 1024 * 1024
+
+var (
+	ErrAttestationTooLarge = util.NewInvalidArgumentErrorf("attestation is too large")
+	ErrInvalidSignature    = util.NewInvalidArgumentErrorf("signature does not match the embedded certificate")
+	ErrNoTrustedKeys       = util.NewInvalidArgumentErrorf("trusted keys are required to enforce signatures")
+)
+
+// VerifiedAttestation is a stored attestation with the result of its verification against the trusted keys of the owner
+type VerifiedAttestation struct {
+	*packages_model.PackageAttestation
+	*attestation_module.Verification
+	Subjects []string
+}
+
+// SigningSettings are the package signing settings of an owner
+type SigningSettings struct {
+	TrustedKeys string
+	Required    bool
+}
+
+// GetSigningSettings returns the package signing settings of the owner
+func GetSigningSettings(ctx context.Context, ownerID int64) (*SigningSettings, error) {
+	settings, err := user_model.GetSettings(ctx, ownerID, []string{attestation_module.SettingKeyTrustedKeys, attestation_module.SettingKeyRequired})
+	if err != nil {
+		return nil, err
+	}
+
+	ss := &SigningSettings{}
+	if s, ok := settings[attestation_module.SettingKeyTrustedKeys]; ok {
+		ss.TrustedKeys = s.SettingValue
+	}
+	if s, ok := settings[attestation_module.SettingKeyRequired]; ok {
+		ss.Required = s.SettingValue == "true"
+	}
+	return ss, nil
+}
+
+// SaveSigningSettings validates and stores the package signing settings of the owner
+func SaveSigningSettings(ctx context.Context, ownerID int64, ss *SigningSettings) error {
+	keys, err := attestation_module.ParsePublicKeys(ss.TrustedKeys)
+	if err != nil {
+		return err
+	}
+	if ss.Required && len(keys) == 0 {
+		return ErrNoTrustedKeys
+	}
+
+	if err := user_model.SetUserSetting(ctx, ownerID, attestation_module.SettingKeyTrustedKeys, strings.TrimSpace(ss.TrustedKeys)); err != nil {
+		return err
+	}
+	return user_model.SetUserSetting(ctx, ownerID, attestation_module.SettingKeyRequired, util.Iif(ss.Required, "true", "false"))
+}
+
+// GetTrustedKeys returns the public keys the owner trusts to sign packages
+func GetTrustedKeys(ctx context.Context, ownerID int64) ([]crypto.PublicKey, error) {
+	s, err := user_model.GetSetting(ctx, ownerID, attestation_module.SettingKeyTrustedKeys)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return attestation_module.ParsePublicKeys(s)
+}
+
+// IsSignatureRequired checks if the owner rejects uploads of package files without a verified signature
+func IsSignatureRequired(ctx context.Context, ownerID int64) (bool, error) {
+	s, err := user_model.GetSetting(ctx, ownerID, attestation_module.SettingKeyRequired)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return s == "true", nil
+}
+
+// UploadAttestation stores a signature or attestation for package files of the owner.
+// The SHA256 digest of the signed file is only needed for formats which don't name their subjects.
+func UploadAttestation(ctx context.Context, owner, doer *user_model.User, data []byte, subjectSHA256 string) (*packages_model.PackageAttestation, error) {
+	if len(data) > MaxAttestationSize {
+		return nil, ErrAttestationTooLarge
+	}
+
+	a, err := attestation_module.Parse(data, subjectSHA256)
+	if err != nil {
+		return nil, err
+	}
+	if a.Verify(nil).Status == attestation_module.StatusInvalid {
+		return nil, ErrInvalidSignature
+	}
+
+	sum := sha256.Sum256(data)
+
+	return packages_model.TryInsertAttestation(ctx, &packages_model.PackageAttestation{
+		OwnerID:       owner.ID,
+		Format:        a.Format,
+		PredicateType: a.PredicateType,
+		Content:       string(data),
+		ContentSHA256: hex.EncodeToString(sum[:]),
+		CreatorID:     doer.ID,
+	}, a.Subjects)
+}
+
+// VerifyAttestation verifies a stored attestation against the trusted keys
+func VerifyAttestation(ctx context.Context, pa *packages_model.PackageAttestation, trustedKeys []crypto.PublicKey) (*VerifiedAttestation, error) {
+	subjects, err := packages_model.GetAttestationSubjects(ctx, pa.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	va := &VerifiedAttestation{
+		PackageAttestation: pa,
+		Subjects:           subjects,
+	}
+
+	var subject string
+	if len(subjects) > 0 {
+		subject = subjects[0]
+	}
+	a, err := attestation_module.Parse([]byte(pa.Content), subject)
+	if err != nil {
+		log.Error("Error parsing stored attestation %d: %v", pa.ID, err)
+		va.Verification = &attestation_module.Verification{Status: attestation_module.StatusInvalid}
+	} else {
+		va.Verification = a.Verify(trustedKeys)
+	}
+	return va, nil
+}
+
+// GetAttestationsBySubjects returns the verified attestations of the owner which cover the digests
+func GetAttestationsBySubjects(ctx context.Context, ownerID int64, sha256s []string) ([]*VerifiedAttestation, error) {
+	pas, err := packages_model.GetAttestationsBySubjects(ctx, ownerID, sha256s)
+	if err != nil {
+		return nil, err
+	}
+	if len(pas) == 0 {
+		return nil, nil
+	}
+
+	trustedKeys, err := GetTrustedKeys(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	vas := make([]*VerifiedAttestation, 0, len(pas))
+	for _, pa := range pas {
+		va, err := VerifyAttestation(ctx, pa, trustedKeys)
+		if err != nil {
+			return nil, err
+		}
+		vas = append(vas, va)
+	}
+	return vas, nil
+}
+
+// GetVersionAttestations returns the verified attestations which cover files of the package version
+func GetVersionAttestations(ctx context.Context, pd *packages_model.PackageDescriptor) ([]*VerifiedAttestation, error) {
+	sha256s := make([]string, 0, len(pd.Files))
+	for _, pfd := range pd.Files {
+		sha256s = append(sha256s, pfd.Blob.HashSHA256)
+	}
+	return GetAttestationsBySubjects(ctx, pd.Owner.ID, sha256s)
+}
+
+// HasVerifiedAttestation checks if the owner has an attestation of the digest which is signed by a trusted key
+func HasVerifiedAttestation(ctx context.Context, ownerID int64, sha256 string) (bool, error) {
+	vas, err := GetAttestationsBySubjects(ctx, ownerID, []string{sha256})
+	if err != nil {
+		return false, err
+	}
+	for _, va := range vas {
+		if va.Status == attestation_module.StatusVerified {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -14,6 +14,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/timeutil"
 	packages_service "code.gitea.io/gitea/services/packages"
 	alpine_service "code.gitea.io/gitea/services/packages/alpine"
 	arch_service "code.gitea.io/gitea/services/packages/arch"
@@ -29,6 +30,10 @@ func CleanupTask(ctx context.Context, olderThan time.Duration) error {
 		return err
 	}
 
+	if err := ExecuteProxyCacheCleanup(ctx); err != nil {
+		return err
+	}
+
 	return CleanupExpiredData(ctx, olderThan)
 }
 
@@ -164,6 +169,33 @@ func ExecuteCleanupRules(ctx context.Context) error {
 	})
 }
 
+// ExecuteProxyCacheCleanup removes the versions fetched by the package proxies after their cache days
+func ExecuteProxyCacheCleanup(ctx context.Context) error {
+	return packages_model.IterateProxiesWithCacheExpiration(ctx, func(ctx context.Context, pp *packages_model.PackageProxy) error {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("While processing package proxy caches")
+		default:
+		}
+
+		olderThan := timeutil.TimeStamp(time.Now().AddDate(0, 0, -pp.CacheDays).Unix())
+		pvs, err := packages_model.FindExpiredProxiedVersions(ctx, pp.OwnerID, pp.Type, olderThan)
+		if err != nil {
+			log.Error("PackageProxy [%d]: FindExpiredProxiedVersions failed: %v", pp.ID, err)
+			return nil
+		}
+		for _, pv := range pvs {
+			log.Debug("PackageProxy [%d]: remove version %d", pp.ID, pv.ID)
+			if err := db.WithTx(ctx, func(ctx context.Context) error {
+				return packages_service.DeletePackageVersionAndReferences(ctx, pv)
+			}); err != nil {
+				log.Error("PackageProxy [%d]: DeletePackageVersionAndReferences failed: %v", pp.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
 func CleanupExpiredData(ctx context.Context, olderThan time.Duration) error {
 	pbs := make([]*packages_model.PackageBlob, 0, 100)
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
@@ -184,6 +216,16 @@ func CleanupExpiredData(ctx context.Context, olderThan time.Duration) error {
 			}
 		}
 
+		pas, err := packages_model.FindUnreferencedAttestations(ctx, olderThan)
+		if err != nil {
+			return err
+		}
+		for _, pa := range pas {
+			if err := packages_model.DeleteAttestationByID(ctx, pa.ID); err != nil {
+				return err
+			}
+		}
+
 		pbs, err = packages_model.FindExpiredUnreferencedBlobs(ctx, olderThan)
 		if err != nil {
 			return err
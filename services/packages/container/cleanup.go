@@ -5,6 +5,7 @@ package container
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	packages_model "code.gitea.io/gitea/models/packages"
@@ -21,6 +22,9 @@ func Cleanup(ctx context.Context, olderThan time.Duration) error {
 	if err := cleanupExpiredBlobUploads(ctx, olderThan); err != nil {
 		return err
 	}
+	if err := cleanupOrphanedReferrers(ctx, olderThan); err != nil {
+		return err
+	}
 	return cleanupExpiredUploadedBlobs(ctx, olderThan)
 }
 
@@ -79,6 +83,22 @@ func cleanupExpiredUploadedBlobs(ctx context.Context, olderThan time.Duration) e
 	return nil
 }
 
+// cleanupOrphanedReferrers removes manifests (signatures, SBOMs, ...) whose subject manifest does not exist anymore
+func cleanupOrphanedReferrers(ctx context.Context, olderThan time.Duration) error {
+	pvs, err := container_model.FindOrphanedReferrers(ctx, olderThan)
+	if err != nil {
+		return err
+	}
+
+	for _, pv := range pvs {
+		if err := packages_service.DeletePackageVersionAndReferences(ctx, pv); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package, pv *packages_model.PackageVersion) (bool, error) {
 	// Always skip the "latest" tag
 	if pv.LowerVersion == "latest" {
@@ -104,5 +124,24 @@ func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule
 		}
 	}
 
+	// Skip manifests referring to an existing manifest, they get removed together with their subject
+	pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject)
+	if err != nil {
+		return false, err
+	}
+	if len(pps) > 0 {
+		_, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+			OwnerID:    p.OwnerID,
+			Image:      p.LowerName,
+			Digest:     pps[0].Value,
+			IsManifest: true,
+		})
+		if err == nil {
+			return true, nil
+		} else if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+			return false, err
+		}
+	}
+
 	return false, nil
 }
@@ -60,5 +60,14 @@ func ParseManifestMetadata(ctx context.Context, rd io.Reader, ownerID int64, ima
 	}
 	defer configReader.Close()
 	metadata, err := container_module.ParseImageConfig(manifest.Config.MediaType, configReader)
-	return &manifest, configDescriptor, metadata, err
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	metadata.ArtifactType = container_module.ManifestArtifactType(&manifest)
+	metadata.Annotations = manifest.Annotations
+	if manifest.Subject != nil {
+		metadata.Subject = manifest.Subject.Digest.String()
+	}
+	return &manifest, configDescriptor, metadata, nil
 }
@@ -0,0 +1,68 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/json"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Referrer is a manifest which refers to another manifest, for example a signature or a SBOM
+type Referrer struct {
+	Version    *packages_model.PackageVersion
+	Descriptor v1.Descriptor
+}
+
+// GetReferrers gets the manifests which refer to the subject manifest
+// If artifactType is not empty, only manifests of this artifact type are returned.
+func GetReferrers(ctx context.Context, ownerID int64, image, subject, artifactType string) ([]*Referrer, error) {
+	pfds, err := container_model.GetReferrers(ctx, ownerID, image, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	referrers := make([]*Referrer, 0, len(pfds))
+	seen := make(map[string]bool)
+	for _, pfd := range pfds {
+		manifestDigest := pfd.Properties.GetByName(container_module.PropertyDigest)
+		// the same manifest may be referenced by a tag and its digest
+		if seen[manifestDigest] {
+			continue
+		}
+		seen[manifestDigest] = true
+
+		pv, err := packages_model.GetVersionByID(ctx, pfd.File.VersionID)
+		if err != nil {
+			return nil, err
+		}
+		metadata := &container_module.Metadata{}
+		if err := json.Unmarshal([]byte(pv.MetadataJSON), metadata); err != nil {
+			return nil, err
+		}
+
+		if artifactType != "" && metadata.ArtifactType != artifactType {
+			continue
+		}
+
+		referrers = append(referrers, &Referrer{
+			Version: pv,
+			Descriptor: v1.Descriptor{
+				MediaType:    pfd.Properties.GetByName(container_module.PropertyMediaType),
+				Digest:       digest.Digest(manifestDigest),
+				Size:         pfd.Blob.Size,
+				ArtifactType: metadata.ArtifactType,
+				Annotations:  metadata.Annotations,
+			},
+		})
+	}
+
+	return referrers, nil
+}
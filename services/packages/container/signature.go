@@ -0,0 +1,100 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/packages"
+	attestation_module "code.gitea.io/gitea/modules/packages/attestation"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+	attestation_service "code.gitea.io/gitea/services/packages/attestation"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// CheckTagSignatureRequired checks if the manifest a tag resolves to has a verified signature if the owner requires signed packages.
+// Manifests are pushed before they can be signed by their digest, so only the tag is held back until the signature is uploaded.
+// Versions fetched by a proxy are skipped because they are not published by the owner.
+func CheckTagSignatureRequired(ctx context.Context, ownerID int64, pfd *packages_model.PackageFileDescriptor) error {
+	required, err := attestation_service.IsSignatureRequired(ctx, ownerID)
+	if err != nil || !required {
+		return err
+	}
+
+	pvps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, pfd.File.VersionID, packages_model.PropertyProxyUpstream)
+	if err != nil {
+		return err
+	}
+	if len(pvps) > 0 {
+		return nil
+	}
+
+	has, err := attestation_service.HasVerifiedAttestation(ctx, ownerID, strings.TrimPrefix(pfd.Properties.GetByName(container_module.PropertyDigest), "sha256:"))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return packages_service.ErrMissingSignature
+	}
+	return nil
+}
+
+// StoreCosignSignatures stores the signatures of a cosign signature manifest as package attestations of the signed image
+func StoreCosignSignatures(ctx context.Context, owner, doer *user_model.User, imageName string, manifest *v1.Manifest) error {
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != attestation_module.MediaTypeCosignSimpleSigning || layer.Size > attestation_service.MaxAttestationSize {
+			continue
+		}
+		signature := layer.Annotations[attestation_module.AnnotationCosignSignature]
+		if signature == "" {
+			continue
+		}
+
+		pfd, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+			OwnerID: owner.ID,
+			Image:   imageName,
+			Digest:  layer.Digest.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		r, err := packages.NewContentStore().OpenBlob(packages.BlobHash256Key(pfd.Blob.HashSHA256))
+		if err != nil {
+			return err
+		}
+		payload, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+
+		data, err := attestation_module.MarshalCosignSignature(payload, signature, layer.Annotations[attestation_module.AnnotationCosignCertificate])
+		if err != nil {
+			return err
+		}
+
+		if _, err := attestation_service.UploadAttestation(ctx, owner, doer, data, ""); err != nil {
+			if errors.Is(err, packages_model.ErrDuplicatePackageAttestation) {
+				continue
+			}
+			if errors.Is(err, util.ErrInvalidArgument) {
+				log.Warn("Ignoring invalid cosign signature %s of image %s: %v", layer.Digest, imageName, err)
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
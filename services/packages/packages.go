@@ -24,12 +24,14 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/storage"
 	notify_service "code.gitea.io/gitea/services/notify"
+	attestation_service "code.gitea.io/gitea/services/packages/attestation"
 )
 
 var (
-	ErrQuotaTypeSize   = errors.New("maximum allowed package type size exceeded")
-	ErrQuotaTotalSize  = errors.New("maximum allowed package storage quota exceeded")
-	ErrQuotaTotalCount = errors.New("maximum allowed package count exceeded")
+	ErrQuotaTypeSize    = errors.New("maximum allowed package type size exceeded")
+	ErrQuotaTotalSize   = errors.New("maximum allowed package storage quota exceeded")
+	ErrQuotaTotalCount  = errors.New("maximum allowed package count exceeded")
+	ErrMissingSignature = errors.New("package file has no signature by a trusted key")
 )
 
 // PackageInfo describes a package
@@ -255,9 +257,40 @@ func addFileToPackageVersion(ctx context.Context, pv *packages_model.PackageVers
 		return nil, nil, false, err
 	}
 
+	if err := checkSignatureRequired(ctx, pv, pvi, pfci); err != nil {
+		return nil, nil, false, err
+	}
+
 	return addFileToPackageVersionUnchecked(ctx, pv, pfci)
 }
 
+// checkSignatureRequired checks if the file has a verified signature if the owner requires signed packages.
+// Versions fetched by a proxy are skipped because they are not published by the owner.
+func checkSignatureRequired(ctx context.Context, pv *packages_model.PackageVersion, pvi *PackageInfo, pfci *PackageFileCreationInfo) error {
+	required, err := attestation_service.IsSignatureRequired(ctx, pvi.Owner.ID)
+	if err != nil || !required {
+		return err
+	}
+
+	pvps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, packages_model.PropertyProxyUpstream)
+	if err != nil {
+		return err
+	}
+	if len(pvps) > 0 {
+		return nil
+	}
+
+	_, _, hashSHA256, _ := pfci.Data.Sums()
+	has, err := attestation_service.HasVerifiedAttestation(ctx, pvi.Owner.ID, hex.EncodeToString(hashSHA256))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return ErrMissingSignature
+	}
+	return nil
+}
+
 func addFileToPackageVersionUnchecked(ctx context.Context, pv *packages_model.PackageVersion, pfci *PackageFileCreationInfo) (*packages_model.PackageFile, *packages_model.PackageBlob, bool, error) {
 	log.Trace("Adding package file: %v, %s", pv.ID, pfci.Filename)
 
@@ -343,61 +376,67 @@ func CheckCountQuotaExceeded(ctx context.Context, doer, owner *user_model.User)
 	return nil
 }
 
-// CheckSizeQuotaExceeded checks if the upload size is bigger than the allowed size
-// The check is skipped if the doer is an admin.
-func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, packageType packages_model.Type, uploadSize int64) error {
-	if doer.IsAdmin {
-		return nil
-	}
-
-	var typeSpecificSize int64
+// GetTypeSizeLimit returns the maximum allowed size of a package file of the package type, -1 if there is no limit
+func GetTypeSizeLimit(packageType packages_model.Type) int64 {
 	switch packageType {
 	case packages_model.TypeAlpine:
-		typeSpecificSize = setting.Packages.LimitSizeAlpine
+		return setting.Packages.LimitSizeAlpine
 	case packages_model.TypeArch:
-		typeSpecificSize = setting.Packages.LimitSizeArch
+		return setting.Packages.LimitSizeArch
 	case packages_model.TypeCargo:
-		typeSpecificSize = setting.Packages.LimitSizeCargo
+		return setting.Packages.LimitSizeCargo
 	case packages_model.TypeChef:
-		typeSpecificSize = setting.Packages.LimitSizeChef
+		return setting.Packages.LimitSizeChef
 	case packages_model.TypeComposer:
-		typeSpecificSize = setting.Packages.LimitSizeComposer
+		return setting.Packages.LimitSizeComposer
 	case packages_model.TypeConan:
-		typeSpecificSize = setting.Packages.LimitSizeConan
+		return setting.Packages.LimitSizeConan
 	case packages_model.TypeConda:
-		typeSpecificSize = setting.Packages.LimitSizeConda
+		return setting.Packages.LimitSizeConda
 	case packages_model.TypeContainer:
-		typeSpecificSize = setting.Packages.LimitSizeContainer
+		return setting.Packages.LimitSizeContainer
 	case packages_model.TypeCran:
-		typeSpecificSize = setting.Packages.LimitSizeCran
+		return setting.Packages.LimitSizeCran
 	case packages_model.TypeDebian:
-		typeSpecificSize = setting.Packages.LimitSizeDebian
+		return setting.Packages.LimitSizeDebian
 	case packages_model.TypeGeneric:
-		typeSpecificSize = setting.Packages.LimitSizeGeneric
+		return setting.Packages.LimitSizeGeneric
 	case packages_model.TypeGo:
-		typeSpecificSize = setting.Packages.LimitSizeGo
+		return setting.Packages.LimitSizeGo
 	case packages_model.TypeHelm:
-		typeSpecificSize = setting.Packages.LimitSizeHelm
+		return setting.Packages.LimitSizeHelm
 	case packages_model.TypeMaven:
-		typeSpecificSize = setting.Packages.LimitSizeMaven
+		return setting.Packages.LimitSizeMaven
 	case packages_model.TypeNpm:
-		typeSpecificSize = setting.Packages.LimitSizeNpm
+		return setting.Packages.LimitSizeNpm
 	case packages_model.TypeNuGet:
-		typeSpecificSize = setting.Packages.LimitSizeNuGet
+		return setting.Packages.LimitSizeNuGet
 	case packages_model.TypePub:
-		typeSpecificSize = setting.Packages.LimitSizePub
+		return setting.Packages.LimitSizePub
 	case packages_model.TypePyPI:
-		typeSpecificSize = setting.Packages.LimitSizePyPI
+		return setting.Packages.LimitSizePyPI
 	case packages_model.TypeRpm:
-		typeSpecificSize = setting.Packages.LimitSizeRpm
+		return setting.Packages.LimitSizeRpm
 	case packages_model.TypeRubyGems:
-		typeSpecificSize = setting.Packages.LimitSizeRubyGems
+		return setting.Packages.LimitSizeRubyGems
 	case packages_model.TypeSwift:
-		typeSpecificSize = setting.Packages.LimitSizeSwift
+		return setting.Packages.LimitSizeSwift
+	case packages_model.TypeTerraform:
+		return setting.Packages.LimitSizeTerraform
 	case packages_model.TypeVagrant:
-		typeSpecificSize = setting.Packages.LimitSizeVagrant
+		return setting.Packages.LimitSizeVagrant
 	}
-	if typeSpecificSize > -1 && typeSpecificSize < uploadSize {
+	return -1
+}
+
+// CheckSizeQuotaExceeded checks if the upload size is bigger than the allowed size
+// The check is skipped if the doer is an admin.
+func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, packageType packages_model.Type, uploadSize int64) error {
+	if doer.IsAdmin {
+		return nil
+	}
+
+	if typeSpecificSize := GetTypeSizeLimit(packageType); typeSpecificSize > -1 && typeSpecificSize < uploadSize {
 		return ErrQuotaTypeSize
 	}
 
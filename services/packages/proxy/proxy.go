@@ -0,0 +1,301 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/hostmatcher"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// the metadata documents are loaded into memory, the package files are streamed into the content store
+const maxMetadataSize = 64 * 1024 * 1024
+
+// Client fetches the packages from the upstream registry of a package proxy
+type Client struct {
+	Proxy *packages_model.PackageProxy
+	Owner *user_model.User
+
+	baseURL  *url.URL
+	password string
+	client   *http.Client
+	tokens   map[string]string // the bearer tokens of the upstream container registry by scope
+}
+
+// GetClient returns the client of the enabled proxy of the package type of the owner,
+// packages_model.ErrPackageProxyNotExist is returned if there is none
+func GetClient(ctx context.Context, owner *user_model.User, packageType packages_model.Type) (*Client, error) {
+	pp, err := packages_model.GetEnabledProxyByOwnerAndType(ctx, owner.ID, packageType)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(owner, pp)
+}
+
+// NewClient creates the client of the proxy, it can only call the hosts allowed by the PROXY_ALLOWED_HOST_LIST setting
+func NewClient(owner *user_model.User, pp *packages_model.PackageProxy) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(pp.URL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	password, err := pp.Password()
+	if err != nil {
+		return nil, err
+	}
+
+	allowedHostListValue := setting.Packages.ProxyAllowedHostList
+	if allowedHostListValue == "" {
+		allowedHostListValue = hostmatcher.MatchBuiltinExternal
+	}
+	allowedHostMatcher := hostmatcher.ParseHostMatchList("packages.PROXY_ALLOWED_HOST_LIST", allowedHostListValue)
+
+	return &Client{
+		Proxy:    pp,
+		Owner:    owner,
+		baseURL:  baseURL,
+		password: password,
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:       proxy.Proxy(),
+				DialContext: hostmatcher.NewDialContext("package proxy", allowedHostMatcher, nil, setting.Proxy.ProxyURLFixed),
+			},
+		},
+		tokens: make(map[string]string),
+	}, nil
+}
+
+// URL returns the absolute url of the path in the upstream registry
+func (c *Client) URL(p string) string {
+	return c.baseURL.String() + "/" + strings.TrimPrefix(p, "/")
+}
+
+// VersionProperties returns the properties of the versions fetched from the upstream registry
+func (c *Client) VersionProperties() map[string]string {
+	return map[string]string{
+		packages_model.PropertyProxyUpstream: c.Proxy.URL,
+	}
+}
+
+// Lock prevents the same package from being fetched by the concurrent requests
+func (c *Client) Lock(ctx context.Context, name string) (globallock.ReleaseFunc, error) {
+	return globallock.Lock(ctx, fmt.Sprintf("pkg_proxy_%d_%s_%s", c.Proxy.OwnerID, c.Proxy.Type, strings.ToLower(name)))
+}
+
+// Get requests the url, which is resolved against the upstream registry if it's a path.
+// An error wrapping util.ErrNotExist is returned if the upstream registry doesn't have it.
+func (c *Client) Get(ctx context.Context, u string, header http.Header) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, u, header)
+}
+
+// Head is like Get, but only requests the headers
+func (c *Client) Head(ctx context.Context, u string, header http.Header) (*http.Response, error) {
+	return c.do(ctx, http.MethodHead, u, header)
+}
+
+func (c *Client) do(ctx context.Context, method, u string, header http.Header) (*http.Response, error) {
+	if !strings.Contains(u, "://") {
+		u = c.URL(u)
+	}
+
+	// the urls taken from the upstream metadata may point to other hosts, they must not get the credentials
+	withCredentials := c.isUpstreamURL(u)
+
+	resp, err := c.send(ctx, method, u, header, "", withCredentials)
+	if err != nil {
+		return nil, err
+	}
+	// the container registries require a token of the scope of the request
+	if resp.StatusCode == http.StatusUnauthorized && strings.HasPrefix(resp.Header.Get("WWW-Authenticate"), "Bearer ") {
+		resp.Body.Close()
+		token, err := c.fetchBearerToken(ctx, resp.Header.Get("WWW-Authenticate"), withCredentials)
+		if err != nil {
+			return nil, err
+		}
+		if resp, err = c.send(ctx, method, u, header, token, withCredentials); err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, util.NewNotExistErrorf("%s does not exist in the upstream registry", u)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		log.Warn("Package proxy [%d]: %s %s responded %d", c.Proxy.ID, method, u, resp.StatusCode)
+		return nil, fmt.Errorf("the upstream registry responded %d for %s", resp.StatusCode, u)
+	}
+	return resp, nil
+}
+
+// isUpstreamURL returns whether the url belongs to the host of the upstream registry
+func (c *Client) isUpstreamURL(u string) bool {
+	parsed, err := url.Parse(u)
+	return err == nil && parsed.Scheme == c.baseURL.Scheme && strings.EqualFold(parsed.Host, c.baseURL.Host)
+}
+
+func (c *Client) send(ctx context.Context, method, u string, header http.Header, token string, withCredentials bool) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if withCredentials && c.Proxy.Username != "" {
+		req.SetBasicAuth(c.Proxy.Username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		log.Warn("Package proxy [%d]: %s %s failed: %v", c.Proxy.ID, method, u, err)
+		return nil, fmt.Errorf("failed to request the upstream registry: %w", err)
+	}
+	return resp, nil
+}
+
+// fetchBearerToken gets a token from the token server of the challenge,
+// the credentials are only sent to it if the challenge is from the upstream registry,
+// see https://distribution.github.io/distribution/spec/auth/token/
+func (c *Client) fetchBearerToken(ctx context.Context, challenge string, withCredentials bool) (string, error) {
+	params := parseChallengeParams(strings.TrimPrefix(challenge, "Bearer "))
+	if token, ok := c.tokens[params["scope"]]; ok {
+		return token, nil
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || (realm.Scheme != "http" && realm.Scheme != "https") {
+		return "", fmt.Errorf("invalid token realm %q of the upstream registry", params["realm"])
+	}
+	query := realm.Query()
+	for _, name := range []string{"service", "scope"} {
+		if params[name] != "" {
+			query.Set(name, params[name])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	resp, err := c.send(ctx, http.MethodGet, realm.String(), nil, "", withCredentials)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("the token server of the upstream registry responded %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxMetadataSize)).Decode(&result); err != nil {
+		return "", err
+	}
+	token := util.IfZero(result.Token, result.AccessToken)
+	c.tokens[params["scope"]] = token
+	return token, nil
+}
+
+// parseChallengeParams parses the parameters like `realm="https://auth.docker.io/token",service="registry.docker.io"`
+func parseChallengeParams(s string) map[string]string {
+	params := make(map[string]string)
+	for s != "" {
+		name, rest, ok := strings.Cut(s, "=")
+		if !ok {
+			break
+		}
+		name = strings.TrimSpace(name)
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+			rest = strings.TrimPrefix(strings.TrimSpace(rest), ",")
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[name] = value
+		s = strings.TrimSpace(rest)
+	}
+	return params
+}
+
+// GetMetadata returns the content of the metadata document of the upstream registry,
+// it's cached and only requested again after the metadata TTL of the proxy
+func (c *Client) GetMetadata(ctx context.Context, p string, header http.Header) ([]byte, error) {
+	key := fmt.Sprintf("pkg_proxy_%d_%d_%s", c.Proxy.ID, c.Proxy.UpdatedUnix, p)
+	ttl := int64(c.Proxy.MetadataTTLMinutes) * int64(time.Minute/time.Second)
+
+	cc := cache.GetCache()
+	if cc != nil && ttl > 0 {
+		if cached, ok := cc.Get(key); ok {
+			return []byte(cached), nil
+		}
+	}
+
+	resp, err := c.Get(ctx, p, header)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxMetadataSize {
+		return nil, fmt.Errorf("the metadata %s of the upstream registry is too large", p)
+	}
+
+	if cc != nil && ttl > 0 {
+		if err := cc.Put(key, string(data), ttl); err != nil {
+			log.Error("Package proxy [%d]: failed to cache the metadata %s: %v", c.Proxy.ID, p, err)
+		}
+	}
+	return data, nil
+}
+
+// Download fetches the file of the url into a hashed buffer, the caller must close it.
+// packages_service.ErrQuotaTypeSize is returned if the file is larger than the size limit of the package type.
+func (c *Client) Download(ctx context.Context, u string, header http.Header) (*packages_module.HashedBuffer, error) {
+	resp, err := c.Get(ctx, u, header)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	limit := packages_service.GetTypeSizeLimit(c.Proxy.Type)
+	if limit < 0 {
+		return packages_module.CreateHashedBufferFromReader(resp.Body)
+	}
+	if resp.ContentLength > limit {
+		return nil, packages_service.ErrQuotaTypeSize
+	}
+	buf, err := packages_module.CreateHashedBufferFromReader(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if buf.Size() > limit {
+		buf.Close()
+		return nil, packages_service.ErrQuotaTypeSize
+	}
+	return buf, nil
+}
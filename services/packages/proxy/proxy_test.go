@@ -0,0 +1,64 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	packages_service "code.gitea.io/gitea/services/packages"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	defer test.MockVariableValue(&setting.Packages.ProxyAllowedHostList, "loopback")()
+	defer test.MockVariableValue(&setting.Packages.LimitSizeNpm, 4)()
+	defer test.MockVariableValue(&setting.AppDataPath, t.TempDir())()
+
+	newServer := func(authorization *string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*authorization = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(r.URL.Path[1:]))
+		}))
+	}
+	var upstreamAuthorization, otherAuthorization string
+	upstream := newServer(&upstreamAuthorization)
+	defer upstream.Close()
+	other := newServer(&otherAuthorization)
+	defer other.Close()
+
+	pp := &packages_model.PackageProxy{Type: packages_model.TypeNpm, URL: upstream.URL, Username: "user"}
+	require.NoError(t, pp.SetPassword("password"))
+	client, err := NewClient(nil, pp)
+	require.NoError(t, err)
+
+	t.Run("Credentials", func(t *testing.T) {
+		resp, err := client.Get(t.Context(), "file", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.NotEmpty(t, upstreamAuthorization)
+
+		// the urls of the upstream metadata may point to other hosts
+		resp, err = client.Get(t.Context(), other.URL+"/file", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Empty(t, otherAuthorization)
+	})
+
+	t.Run("DownloadSizeLimit", func(t *testing.T) {
+		buf, err := client.Download(t.Context(), "1234", nil)
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, buf.Size())
+		buf.Close()
+
+		_, err = client.Download(t.Context(), "12345", nil)
+		assert.ErrorIs(t, err, packages_service.ErrQuotaTypeSize)
+	})
+}
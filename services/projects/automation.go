@@ -0,0 +1,134 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"xorm.io/builder"
+)
+
+// RunAutomation applies the column automation rules matching the event to the issue.
+// If the issue belongs to a project, it is moved to the column of the first matching rule of that project.
+// Otherwise, for the "opened" events, it is added to the open projects which have a matching rule with AutoAdd enabled.
+// addedLabel is the label which has been added to the issue for the "label_added" event.
+func RunAutomation(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, event project_model.AutomationEvent, addedLabel *issues_model.Label) error {
+	if event == project_model.AutomationEventLabelAdded && addedLabel == nil {
+		return nil
+	}
+
+	if err := issue.LoadProject(ctx); err != nil {
+		return err
+	}
+	if issue.Project != nil {
+		if issue.Project.IsClosed {
+			return nil
+		}
+		rules, err := db.Find[project_model.ColumnAutomation](ctx, project_model.FindColumnAutomationsOptions{
+			ProjectID: issue.Project.ID,
+			Event:     event,
+		})
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			if !ruleMatches(ctx, rule, issue, addedLabel) {
+				continue
+			}
+			return moveIssueToColumn(ctx, doer, issue, issue.Project, rule.ColumnID)
+		}
+		return nil
+	}
+
+	if !event.CanAutoAdd() {
+		return nil
+	}
+	return autoAddIssue(ctx, doer, issue, event)
+}
+
+// ruleMatches evaluates the label filter of the rule: the label which has been added for the "label_added" event,
+// or the label the issue must have for the other events. A rule without a label matches all issues.
+func ruleMatches(ctx context.Context, rule *project_model.ColumnAutomation, issue *issues_model.Issue, addedLabel *issues_model.Label) bool {
+	if rule.LabelID == 0 {
+		return true
+	}
+	if rule.Event == project_model.AutomationEventLabelAdded {
+		return addedLabel != nil && addedLabel.ID == rule.LabelID
+	}
+	return issues_model.HasIssueLabel(ctx, issue.ID, rule.LabelID)
+}
+
+// moveIssueToColumn moves the issue to the end of the column of its project
+func moveIssueToColumn(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, project *project_model.Project, columnID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		column, err := project_model.GetColumn(ctx, columnID)
+		if err != nil {
+			return err
+		}
+		if column.ProjectID != project.ID {
+			return nil
+		}
+
+		currentColumnID, err := issue.ProjectColumnID(ctx)
+		if err != nil {
+			return err
+		}
+		if currentColumnID == column.ID {
+			return nil
+		}
+
+		sorting, err := column.NextIssueSorting(ctx)
+		if err != nil {
+			return err
+		}
+		return MoveIssuesOnProjectColumn(ctx, doer, column, map[int64]int64{sorting: issue.ID})
+	})
+}
+
+func autoAddIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, event project_model.AutomationEvent) error {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	projects := make([]*project_model.Project, 0, 2)
+	if err := db.GetEngine(ctx).
+		Where(builder.Eq{"is_closed": false}).
+		And(builder.Or(
+			builder.Eq{"repo_id": issue.RepoID},
+			builder.Eq{"owner_id": issue.Repo.OwnerID, "repo_id": 0},
+		)).
+		And(builder.In("id", builder.Select("project_id").From("project_column_automation").
+			Where(builder.Eq{"event": event, "auto_add": true}))).
+		OrderBy("id ASC").
+		Find(&projects); err != nil {
+		return err
+	}
+
+	// an issue can only belong to one project, so the first matching project wins
+	for _, project := range projects {
+		if !project.CanBeAccessedByOwnerRepo(issue.Repo.OwnerID, issue.Repo) {
+			continue
+		}
+		rules, err := db.Find[project_model.ColumnAutomation](ctx, project_model.FindColumnAutomationsOptions{
+			ProjectID: project.ID,
+			Event:     event,
+			AutoAdd:   true,
+		})
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			if !ruleMatches(ctx, rule, issue, nil) {
+				continue
+			}
+			return issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, project.ID, rule.ColumnID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAutomation(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	t.Run("MoveOnClose", func(t *testing.T) {
+		require.NoError(t, project_model.CreateColumnAutomation(t.Context(), &project_model.ColumnAutomation{
+			ProjectID: 1,
+			ColumnID:  3,
+			Event:     project_model.AutomationEventIssueClosed,
+		}))
+
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		require.NoError(t, RunAutomation(t.Context(), doer, issue, project_model.AutomationEventIssueClosed, nil))
+
+		pi := unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1})
+		assert.EqualValues(t, 3, pi.ProjectColumnID)
+		assert.EqualValues(t, 1, pi.Sorting) // issue 5 is already in the column
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{
+			Type:      issues_model.CommentTypeProjectColumn,
+			IssueID:   1,
+			ProjectID: 1,
+		})
+
+		// the event doesn't match any rule
+		require.NoError(t, RunAutomation(t.Context(), doer, issue, project_model.AutomationEventIssueReopened, nil))
+		pi = unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1})
+		assert.EqualValues(t, 3, pi.ProjectColumnID)
+	})
+
+	t.Run("LabelAdded", func(t *testing.T) {
+		require.NoError(t, project_model.CreateColumnAutomation(t.Context(), &project_model.ColumnAutomation{
+			ProjectID: 1,
+			ColumnID:  2,
+			Event:     project_model.AutomationEventLabelAdded,
+			LabelID:   2,
+		}))
+
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		label1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 1})
+		label2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 2})
+
+		require.NoError(t, RunAutomation(t.Context(), doer, issue, project_model.AutomationEventLabelAdded, label1))
+		pi := unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1})
+		assert.EqualValues(t, 3, pi.ProjectColumnID)
+
+		require.NoError(t, RunAutomation(t.Context(), doer, issue, project_model.AutomationEventLabelAdded, label2))
+		pi = unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1})
+		assert.EqualValues(t, 2, pi.ProjectColumnID)
+	})
+
+	t.Run("LabelFilter", func(t *testing.T) {
+		require.NoError(t, project_model.CreateColumnAutomation(t.Context(), &project_model.ColumnAutomation{
+			ProjectID: 1,
+			ColumnID:  3,
+			Event:     project_model.AutomationEventIssueReopened,
+			LabelID:   2,
+		}))
+		require.NoError(t, project_model.CreateColumnAutomation(t.Context(), &project_model.ColumnAutomation{
+			ProjectID: 1,
+			ColumnID:  1,
+			Event:     project_model.AutomationEventIssueReopened,
+			LabelID:   1,
+		}))
+
+		// issue 1 only has label 1, so the first rule is skipped
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		require.NoError(t, RunAutomation(t.Context(), doer, issue, project_model.AutomationEventIssueReopened, nil))
+		pi := unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1})
+		assert.EqualValues(t, 1, pi.ProjectColumnID)
+	})
+
+	t.Run("AutoAdd", func(t *testing.T) {
+		require.NoError(t, project_model.CreateColumnAutomation(t.Context(), &project_model.ColumnAutomation{
+			ProjectID: 1,
+			ColumnID:  2,
+			Event:     project_model.AutomationEventPullRequestOpened,
+			LabelID:   1,
+			AutoAdd:   true,
+		}))
+
+		// pull request 11 doesn't have label 1
+		pull := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 11})
+		require.NoError(t, RunAutomation(t.Context(), doer, pull, project_model.AutomationEventPullRequestOpened, nil))
+		unittest.AssertNotExistsBean(t, &project_model.ProjectIssue{IssueID: 11})
+
+		require.NoError(t, issues_model.NewIssueLabel(t.Context(), pull, unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 1}), doer))
+		require.NoError(t, RunAutomation(t.Context(), doer, pull, project_model.AutomationEventPullRequestOpened, nil))
+		pi := unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 11})
+		assert.EqualValues(t, 1, pi.ProjectID)
+		assert.EqualValues(t, 2, pi.ProjectColumnID)
+	})
+
+	t.Run("InvalidRule", func(t *testing.T) {
+		assert.Error(t, project_model.CreateColumnAutomation(t.Context(), &project_model.ColumnAutomation{
+			ProjectID: 1,
+			ColumnID:  2,
+			Event:     "unknown",
+		}))
+		assert.Error(t, project_model.CreateColumnAutomation(t.Context(), &project_model.ColumnAutomation{
+			ProjectID: 1,
+			ColumnID:  2,
+			Event:     project_model.AutomationEventLabelAdded,
+		}))
+		assert.Error(t, project_model.CreateColumnAutomation(t.Context(), &project_model.ColumnAutomation{
+			ProjectID: 1,
+			ColumnID:  2,
+			Event:     project_model.AutomationEventIssueClosed,
+			AutoAdd:   true,
+		}))
+		// column 4 belongs to project 4
+		assert.Error(t, project_model.CreateColumnAutomation(t.Context(), &project_model.ColumnAutomation{
+			ProjectID: 1,
+			ColumnID:  4,
+			Event:     project_model.AutomationEventIssueClosed,
+		}))
+	})
+}
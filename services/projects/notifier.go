@@ -0,0 +1,98 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+func init() {
+	notify_service.RegisterNotifier(&automationNotifier{})
+}
+
+type automationNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &automationNotifier{}
+
+func runAutomation(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, event project_model.AutomationEvent, addedLabel *issues_model.Label) {
+	if err := RunAutomation(ctx, doer, issue, event, addedLabel); err != nil {
+		log.Error("RunAutomation[%s] for issue %d: %v", event, issue.ID, err)
+	}
+}
+
+func (n *automationNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, _ []*user_model.User) {
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	runAutomation(ctx, issue.Poster, issue, project_model.AutomationEventIssueOpened, nil)
+}
+
+func (n *automationNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, _ string, issue *issues_model.Issue, _ *issues_model.Comment, isClosed bool) {
+	var event project_model.AutomationEvent
+	switch {
+	case issue.IsPull && isClosed:
+		event = project_model.AutomationEventPullRequestClosed
+	case issue.IsPull:
+		event = project_model.AutomationEventPullRequestReopened
+	case isClosed:
+		event = project_model.AutomationEventIssueClosed
+	default:
+		event = project_model.AutomationEventIssueReopened
+	}
+	runAutomation(ctx, doer, issue, event, nil)
+}
+
+func (n *automationNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, addedLabels, _ []*issues_model.Label) {
+	for _, label := range addedLabels {
+		runAutomation(ctx, doer, issue, project_model.AutomationEventLabelAdded, label)
+	}
+}
+
+func (n *automationNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, _ []*user_model.User) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	if err := pr.Issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	runAutomation(ctx, pr.Issue.Poster, pr.Issue, project_model.AutomationEventPullRequestOpened, nil)
+}
+
+func (n *automationNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	runAutomation(ctx, doer, pr.Issue, project_model.AutomationEventPullRequestMerged, nil)
+}
+
+func (n *automationNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	n.MergePullRequest(ctx, doer, pr)
+}
+
+func (n *automationNotifier) PullRequestReview(ctx context.Context, pr *issues_model.PullRequest, review *issues_model.Review, _ *issues_model.Comment, _ []*user_model.User) {
+	if review.Type != issues_model.ReviewTypeApprove {
+		return
+	}
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	if err := review.LoadReviewer(ctx); err != nil {
+		log.Error("LoadReviewer: %v", err)
+		return
+	}
+	runAutomation(ctx, review.Reviewer, pr.Issue, project_model.AutomationEventPullRequestApproved, nil)
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"math"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+)
+
+// RoadmapOptions selects the date or iteration fields providing the dates of the roadmap bars.
+// Without end field the bars end at the due date of the issues or of their milestones,
+// a bar without start date only covers its end date.
+type RoadmapOptions struct {
+	StartFieldID int64
+	EndFieldID   int64
+}
+
+// RoadmapBar is a period of the roadmap, its position on the timeline is in percent of the timeline width
+type RoadmapBar struct {
+	Start  time.Time
+	End    time.Time
+	Offset float64
+	Width  float64
+}
+
+// Roadmap is the timeline of the roadmap view, it covers the whole months of all bars and today
+type Roadmap struct {
+	Start       time.Time
+	End         time.Time
+	Months      []*RoadmapBar
+	Bars        map[int64]*RoadmapBar // by issue id, the issues without dates have no bar
+	TodayOffset float64
+}
+
+// BuildRoadmap places the rows with dates on a timeline
+func BuildRoadmap(rows []*TableRow, fields []*project_model.Field, opts RoadmapOptions, now time.Time) *Roadmap {
+	startField := findDateField(fields, opts.StartFieldID)
+	endField := findDateField(fields, opts.EndFieldID)
+
+	today := dateOf(now)
+	roadmap := &Roadmap{Start: today, End: today, Bars: make(map[int64]*RoadmapBar)}
+	for _, row := range rows {
+		start, end, ok := rowDates(row, startField, endField)
+		if !ok {
+			continue
+		}
+		roadmap.Bars[row.Issue.ID] = &RoadmapBar{Start: start, End: end}
+		if start.Before(roadmap.Start) {
+			roadmap.Start = start
+		}
+		if end.After(roadmap.End) {
+			roadmap.End = end
+		}
+	}
+	roadmap.Start = time.Date(roadmap.Start.Year(), roadmap.Start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	roadmap.End = time.Date(roadmap.End.Year(), roadmap.End.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+
+	for month := roadmap.Start; month.Before(roadmap.End); month = month.AddDate(0, 1, 0) {
+		bar := &RoadmapBar{Start: month, End: month.AddDate(0, 1, -1)}
+		roadmap.place(bar)
+		roadmap.Months = append(roadmap.Months, bar)
+	}
+	for _, bar := range roadmap.Bars {
+		roadmap.place(bar)
+	}
+	roadmap.TodayOffset = roadmap.percent(days(roadmap.Start, today))
+	return roadmap
+}
+
+func (r *Roadmap) place(bar *RoadmapBar) {
+	bar.Offset = r.percent(days(r.Start, bar.Start))
+	bar.Width = r.percent(days(bar.Start, bar.End) + 1)
+}
+
+func (r *Roadmap) percent(numDays int) float64 {
+	total := days(r.Start, r.End) + 1
+	return math.Round(float64(numDays)*10000/float64(total)) / 100
+}
+
+func days(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func findDateField(fields []*project_model.Field, id int64) *project_model.Field {
+	f := findField(fields, id)
+	if f == nil || (f.Type != project_model.FieldTypeDate && f.Type != project_model.FieldTypeIteration) {
+		return nil
+	}
+	return f
+}
+
+// fieldDate returns the date of a date field value, or the first or the last day of an iteration
+func fieldDate(f *project_model.Field, value string, end bool) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if f.Type == project_model.FieldTypeIteration {
+		it := f.GetIteration(parseInt64(value))
+		if it == nil {
+			return time.Time{}, false
+		}
+		value = it.StartDate
+		if end {
+			value = it.EndDate()
+		}
+	}
+	t, err := time.Parse(project_model.FieldDateLayout, value)
+	return t, err == nil
+}
+
+// issueDueDate returns the deadline of the issue or of its milestone
+func issueDueDate(issue *issues_model.Issue) (time.Time, bool) {
+	deadline := issue.DeadlineUnix
+	if deadline == 0 && issue.Milestone != nil {
+		deadline = issue.Milestone.DeadlineUnix
+	}
+	// milestones without due date have a deadline in the year 9999
+	if deadline == 0 || deadline.Year() >= 9999 {
+		return time.Time{}, false
+	}
+	return dateOf(deadline.AsLocalTime()), true
+}
+
+func rowDates(row *TableRow, startField, endField *project_model.Field) (start, end time.Time, ok bool) {
+	var hasStart, hasEnd bool
+	if startField != nil {
+		start, hasStart = fieldDate(startField, row.FieldValues[startField.ID], false)
+	}
+	if endField != nil {
+		end, hasEnd = fieldDate(endField, row.FieldValues[endField.ID], true)
+	} else {
+		end, hasEnd = issueDueDate(row.Issue)
+	}
+
+	switch {
+	case hasStart && hasEnd:
+		if end.Before(start) {
+			start = end
+		}
+	case hasEnd:
+		start = end
+	case hasStart:
+		end = start
+	default:
+		return start, end, false
+	}
+	return start, end, true
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRoadmap(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+	start := &project_model.Field{ID: 1, Type: project_model.FieldTypeDate}
+	end := &project_model.Field{ID: 2, Type: project_model.FieldTypeDate}
+	sprint := &project_model.Field{ID: 3, Type: project_model.FieldTypeIteration, Iterations: []*project_model.FieldIteration{
+		{ID: 1, StartDate: "2025-02-03", Duration: 14},
+	}}
+	fields := []*project_model.Field{start, end, sprint}
+	rows := []*TableRow{
+		{Issue: &issues_model.Issue{ID: 1}, FieldValues: map[int64]string{1: "2025-01-10", 2: "2025-01-19", 3: "1"}},
+		{Issue: &issues_model.Issue{ID: 2}, FieldValues: map[int64]string{2: "2025-03-31"}},
+		{Issue: &issues_model.Issue{ID: 3}, FieldValues: map[int64]string{1: "2025-02-01"}},
+		{Issue: &issues_model.Issue{ID: 4}},
+	}
+	now := date("2025-02-15")
+
+	roadmap := BuildRoadmap(rows, fields, RoadmapOptions{StartFieldID: 1, EndFieldID: 2}, now)
+	assert.Equal(t, date("2025-01-01"), roadmap.Start)
+	assert.Equal(t, date("2025-03-31"), roadmap.End)
+	assert.Len(t, roadmap.Months, 3)
+	assert.Equal(t, &RoadmapBar{Start: date("2025-02-01"), End: date("2025-02-28"), Offset: 34.44, Width: 31.11}, roadmap.Months[1])
+	assert.Equal(t, 50.0, roadmap.TodayOffset)
+
+	assert.Equal(t, &RoadmapBar{Start: date("2025-01-10"), End: date("2025-01-19"), Offset: 10, Width: 11.11}, roadmap.Bars[1])
+	// a bar without start date is a single day at its end date
+	assert.Equal(t, date("2025-03-31"), roadmap.Bars[2].Start)
+	assert.Equal(t, 98.89, roadmap.Bars[2].Offset)
+	// a bar without end date is a single day at its start date
+	assert.Equal(t, date("2025-02-01"), roadmap.Bars[3].End)
+	assert.NotContains(t, roadmap.Bars, int64(4))
+
+	roadmap = BuildRoadmap(rows, fields, RoadmapOptions{StartFieldID: 3, EndFieldID: 3}, now)
+	assert.Len(t, roadmap.Bars, 1)
+	assert.Equal(t, date("2025-02-03"), roadmap.Bars[1].Start)
+	assert.Equal(t, date("2025-02-16"), roadmap.Bars[1].End)
+
+	// by default the bars are the due dates of the issues or of their milestones
+	rows = []*TableRow{
+		{Issue: &issues_model.Issue{ID: 1, DeadlineUnix: timeutil.TimeStamp(date("2025-01-20").Unix())}},
+		{Issue: &issues_model.Issue{ID: 2, Milestone: &issues_model.Milestone{DeadlineUnix: timeutil.TimeStamp(date("2025-04-30").Unix())}}},
+		{Issue: &issues_model.Issue{ID: 3, Milestone: &issues_model.Milestone{DeadlineUnix: 253370764800}}},
+	}
+	roadmap = BuildRoadmap(rows, fields, RoadmapOptions{}, now)
+	assert.Len(t, roadmap.Bars, 2)
+	assert.Equal(t, date("2025-01-20"), roadmap.Bars[1].Start)
+	assert.Equal(t, date("2025-01-20"), roadmap.Bars[1].End)
+	assert.Equal(t, date("2025-01-01"), roadmap.Start)
+	assert.Equal(t, date("2025-04-30"), roadmap.End)
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	user_model "code.gitea.io/gitea/models/user"
+)
+
+// TableRow is an issue of a project with its column and custom field values
+type TableRow struct {
+	Issue       *issues_model.Issue
+	Column      *project_model.Column
+	FieldValues map[int64]string
+}
+
+// TableOptions are the filters and the sorting of the table and roadmap views of a project
+type TableOptions struct {
+	// FieldFilters maps field ids to the stored values the issues must have
+	FieldFilters map[int64]string
+	AssigneeID   int64
+	LabelID      int64
+	MilestoneID  int64
+	SortFieldID  int64
+	SortDesc     bool
+}
+
+// LoadProjectTableRows returns the issues of the project with their assignees, labels, milestones and pull requests as table rows ordered by column
+func LoadProjectTableRows(ctx context.Context, project *project_model.Project, columns project_model.ColumnList, issueOpts *issues_model.IssuesOptions) ([]*TableRow, error) {
+	issuesMap, err := LoadIssuesFromProject(ctx, project, issueOpts)
+	if err != nil {
+		return nil, err
+	}
+	values, err := project_model.GetFieldValuesMap(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues issues_model.IssueList
+	rows := make([]*TableRow, 0, len(issuesMap))
+	for _, column := range columns {
+		for _, issue := range issuesMap[column.ID] {
+			rows = append(rows, &TableRow{Issue: issue, Column: column, FieldValues: values[issue.ID]})
+			issues = append(issues, issue)
+		}
+	}
+
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return nil, err
+	}
+	if err := issues.LoadAssignees(ctx); err != nil {
+		return nil, err
+	}
+	if err := issues.LoadLabels(ctx); err != nil {
+		return nil, err
+	}
+	if err := issues.LoadMilestones(ctx); err != nil {
+		return nil, err
+	}
+	if err := issues.LoadPullRequests(ctx); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FilterTableRows returns the rows matching the filters of the options, sorted by the custom field of the options
+func FilterTableRows(rows []*TableRow, fields []*project_model.Field, opts TableOptions) []*TableRow {
+	filtered := make([]*TableRow, 0, len(rows))
+	for _, row := range rows {
+		if row.matches(opts) {
+			filtered = append(filtered, row)
+		}
+	}
+
+	sortField := findField(fields, opts.SortFieldID)
+	if sortField != nil {
+		slices.SortStableFunc(filtered, func(a, b *TableRow) int {
+			va, vb := a.FieldValues[sortField.ID], b.FieldValues[sortField.ID]
+			if opts.SortDesc && va != "" && vb != "" {
+				return sortField.CompareValues(vb, va)
+			}
+			return sortField.CompareValues(va, vb)
+		})
+	}
+	return filtered
+}
+
+func (row *TableRow) matches(opts TableOptions) bool {
+	for fieldID, value := range opts.FieldFilters {
+		if row.FieldValues[fieldID] != value {
+			return false
+		}
+	}
+	if opts.AssigneeID > 0 && !slices.ContainsFunc(row.Issue.Assignees, func(u *user_model.User) bool { return u.ID == opts.AssigneeID }) {
+		return false
+	}
+	if opts.LabelID > 0 && !slices.ContainsFunc(row.Issue.Labels, func(l *issues_model.Label) bool { return l.ID == opts.LabelID }) {
+		return false
+	}
+	if opts.MilestoneID > 0 && row.Issue.MilestoneID != opts.MilestoneID {
+		return false
+	}
+	return true
+}
+
+func findField(fields []*project_model.Field, id int64) *project_model.Field {
+	for _, f := range fields {
+		if f.ID == id {
+			return f
+		}
+	}
+	return nil
+}
+
+// TableChoices are the assignees, labels and milestones of the issues of a project, they can be used as filters
+type TableChoices struct {
+	Assignees  []*user_model.User
+	Labels     []*issues_model.Label
+	Milestones []*issues_model.Milestone
+}
+
+// CollectTableChoices returns the distinct assignees, labels and milestones of the rows sorted by name
+func CollectTableChoices(rows []*TableRow) *TableChoices {
+	choices := &TableChoices{}
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		for _, u := range row.Issue.Assignees {
+			if key := "a" + strconv.FormatInt(u.ID, 10); !seen[key] {
+				seen[key] = true
+				choices.Assignees = append(choices.Assignees, u)
+			}
+		}
+		for _, l := range row.Issue.Labels {
+			if key := "l" + strconv.FormatInt(l.ID, 10); !seen[key] {
+				seen[key] = true
+				choices.Labels = append(choices.Labels, l)
+			}
+		}
+		if m := row.Issue.Milestone; m != nil {
+			if key := "m" + strconv.FormatInt(m.ID, 10); !seen[key] {
+				seen[key] = true
+				choices.Milestones = append(choices.Milestones, m)
+			}
+		}
+	}
+	slices.SortFunc(choices.Assignees, func(a, b *user_model.User) int {
+		return strings.Compare(strings.ToLower(a.GetDisplayName()), strings.ToLower(b.GetDisplayName()))
+	})
+	slices.SortFunc(choices.Labels, func(a, b *issues_model.Label) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	slices.SortFunc(choices.Milestones, func(a, b *issues_model.Milestone) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return choices
+}
+
+// The built-in groupings of the table and roadmap views, the rows can also be grouped by a custom field with "field_{id}"
+const (
+	GroupByColumn    = "column"
+	GroupByAssignee  = "assignee"
+	GroupByLabel     = "label"
+	GroupByMilestone = "milestone"
+)
+
+// TableGroup is a group of rows of the table or roadmap view, the rows without value for the grouping are in a group without title
+type TableGroup struct {
+	Title string
+	Color string
+	Rows  []*TableRow
+
+	key     string
+	sorting int64
+}
+
+// GroupTableRows groups the rows by column, assignee, label, milestone or custom field.
+// An issue with several assignees or labels is in the group of each of them.
+// The rows keep their order in the groups, the group without title comes last.
+// Without valid grouping all rows are returned in a single group without title.
+func GroupTableRows(rows []*TableRow, fields []*project_model.Field, groupBy string) []*TableGroup {
+	var field *project_model.Field
+	if fieldID, ok := strings.CutPrefix(groupBy, "field_"); ok {
+		id, _ := strconv.ParseInt(fieldID, 10, 64)
+		if field = findField(fields, id); field == nil {
+			groupBy = ""
+		}
+	}
+
+	var groups []*TableGroup
+	none := &TableGroup{}
+	byKey := make(map[string]*TableGroup)
+	add := func(row *TableRow, key, title, color string, sorting int64) {
+		g, ok := byKey[key]
+		if !ok {
+			g = &TableGroup{Title: title, Color: color, key: key, sorting: sorting}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.Rows = append(g.Rows, row)
+	}
+
+	for _, row := range rows {
+		switch {
+		case groupBy == GroupByColumn:
+			add(row, strconv.FormatInt(row.Column.ID, 10), row.Column.Title, row.Column.Color, int64(row.Column.Sorting))
+		case groupBy == GroupByAssignee && len(row.Issue.Assignees) > 0:
+			for _, u := range row.Issue.Assignees {
+				add(row, strconv.FormatInt(u.ID, 10), u.GetDisplayName(), "", 0)
+			}
+		case groupBy == GroupByLabel && len(row.Issue.Labels) > 0:
+			for _, l := range row.Issue.Labels {
+				add(row, strconv.FormatInt(l.ID, 10), l.Name, l.Color, 0)
+			}
+		case groupBy == GroupByMilestone && row.Issue.Milestone != nil:
+			add(row, strconv.FormatInt(row.Issue.Milestone.ID, 10), row.Issue.Milestone.Name, "", 0)
+		case field != nil && row.FieldValues[field.ID] != "":
+			value := row.FieldValues[field.ID]
+			var color string
+			if field.Type == project_model.FieldTypeSingleSelect {
+				if opt := field.GetOption(parseInt64(value)); opt != nil {
+					color = opt.Color
+				}
+			}
+			add(row, value, field.DisplayValue(value), color, 0)
+		default:
+			none.Rows = append(none.Rows, row)
+		}
+	}
+
+	slices.SortStableFunc(groups, func(a, b *TableGroup) int {
+		switch {
+		case groupBy == GroupByColumn:
+			return cmp.Or(cmp.Compare(a.sorting, b.sorting), cmp.Compare(parseInt64(a.key), parseInt64(b.key)))
+		case field != nil:
+			return field.CompareValues(a.key, b.key)
+		}
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	})
+	if len(none.Rows) > 0 || len(groups) == 0 {
+		groups = append(groups, none)
+	}
+	return groups
+}
+
+func parseInt64(s string) int64 {
+	v, _ := strconv.ParseInt(s, 10, 64)
+	return v
+}
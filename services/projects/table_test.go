@@ -0,0 +1,97 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"fmt"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProjectTableRows(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	project := unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 1})
+	estimate := &project_model.Field{ProjectID: project.ID, Name: "Estimate", Type: project_model.FieldTypeNumber}
+	require.NoError(t, project_model.CreateField(t.Context(), estimate))
+	require.NoError(t, project_model.SetFieldValue(t.Context(), estimate, 1, "8"))
+	require.NoError(t, project_model.SetFieldValue(t.Context(), estimate, 3, "2"))
+	require.NoError(t, project_model.SetFieldValue(t.Context(), estimate, 5, "13"))
+
+	columns, err := project.GetColumns(t.Context())
+	require.NoError(t, err)
+	fields := []*project_model.Field{estimate}
+	issueIDs := func(rows []*TableRow) (ids []int64) {
+		for _, row := range rows {
+			ids = append(ids, row.Issue.ID)
+		}
+		return ids
+	}
+
+	rows, err := LoadProjectTableRows(t.Context(), project, columns, &issues_model.IssuesOptions{RepoIDs: []int64{1}})
+	require.NoError(t, err)
+	// ordered by column: issue 1 and 2 (no column) are in the default column "To Do"
+	assert.ElementsMatch(t, []int64{1, 2}, issueIDs(rows[:2]))
+	assert.Equal(t, []int64{3, 5}, issueIDs(rows[2:]))
+	assert.Equal(t, "In Progress", rows[2].Column.Title)
+
+	assert.Equal(t, []int64{3, 1, 5, 2}, issueIDs(FilterTableRows(rows, fields, TableOptions{SortFieldID: estimate.ID})))
+	assert.Equal(t, []int64{5, 1, 3, 2}, issueIDs(FilterTableRows(rows, fields, TableOptions{SortFieldID: estimate.ID, SortDesc: true})))
+	assert.Equal(t, []int64{1}, issueIDs(FilterTableRows(rows, fields, TableOptions{FieldFilters: map[int64]string{estimate.ID: "8"}})))
+	assert.Equal(t, []int64{1}, issueIDs(FilterTableRows(rows, fields, TableOptions{AssigneeID: 1})))
+	assert.Equal(t, []int64{5}, issueIDs(FilterTableRows(rows, fields, TableOptions{LabelID: 2})))
+	assert.Equal(t, []int64{3}, issueIDs(FilterTableRows(rows, fields, TableOptions{MilestoneID: 3})))
+
+	choices := CollectTableChoices(rows)
+	assert.Len(t, choices.Assignees, 1)
+	assert.Len(t, choices.Labels, 3)
+	assert.Len(t, choices.Milestones, 2)
+}
+
+func TestGroupTableRows(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	project := unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 1})
+	priority := &project_model.Field{
+		ProjectID: project.ID,
+		Name:      "Priority",
+		Type:      project_model.FieldTypeSingleSelect,
+		Options:   []*project_model.FieldOption{{Name: "High"}, {Name: "Low"}},
+	}
+	require.NoError(t, project_model.CreateField(t.Context(), priority))
+	require.NoError(t, project_model.SetFieldValue(t.Context(), priority, 3, "2"))
+	require.NoError(t, project_model.SetFieldValue(t.Context(), priority, 5, "1"))
+
+	columns, err := project.GetColumns(t.Context())
+	require.NoError(t, err)
+	rows, err := LoadProjectTableRows(t.Context(), project, columns, &issues_model.IssuesOptions{RepoIDs: []int64{1}})
+	require.NoError(t, err)
+	fields := []*project_model.Field{priority}
+
+	type group struct {
+		Title string
+		Count int
+	}
+	groupsOf := func(groupBy string) (groups []group) {
+		for _, g := range GroupTableRows(rows, fields, groupBy) {
+			groups = append(groups, group{g.Title, len(g.Rows)})
+		}
+		return groups
+	}
+
+	assert.Equal(t, []group{{"", 4}}, groupsOf(""))
+	assert.Equal(t, []group{{"", 4}}, groupsOf("field_0"))
+	assert.Equal(t, []group{{"To Do", 2}, {"In Progress", 1}, {"Done", 1}}, groupsOf(GroupByColumn))
+	assert.Equal(t, []group{{"user1", 1}, {"", 3}}, groupsOf(GroupByAssignee))
+	assert.Equal(t, []group{{"label1", 2}, {"label2", 1}, {"orglabel4", 1}, {"", 1}}, groupsOf(GroupByLabel))
+	assert.Equal(t, []group{{"milestone1", 1}, {"milestone3", 1}, {"", 2}}, groupsOf(GroupByMilestone))
+	// the groups of a single select field are in the order of the options
+	assert.Equal(t, []group{{"High", 1}, {"Low", 1}, {"", 2}}, groupsOf(fmt.Sprintf("field_%d", priority.ID)))
+}
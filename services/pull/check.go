@@ -31,6 +31,7 @@ import (
 	"code.gitea.io/gitea/modules/timeutil"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	"code.gitea.io/gitea/services/automergequeue"
+	issue_service "code.gitea.io/gitea/services/issue"
 	notify_service "code.gitea.io/gitea/services/notify"
 )
 
@@ -46,6 +47,7 @@ var (
 	ErrIsChecking          = errors.New("cannot merge while conflict checking is in progress")
 	ErrNotMergeableState   = errors.New("not in mergeable state")
 	ErrDependenciesLeft    = errors.New("is blocked by an open dependency")
+	ErrMergeQueueRequired  = errors.New("must be merged through the merge queue")
 )
 
 func markPullRequestStatusAsChecking(ctx context.Context, pr *issues_model.PullRequest) bool {
@@ -243,6 +245,50 @@ func isSignedIfRequired(ctx context.Context, pr *issues_model.PullRequest, doer
 	return sign, err
 }
 
+// GetMissingCodeOwnerApprovals returns the groups of code owners of the changed files which haven't approved the pull request yet.
+// It's empty if the protected branch doesn't require approvals from code owners.
+func GetMissingCodeOwnerApprovals(ctx context.Context, pb *git_model.ProtectedBranch, pr *issues_model.PullRequest) ([]*issues_model.CodeOwnerGroup, error) {
+	if pb == nil || !pb.RequireCodeOwnerApprovals {
+		return nil, nil
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, pr.BaseRepo)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	// use the CODEOWNERS of the base branch, so a pull request can't change its own code owners
+	commit, err := gitRepo.GetBranchCommit(pr.BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+	rules := issue_service.GetCodeOwnersFromCommit(ctx, commit)
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	mergeBase := pr.MergeBase
+	if mergeBase == "" {
+		if mergeBase, _, err = gitRepo.GetMergeBase("", git.BranchPrefix+pr.BaseBranch, pr.GetGitHeadRefName()); err != nil {
+			return nil, err
+		}
+	}
+	changedFiles, err := gitRepo.GetFilesChangedBetween(mergeBase, pr.GetGitHeadRefName())
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := issues_model.GroupFilesByCodeOwners(ctx, rules, changedFiles)
+	if err != nil {
+		return nil, err
+	}
+	return issues_model.GetCodeOwnerGroupsWithoutApproval(ctx, pb, pr, groups)
+}
+
 // markPullRequestAsMergeable checks if pull request is possible to leaving checking status,
 // and set to be either conflict or mergeable.
 func markPullRequestAsMergeable(ctx context.Context, pr *issues_model.PullRequest) {
@@ -6,14 +6,17 @@ package pull
 
 import (
 	"context"
+	"slices"
 
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/commitstatus"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/glob"
 	"code.gitea.io/gitea/modules/log"
+	actions_service "code.gitea.io/gitea/services/actions"
 
 	"github.com/pkg/errors"
 )
@@ -65,13 +68,47 @@ func MergeRequiredContextsCommitStatus(commitStatuses []*git_model.CommitStatus,
 	return commitstatus.CommitStatusPending
 }
 
+// GetRequiredStatusChecks returns whether the status checks of the head commit of a pull request are required by the protected branch rule
+// and the required contexts, the jobs of the required workflows of the organization which run on the head commit are always required,
+// even if the branch isn't protected. It returns no contexts if all the status checks are required.
+func GetRequiredStatusChecks(ctx context.Context, repo *repo_model.Repository, pb *git_model.ProtectedBranch, headCommitID string) (bool, []string, error) {
+	contexts, err := actions_service.GetRequiredWorkflowStatusCheckContexts(ctx, repo, headCommitID)
+	if err != nil {
+		return false, nil, err
+	}
+	if pb == nil {
+		return len(contexts) > 0, contexts, nil
+	}
+	if len(contexts) == 0 {
+		return pb.EnableStatusCheck, pb.StatusCheckContexts, nil
+	}
+	if pb.EnableStatusCheck {
+		if len(pb.StatusCheckContexts) == 0 {
+			// all the status checks are required, and the ones of the required workflows have to be present
+			contexts = append(contexts, "*")
+		} else {
+			contexts = append(slices.Clone(pb.StatusCheckContexts), contexts...)
+		}
+	}
+	return true, contexts, nil
+}
+
 // IsPullCommitStatusPass returns if all required status checks PASS
 func IsPullCommitStatusPass(ctx context.Context, pr *issues_model.PullRequest) (bool, error) {
 	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
 	if err != nil {
 		return false, errors.Wrap(err, "GetLatestCommitStatus")
 	}
-	if pb == nil || !pb.EnableStatusCheck {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return false, errors.Wrap(err, "LoadBaseRepo")
+	}
+	sha, err := getPullHeadCommitID(ctx, pr)
+	if err != nil {
+		return false, err
+	}
+	if enabled, _, err := GetRequiredStatusChecks(ctx, pr.BaseRepo, pb, sha); err != nil {
+		return false, errors.Wrap(err, "GetRequiredStatusChecks")
+	} else if !enabled {
 		return true, nil
 	}
 
@@ -82,8 +119,8 @@ func IsPullCommitStatusPass(ctx context.Context, pr *issues_model.PullRequest) (
 	return state.IsSuccess(), nil
 }
 
-// GetPullRequestCommitStatusState returns pull request merged commit status state
-func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullRequest) (commitstatus.CommitStatusState, error) {
+// getPullHeadCommitID returns the commit of the head branch of the pull request
+func getPullHeadCommitID(ctx context.Context, pr *issues_model.PullRequest) (string, error) {
 	// Ensure HeadRepo is loaded
 	if err := pr.LoadHeadRepo(ctx); err != nil {
 		return "", errors.Wrap(err, "LoadHeadRepo")
@@ -107,12 +144,15 @@ func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullR
 		return "", errors.New("Head branch does not exist, can not merge")
 	}
 
-	var sha string
 	if pr.Flow == issues_model.PullRequestFlowGithub {
-		sha, err = headGitRepo.GetBranchCommitID(pr.HeadBranch)
-	} else {
-		sha, err = headGitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+		return headGitRepo.GetBranchCommitID(pr.HeadBranch)
 	}
+	return headGitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+}
+
+// GetPullRequestCommitStatusState returns pull request merged commit status state
+func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullRequest) (commitstatus.CommitStatusState, error) {
+	sha, err := getPullHeadCommitID(ctx, pr)
 	if err != nil {
 		return "", err
 	}
@@ -130,9 +170,9 @@ func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullR
 	if err != nil {
 		return "", errors.Wrap(err, "LoadProtectedBranch")
 	}
-	var requiredContexts []string
-	if pb != nil {
-		requiredContexts = pb.StatusCheckContexts
+	_, requiredContexts, err := GetRequiredStatusChecks(ctx, pr.BaseRepo, pb, sha)
+	if err != nil {
+		return "", errors.Wrap(err, "GetRequiredStatusChecks")
 	}
 
 	return MergeRequiredContextsCommitStatus(commitStatuses, requiredContexts), nil
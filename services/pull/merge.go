@@ -241,6 +241,13 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 		return ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: mergeStyle}
 	}
 
+	// The status checks are verified by the merge queue, so the pull request must not be merged directly
+	if required, err := IsMergeQueueRequired(ctx, pr); err != nil {
+		return err
+	} else if required {
+		return ErrMergeQueueRequired
+	}
+
 	releaser, err := globallock.Lock(ctx, getPullWorkingLockKey(pr.ID))
 	if err != nil {
 		log.Error("lock.Lock(): %v", err)
@@ -270,6 +277,11 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 		return err
 	}
 
+	return afterMergePushed(ctx, pr, doer, wasAutoMerged)
+}
+
+// afterMergePushed notifies about a pull request which has been pushed to its base branch and closes referenced issues
+func afterMergePushed(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, wasAutoMerged bool) (err error) {
 	// reload pull request because it has been updated by post receive hook
 	pr, err = issues_model.GetPullRequestByID(ctx, pr.ID)
 	if err != nil {
@@ -296,6 +308,10 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 	// Reset cached commit count
 	cache.Remove(pr.Issue.Repo.GetCommitsCountCacheKey(pr.BaseBranch, true))
 
+	if err := retargetStackedPulls(ctx, doer, pr); err != nil {
+		log.Error("retargetStackedPulls %-v: %v", pr, err)
+	}
+
 	return handleCloseCrossReferences(ctx, pr, doer)
 }
 
@@ -340,25 +356,8 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 	defer cancel()
 
 	// Merge commits.
-	switch mergeStyle {
-	case repo_model.MergeStyleMerge:
-		if err := doMergeStyleMerge(mergeCtx, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
-		if err := doMergeStyleRebase(mergeCtx, mergeStyle, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleSquash:
-		if err := doMergeStyleSquash(mergeCtx, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleFastForwardOnly:
-		if err := doMergeStyleFastForwardOnly(mergeCtx); err != nil {
-			return "", err
-		}
-	default:
-		return "", ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: mergeStyle}
+	if err := doMergeStyle(mergeCtx, mergeStyle, message); err != nil {
+		return "", err
 	}
 
 	// OK we should cache our current head and origin/headbranch
@@ -436,6 +435,22 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 	return mergeCommitID, nil
 }
 
+// doMergeStyle merges the tracking branch into the base branch of the temporary repository with the given merge style
+func doMergeStyle(mergeCtx *mergeContext, mergeStyle repo_model.MergeStyle, message string) error {
+	switch mergeStyle {
+	case repo_model.MergeStyleMerge:
+		return doMergeStyleMerge(mergeCtx, message)
+	case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
+		return doMergeStyleRebase(mergeCtx, mergeStyle, message)
+	case repo_model.MergeStyleSquash:
+		return doMergeStyleSquash(mergeCtx, message)
+	case repo_model.MergeStyleFastForwardOnly:
+		return doMergeStyleFastForwardOnly(mergeCtx)
+	default:
+		return ErrInvalidMergeStyle{ID: mergeCtx.pr.BaseRepo.ID, Style: mergeStyle}
+	}
+}
+
 func commitAndSignNoAuthor(ctx *mergeContext, message string) error {
 	cmdCommit := gitcmd.NewCommand("commit").AddOptionFormat("--message=%s", message)
 	if ctx.signKey == nil {
@@ -578,20 +593,37 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 		return fmt.Errorf("LoadProtectedBranch: %v", err)
 	}
 	if pb == nil {
+		// the required workflows of the organization have to pass even if the branch isn't protected
+		isPass, err := IsPullCommitStatusPass(ctx, pr)
+		if err != nil {
+			return err
+		}
+		if !isPass {
+			return util.ErrorWrap(ErrNotReadyToMerge, "Not all required status checks successful")
+		}
 		return nil
 	}
 
-	isPass, err := IsPullCommitStatusPass(ctx, pr)
-	if err != nil {
-		return err
-	}
-	if !isPass {
-		return util.ErrorWrap(ErrNotReadyToMerge, "Not all required status checks successful")
+	// With a merge queue the required status checks are verified against the merge group commit
+	// by the queue itself before the base branch gets updated.
+	if !pb.IsMergeQueueEnabled() {
+		isPass, err := IsPullCommitStatusPass(ctx, pr)
+		if err != nil {
+			return err
+		}
+		if !isPass {
+			return util.ErrorWrap(ErrNotReadyToMerge, "Not all required status checks successful")
+		}
 	}
 
 	if !issues_model.HasEnoughApprovals(ctx, pb, pr) {
 		return util.ErrorWrap(ErrNotReadyToMerge, "Does not have enough approvals")
 	}
+	if missing, err := GetMissingCodeOwnerApprovals(ctx, pb, pr); err != nil {
+		return fmt.Errorf("GetMissingCodeOwnerApprovals: %w", err)
+	} else if len(missing) > 0 {
+		return util.ErrorWrap(ErrNotReadyToMerge, "Does not have approvals from all code owners")
+	}
 	if issues_model.MergeBlockedByRejectedReview(ctx, pb, pr) {
 		return util.ErrorWrap(ErrNotReadyToMerge, "There are requested changes")
 	}
@@ -599,7 +631,8 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 		return util.ErrorWrap(ErrNotReadyToMerge, "There are official review requests")
 	}
 
-	if issues_model.MergeBlockedByOutdatedBranch(pb, pr) {
+	// The merge group commit of a merge queue is always created on top of the current base branch
+	if !pb.IsMergeQueueEnabled() && issues_model.MergeBlockedByOutdatedBranch(pb, pr) {
 		return util.ErrorWrap(ErrNotReadyToMerge, "The head branch is behind the base branch")
 	}
 
@@ -717,6 +750,11 @@ func SetMerged(ctx context.Context, pr *issues_model.PullRequest, mergedCommitID
 			return false, fmt.Errorf("DeleteScheduledAutoMerge[%d]: %v", pr.ID, err)
 		}
 
+		// Removing the pull from the merge queue and ignore if not exist
+		if err := pull_model.DeleteMergeQueueEntry(ctx, pr.ID); err != nil && !db.IsErrNotExist(err) {
+			return false, fmt.Errorf("DeleteMergeQueueEntry[%d]: %v", pr.ID, err)
+		}
+
 		// Set issue as closed
 		if _, err := issues_model.SetIssueAsClosed(ctx, pr.Issue, pr.Merger, true); err != nil {
 			return false, fmt.Errorf("ChangeIssueStatus: %w", err)
@@ -0,0 +1,151 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// MergeGroupBranchPrefix is the prefix of the branches holding the speculative merge commits of a merge queue
+const MergeGroupBranchPrefix = "gitea-mq/"
+
+// GetMergeGroupBranchName returns the name of the branch which holds the merge group commit of a pull request
+func GetMergeGroupBranchName(baseBranch string, index int64) string {
+	return fmt.Sprintf("%s%s/%d", MergeGroupBranchPrefix, baseBranch, index)
+}
+
+// IsMergeQueueRequired returns whether the pull request has to be merged through the merge queue of its base branch
+func IsMergeQueueRequired(ctx context.Context, pr *issues_model.PullRequest) (bool, error) {
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return false, err
+	}
+	return pb != nil && pb.IsMergeQueueEnabled(), nil
+}
+
+// CreateMergeGroupCommit merges the pull request onto parentBranch without touching the base branch and pushes
+// the result to the merge group branch of the pull request, so that status checks can be run against it.
+// parentBranch is either the base branch or the merge group branch of the previous pull request in the queue.
+func CreateMergeGroupCommit(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, mergeStyle repo_model.MergeStyle, message, parentBranch string) (parentCommitID, mergeGroupCommitID string, err error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return "", "", fmt.Errorf("unable to load base repo: %w", err)
+	}
+
+	// Merge against the parent branch instead of the base branch.
+	speculativePR := *pr
+	speculativePR.BaseBranch = parentBranch
+
+	mergeCtx, cancel, err := createTemporaryRepoForMerge(ctx, &speculativePR, doer, "")
+	if err != nil {
+		return "", "", err
+	}
+	defer cancel()
+
+	if err := doMergeStyle(mergeCtx, mergeStyle, message); err != nil {
+		return "", "", err
+	}
+
+	parentCommitID, err = git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, "original_"+baseBranch)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to get full commit id for origin/%s: %w", parentBranch, err)
+	}
+	mergeGroupCommitID, err = git.GetFullCommitID(ctx, mergeCtx.tmpBasePath, baseBranch)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to get full commit id for the merge group: %w", err)
+	}
+
+	if setting.LFS.StartServer {
+		if err := LFSPush(ctx, mergeCtx.tmpBasePath, mergeGroupCommitID, parentCommitID, pr); err != nil {
+			return "", "", err
+		}
+	}
+
+	// The merge group branch is an internal branch, so the push must not trigger any hooks.
+	mergeCtx.env = repo_module.InternalPushingEnvironment(doer, pr.BaseRepo)
+	pushCmd := gitcmd.NewCommand("push", "-f", "origin").
+		AddDynamicArguments(baseBranch + ":" + git.BranchPrefix + GetMergeGroupBranchName(pr.BaseBranch, pr.Index))
+	if err := mergeCtx.PrepareGitCmd(pushCmd).Run(ctx); err != nil {
+		return "", "", fmt.Errorf("git push: %s", mergeCtx.errbuf.String())
+	}
+
+	return parentCommitID, mergeGroupCommitID, nil
+}
+
+// MergeQueuedPullRequest fast-forwards the base branch of the pull request to its tested merge group commit
+func MergeQueuedPullRequest(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, mergeGroupCommitID string) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return fmt.Errorf("unable to load base repo: %w", err)
+	} else if err := pr.LoadHeadRepo(ctx); err != nil {
+		return fmt.Errorf("unable to load head repo: %w", err)
+	}
+
+	releaser, err := globallock.Lock(ctx, getPullWorkingLockKey(pr.ID))
+	if err != nil {
+		return fmt.Errorf("lock.Lock: %w", err)
+	}
+	defer releaser()
+
+	// Reviews may have changed while the pull request was waiting in the queue. The status checks are
+	// not checked here as they have been verified against the merge group commit by the queue.
+	if err := CheckPullBranchProtections(ctx, pr, false); err != nil {
+		return err
+	}
+
+	defer func() {
+		go AddTestPullRequestTask(TestPullRequestOptions{
+			RepoID: pr.BaseRepo.ID,
+			Doer:   doer,
+			Branch: pr.BaseBranch,
+		})
+	}()
+
+	headUser := doer
+	if err := pr.HeadRepo.LoadOwner(ctx); err == nil {
+		headUser = pr.HeadRepo.Owner
+	} else if !user_model.IsErrUserNotExist(err) {
+		return err
+	}
+
+	env := repo_module.FullPushingEnvironment(headUser, doer, pr.BaseRepo, pr.BaseRepo.Name, pr.ID, pr.Index)
+	env = append(env, repo_module.EnvPushTrigger+"="+string(repo_module.PushTriggerPRMergeToBase))
+
+	// The push is not forced, so it fails if the base branch has moved since the merge group commit was created.
+	// The post receive hook marks the pull request as merged.
+	err = gitrepo.Push(ctx, pr.BaseRepo, pr.BaseRepo, git.PushOptions{
+		LocalRefName: mergeGroupCommitID,
+		Branch:       git.BranchPrefix + pr.BaseBranch,
+		Env:          env,
+	})
+	releaser()
+	if err != nil {
+		return err
+	}
+
+	return afterMergePushed(ctx, pr, doer, true)
+}
+
+// RemoveMergeGroupBranch deletes the merge group branch of a pull request
+func RemoveMergeGroupBranch(ctx context.Context, pr *issues_model.PullRequest) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		log.Error("LoadBaseRepo %-v: %v", pr, err)
+		return
+	}
+	refName := git.BranchPrefix + GetMergeGroupBranchName(pr.BaseBranch, pr.Index)
+	if err := gitrepo.RemoveRef(ctx, pr.BaseRepo, refName); err != nil {
+		log.Error("RemoveRef %s in %-v: %v", refName, pr.BaseRepo, err)
+	}
+}
@@ -284,7 +284,7 @@ func (err ErrRebaseConflicts) Error() string {
 
 // rebaseTrackingOnToBase checks out the tracking branch as staging and rebases it on to the base branch
 // if there is a conflict it will return an ErrRebaseConflicts
-func rebaseTrackingOnToBase(ctx *mergeContext, mergeStyle repo_model.MergeStyle) error {
+func rebaseTrackingOnToBase(ctx *mergeContext, mergeStyle repo_model.MergeStyle, upstream string) error {
 	// Checkout head branch
 	if err := ctx.PrepareGitCmd(gitcmd.NewCommand("checkout", "-b").AddDynamicArguments(stagingBranch, trackingBranch)).
 		Run(ctx); err != nil {
@@ -293,9 +293,14 @@ func rebaseTrackingOnToBase(ctx *mergeContext, mergeStyle repo_model.MergeStyle)
 	ctx.outbuf.Reset()
 	ctx.errbuf.Reset()
 
-	// Rebase before merging
-	if err := ctx.PrepareGitCmd(gitcmd.NewCommand("rebase").AddDynamicArguments(baseBranch)).
-		Run(ctx); err != nil {
+	// Rebase before merging, only the commits after the upstream are rebased if it's given
+	rebaseCmd := gitcmd.NewCommand("rebase")
+	if upstream != "" {
+		rebaseCmd.AddArguments("--onto").AddDynamicArguments(baseBranch, upstream)
+	} else {
+		rebaseCmd.AddDynamicArguments(baseBranch)
+	}
+	if err := ctx.PrepareGitCmd(rebaseCmd).Run(ctx); err != nil {
 		// Rebase will leave a REBASE_HEAD file in .git if there is a conflict
 		if _, statErr := os.Stat(filepath.Join(ctx.tmpBasePath, ".git", "REBASE_HEAD")); statErr == nil {
 			var commitSha string
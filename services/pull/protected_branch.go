@@ -6,18 +6,36 @@ package pull
 import (
 	"context"
 
+	audit_model "code.gitea.io/gitea/models/audit"
 	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/services/audit"
 )
 
-func CreateOrUpdateProtectedBranch(ctx context.Context, repo *repo_model.Repository,
+func CreateOrUpdateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
 	protectBranch *git_model.ProtectedBranch, whitelistOptions git_model.WhitelistOptions,
 ) error {
+	var oldProtectBranch *git_model.ProtectedBranch
+	if protectBranch.ID > 0 {
+		var err error
+		oldProtectBranch, err = git_model.GetProtectedBranchRuleByID(ctx, repo.ID, protectBranch.ID)
+		if err != nil {
+			return err
+		}
+	}
+
 	err := git_model.UpdateProtectBranch(ctx, repo, protectBranch, whitelistOptions)
 	if err != nil {
 		return err
 	}
 
+	if oldProtectBranch == nil {
+		audit.Record(ctx, doer, audit_model.ActionRepoBranchProtectionCreate, audit.RepoScope(repo), audit.ProtectedBranchTarget(protectBranch), nil, audit.ProtectedBranchState(protectBranch))
+	} else {
+		audit.Record(ctx, doer, audit_model.ActionRepoBranchProtectionUpdate, audit.RepoScope(repo), audit.ProtectedBranchTarget(protectBranch), audit.ProtectedBranchState(oldProtectBranch), audit.ProtectedBranchState(protectBranch))
+	}
+
 	isPlainRule := !git_model.IsRuleNameSpecial(protectBranch.RuleName)
 	var isBranchExist bool
 	if isPlainRule {
@@ -45,3 +63,13 @@ func CreateOrUpdateProtectedBranch(ctx context.Context, repo *repo_model.Reposit
 
 	return nil
 }
+
+// DeleteProtectedBranch removes a branch protection rule of the repository
+func DeleteProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) error {
+	if err := git_model.DeleteProtectedBranch(ctx, repo, rule.ID); err != nil {
+		return err
+	}
+
+	audit.Record(ctx, doer, audit_model.ActionRepoBranchProtectionDelete, audit.RepoScope(repo), audit.ProtectedBranchTarget(rule), audit.ProtectedBranchState(rule), nil)
+	return nil
+}
@@ -662,27 +662,32 @@ func UpdateRef(ctx context.Context, pr *issues_model.PullRequest) (err error) {
 
 // retargetBranchPulls change target branch for all pull requests whose base branch is the branch
 // Both branch and targetBranch must be in the same repo (for security reasons)
-func retargetBranchPulls(ctx context.Context, doer *user_model.User, repoID int64, branch, targetBranch string) error {
+// It returns the pull requests which have been retargeted.
+func retargetBranchPulls(ctx context.Context, doer *user_model.User, repoID int64, branch, targetBranch string) (issues_model.PullRequestList, error) {
 	prs, err := issues_model.GetUnmergedPullRequestsByBaseInfo(ctx, repoID, branch)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := prs.LoadAttributes(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
 	var errs []error
+	retargeted := make(issues_model.PullRequestList, 0, len(prs))
 	for _, pr := range prs {
 		if err = pr.Issue.LoadRepo(ctx); err != nil {
 			errs = append(errs, err)
-		} else if err = ChangeTargetBranch(ctx, pr, doer, targetBranch); err != nil &&
-			!issues_model.IsErrIssueIsClosed(err) && !IsErrPullRequestHasMerged(err) &&
-			!issues_model.IsErrPullRequestAlreadyExists(err) {
-			errs = append(errs, err)
+		} else if err = ChangeTargetBranch(ctx, pr, doer, targetBranch); err != nil {
+			if !issues_model.IsErrIssueIsClosed(err) && !IsErrPullRequestHasMerged(err) &&
+				!issues_model.IsErrPullRequestAlreadyExists(err) {
+				errs = append(errs, err)
+			}
+		} else {
+			retargeted = append(retargeted, pr)
 		}
 	}
-	return errors.Join(errs...)
+	return retargeted, errors.Join(errs...)
 }
 
 // AdjustPullsCausedByBranchDeleted close all the pull requests who's head branch is the branch
@@ -717,7 +722,7 @@ func AdjustPullsCausedByBranchDeleted(ctx context.Context, doer *user_model.User
 	}
 
 	if setting.Repository.PullRequest.RetargetChildrenOnMerge {
-		if err := retargetBranchPulls(ctx, doer, repo.ID, branch, repo.DefaultBranch); err != nil {
+		if _, err := retargetBranchPulls(ctx, doer, repo.ID, branch, repo.DefaultBranch); err != nil {
 			log.Error("retargetBranchPulls failed: %v", err)
 			errs = append(errs, err)
 		}
@@ -88,3 +88,35 @@ func TestPullRequest_GetDefaultMergeMessage_ExternalTracker(t *testing.T) {
 
 	assert.Equal(t, "Merge pull request 'issue3' (#3) from user2/repo2:branch2 into master", mergeMessage)
 }
+
+func TestGetPullRequestStack(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// pull request 5 (pr-to-update -> branch2) is stacked on pull request 2 (branch2 -> master)
+	pr2 := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	pr5 := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})
+
+	lower, err := getStackedOnPull(t.Context(), pr5)
+	assert.NoError(t, err)
+	if assert.NotNil(t, lower) {
+		assert.EqualValues(t, 2, lower.ID)
+	}
+	lower, err = getStackedOnPull(t.Context(), pr2)
+	assert.NoError(t, err)
+	assert.Nil(t, lower)
+
+	for _, pr := range []*issues_model.PullRequest{pr2, pr5} {
+		stack, err := GetPullRequestStack(t.Context(), pr)
+		assert.NoError(t, err)
+		if assert.Len(t, stack, 2) {
+			assert.EqualValues(t, 2, stack[0].ID)
+			assert.EqualValues(t, 5, stack[1].ID)
+		}
+	}
+
+	// pull request 1 has been merged, so it isn't part of a stack
+	pr1 := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 1})
+	stack, err := GetPullRequestStack(t.Context(), pr1)
+	assert.NoError(t, err)
+	assert.Empty(t, stack)
+}
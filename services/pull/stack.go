@@ -0,0 +1,301 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// A pull request is stacked on another one if its base branch is the head branch of the other pull request
+// in the same repository. Stacked pull requests are retargeted to the base branch of the lower pull request
+// once it has been merged.
+
+// getStackedOnPull returns the open pull request which the pull request is stacked on, it's nil if there is none
+func getStackedOnPull(ctx context.Context, pr *issues_model.PullRequest) (*issues_model.PullRequest, error) {
+	prs, err := issues_model.GetUnmergedPullRequestsByHeadInfo(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+	for _, lower := range prs {
+		if lower.BaseRepoID == pr.BaseRepoID && lower.ID != pr.ID {
+			return lower, nil
+		}
+	}
+	return nil, nil
+}
+
+// getStackedPulls returns the open pull requests which are stacked on the pull request
+func getStackedPulls(ctx context.Context, pr *issues_model.PullRequest) (issues_model.PullRequestList, error) {
+	if pr.HeadRepoID != pr.BaseRepoID || pr.Flow != issues_model.PullRequestFlowGithub {
+		return nil, nil
+	}
+	return issues_model.GetUnmergedPullRequestsByBaseInfo(ctx, pr.BaseRepoID, pr.HeadBranch)
+}
+
+// getPullsBelow returns the pull requests below the pull request in its stack, from the bottom of the stack
+func getPullsBelow(ctx context.Context, pr *issues_model.PullRequest) (issues_model.PullRequestList, error) {
+	var below issues_model.PullRequestList
+	visited := map[int64]bool{pr.ID: true}
+	for cur := pr; ; {
+		lower, err := getStackedOnPull(ctx, cur)
+		if err != nil {
+			return nil, err
+		} else if lower == nil || visited[lower.ID] {
+			break
+		}
+		visited[lower.ID] = true
+		below = append(issues_model.PullRequestList{lower}, below...)
+		cur = lower
+	}
+	return below, nil
+}
+
+// GetPullRequestStack returns all pull requests of the stack of the pull request from the bottom to the top,
+// the pull requests stacked on the same pull request are listed one after another.
+// It's empty if the pull request isn't part of a stack.
+func GetPullRequestStack(ctx context.Context, pr *issues_model.PullRequest) (issues_model.PullRequestList, error) {
+	stack, err := getPullsBelow(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	stack = append(stack, pr)
+
+	visited := make(map[int64]bool, len(stack))
+	for _, p := range stack {
+		visited[p.ID] = true
+	}
+	var addStackedPulls func(pr *issues_model.PullRequest) error
+	addStackedPulls = func(pr *issues_model.PullRequest) error {
+		prs, err := getStackedPulls(ctx, pr)
+		if err != nil {
+			return err
+		}
+		for _, upper := range prs {
+			if visited[upper.ID] {
+				continue
+			}
+			visited[upper.ID] = true
+			stack = append(stack, upper)
+			if err := addStackedPulls(upper); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := addStackedPulls(pr); err != nil {
+		return nil, err
+	}
+
+	if len(stack) == 1 {
+		return nil, nil
+	}
+	if err := stack.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	return stack, nil
+}
+
+// retargetStackedPulls retargets the pull requests stacked on the merged pull request to its base branch,
+// and rebases their head branches onto the base branch without the commits of the merged pull request.
+func retargetStackedPulls(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
+	if !setting.Repository.PullRequest.RetargetChildrenOnMerge {
+		return nil
+	}
+
+	if pr.HeadRepoID != pr.BaseRepoID || pr.Flow != issues_model.PullRequestFlowGithub {
+		return nil
+	}
+
+	// the head commit of the merged pull request, the commits of the stacked pull requests after it are kept
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, pr.BaseRepo)
+	if err != nil {
+		return err
+	}
+	oldHeadCommitID, err := gitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+	closer.Close()
+	if err != nil {
+		return err
+	}
+
+	prs, err := retargetBranchPulls(ctx, doer, pr.BaseRepoID, pr.HeadBranch, pr.BaseBranch)
+	errs := []error{err}
+	for _, upper := range prs {
+		if err := rebaseStackedPull(ctx, doer, upper, oldHeadCommitID); err != nil {
+			if IsErrRebaseConflicts(err) {
+				// the pull request shows the conflicts, they have to be resolved by the author
+				log.Debug("Unable to rebase %-v onto %s: %v", upper, upper.BaseBranch, err)
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// rebaseStackedPull rebases the commits of the head branch after the upstream onto the base branch,
+// it does nothing if the doer isn't allowed to rebase the head branch.
+func rebaseStackedPull(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, upstream string) error {
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return err
+	} else if pr.HeadRepo == nil {
+		return nil
+	}
+	if _, rebaseAllowed, err := IsUserAllowedToUpdate(ctx, pr, doer); err != nil {
+		return err
+	} else if !rebaseAllowed {
+		return nil
+	}
+
+	releaser, err := globallock.Lock(ctx, getPullWorkingLockKey(pr.ID))
+	if err != nil {
+		return fmt.Errorf("lock.Lock: %w", err)
+	}
+	defer releaser()
+
+	return updateHeadByRebaseOnToBase(ctx, pr, doer, upstream)
+}
+
+// ErrStackedPullNotMergeable represents an error that a pull request of a stack can't be merged
+type ErrStackedPullNotMergeable struct {
+	Index int64
+	Err   error
+}
+
+func (err ErrStackedPullNotMergeable) Error() string {
+	return fmt.Sprintf("pull request #%d of the stack can't be merged: %v", err.Index, err.Err)
+}
+
+func (err ErrStackedPullNotMergeable) Unwrap() error {
+	return err.Err
+}
+
+// MergeStack merges the pull request and all pull requests below it in its stack, from the bottom of the stack.
+// Every merged pull request retargets the next one to the base branch, so each pull request is merged into the
+// base branch of the bottom pull request. It stops at the first pull request which can't be merged.
+func MergeStack(ctx context.Context, doer *user_model.User, perm *access_model.Permission, pr *issues_model.PullRequest, mergeStyle repo_model.MergeStyle) (int, error) {
+	stack, err := getPullsBelow(ctx, pr)
+	if err != nil {
+		return 0, err
+	}
+	stack = append(stack, pr)
+
+	for i, p := range stack {
+		// reload the pull request because it has been retargeted and rebased by merging the previous one
+		p, err = issues_model.GetPullRequestByID(ctx, p.ID)
+		if err != nil {
+			return i, err
+		}
+		if err := p.LoadIssue(ctx); err != nil {
+			return i, err
+		}
+		if err := p.LoadBaseRepo(ctx); err != nil {
+			return i, err
+		}
+		p.Issue.Repo = p.BaseRepo
+
+		if i > 0 {
+			if err := refreshStackedPull(ctx, p); err != nil {
+				return i, err
+			}
+		}
+		// the head commit which is checked, the merge fails if the head branch is pushed again in the meantime
+		headCommitID, err := getPullHeadCommitID(ctx, p)
+		if err != nil {
+			return i, err
+		}
+
+		if err := CheckPullMergeable(ctx, doer, perm, p, MergeCheckTypeGeneral, false); err != nil {
+			return i, ErrStackedPullNotMergeable{Index: p.Index, Err: err}
+		}
+
+		message, err := getStackedPullMergeMessage(ctx, p, mergeStyle)
+		if err != nil {
+			return i, err
+		}
+		if err := Merge(ctx, p, doer, mergeStyle, headCommitID, message, false); err != nil {
+			return i, ErrStackedPullNotMergeable{Index: p.Index, Err: err}
+		}
+	}
+	return len(stack), nil
+}
+
+// stackedPullCheckTimeout is how long to wait for the check of a pull request of a stack started by the push of its rebased head branch
+const stackedPullCheckTimeout = time.Minute
+
+// refreshStackedPull updates the head of a pull request which has been retargeted and rebased by merging the previous one
+// and tests it immediately instead of waiting for the check queue, then it waits for the check started by the push of
+// the rebased head branch if there is one, so the pull request is checked with its new head commit before it's merged.
+func refreshStackedPull(ctx context.Context, pr *issues_model.PullRequest) error {
+	if err := testStackedPull(ctx, pr); err != nil {
+		return err
+	}
+
+	// the push of the rebased head branch may have started another check
+	for deadline := time.Now().Add(stackedPullCheckTimeout); ; {
+		reloaded, err := issues_model.GetPullRequestByID(ctx, pr.ID)
+		if err != nil {
+			return err
+		}
+		pr.Status, pr.MergeBase = reloaded.Status, reloaded.MergeBase
+		pr.ConflictedFiles, pr.ChangedProtectedFiles = reloaded.ConflictedFiles, reloaded.ChangedProtectedFiles
+		if pr.Status != issues_model.PullRequestStatusChecking || time.Now().After(deadline) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// testStackedPull updates the head ref of the pull request in the base repository and tests its mergeability
+func testStackedPull(ctx context.Context, pr *issues_model.PullRequest) error {
+	releaser, err := globallock.Lock(ctx, getPullWorkingLockKey(pr.ID))
+	if err != nil {
+		return fmt.Errorf("lock.Lock: %w", err)
+	}
+	defer releaser()
+
+	if err := PushToBaseRepo(ctx, pr); err != nil {
+		return err
+	}
+	if err := testPullRequestBranchMergeable(pr); err != nil {
+		return err
+	}
+	_, err = pr.UpdateColsIfNotMerged(ctx, "merge_base", "status", "conflicted_files", "changed_protected_files")
+	return err
+}
+
+func getStackedPullMergeMessage(ctx context.Context, pr *issues_model.PullRequest, mergeStyle repo_model.MergeStyle) (string, error) {
+	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, pr.BaseRepo)
+	if err != nil {
+		return "", err
+	}
+	defer closer.Close()
+
+	message, body, err := GetDefaultMergeMessage(ctx, gitRepo, pr, mergeStyle)
+	if err != nil {
+		return "", err
+	}
+	if body != "" {
+		message += "\n\n" + body
+	}
+	return message, nil
+}
@@ -78,7 +78,7 @@ func Update(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.
 	}()
 
 	if rebase {
-		return updateHeadByRebaseOnToBase(ctx, pr, doer)
+		return updateHeadByRebaseOnToBase(ctx, pr, doer, "")
 	}
 
 	// TODO: FakePR: it is somewhat hacky, but it is the only way to "merge" at the moment
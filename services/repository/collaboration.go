@@ -8,17 +8,20 @@ import (
 	"context"
 	"fmt"
 
+	audit_model "code.gitea.io/gitea/models/audit"
 	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/services/audit"
 
 	"xorm.io/builder"
 )
 
-func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u *user_model.User, mode perm.AccessMode) error {
+// AddOrUpdateCollaborator adds the user as a collaborator of the repository or changes its access mode
+func AddOrUpdateCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, u *user_model.User, mode perm.AccessMode) error {
 	// only allow valid access modes, read, write and admin
 	if mode < perm.AccessModeRead || mode > perm.AccessModeAdmin {
 		return perm.ErrInvalidAccessMode
@@ -32,7 +35,9 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 		return user_model.ErrBlockedUser
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	var oldMode perm.AccessMode
+	changed := false
+	err := db.WithTx(ctx, func(ctx context.Context) error {
 		collaboration, has, err := db.Get[repo_model.Collaboration](ctx, builder.Eq{
 			"repo_id": repo.ID,
 			"user_id": u.ID,
@@ -43,6 +48,7 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 			if collaboration.Mode == mode {
 				return nil
 			}
+			oldMode = collaboration.Mode
 			if _, err = db.GetEngine(ctx).
 				Where("repo_id=?", repo.ID).
 				And("user_id=?", u.ID).
@@ -60,19 +66,56 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 			return err
 		}
 
+		changed = true
 		return access_model.RecalculateUserAccess(ctx, repo, u.ID)
 	})
+	if err != nil || !changed {
+		return err
+	}
+
+	if oldMode == perm.AccessModeNone {
+		audit.Record(ctx, doer, audit_model.ActionRepoCollaboratorAdd, audit.RepoScope(repo), audit.UserTarget(u), nil, audit.AccessModeState(mode))
+	} else {
+		audit.Record(ctx, doer, audit_model.ActionRepoCollaboratorUpdate, audit.RepoScope(repo), audit.UserTarget(u), audit.AccessModeState(oldMode), audit.AccessModeState(mode))
+	}
+	return nil
+}
+
+// ChangeCollaborationAccessMode changes the access mode of an existing collaborator
+func ChangeCollaborationAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) error {
+	// Discard invalid input
+	if mode <= perm.AccessModeNone || mode > perm.AccessModeOwner {
+		return nil
+	}
+
+	collaboration, has, err := db.Get[repo_model.Collaboration](ctx, builder.Eq{
+		"repo_id": repo.ID,
+		"user_id": collaborator.ID,
+	})
+	if err != nil || !has || collaboration.Mode == mode {
+		return err
+	}
+
+	if err := repo_model.ChangeCollaborationAccessMode(ctx, repo, collaborator.ID, mode); err != nil {
+		return err
+	}
+
+	audit.Record(ctx, doer, audit_model.ActionRepoCollaboratorUpdate, audit.RepoScope(repo), audit.UserTarget(collaborator), audit.AccessModeState(collaboration.Mode), audit.AccessModeState(mode))
+	return nil
 }
 
 // DeleteCollaboration removes collaboration relation between the user and repository.
-func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, collaborator *user_model.User) (err error) {
-	collaboration := &repo_model.Collaboration{
-		RepoID: repo.ID,
-		UserID: collaborator.ID,
+func DeleteCollaboration(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) (err error) {
+	collaboration, has, err := db.Get[repo_model.Collaboration](ctx, builder.Eq{
+		"repo_id": repo.ID,
+		"user_id": collaborator.ID,
+	})
+	if err != nil || !has {
+		return err
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
-		if has, err := db.GetEngine(ctx).Delete(collaboration); err != nil {
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		if has, err := db.GetEngine(ctx).Delete(&repo_model.Collaboration{ID: collaboration.ID}); err != nil {
 			return err
 		} else if has == 0 {
 			return nil
@@ -97,6 +140,12 @@ func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, colla
 		// Unassign a user from any issue (s)he has been assigned to in the repository
 		return ReconsiderRepoIssuesAssignee(ctx, repo, collaborator)
 	})
+	if err != nil {
+		return err
+	}
+
+	audit.Record(ctx, doer, audit_model.ActionRepoCollaboratorRemove, audit.RepoScope(repo), audit.UserTarget(collaborator), audit.AccessModeState(collaboration.Mode), nil)
+	return nil
 }
 
 func ReconsiderRepoIssuesAssignee(ctx context.Context, repo *repo_model.Repository, user *user_model.User) error {
@@ -20,6 +20,7 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	secret_model "code.gitea.io/gitea/models/secret"
 	system_model "code.gitea.io/gitea/models/system"
+	terraform_model "code.gitea.io/gitea/models/terraform"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/models/webhook"
 	actions_module "code.gitea.io/gitea/modules/actions"
@@ -82,6 +83,12 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return fmt.Errorf("list actions artifacts of repo %v: %w", repoID, err)
 	}
 
+	// Query the caches of this repo, they will be needed after they have been deleted to remove their files in ObjectStorage
+	caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{RepoID: repoID})
+	if err != nil {
+		return fmt.Errorf("list actions caches of repo %v: %w", repoID, err)
+	}
+
 	// In case owner is a organization, we have to change repo specific teams
 	// if ignoreOrgTeams is not true
 	var org *user_model.User
@@ -170,6 +177,8 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&webhook.Webhook{RepoID: repoID},
 		&secret_model.Secret{RepoID: repoID},
 		&actions_model.ActionTaskStep{RepoID: repoID},
+		&actions_model.ActionTaskAnnotation{RepoID: repoID},
+		&actions_model.ActionTaskSummary{RepoID: repoID},
 		&actions_model.ActionTask{RepoID: repoID},
 		&actions_model.ActionRunJob{RepoID: repoID},
 		&actions_model.ActionRun{RepoID: repoID},
@@ -178,6 +187,11 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&actions_model.ActionSchedule{RepoID: repoID},
 		&actions_model.ActionArtifact{RepoID: repoID},
 		&actions_model.ActionRunnerToken{RepoID: repoID},
+		&actions_model.ActionEnvironment{RepoID: repoID},
+		&actions_model.ActionDeployment{RepoID: repoID},
+		&actions_model.ActionCache{RepoID: repoID},
+		&actions_model.ActionApprovalPolicy{RepoID: repoID},
+		&actions_model.ActionRequiredWorkflow{RepoID: repoID},
 		&issues_model.IssuePin{RepoID: repoID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
@@ -292,6 +306,11 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return err
 	}
 
+	terraformStateVersions, err := terraform_model.DeleteStatesByRepoID(ctx, repoID)
+	if err != nil {
+		return err
+	}
+
 	if err = committer.Commit(); err != nil {
 		return err
 	}
@@ -349,6 +368,11 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		system_model.RemoveStorageWithNotice(ctx, storage.Attachments, "Delete issue attachment", newAttachment)
 	}
 
+	// Remove Terraform state versions
+	for _, sv := range terraformStateVersions {
+		system_model.RemoveStorageWithNotice(ctx, storage.TerraformStates, "Delete Terraform state version", sv.RelativePath())
+	}
+
 	if len(repo.Avatar) > 0 {
 		if err := storage.RepoAvatars.Delete(repo.CustomAvatarRelativePath()); err != nil {
 			log.Error("remove avatar file %q: %v", repo.CustomAvatarRelativePath(), err)
@@ -373,6 +397,11 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		}
 	}
 
+	// delete actions caches in ObjectStorage after the repo have already been deleted
+	for _, c := range caches {
+		actions_service.RemoveCacheFiles(c)
+	}
+
 	return nil
 }
 
@@ -6,37 +6,120 @@ package secrets
 import (
 	"context"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
 	secret_model "code.gitea.io/gitea/models/secret"
+	"code.gitea.io/gitea/modules/util"
 )
 
-func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data, description string) (*secret_model.Secret, bool, error) {
+// CreateOrUpdateSecret creates or updates a secret, the repositories and environments which can use an org or user level secret
+// are changed if scope is not nil, otherwise a new secret is visible to all repositories of its owner
+func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data, description string, scope *actions_model.RepoScope) (*secret_model.Secret, bool, error) {
 	if err := ValidateName(name); err != nil {
 		return nil, false, err
 	}
+	if scope != nil {
+		if err := ValidateRepoScope(ctx, ownerID, repoID, scope); err != nil {
+			return nil, false, err
+		}
+	}
 
-	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
-		OwnerID: ownerID,
-		RepoID:  repoID,
-		Name:    name,
+	var secret *secret_model.Secret
+	var created bool
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+			OwnerID: ownerID,
+			RepoID:  repoID,
+			Name:    name,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(s) == 0 {
+			secret, err = secret_model.InsertEncryptedSecret(ctx, ownerID, repoID, name, data, description)
+			if err != nil {
+				return err
+			}
+			created = true
+		} else {
+			secret = s[0]
+			if err := secret_model.UpdateSecret(ctx, secret.ID, data, description); err != nil {
+				return err
+			}
+		}
+
+		if scope == nil {
+			return nil
+		}
+		secret.RepoScope = *scope
+		return secret_model.UpdateSecretScope(ctx, secret.ID, *scope)
 	})
 	if err != nil {
 		return nil, false, err
 	}
+	return secret, created, nil
+}
+
+// CreateOrUpdateEnvironmentSecret creates or updates a secret of an environment of a repository
+func CreateOrUpdateEnvironmentSecret(ctx context.Context, env *actions_model.ActionEnvironment, name, data, description string) (*secret_model.Secret, bool, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, false, err
+	}
 
-	if len(s) == 0 {
-		s, err := secret_model.InsertEncryptedSecret(ctx, ownerID, repoID, name, data, description)
+	var secret *secret_model.Secret
+	var created bool
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+			RepoID:        env.RepoID,
+			EnvironmentID: env.ID,
+			Name:          name,
+		})
 		if err != nil {
-			return nil, false, err
+			return err
 		}
-		return s, true, nil
-	}
 
-	if err := secret_model.UpdateSecret(ctx, s[0].ID, data, description); err != nil {
+		if len(s) == 0 {
+			secret, err = secret_model.InsertEncryptedEnvironmentSecret(ctx, env.RepoID, env.ID, name, data, description)
+			created = true
+			return err
+		}
+		secret = s[0]
+		return secret_model.UpdateSecret(ctx, secret.ID, data, description)
+	})
+	if err != nil {
 		return nil, false, err
 	}
+	return secret, created, nil
+}
 
-	return s[0], false, nil
+// ValidateRepoScope checks the visibility and the environments of an org or user level secret or variable,
+// the selected repositories must belong to its owner
+func ValidateRepoScope(ctx context.Context, ownerID, repoID int64, scope *actions_model.RepoScope) error {
+	scope.Normalize()
+	if !scope.Visibility.IsValid() {
+		return util.NewInvalidArgumentErrorf("invalid visibility %q", scope.Visibility)
+	}
+	if scope.IsRestricted() && (ownerID == 0 || repoID != 0) {
+		return util.NewInvalidArgumentErrorf("only org or user level secrets and variables can be restricted to some repositories or environments")
+	}
+	for _, env := range scope.SelectedEnvironments {
+		if len(env) > actions_model.EnvironmentNameMaxLength {
+			return util.NewInvalidArgumentErrorf("environment name %q is too long", env)
+		}
+	}
+
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, scope.SelectedRepoIDs)
+	if err != nil {
+		return err
+	}
+	for _, id := range scope.SelectedRepoIDs {
+		if repo, ok := repos[id]; !ok || repo.OwnerID != ownerID {
+			return util.NewInvalidArgumentErrorf("repository %d does not belong to the owner", id)
+		}
+	}
+	return nil
 }
 
 func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) error {
@@ -55,6 +138,23 @@ func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) erro
 	return deleteSecret(ctx, s[0])
 }
 
+// DeleteEnvironmentSecretByID deletes a secret of an environment of a repository
+func DeleteEnvironmentSecretByID(ctx context.Context, env *actions_model.ActionEnvironment, secretID int64) error {
+	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		SecretID:      secretID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(s) != 1 {
+		return secret_model.ErrSecretNotFound{}
+	}
+
+	return deleteSecret(ctx, s[0])
+}
+
 func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string) error {
 	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
 		OwnerID: ownerID,
@@ -0,0 +1,121 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrInvalidStateName     = util.NewInvalidArgumentErrorf("terraform state name is invalid")
+	ErrInvalidStateDocument = util.NewInvalidArgumentErrorf("terraform state document is invalid")
+)
+
+var stateNamePattern = regexp.MustCompile(`\A[0-9A-Za-z][0-9A-Za-z._-]{0,99}\z`)
+
+// IsValidStateName checks if the name can be used as name of a state
+func IsValidStateName(name string) bool {
+	return stateNamePattern.MatchString(name)
+}
+
+type stateDocument struct {
+	Version *int   `json:"version"`
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+func parseStateDocument(data []byte) (*stateDocument, error) {
+	doc := &stateDocument{}
+	if err := json.Unmarshal(data, doc); err != nil || doc.Version == nil {
+		return nil, ErrInvalidStateDocument
+	}
+	return doc, nil
+}
+
+// SaveState stores the data as new version of the state. If the state is locked, lockID must match the id of the lock.
+func SaveState(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, name, lockID string, data []byte) (*terraform_model.StateVersion, error) {
+	if !IsValidStateName(name) {
+		return nil, ErrInvalidStateName
+	}
+
+	doc, err := parseStateDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := terraform_model.GetOrCreateState(ctx, repo.ID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	sv := &terraform_model.StateVersion{
+		Serial:    doc.Serial,
+		Lineage:   doc.Lineage,
+		Size:      int64(len(data)),
+		CreatorID: doer.ID,
+	}
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := terraform_model.AddStateVersion(ctx, s, lockID, sv); err != nil {
+			return err
+		}
+		_, err := storage.TerraformStates.Save(sv.RelativePath(), bytes.NewReader(data), sv.Size)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := pruneStateVersions(ctx, s); err != nil {
+		return nil, err
+	}
+
+	return sv, nil
+}
+
+// pruneStateVersions deletes the versions exceeding the configured number of versions to keep
+func pruneStateVersions(ctx context.Context, s *terraform_model.State) error {
+	if setting.TerraformState.MaxVersions <= 0 {
+		return nil
+	}
+
+	versions, err := terraform_model.FindOutdatedStateVersions(ctx, s.ID, setting.TerraformState.MaxVersions)
+	if err != nil {
+		return err
+	}
+	for _, sv := range versions {
+		if err := terraform_model.DeleteStateVersionByID(ctx, sv.ID); err != nil {
+			return err
+		}
+		system_model.RemoveStorageWithNotice(ctx, storage.TerraformStates, "Delete Terraform state version", sv.RelativePath())
+	}
+	return nil
+}
+
+// OpenStateVersion opens the stored document of the version
+func OpenStateVersion(sv *terraform_model.StateVersion) (storage.Object, error) {
+	return storage.TerraformStates.Open(sv.RelativePath())
+}
+
+// DeleteState deletes the state and all of its versions
+func DeleteState(ctx context.Context, s *terraform_model.State) error {
+	versions, err := terraform_model.DeleteState(ctx, s)
+	if err != nil {
+		return err
+	}
+	for _, sv := range versions {
+		system_model.RemoveStorageWithNotice(ctx, storage.TerraformStates, "Delete Terraform state version", sv.RelativePath())
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"context"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	audit_service "code.gitea.io/gitea/services/audit"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"xorm.io/builder"
+)
+
+// CreateAccessToken creates a new access token of the user
+func CreateAccessToken(ctx context.Context, doer, u *user_model.User, t *auth_model.AccessToken) error {
+	if err := auth_model.NewAccessToken(ctx, t); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserAccessTokenCreate, audit_service.OwnerScope(u), audit_service.AccessTokenTarget(t), nil, audit_service.AccessTokenState(t))
+	return nil
+}
+
+// DeleteAccessToken deletes an access token of the user
+func DeleteAccessToken(ctx context.Context, doer, u *user_model.User, id int64) error {
+	t, exist, err := db.Get[auth_model.AccessToken](ctx, builder.Eq{"id": id, "uid": u.ID})
+	if err != nil {
+		return err
+	} else if !exist {
+		return auth_model.ErrAccessTokenNotExist{}
+	}
+	if err := auth_model.DeleteAccessTokenByID(ctx, id, u.ID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserAccessTokenDelete, audit_service.OwnerScope(u), audit_service.AccessTokenTarget(t), audit_service.AccessTokenState(t), nil)
+	return nil
+}
+
+// EnableTwoFactor enrolls the user in the TOTP based two-factor authentication
+func EnableTwoFactor(ctx context.Context, doer, u *user_model.User, t *auth_model.TwoFactor) error {
+	if err := auth_model.NewTwoFactor(ctx, t); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserTwoFactorEnable, audit_service.OwnerScope(u), audit_service.UserTarget(u), nil, nil)
+	return nil
+}
+
+// DisableTwoFactor removes the TOTP based two-factor authentication of the user
+func DisableTwoFactor(ctx context.Context, doer, u *user_model.User, t *auth_model.TwoFactor) error {
+	if err := auth_model.DeleteTwoFactorByID(ctx, t.ID, u.ID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserTwoFactorDisable, audit_service.OwnerScope(u), audit_service.UserTarget(u), nil, nil)
+	return nil
+}
+
+// AddWebAuthnCredential registers a new security key of the user
+func AddWebAuthnCredential(ctx context.Context, doer, u *user_model.User, name string, cred *webauthn.Credential) (*auth_model.WebAuthnCredential, error) {
+	c, err := auth_model.CreateCredential(ctx, u.ID, name, cred)
+	if err != nil {
+		return nil, err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserWebAuthnAdd, audit_service.OwnerScope(u), audit_service.WebAuthnCredentialTarget(c), nil, nil)
+	return c, nil
+}
+
+// RemoveWebAuthnCredential removes a security key of the user, it returns false if the key doesn't exist
+func RemoveWebAuthnCredential(ctx context.Context, doer, u *user_model.User, id int64) (bool, error) {
+	c, err := auth_model.GetWebAuthnCredentialByID(ctx, id)
+	if err != nil {
+		if auth_model.IsErrWebAuthnCredentialNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if c.UserID != u.ID {
+		return false, nil
+	}
+	removed, err := auth_model.DeleteCredential(ctx, id, u.ID)
+	if err != nil || !removed {
+		return removed, err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserWebAuthnRemove, audit_service.OwnerScope(u), audit_service.WebAuthnCredentialTarget(c), nil, nil)
+	return true, nil
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 
+	audit_model "code.gitea.io/gitea/models/audit"
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
@@ -23,6 +24,7 @@ import (
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
+	audit_service "code.gitea.io/gitea/services/audit"
 )
 
 type Requester func(context.Context, *webhook_model.Webhook, *webhook_model.HookTask) (req *http.Request, body []byte, err error)
@@ -235,3 +237,84 @@ func ReplayHookTask(ctx context.Context, w *webhook_model.Webhook, uuid string)
 
 	return enqueueHookTask(task.ID)
 }
+
+// CreateWebhook creates a new webhook and records it in the audit log
+func CreateWebhook(ctx context.Context, doer *user_model.User, w *webhook_model.Webhook) error {
+	if err := webhook_model.CreateWebhook(ctx, w); err != nil {
+		return err
+	}
+	recordWebhookEvent(ctx, doer, audit_model.ActionWebhookCreate, w, nil, audit_service.WebhookState(w))
+	return nil
+}
+
+// UpdateWebhook updates a webhook and records the changes in the audit log
+func UpdateWebhook(ctx context.Context, doer *user_model.User, w *webhook_model.Webhook) error {
+	oldHook, err := webhook_model.GetWebhookByID(ctx, w.ID)
+	if err != nil {
+		return err
+	}
+	if err := webhook_model.UpdateWebhook(ctx, w); err != nil {
+		return err
+	}
+	recordWebhookEvent(ctx, doer, audit_model.ActionWebhookUpdate, w, audit_service.WebhookState(oldHook), audit_service.WebhookState(w))
+	return nil
+}
+
+// DeleteWebhookByRepoID deletes a webhook of a repository
+func DeleteWebhookByRepoID(ctx context.Context, doer *user_model.User, repoID, id int64) error {
+	w, err := webhook_model.GetWebhookByRepoID(ctx, repoID, id)
+	if err != nil {
+		return err
+	}
+	return deleteWebhook(ctx, doer, w)
+}
+
+// DeleteWebhookByOwnerID deletes a webhook of a user or an organization
+func DeleteWebhookByOwnerID(ctx context.Context, doer *user_model.User, ownerID, id int64) error {
+	w, err := webhook_model.GetWebhookByOwnerID(ctx, ownerID, id)
+	if err != nil {
+		return err
+	}
+	return deleteWebhook(ctx, doer, w)
+}
+
+// DeleteDefaultSystemWebhook deletes a system or default webhook
+func DeleteDefaultSystemWebhook(ctx context.Context, doer *user_model.User, id int64) error {
+	w, err := webhook_model.GetSystemOrDefaultWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := webhook_model.DeleteDefaultSystemWebhook(ctx, id); err != nil {
+		return err
+	}
+	recordWebhookEvent(ctx, doer, audit_model.ActionWebhookDelete, w, audit_service.WebhookState(w), nil)
+	return nil
+}
+
+func deleteWebhook(ctx context.Context, doer *user_model.User, w *webhook_model.Webhook) error {
+	if err := webhook_model.DeleteWebhookByID(ctx, w.ID); err != nil {
+		return err
+	}
+	recordWebhookEvent(ctx, doer, audit_model.ActionWebhookDelete, w, audit_service.WebhookState(w), nil)
+	return nil
+}
+
+func recordWebhookEvent(ctx context.Context, doer *user_model.User, action audit_model.Action, w *webhook_model.Webhook, before, after any) {
+	scope := audit_service.SystemScope()
+	if w.RepoID > 0 {
+		repo, err := repo_model.GetRepositoryByID(ctx, w.RepoID)
+		if err != nil {
+			log.Error("GetRepositoryByID[%d]: %v", w.RepoID, err)
+			return
+		}
+		scope = audit_service.RepoScope(repo)
+	} else if w.OwnerID > 0 {
+		owner, err := user_model.GetUserByID(ctx, w.OwnerID)
+		if err != nil {
+			log.Error("GetUserByID[%d]: %v", w.OwnerID, err)
+			return
+		}
+		scope = audit_service.OwnerScope(owner)
+	}
+	audit_service.Record(ctx, doer, action, scope, audit_service.WebhookTarget(w), before, after)
+}
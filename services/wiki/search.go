@@ -0,0 +1,113 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package wiki
+
+import (
+	"context"
+	"html/template"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/indexer"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// SearchResult is a wiki page matching a search
+type SearchResult struct {
+	WebPath     WebPath
+	SubURL      string
+	Title       string
+	CommitID    string
+	UpdatedUnix timeutil.TimeStamp
+	// Snippet and FormattedSnippet are empty if the page is matched by its title only
+	Snippet          string
+	FormattedSnippet template.HTML
+}
+
+// SearchWikiPages searches the pages of a wiki by the keyword.
+// If the wiki indexer is disabled, only the page titles are matched.
+func SearchWikiPages(ctx context.Context, repo *repo_model.Repository, keyword string, searchMode indexer.SearchModeType, listOptions db.ListOptions) ([]*SearchResult, int, error) {
+	if !setting.Indexer.WikiIndexerEnabled {
+		return searchWikiPageTitles(ctx, repo, keyword, listOptions)
+	}
+
+	total, indexerResults, err := wiki_indexer.PerformSearch(ctx, &wiki_indexer.SearchOptions{
+		RepoIDs:    []int64{repo.ID},
+		Keyword:    keyword,
+		SearchMode: searchMode,
+		Paginator:  &listOptions,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]*SearchResult, 0, len(indexerResults))
+	for _, r := range indexerResults {
+		wikiName, err := GitPathToWebPath(r.Filename)
+		if err != nil {
+			log.Warn("Unexpected filename %q in the wiki indexer: %v", r.Filename, err)
+			continue
+		}
+		_, title := WebPathToUserTitle(wikiName)
+		results = append(results, &SearchResult{
+			WebPath:          wikiName,
+			SubURL:           WebPathToURLPath(wikiName),
+			Title:            title,
+			CommitID:         r.CommitID,
+			UpdatedUnix:      r.UpdatedUnix,
+			Snippet:          r.Snippet,
+			FormattedSnippet: r.FormattedSnippet,
+		})
+	}
+	return results, total, nil
+}
+
+// searchWikiPageTitles returns the pages whose titles contain the keyword
+func searchWikiPageTitles(ctx context.Context, repo *repo_model.Repository, keyword string, listOptions db.ListOptions) ([]*SearchResult, int, error) {
+	wikiRepo, err := gitrepo.OpenRepository(ctx, repo.WikiStorageRepo())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer wikiRepo.Close()
+
+	commit, err := wikiRepo.GetBranchCommit(repo.DefaultWikiBranch)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries, err := commit.ListEntries()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	keyword = strings.ToLower(keyword)
+	var results []*SearchResult
+	for _, entry := range entries {
+		if !entry.IsRegular() {
+			continue
+		}
+		wikiName, err := GitPathToWebPath(entry.Name())
+		if err != nil {
+			continue
+		}
+		_, title := WebPathToUserTitle(wikiName)
+		if !strings.Contains(strings.ToLower(title), keyword) {
+			continue
+		}
+		results = append(results, &SearchResult{
+			WebPath:  wikiName,
+			SubURL:   WebPathToURLPath(wikiName),
+			Title:    title,
+			CommitID: commit.ID.String(),
+		})
+	}
+
+	total := len(results)
+	skip, take := listOptions.GetSkipTake()
+	return results[min(skip, total):min(skip+take, total)], total, nil
+}
@@ -19,6 +19,7 @@ import (
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/globallock"
 	"code.gitea.io/gitea/modules/graceful"
+	wiki_indexer "code.gitea.io/gitea/modules/indexer/wiki"
 	"code.gitea.io/gitea/modules/log"
 	repo_module "code.gitea.io/gitea/modules/repository"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
@@ -376,6 +377,7 @@ func DeleteWiki(ctx context.Context, repo *repo_model.Repository) error {
 		}
 	}
 
+	wiki_indexer.UpdateWikiIndexer(repo)
 	return nil
 }
 
@@ -8,11 +8,13 @@ import (
 	"strings"
 	"testing"
 
+	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/indexer"
 	repo_service "code.gitea.io/gitea/services/repository"
 
 	_ "code.gitea.io/gitea/models/actions"
@@ -327,3 +329,23 @@ func TestWebPathFromRequest(t *testing.T) {
 	assert.Equal(t, WebPath("a"), WebPathFromRequest("a"))
 	assert.Equal(t, WebPath("b"), WebPathFromRequest("a/../b"))
 }
+
+func TestSearchWikiPageTitles(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	results, total, err := SearchWikiPages(t.Context(), repo, "page with", indexer.SearchModeFuzzy, db.ListOptions{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	titles := make([]string, 0, len(results))
+	for _, result := range results {
+		titles = append(titles, result.Title)
+		assert.Empty(t, result.Snippet)
+	}
+	assert.ElementsMatch(t, []string{"Page With Image", "Page With Spaced Name"}, titles)
+
+	results, total, err = SearchWikiPages(t.Context(), repo, "page with", indexer.SearchModeFuzzy, db.ListOptions{Page: 2, PageSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, results, 1)
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	auth_model "code.gitea.io/gitea/models/auth"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/commitstatus"
+	"code.gitea.io/gitea/modules/gitrepo"
+	api "code.gitea.io/gitea/modules/structs"
+	pull_service "code.gitea.io/gitea/services/pull"
+	repo_service "code.gitea.io/gitea/services/repository"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionsRequiredWorkflow(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2}) // owner of org3
+		org3 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+		session := loginUser(t, user2.Name)
+
+		createRepo := func(name string) *repo_model.Repository {
+			repo, err := repo_service.CreateRepository(t.Context(), user2, org3, repo_service.CreateRepoOptions{
+				Name:          name,
+				AutoInit:      true,
+				Readme:        "Default",
+				DefaultBranch: "main",
+			})
+			require.NoError(t, err)
+			return repo
+		}
+		workflowRepo := createRepo("required-workflows")
+		targetRepo := createRepo("required-workflow-target")
+
+		_, err := files_service.ChangeRepoFiles(t.Context(), workflowRepo, user2, &files_service.ChangeRepoFilesOptions{
+			Files: []*files_service.ChangeRepoFile{
+				{
+					Operation: "create",
+					TreePath:  ".gitea/workflows/scan.yml",
+					ContentReader: strings.NewReader(`name: security scan
+on: pull_request
+jobs:
+  scan:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo scanning
+`),
+				},
+				{
+					Operation: "create",
+					TreePath:  ".gitea/workflows/build.yml",
+					ContentReader: strings.NewReader(`name: build
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo building
+`),
+				},
+			},
+			Message:   "add required workflow",
+			OldBranch: "main",
+			NewBranch: "main",
+		})
+		require.NoError(t, err)
+
+		settingsLink := fmt.Sprintf("/org/%s/settings/actions/required_workflows", org3.Name)
+		// the workflows which can't be triggered by a pull request can't be required
+		req := NewRequestWithValues(t, "POST", settingsLink+"/new", map[string]string{
+			"repo_id":      strconv.FormatInt(workflowRepo.ID, 10),
+			"workflow_id":  "build.yml",
+			"target_repos": strconv.FormatInt(targetRepo.ID, 10),
+		})
+		session.MakeRequest(t, req, http.StatusBadRequest)
+		unittest.AssertNotExistsBean(t, &actions_model.ActionRequiredWorkflow{OwnerID: org3.ID, WorkflowID: "build.yml"})
+
+		req = NewRequestWithValues(t, "POST", settingsLink+"/new", map[string]string{
+			"repo_id":      strconv.FormatInt(workflowRepo.ID, 10),
+			"workflow_id":  "scan.yml",
+			"target_repos": strconv.FormatInt(targetRepo.ID, 10),
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+		required := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRequiredWorkflow{OwnerID: org3.ID, WorkflowID: "scan.yml"})
+		assert.Equal(t, []int64{targetRepo.ID}, required.RepoIDs)
+		session.MakeRequest(t, NewRequest(t, "GET", settingsLink), http.StatusOK)
+
+		// the repositories out of the organization can't be targeted
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("%s/%d/edit", settingsLink, required.ID), map[string]string{
+			"repo_id":      strconv.FormatInt(workflowRepo.ID, 10),
+			"workflow_id":  "scan.yml",
+			"target_repos": "1",
+		})
+		session.MakeRequest(t, req, http.StatusBadRequest)
+
+		// nothing is required before the workflow runs
+		enabled, contexts, err := pull_service.GetRequiredStatusChecks(t.Context(), targetRepo, nil, "")
+		require.NoError(t, err)
+		assert.False(t, enabled)
+		assert.Empty(t, contexts)
+
+		// the workflow runs on the pull requests of the target repository
+		require.NoError(t, repo_service.CreateNewBranch(t.Context(), user2, targetRepo, "main", "feature"))
+		_, err = files_service.ChangeRepoFiles(t.Context(), targetRepo, user2, &files_service.ChangeRepoFilesOptions{
+			Files: []*files_service.ChangeRepoFile{
+				{Operation: "create", TreePath: "feature.txt", ContentReader: strings.NewReader("feature")},
+			},
+			Message:   "add feature",
+			OldBranch: "feature",
+			NewBranch: "feature",
+		})
+		require.NoError(t, err)
+		pullIssue := &issues_model.Issue{RepoID: targetRepo.ID, Title: "A feature", PosterID: user2.ID, Poster: user2, IsPull: true}
+		pullRequest := &issues_model.PullRequest{
+			HeadRepoID: targetRepo.ID,
+			BaseRepoID: targetRepo.ID,
+			HeadBranch: "feature",
+			BaseBranch: "main",
+			HeadRepo:   targetRepo,
+			BaseRepo:   targetRepo,
+			Type:       issues_model.PullRequestGitea,
+		}
+		require.NoError(t, pull_service.NewPullRequest(t.Context(), &pull_service.NewPullRequestOptions{Repo: targetRepo, Issue: pullIssue, PullRequest: pullRequest}))
+		unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRun{RepoID: targetRepo.ID, WorkflowID: "scan.yml", RequiredWorkflowID: required.ID})
+		headCommitID, err := gitrepo.GetBranchCommitID(t.Context(), targetRepo, "feature")
+		require.NoError(t, err)
+
+		// the jobs of the workflow are required on the commits on which it runs, even if the branch isn't protected
+		enabled, contexts, err = pull_service.GetRequiredStatusChecks(t.Context(), targetRepo, nil, headCommitID)
+		require.NoError(t, err)
+		assert.True(t, enabled)
+		assert.Equal(t, []string{"required: security scan / *"}, contexts)
+
+		// the jobs of the workflow are required by the protected branches of the target repository
+		require.NoError(t, git_model.UpdateProtectBranch(t.Context(), targetRepo, &git_model.ProtectedBranch{
+			RepoID:   targetRepo.ID,
+			RuleName: "main",
+		}, git_model.WhitelistOptions{}))
+		pb, err := git_model.GetFirstMatchProtectedBranchRule(t.Context(), targetRepo.ID, "main")
+		require.NoError(t, err)
+		enabled, contexts, err = pull_service.GetRequiredStatusChecks(t.Context(), targetRepo, pb, headCommitID)
+		require.NoError(t, err)
+		assert.True(t, enabled)
+		assert.Equal(t, []string{"required: security scan / *"}, contexts)
+
+		pass, err := pull_service.IsPullCommitStatusPass(t.Context(), pullRequest)
+		require.NoError(t, err)
+		assert.False(t, pass)
+
+		// the statuses which aren't created by the required workflow can't satisfy it
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+		statusLink := fmt.Sprintf("/api/v1/repos/%s/statuses/%s", targetRepo.FullName(), headCommitID)
+		req = NewRequestWithJSON(t, "POST", statusLink, &api.CreateStatusOption{
+			State:   commitstatus.CommitStatusSuccess,
+			Context: "security scan / scan (pull_request)",
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+		req = NewRequestWithJSON(t, "POST", statusLink, &api.CreateStatusOption{
+			State:   commitstatus.CommitStatusSuccess,
+			Context: "required: security scan / scan (pull_request)",
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+		pass, err = pull_service.IsPullCommitStatusPass(t.Context(), pullRequest)
+		require.NoError(t, err)
+		assert.False(t, pass)
+
+		// it's not required anymore after it's deleted
+		req = NewRequest(t, "POST", fmt.Sprintf("%s/%d/delete", settingsLink, required.ID))
+		session.MakeRequest(t, req, http.StatusOK)
+		unittest.AssertNotExistsBean(t, &actions_model.ActionRequiredWorkflow{ID: required.ID})
+		pass, err = pull_service.IsPullCommitStatusPass(t.Context(), pullRequest)
+		require.NoError(t, err)
+		assert.True(t, pass)
+	})
+}
@@ -927,7 +927,7 @@ jobs:
 		assert.NoError(t, err)
 		inputs := &api.CreateActionWorkflowDispatch{
 			Ref: "main",
-			Inputs: map[string]string{
+			Inputs: map[string]any{
 				"myinput":  "val0",
 				"myinput3": "true",
 			},
@@ -1013,7 +1013,7 @@ jobs:
 		assert.NoError(t, err)
 		inputs := &api.CreateActionWorkflowDispatch{
 			Ref: "main",
-			Inputs: map[string]string{
+			Inputs: map[string]any{
 				"myinput":  "val0",
 				"myinput3": "true",
 			},
@@ -1043,6 +1043,118 @@ jobs:
 	})
 }
 
+func TestWorkflowDispatchPublicApiWithTypedInputs(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		session := loginUser(t, user2.Name)
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+
+		// create the repo
+		repo, err := repo_service.CreateRepository(t.Context(), user2, user2, repo_service.CreateRepoOptions{
+			Name:          "workflow-dispatch-typed-inputs",
+			Description:   "test workflow-dispatch ci event",
+			AutoInit:      true,
+			Gitignores:    "Go",
+			License:       "MIT",
+			Readme:        "Default",
+			DefaultBranch: "main",
+			IsPrivate:     false,
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, repo)
+
+		// add workflow file to the repo
+		addWorkflowToBaseResp, err := files_service.ChangeRepoFiles(t.Context(), repo, user2, &files_service.ChangeRepoFilesOptions{
+			Files: []*files_service.ChangeRepoFile{
+				{
+					Operation: "create",
+					TreePath:  ".gitea/workflows/dispatch.yml",
+					ContentReader: strings.NewReader(`
+on:
+  workflow_dispatch:
+    inputs:
+      name: { required: true }
+      debug: { type: boolean, default: false }
+      count: { type: number, default: 1 }
+      level: { type: choice, options: [low, high], default: low }
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo helloworld
+`),
+				},
+			},
+			Message:   "add workflow",
+			OldBranch: "main",
+			NewBranch: "main",
+			Author: &files_service.IdentityOptions{
+				GitUserName:  user2.Name,
+				GitUserEmail: user2.Email,
+			},
+			Committer: &files_service.IdentityOptions{
+				GitUserName:  user2.Name,
+				GitUserEmail: user2.Email,
+			},
+			Dates: &files_service.CommitDateOptions{
+				Author:    time.Now(),
+				Committer: time.Now(),
+			},
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, addWorkflowToBaseResp)
+
+		dispatchURL := fmt.Sprintf("/api/v1/repos/%s/actions/workflows/dispatch.yml/dispatches", repo.FullName())
+
+		// the required input is missing
+		req := NewRequestWithJSON(t, "POST", dispatchURL, &api.CreateActionWorkflowDispatch{
+			Ref:    "main",
+			Inputs: map[string]any{"debug": true},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		// the value isn't one of the options
+		req = NewRequestWithJSON(t, "POST", dispatchURL, &api.CreateActionWorkflowDispatch{
+			Ref:    "main",
+			Inputs: map[string]any{"name": "gitea", "level": "medium"},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "POST", dispatchURL, &api.CreateActionWorkflowDispatch{
+			Ref:              "main",
+			Inputs:           map[string]any{"name": "gitea", "debug": true, "count": 3, "level": "high"},
+			ReturnRunDetails: true,
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		dispatchRun := &api.ActionWorkflowDispatchRun{}
+		DecodeJSON(t, resp, dispatchRun)
+
+		run := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRun{ID: dispatchRun.WorkflowRunID})
+		assert.Equal(t, repo.ID, run.RepoID)
+		assert.Equal(t, fmt.Sprintf("%s/actions/runs/%d", repo.APIURL(), run.ID), dispatchRun.RunURL)
+		assert.Equal(t, fmt.Sprintf("%s/actions/runs/%d", repo.HTMLURL(), run.Index), dispatchRun.HTMLURL)
+		dispatchPayload := &api.WorkflowDispatchPayload{}
+		assert.NoError(t, json.Unmarshal([]byte(run.EventPayload), dispatchPayload))
+		assert.Equal(t, map[string]any{"name": "gitea", "debug": "true", "count": "3", "level": "high"}, dispatchPayload.Inputs)
+
+		// cancel the run, and rerun its failed jobs
+		runURL := fmt.Sprintf("/api/v1/repos/%s/actions/runs/%d", repo.FullName(), run.ID)
+		req = NewRequest(t, "POST", runURL+"/rerun-failed-jobs").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+		req = NewRequest(t, "POST", runURL+"/cancel").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "POST", runURL+"/cancel").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+		job := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{RunID: run.ID})
+		assert.Equal(t, actions_model.StatusCancelled, job.Status)
+
+		req = NewRequest(t, "POST", runURL+"/rerun-failed-jobs").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		job = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID})
+		assert.Equal(t, actions_model.StatusWaiting, job.Status)
+	})
+}
+
 func TestWorkflowDispatchPublicApiWithInputsNonDefaultBranchJSON(t *testing.T) {
 	onGiteaRun(t, func(t *testing.T, u *url.URL) {
 		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
@@ -1143,7 +1255,7 @@ jobs:
 		assert.NoError(t, err)
 		inputs := &api.CreateActionWorkflowDispatch{
 			Ref: "refs/heads/dispatch",
-			Inputs: map[string]string{
+			Inputs: map[string]any{
 				"myinput":  "val0",
 				"myinput3": "true",
 			},
@@ -1293,7 +1405,7 @@ jobs:
 
 		inputs := &api.CreateActionWorkflowDispatch{
 			Ref: "main",
-			Inputs: map[string]string{
+			Inputs: map[string]any{
 				"myinput":  "val0",
 				"myinput3": "true",
 			},
@@ -1341,7 +1453,7 @@ jobs:
 		assert.NoError(t, err)
 		inputs = &api.CreateActionWorkflowDispatch{
 			Ref: "main",
-			Inputs: map[string]string{
+			Inputs: map[string]any{
 				"myinput":  "val0",
 				"myinput3": "true",
 			},
@@ -0,0 +1,104 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIActionsRunnerGroup(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteOrganization)
+	urlPrefix := "/api/v1/orgs/org3/actions/runner-groups"
+
+	// repo 3 belongs to org3, repo 1 doesn't
+	req := NewRequestWithJSON(t, "POST", urlPrefix, &api.CreateActionRunnerGroupOption{
+		Name:          "deploy",
+		RepositoryIDs: []int64{1},
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", urlPrefix, &api.CreateActionRunnerGroupOption{
+		Name:          "deploy",
+		RepositoryIDs: []int64{3},
+		BranchFilters: []string{"main"},
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	group := api.ActionRunnerGroup{}
+	DecodeJSON(t, resp, &group)
+	assert.Equal(t, "deploy", group.Name)
+	assert.Equal(t, []int64{3}, group.RepositoryIDs)
+	assert.Empty(t, group.Workflows)
+
+	req = NewRequestWithJSON(t, "POST", urlPrefix, &api.CreateActionRunnerGroupOption{Name: "Deploy"}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusConflict)
+
+	workflows := []string{"deploy.yml"}
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("%s/%d", urlPrefix, group.ID), &api.EditActionRunnerGroupOption{Workflows: workflows}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &group)
+	assert.Equal(t, workflows, group.Workflows)
+	assert.Equal(t, []string{"main"}, group.BranchFilters)
+
+	req = NewRequest(t, "GET", urlPrefix).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	groups := api.ActionRunnerGroupsResponse{}
+	DecodeJSON(t, resp, &groups)
+	assert.EqualValues(t, 1, groups.TotalCount)
+
+	// runner 34347 belongs to org3, runner 34346 belongs to user1
+	req = NewRequest(t, "PUT", fmt.Sprintf("%s/%d/runners/%d", urlPrefix, group.ID, 34347)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	req = NewRequest(t, "PUT", fmt.Sprintf("%s/%d/runners/%d", urlPrefix, group.ID, 34346)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+
+	req = NewRequest(t, "GET", fmt.Sprintf("%s/%d/runners", urlPrefix, group.ID)).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	runners := api.ActionRunnersResponse{}
+	DecodeJSON(t, resp, &runners)
+	if assert.Len(t, runners.Entries, 1) {
+		assert.EqualValues(t, 34347, runners.Entries[0].ID)
+		assert.Equal(t, group.ID, runners.Entries[0].GroupID)
+	}
+
+	// the runners are moved out of the deleted group
+	req = NewRequest(t, "DELETE", fmt.Sprintf("%s/%d", urlPrefix, group.ID)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/orgs/org3/actions/runners/%d", 34347)).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	runner := api.ActionRunner{}
+	DecodeJSON(t, resp, &runner)
+	assert.Zero(t, runner.GroupID)
+
+	// only the site admin could manage the instance-level groups
+	req = NewRequest(t, "GET", "/api/v1/admin/actions/runner-groups").AddTokenAuth(getUserToken(t, "user2", auth_model.AccessTokenScopeWriteAdmin))
+	MakeRequest(t, req, http.StatusForbidden)
+	adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+	req = NewRequestWithJSON(t, "POST", "/api/v1/admin/actions/runner-groups", &api.CreateActionRunnerGroupOption{
+		Name:            "global",
+		AllRepositories: true,
+	}).AddTokenAuth(adminToken)
+	resp = MakeRequest(t, req, http.StatusCreated)
+	DecodeJSON(t, resp, &group)
+	// runner 34349 is a global runner, runner 34347 isn't
+	req = NewRequest(t, "PUT", fmt.Sprintf("/api/v1/admin/actions/runner-groups/%d/runners/%d", group.ID, 34347)).AddTokenAuth(adminToken)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequest(t, "PUT", fmt.Sprintf("/api/v1/admin/actions/runner-groups/%d/runners/%d", group.ID, 34349)).AddTokenAuth(adminToken)
+	MakeRequest(t, req, http.StatusNoContent)
+	// the group can't be deleted while it contains runners
+	groupLink := fmt.Sprintf("/api/v1/admin/actions/runner-groups/%d", group.ID)
+	MakeRequest(t, NewRequest(t, "DELETE", groupLink).AddTokenAuth(adminToken), http.StatusUnprocessableEntity)
+	req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/admin/actions/runner-groups/%d/runners/%d", group.ID, 34349)).AddTokenAuth(adminToken)
+	MakeRequest(t, req, http.StatusNoContent)
+	MakeRequest(t, NewRequest(t, "DELETE", groupLink).AddTokenAuth(adminToken), http.StatusNoContent)
+}
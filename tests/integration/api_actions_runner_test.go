@@ -11,6 +11,7 @@ import (
 
 	auth_model "code.gitea.io/gitea/models/auth"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -297,6 +298,59 @@ func testActionsRunnerRepo(t *testing.T) {
 		MakeRequest(t, req, http.StatusNotFound)
 	})
 
+	t.Run("GenerateJITConfig", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/actions/runners/generate-jitconfig", &api.GenerateActionRunnerJITConfigOption{
+			Name:   "jit-runner",
+			Labels: []string{"ubuntu-latest:docker://node:20-bookworm"},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		jitConfig := api.ActionRunnerJITConfig{}
+		DecodeJSON(t, resp, &jitConfig)
+		assert.Equal(t, "jit-runner", jitConfig.Runner.Name)
+		assert.True(t, jitConfig.Runner.Ephemeral)
+		assert.NotEmpty(t, jitConfig.EncodedJITConfig)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/user2/repo1/actions/runners/%d", jitConfig.Runner.ID)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		// the labels are required
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/actions/runners/generate-jitconfig", &api.GenerateActionRunnerJITConfigOption{
+			Name: "jit-runner",
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		// the credentials of the config must not be reusable, so the runner has to be ephemeral
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/actions/runners/generate-jitconfig", &api.GenerateActionRunnerJITConfigOption{
+			Name:      "jit-runner",
+			Labels:    []string{"ubuntu-latest"},
+			Ephemeral: util.ToPointer(false),
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		// it's forbidden with read scope
+		readToken := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/actions/runners/generate-jitconfig", &api.GenerateActionRunnerJITConfigOption{
+			Name:   "jit-runner",
+			Labels: []string{"ubuntu-latest"},
+		}).AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Queue", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/actions/runners/queue").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		queues := api.ActionRunnerQueuesResponse{}
+		DecodeJSON(t, resp, &queues)
+		var total int64
+		for _, queue := range queues.Entries {
+			assert.NotEmpty(t, queue.Labels)
+			total += queue.PendingJobs
+		}
+		assert.Equal(t, queues.TotalPendingJobs, total)
+	})
+
 	t.Run("DeleteReadScopeForbidden", func(t *testing.T) {
 		userUsername := "user2"
 		token := getUserToken(t, userUsername, auth_model.AccessTokenScopeReadRepository)
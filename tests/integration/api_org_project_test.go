@@ -0,0 +1,151 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIOrgProjectItems(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	project := &project_model.Project{
+		Title:        "org project",
+		OwnerID:      3,
+		Type:         project_model.TypeOrganization,
+		TemplateType: project_model.TemplateTypeBasicKanban,
+		CreatorID:    user2.ID,
+	}
+	require.NoError(t, project_model.NewProject(t.Context(), project))
+
+	// issue 6 belongs to the private repo3, issue 16 to the public repo21
+	for _, issueID := range []int64{6, 16} {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issueID})
+		require.NoError(t, issues_model.IssueAssignOrRemoveProject(t.Context(), issue, user2, project.ID, 0))
+	}
+
+	link := fmt.Sprintf("/api/v1/orgs/org3/projects/%d/items", project.ID)
+
+	listItems := func(t *testing.T, req *RequestWrapper) []int64 {
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var items []*api.ProjectItem
+		DecodeJSON(t, resp, &items)
+
+		issueIDs := make([]int64, 0, len(items))
+		for _, item := range items {
+			issueIDs = append(issueIDs, item.IssueID)
+		}
+		return issueIDs
+	}
+
+	t.Run("Anonymous", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		assert.ElementsMatch(t, []int64{16}, listItems(t, NewRequest(t, "GET", link)))
+	})
+
+	t.Run("PublicOnlyToken", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := getUserToken(t, user2.Name, auth_model.AccessTokenScopeReadOrganization, auth_model.AccessTokenScopeReadIssue, auth_model.AccessTokenScopePublicOnly)
+		assert.ElementsMatch(t, []int64{16}, listItems(t, NewRequest(t, "GET", link).AddTokenAuth(token)))
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := getUserToken(t, "user1", auth_model.AccessTokenScopeReadOrganization, auth_model.AccessTokenScopeReadIssue)
+		assert.ElementsMatch(t, []int64{6, 16}, listItems(t, NewRequest(t, "GET", link).AddTokenAuth(token)))
+	})
+
+	t.Run("NoProjectsUnit", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// user15 is only in a team of org3 without the projects unit
+		token := getUserToken(t, "user15", auth_model.AccessTokenScopeReadOrganization, auth_model.AccessTokenScopeReadIssue)
+		MakeRequest(t, NewRequest(t, "GET", link).AddTokenAuth(token), http.StatusNotFound)
+	})
+
+	t.Run("SetFieldValue", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		field := &project_model.Field{ProjectID: project.ID, Name: "Notes", Type: project_model.FieldTypeText}
+		require.NoError(t, project_model.CreateField(t.Context(), field))
+		fieldLink := func(issueID int64) string {
+			return fmt.Sprintf("%s/%d/fields/%d", link, issueID, field.ID)
+		}
+
+		token := getUserToken(t, user2.Name, auth_model.AccessTokenScopeWriteOrganization)
+		req := NewRequestWithJSON(t, "PUT", fieldLink(16), &api.SetProjectFieldValueOption{Value: "reviewed"}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var value api.ProjectFieldValue
+		DecodeJSON(t, resp, &value)
+		assert.Equal(t, "reviewed", value.Value)
+
+		// issue 1 doesn't belong to a repository of org3
+		req = NewRequestWithJSON(t, "PUT", fieldLink(1), &api.SetProjectFieldValueOption{Value: "reviewed"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithJSON(t, "PUT", fieldLink(16), &api.SetProjectFieldValueOption{}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		token = getUserToken(t, "user15", auth_model.AccessTokenScopeWriteOrganization)
+		req = NewRequestWithJSON(t, "PUT", fieldLink(16), &api.SetProjectFieldValueOption{Value: "reviewed"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
+
+func TestOrgProjectTableViews(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	project := &project_model.Project{
+		Title:        "org project",
+		OwnerID:      3,
+		Type:         project_model.TypeOrganization,
+		TemplateType: project_model.TemplateTypeBasicKanban,
+		CreatorID:    user2.ID,
+	}
+	require.NoError(t, project_model.NewProject(t.Context(), project))
+
+	// issue 6 belongs to the private repo3, issue 16 to the public repo21
+	for _, issueID := range []int64{6, 16} {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issueID})
+		require.NoError(t, issues_model.IssueAssignOrRemoveProject(t.Context(), issue, user2, project.ID, 0))
+	}
+
+	session := loginUser(t, user2.Name)
+	for _, view := range []string{"table", "roadmap"} {
+		t.Run(view, func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			link := fmt.Sprintf("/org3/-/projects/%d/%s", project.ID, view)
+
+			resp := MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "just a normal issue")
+			assert.NotContains(t, resp.Body.String(), "issue6")
+
+			resp = session.MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "just a normal issue")
+			assert.Contains(t, resp.Body.String(), "issue6")
+		})
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	attestation_module "code.gitea.io/gitea/modules/packages/attestation"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAttestation(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	session := loginUser(t, user.Name)
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	sign := func(t *testing.T, content []byte) (string, string) {
+		sum := sha256.Sum256(content)
+		sig, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+		require.NoError(t, err)
+		return hex.EncodeToString(sum[:]), base64.StdEncoding.EncodeToString(sig)
+	}
+
+	attestationsURL := fmt.Sprintf("/api/v1/packages/%s/-/attestations", user.Name)
+	packageURL := fmt.Sprintf("/api/packages/%s/generic/signed/1.0.0", user.Name)
+
+	content := []byte("signed package content")
+	digest, signature := sign(t, content)
+
+	var attestation *api.PackageAttestation
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "POST", attestationsURL, strings.NewReader(signature)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "POST", attestationsURL+"?sha256="+digest, strings.NewReader("invalid")).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "POST", attestationsURL+"?sha256="+digest, strings.NewReader(signature)).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		attestation = &api.PackageAttestation{}
+		DecodeJSON(t, resp, attestation)
+		assert.Equal(t, attestation_module.FormatSignature, attestation.Format)
+		assert.Equal(t, []string{digest}, attestation.Subjects)
+		assert.Equal(t, string(attestation_module.StatusUnverified), attestation.Status)
+		assert.Equal(t, user.Name, attestation.Creator.UserName)
+
+		req = NewRequestWithBody(t, "POST", attestationsURL+"?sha256="+digest, strings.NewReader(signature)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		// an envelope signed by the key which doesn't name its subjects can't be replayed for any file
+		envelopePayload := []byte("signed by a trusted key")
+		pae := attestation_module.PAE("text/plain", envelopePayload)
+		_, envelopeSignature := sign(t, pae)
+		envelope := fmt.Sprintf(`{"payloadType":"text/plain","payload":%q,"signatures":[{"keyid":"","sig":%q}]}`, base64.StdEncoding.EncodeToString(envelopePayload), envelopeSignature)
+		otherDigest := fmt.Sprintf("%x", sha256.Sum256([]byte("other package content")))
+		req = NewRequestWithBody(t, "POST", attestationsURL+"?sha256="+otherDigest, strings.NewReader(envelope)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("TrustedKeys", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		settingsURL := "/user/settings/packages/signing"
+
+		req := NewRequestWithValues(t, "POST", settingsURL, map[string]string{
+			"trusted_keys": "invalid",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		keys, err := user_model.GetSetting(t.Context(), user.ID, attestation_module.SettingKeyTrustedKeys)
+		assert.Error(t, err)
+		assert.Empty(t, keys)
+
+		req = NewRequestWithValues(t, "POST", settingsURL, map[string]string{
+			"trusted_keys": publicKey,
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/%d", attestationsURL, attestation.ID)).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		DecodeJSON(t, resp, attestation)
+		assert.Equal(t, string(attestation_module.StatusVerified), attestation.Status)
+		assert.Equal(t, attestation_module.Fingerprint(key.Public()), attestation.KeyFingerprint)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/%d/content", attestationsURL, attestation.ID)).
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, signature, resp.Body.String())
+	})
+
+	t.Run("RequireSignature", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithValues(t, "POST", "/user/settings/packages/signing", map[string]string{
+			"trusted_keys": publicKey,
+			"required":     "on",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		req = NewRequestWithBody(t, "PUT", packageURL+"/unsigned.bin", bytes.NewReader([]byte("unsigned package content"))).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithBody(t, "PUT", packageURL+"/signed.bin", bytes.NewReader(content)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/generic/signed/1.0.0/attestations", user.Name)).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var attestations []*api.PackageAttestation
+		DecodeJSON(t, resp, &attestations)
+		assert.Len(t, attestations, 1)
+		assert.Equal(t, attestation.ID, attestations[0].ID)
+		assert.Equal(t, string(attestation_module.StatusVerified), attestations[0].Status)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/generic/signed/1.0.0", user.Name))
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "Verified")
+
+		require.NoError(t, user_model.SetUserSetting(t.Context(), user.ID, attestation_module.SettingKeyRequired, "false"))
+	})
+
+	t.Run("CosignContainerSignature", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", setting.AppURL+"v2/token").
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		tokenResponse := &struct {
+			Token string `json:"token"`
+		}{}
+		DecodeJSON(t, resp, tokenResponse)
+		containerToken := "Bearer " + tokenResponse.Token
+
+		imageDigest := fmt.Sprintf("%x", sha256.Sum256([]byte("image manifest")))
+		payload := fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"localhost/%s/image"},"image":{"docker-manifest-digest":"sha256:%s"},"type":"cosign container image signature"},"optional":null}`, user.Name, imageDigest)
+		payloadDigest, payloadSignature := sign(t, []byte(payload))
+		config := `{"architecture":"","created":"0001-01-01T00:00:00Z","history":[{"created":"0001-01-01T00:00:00Z"}],"os":"","rootfs":{"type":"layers","diff_ids":["sha256:` + payloadDigest + `"]},"config":{}}`
+		configDigest := fmt.Sprintf("%x", sha256.Sum256([]byte(config)))
+
+		imageURL := fmt.Sprintf("%sv2/%s/image", setting.AppURL, user.Name)
+		for digest, blob := range map[string]string{payloadDigest: payload, configDigest: config} {
+			req = NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=sha256:%s", imageURL, digest), strings.NewReader(blob)).
+				AddTokenAuth(containerToken)
+			MakeRequest(t, req, http.StatusCreated)
+		}
+
+		manifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","size":%d,"digest":"sha256:%s"},"layers":[{"mediaType":%q,"size":%d,"digest":"sha256:%s","annotations":{%q:%q}}]}`,
+			len(config), configDigest, attestation_module.MediaTypeCosignSimpleSigning, len(payload), payloadDigest, attestation_module.AnnotationCosignSignature, payloadSignature)
+		req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/sha256-%s.sig", imageURL, url.PathEscape(imageDigest)), strings.NewReader(manifest)).
+			AddTokenAuth(containerToken).
+			SetHeader("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		MakeRequest(t, req, http.StatusCreated)
+
+		pas, err := packages_model.GetAttestationsBySubjects(t.Context(), user.ID, []string{imageDigest})
+		assert.NoError(t, err)
+		assert.Len(t, pas, 1)
+		assert.Equal(t, attestation_module.FormatCosign, pas[0].Format)
+	})
+
+	t.Run("RequireContainerSignature", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		require.NoError(t, user_model.SetUserSetting(t.Context(), user.ID, attestation_module.SettingKeyRequired, "true"))
+		defer func() {
+			require.NoError(t, user_model.SetUserSetting(t.Context(), user.ID, attestation_module.SettingKeyRequired, "false"))
+		}()
+
+		req := NewRequest(t, "GET", setting.AppURL+"v2/token").
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		tokenResponse := &struct {
+			Token string `json:"token"`
+		}{}
+		DecodeJSON(t, resp, tokenResponse)
+		containerToken := "Bearer " + tokenResponse.Token
+
+		imageURL := fmt.Sprintf("%sv2/%s/signed-image", setting.AppURL, user.Name)
+
+		config := `{"architecture":"amd64","os":"linux","rootfs":{"type":"layers","diff_ids":[]},"config":{}}`
+		configDigest := fmt.Sprintf("%x", sha256.Sum256([]byte(config)))
+		req = NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=sha256:%s", imageURL, configDigest), strings.NewReader(config)).
+			AddTokenAuth(containerToken)
+		MakeRequest(t, req, http.StatusCreated)
+
+		manifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","size":%d,"digest":"sha256:%s"},"layers":[]}`, len(config), configDigest)
+		req = NewRequestWithBody(t, "PUT", imageURL+"/manifests/latest", strings.NewReader(manifest)).
+			AddTokenAuth(containerToken).
+			SetHeader("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		MakeRequest(t, req, http.StatusCreated)
+
+		manifestDigest, manifestSignature := sign(t, []byte(manifest))
+
+		// the unsigned image can be pulled by its digest to sign it, but its tag doesn't resolve
+		for _, method := range []string{"HEAD", "GET"} {
+			req = NewRequest(t, method, imageURL+"/manifests/latest").
+				AddTokenAuth(containerToken)
+			MakeRequest(t, req, http.StatusForbidden)
+
+			req = NewRequest(t, method, imageURL+"/manifests/sha256:"+manifestDigest).
+				AddTokenAuth(containerToken)
+			MakeRequest(t, req, http.StatusOK)
+		}
+
+		req = NewRequestWithBody(t, "POST", attestationsURL+"?sha256="+manifestDigest, strings.NewReader(manifestSignature)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", imageURL+"/manifests/latest").
+			AddTokenAuth(containerToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "sha256:"+manifestDigest, resp.Header().Get("Docker-Content-Digest"))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", fmt.Sprintf("%s/%d", attestationsURL, attestation.ID)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/%d", attestationsURL, attestation.ID)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		unittest.AssertNotExistsBean(t, &packages_model.PackageAttestationSubject{AttestationID: attestation.ID})
+	})
+}
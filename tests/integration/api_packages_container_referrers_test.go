@@ -0,0 +1,211 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	"code.gitea.io/gitea/modules/setting"
+	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+	"code.gitea.io/gitea/tests"
+
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageContainerReferrers(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	req := NewRequest(t, "GET", setting.AppURL+"v2/token").
+		AddBasicAuth(user.Name)
+	resp := MakeRequest(t, req, http.StatusOK)
+
+	tokenResponse := &struct {
+		Token string `json:"token"`
+	}{}
+	DecodeJSON(t, resp, tokenResponse)
+	token := "Bearer " + tokenResponse.Token
+
+	image := "signed"
+	imageURL := fmt.Sprintf("%sv2/%s/%s", setting.AppURL, user.Name, image)
+
+	sha256Digest := func(content string) string {
+		return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(content)))
+	}
+
+	uploadBlob := func(t *testing.T, content string) {
+		req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", imageURL, sha256Digest(content)), strings.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+
+	uploadManifest := func(t *testing.T, reference, content string) *http.Response {
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", imageURL, reference), strings.NewReader(content)).
+			AddTokenAuth(token).
+			SetHeader("Content-Type", oci.MediaTypeImageManifest)
+		return MakeRequest(t, req, http.StatusCreated).Result()
+	}
+
+	configContent := `{"architecture":"amd64","os":"linux","config":{}}`
+	layerContent := "layer"
+	imageManifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"config":{"mediaType":%q,"digest":%q,"size":%d},"layers":[{"mediaType":%q,"digest":%q,"size":%d}]}`,
+		oci.MediaTypeImageManifest, oci.MediaTypeImageConfig, sha256Digest(configContent), len(configContent), oci.MediaTypeImageLayerGzip, sha256Digest(layerContent), len(layerContent))
+	imageDigest := sha256Digest(imageManifest)
+
+	emptyContent := `{}`
+	sbomContent := `{"spdxVersion":"SPDX-2.3"}`
+	sbomArtifactType := "application/spdx+json"
+	sbomManifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"artifactType":%q,"config":{"mediaType":%q,"digest":%q,"size":%d},"layers":[{"mediaType":%q,"digest":%q,"size":%d}],"subject":{"mediaType":%q,"digest":%q,"size":%d},"annotations":{"org.opencontainers.image.created":"2025-01-01T00:00:00Z"}}`,
+		oci.MediaTypeImageManifest, sbomArtifactType, oci.MediaTypeEmptyJSON, sha256Digest(emptyContent), len(emptyContent), sbomArtifactType, sha256Digest(sbomContent), len(sbomContent), oci.MediaTypeImageManifest, imageDigest, len(imageManifest))
+	sbomDigest := sha256Digest(sbomManifest)
+
+	signatureContent := `{"signature":"dummy"}`
+	signatureArtifactType := "application/vnd.example.signature.v1+json"
+	signatureManifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"config":{"mediaType":%q,"digest":%q,"size":%d},"layers":[{"mediaType":"application/json","digest":%q,"size":%d}],"subject":{"mediaType":%q,"digest":%q,"size":%d}}`,
+		oci.MediaTypeImageManifest, signatureArtifactType, sha256Digest(emptyContent), len(emptyContent), sha256Digest(signatureContent), len(signatureContent), oci.MediaTypeImageManifest, imageDigest, len(imageManifest))
+	signatureDigest := sha256Digest(signatureManifest)
+
+	for _, content := range []string{configContent, layerContent, emptyContent, sbomContent, signatureContent} {
+		uploadBlob(t, content)
+	}
+
+	t.Run("UploadManifests", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := uploadManifest(t, "v1", imageManifest)
+		assert.Empty(t, resp.Header.Get("OCI-Subject"))
+
+		resp = uploadManifest(t, sbomDigest, sbomManifest)
+		assert.Equal(t, imageDigest, resp.Header.Get("OCI-Subject"))
+
+		pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeContainer, image, sbomDigest)
+		assert.NoError(t, err)
+
+		pd, err := packages_model.GetPackageDescriptor(t.Context(), pv)
+		assert.NoError(t, err)
+		assert.Equal(t, imageDigest, pd.VersionProperties.GetByName(container_module.PropertyManifestSubject))
+
+		metadata := pd.Metadata.(*container_module.Metadata)
+		assert.Equal(t, sbomArtifactType, metadata.ArtifactType)
+		assert.Equal(t, imageDigest, metadata.Subject)
+		assert.Equal(t, "unknown/unknown", metadata.Platform)
+
+		// the subject does not need to exist
+		resp = uploadManifest(t, signatureDigest, signatureManifest)
+		assert.Equal(t, imageDigest, resp.Header.Get("OCI-Subject"))
+	})
+
+	t.Run("GetReferrers", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s", imageURL, imageDigest)).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		assert.Equal(t, oci.MediaTypeImageIndex, resp.Header().Get("Content-Type"))
+		assert.Empty(t, resp.Header().Get("OCI-Filters-Applied"))
+
+		var index oci.Index
+		DecodeJSON(t, resp, &index)
+		assert.Equal(t, 2, index.SchemaVersion)
+		assert.Equal(t, oci.MediaTypeImageIndex, index.MediaType)
+		assert.Len(t, index.Manifests, 2)
+
+		descriptors := make(map[string]oci.Descriptor)
+		for _, d := range index.Manifests {
+			descriptors[d.Digest.String()] = d
+		}
+		assert.Equal(t, oci.MediaTypeImageManifest, descriptors[sbomDigest].MediaType)
+		assert.EqualValues(t, len(sbomManifest), descriptors[sbomDigest].Size)
+		assert.Equal(t, sbomArtifactType, descriptors[sbomDigest].ArtifactType)
+		assert.Equal(t, map[string]string{"org.opencontainers.image.created": "2025-01-01T00:00:00Z"}, descriptors[sbomDigest].Annotations)
+		// without artifactType the media type of the config is used
+		assert.Equal(t, signatureArtifactType, descriptors[signatureDigest].ArtifactType)
+
+		t.Run("FilterArtifactType", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s?artifactType=%s", imageURL, imageDigest, url.QueryEscape(sbomArtifactType))).
+				AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			assert.Equal(t, "artifactType", resp.Header().Get("OCI-Filters-Applied"))
+
+			var index oci.Index
+			DecodeJSON(t, resp, &index)
+			assert.Len(t, index.Manifests, 1)
+			assert.Equal(t, sbomDigest, index.Manifests[0].Digest.String())
+		})
+
+		t.Run("UnknownSubject", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s", imageURL, sbomDigest)).
+				AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var index oci.Index
+			DecodeJSON(t, resp, &index)
+			assert.Empty(t, index.Manifests)
+
+			req = NewRequest(t, "GET", imageURL+"/referrers/invalid").
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+		})
+	})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, user.Name)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/container/%s/v1", user.Name, image))
+		resp := session.MakeRequest(t, req, http.StatusOK)
+
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 1, htmlDoc.Find(fmt.Sprintf(`a[href$="/%s"]`, sbomDigest)).Length())
+		assert.Equal(t, 1, htmlDoc.Find(fmt.Sprintf(`a[href$="/%s"]`, signatureDigest)).Length())
+	})
+
+	t.Run("Cleanup", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		ageVersions := func(t *testing.T) {
+			_, err := db.GetEngine(t.Context()).Exec("UPDATE package_version SET created_unix = ?", time.Now().Add(-2*time.Hour).Unix())
+			assert.NoError(t, err)
+		}
+
+		ageVersions(t)
+
+		// referrers are kept as long as their subject exists
+		assert.NoError(t, packages_cleanup_service.CleanupTask(t.Context(), time.Hour))
+
+		_, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeContainer, image, sbomDigest)
+		assert.NoError(t, err)
+
+		req := NewRequest(t, "DELETE", fmt.Sprintf("%s/manifests/%s", imageURL, imageDigest)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusAccepted)
+
+		assert.NoError(t, packages_cleanup_service.CleanupTask(t.Context(), time.Hour))
+
+		for _, digest := range []string{sbomDigest, signatureDigest} {
+			_, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeContainer, image, digest)
+			assert.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+		}
+	})
+}
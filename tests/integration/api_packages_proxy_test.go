@@ -0,0 +1,387 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageProxy(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Packages.ProxyAllowedHostList, "loopback")()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	upstreamFiles := map[string][]byte{}
+	upstreamRequests := map[string]int{}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamRequests[r.URL.EscapedPath()]++
+
+		// the container registry requires a bearer token
+		if strings.HasPrefix(r.URL.Path, "/v2/token") {
+			_, _ = w.Write([]byte(`{"token":"upstream-token"}`))
+			return
+		}
+		if strings.Contains(r.URL.Path, "/v2/") && r.Header.Get("Authorization") != "Bearer upstream-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/v2/token",service="upstream",scope="repository:library/proxy-test:pull"`, r.Host))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		data, ok := upstreamFiles[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer upstream.Close()
+
+	createProxy := func(t *testing.T, packageType packages_model.Type, url string) *packages_model.PackageProxy {
+		pp, err := packages_model.InsertProxy(t.Context(), &packages_model.PackageProxy{
+			Enabled: true,
+			OwnerID: user.ID,
+			Type:    packageType,
+			URL:     url,
+		})
+		require.NoError(t, err)
+		return pp
+	}
+
+	assertProxiedVersion := func(t *testing.T, packageType packages_model.Type, name, version string) *packages_model.PackageVersion {
+		pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packageType, name, version)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, pv.CreatorID)
+		pvps, err := packages_model.GetPropertiesByName(t.Context(), packages_model.PropertyTypeVersion, pv.ID, packages_model.PropertyProxyUpstream)
+		require.NoError(t, err)
+		require.Len(t, pvps, 1)
+		return pv
+	}
+
+	t.Run("Npm", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		tarball := []byte("npm tarball content")
+		hashSHA1 := sha1.Sum(tarball)
+		hashSHA512 := sha512.Sum512(tarball)
+		upstreamFiles["/proxy-test"] = []byte(`{
+			"_id": "proxy-test",
+			"name": "proxy-test",
+			"dist-tags": {"latest": "1.0.0"},
+			"versions": {
+				"1.0.0": {
+					"name": "proxy-test",
+					"version": "1.0.0",
+					"description": "Proxied package",
+					"dist": {
+						"shasum": "` + hex.EncodeToString(hashSHA1[:]) + `",
+						"integrity": "sha512-` + base64.StdEncoding.EncodeToString(hashSHA512[:]) + `",
+						"tarball": "` + upstream.URL + `/proxy-test/-/proxy-test-1.0.0.tgz"
+					}
+				}
+			}
+		}`)
+		upstreamFiles["/proxy-test/-/proxy-test-1.0.0.tgz"] = tarball
+
+		root := fmt.Sprintf("/api/packages/%s/npm", user.Name)
+
+		req := NewRequest(t, "GET", root+"/proxy-test").AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		createProxy(t, packages_model.TypeNpm, upstream.URL)
+
+		req = NewRequest(t, "GET", root+"/proxy-test").AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var metadata struct {
+			DistTags map[string]string `json:"dist-tags"`
+			Versions map[string]struct {
+				Dist struct {
+					Tarball string `json:"tarball"`
+				} `json:"dist"`
+			} `json:"versions"`
+		}
+		DecodeJSON(t, resp, &metadata)
+		assert.Equal(t, "1.0.0", metadata.DistTags["latest"])
+		require.Contains(t, metadata.Versions, "1.0.0")
+		tarballURL := metadata.Versions["1.0.0"].Dist.Tarball
+		assert.True(t, strings.HasPrefix(tarballURL, setting.AppURL+"api/packages/"+user.Name+"/npm/"))
+
+		req = NewRequest(t, "GET", tarballURL).AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, tarball, resp.Body.Bytes())
+
+		assertProxiedVersion(t, packages_model.TypeNpm, "proxy-test", "1.0.0")
+
+		// the stored version is served without requesting the upstream registry
+		requests := upstreamRequests["/proxy-test/-/proxy-test-1.0.0.tgz"]
+		req = NewRequest(t, "GET", tarballURL).AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, requests, upstreamRequests["/proxy-test/-/proxy-test-1.0.0.tgz"])
+
+		req = NewRequest(t, "GET", root+"/proxy-test/-/2.0.0/proxy-test-2.0.0.tgz").AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		t.Run("IntegrityMismatch", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			upstreamFiles["/proxy-test-invalid"] = []byte(`{
+				"name": "proxy-test-invalid",
+				"versions": {
+					"1.0.0": {
+						"name": "proxy-test-invalid",
+						"version": "1.0.0",
+						"dist": {
+							"integrity": "sha512-` + base64.StdEncoding.EncodeToString(hashSHA512[:]) + `",
+							"tarball": "` + upstream.URL + `/proxy-test-invalid/-/proxy-test-invalid-1.0.0.tgz"
+						}
+					}
+				}
+			}`)
+			upstreamFiles["/proxy-test-invalid/-/proxy-test-invalid-1.0.0.tgz"] = []byte("other content")
+
+			req := NewRequest(t, "GET", root+"/proxy-test-invalid/-/1.0.0/proxy-test-invalid-1.0.0.tgz").AddBasicAuth(user.Name)
+			MakeRequest(t, req, http.StatusBadGateway)
+
+			_, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeNpm, "proxy-test-invalid", "1.0.0")
+			assert.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+		})
+	})
+
+	t.Run("PyPI", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		content := []byte("pypi package content")
+		hashSHA256 := sha256.Sum256(content)
+		file := `{
+			"filename": "proxy_test-1.0.0.tar.gz",
+			"url": "` + upstream.URL + `/packages/proxy_test-1.0.0.tar.gz",
+			"digests": {"sha256": "` + hex.EncodeToString(hashSHA256[:]) + `"},
+			"requires_python": ">=3.8"
+		}`
+		upstreamFiles["/pypi/proxy-test/json"] = []byte(`{"info": {"name": "proxy-test"}, "releases": {"1.0.0": [` + file + `]}}`)
+		upstreamFiles["/pypi/proxy-test/1.0.0/json"] = []byte(`{"info": {"name": "proxy-test", "summary": "Proxied package"}, "urls": [` + file + `]}`)
+		upstreamFiles["/packages/proxy_test-1.0.0.tar.gz"] = content
+
+		createProxy(t, packages_model.TypePyPI, upstream.URL)
+
+		root := fmt.Sprintf("/api/packages/%s/pypi", user.Name)
+
+		req := NewRequest(t, "GET", root+"/simple/proxy-test").AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		link, _ := htmlDoc.Find("a").Attr("href")
+		assert.Equal(t, fmt.Sprintf("%s%s/files/proxy-test/1.0.0/proxy_test-1.0.0.tar.gz#sha256=%x", setting.AppURL, root[1:], hashSHA256), link)
+		requiresPython, _ := htmlDoc.Find("a").Attr("data-requires-python")
+		assert.Equal(t, ">=3.8", requiresPython)
+
+		req = NewRequest(t, "GET", link).AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		assertProxiedVersion(t, packages_model.TypePyPI, "proxy-test", "1.0.0")
+
+		// the stored file is listed instead of the upstream one
+		req = NewRequest(t, "GET", root+"/simple/proxy-test").AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		htmlDoc = NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 1, htmlDoc.Find("a").Length())
+
+		req = NewRequest(t, "GET", root+"/simple/unknown-package").AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Maven", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		jar := []byte("maven jar content")
+		hashSHA1 := sha1.Sum(jar)
+		upstreamFiles["/com/gitea/proxy-test/maven-metadata.xml"] = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<metadata><groupId>com.gitea</groupId><artifactId>proxy-test</artifactId><versioning><release>1.0.0</release><latest>1.0.0</latest><versions><version>1.0.0</version></versions></versioning></metadata>`)
+		upstreamFiles["/com/gitea/proxy-test/1.0.0/proxy-test-1.0.0.jar"] = jar
+		upstreamFiles["/com/gitea/proxy-test/1.0.0/proxy-test-1.0.0.jar.sha1"] = []byte(hex.EncodeToString(hashSHA1[:]))
+
+		createProxy(t, packages_model.TypeMaven, upstream.URL)
+
+		root := fmt.Sprintf("/api/packages/%s/maven/com/gitea/proxy-test", user.Name)
+
+		req := NewRequest(t, "GET", root+"/maven-metadata.xml").AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "<version>1.0.0</version>")
+
+		req = NewRequest(t, "GET", root+"/1.0.0/proxy-test-1.0.0.jar").AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, jar, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/1.0.0/proxy-test-1.0.0.jar.sha1").AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, hex.EncodeToString(hashSHA1[:]), resp.Body.String())
+
+		assertProxiedVersion(t, packages_model.TypeMaven, "com.gitea:proxy-test", "1.0.0")
+
+		req = NewRequest(t, "GET", root+"/1.0.0/proxy-test-1.0.0.pom").AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Container", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		config := []byte(`{"architecture":"amd64","os":"linux"}`)
+		layer := []byte("container layer content")
+		configDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(config))
+		layerDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(layer))
+		manifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"%s","size":%d},"layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":"%s","size":%d}]}`, configDigest, len(config), layerDigest, len(layer)))
+		manifestDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(manifest))
+
+		// the path makes the upstream registry look like Docker Hub which keeps the official images in the "library" namespace
+		createProxy(t, packages_model.TypeContainer, upstream.URL+"/docker.io")
+		upstreamFiles["/docker.io/v2/library/proxy-test/manifests/latest"] = manifest
+		upstreamFiles["/docker.io/v2/library/proxy-test/blobs/"+configDigest] = config
+		upstreamFiles["/docker.io/v2/library/proxy-test/blobs/"+layerDigest] = layer
+
+		req := NewRequest(t, "GET", setting.AppURL+"v2/token").AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var tokenResponse struct {
+			Token string `json:"token"`
+		}
+		DecodeJSON(t, resp, &tokenResponse)
+		token := "Bearer " + tokenResponse.Token
+
+		root := fmt.Sprintf("%sv2/%s/proxy-test", setting.AppURL, user.Name)
+
+		req = NewRequest(t, "GET", root+"/manifests/latest").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, manifest, resp.Body.Bytes())
+		assert.Equal(t, manifestDigest, resp.Header().Get("Docker-Content-Digest"))
+
+		req = NewRequest(t, "GET", root+"/blobs/"+layerDigest).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, layer, resp.Body.Bytes())
+
+		req = NewRequest(t, "HEAD", root+"/manifests/"+manifestDigest).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		assertProxiedVersion(t, packages_model.TypeContainer, "proxy-test", "latest")
+
+		// the tag is refreshed if it points to another manifest in the upstream registry
+		manifest2 := []byte(fmt.Sprintf(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"%s","size":%d},"layers":[]}`, configDigest, len(config)))
+		upstreamFiles["/docker.io/v2/library/proxy-test/manifests/latest"] = manifest2
+
+		req = NewRequest(t, "GET", root+"/manifests/latest").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, manifest2, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/manifests/unknown").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Cleanup", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pp, err := packages_model.GetEnabledProxyByOwnerAndType(t.Context(), user.ID, packages_model.TypeNpm)
+		require.NoError(t, err)
+		pp.CacheDays = 7
+		require.NoError(t, packages_model.UpdateProxy(t.Context(), pp))
+
+		require.NoError(t, packages_cleanup_service.ExecuteProxyCacheCleanup(t.Context()))
+		pv := assertProxiedVersion(t, packages_model.TypeNpm, "proxy-test", "1.0.0")
+
+		_, err = db.GetEngine(t.Context()).Exec("UPDATE package_version SET created_unix = ? WHERE id = ?", timeutil.TimeStamp(time.Now().AddDate(0, 0, -8).Unix()), pv.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, packages_cleanup_service.ExecuteProxyCacheCleanup(t.Context()))
+		_, err = packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeNpm, "proxy-test", "1.0.0")
+		assert.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+
+		// the versions fetched by the other proxies are kept
+		assertProxiedVersion(t, packages_model.TypeMaven, "com.gitea:proxy-test", "1.0.0")
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, user.Name)
+
+		req := NewRequest(t, "GET", "/user/settings/packages")
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), upstream.URL)
+
+		req = NewRequestWithValues(t, "POST", "/user/settings/packages/proxies/add", map[string]string{
+			"type":   "npm",
+			"url":    "https://registry.npmjs.org",
+			"action": "save",
+		})
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, 1, NewHTMLParser(t, resp.Body).Find(".field.error").Length())
+
+		pps, err := packages_model.GetProxiesByOwner(t.Context(), user.ID)
+		require.NoError(t, err)
+		require.Len(t, pps, 4)
+
+		var pp *packages_model.PackageProxy
+		for _, p := range pps {
+			if p.Type == packages_model.TypeMaven {
+				pp = p
+			}
+		}
+
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/user/settings/packages/proxies/%d", pp.ID), map[string]string{
+			"type":                 "maven",
+			"url":                  "https://repo.maven.apache.org/maven2",
+			"username":             "maven-user",
+			"password":             "maven-password",
+			"metadata_ttl_minutes": "60",
+			"cache_days":           "30",
+			"action":               "save",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		pp = unittest.AssertExistsAndLoadBean(t, &packages_model.PackageProxy{ID: pp.ID})
+		assert.False(t, pp.Enabled)
+		assert.Equal(t, "https://repo.maven.apache.org/maven2", pp.URL)
+		assert.Equal(t, 60, pp.MetadataTTLMinutes)
+		assert.Equal(t, 30, pp.CacheDays)
+		password, err := pp.Password()
+		assert.NoError(t, err)
+		assert.Equal(t, "maven-password", password)
+
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/user/settings/packages/proxies/%d", pp.ID), map[string]string{
+			"type":   "maven",
+			"url":    "https://repo.maven.apache.org/maven2",
+			"action": "remove",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &packages_model.PackageProxy{ID: pp.ID})
+
+		// another user can't edit the proxies of the user
+		session = loginUser(t, "user4")
+		pps, err = packages_model.GetProxiesByOwner(t.Context(), user.ID)
+		require.NoError(t, err)
+		req = NewRequest(t, "GET", fmt.Sprintf("/user/settings/packages/proxies/%d", pps[0].ID))
+		session.MakeRequest(t, req, http.StatusNotFound)
+	})
+}
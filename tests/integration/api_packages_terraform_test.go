@@ -0,0 +1,336 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageTerraform(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	t.Run("ServiceDiscovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/.well-known/terraform.json")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result map[string]string
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, "/api/packages/-/terraform/modules/", result["modules.v1"])
+		assert.Equal(t, "/api/packages/-/terraform/providers/", result["providers.v1"])
+	})
+
+	t.Run("Module", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		moduleName := "network"
+		moduleSystem := "aws"
+		moduleVersion := "1.2.0"
+		readme := "# Network Module"
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for name, content := range map[string]string{"main.tf": `variable "cidr" {}`, "README.md": readme} {
+			tw.WriteHeader(&tar.Header{
+				Name: name,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			tw.Write([]byte(content))
+		}
+		tw.Close()
+		zw.Close()
+		content := buf.Bytes()
+
+		root := fmt.Sprintf("/api/packages/%s/terraform/modules/%s/%s", user.Name, moduleName, moduleSystem)
+		protocolRoot := fmt.Sprintf("/api/packages/-/terraform/modules/%s/%s/%s", user.Name, moduleName, moduleSystem)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", root+"/"+moduleVersion, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", root+"/invalid", bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", root+"/"+moduleVersion, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeTerraform)
+			require.NoError(t, err)
+			require.Len(t, pvs, 1)
+
+			pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+			require.NoError(t, err)
+			assert.Equal(t, moduleName+"/"+moduleSystem, pd.Package.Name)
+			assert.Equal(t, moduleVersion, pd.Version.Version)
+			assert.IsType(t, &terraform_module.Metadata{}, pd.Metadata)
+			assert.Equal(t, terraform_module.KindModule, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Equal(t, readme, pd.Metadata.(*terraform_module.Metadata).Readme)
+			require.Len(t, pd.Files, 1)
+			assert.Equal(t, "network-aws-1.2.0.tar.gz", pd.Files[0].File.Name)
+
+			req = NewRequestWithBody(t, "PUT", root+"/"+moduleVersion, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("/api/packages/-/terraform/modules/%s/%s/gcp/versions", user.Name, moduleName))
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", protocolRoot+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			type versionsResponse struct {
+				Modules []struct {
+					Versions []struct {
+						Version string `json:"version"`
+					} `json:"versions"`
+				} `json:"modules"`
+			}
+
+			var result versionsResponse
+			DecodeJSON(t, resp, &result)
+			require.Len(t, result.Modules, 1)
+			require.Len(t, result.Modules[0].Versions, 1)
+			assert.Equal(t, moduleVersion, result.Modules[0].Versions[0].Version)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", protocolRoot+"/0.1.0/download")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", protocolRoot+"/"+moduleVersion+"/download")
+			resp := MakeRequest(t, req, http.StatusNoContent)
+
+			location := resp.Header().Get("X-Terraform-Get")
+			assert.Equal(t, fmt.Sprintf("%sapi/packages/%s/terraform/modules/%s/%s/%s?archive=tar.gz", setting.AppURL, user.Name, moduleName, moduleSystem, moduleVersion), location)
+
+			req = NewRequest(t, "GET", strings.TrimPrefix(location, strings.TrimSuffix(setting.AppURL, "/")))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+		})
+
+		t.Run("View", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/terraform/%s%%2F%s/%s", user.Name, moduleName, moduleSystem, moduleVersion))
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			appURL, _ := url.Parse(setting.AppURL)
+			assert.Contains(t, resp.Body.String(), fmt.Sprintf(`source  = "%s/%s/%s/%s"`, appURL.Host, user.Name, moduleName, moduleSystem))
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "DELETE", root+"/"+moduleVersion)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "DELETE", root+"/"+moduleVersion).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNoContent)
+
+			req = NewRequest(t, "GET", protocolRoot+"/versions")
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+
+	t.Run("Provider", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		providerType := "dummy"
+		providerVersion := "2.0.0"
+
+		createArchive := func(filename string) []byte {
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			w, _ := zw.Create(filename)
+			w.Write([]byte(filename))
+			zw.Close()
+			return buf.Bytes()
+		}
+
+		root := fmt.Sprintf("/api/packages/%s/terraform/providers/%s/%s", user.Name, providerType, providerVersion)
+		protocolRoot := fmt.Sprintf("/api/packages/-/terraform/providers/%s/%s", user.Name, providerType)
+
+		platforms := map[string][]byte{
+			"terraform-provider-dummy_2.0.0_linux_amd64.zip":  createArchive("terraform-provider-dummy_v2.0.0"),
+			"terraform-provider-dummy_2.0.0_darwin_arm64.zip": createArchive("terraform-provider-dummy_v2.0.0"),
+		}
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			for filename, content := range platforms {
+				req := NewRequestWithBody(t, "PUT", root+"/"+filename, bytes.NewReader(content))
+				MakeRequest(t, req, http.StatusUnauthorized)
+
+				req = NewRequestWithBody(t, "PUT", root+"/"+filename+"?protocols=5.0,6.0", bytes.NewReader(content)).
+					AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusCreated)
+
+				req = NewRequestWithBody(t, "PUT", root+"/"+filename, bytes.NewReader(content)).
+					AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusConflict)
+			}
+
+			req := NewRequestWithBody(t, "PUT", root+"/terraform-provider-other_2.0.0_linux_amd64.zip", bytes.NewReader(platforms["terraform-provider-dummy_2.0.0_linux_amd64.zip"])).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", root+"/terraform-provider-dummy_2.0.0_linux_386.zip", bytes.NewReader(createArchive("README.md"))).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeTerraform)
+			require.NoError(t, err)
+			require.Len(t, pvs, 1)
+
+			pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+			require.NoError(t, err)
+			assert.Equal(t, providerType, pd.Package.Name)
+			assert.Equal(t, terraform_module.KindProvider, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Len(t, pd.Files, 2)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", protocolRoot+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			type versionsResponse struct {
+				Versions []struct {
+					Version   string   `json:"version"`
+					Protocols []string `json:"protocols"`
+					Platforms []struct {
+						OS   string `json:"os"`
+						Arch string `json:"arch"`
+					} `json:"platforms"`
+				} `json:"versions"`
+			}
+
+			var result versionsResponse
+			DecodeJSON(t, resp, &result)
+			require.Len(t, result.Versions, 1)
+			assert.Equal(t, providerVersion, result.Versions[0].Version)
+			assert.Equal(t, []string{"5.0", "6.0"}, result.Versions[0].Protocols)
+			assert.Len(t, result.Versions[0].Platforms, 2)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", protocolRoot+"/"+providerVersion+"/download/windows/amd64")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", protocolRoot+"/"+providerVersion+"/download/linux/amd64")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			type downloadResponse struct {
+				Protocols           []string `json:"protocols"`
+				OS                  string   `json:"os"`
+				Arch                string   `json:"arch"`
+				Filename            string   `json:"filename"`
+				DownloadURL         string   `json:"download_url"`
+				SHASumsURL          string   `json:"shasums_url"`
+				SHASumsSignatureURL string   `json:"shasums_signature_url"`
+				SHASum              string   `json:"shasum"`
+				SigningKeys         struct {
+					GPGPublicKeys []struct {
+						KeyID      string `json:"key_id"`
+						ASCIIArmor string `json:"ascii_armor"`
+					} `json:"gpg_public_keys"`
+				} `json:"signing_keys"`
+			}
+
+			var result downloadResponse
+			DecodeJSON(t, resp, &result)
+
+			filename := "terraform-provider-dummy_2.0.0_linux_amd64.zip"
+			hash := sha256.Sum256(platforms[filename])
+
+			assert.Equal(t, []string{"5.0", "6.0"}, result.Protocols)
+			assert.Equal(t, "linux", result.OS)
+			assert.Equal(t, "amd64", result.Arch)
+			assert.Equal(t, filename, result.Filename)
+			assert.Equal(t, hex.EncodeToString(hash[:]), result.SHASum)
+			require.Len(t, result.SigningKeys.GPGPublicKeys, 1)
+
+			toPath := func(u string) string {
+				return strings.TrimPrefix(u, strings.TrimSuffix(setting.AppURL, "/"))
+			}
+
+			req = NewRequest(t, "GET", toPath(result.DownloadURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, platforms[filename], resp.Body.Bytes())
+
+			req = NewRequest(t, "GET", toPath(result.SHASumsURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+			sums := resp.Body.Bytes()
+			assert.Contains(t, string(sums), result.SHASum+"  "+filename+"\n")
+			assert.Len(t, strings.Split(strings.TrimSpace(string(sums)), "\n"), 2)
+
+			req = NewRequest(t, "GET", toPath(result.SHASumsSignatureURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(result.SigningKeys.GPGPublicKeys[0].ASCIIArmor))
+			require.NoError(t, err)
+			assert.Equal(t, keyring[0].PrimaryKey.KeyIdString(), result.SigningKeys.GPGPublicKeys[0].KeyID)
+			_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(sums), resp.Body, nil)
+			assert.NoError(t, err)
+
+			req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/%s/terraform/key", user.Name))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, result.SigningKeys.GPGPublicKeys[0].ASCIIArmor, resp.Body.String())
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "DELETE", root).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNoContent)
+
+			req = NewRequest(t, "GET", protocolRoot+"/versions")
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+}
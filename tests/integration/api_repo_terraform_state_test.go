@@ -0,0 +1,173 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRepoTerraformState(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// user2 owns repo1, user4 can only read it
+	writeToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+	readToken := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+	readerToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)
+
+	stateURL := "/api/v1/repos/user2/repo1/terraform/state/production"
+
+	lockInfo := func(id string) *bytes.Reader {
+		return bytes.NewReader([]byte(`{"ID":"` + id + `","Operation":"OperationTypeApply","Who":"user2@host","Version":"1.9.0","Path":""}`))
+	}
+	state := func(serial int) string {
+		return `{"version":4,"terraform_version":"1.9.0","serial":` + strings.Repeat("1", serial) + `,"lineage":"6a8c9c37","outputs":{},"resources":[]}`
+	}
+
+	t.Run("Permissions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", stateURL), http.StatusUnauthorized)
+		MakeRequest(t, NewRequest(t, "GET", stateURL).AddBasicAuth("user4", readerToken), http.StatusForbidden)
+		MakeRequest(t, NewRequestWithBody(t, "LOCK", stateURL, lockInfo("lock-0")).AddBasicAuth("user2", readToken), http.StatusForbidden)
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(1))).AddBasicAuth("user2", readToken), http.StatusForbidden)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", stateURL).AddBasicAuth("user2", writeToken), http.StatusNotFound)
+
+		MakeRequest(t, NewRequestWithBody(t, "LOCK", stateURL, lockInfo("lock-1")).AddBasicAuth("user2", writeToken), http.StatusOK)
+
+		resp := MakeRequest(t, NewRequestWithBody(t, "LOCK", stateURL, lockInfo("lock-2")).AddBasicAuth("user2", writeToken), http.StatusLocked)
+		var current terraform_model.LockInfo
+		DecodeJSON(t, resp, &current)
+		assert.Equal(t, "lock-1", current.ID)
+		assert.Equal(t, "user2@host", current.Who)
+
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(1))).AddBasicAuth("user2", writeToken), http.StatusLocked)
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL+"?ID=lock-2", strings.NewReader(state(1))).AddBasicAuth("user2", writeToken), http.StatusLocked)
+
+		resp = MakeRequest(t, NewRequestWithBody(t, "POST", stateURL+"?ID=lock-1", strings.NewReader(state(1))).AddBasicAuth("user2", writeToken), http.StatusCreated)
+		var version api.TerraformStateVersion
+		DecodeJSON(t, resp, &version)
+		assert.EqualValues(t, 1, version.Version)
+		assert.EqualValues(t, 1, version.Serial)
+		assert.Equal(t, "6a8c9c37", version.Lineage)
+		assert.Equal(t, "user2", version.Creator.UserName)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/terraform/state").AddTokenAuth(writeToken), http.StatusOK)
+		var states []*api.TerraformState
+		DecodeJSON(t, resp, &states)
+		assert.Len(t, states, 1)
+		assert.Equal(t, "production", states[0].Name)
+		assert.EqualValues(t, 1, states[0].LatestVersion)
+		if assert.NotNil(t, states[0].Lock) {
+			assert.Equal(t, "lock-1", states[0].Lock.ID)
+			assert.Equal(t, "OperationTypeApply", states[0].Lock.Operation)
+			assert.Equal(t, "user2", states[0].Lock.Locker.UserName)
+		}
+
+		// the lock holder is shown in the repository settings
+		session := loginUser(t, "user2")
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/settings/terraform"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "production")
+		assert.Contains(t, resp.Body.String(), "user2@host")
+
+		MakeRequest(t, NewRequest(t, "DELETE", stateURL).AddBasicAuth("user2", writeToken), http.StatusLocked)
+
+		MakeRequest(t, NewRequestWithBody(t, "UNLOCK", stateURL, lockInfo("lock-2")).AddBasicAuth("user2", writeToken), http.StatusConflict)
+		MakeRequest(t, NewRequestWithBody(t, "UNLOCK", stateURL, lockInfo("lock-1")).AddBasicAuth("user2", writeToken), http.StatusOK)
+
+		// "terraform force-unlock" sends no lock information
+		MakeRequest(t, NewRequestWithBody(t, "LOCK", stateURL, lockInfo("lock-3")).AddBasicAuth("user2", writeToken), http.StatusOK)
+		MakeRequest(t, NewRequestWithBody(t, "UNLOCK", stateURL, strings.NewReader("")).AddBasicAuth("user2", writeToken), http.StatusOK)
+
+		// the lock can be removed in the repository settings
+		MakeRequest(t, NewRequestWithBody(t, "LOCK", stateURL, lockInfo("lock-4")).AddBasicAuth("user2", writeToken), http.StatusOK)
+		session.MakeRequest(t, NewRequest(t, "POST", "/user2/repo1/settings/terraform/production/unlock"), http.StatusOK)
+		s := unittest.AssertExistsAndLoadBean(t, &terraform_model.State{RepoID: 1, Name: "production"})
+		assert.False(t, s.IsLocked())
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL, strings.NewReader("not a state")).AddBasicAuth("user2", writeToken), http.StatusBadRequest)
+
+		req := NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(2))).AddBasicAuth("user2", writeToken)
+		req.SetHeader("Content-MD5", base64.StdEncoding.EncodeToString([]byte("invalid")))
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		checksum := md5.Sum([]byte(state(2)))
+		req = NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(2))).AddBasicAuth("user2", writeToken)
+		req.SetHeader("Content-MD5", base64.StdEncoding.EncodeToString(checksum[:]))
+		MakeRequest(t, req, http.StatusCreated)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", stateURL).AddBasicAuth("user2", writeToken), http.StatusOK)
+		assert.Equal(t, state(2), resp.Body.String())
+
+		resp = MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions/1").AddBasicAuth("user2", writeToken), http.StatusOK)
+		assert.Equal(t, state(1), resp.Body.String())
+
+		resp = MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions").AddBasicAuth("user2", writeToken), http.StatusOK)
+		var versions []*api.TerraformStateVersion
+		DecodeJSON(t, resp, &versions)
+		assert.Len(t, versions, 2)
+		assert.EqualValues(t, 2, versions[0].Version)
+		assert.EqualValues(t, 11, versions[0].Serial)
+
+		session := loginUser(t, "user2")
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/settings/terraform/production"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "/user2/repo1/settings/terraform/production/versions/2")
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/settings/terraform/production/versions/1"), http.StatusOK)
+		assert.Equal(t, state(1), resp.Body.String())
+
+		defer test.MockVariableValue(&setting.TerraformState.MaxVersions, 2)()
+
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(3))).AddBasicAuth("user2", writeToken), http.StatusCreated)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions").AddBasicAuth("user2", writeToken), http.StatusOK)
+		DecodeJSON(t, resp, &versions)
+		assert.Len(t, versions, 2)
+		assert.EqualValues(t, 3, versions[0].Version)
+		assert.EqualValues(t, 2, versions[1].Version)
+
+		MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions/1").AddBasicAuth("user2", writeToken), http.StatusNotFound)
+	})
+
+	t.Run("MaxStateSize", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.TerraformState.MaxStateSize, int64(len(state(4))))()
+
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(5))).AddBasicAuth("user2", writeToken), http.StatusRequestEntityTooLarge)
+		MakeRequest(t, NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(state(5))).AddBasicAuth("user2", writeToken), http.StatusRequestEntityTooLarge)
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(4))).AddBasicAuth("user2", writeToken), http.StatusCreated)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "DELETE", stateURL).AddBasicAuth("user2", writeToken), http.StatusNoContent)
+		MakeRequest(t, NewRequest(t, "GET", stateURL).AddBasicAuth("user2", writeToken), http.StatusNotFound)
+
+		unittest.AssertNotExistsBean(t, &terraform_model.State{RepoID: 1})
+		unittest.AssertNotExistsBean(t, &terraform_model.StateVersion{RepoID: 1})
+	})
+}
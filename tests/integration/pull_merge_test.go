@@ -711,6 +711,57 @@ func TestPullDontRetargetChildOnWrongRepo(t *testing.T) {
 	})
 }
 
+func TestPullMergeStack(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		session := loginUser(t, "user1")
+		testRepoFork(t, session, "user2", "repo1", "user1", "repo1", "")
+		testEditFileToNewBranch(t, session, "user1", "repo1", "master", "base-pr", "README.md", "Hello, World\n(Edited - TestPullMergeStack - base PR)\n")
+		testEditFileToNewBranch(t, session, "user1", "repo1", "base-pr", "child-pr", "README.md", "Hello, World\n(Edited - TestPullMergeStack - base PR)\n(Edited - TestPullMergeStack - child PR)\n")
+
+		respBasePR := testPullCreate(t, session, "user1", "repo1", true, "master", "base-pr", "Base Pull Request")
+		elemBasePR := strings.Split(test.RedirectURL(respBasePR), "/")
+		assert.Equal(t, "pulls", elemBasePR[3])
+
+		respChildPR := testPullCreate(t, session, "user1", "repo1", true, "base-pr", "child-pr", "Child Pull Request")
+		elemChildPR := strings.Split(test.RedirectURL(respChildPR), "/")
+		assert.Equal(t, "pulls", elemChildPR[3])
+
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerName: "user1", Name: "repo1"})
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo1)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		oldChildHeadID, err := gitRepo.GetBranchCommitID("child-pr")
+		require.NoError(t, err)
+
+		// squash merging doesn't keep the commit of the base PR, so the child PR must be rebased without it
+		req := NewRequestWithValues(t, "POST", path.Join(elemChildPR[1], elemChildPR[2], "pulls", elemChildPR[4], "merge_stack"), map[string]string{
+			"do": string(repo_model.MergeStyleSquash),
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		basePR := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo1.ID, HeadBranch: "base-pr"})
+		childPR := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo1.ID, HeadBranch: "child-pr"})
+		assert.True(t, basePR.HasMerged)
+		assert.True(t, childPR.HasMerged)
+		assert.Equal(t, "master", childPR.BaseBranch)
+
+		childHead, err := gitRepo.GetBranchCommit("child-pr")
+		require.NoError(t, err)
+		assert.NotEqual(t, oldChildHeadID, childHead.ID.String())
+		assert.Equal(t, 1, childHead.ParentCount())
+		parentID, err := childHead.ParentID(0)
+		require.NoError(t, err)
+		assert.Equal(t, basePR.MergedCommitID, parentID.String())
+
+		master, err := gitRepo.GetBranchCommit("master")
+		require.NoError(t, err)
+		assert.Equal(t, childPR.MergedCommitID, master.ID.String())
+		content, err := master.GetFileContent("README.md", 1024)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello, World\n(Edited - TestPullMergeStack - base PR)\n(Edited - TestPullMergeStack - child PR)\n", content)
+	})
+}
+
 func TestPullRequestMergedWithNoPermissionDeleteBranch(t *testing.T) {
 	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
 		session := loginUser(t, "user4")
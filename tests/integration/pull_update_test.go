@@ -98,7 +98,7 @@ func TestAPIPullUpdateByRebase(t *testing.T) {
 
 		// use a user which have write access to the pr but not write permission to the head repository to do the rebase
 		user40 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 40})
-		err = repo_service.AddOrUpdateCollaborator(t.Context(), pr.BaseRepo, user40, perm.AccessModeWrite)
+		err = repo_service.AddOrUpdateCollaborator(t.Context(), nil, pr.BaseRepo, user40, perm.AccessModeWrite)
 		assert.NoError(t, err)
 		token40 := getUserToken(t, "user40", auth_model.AccessTokenScopeWriteRepository)
 
@@ -106,7 +106,7 @@ func TestAPIPullUpdateByRebase(t *testing.T) {
 			AddTokenAuth(token40)
 		session.MakeRequest(t, req, http.StatusForbidden)
 
-		err = repo_service.AddOrUpdateCollaborator(t.Context(), pr.HeadRepo, user40, perm.AccessModeWrite)
+		err = repo_service.AddOrUpdateCollaborator(t.Context(), nil, pr.HeadRepo, user40, perm.AccessModeWrite)
 		assert.NoError(t, err)
 
 		req = NewRequestf(t, "POST", "/api/v1/repos/%s/%s/pulls/%d/update?style=rebase", pr.BaseRepo.OwnerName, pr.BaseRepo.Name, pr.Issue.Index).
@@ -164,6 +164,48 @@ func TestAPIPullUpdateByRebase2(t *testing.T) {
 	})
 }
 
+func TestAPIPullUpdateByRebaseKeepsCommits(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		org26 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 26})
+		pr := createOutdatedPR(t, user, org26)
+		require.NoError(t, pr.LoadBaseRepo(t.Context()))
+		require.NoError(t, pr.LoadHeadRepo(t.Context()))
+		require.NoError(t, pr.LoadIssue(t.Context()))
+
+		enableRepoAllowUpdateWithRebase(t, pr.BaseRepo.ID, true)
+
+		// without an upstream all commits of the head branch which aren't in the base branch are rebased
+		token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWriteRepository)
+		req := NewRequestf(t, "POST", "/api/v1/repos/%s/%s/pulls/%d/update?style=rebase", pr.BaseRepo.OwnerName, pr.BaseRepo.Name, pr.Issue.Index).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		baseGitRepo, err := gitrepo.OpenRepository(t.Context(), pr.BaseRepo)
+		require.NoError(t, err)
+		defer baseGitRepo.Close()
+		baseCommitID, err := baseGitRepo.GetBranchCommitID(pr.BaseBranch)
+		require.NoError(t, err)
+
+		headGitRepo, err := gitrepo.OpenRepository(t.Context(), pr.HeadRepo)
+		require.NoError(t, err)
+		defer headGitRepo.Close()
+		headCommit, err := headGitRepo.GetBranchCommit(pr.HeadBranch)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Add File on PR branch", headCommit.Summary())
+		assert.Equal(t, 1, headCommit.ParentCount())
+		parentID, err := headCommit.ParentID(0)
+		require.NoError(t, err)
+		assert.Equal(t, baseCommitID, parentID.String())
+		for _, file := range []string{"File_A", "File_B"} {
+			has, err := headCommit.HasFile(file)
+			require.NoError(t, err)
+			assert.True(t, has, file)
+		}
+	})
+}
+
 func createOutdatedPR(t *testing.T, actor, forkOrg *user_model.User) *issues_model.PullRequest {
 	baseRepo, err := repo_service.CreateRepository(t.Context(), actor, actor, repo_service.CreateRepoOptions{
 		Name:        "repo-pr-update",
@@ -10,15 +10,20 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
 	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func assertFileExist(t *testing.T, p string) {
@@ -54,6 +59,36 @@ func TestRepoCloneWiki(t *testing.T) {
 	})
 }
 
+func TestRepoWikiPushIndexer(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		// the wiki of the fixtures has no hooks
+		require.NoError(t, gitrepo.CreateDelegateHooks(t.Context(), repo.WikiStorageRepo()))
+
+		u.Path = "user2/repo1.wiki.git"
+		u.User = url.UserPassword("user2", userPassword)
+
+		dstPath := t.TempDir()
+		require.NoError(t, git.Clone(t.Context(), u.String(), dstPath, git.CloneRepoOptions{}))
+		doGitCheckoutWriteFileCommit(localGitAddCommitOptions{
+			LocalRepoPath:   dstPath,
+			CheckoutBranch:  "master",
+			TreeFilePath:    "Pushed-Page.md",
+			TreeFileContent: "This page mentions zanzibar.\n",
+		})(t)
+		doGitPushTestRepository(dstPath, "origin", "master")(t)
+
+		// the wiki indexer is updated by the post-receive hook of the wiki
+		assert.Eventually(t, func() bool {
+			req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/wiki/search?q=zanzibar")
+			resp := MakeRequest(t, req, http.StatusOK)
+			var results []*api.WikiSearchResult
+			DecodeJSON(t, resp, &results)
+			return len(results) == 1 && results[0].SubURL == "Pushed-Page"
+		}, 10*time.Second, 100*time.Millisecond)
+	})
+}
+
 func Test_RepoWikiPages(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
@@ -224,6 +224,7 @@ func PrepareCleanPackageData(t testing.TB) {
 		&packages_model.PackageProperty{},
 		&packages_model.PackageBlobUpload{},
 		&packages_model.PackageCleanupRule{},
+		&packages_model.PackageProxy{},
 	))
 	assert.NoError(t, storage.Clean(storage.Packages))
 }
@@ -30,6 +30,10 @@ export function initRepositoryActionView() {
       showLogSeconds: el.getAttribute('data-locale-show-log-seconds'),
       showFullScreen: el.getAttribute('data-locale-show-full-screen'),
       downloadLogs: el.getAttribute('data-locale-download-logs'),
+      downloadAllLogs: el.getAttribute('data-locale-download-all-logs'),
+      searchLogs: el.getAttribute('data-locale-search-logs'),
+      annotationsTitle: el.getAttribute('data-locale-annotations-title'),
+      summaryTitle: el.getAttribute('data-locale-summary-title'),
       status: {
         unknown: el.getAttribute('data-locale-status-unknown'),
         waiting: el.getAttribute('data-locale-status-waiting'),
@@ -39,6 +43,7 @@ export function initRepositoryActionView() {
         cancelled: el.getAttribute('data-locale-status-cancelled'),
         skipped: el.getAttribute('data-locale-status-skipped'),
         blocked: el.getAttribute('data-locale-status-blocked'),
+        pending_deployment: el.getAttribute('data-locale-status-pending-deployment'),
       },
       logsAlwaysAutoScroll: el.getAttribute('data-locale-logs-always-auto-scroll'),
       logsAlwaysExpandRunning: el.getAttribute('data-locale-logs-always-expand-running'),